@@ -0,0 +1,47 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// BackupCatalogEntry is a backup available in the storage, which can be used as a restore point.
+type BackupCatalogEntry struct {
+	// FileName is the name of the backup file in the storage.
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	FileName string `json:"fileName"`
+	// Time is the time when the backup was taken.
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	Time metav1.Time `json:"time"`
+	// Size is the size in bytes of the backup file, as recorded in its manifest.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	Size int64 `json:"size,omitempty"`
+	// Compression is the algorithm used to compress the backup file.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	Compression CompressAlgorithm `json:"compression,omitempty"`
+	// GTID is the GTID position of the backup, as recorded in its manifest.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	GTID string `json:"gtid,omitempty"`
+	// TargetPod is the Pod where the physical backup was taken, as recorded in its manifest.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	TargetPod string `json:"targetPod,omitempty"`
+}
+
+// BackupCatalog lists the backups available in the storage.
+type BackupCatalog struct {
+	// Backups are the most recent backups available in the storage, sorted from the most recent to the oldest.
+	// At most 30 backups are listed, see TotalBackups for the total number of backups.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	Backups []BackupCatalogEntry `json:"backups,omitempty"`
+	// TotalBackups is the total number of backups available in the storage.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	TotalBackups int32 `json:"totalBackups,omitempty"`
+	// LastUpdateTime is the last time that the catalog was updated.
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	LastUpdateTime metav1.Time `json:"lastUpdateTime"`
+}
@@ -0,0 +1,190 @@
+package v1alpha1
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+// DefaultBackupHookTimeout is the default maximum duration of a BackupHook.
+var DefaultBackupHookTimeout = metav1.Duration{Duration: 1 * time.Minute}
+
+// BackupHookPodRole defines the Pod where the SQL of a BackupHook is executed.
+type BackupHookPodRole string
+
+const (
+	// BackupHookPodRolePrimary executes the hook in the primary Pod.
+	BackupHookPodRolePrimary BackupHookPodRole = "Primary"
+	// BackupHookPodRoleTarget executes the hook in the Pod where the backup is taken.
+	BackupHookPodRoleTarget BackupHookPodRole = "Target"
+)
+
+// Validate determines whether a BackupHookPodRole is valid.
+func (r BackupHookPodRole) Validate() error {
+	switch r {
+	case BackupHookPodRolePrimary, BackupHookPodRoleTarget:
+		return nil
+	default:
+		return fmt.Errorf("invalid pod role: %v, supported roles: [%v|%v]", r, BackupHookPodRolePrimary, BackupHookPodRoleTarget)
+	}
+}
+
+// BackupHookOnError defines what to do when a BackupHook fails.
+type BackupHookOnError string
+
+const (
+	// BackupHookOnErrorFail stops running the remaining hooks. When a pre hook fails, the backup is not taken.
+	BackupHookOnErrorFail BackupHookOnError = "Fail"
+	// BackupHookOnErrorContinue ignores the error and continues running the remaining hooks.
+	BackupHookOnErrorContinue BackupHookOnError = "Continue"
+)
+
+// Validate determines whether a BackupHookOnError is valid.
+func (o BackupHookOnError) Validate() error {
+	switch o {
+	case BackupHookOnErrorFail, BackupHookOnErrorContinue:
+		return nil
+	default:
+		return fmt.Errorf("invalid onError policy: %v, supported policies: [%v|%v]", o, BackupHookOnErrorFail, BackupHookOnErrorContinue)
+	}
+}
+
+// BackupHookExec defines a command to be executed in the Pod where the backup is taken.
+type BackupHookExec struct {
+	// Command to be executed. It is not run in a shell, wrap it with 'sh -c' if you need one.
+	// +kubebuilder:validation:Required
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Command []string `json:"command"`
+	// Container where the command is executed. It defaults to the MariaDB container.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Container *string `json:"container,omitempty"`
+}
+
+// BackupHook defines an action to be performed by the operator before or after taking a backup.
+type BackupHook struct {
+	// Name identifies the hook. It must be unique within the pre or post hooks.
+	// +kubebuilder:validation:Required
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Name string `json:"name"`
+	// SQL statement to be executed using the superuser credentials of the MariaDB.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	SQL *string `json:"sql,omitempty"`
+	// PodRole defines the Pod where the SQL statement is executed. It defaults to 'Primary'.
+	// +optional
+	// +kubebuilder:validation:Enum=Primary;Target
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	PodRole *BackupHookPodRole `json:"podRole,omitempty"`
+	// Exec defines a command to be executed in the Pod where the backup is taken.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Exec *BackupHookExec `json:"exec,omitempty"`
+	// Timeout defines the maximum duration of the hook. It defaults to 1 minute.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+	// OnError defines what to do when the hook fails. It defaults to 'Fail'.
+	// +optional
+	// +kubebuilder:validation:Enum=Fail;Continue
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	OnError *BackupHookOnError `json:"onError,omitempty"`
+}
+
+// Validate determines whether a BackupHook is valid.
+func (h *BackupHook) Validate() error {
+	if h.Name == "" {
+		return errors.New("'name' must be set")
+	}
+	if (h.SQL == nil) == (h.Exec == nil) {
+		return errors.New("exactly one of 'sql' or 'exec' must be set")
+	}
+	if h.SQL != nil && *h.SQL == "" {
+		return errors.New("'sql' must not be empty")
+	}
+	if h.Exec != nil && len(h.Exec.Command) == 0 {
+		return errors.New("'exec.command' must not be empty")
+	}
+	if h.PodRole != nil {
+		if h.SQL == nil {
+			return errors.New("'podRole' may only be set when 'sql' is set")
+		}
+		if err := h.PodRole.Validate(); err != nil {
+			return err
+		}
+	}
+	if h.Timeout != nil && h.Timeout.Duration <= 0 {
+		return errors.New("'timeout' must be greater than zero")
+	}
+	if h.OnError != nil {
+		if err := h.OnError.Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// PodRoleOrDefault returns the Pod where the SQL statement is executed.
+func (h *BackupHook) PodRoleOrDefault() BackupHookPodRole {
+	return ptr.Deref(h.PodRole, BackupHookPodRolePrimary)
+}
+
+// TimeoutOrDefault returns the maximum duration of the hook.
+func (h *BackupHook) TimeoutOrDefault() time.Duration {
+	return ptr.Deref(h.Timeout, DefaultBackupHookTimeout).Duration
+}
+
+// OnErrorOrDefault returns what to do when the hook fails.
+func (h *BackupHook) OnErrorOrDefault() BackupHookOnError {
+	return ptr.Deref(h.OnError, BackupHookOnErrorFail)
+}
+
+// BackupHooks defines the hooks to be run by the operator before and after taking a backup.
+type BackupHooks struct {
+	// Pre hooks are run sequentially before the backup is taken. The backup is not taken if one of them fails with the 'Fail' policy.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Pre []BackupHook `json:"pre,omitempty"`
+	// Post hooks are run sequentially after the backup has been successfully taken.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Post []BackupHook `json:"post,omitempty"`
+}
+
+// Validate determines whether BackupHooks are valid.
+func (h *BackupHooks) Validate() error {
+	if err := validateBackupHooks(h.Pre); err != nil {
+		return fmt.Errorf("invalid 'pre': %v", err)
+	}
+	if err := validateBackupHooks(h.Post); err != nil {
+		return fmt.Errorf("invalid 'post': %v", err)
+	}
+	return nil
+}
+
+// HasPre indicates whether pre hooks are defined.
+func (h *BackupHooks) HasPre() bool {
+	return h != nil && len(h.Pre) > 0
+}
+
+// HasPost indicates whether post hooks are defined.
+func (h *BackupHooks) HasPost() bool {
+	return h != nil && len(h.Post) > 0
+}
+
+func validateBackupHooks(hooks []BackupHook) error {
+	names := make(map[string]struct{}, len(hooks))
+	for i, hook := range hooks {
+		if err := hook.Validate(); err != nil {
+			return fmt.Errorf("invalid hook at index %d: %v", i, err)
+		}
+		if _, ok := names[hook.Name]; ok {
+			return fmt.Errorf("hook '%s' is duplicated", hook.Name)
+		}
+		names[hook.Name] = struct{}{}
+	}
+	return nil
+}
@@ -0,0 +1,189 @@
+package v1alpha1
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+var _ = Describe("BackupHook types", func() {
+	Context("When validating BackupHooks", func() {
+		DescribeTable(
+			"Should validate",
+			func(hooks *BackupHooks, wantErr bool) {
+				err := hooks.Validate()
+				if wantErr {
+					Expect(err).To(HaveOccurred())
+				} else {
+					Expect(err).ToNot(HaveOccurred())
+				}
+			},
+			Entry(
+				"Empty",
+				&BackupHooks{},
+				false,
+			),
+			Entry(
+				"Valid",
+				&BackupHooks{
+					Pre: []BackupHook{
+						{
+							Name:    "marker",
+							SQL:     ptr.To("INSERT INTO audit.backups VALUES (NOW())"),
+							PodRole: ptr.To(BackupHookPodRoleTarget),
+							Timeout: &metav1.Duration{Duration: 10 * time.Second},
+						},
+						{
+							Name: "flush",
+							Exec: &BackupHookExec{
+								Command: []string{"sh", "-c", "sync"},
+							},
+							OnError: ptr.To(BackupHookOnErrorContinue),
+						},
+					},
+					Post: []BackupHook{
+						{
+							Name: "marker",
+							SQL:  ptr.To("UPDATE audit.backups SET completed = NOW()"),
+						},
+					},
+				},
+				false,
+			),
+			Entry(
+				"No name",
+				&BackupHooks{
+					Pre: []BackupHook{
+						{
+							SQL: ptr.To("SELECT 1"),
+						},
+					},
+				},
+				true,
+			),
+			Entry(
+				"Duplicated name",
+				&BackupHooks{
+					Post: []BackupHook{
+						{
+							Name: "audit",
+							SQL:  ptr.To("SELECT 1"),
+						},
+						{
+							Name: "audit",
+							SQL:  ptr.To("SELECT 2"),
+						},
+					},
+				},
+				true,
+			),
+			Entry(
+				"No action",
+				&BackupHooks{
+					Pre: []BackupHook{
+						{
+							Name: "none",
+						},
+					},
+				},
+				true,
+			),
+			Entry(
+				"SQL and exec",
+				&BackupHooks{
+					Pre: []BackupHook{
+						{
+							Name: "both",
+							SQL:  ptr.To("SELECT 1"),
+							Exec: &BackupHookExec{
+								Command: []string{"true"},
+							},
+						},
+					},
+				},
+				true,
+			),
+			Entry(
+				"Empty command",
+				&BackupHooks{
+					Pre: []BackupHook{
+						{
+							Name: "exec",
+							Exec: &BackupHookExec{},
+						},
+					},
+				},
+				true,
+			),
+			Entry(
+				"Pod role with exec",
+				&BackupHooks{
+					Pre: []BackupHook{
+						{
+							Name: "exec",
+							Exec: &BackupHookExec{
+								Command: []string{"true"},
+							},
+							PodRole: ptr.To(BackupHookPodRolePrimary),
+						},
+					},
+				},
+				true,
+			),
+			Entry(
+				"Invalid pod role",
+				&BackupHooks{
+					Pre: []BackupHook{
+						{
+							Name:    "sql",
+							SQL:     ptr.To("SELECT 1"),
+							PodRole: ptr.To(BackupHookPodRole("Replica")),
+						},
+					},
+				},
+				true,
+			),
+			Entry(
+				"Invalid timeout",
+				&BackupHooks{
+					Pre: []BackupHook{
+						{
+							Name:    "sql",
+							SQL:     ptr.To("SELECT 1"),
+							Timeout: &metav1.Duration{},
+						},
+					},
+				},
+				true,
+			),
+			Entry(
+				"Invalid onError",
+				&BackupHooks{
+					Post: []BackupHook{
+						{
+							Name:    "sql",
+							SQL:     ptr.To("SELECT 1"),
+							OnError: ptr.To(BackupHookOnError("Retry")),
+						},
+					},
+				},
+				true,
+			),
+		)
+	})
+
+	Context("When defaulting a BackupHook", func() {
+		It("Should return defaults", func() {
+			hook := BackupHook{
+				Name: "sql",
+				SQL:  ptr.To("SELECT 1"),
+			}
+			Expect(hook.PodRoleOrDefault()).To(Equal(BackupHookPodRolePrimary))
+			Expect(hook.TimeoutOrDefault()).To(Equal(time.Minute))
+			Expect(hook.OnErrorOrDefault()).To(Equal(BackupHookOnErrorFail))
+		})
+	})
+})
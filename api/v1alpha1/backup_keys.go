@@ -19,3 +19,17 @@ func (b *Backup) StoragePVCKey() types.NamespacedName {
 		Namespace: b.Namespace,
 	}
 }
+
+func (b *Backup) RoleKey() types.NamespacedName {
+	return types.NamespacedName{
+		Name:      fmt.Sprintf("%s-role", b.Spec.ServiceAccountKey(b.ObjectMeta).Name),
+		Namespace: b.Namespace,
+	}
+}
+
+func (b *Backup) RoleBindingKey() types.NamespacedName {
+	return types.NamespacedName{
+		Name:      fmt.Sprintf("%s-rolebinding", b.Spec.ServiceAccountKey(b.ObjectMeta).Name),
+		Namespace: b.Namespace,
+	}
+}
@@ -0,0 +1,52 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// BackupProgressPhase is a phase of a backup or a restoration.
+type BackupProgressPhase string
+
+const (
+	// BackupProgressPhaseDump is the phase where the backup is taken from the database.
+	BackupProgressPhaseDump BackupProgressPhase = "Dump"
+	// BackupProgressPhaseCompress is the phase where the backup is compressed, and encrypted if enabled.
+	BackupProgressPhaseCompress BackupProgressPhase = "Compress"
+	// BackupProgressPhaseUpload is the phase where the backup is uploaded to the storage.
+	BackupProgressPhaseUpload BackupProgressPhase = "Upload"
+	// BackupProgressPhaseDownload is the phase where the backup is downloaded from the storage.
+	BackupProgressPhaseDownload BackupProgressPhase = "Download"
+	// BackupProgressPhasePrepare is the phase where a physical backup is prepared to be restored.
+	BackupProgressPhasePrepare BackupProgressPhase = "Prepare"
+	// BackupProgressPhaseApply is the phase where the backup is applied to the database.
+	BackupProgressPhaseApply BackupProgressPhase = "Apply"
+)
+
+// BackupProgress reports the progress of a running backup or restoration.
+type BackupProgress struct {
+	// Phase is the current phase.
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	Phase BackupProgressPhase `json:"phase"`
+	// BytesProcessed is the number of bytes processed during the current phase.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	BytesProcessed int64 `json:"bytesProcessed,omitempty"`
+	// TotalBytes is the number of bytes to be processed during the current phase, when known.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	TotalBytes *int64 `json:"totalBytes,omitempty"`
+	// BytesPerSecond is the throughput of the current phase since the previous update.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	BytesPerSecond int64 `json:"bytesPerSecond,omitempty"`
+	// StartTime is the time when the current phase started.
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	StartTime metav1.Time `json:"startTime"`
+	// LastUpdateTime is the last time that the progress was updated.
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	LastUpdateTime metav1.Time `json:"lastUpdateTime"`
+	// EstimatedCompletionTime is the estimated time when the current phase will be completed, when the total bytes are known.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	EstimatedCompletionTime *metav1.Time `json:"estimatedCompletionTime,omitempty"`
+}
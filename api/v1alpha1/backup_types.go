@@ -3,14 +3,103 @@ package v1alpha1
 import (
 	"errors"
 	"fmt"
+	"regexp"
+	"strings"
 	"time"
 
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/utils/ptr"
 )
 
+// ParallelBackup defines the parallel logical backup mode.
+// The dump is split into a schema file, a data file per table and a post-data file with the triggers, views, routines and events.
+// These files are bundled together with a manifest, containing the GTID of the snapshot and the checksums of the files, into a single archive.
+type ParallelBackup struct {
+	// Enabled is a flag to enable the parallel logical backup mode.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:booleanSwitch"}
+	Enabled bool `json:"enabled,omitempty"`
+	// ChunkSize is the maximum size of each data file. Tables bigger than this size are split into multiple chunks that can be restored concurrently.
+	// If not provided, each table is stored in a single data file.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:advanced"}
+	ChunkSize *resource.Quantity `json:"chunkSize,omitempty"`
+}
+
+// Validate determines whether the ParallelBackup configuration is valid.
+func (p *ParallelBackup) Validate() error {
+	if p.ChunkSize != nil && p.ChunkSize.Sign() <= 0 {
+		return errors.New("'chunkSize' must be greater than zero")
+	}
+	return nil
+}
+
+// parallelBackupIncompatibleArgs are the mariadb-dump options that remove the comments used to split parallel backups.
+var parallelBackupIncompatibleArgs = []string{
+	"--skip-comments",
+	"--compact",
+	"--comments=0",
+	"--comments=false",
+	"--comments=off",
+}
+
+// ValidateArgs determines whether the mariadb-dump arguments are compatible with the parallel backup mode.
+func (p *ParallelBackup) ValidateArgs(args []string) error {
+	if !p.Enabled {
+		return nil
+	}
+	for _, arg := range args {
+		for _, incompatibleArg := range parallelBackupIncompatibleArgs {
+			if strings.EqualFold(strings.TrimSpace(arg), incompatibleArg) {
+				return fmt.Errorf("argument '%s' is not supported, as parallel backups are split by the comments of the dump", arg)
+			}
+		}
+	}
+	return nil
+}
+
+var tablePatternRegex = regexp.MustCompile(`^[\w*-]+\.[\w*-]+$`)
+
+// validateTablePattern validates a table pattern in the 'database.table' format, where '*' matches any sequence of characters.
+func validateTablePattern(pattern string) error {
+	if !tablePatternRegex.MatchString(pattern) {
+		return fmt.Errorf(
+			"invalid table pattern '%s': it must have the 'database.table' format, where '*' matches any sequence of characters",
+			pattern,
+		)
+	}
+	return nil
+}
+
+// TableSelection defines the tables to be backed up.
+// Tables are selected by patterns in the 'database.table' format, where '*' matches any sequence of characters, for example 'db.*' or '*.users'.
+type TableSelection struct {
+	// Include is a list of table patterns to be backed up. If not provided, all tables are backed up.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Include []string `json:"include,omitempty"`
+	// Exclude is a list of table patterns to be excluded from the backup. It takes precedence over Include.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Exclude []string `json:"exclude,omitempty"`
+}
+
+// Validate determines whether the TableSelection is valid.
+func (t *TableSelection) Validate() error {
+	if len(t.Include) == 0 && len(t.Exclude) == 0 {
+		return errors.New("at least one of 'include' or 'exclude' must be provided")
+	}
+	for _, pattern := range append(t.Include, t.Exclude...) {
+		if err := validateTablePattern(pattern); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // BackupSpec defines the desired state of Backup
 type BackupSpec struct {
 	// JobContainerTemplate defines templates to configure Container objects.
@@ -28,9 +117,19 @@ type BackupSpec struct {
 	MariaDBRef MariaDBRef `json:"mariaDbRef" webhook:"inmutable"`
 	// Compression algorithm to be used in the Backup.
 	// +optional
-	// +kubebuilder:validation:Enum=none;bzip2;gzip
+	// +kubebuilder:validation:Enum=none;bzip2;gzip;zstd;lz4
 	// +operator-sdk:csv:customresourcedefinitions:type=spec
 	Compression CompressAlgorithm `json:"compression,omitempty"`
+	// CompressionLevel to be used by the compression algorithm. Only supported by zstd, where it ranges from 1 (fastest) to 22 (best compression).
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=22
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:number","urn:alm:descriptor:com.tectonic.ui:advanced"}
+	CompressionLevel *int32 `json:"compressionLevel,omitempty"`
+	// Encryption defines the client-side encryption configuration for the backups.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:advanced"}
+	Encryption *Encryption `json:"encryption,omitempty"`
 	// StagingStorage defines the temporary storage used to keep external backups (i.e. S3) while they are being processed.
 	// It defaults to an emptyDir volume, meaning that the backups will be temporarily stored in the node where the Backup Job is scheduled.
 	// The staging area gets cleaned up after each backup is completed, consider this for sizing it appropriately.
@@ -50,10 +149,36 @@ type BackupSpec struct {
 	// +optional
 	// +operator-sdk:csv:customresourcedefinitions:type=spec
 	MaxRetention metav1.Duration `json:"maxRetention,omitempty" webhook:"inmutableinit"`
+	// Retention defines a grandfather-father-son retention policy for backups, such as keeping 7 daily, 4 weekly and 12 monthly backups.
+	// When specified, it takes precedence over MaxRetention. Old backups will be cleaned up by the Backup Job.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Retention *RetentionPolicy `json:"retention,omitempty" webhook:"inmutableinit"`
+	// SecondaryStorages defines additional storages where backups are replicated after being uploaded to the primary storage.
+	// Retention is applied independently to each of them, and they are used as a fallback when the primary storage is unreachable during restorations.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	SecondaryStorages []SecondaryStorage `json:"secondaryStorages,omitempty"`
+	// Throttling limits the bandwidth used to transfer the backups to and from the storage.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:advanced"}
+	Throttling *Throttling `json:"throttling,omitempty"`
+	// Hooks defines the SQL statements and commands to be run by the operator before and after taking a backup.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Hooks *BackupHooks `json:"hooks,omitempty"`
 	// Databases defines the logical databases to be backed up. If not provided, all databases are backed up.
 	// +optional
 	// +operator-sdk:csv:customresourcedefinitions:type=spec
 	Databases []string `json:"databases,omitempty"`
+	// Tables defines the tables to be backed up. If not provided, all the tables of the selected databases are backed up.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Tables *TableSelection `json:"tables,omitempty"`
+	// Parallel defines the parallel logical backup mode, which allows Restores to load the tables concurrently.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Parallel *ParallelBackup `json:"parallel,omitempty"`
 	// IgnoreGlobalPriv indicates to ignore the mysql.global_priv in backups.
 	// If not provided, it will default to true when the referred MariaDB instance has Galera enabled and otherwise to false.
 	// See: https://github.com/mariadb-operator/mariadb-operator/issues/556
@@ -70,9 +195,9 @@ type BackupSpec struct {
 	// +optional
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:number","urn:alm:descriptor:com.tectonic.ui:advanced"}
 	BackoffLimit int32 `json:"backoffLimit,omitempty"`
-	// RestartPolicy to be added to the Backup Pod.
+	// RestartPolicy to be added to the Backup Pod. It defaults to OnFailure, or to Never when the parallel mode is enabled,
+	// which is the only supported policy in that mode.
 	// +optional
-	// +kubebuilder:default=OnFailure
 	// +kubebuilder:validation:Enum=Always;OnFailure;Never
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:advanced"}
 	RestartPolicy corev1.RestartPolicy `json:"restartPolicy,omitempty" webhook:"inmutable"`
@@ -88,6 +213,19 @@ type BackupStatus struct {
 	// +optional
 	// +operator-sdk:csv:customresourcedefinitions:type=status,xDescriptors={"urn:alm:descriptor:io.kubernetes.conditions"}
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
+	// SecondaryStorages is the replication status of the secondary storages.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	SecondaryStorages []SecondaryStorageStatus `json:"secondaryStorages,omitempty"`
+	// Progress of the running backup.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	Progress *BackupProgress `json:"progress,omitempty"`
+	// Catalog lists the backups available in the storage.
+	// It is refreshed by the backup Job after every backup, so it does not reflect changes made to the storage in between.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	Catalog *BackupCatalog `json:"catalog,omitempty"`
 }
 
 func (b *BackupStatus) SetCondition(condition metav1.Condition) {
@@ -131,12 +269,62 @@ func (b *Backup) Validate() error {
 	if err := b.Spec.Compression.Validate(); err != nil {
 		return fmt.Errorf("invalid Compression: %v", err)
 	}
-	if b.Spec.Storage.S3 == nil && b.Spec.StagingStorage != nil {
-		return errors.New("'spec.stagingStorage' may only be specified when 'spec.storage.s3' is set")
+	if err := b.Spec.Compression.ValidateLevel(b.Spec.CompressionLevel); err != nil {
+		return fmt.Errorf("invalid CompressionLevel: %v", err)
+	}
+	if b.Spec.Encryption != nil {
+		if err := b.Spec.Encryption.Validate(); err != nil {
+			return fmt.Errorf("invalid Encryption: %v", err)
+		}
+	}
+	if b.Spec.Retention != nil {
+		if err := b.Spec.Retention.Validate(); err != nil {
+			return fmt.Errorf("invalid Retention: %v", err)
+		}
+	}
+	if err := ValidateSecondaryStorages(b.Spec.SecondaryStorages); err != nil {
+		return fmt.Errorf("invalid SecondaryStorages: %v", err)
+	}
+	if b.Spec.Throttling != nil {
+		if err := b.Spec.Throttling.Validate(); err != nil {
+			return fmt.Errorf("invalid Throttling: %v", err)
+		}
+		if b.Spec.Throttling.IOPSLimit != nil {
+			return errors.New("'spec.throttling.iopsLimit' is only supported by PhysicalBackups")
+		}
+	}
+	if b.Spec.Hooks != nil {
+		if err := b.Spec.Hooks.Validate(); err != nil {
+			return fmt.Errorf("invalid Hooks: %v", err)
+		}
+	}
+	if b.Spec.Parallel != nil {
+		if err := b.Spec.Parallel.Validate(); err != nil {
+			return fmt.Errorf("invalid Parallel: %v", err)
+		}
+		if err := b.Spec.Parallel.ValidateArgs(b.Spec.Args); err != nil {
+			return fmt.Errorf("invalid Args: %v", err)
+		}
+		if b.IsParallelEnabled() && b.Spec.RestartPolicy != "" && b.Spec.RestartPolicy != corev1.RestartPolicyNever {
+			return fmt.Errorf("'spec.restartPolicy' must be '%s' when 'spec.parallel' is enabled", corev1.RestartPolicyNever)
+		}
+	}
+	if b.Spec.Tables != nil {
+		if err := b.Spec.Tables.Validate(); err != nil {
+			return fmt.Errorf("invalid Tables: %v", err)
+		}
+	}
+	if b.Spec.Storage.S3 == nil && b.Spec.Storage.GCS == nil && b.Spec.StagingStorage != nil {
+		return errors.New("'spec.stagingStorage' may only be specified when 'spec.storage.s3' or 'spec.storage.gcs' are set")
 	}
 	return nil
 }
 
+// IsParallelEnabled indicates whether the parallel logical backup mode is enabled.
+func (b *Backup) IsParallelEnabled() bool {
+	return b.Spec.Parallel != nil && b.Spec.Parallel.Enabled
+}
+
 func (b *Backup) SetDefaults(mariadb *MariaDB) {
 	if b.Spec.Compression == CompressAlgorithm("") {
 		b.Spec.Compression = CompressNone
@@ -168,7 +356,7 @@ func (b *Backup) SetExternalDefaults(mariadb *ExternalMariaDB) {
 }
 
 func (b *Backup) Volume() (StorageVolumeSource, error) {
-	if b.Spec.Storage.S3 != nil {
+	if b.Spec.Storage.S3 != nil || b.Spec.Storage.GCS != nil {
 		stagingStorage := ptr.Deref(b.Spec.StagingStorage, StagingStorage{})
 		return stagingStorage.VolumeOrEmptyDir(b.StagingPVCKey()), nil
 	}
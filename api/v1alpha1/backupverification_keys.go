@@ -0,0 +1,16 @@
+package v1alpha1
+
+import (
+	"fmt"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// MariaDBKey defines the key for the ephemeral MariaDB of a verification started at a given time.
+func (b *BackupVerification) MariaDBKey(startTime time.Time) types.NamespacedName {
+	return types.NamespacedName{
+		Name:      fmt.Sprintf("%s-%d", b.Name, startTime.Unix()),
+		Namespace: b.Namespace,
+	}
+}
@@ -0,0 +1,258 @@
+package v1alpha1
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// BackupVerificationMariaDB defines the ephemeral MariaDB where the backup is restored during verification.
+type BackupVerificationMariaDB struct {
+	// Image name to be used by the ephemeral MariaDB. It defaults to the image of the MariaDB referred by the backup.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Image string `json:"image,omitempty"`
+	// Storage defines the storage options of the ephemeral MariaDB. It defaults to the storage size of the MariaDB referred by the backup.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Storage *Storage `json:"storage,omitempty"`
+	// Resources describes the compute resource requirements of the ephemeral MariaDB.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:resourceRequirements"}
+	Resources *ResourceRequirements `json:"resources,omitempty"`
+	// RestoreJob defines additional properties for the restoration Job.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:advanced"}
+	RestoreJob *Job `json:"restoreJob,omitempty"`
+}
+
+// BackupVerificationAssertion is a SQL query executed against the restored data.
+type BackupVerificationAssertion struct {
+	// Name identifies the assertion in the verification results.
+	// +kubebuilder:validation:Required
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Name string `json:"name"`
+	// Database to be used when executing the query.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Database *string `json:"database,omitempty"`
+	// Query to be executed. It must return a single column.
+	// +kubebuilder:validation:Required
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Query string `json:"query"`
+	// Expected value of the first row returned by the query.
+	// If not provided, the assertion succeeds as long as the query returns at least one row.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Expected *string `json:"expected,omitempty"`
+}
+
+// BackupVerificationSpec defines the desired state of BackupVerification.
+type BackupVerificationSpec struct {
+	// BackupRef is a reference to the Backup or PhysicalBackup to be verified. If the Kind is not specified, a logical Backup is assumed.
+	// +kubebuilder:validation:Required
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	BackupRef TypedLocalObjectReference `json:"backupRef" webhook:"inmutable"`
+	// PointInTimeRecoveryRef is a reference to a PointInTimeRecovery object.
+	// Providing this field implies replaying the archived binary logs on top of the restored PhysicalBackup,
+	// up to the targetRecoveryTime. The PointInTimeRecovery must refer to the PhysicalBackup referred by backupRef.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	PointInTimeRecoveryRef *LocalObjectReference `json:"pointInTimeRecoveryRef,omitempty"`
+	// TargetRecoveryTime is a RFC3339 (1970-01-01T00:00:00Z) date and time that defines the point in time to be verified.
+	// It defaults to the time when the verification starts, meaning that the latest backup is verified.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	TargetRecoveryTime *metav1.Time `json:"targetRecoveryTime,omitempty"`
+	// Schedule defines when the verification will be performed. If not provided, the verification is performed once.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Schedule *Schedule `json:"schedule,omitempty"`
+	// MariaDB defines the ephemeral MariaDB where the backup is restored.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	MariaDB BackupVerificationMariaDB `json:"mariadb,omitempty"`
+	// Assertions are SQL queries executed against the restored data. The verification fails if any of them fails.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Assertions []BackupVerificationAssertion `json:"assertions,omitempty"`
+	// Timeout defines the maximum duration of a verification, including the restoration of the backup.
+	// It defaults to 1 hour.
+	// +kubebuilder:default="1h"
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+	// InheritMetadata defines the metadata to be inherited by children resources.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:advanced"}
+	InheritMetadata *Metadata `json:"inheritMetadata,omitempty"`
+}
+
+// BackupVerificationAssertionResult is the result of an assertion.
+type BackupVerificationAssertionResult struct {
+	// Name of the assertion.
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	Name string `json:"name"`
+	// Succeeded indicates whether the assertion succeeded.
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	Succeeded bool `json:"succeeded"`
+	// Value returned by the query.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	Value *string `json:"value,omitempty"`
+	// Message describes why the assertion failed.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	Message string `json:"message,omitempty"`
+}
+
+// BackupVerificationResult is the result of a verification.
+type BackupVerificationResult struct {
+	// MariaDB is the name of the ephemeral MariaDB where the backup is restored.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	MariaDB string `json:"mariadb,omitempty"`
+	// BackupFileName is the name of the verified backup file or VolumeSnapshot.
+	// It is not available when the backup is stored in a PersistentVolumeClaim or a Volume.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	BackupFileName string `json:"backupFileName,omitempty"`
+	// TargetRecoveryTime is the point in time that was verified.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	TargetRecoveryTime *metav1.Time `json:"targetRecoveryTime,omitempty"`
+	// StartTime is the time when the verification started.
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	StartTime metav1.Time `json:"startTime"`
+	// CompletionTime is the time when the verification completed.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+	// Duration of the verification.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	Duration *metav1.Duration `json:"duration,omitempty"`
+	// Succeeded indicates whether the backup was restored and all the assertions succeeded.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	Succeeded bool `json:"succeeded"`
+	// Message describes the result of the verification.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	Message string `json:"message,omitempty"`
+	// Assertions are the results of the assertions.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	Assertions []BackupVerificationAssertionResult `json:"assertions,omitempty"`
+}
+
+// Complete marks the verification as completed.
+func (r *BackupVerificationResult) Complete(succeeded bool, message string, now time.Time) {
+	r.CompletionTime = &metav1.Time{Time: now}
+	r.Duration = &metav1.Duration{Duration: now.Sub(r.StartTime.Time)}
+	r.Succeeded = succeeded
+	r.Message = message
+}
+
+// BackupVerificationStatus defines the observed state of BackupVerification.
+type BackupVerificationStatus struct {
+	// Conditions for the BackupVerification object.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status,xDescriptors={"urn:alm:descriptor:io.kubernetes.conditions"}
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+	// LastScheduleTime is the last time that a verification was scheduled.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	LastScheduleTime *metav1.Time `json:"lastScheduleTime,omitempty"`
+	// CurrentVerification is the verification in progress.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	CurrentVerification *BackupVerificationResult `json:"currentVerification,omitempty"`
+	// LastVerification is the result of the last completed verification.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	LastVerification *BackupVerificationResult `json:"lastVerification,omitempty"`
+}
+
+func (s *BackupVerificationStatus) SetCondition(condition metav1.Condition) {
+	if s.Conditions == nil {
+		s.Conditions = make([]metav1.Condition, 0)
+	}
+	meta.SetStatusCondition(&s.Conditions, condition)
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:shortName=bvmdb
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Verified",type="string",JSONPath=".status.conditions[?(@.type==\"BackupVerified\")].status"
+// +kubebuilder:printcolumn:name="Status",type="string",JSONPath=".status.conditions[?(@.type==\"BackupVerified\")].message"
+// +kubebuilder:printcolumn:name="Backup",type="string",JSONPath=".spec.backupRef.name"
+// +kubebuilder:printcolumn:name="Last Scheduled",type="date",JSONPath=".status.lastScheduleTime"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+// +operator-sdk:csv:customresourcedefinitions:resources={{BackupVerification,v1alpha1},{MariaDB,v1alpha1},{PersistentVolumeClaim,v1}}
+
+// BackupVerification is the Schema for the backupverifications API.
+// It periodically restores a backup in an ephemeral MariaDB and runs assertions against the restored data.
+type BackupVerification struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   BackupVerificationSpec   `json:"spec,omitempty"`
+	Status BackupVerificationStatus `json:"status,omitempty"`
+}
+
+// IsVerifying indicates whether a verification is in progress.
+func (b *BackupVerification) IsVerifying() bool {
+	return b.Status.CurrentVerification != nil
+}
+
+// BackupKind returns the kind of the backup to be verified.
+func (b *BackupVerification) BackupKind() string {
+	if b.Spec.BackupRef.Kind == "" {
+		return BackupKind
+	}
+	return b.Spec.BackupRef.Kind
+}
+
+// Validate determines whether a BackupVerification is valid.
+func (b *BackupVerification) Validate() error {
+	if b.Spec.BackupRef.Name == "" {
+		return errors.New("'backupRef.name' must be set")
+	}
+	switch b.BackupKind() {
+	case BackupKind, PhysicalBackupKind:
+	default:
+		return fmt.Errorf("unsupported backup kind: '%v', supported kinds: [%v|%v]", b.Spec.BackupRef.Kind, BackupKind, PhysicalBackupKind)
+	}
+	if b.Spec.PointInTimeRecoveryRef != nil && b.BackupKind() != PhysicalBackupKind {
+		return fmt.Errorf("'pointInTimeRecoveryRef' may only be set when 'backupRef.kind' is %v", PhysicalBackupKind)
+	}
+	if b.Spec.Schedule != nil {
+		if err := b.Spec.Schedule.Validate(); err != nil {
+			return fmt.Errorf("invalid Schedule: %v", err)
+		}
+	}
+	names := make(map[string]struct{}, len(b.Spec.Assertions))
+	for _, a := range b.Spec.Assertions {
+		if a.Name == "" || a.Query == "" {
+			return errors.New("assertions must have 'name' and 'query'")
+		}
+		if _, ok := names[a.Name]; ok {
+			return fmt.Errorf("duplicated assertion name: '%s'", a.Name)
+		}
+		names[a.Name] = struct{}{}
+	}
+	return nil
+}
+
+// +kubebuilder:object:root=true
+
+// BackupVerificationList contains a list of BackupVerification.
+type BackupVerificationList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []BackupVerification `json:"items"`
+}
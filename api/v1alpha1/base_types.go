@@ -11,6 +11,7 @@ import (
 	"github.com/mariadb-operator/mariadb-operator/v26/pkg/environment"
 	cron "github.com/robfig/cron/v3"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/intstr"
@@ -692,6 +693,41 @@ type AzureBlob struct {
 	// +optional
 	// +operator-sdk:csv:customresourcedefinitions:type=spec
 	TLS *TLSConfig `json:"tls,omitempty"`
+	// ObjectLock defines the immutability policy applied to the uploaded blobs.
+	// It requires version-level immutability support to be enabled in the container.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:advanced"}
+	ObjectLock *ObjectLock `json:"objectLock,omitempty"`
+}
+
+// Validate determines whether the AzureBlob configuration is valid.
+func (a *AzureBlob) Validate() error {
+	if a.ObjectLock != nil {
+		if err := a.ObjectLock.Validate(); err != nil {
+			return fmt.Errorf("invalid 'objectLock': %v", err)
+		}
+	}
+	return nil
+}
+
+type GCS struct {
+	// Bucket is the name of the Google Cloud Storage bucket.
+	// +kubebuilder:validation:Required
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Bucket string `json:"bucket"`
+	// Prefix indicates a folder/subfolder in the bucket. For example: mariadb/ or mariadb/backups. A trailing slash '/' is added if not provided.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Prefix string `json:"prefix"`
+	// Endpoint is the Google Cloud Storage JSON API endpoint, including the scheme. It defaults to https://storage.googleapis.com.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:advanced"}
+	Endpoint string `json:"endpoint,omitempty"`
+	// ServiceAccountKeySecretKeyRef is a reference to a Secret key containing a Google service account JSON key.
+	// If not provided, workload identity is used, which requires the ServiceAccount of the Pod to be bound to a Google service account.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	ServiceAccountKeySecretKeyRef *SecretKeySelector `json:"serviceAccountKeySecretKeyRef,omitempty"`
 }
 
 type S3 struct {
@@ -733,6 +769,82 @@ type S3 struct {
 	// +optional
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:advanced"}
 	SSEC *SSECConfig `json:"ssec,omitempty"`
+	// ObjectLock defines the S3 Object Lock retention applied to the uploaded objects.
+	// It requires Object Lock to be enabled in the bucket.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:advanced"}
+	ObjectLock *ObjectLock `json:"objectLock,omitempty"`
+}
+
+// Validate determines whether the S3 configuration is valid.
+func (s *S3) Validate() error {
+	if s.ObjectLock != nil {
+		if err := s.ObjectLock.Validate(); err != nil {
+			return fmt.Errorf("invalid 'objectLock': %v", err)
+		}
+	}
+	return nil
+}
+
+// ObjectLockMode defines the retention mode of the locked objects.
+type ObjectLockMode string
+
+const (
+	// ObjectLockModeGovernance allows users with special permissions to delete the objects before the retention expires.
+	// It corresponds to an unlocked immutability policy in Azure Blob Storage.
+	ObjectLockModeGovernance ObjectLockMode = "Governance"
+	// ObjectLockModeCompliance prevents any user, including the root account, from deleting the objects before the retention expires.
+	// It corresponds to a locked immutability policy in Azure Blob Storage.
+	ObjectLockModeCompliance ObjectLockMode = "Compliance"
+)
+
+// Validate determines whether the ObjectLockMode is valid.
+func (m ObjectLockMode) Validate() error {
+	switch m {
+	case ObjectLockModeGovernance, ObjectLockModeCompliance:
+		return nil
+	default:
+		return fmt.Errorf("invalid mode: %v, supported modes: [%s, %s]", m, ObjectLockModeGovernance, ObjectLockModeCompliance)
+	}
+}
+
+// ObjectLock defines the write-once-read-many (WORM) protection of the objects uploaded to the storage.
+// Locked objects cannot be deleted nor overwritten until their retention expires, therefore they are skipped by the retention policies.
+type ObjectLock struct {
+	// Mode is the retention mode of the locked objects. It defaults to Governance.
+	// +optional
+	// +kubebuilder:default=Governance
+	// +kubebuilder:validation:Enum=Governance;Compliance
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Mode ObjectLockMode `json:"mode,omitempty"`
+	// RetainUntil is the duration, since the upload, during which the objects are locked.
+	// +kubebuilder:validation:Required
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	RetainUntil metav1.Duration `json:"retainUntil"`
+	// LegalHold places a legal hold on the objects, which prevents them from being deleted regardless of their retention,
+	// until the hold is explicitly removed.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	LegalHold bool `json:"legalHold,omitempty"`
+}
+
+// Validate determines whether the ObjectLock configuration is valid.
+func (o *ObjectLock) Validate() error {
+	if err := o.ModeOrDefault().Validate(); err != nil {
+		return err
+	}
+	if o.RetainUntil.Duration <= 0 {
+		return errors.New("'retainUntil' must be greater than zero")
+	}
+	return nil
+}
+
+// ModeOrDefault returns the retention mode, defaulting to Governance.
+func (o *ObjectLock) ModeOrDefault() ObjectLockMode {
+	if o.Mode == "" {
+		return ObjectLockModeGovernance
+	}
+	return o.Mode
 }
 
 // SSECConfig defines the configuration for SSE-C (Server-Side Encryption with Customer-Provided Keys).
@@ -744,6 +856,123 @@ type SSECConfig struct {
 	CustomerKeySecretKeyRef SecretKeySelector `json:"customerKeySecretKeyRef"`
 }
 
+// Encryption defines the client-side encryption configuration.
+// Data is encrypted with AES-256-GCM before being uploaded to the storage, and it is decrypted after being downloaded.
+type Encryption struct {
+	// KeySecretKeyRef is a reference to a Secret key containing the encryption key used to encrypt new data.
+	// The key must be a 32-byte (256-bit) key encoded in base64.
+	// +kubebuilder:validation:Required
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	KeySecretKeyRef SecretKeySelector `json:"keySecretKeyRef"`
+	// PreviousKeySecretKeyRefs are references to Secret keys containing encryption keys that were previously used.
+	// They are only used to decrypt existing data, allowing to rotate the encryption key without breaking the restoration of older backups.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:advanced"}
+	PreviousKeySecretKeyRefs []SecretKeySelector `json:"previousKeySecretKeyRefs,omitempty"`
+	// AllowUnencrypted allows reading data that has not been encrypted, such as backups taken before enabling encryption.
+	// When disabled, data without an encryption header is rejected, preventing unencrypted or forged data from being restored.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:booleanSwitch","urn:alm:descriptor:com.tectonic.ui:advanced"}
+	AllowUnencrypted bool `json:"allowUnencrypted,omitempty"`
+}
+
+// Validate determines whether the Encryption configuration is valid.
+func (e *Encryption) Validate() error {
+	if e.KeySecretKeyRef.Name == "" || e.KeySecretKeyRef.Key == "" {
+		return errors.New("'keySecretKeyRef' must reference a Secret key")
+	}
+	for i, ref := range e.PreviousKeySecretKeyRefs {
+		if ref.Name == "" || ref.Key == "" {
+			return fmt.Errorf("'previousKeySecretKeyRefs[%d]' must reference a Secret key", i)
+		}
+	}
+	return nil
+}
+
+// RetentionPolicy defines a grandfather-father-son retention policy for backups.
+// A backup is kept when it is matched by at least one of the rules, otherwise it is cleaned up.
+// Periods are evaluated in UTC, and the most recent backup of each period is the one being kept.
+type RetentionPolicy struct {
+	// KeepLast is the number of most recent backups to keep.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	KeepLast int32 `json:"keepLast,omitempty"`
+	// KeepHourly is the number of hourly backups to keep.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	KeepHourly int32 `json:"keepHourly,omitempty"`
+	// KeepDaily is the number of daily backups to keep.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	KeepDaily int32 `json:"keepDaily,omitempty"`
+	// KeepWeekly is the number of weekly backups to keep. Weeks are ISO 8601 weeks, starting on Monday.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	KeepWeekly int32 `json:"keepWeekly,omitempty"`
+	// KeepMonthly is the number of monthly backups to keep.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	KeepMonthly int32 `json:"keepMonthly,omitempty"`
+	// KeepYearly is the number of yearly backups to keep.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	KeepYearly int32 `json:"keepYearly,omitempty"`
+}
+
+// Validate determines whether the RetentionPolicy is valid.
+func (r *RetentionPolicy) Validate() error {
+	for _, keep := range []int32{r.KeepLast, r.KeepHourly, r.KeepDaily, r.KeepWeekly, r.KeepMonthly, r.KeepYearly} {
+		if keep < 0 {
+			return errors.New("retention rules must not be negative")
+		}
+	}
+	if r.KeepLast == 0 && r.KeepHourly == 0 && r.KeepDaily == 0 && r.KeepWeekly == 0 && r.KeepMonthly == 0 && r.KeepYearly == 0 {
+		return errors.New("at least one retention rule must be greater than zero")
+	}
+	return nil
+}
+
+// Throttling defines the limits applied when transferring data to and from the storage, preventing backups from saturating
+// the network and the disks shared with the database traffic.
+type Throttling struct {
+	// BandwidthLimit is the maximum number of bytes per second transferred to and from the object storage, for example 50Mi.
+	// It applies both to uploads and downloads, and it is shared by the primary and the secondary storages.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	BandwidthLimit *resource.Quantity `json:"bandwidthLimit,omitempty"`
+	// IOPSLimit is the maximum number of read and write operations per second performed by mariadb-backup when copying the data files.
+	// It is passed to mariadb-backup via the --throttle flag, and it is only supported by PhysicalBackups.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:number"}
+	IOPSLimit *int32 `json:"iopsLimit,omitempty"`
+}
+
+// BandwidthLimitBytes returns the bandwidth limit in bytes per second, or 0 when it is not limited.
+func (t *Throttling) BandwidthLimitBytes() int64 {
+	if t == nil || t.BandwidthLimit == nil {
+		return 0
+	}
+	return t.BandwidthLimit.Value()
+}
+
+// Validate determines whether the Throttling is valid.
+func (t *Throttling) Validate() error {
+	if t.BandwidthLimit != nil && t.BandwidthLimit.Sign() <= 0 {
+		return errors.New("'bandwidthLimit' must be greater than zero")
+	}
+	if t.IOPSLimit != nil && *t.IOPSLimit <= 0 {
+		return errors.New("'iopsLimit' must be greater than zero")
+	}
+	return nil
+}
+
 // Metadata defines the metadata to added to resources.
 type Metadata struct {
 	// Labels to be added to children resources.
@@ -805,14 +1034,33 @@ const (
 	CompressBzip2 CompressAlgorithm = "bzip2"
 	// Gzip compression. Good compression/decompression speed, but worse compression ratio compared to bzip2.
 	CompressGzip CompressAlgorithm = "gzip"
+	// Zstd compression. Fast compression/decompression speed and good compression ratio, tunable via compression level.
+	CompressZstd CompressAlgorithm = "zstd"
+	// Lz4 compression. Fastest compression/decompression speed, but worse compression ratio compared to the rest of algorithms.
+	CompressLz4 CompressAlgorithm = "lz4"
+)
+
+const (
+	// MinZstdCompressionLevel is the minimum zstd compression level.
+	MinZstdCompressionLevel int32 = 1
+	// MaxZstdCompressionLevel is the maximum zstd compression level.
+	MaxZstdCompressionLevel int32 = 22
 )
 
+var supportedCompressAlgorithms = []CompressAlgorithm{
+	CompressNone,
+	CompressBzip2,
+	CompressGzip,
+	CompressZstd,
+	CompressLz4,
+}
+
 func (c CompressAlgorithm) Validate() error {
 	switch c {
-	case CompressAlgorithm(""), CompressNone, CompressBzip2, CompressGzip:
+	case CompressAlgorithm(""), CompressNone, CompressBzip2, CompressGzip, CompressZstd, CompressLz4:
 		return nil
 	default:
-		return fmt.Errorf("invalid compression: %v, supported algorithms: [%v|%v|%v]", c, CompressNone, CompressBzip2, CompressGzip)
+		return fmt.Errorf("invalid compression: %v, supported algorithms: %v", c, supportedCompressAlgorithmsString())
 	}
 }
 
@@ -824,11 +1072,34 @@ func (c CompressAlgorithm) Extension() (string, error) {
 		return "bz2", nil
 	case CompressGzip:
 		return "gz", nil
+	case CompressZstd:
+		return "zst", nil
+	case CompressLz4:
+		return "lz4", nil
 	default:
-		return "", fmt.Errorf("invalid compression: %v, supported algorithms: [%v|%v|%v]", c, CompressNone, CompressBzip2, CompressGzip)
+		return "", fmt.Errorf("invalid compression: %v, supported algorithms: %v", c, supportedCompressAlgorithmsString())
 	}
 }
 
+// ValidateLevel validates the compression level for the algorithm. Only zstd supports configuring the level.
+func (c CompressAlgorithm) ValidateLevel(level *int32) error {
+	if level == nil {
+		return nil
+	}
+	if c != CompressZstd {
+		return fmt.Errorf("compression level is only supported by the %v algorithm", CompressZstd)
+	}
+	if *level < MinZstdCompressionLevel || *level > MaxZstdCompressionLevel {
+		return fmt.Errorf(
+			"invalid compression level: %d, it must be between %d and %d",
+			*level,
+			MinZstdCompressionLevel,
+			MaxZstdCompressionLevel,
+		)
+	}
+	return nil
+}
+
 func CompressionFromExtension(ext string) (CompressAlgorithm, error) {
 	switch ext {
 	case "":
@@ -837,17 +1108,33 @@ func CompressionFromExtension(ext string) (CompressAlgorithm, error) {
 		return CompressBzip2, nil
 	case "gz":
 		return CompressGzip, nil
+	case "zst":
+		return CompressZstd, nil
+	case "lz4":
+		return CompressLz4, nil
 	default:
-		return "", fmt.Errorf("unknown compression extension: %q, supported extensions: [bz2|gz]", ext)
+		return "", fmt.Errorf("unknown compression extension: %q, supported extensions: [bz2|gz|zst|lz4]", ext)
 	}
 }
 
+func supportedCompressAlgorithmsString() string {
+	algs := make([]string, len(supportedCompressAlgorithms))
+	for i, alg := range supportedCompressAlgorithms {
+		algs[i] = string(alg)
+	}
+	return fmt.Sprintf("[%s]", strings.Join(algs, "|"))
+}
+
 // BackupStorage defines the final storage for backups.
 type BackupStorage struct {
 	// S3 defines the configuration to store backups in a S3 compatible storage.
 	// +optional
 	// +operator-sdk:csv:customresourcedefinitions:type=spec
 	S3 *S3 `json:"s3,omitempty"`
+	// GCS defines the configuration to store backups in Google Cloud Storage.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	GCS *GCS `json:"gcs,omitempty"`
 	// PersistentVolumeClaim is a Kubernetes PVC specification.
 	// +optional
 	// +operator-sdk:csv:customresourcedefinitions:type=spec
@@ -870,6 +1157,11 @@ func (b *BackupStorage) Validate() error {
 	if storageTypes != 1 {
 		return errors.New("exactly one storage type should be provided")
 	}
+	if b.S3 != nil {
+		if err := b.S3.Validate(); err != nil {
+			return fmt.Errorf("invalid 's3': %v", err)
+		}
+	}
 	return nil
 }
 
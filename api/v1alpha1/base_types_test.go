@@ -1,10 +1,13 @@
 package v1alpha1
 
 import (
+	"time"
+
 	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/utils/ptr"
 )
@@ -373,4 +376,167 @@ var _ = Describe("Base types", func() {
 			),
 		)
 	})
+
+	Context("When validating compression", func() {
+		DescribeTable(
+			"Should validate level",
+			func(
+				calg CompressAlgorithm,
+				level *int32,
+				wantErr bool,
+			) {
+				err := calg.ValidateLevel(level)
+				if wantErr {
+					Expect(err).To(HaveOccurred())
+				} else {
+					Expect(err).ToNot(HaveOccurred())
+				}
+			},
+			Entry(
+				"no level",
+				CompressGzip,
+				nil,
+				false,
+			),
+			Entry(
+				"zstd level",
+				CompressZstd,
+				ptr.To(int32(19)),
+				false,
+			),
+			Entry(
+				"zstd level out of range",
+				CompressZstd,
+				ptr.To(int32(23)),
+				true,
+			),
+			Entry(
+				"level not supported",
+				CompressLz4,
+				ptr.To(int32(3)),
+				true,
+			),
+		)
+		DescribeTable(
+			"Should get algorithm from extension",
+			func(
+				calg CompressAlgorithm,
+			) {
+				ext, err := calg.Extension()
+				Expect(err).ToNot(HaveOccurred())
+				extCalg, err := CompressionFromExtension(ext)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(extCalg).To(Equal(calg))
+			},
+			Entry("none", CompressNone),
+			Entry("gzip", CompressGzip),
+			Entry("bzip2", CompressBzip2),
+			Entry("zstd", CompressZstd),
+			Entry("lz4", CompressLz4),
+		)
+	})
+
+	Context("When validating throttling", func() {
+		DescribeTable(
+			"Should validate",
+			func(
+				throttling *Throttling,
+				wantBandwidthLimit int64,
+				wantErr bool,
+			) {
+				err := throttling.Validate()
+				if wantErr {
+					Expect(err).To(HaveOccurred())
+				} else {
+					Expect(err).ToNot(HaveOccurred())
+				}
+				Expect(throttling.BandwidthLimitBytes()).To(Equal(wantBandwidthLimit))
+			},
+			Entry(
+				"empty",
+				&Throttling{},
+				int64(0),
+				false,
+			),
+			Entry(
+				"bandwidth and IOPS limits",
+				&Throttling{
+					BandwidthLimit: ptr.To(resource.MustParse("50Mi")),
+					IOPSLimit:      ptr.To(int32(100)),
+				},
+				int64(52428800),
+				false,
+			),
+			Entry(
+				"zero bandwidth limit",
+				&Throttling{
+					BandwidthLimit: ptr.To(resource.MustParse("0")),
+				},
+				int64(0),
+				true,
+			),
+			Entry(
+				"negative IOPS limit",
+				&Throttling{
+					IOPSLimit: ptr.To(int32(-1)),
+				},
+				int64(0),
+				true,
+			),
+		)
+	})
+
+	Context("When validating object lock", func() {
+		DescribeTable(
+			"Should validate",
+			func(
+				objectLock *ObjectLock,
+				wantMode ObjectLockMode,
+				wantErr bool,
+			) {
+				err := objectLock.Validate()
+				if wantErr {
+					Expect(err).To(HaveOccurred())
+				} else {
+					Expect(err).ToNot(HaveOccurred())
+				}
+				Expect(objectLock.ModeOrDefault()).To(Equal(wantMode))
+			},
+			Entry(
+				"default mode",
+				&ObjectLock{
+					RetainUntil: metav1.Duration{Duration: 24 * time.Hour},
+				},
+				ObjectLockModeGovernance,
+				false,
+			),
+			Entry(
+				"compliance with legal hold",
+				&ObjectLock{
+					Mode:        ObjectLockModeCompliance,
+					RetainUntil: metav1.Duration{Duration: 24 * time.Hour},
+					LegalHold:   true,
+				},
+				ObjectLockModeCompliance,
+				false,
+			),
+			Entry(
+				"invalid mode",
+				&ObjectLock{
+					Mode:        ObjectLockMode("foo"),
+					RetainUntil: metav1.Duration{Duration: 24 * time.Hour},
+				},
+				ObjectLockMode("foo"),
+				true,
+			),
+			Entry(
+				"missing retain until",
+				&ObjectLock{
+					Mode: ObjectLockModeGovernance,
+				},
+				ObjectLockModeGovernance,
+				true,
+			),
+		)
+	})
 })
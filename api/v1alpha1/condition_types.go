@@ -25,6 +25,18 @@ const (
 	ConditionTypeReplicaRecovered string = "ReplicaRecovered"
 	// ConditionTypeReplicationConfigured indicates that replication has been successfully configured.
 	ConditionTypeReplicationConfigured string = "ReplicationConfigured"
+	// ConditionTypeIntegrityVerified indicates that the latest backup artifact has been stored with the size recorded in its integrity manifest.
+	ConditionTypeIntegrityVerified string = "IntegrityVerified"
+	// ConditionTypeBackupVerified indicates that the last backup verification succeeded.
+	ConditionTypeBackupVerified string = "BackupVerified"
+	// ConditionTypePreHooksExecuted indicates that the pre hooks of the last backup have been executed.
+	ConditionTypePreHooksExecuted string = "PreHooksExecuted"
+	// ConditionTypePostHooksExecuted indicates that the post hooks of the last backup have been executed.
+	ConditionTypePostHooksExecuted string = "PostHooksExecuted"
+	// ConditionTypeDegraded indicates that the resource is degraded, for example, because the binlog index is inconsistent.
+	ConditionTypeDegraded string = "Degraded"
+	// ConditionTypeFlashbackGenerated indicates that the reverse SQL of a Flashback has been generated.
+	ConditionTypeFlashbackGenerated string = "FlashbackGenerated"
 
 	ConditionReasonStatefulSetNotReady   string = "StatefulSetNotReady"
 	ConditionReasonStatefulSetReady      string = "StatefulSetReady"
@@ -60,6 +72,7 @@ const (
 	ConditionReasonSuspended             string = "Suspended"
 	ConditionReasonMaintenance           string = "Maintenance"
 	ConditionReasonCordoned              string = "Cordoned"
+	ConditionReasonRestoreDryRun         string = "RestoreDryRun"
 
 	ConditionReasonMaxScaleNotReady string = "MaxScaleNotReady"
 	ConditionReasonMaxScaleReady    string = "MaxScaleReady"
@@ -86,6 +99,25 @@ const (
 
 	ConditionReasonConnectionFailed string = "ConnectionFailed"
 
+	ConditionReasonIntegrityVerified           string = "IntegrityVerified"
+	ConditionReasonIntegrityVerificationFailed string = "IntegrityVerificationFailed"
+
+	ConditionReasonBinlogIndexConsistent   string = "BinlogIndexConsistent"
+	ConditionReasonBinlogIndexInconsistent string = "BinlogIndexInconsistent"
+
+	ConditionReasonBackupVerifying          string = "BackupVerifying"
+	ConditionReasonBackupVerified           string = "BackupVerified"
+	ConditionReasonBackupVerificationFailed string = "BackupVerificationFailed"
+
+	ConditionReasonHooksSucceeded           string = "HooksSucceeded"
+	ConditionReasonHooksSucceededWithErrors string = "HooksSucceededWithErrors"
+	ConditionReasonHooksFailed              string = "HooksFailed"
+
+	ConditionReasonFlashbackGenerating      string = "FlashbackGenerating"
+	ConditionReasonFlashbackGenerated       string = "FlashbackGenerated"
+	ConditionReasonFlashbackGenerationError string = "FlashbackGenerationError"
+	ConditionReasonFlashbackPendingApproval string = "FlashbackPendingApproval"
+
 	ConditionReasonCreated string = "Created"
 	ConditionReasonHealthy string = "Healthy"
 	ConditionReasonFailed  string = "Failed"
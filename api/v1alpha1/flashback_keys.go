@@ -0,0 +1,49 @@
+package v1alpha1
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// OutputKey defines the key for the ConfigMap or Secret where the reverse SQL is stored.
+func (f *Flashback) OutputKey() types.NamespacedName {
+	name := f.Spec.Output.Name
+	if name == "" {
+		name = f.Name
+	}
+	return types.NamespacedName{
+		Name:      name,
+		Namespace: f.Namespace,
+	}
+}
+
+// GenerateJobKey defines the key for the Job that generates the reverse SQL.
+func (f *Flashback) GenerateJobKey() types.NamespacedName {
+	return types.NamespacedName{
+		Name:      f.Name,
+		Namespace: f.Namespace,
+	}
+}
+
+// ApplyJobKey defines the key for the Job that applies the reverse SQL.
+func (f *Flashback) ApplyJobKey() types.NamespacedName {
+	return types.NamespacedName{
+		Name:      fmt.Sprintf("%s-apply", f.Name),
+		Namespace: f.Namespace,
+	}
+}
+
+func (f *Flashback) RoleKey() types.NamespacedName {
+	return types.NamespacedName{
+		Name:      fmt.Sprintf("%s-role", f.Spec.ServiceAccountKey(f.ObjectMeta).Name),
+		Namespace: f.Namespace,
+	}
+}
+
+func (f *Flashback) RoleBindingKey() types.NamespacedName {
+	return types.NamespacedName{
+		Name:      fmt.Sprintf("%s-rolebinding", f.Spec.ServiceAccountKey(f.ObjectMeta).Name),
+		Namespace: f.Namespace,
+	}
+}
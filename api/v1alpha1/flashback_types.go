@@ -0,0 +1,233 @@
+package v1alpha1
+
+import (
+	"errors"
+	"fmt"
+
+	mariadbrepl "github.com/mariadb-operator/mariadb-operator/v26/pkg/replication"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// FlashbackOutputKind is the kind of object where the reverse SQL is stored.
+type FlashbackOutputKind string
+
+const (
+	// FlashbackOutputConfigMap stores the reverse SQL in a ConfigMap.
+	FlashbackOutputConfigMap FlashbackOutputKind = "ConfigMap"
+	// FlashbackOutputSecret stores the reverse SQL in a Secret.
+	FlashbackOutputSecret FlashbackOutputKind = "Secret"
+
+	// FlashbackOutputDefaultKey is the default key where the reverse SQL is stored.
+	FlashbackOutputDefaultKey = "flashback.sql"
+)
+
+// FlashbackOutput defines where the reverse SQL is stored for review.
+type FlashbackOutput struct {
+	// Kind of the object where the reverse SQL is stored. It defaults to ConfigMap.
+	// A Secret should be used when the affected rows contain sensitive data.
+	// +optional
+	// +kubebuilder:validation:Enum=ConfigMap;Secret
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Kind FlashbackOutputKind `json:"kind,omitempty"`
+	// Name of the object where the reverse SQL is stored. It defaults to the name of the Flashback.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Name string `json:"name,omitempty"`
+	// Key of the object where the reverse SQL is stored. It defaults to 'flashback.sql'.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Key string `json:"key,omitempty"`
+}
+
+// FlashbackSpec defines the desired state of Flashback.
+type FlashbackSpec struct {
+	// JobContainerTemplate defines templates to configure Container objects.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	JobContainerTemplate `json:",inline"`
+	// JobPodTemplate defines templates to configure Pod objects.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	JobPodTemplate `json:",inline"`
+	// MariaDBRef is a reference to the MariaDB object where the reverse SQL is applied.
+	// +kubebuilder:validation:Required
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	MariaDBRef MariaDBRef `json:"mariaDbRef" webhook:"inmutable"`
+	// PointInTimeRecoveryRef is a reference to the PointInTimeRecovery object where the binary logs of the MariaDB are archived.
+	// +kubebuilder:validation:Required
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	PointInTimeRecoveryRef LocalObjectReference `json:"pointInTimeRecoveryRef" webhook:"inmutable"`
+	// StartTime is a RFC3339 (1970-01-01T00:00:00Z) date and time that defines the beginning of the window to be undone, inclusive.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	StartTime *metav1.Time `json:"startTime,omitempty" webhook:"inmutable"`
+	// EndTime is a RFC3339 (1970-01-01T00:00:00Z) date and time that defines the end of the window to be undone, exclusive.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	EndTime *metav1.Time `json:"endTime,omitempty" webhook:"inmutable"`
+	// StartGtid is the GTID (0-10-42) of the first transaction to be undone, as an alternative to StartTime.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	StartGtid *mariadbrepl.Gtid `json:"startGtid,omitempty" webhook:"inmutable"`
+	// EndGtid is the GTID (0-10-42) of the last transaction to be undone, as an alternative to EndTime.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	EndGtid *mariadbrepl.Gtid `json:"endGtid,omitempty" webhook:"inmutable"`
+	// Database restricts the undone row events to the ones of this database.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Database *string `json:"database,omitempty" webhook:"inmutable"`
+	// Table restricts the undone row events to the ones of this table. It requires Database to be set.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Table *string `json:"table,omitempty" webhook:"inmutable"`
+	// Output defines where the reverse SQL is stored for review. It defaults to a ConfigMap with the name of the Flashback.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Output FlashbackOutput `json:"output,omitempty" webhook:"inmutable"`
+	// Approved indicates that the reverse SQL has been reviewed and it can be applied to the MariaDB once it has been generated.
+	// It cannot be unset after the reverse SQL has been applied.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:booleanSwitch"}
+	Approved bool `json:"approved,omitempty"`
+	// LogLevel to be used in the Flashback Job. It defaults to 'info'.
+	// +optional
+	// +kubebuilder:default=info
+	// +kubebuilder:validation:Enum=debug;info;warn;error;dpanic;panic;fatal
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:advanced"}
+	LogLevel string `json:"logLevel,omitempty"`
+	// BackoffLimit defines the maximum number of attempts to successfully generate the reverse SQL.
+	// The reverse SQL is applied in a single transaction and it is not retried, as it is not idempotent.
+	// +optional
+	// +kubebuilder:default=5
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:number","urn:alm:descriptor:com.tectonic.ui:advanced"}
+	BackoffLimit int32 `json:"backoffLimit,omitempty"`
+	// InheritMetadata defines the metadata to be inherited by children resources.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:advanced"}
+	InheritMetadata *Metadata `json:"inheritMetadata,omitempty"`
+}
+
+// FlashbackStatus defines the observed state of Flashback.
+type FlashbackStatus struct {
+	// Conditions for the Flashback object.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status,xDescriptors={"urn:alm:descriptor:io.kubernetes.conditions"}
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+	// GeneratedTime is the time when the reverse SQL was generated.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	GeneratedTime *metav1.Time `json:"generatedTime,omitempty"`
+	// AppliedTime is the time when the reverse SQL was applied.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	AppliedTime *metav1.Time `json:"appliedTime,omitempty"`
+}
+
+func (s *FlashbackStatus) SetCondition(condition metav1.Condition) {
+	if s.Conditions == nil {
+		s.Conditions = make([]metav1.Condition, 0)
+	}
+	meta.SetStatusCondition(&s.Conditions, condition)
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:shortName=fmdb
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Generated",type="string",JSONPath=".status.conditions[?(@.type==\"FlashbackGenerated\")].status"
+// +kubebuilder:printcolumn:name="Complete",type="string",JSONPath=".status.conditions[?(@.type==\"Complete\")].status"
+// +kubebuilder:printcolumn:name="Status",type="string",JSONPath=".status.conditions[?(@.type==\"Complete\")].message"
+// +kubebuilder:printcolumn:name="MariaDB",type="string",JSONPath=".spec.mariaDbRef.name"
+// +kubebuilder:printcolumn:name="Approved",type="boolean",JSONPath=".spec.approved"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+// +operator-sdk:csv:customresourcedefinitions:resources={{Flashback,v1alpha1},{ConfigMap,v1},{Secret,v1},{Job,v1},{ServiceAccount,v1}}
+
+// Flashback is the Schema for the flashbacks API.
+// It undoes the row events of a time or GTID window using the binary logs archived by a PointInTimeRecovery.
+type Flashback struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   FlashbackSpec   `json:"spec,omitempty"`
+	Status FlashbackStatus `json:"status,omitempty"`
+}
+
+// IsGenerated indicates whether the reverse SQL has been generated.
+func (f *Flashback) IsGenerated() bool {
+	return meta.IsStatusConditionTrue(f.Status.Conditions, ConditionTypeFlashbackGenerated)
+}
+
+// IsComplete indicates whether the reverse SQL has been applied.
+func (f *Flashback) IsComplete() bool {
+	return meta.IsStatusConditionTrue(f.Status.Conditions, ConditionTypeComplete)
+}
+
+// OutputKind returns the kind of object where the reverse SQL is stored.
+func (f *Flashback) OutputKind() FlashbackOutputKind {
+	if f.Spec.Output.Kind == "" {
+		return FlashbackOutputConfigMap
+	}
+	return f.Spec.Output.Kind
+}
+
+// OutputDataKey returns the key of the object where the reverse SQL is stored.
+func (f *Flashback) OutputDataKey() string {
+	if f.Spec.Output.Key == "" {
+		return FlashbackOutputDefaultKey
+	}
+	return f.Spec.Output.Key
+}
+
+// SetDefaults sets default values.
+func (f *Flashback) SetDefaults(mariadb *MariaDB) {
+	if f.Spec.BackoffLimit == 0 {
+		f.Spec.BackoffLimit = 5
+	}
+	if f.Spec.LogLevel == "" {
+		f.Spec.LogLevel = "info"
+	}
+	f.Spec.JobPodTemplate.SetDefaults(f.ObjectMeta, mariadb.ObjectMeta)
+}
+
+// Validate determines whether a Flashback is valid.
+func (f *Flashback) Validate() error {
+	if f.Spec.PointInTimeRecoveryRef.Name == "" {
+		return errors.New("'pointInTimeRecoveryRef.name' must be set")
+	}
+	if (f.Spec.StartTime == nil) == (f.Spec.StartGtid == nil) {
+		return errors.New("exactly one of 'startTime' or 'startGtid' must be set")
+	}
+	if (f.Spec.EndTime == nil) == (f.Spec.EndGtid == nil) {
+		return errors.New("exactly one of 'endTime' or 'endGtid' must be set")
+	}
+	if f.Spec.StartTime != nil && f.Spec.EndTime != nil && !f.Spec.StartTime.Before(f.Spec.EndTime) {
+		return errors.New("'startTime' must be before 'endTime'")
+	}
+	if f.Spec.StartGtid != nil && f.Spec.EndGtid != nil {
+		greaterThan, err := f.Spec.StartGtid.GreaterThan(f.Spec.EndGtid)
+		if err != nil {
+			return fmt.Errorf("error comparing 'startGtid' and 'endGtid': %v", err)
+		}
+		if greaterThan {
+			return errors.New("'startGtid' must not be greater than 'endGtid'")
+		}
+	}
+	if f.Spec.Table != nil && f.Spec.Database == nil {
+		return errors.New("'table' requires 'database' to be set")
+	}
+	switch f.OutputKind() {
+	case FlashbackOutputConfigMap, FlashbackOutputSecret:
+	default:
+		return fmt.Errorf("unsupported output kind: '%v', supported kinds: [%v|%v]",
+			f.Spec.Output.Kind, FlashbackOutputConfigMap, FlashbackOutputSecret)
+	}
+	return nil
+}
+
+// +kubebuilder:object:root=true
+
+// FlashbackList contains a list of Flashback.
+type FlashbackList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Flashback `json:"items"`
+}
@@ -14,8 +14,12 @@ const (
 	BackupKind = "Backup"
 	// PhysicalBackupKind is the kind name of PhysicalBackup
 	PhysicalBackupKind = "PhysicalBackup"
+	// RestoreKind is the kind name of Restore
+	RestoreKind = "Restore"
 	// ExternalMariaDBKind is the kind name of ExternalMariaDB
 	ExternalMariaDBKind = "ExternalMariaDB"
+	// FlashbackKind is the kind name of Flashback
+	FlashbackKind = "Flashback"
 )
 
 var (
@@ -32,9 +36,11 @@ var (
 func addKnownTypes(scheme *runtime.Scheme) error {
 	scheme.AddKnownTypes(GroupVersion,
 		&Backup{}, &BackupList{},
+		&BackupVerification{}, &BackupVerificationList{},
 		&Connection{}, &ConnectionList{},
 		&Database{}, &DatabaseList{},
 		&ExternalMariaDB{}, &ExternalMariaDBList{},
+		&Flashback{}, &FlashbackList{},
 		&Grant{}, &GrantList{},
 		&MariaDB{}, &MariaDBList{},
 		&MaxScale{}, &MaxScaleList{},
@@ -208,8 +208,10 @@ type BootstrapFrom struct {
 	// +operator-sdk:csv:customresourcedefinitions:type=spec
 	VolumeSnapshotRef *LocalObjectReference `json:"volumeSnapshotRef,omitempty" webhook:"inmutableinit"`
 	// PointInTimeRecoveryRef is a reference to a PointInTimeRecovery object.
-	// Providing this field implies restoring the PhysicalBackup referenced in the PointInTimeRecovery object and replaying the
+	// Providing this field implies restoring the base backup referenced in the PointInTimeRecovery object and replaying the
 	// archived binary logs up to the point-in-time restoration target, defined by the targetRecoveryTime field.
+	// The base backup may be a PhysicalBackup, or a logical Backup, which is restored through a Restore object before
+	// replaying the binary logs from the GTID recorded in its metadata.
 	// +optional
 	// +operator-sdk:csv:customresourcedefinitions:type=spec
 	PointInTimeRecoveryRef *LocalObjectReference `json:"pointInTimeRecoveryRef,omitempty"`
@@ -230,15 +232,46 @@ type BootstrapFrom struct {
 	// +optional
 	// +operator-sdk:csv:customresourcedefinitions:type=spec
 	AzureBlob *AzureBlob `json:"azureBlob,omitempty" webhook:"inmutableinit"`
+	// GCS defines the configuration to restore backups from Google Cloud Storage.
+	// This field takes precedence over the Volume source.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	GCS *GCS `json:"gcs,omitempty" webhook:"inmutableinit"`
 	// Volume is a Kubernetes Volume object that contains a backup.
 	// +optional
 	// +operator-sdk:csv:customresourcedefinitions:type=spec
 	Volume *StorageVolumeSource `json:"volume,omitempty" webhook:"inmutableinit"`
+	// Encryption defines the client-side encryption configuration used to decrypt the backups.
+	// It is inferred from the backup object when BackupRef is provided.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:advanced"}
+	Encryption *Encryption `json:"encryption,omitempty" webhook:"inmutableinit"`
+	// SecondaryStorages are used as a fallback when the backups cannot be listed from the primary storage, in the order they are defined.
+	// They are inferred from the backup object when BackupRef is provided.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:advanced"}
+	SecondaryStorages []SecondaryStorage `json:"secondaryStorages,omitempty" webhook:"inmutableinit"`
+	// Streaming streams physical backups from the object storage into mariadb-backup, without staging them in a volume.
+	// It is inferred from the backup object when BackupRef is provided.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:advanced"}
+	Streaming *PhysicalBackupStreaming `json:"streaming,omitempty" webhook:"inmutableinit"`
 	// TargetRecoveryTime is a RFC3339 (1970-01-01T00:00:00Z) date and time that defines the point in time recovery objective.
 	// It is used to determine the closest restoration source in time.
 	// +optional
 	// +operator-sdk:csv:customresourcedefinitions:type=spec
 	TargetRecoveryTime *metav1.Time `json:"targetRecoveryTime,omitempty" webhook:"inmutable"`
+	// TargetRecoveryGtid is a GTID (0-10-42) that defines the point in time recovery objective, as an alternative to TargetRecoveryTime.
+	// The binary logs are replayed up to, and including, the event with this GTID.
+	// It is used to determine the closest restoration source, which must not be after the GTID.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	TargetRecoveryGtid *mariadbrepl.Gtid `json:"targetRecoveryGtid,omitempty" webhook:"inmutable"`
+	// StopBeforeTargetRecoveryGtid stops the recovery right before the event with TargetRecoveryGtid, which is not replayed.
+	// It is useful to recover right before an accidental statement, i.e. DROP TABLE.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:booleanSwitch"}
+	StopBeforeTargetRecoveryGtid bool `json:"stopBeforeTargetRecoveryGtid,omitempty" webhook:"inmutable"`
 	// StagingStorage defines the temporary storage used to keep external backups and binary logs (i.e. S3) while they are being processed.
 	// It defaults to an emptyDir volume, meaning that the backups will be temporarily stored in the node where the Job is scheduled.
 	// +optional
@@ -254,11 +287,17 @@ type BootstrapFrom struct {
 	// +kubebuilder:validation:Enum=debug;info;warn;error;dpanic;panic;fatal
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:advanced"}
 	LogLevel string `json:"logLevel,omitempty"`
+	// DryRun computes the restoration plan, including the backup and the binary logs to be used, and reports it in the status
+	// without restoring any data nor provisioning the MariaDB. Disable it to proceed with the bootstrap.
+	// It is only supported for backups stored in S3, Azure Blob Storage or GCS, and for VolumeSnapshots.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:booleanSwitch"}
+	DryRun bool `json:"dryRun,omitempty"`
 }
 
 func (b *BootstrapFrom) Validate() error {
 	if b.BackupRef == nil && b.VolumeSnapshotRef == nil && b.PointInTimeRecoveryRef == nil &&
-		b.S3 == nil && b.AzureBlob == nil && b.Volume == nil {
+		b.S3 == nil && b.AzureBlob == nil && b.GCS == nil && b.Volume == nil {
 		return errors.New("unable to determine bootstrap source")
 	}
 
@@ -293,17 +332,47 @@ func (b *BootstrapFrom) Validate() error {
 		}
 	}
 
+	if b.Encryption != nil {
+		if err := b.Encryption.Validate(); err != nil {
+			return fmt.Errorf("invalid 'encryption': %v", err)
+		}
+	}
+	if err := ValidateSecondaryStorages(b.SecondaryStorages); err != nil {
+		return fmt.Errorf("invalid 'secondaryStorages': %v", err)
+	}
+	if b.Streaming != nil {
+		if err := b.Streaming.Validate(); err != nil {
+			return fmt.Errorf("invalid 'streaming': %v", err)
+		}
+	}
+	if b.Streaming.IsEnabled() {
+		if b.BackupContentType == BackupContentTypeLogical {
+			return errors.New("'streaming' is only supported by physical backups")
+		}
+		if b.StagingStorage != nil {
+			return errors.New("'stagingStorage' may not be set when 'streaming' is enabled")
+		}
+	}
+
 	if b.VolumeSnapshotRef != nil && b.BackupContentType != "" && b.BackupContentType != BackupContentTypePhysical {
 		return errors.New("inconsistent 'volumeSnapshotRef' and 'backupContentType' fields. Physical type must be set in this case")
 	}
+	if b.DryRun && b.BackupRef == nil && b.VolumeSnapshotRef == nil && b.PointInTimeRecoveryRef == nil &&
+		b.S3 == nil && b.AzureBlob == nil && b.GCS == nil {
+		return errors.New("'dryRun' is only supported with 'backupRef', 'volumeSnapshotRef', 'pointInTimeRecoveryRef', " +
+			"'s3', 'azureBlob' or 'gcs' sources")
+	}
+	if err := validateTargetRecovery(b.TargetRecoveryTime, b.TargetRecoveryGtid, b.StopBeforeTargetRecoveryGtid); err != nil {
+		return err
+	}
 
 	return b.validateMutuallyExclusive()
 }
 
 func (b *BootstrapFrom) validateMutuallyExclusive() error {
 	if b.VolumeSnapshotRef != nil {
-		if b.S3 != nil || b.Volume != nil || b.RestoreJob != nil {
-			return errors.New("'s3', 'volume' and 'restoreJob' may not be set when 'volumeSnapshotRef' is set")
+		if b.S3 != nil || b.GCS != nil || b.Volume != nil || b.RestoreJob != nil {
+			return errors.New("'s3', 'gcs', 'volume' and 'restoreJob' may not be set when 'volumeSnapshotRef' is set")
 		}
 	}
 	if b.PointInTimeRecoveryRef != nil {
@@ -335,7 +404,7 @@ func (b *BootstrapFrom) SetDefaults(mariadb *MariaDB) {
 	if b.BackupContentType == "" {
 		b.BackupContentType = BackupContentTypeLogical
 	}
-	if b.BackupContentType == BackupContentTypePhysical && (b.S3 != nil || b.AzureBlob != nil) {
+	if b.BackupContentType == BackupContentTypePhysical && (b.S3 != nil || b.AzureBlob != nil || b.GCS != nil) {
 		stagingStorage := ptr.Deref(b.StagingStorage, StagingStorage{})
 		b.Volume = ptr.To(stagingStorage.VolumeOrEmptyDir(mariadb.BootstrapFromStagingPVCKey()))
 	}
@@ -353,6 +422,16 @@ func (b *BootstrapFrom) SetDefaultsWithPhysicalBackup(physicalBackup *PhysicalBa
 	b.Volume = &volume
 	b.S3 = physicalBackup.Spec.Storage.S3
 	b.AzureBlob = physicalBackup.Spec.Storage.AzureBlob
+	b.GCS = physicalBackup.Spec.Storage.GCS
+	if b.Encryption == nil {
+		b.Encryption = physicalBackup.Spec.Encryption
+	}
+	if b.SecondaryStorages == nil {
+		b.SecondaryStorages = physicalBackup.Spec.SecondaryStorages
+	}
+	if b.Streaming == nil {
+		b.Streaming = physicalBackup.Spec.Streaming
+	}
 	return nil
 }
 
@@ -367,6 +446,11 @@ func (b *BootstrapFrom) TargetRecoveryTimeOrDefault() time.Time {
 	return time.Now()
 }
 
+// LastRecoveryGtid returns the last GTID to be replayed in order to reach the TargetRecoveryGtid, if any.
+func (b *BootstrapFrom) LastRecoveryGtid() *mariadbrepl.Gtid {
+	return lastRecoveryGtid(b.TargetRecoveryGtid, b.StopBeforeTargetRecoveryGtid)
+}
+
 func (b *BootstrapFrom) RestoreSource() (*RestoreSource, error) {
 	var backupRef *LocalObjectReference
 	if b.BackupRef != nil {
@@ -380,9 +464,15 @@ func (b *BootstrapFrom) RestoreSource() (*RestoreSource, error) {
 	return &RestoreSource{
 		BackupRef:          backupRef,
 		S3:                 b.S3,
+		GCS:                b.GCS,
 		Volume:             b.Volume,
+		Encryption:         b.Encryption,
+		SecondaryStorages:  b.SecondaryStorages,
 		TargetRecoveryTime: b.TargetRecoveryTime,
 		StagingStorage:     b.StagingStorage,
+
+		TargetRecoveryGtid:           b.TargetRecoveryGtid,
+		StopBeforeTargetRecoveryGtid: b.StopBeforeTargetRecoveryGtid,
 	}, nil
 }
 
@@ -846,6 +936,19 @@ type MariaDBPointInTimeRecoveryStatus struct {
 	// +optional
 	// +operator-sdk:csv:customresourcedefinitions:type=status
 	StorageReadyForArchival *bool `json:"storageReadyForArchival,omitempty"`
+	// PendingBinaryLogs is the number of binary logs, excluding the active one, pending to be archived after the last archival.
+	// A growing number indicates that the archival is not able to keep up, for instance, due to throttling or the archive timeout.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	PendingBinaryLogs int32 `json:"pendingBinaryLogs,omitempty"`
+	// PendingBytes is the size in bytes of the binary logs pending to be archived.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	PendingBytes int64 `json:"pendingBytes,omitempty"`
+	// ArchivalLag is the time between the last archived binary log event and the last event of the binary logs pending to be archived.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	ArchivalLag *metav1.Duration `json:"archivalLag,omitempty"`
 }
 
 // MariaDBStatus defines the observed state of MariaDB
@@ -903,6 +1006,10 @@ type MariaDBStatus struct {
 	// +optional
 	// +operator-sdk:csv:customresourcedefinitions:type=status
 	RootPasswordHash *string `json:"rootPasswordHash,omitempty"`
+	// RestorePlan is the restoration plan computed when bootstrapping in dry-run mode.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	RestorePlan *RestorePlan `json:"restorePlan,omitempty"`
 }
 
 // SetCondition sets a status condition to MariaDB
@@ -1124,6 +1231,14 @@ func (m *MariaDB) IsRestoringBackup() bool {
 	return meta.IsStatusConditionFalse(m.Status.Conditions, ConditionTypeBackupRestored)
 }
 
+// IsBootstrapDryRun indicates whether the MariaDB instance is computing the restoration plan of its bootstrap source,
+// before any data has been restored.
+func (m *MariaDB) IsBootstrapDryRun() bool {
+	return m.Spec.BootstrapFrom != nil && m.Spec.BootstrapFrom.DryRun &&
+		meta.FindStatusCondition(m.Status.Conditions, ConditionTypeBackupRestored) == nil &&
+		meta.FindStatusCondition(m.Status.Conditions, ConditionTypeInitialized) == nil
+}
+
 // HasRestoredBackup indicates whether the MariaDB instance has restored a Backup
 func (m *MariaDB) HasRestoredBackup() bool {
 	return meta.IsStatusConditionTrue(m.Status.Conditions, ConditionTypeBackupRestored)
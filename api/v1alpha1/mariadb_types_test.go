@@ -1987,6 +1987,64 @@ var _ = Describe("MariaDB types", func() {
 				},
 				false,
 			),
+			Entry(
+				"Streaming with logical backup",
+				&BootstrapFrom{
+					S3: &S3{
+						Bucket: "test",
+					},
+					BackupContentType: BackupContentTypeLogical,
+					Streaming: &PhysicalBackupStreaming{
+						Enabled: true,
+					},
+				},
+				true,
+			),
+			Entry(
+				"Streaming with staging storage",
+				&BootstrapFrom{
+					S3: &S3{
+						Bucket: "test",
+					},
+					BackupContentType: BackupContentTypePhysical,
+					StagingStorage: &StagingStorage{
+						PersistentVolumeClaim: &PersistentVolumeClaimSpec{},
+					},
+					Streaming: &PhysicalBackupStreaming{
+						Enabled: true,
+					},
+				},
+				true,
+			),
+			Entry(
+				"Streaming with invalid part size",
+				&BootstrapFrom{
+					S3: &S3{
+						Bucket: "test",
+					},
+					BackupContentType: BackupContentTypePhysical,
+					Streaming: &PhysicalBackupStreaming{
+						Enabled:  true,
+						PartSize: ptr.To(resource.MustParse("1Mi")),
+					},
+				},
+				true,
+			),
+			Entry(
+				"Valid streaming",
+				&BootstrapFrom{
+					S3: &S3{
+						Bucket: "test",
+					},
+					BackupContentType: BackupContentTypePhysical,
+					Streaming: &PhysicalBackupStreaming{
+						Enabled:        true,
+						PartSize:       ptr.To(resource.MustParse("128Mi")),
+						MaxPartRetries: ptr.To(int32(5)),
+					},
+				},
+				false,
+			),
 		)
 
 		DescribeTable(
@@ -10,6 +10,7 @@ import (
 	mdbtime "github.com/mariadb-operator/mariadb-operator/v26/pkg/time"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -20,6 +21,14 @@ var (
 	DefaultPhysicalBackupMaxRetention = metav1.Duration{Duration: 30 * 24 * time.Hour}
 	// DefaultPhysicalBackupTimeout defines the default maximum duration of a PhysicalBackup job or snapshot.
 	DefaultPhysicalBackupTimeout = metav1.Duration{Duration: 1 * time.Hour}
+	// DefaultPhysicalBackupStreamingPartSize defines the default size of the parts uploaded when streaming physical backups.
+	DefaultPhysicalBackupStreamingPartSize = resource.MustParse("64Mi")
+	// DefaultPhysicalBackupStreamingMaxPartRetries defines the default number of retries of each part when streaming physical backups.
+	DefaultPhysicalBackupStreamingMaxPartRetries = int32(10)
+
+	// S3 requires parts of at least 5Mi, except for the last one, and of at most 5Gi.
+	minPhysicalBackupStreamingPartSize = resource.MustParse("5Mi")
+	maxPhysicalBackupStreamingPartSize = resource.MustParse("5Gi")
 )
 
 // PhysicalBackupPodTemplate defines a template to configure Container objects that run in a PhysicalBackup.
@@ -113,6 +122,152 @@ func (s *PhysicalBackupSchedule) Validate() error {
 	return nil
 }
 
+// PhysicalBackupIncremental defines how incremental physical backups are taken.
+type PhysicalBackupIncremental struct {
+	// FullCron is a cron expression that defines when a full backup should be taken to start a new chain.
+	// The rest of the scheduled backups will be incremental, relative to the previous backup in the chain.
+	// +kubebuilder:validation:Required
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	FullCron string `json:"fullCron"`
+	// MaxIncrementals is the maximum number of incremental backups allowed in a chain.
+	// When reached, a full backup is taken regardless of the FullCron schedule.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:number"}
+	MaxIncrementals *int32 `json:"maxIncrementals,omitempty"`
+}
+
+// Validate determines whether a PhysicalBackupIncremental is valid.
+func (i *PhysicalBackupIncremental) Validate() error {
+	if _, err := CronParser.Parse(i.FullCron); err != nil {
+		return fmt.Errorf("invalid fullCron: %v", err)
+	}
+	if i.MaxIncrementals != nil && *i.MaxIncrementals < 1 {
+		return errors.New("maxIncrementals must be greater than 0")
+	}
+	return nil
+}
+
+// PhysicalBackupStreaming defines how physical backups are streamed to object storage.
+type PhysicalBackupStreaming struct {
+	// Enabled streams the output of mariadb-backup through compression and encryption directly into a multipart upload, without a staging volume.
+	// Restorations stream the backups the reverse way, from the object storage into mariadb-backup.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:booleanSwitch"}
+	Enabled bool `json:"enabled,omitempty"`
+	// PartSize is the size of each part of the multipart upload. Parts are buffered in memory, consider this when sizing the Job resources.
+	// Object storages limit the number of parts per object (i.e. 10000 in S3), which determines the maximum size of a backup.
+	// It defaults to 64Mi.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	PartSize *resource.Quantity `json:"partSize,omitempty"`
+	// MaxPartRetries is the number of times that the upload of a part is retried before failing the backup.
+	// It defaults to 10.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:number"}
+	MaxPartRetries *int32 `json:"maxPartRetries,omitempty"`
+}
+
+// IsEnabled determines whether streaming is enabled.
+func (s *PhysicalBackupStreaming) IsEnabled() bool {
+	return s != nil && s.Enabled
+}
+
+// PartSizeOrDefault returns the part size in bytes.
+func (s *PhysicalBackupStreaming) PartSizeOrDefault() int64 {
+	partSize := ptr.Deref(s.PartSize, DefaultPhysicalBackupStreamingPartSize)
+	return partSize.Value()
+}
+
+// MaxPartRetriesOrDefault returns the number of retries of each part.
+func (s *PhysicalBackupStreaming) MaxPartRetriesOrDefault() int32 {
+	return ptr.Deref(s.MaxPartRetries, DefaultPhysicalBackupStreamingMaxPartRetries)
+}
+
+// Validate determines whether a PhysicalBackupStreaming is valid.
+func (s *PhysicalBackupStreaming) Validate() error {
+	if s.PartSize != nil && (s.PartSize.Cmp(minPhysicalBackupStreamingPartSize) < 0 || s.PartSize.Cmp(maxPhysicalBackupStreamingPartSize) > 0) {
+		return fmt.Errorf("partSize must be between %s and %s", minPhysicalBackupStreamingPartSize.String(),
+			maxPhysicalBackupStreamingPartSize.String())
+	}
+	if s.MaxPartRetries != nil && *s.MaxPartRetries < 0 {
+		return errors.New("maxPartRetries must be greater than or equal to 0")
+	}
+	return nil
+}
+
+// PhysicalBackupType defines the type of a physical backup.
+type PhysicalBackupType string
+
+const (
+	// PhysicalBackupTypeFull indicates that the physical backup contains a full copy of the data.
+	PhysicalBackupTypeFull PhysicalBackupType = "Full"
+	// PhysicalBackupTypeIncremental indicates that the physical backup contains the changes since its parent backup.
+	PhysicalBackupTypeIncremental PhysicalBackupType = "Incremental"
+)
+
+// PhysicalBackupChainLink is a physical backup that belongs to an incremental backup chain.
+type PhysicalBackupChainLink struct {
+	// FileName is the name of the backup file.
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	FileName string `json:"fileName"`
+	// ParentFileName is the name of the backup file this incremental backup is based on.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	ParentFileName string `json:"parentFileName,omitempty"`
+	// Type is the type of the backup.
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	Type PhysicalBackupType `json:"type"`
+	// FromLSN is the log sequence number where the backup starts.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	FromLSN int64 `json:"fromLsn,omitempty"`
+	// ToLSN is the log sequence number where the backup ends.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	ToLSN int64 `json:"toLsn,omitempty"`
+	// GTID is the GTID position of the backup, only available when point-in-time recovery is enabled.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	GTID string `json:"gtid,omitempty"`
+}
+
+// PhysicalBackupChain is an incremental backup chain, composed by a full backup followed by incremental backups.
+type PhysicalBackupChain struct {
+	// PodIndex is the index of the Pod where the backups of the chain are taken.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	PodIndex *int `json:"podIndex,omitempty"`
+	// StartTime is the time when the full backup of the chain was scheduled.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+	// Backups are the backups that compose the chain, starting with the full backup.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	Backups []PhysicalBackupChainLink `json:"backups,omitempty"`
+}
+
+// Last returns the last backup of the chain.
+func (c *PhysicalBackupChain) Last() *PhysicalBackupChainLink {
+	if len(c.Backups) == 0 {
+		return nil
+	}
+	return &c.Backups[len(c.Backups)-1]
+}
+
+// NumIncrementals returns the number of incremental backups of the chain.
+func (c *PhysicalBackupChain) NumIncrementals() int {
+	numIncrementals := 0
+	for _, b := range c.Backups {
+		if b.Type == PhysicalBackupTypeIncremental {
+			numIncrementals++
+		}
+	}
+	return numIncrementals
+}
+
 // PhysicalBackupVolumeSnapshot defines parameters for the VolumeSnapshots used as physical backups.
 type PhysicalBackupVolumeSnapshot struct {
 	// Metadata is extra metadata to the added to the VolumeSnapshot objects.
@@ -135,6 +290,10 @@ type PhysicalBackupStorage struct {
 	// +optional
 	// +operator-sdk:csv:customresourcedefinitions:type=spec
 	AzureBlob *AzureBlob `json:"azureBlob,omitempty"`
+	// GCS defines the configuration to store backups in Google Cloud Storage.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	GCS *GCS `json:"gcs,omitempty"`
 	// PersistentVolumeClaim is a Kubernetes PVC specification.
 	// +optional
 	// +operator-sdk:csv:customresourcedefinitions:type=spec
@@ -161,6 +320,16 @@ func (b *PhysicalBackupStorage) Validate() error {
 	if storageTypes != 1 {
 		return errors.New("exactly one storage type should be provided")
 	}
+	if b.S3 != nil {
+		if err := b.S3.Validate(); err != nil {
+			return fmt.Errorf("invalid 's3': %v", err)
+		}
+	}
+	if b.AzureBlob != nil {
+		if err := b.AzureBlob.Validate(); err != nil {
+			return fmt.Errorf("invalid 'azureBlob': %v", err)
+		}
+	}
 	return nil
 }
 
@@ -183,9 +352,19 @@ type PhysicalBackupSpec struct {
 	Target *PhysicalBackupTarget `json:"target,omitempty"`
 	// Compression algorithm to be used in the Backup.
 	// +optional
-	// +kubebuilder:validation:Enum=none;bzip2;gzip
+	// +kubebuilder:validation:Enum=none;bzip2;gzip;zstd;lz4
 	// +operator-sdk:csv:customresourcedefinitions:type=spec
 	Compression CompressAlgorithm `json:"compression,omitempty"`
+	// CompressionLevel to be used by the compression algorithm. Only supported by zstd, where it ranges from 1 (fastest) to 22 (best compression).
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=22
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:number","urn:alm:descriptor:com.tectonic.ui:advanced"}
+	CompressionLevel *int32 `json:"compressionLevel,omitempty"`
+	// Encryption defines the client-side encryption configuration for the backups.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:advanced"}
+	Encryption *Encryption `json:"encryption,omitempty"`
 	// StagingStorage defines the temporary storage used to keep external backups (i.e. S3) while they are being processed.
 	// It defaults to an emptyDir volume, meaning that the backups will be temporarily stored in the node where the PhysicalBackup Job is scheduled.
 	// The staging area gets cleaned up after each backup is completed, consider this for sizing it appropriately.
@@ -205,6 +384,37 @@ type PhysicalBackupSpec struct {
 	// +optional
 	// +operator-sdk:csv:customresourcedefinitions:type=spec
 	MaxRetention metav1.Duration `json:"maxRetention,omitempty"`
+	// Retention defines a grandfather-father-son retention policy for backups, such as keeping 7 daily, 4 weekly and 12 monthly backups.
+	// When specified, it takes precedence over MaxRetention. Old backups will be cleaned up by the PhysicalBackup Job.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Retention *RetentionPolicy `json:"retention,omitempty"`
+	// SecondaryStorages defines additional storages where backups are replicated after being uploaded to the primary storage.
+	// Retention is applied independently to each of them, and they are used as a fallback when the primary storage is unreachable during restorations.
+	// It is not supported when using VolumeSnapshots.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	SecondaryStorages []SecondaryStorage `json:"secondaryStorages,omitempty"`
+	// Streaming streams the backups directly to the object storage, without staging them in a volume.
+	// It is only supported when using S3, Azure Blob Storage or GCS, and it may not be combined with StagingStorage or SecondaryStorages.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Streaming *PhysicalBackupStreaming `json:"streaming,omitempty"`
+	// Throttling limits the bandwidth used to transfer the backups to and from the storage, as well as the I/O operations performed by mariadb-backup.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:advanced"}
+	Throttling *Throttling `json:"throttling,omitempty"`
+	// Hooks defines the SQL statements and commands to be run by the operator before and after taking a backup.
+	// It is not supported when using VolumeSnapshots.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Hooks *BackupHooks `json:"hooks,omitempty"`
+	// Incremental enables incremental physical backups. Scheduled backups will build chains composed by a full backup followed by incremental backups,
+	// which only contain the changes since the previous backup in the chain. Restoring from an incremental backup applies the whole chain automatically.
+	// Retention never deletes a backup that a retained incremental backup depends on.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Incremental *PhysicalBackupIncremental `json:"incremental,omitempty"`
 	// Timeout defines the maximum duration of a PhysicalBackup job or snapshot.
 	// If this duration is exceeded, the job or snapshot is considered expired and is deleted by the operator.
 	// A new job or snapshot will then be created according to the schedule.
@@ -271,6 +481,24 @@ type PhysicalBackupStatus struct {
 	// +optional
 	// +operator-sdk:csv:customresourcedefinitions:type=status
 	LastScheduleOnDemand *string `json:"lastScheduleOnDemand,omitempty"`
+	// IncrementalChain is the incremental backup chain currently being built.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	IncrementalChain *PhysicalBackupChain `json:"incrementalChain,omitempty"`
+	// SecondaryStorages is the replication status of the secondary storages.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	SecondaryStorages []SecondaryStorageStatus `json:"secondaryStorages,omitempty"`
+	// Progress of the running backup.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	Progress *BackupProgress `json:"progress,omitempty"`
+	// Catalog lists the backups available in the storage.
+	// It is refreshed by the backup Job after every backup, so it does not reflect changes made to the storage in between.
+	// When using VolumeSnapshots, it is refreshed by the controller instead.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	Catalog *BackupCatalog `json:"catalog,omitempty"`
 }
 
 func (b *PhysicalBackupStatus) SetCondition(condition metav1.Condition) {
@@ -303,6 +531,41 @@ func (b *PhysicalBackup) IsComplete() bool {
 	return meta.IsStatusConditionTrue(b.Status.Conditions, ConditionTypeComplete)
 }
 
+// IsIncrementalEnabled indicates whether incremental backups are enabled.
+func (b *PhysicalBackup) IsIncrementalEnabled() bool {
+	return b.Spec.Incremental != nil && b.Spec.Storage.VolumeSnapshot == nil
+}
+
+// IncrementalParent returns the backup that the next incremental backup should be based on.
+// It returns nil when a full backup should be taken instead, either because a new chain should be started according to the
+// FullCron schedule, the maximum number of incrementals has been reached or the backup is taken in a different Pod,
+// as LSNs are specific to each server.
+func (b *PhysicalBackup) IncrementalParent(podIndex int, now time.Time) (*PhysicalBackupChainLink, error) {
+	if !b.IsIncrementalEnabled() {
+		return nil, nil
+	}
+	chain := b.Status.IncrementalChain
+	if chain == nil || chain.Last() == nil || chain.StartTime == nil {
+		return nil, nil
+	}
+	if chain.PodIndex == nil || *chain.PodIndex != podIndex {
+		return nil, nil
+	}
+
+	fullSchedule, err := CronParser.Parse(b.Spec.Incremental.FullCron)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing fullCron: %v", err)
+	}
+	if !fullSchedule.Next(chain.StartTime.Time).After(now) {
+		return nil, nil
+	}
+	if maxIncrementals := b.Spec.Incremental.MaxIncrementals; maxIncrementals != nil &&
+		chain.NumIncrementals() >= int(*maxIncrementals) {
+		return nil, nil
+	}
+	return chain.Last(), nil
+}
+
 func (b *PhysicalBackup) Validate() error {
 	if b.Spec.Target != nil {
 		if err := b.Spec.Target.Validate(); err != nil {
@@ -320,13 +583,72 @@ func (b *PhysicalBackup) Validate() error {
 	if err := b.Spec.Compression.Validate(); err != nil {
 		return fmt.Errorf("invalid Compression: %v", err)
 	}
+	if err := b.Spec.Compression.ValidateLevel(b.Spec.CompressionLevel); err != nil {
+		return fmt.Errorf("invalid CompressionLevel: %v", err)
+	}
+	if b.Spec.Encryption != nil {
+		if err := b.Spec.Encryption.Validate(); err != nil {
+			return fmt.Errorf("invalid Encryption: %v", err)
+		}
+	}
+	if b.Spec.Retention != nil {
+		if err := b.Spec.Retention.Validate(); err != nil {
+			return fmt.Errorf("invalid Retention: %v", err)
+		}
+	}
+	if b.Spec.Incremental != nil {
+		if err := b.Spec.Incremental.Validate(); err != nil {
+			return fmt.Errorf("invalid Incremental: %v", err)
+		}
+	}
+	if err := ValidateSecondaryStorages(b.Spec.SecondaryStorages); err != nil {
+		return fmt.Errorf("invalid SecondaryStorages: %v", err)
+	}
+	if b.Spec.Streaming != nil {
+		if err := b.Spec.Streaming.Validate(); err != nil {
+			return fmt.Errorf("invalid Streaming: %v", err)
+		}
+	}
+	if b.Spec.Throttling != nil {
+		if err := b.Spec.Throttling.Validate(); err != nil {
+			return fmt.Errorf("invalid Throttling: %v", err)
+		}
+	}
+	if b.Spec.Hooks != nil {
+		if err := b.Spec.Hooks.Validate(); err != nil {
+			return fmt.Errorf("invalid Hooks: %v", err)
+		}
+	}
 
 	storage := b.Spec.Storage
-	if storage.VolumeSnapshot != nil && (storage.S3 != nil || storage.Volume != nil) {
-		return errors.New("'s3' and 'volume' storage types may not be set when 'volumeSnapshotRef' is set")
+	if storage.VolumeSnapshot != nil && (storage.S3 != nil || storage.GCS != nil || storage.Volume != nil) {
+		return errors.New("'s3', 'gcs' and 'volume' storage types may not be set when 'volumeSnapshotRef' is set")
+	}
+	if storage.VolumeSnapshot != nil && b.Spec.Incremental != nil {
+		return errors.New("'spec.incremental' may not be set when 'volumeSnapshot' storage is set")
+	}
+	if storage.VolumeSnapshot != nil && len(b.Spec.SecondaryStorages) > 0 {
+		return errors.New("'spec.secondaryStorages' may not be set when 'volumeSnapshot' storage is set")
+	}
+	if storage.VolumeSnapshot != nil && b.Spec.Hooks != nil {
+		return errors.New("'spec.hooks' may not be set when 'volumeSnapshot' storage is set")
 	}
-	if storage.S3 == nil && b.Spec.StagingStorage != nil {
-		return errors.New("'spec.stagingStorage' may only be specified when 'spec.storage.s3' is set")
+	if storage.VolumeSnapshot != nil && b.Spec.Encryption != nil {
+		return errors.New("'spec.encryption' may not be set when 'volumeSnapshot' storage is set")
+	}
+	if storage.S3 == nil && storage.GCS == nil && b.Spec.StagingStorage != nil {
+		return errors.New("'spec.stagingStorage' may only be specified when 'spec.storage.s3' or 'spec.storage.gcs' are set")
+	}
+	if b.Spec.Streaming.IsEnabled() {
+		if storage.S3 == nil && storage.AzureBlob == nil && storage.GCS == nil {
+			return errors.New("'spec.streaming' may only be enabled when 'spec.storage.s3', 'spec.storage.azureBlob' or 'spec.storage.gcs' are set")
+		}
+		if b.Spec.StagingStorage != nil {
+			return errors.New("'spec.stagingStorage' may not be set when 'spec.streaming' is enabled")
+		}
+		if len(b.Spec.SecondaryStorages) > 0 {
+			return errors.New("'spec.secondaryStorages' may not be set when 'spec.streaming' is enabled")
+		}
 	}
 	return nil
 }
@@ -371,6 +693,10 @@ func (b *PhysicalBackup) Volume() (StorageVolumeSource, error) {
 		stagingStorage := ptr.Deref(b.Spec.StagingStorage, StagingStorage{})
 		return stagingStorage.VolumeOrEmptyDir(b.StagingPVCKey()), nil
 	}
+	if b.Spec.Storage.GCS != nil {
+		stagingStorage := ptr.Deref(b.Spec.StagingStorage, StagingStorage{})
+		return stagingStorage.VolumeOrEmptyDir(b.StagingPVCKey()), nil
+	}
 	if b.Spec.Storage.PersistentVolumeClaim != nil {
 		return StorageVolumeSource{
 			PersistentVolumeClaim: &PersistentVolumeClaimVolumeSource{
@@ -1,6 +1,7 @@
 package v1alpha1
 
 import (
+	"fmt"
 	"time"
 
 	. "github.com/onsi/ginkgo/v2"
@@ -159,6 +160,109 @@ var _ = Describe("PhysicalBackup types", func() {
 				},
 			),
 		)
+		DescribeTable(
+			"Should get incremental parent",
+			func(backup *PhysicalBackup, podIndex int, now time.Time, expectedParent *PhysicalBackupChainLink) {
+				parent, err := backup.IncrementalParent(podIndex, now)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(parent).To(BeEquivalentTo(expectedParent))
+			},
+			Entry(
+				"Incremental disabled",
+				&PhysicalBackup{
+					ObjectMeta: objMeta,
+					Status: PhysicalBackupStatus{
+						IncrementalChain: testIncrementalChain(0, 0),
+					},
+				},
+				0,
+				testIncrementalTime(6),
+				nil,
+			),
+			Entry(
+				"No chain",
+				&PhysicalBackup{
+					ObjectMeta: objMeta,
+					Spec: PhysicalBackupSpec{
+						Incremental: &PhysicalBackupIncremental{
+							FullCron: "0 0 * * *",
+						},
+					},
+				},
+				0,
+				testIncrementalTime(6),
+				nil,
+			),
+			Entry(
+				"Incremental",
+				&PhysicalBackup{
+					ObjectMeta: objMeta,
+					Spec: PhysicalBackupSpec{
+						Incremental: &PhysicalBackupIncremental{
+							FullCron: "0 0 * * *",
+						},
+					},
+					Status: PhysicalBackupStatus{
+						IncrementalChain: testIncrementalChain(0, 2),
+					},
+				},
+				0,
+				testIncrementalTime(6),
+				&testIncrementalChain(0, 2).Backups[2],
+			),
+			Entry(
+				"Different Pod",
+				&PhysicalBackup{
+					ObjectMeta: objMeta,
+					Spec: PhysicalBackupSpec{
+						Incremental: &PhysicalBackupIncremental{
+							FullCron: "0 0 * * *",
+						},
+					},
+					Status: PhysicalBackupStatus{
+						IncrementalChain: testIncrementalChain(0, 2),
+					},
+				},
+				1,
+				testIncrementalTime(6),
+				nil,
+			),
+			Entry(
+				"Full schedule reached",
+				&PhysicalBackup{
+					ObjectMeta: objMeta,
+					Spec: PhysicalBackupSpec{
+						Incremental: &PhysicalBackupIncremental{
+							FullCron: "0 0 * * *",
+						},
+					},
+					Status: PhysicalBackupStatus{
+						IncrementalChain: testIncrementalChain(0, 2),
+					},
+				},
+				0,
+				testIncrementalTime(24),
+				nil,
+			),
+			Entry(
+				"Max incrementals reached",
+				&PhysicalBackup{
+					ObjectMeta: objMeta,
+					Spec: PhysicalBackupSpec{
+						Incremental: &PhysicalBackupIncremental{
+							FullCron:        "0 0 * * *",
+							MaxIncrementals: ptr.To(int32(2)),
+						},
+					},
+					Status: PhysicalBackupStatus{
+						IncrementalChain: testIncrementalChain(0, 2),
+					},
+				},
+				0,
+				testIncrementalTime(6),
+				nil,
+			),
+		)
 		DescribeTable(
 			"Should return a volume",
 			func(backup *PhysicalBackup, expectedVolume StorageVolumeSource, wantErr bool) {
@@ -333,5 +437,136 @@ var _ = Describe("PhysicalBackup types", func() {
 				false,
 			),
 		)
+		DescribeTable(
+			"Should validate streaming",
+			func(streaming *PhysicalBackupStreaming, storage PhysicalBackupStorage, stagingStorage *StagingStorage, wantErr bool) {
+				backup := &PhysicalBackup{
+					ObjectMeta: objMeta,
+					Spec: PhysicalBackupSpec{
+						Storage:        storage,
+						StagingStorage: stagingStorage,
+						Streaming:      streaming,
+					},
+				}
+				err := backup.Validate()
+				if wantErr {
+					Expect(err).To(HaveOccurred())
+				} else {
+					Expect(err).ToNot(HaveOccurred())
+				}
+			},
+			Entry(
+				"Disabled",
+				&PhysicalBackupStreaming{
+					Enabled: false,
+				},
+				PhysicalBackupStorage{
+					PersistentVolumeClaim: &PersistentVolumeClaimSpec{},
+				},
+				nil,
+				false,
+			),
+			Entry(
+				"S3",
+				&PhysicalBackupStreaming{
+					Enabled: true,
+				},
+				PhysicalBackupStorage{
+					S3: &S3{},
+				},
+				nil,
+				false,
+			),
+			Entry(
+				"GCS with part size and retries",
+				&PhysicalBackupStreaming{
+					Enabled:        true,
+					PartSize:       ptr.To(resource.MustParse("16Mi")),
+					MaxPartRetries: ptr.To(int32(3)),
+				},
+				PhysicalBackupStorage{
+					GCS: &GCS{},
+				},
+				nil,
+				false,
+			),
+			Entry(
+				"PVC",
+				&PhysicalBackupStreaming{
+					Enabled: true,
+				},
+				PhysicalBackupStorage{
+					PersistentVolumeClaim: &PersistentVolumeClaimSpec{},
+				},
+				nil,
+				true,
+			),
+			Entry(
+				"Staging storage",
+				&PhysicalBackupStreaming{
+					Enabled: true,
+				},
+				PhysicalBackupStorage{
+					S3: &S3{},
+				},
+				&StagingStorage{
+					PersistentVolumeClaim: &PersistentVolumeClaimSpec{},
+				},
+				true,
+			),
+			Entry(
+				"Part size too small",
+				&PhysicalBackupStreaming{
+					Enabled:  true,
+					PartSize: ptr.To(resource.MustParse("1Mi")),
+				},
+				PhysicalBackupStorage{
+					S3: &S3{},
+				},
+				nil,
+				true,
+			),
+			Entry(
+				"Part size too large",
+				&PhysicalBackupStreaming{
+					Enabled:  true,
+					PartSize: ptr.To(resource.MustParse("10Gi")),
+				},
+				PhysicalBackupStorage{
+					S3: &S3{},
+				},
+				nil,
+				true,
+			),
+		)
 	})
 })
+
+func testIncrementalTime(hours int) time.Time {
+	return time.Date(2025, 1, 1, hours, 0, 0, 0, time.UTC)
+}
+
+func testIncrementalChain(podIndex, numIncrementals int) *PhysicalBackupChain {
+	chain := &PhysicalBackupChain{
+		PodIndex:  ptr.To(podIndex),
+		StartTime: &metav1.Time{Time: testIncrementalTime(0)},
+		Backups: []PhysicalBackupChainLink{
+			{
+				FileName: "physicalbackup-20250101000000.xb",
+				Type:     PhysicalBackupTypeFull,
+				ToLSN:    100,
+			},
+		},
+	}
+	for i := 1; i <= numIncrementals; i++ {
+		parent := chain.Backups[i-1]
+		chain.Backups = append(chain.Backups, PhysicalBackupChainLink{
+			FileName:       fmt.Sprintf("physicalbackup-202501010%d0000.xb", i),
+			ParentFileName: parent.FileName,
+			Type:           PhysicalBackupTypeIncremental,
+			FromLSN:        parent.ToLSN,
+			ToLSN:          parent.ToLSN + 100,
+		})
+	}
+	return chain
+}
@@ -0,0 +1,14 @@
+package v1alpha1
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func (b *PointInTimeRecovery) StoragePVCKey() types.NamespacedName {
+	return types.NamespacedName{
+		Name:      fmt.Sprintf("%s-binlogs", b.Name),
+		Namespace: b.Namespace,
+	}
+}
@@ -1,34 +1,96 @@
 package v1alpha1
 
 import (
+	"errors"
 	"fmt"
+	"strings"
+	"time"
 
+	mariadbrepl "github.com/mariadb-operator/mariadb-operator/v26/pkg/replication"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
+var (
+	// DefaultBinlogStreamingFlushInterval defines the default interval to upload the streamed binary log events.
+	DefaultBinlogStreamingFlushInterval = metav1.Duration{Duration: 5 * time.Second}
+
+	// DefaultBinlogRetentionSafetyMargin defines the default safety margin kept before the oldest retained physical backup.
+	DefaultBinlogRetentionSafetyMargin = metav1.Duration{Duration: 1 * time.Hour}
+
+	// DefaultBinlogCDCPrefix defines the default prefix where the change data capture events are exported.
+	DefaultBinlogCDCPrefix = "cdc"
+
+	minBinlogStreamingFlushInterval = 1 * time.Second
+)
+
 // PointInTimeRecoverySpec defines the desired state of PointInTimeRecovery. It contains binlog archive and point-in-time restoration settings.
 type PointInTimeRecoverySpec struct {
 	// PhysicalBackupRef is a reference to a PhysicalBackup object that will be used as base backup.
-	// +kubebuilder:validation:Required
+	// Exactly one of physicalBackupRef or backupRef must be set.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	PhysicalBackupRef *LocalObjectReference `json:"physicalBackupRef,omitempty"`
+	// BackupRef is a reference to a logical Backup object that will be used as base backup.
+	// The GTID of the consistent snapshot taken by mariadb-dump is recorded in the backup metadata,
+	// and the archived binary logs are replayed starting from it after the logical restoration.
+	// Exactly one of physicalBackupRef or backupRef must be set.
+	// +optional
 	// +operator-sdk:csv:customresourcedefinitions:type=spec
-	PhysicalBackupRef LocalObjectReference `json:"physicalBackupRef"`
+	BackupRef *LocalObjectReference `json:"backupRef,omitempty"`
 	// PointInTimeRecoveryStorage is the storage where the point in time recovery data will be stored
 	// +kubebuilder:validation:Required
 	// +operator-sdk:csv:customresourcedefinitions:type=spec
 	PointInTimeRecoveryStorage PointInTimeRecoveryStorage `json:"storage"`
+	// SecondaryStorages defines additional storages where the binary logs are replicated after being archived in the primary storage.
+	// They are used as a fallback when the primary storage is unreachable during point-in-time restorations.
+	// PersistentVolumeClaims are mounted in the MariaDB Pods and in the point-in-time restoration Jobs.
+	// When MaxRetention or Retention are set, the binary logs older than the time span they cover are purged from the secondary storage.
+	// Otherwise, the retention of the primary storage is applied.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	SecondaryStorages []SecondaryStorage `json:"secondaryStorages,omitempty"`
 	// Compression algorithm to be used for compressing the binary logs.
 	// This field is immutable, it cannot be updated after creation.
 	// +optional
-	// +kubebuilder:validation:Enum=none;bzip2;gzip
+	// +kubebuilder:validation:Enum=none;bzip2;gzip;zstd;lz4
 	// +operator-sdk:csv:customresourcedefinitions:type=spec
 	Compression CompressAlgorithm `json:"compression,omitempty" webhook:"inmutable"`
+	// CompressionLevel to be used by the compression algorithm. Only supported by zstd, where it ranges from 1 (fastest) to 22 (best compression).
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=22
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:number","urn:alm:descriptor:com.tectonic.ui:advanced"}
+	CompressionLevel *int32 `json:"compressionLevel,omitempty"`
+	// Encryption defines the client-side encryption configuration for the archived binary logs.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:advanced"}
+	Encryption *Encryption `json:"encryption,omitempty"`
 	// ArchiveTimeout defines the maximum duration for the binary log archival.
 	// If this duration is exceeded, the sidecar agent will log an error and it will be retried in the next archive cycle.
+	// The replication to the secondary storages is bounded by a separate timeout of the same duration.
 	// It defaults to 1 hour.
 	// +kubebuilder:default="1h"
 	// +optional
 	// +operator-sdk:csv:customresourcedefinitions:type=spec
 	ArchiveTimeout *metav1.Duration `json:"archiveTimeout,omitempty"`
+	// Throttling limits the bandwidth used to archive the binary logs and to pull them during point-in-time restorations.
+	// When the archival is not able to keep up with the generated binary logs, the lag is reported in the MariaDB status.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:advanced"}
+	Throttling *Throttling `json:"throttling,omitempty"`
+	// Streaming continuously archives the events of the active binary log, without waiting for MariaDB to rotate it.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Streaming *BinlogStreaming `json:"streaming,omitempty"`
+	// CDC exports the row events of the archived binary logs as newline-delimited JSON, so they can be consumed by downstream systems.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	CDC *BinlogCDC `json:"cdc,omitempty"`
+	// Retention defines how long the archived binary logs are kept in the storage.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Retention *BinlogRetention `json:"retention,omitempty"`
 	// StrictMode controls the behavior when a point-in-time restoration cannot reach the exact target time:
 	// When enabled: Returns an error and avoids replaying binary logs if target time is not reached.
 	// When disabled (default): Replays available binary logs until the last recoverable time. It logs logs an error if target time is not reached.
@@ -37,6 +99,152 @@ type PointInTimeRecoverySpec struct {
 	StrictMode bool `json:"strictMode"`
 }
 
+// BinlogStreaming defines how the active binary log is streamed to the storage.
+type BinlogStreaming struct {
+	// Enabled connects the sidecar agent as a replication client to MariaDB and uploads the committed transactions of the active binary log in chunks.
+	// Chunks are consolidated into a complete binary log once MariaDB rotates it, and they are not replicated to the secondary storages.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:booleanSwitch"}
+	Enabled bool `json:"enabled,omitempty"`
+	// FlushInterval defines how often the streamed events are uploaded as a chunk, which bounds the recovery point objective.
+	// It defaults to 5 seconds.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	FlushInterval *metav1.Duration `json:"flushInterval,omitempty"`
+}
+
+// IsEnabled determines whether streaming is enabled.
+func (s *BinlogStreaming) IsEnabled() bool {
+	return s != nil && s.Enabled
+}
+
+// GetFlushInterval returns the flush interval, falling back to the default.
+func (s *BinlogStreaming) GetFlushInterval() time.Duration {
+	if s == nil || s.FlushInterval == nil {
+		return DefaultBinlogStreamingFlushInterval.Duration
+	}
+	return s.FlushInterval.Duration
+}
+
+// Validate determines whether a BinlogStreaming is valid.
+func (s *BinlogStreaming) Validate() error {
+	if s.FlushInterval != nil && s.FlushInterval.Duration < minBinlogStreamingFlushInterval {
+		return fmt.Errorf("flushInterval must be at least %s", minBinlogStreamingFlushInterval)
+	}
+	return nil
+}
+
+// BinlogCDC defines how the change data capture events are exported.
+type BinlogCDC struct {
+	// Enabled decodes the row events of the archived binary logs and exports them as newline-delimited JSON,
+	// one object per binary log, to the storage of the PointInTimeRecovery.
+	// The binary logs must be written in row format. Column names are only available when binlog_row_metadata is set to FULL.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:booleanSwitch"}
+	Enabled bool `json:"enabled,omitempty"`
+	// Prefix within the storage of the PointInTimeRecovery where the events are exported. It defaults to "cdc".
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Prefix string `json:"prefix,omitempty"`
+}
+
+// IsEnabled determines whether change data capture is enabled.
+func (c *BinlogCDC) IsEnabled() bool {
+	return c != nil && c.Enabled
+}
+
+// GetPrefix returns the prefix where the events are exported, falling back to the default.
+func (c *BinlogCDC) GetPrefix() string {
+	if c == nil || strings.Trim(c.Prefix, "/") == "" {
+		return DefaultBinlogCDCPrefix
+	}
+	return strings.Trim(c.Prefix, "/")
+}
+
+// Validate determines whether a BinlogCDC is valid.
+func (c *BinlogCDC) Validate() error {
+	// binlogs are archived under the server-<id> prefixes, which cannot be shared with the exported events.
+	for _, part := range strings.Split(c.GetPrefix(), "/") {
+		if strings.HasPrefix(part, "server-") {
+			return fmt.Errorf("prefix '%s' cannot contain 'server-' segments", c.Prefix)
+		}
+	}
+	return nil
+}
+
+// BinlogCDCStatus represents the current status of the change data capture export.
+type BinlogCDCStatus struct {
+	// LastExportedGtid is the GTID of the last exported transaction. It is used as checkpoint, and the transactions up to it are not exported again.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	LastExportedGtid *mariadbrepl.Gtid `json:"lastExportedGtid,omitempty"`
+	// LastExportedServerId is the server_id of the server where the last exported binary log was written.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	LastExportedServerId uint32 `json:"lastExportedServerId,omitempty"`
+	// LastExportedBinaryLog is the name of the last exported binary log. It is used as checkpoint along with LastExportedServerId,
+	// and the binary logs of the same server up to it are not decoded again.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	LastExportedBinaryLog string `json:"lastExportedBinaryLog,omitempty"`
+	// LastExportTime is the last time that the events were exported.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	LastExportTime *metav1.Time `json:"lastExportTime,omitempty"`
+}
+
+// BinlogRetentionMode defines which archived binary logs are kept in the storage.
+type BinlogRetentionMode string
+
+const (
+	// BinlogRetentionModeUnlimited keeps all the archived binary logs.
+	BinlogRetentionModeUnlimited BinlogRetentionMode = "Unlimited"
+	// BinlogRetentionModePhysicalBackup purges the archived binary logs that are no longer needed by any retained physical backup.
+	BinlogRetentionModePhysicalBackup BinlogRetentionMode = "PhysicalBackup"
+)
+
+// BinlogRetention defines how long the archived binary logs are kept in the storage.
+type BinlogRetention struct {
+	// Mode defines which archived binary logs are kept. When set to PhysicalBackup, the binary logs whose GTID range ends before
+	// the GTID of the oldest retained base backup, either physical or logical, are purged from the storage and from the binlog index.
+	// Binary logs in the secondary storages are not purged. It defaults to Unlimited.
+	// +optional
+	// +kubebuilder:validation:Enum=Unlimited;PhysicalBackup
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Mode BinlogRetentionMode `json:"mode,omitempty"`
+	// SafetyMargin is the period of binary logs kept before the ones needed by the oldest retained physical backup.
+	// It defaults to 1 hour.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	SafetyMargin *metav1.Duration `json:"safetyMargin,omitempty"`
+}
+
+// IsPhysicalBackupMode determines whether the binary logs are purged based on the retained physical backups.
+func (r *BinlogRetention) IsPhysicalBackupMode() bool {
+	return r != nil && r.Mode == BinlogRetentionModePhysicalBackup
+}
+
+// GetSafetyMargin returns the safety margin, falling back to the default.
+func (r *BinlogRetention) GetSafetyMargin() time.Duration {
+	if r == nil || r.SafetyMargin == nil {
+		return DefaultBinlogRetentionSafetyMargin.Duration
+	}
+	return r.SafetyMargin.Duration
+}
+
+// Validate determines whether a BinlogRetention is valid.
+func (r *BinlogRetention) Validate() error {
+	switch r.Mode {
+	case "", BinlogRetentionModeUnlimited, BinlogRetentionModePhysicalBackup:
+	default:
+		return fmt.Errorf("unsupported mode: %s", r.Mode)
+	}
+	if r.SafetyMargin != nil && r.SafetyMargin.Duration < 0 {
+		return errors.New("safetyMargin must not be negative")
+	}
+	return nil
+}
+
 // PointInTimeRecoveryStorage stores the different storage options for PITR
 type PointInTimeRecoveryStorage struct {
 	// S3 is the S3-compatible storage where the binary logs will be kept.
@@ -47,12 +255,32 @@ type PointInTimeRecoveryStorage struct {
 	// +optional
 	// +operator-sdk:csv:customresourcedefinitions:type=spec
 	AzureBlob *AzureBlob `json:"azureBlob,omitempty"`
+	// GCS is the Google Cloud Storage bucket where the binary logs will be kept.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	GCS *GCS `json:"gcs,omitempty"`
+	// PersistentVolumeClaim is a Kubernetes PVC specification where the binary logs will be kept.
+	// The PVC is mounted by all the MariaDB Pods, so a ReadWriteMany access mode is required when running multiple replicas.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	PersistentVolumeClaim *PersistentVolumeClaimSpec `json:"persistentVolumeClaim,omitempty"`
+	// Volume is a Kubernetes volume specification where the binary logs will be kept.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Volume *StorageVolumeSource `json:"volume,omitempty"`
+}
+
+// IsFileSystem determines whether the binary logs are kept in a volume mounted in the Pods, rather than in an object storage.
+func (s *PointInTimeRecoveryStorage) IsFileSystem() bool {
+	return s.PersistentVolumeClaim != nil || s.Volume != nil
 }
 
 // +kubebuilder:object:root=true
 // +kubebuilder:resource:shortName=pitr
 // +kubebuilder:subresource:status
 // +kubebuilder:printcolumn:name="Physical Backup",type="string",JSONPath=".spec.physicalBackupRef.name"
+// +kubebuilder:printcolumn:name="Backup",type="string",JSONPath=".spec.backupRef.name"
+// +kubebuilder:printcolumn:name="Earliest Recoverable Time",type="string",JSONPath=".status.earliestRecoverableTime",priority=1
 // +kubebuilder:printcolumn:name="Last Recoverable Time",type="string",JSONPath=".status.lastRecoverableTime"
 // +kubebuilder:printcolumn:name="Strict Mode",type="boolean",JSONPath=".spec.strictMode"
 // +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
@@ -68,10 +296,33 @@ type PointInTimeRecovery struct {
 
 // PointInTimeRecoveryStatus represents the current status of the point-in-time-recovery.
 type PointInTimeRecoveryStatus struct {
+	// Conditions for the PointInTimeRecovery object.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status,xDescriptors={"urn:alm:descriptor:io.kubernetes.conditions"}
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+	// EarliestRecoverableTime is the oldest recoverable time based on the oldest retained physical backup and the archived binary logs.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	EarliestRecoverableTime *string `json:"earliestRecoverableTime,omitempty"`
 	// LastRecoverableTime is the most recent recoverable time based on the current state of physical backups and archived binary logs.
 	// +optional
 	// +operator-sdk:csv:customresourcedefinitions:type=status
 	LastRecoverableTime *string `json:"lastRecoverableTime,omitempty"`
+	// SecondaryStorages is the replication status of the secondary storages.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	SecondaryStorages []SecondaryStorageStatus `json:"secondaryStorages,omitempty"`
+	// CDC is the status of the change data capture export.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	CDC *BinlogCDCStatus `json:"cdc,omitempty"`
+}
+
+func (p *PointInTimeRecoveryStatus) SetCondition(condition metav1.Condition) {
+	if p.Conditions == nil {
+		p.Conditions = make([]metav1.Condition, 0)
+	}
+	meta.SetStatusCondition(&p.Conditions, condition)
 }
 
 // +kubebuilder:object:root=true
@@ -83,20 +334,110 @@ type PointInTimeRecoveryList struct {
 	Items           []PointInTimeRecovery `json:"items"`
 }
 
+// IsLogical determines whether a logical Backup is used as base backup.
+func (b *PointInTimeRecovery) IsLogical() bool {
+	return b.Spec.BackupRef != nil
+}
+
+// BaseBackupKind returns the kind of the object used as base backup.
+func (b *PointInTimeRecovery) BaseBackupKind() string {
+	if b.IsLogical() {
+		return BackupKind
+	}
+	return PhysicalBackupKind
+}
+
+// BaseBackupRef returns the reference to the object used as base backup.
+func (b *PointInTimeRecovery) BaseBackupRef() *LocalObjectReference {
+	if b.IsLogical() {
+		return b.Spec.BackupRef
+	}
+	return b.Spec.PhysicalBackupRef
+}
+
+// Volume returns the volume where the binary logs are kept when using filesystem storage.
+func (b *PointInTimeRecovery) Volume() (StorageVolumeSource, error) {
+	storage := b.Spec.PointInTimeRecoveryStorage
+	if storage.PersistentVolumeClaim != nil {
+		return StorageVolumeSource{
+			PersistentVolumeClaim: &PersistentVolumeClaimVolumeSource{
+				ClaimName: b.StoragePVCKey().Name,
+			},
+		}, nil
+	}
+	if storage.Volume != nil {
+		return *storage.Volume, nil
+	}
+	return StorageVolumeSource{}, errors.New("unable to get volume for PointInTimeRecovery")
+}
+
 func (b *PointInTimeRecovery) Validate() error {
+	if (b.Spec.PhysicalBackupRef == nil) == (b.Spec.BackupRef == nil) {
+		return errors.New("exactly one of physicalBackupRef or backupRef must be set")
+	}
 	if err := b.Spec.PointInTimeRecoveryStorage.Validate(); err != nil {
 		return fmt.Errorf("invalid storage: %w", err)
 	}
-
+	if err := b.Spec.Compression.Validate(); err != nil {
+		return fmt.Errorf("invalid compression: %w", err)
+	}
+	if err := b.Spec.Compression.ValidateLevel(b.Spec.CompressionLevel); err != nil {
+		return fmt.Errorf("invalid compression level: %w", err)
+	}
+	if b.Spec.Encryption != nil {
+		if err := b.Spec.Encryption.Validate(); err != nil {
+			return fmt.Errorf("invalid encryption: %w", err)
+		}
+	}
+	if err := ValidateSecondaryStorages(b.Spec.SecondaryStorages); err != nil {
+		return fmt.Errorf("invalid secondary storages: %w", err)
+	}
+	if b.Spec.Throttling != nil {
+		if err := b.Spec.Throttling.Validate(); err != nil {
+			return fmt.Errorf("invalid throttling: %w", err)
+		}
+		if b.Spec.Throttling.IOPSLimit != nil {
+			return errors.New("throttling: iopsLimit is only supported by PhysicalBackups")
+		}
+	}
+	if b.Spec.Retention != nil {
+		if err := b.Spec.Retention.Validate(); err != nil {
+			return fmt.Errorf("invalid retention: %w", err)
+		}
+	}
+	if b.Spec.Streaming != nil {
+		if err := b.Spec.Streaming.Validate(); err != nil {
+			return fmt.Errorf("invalid streaming: %w", err)
+		}
+	}
+	if b.Spec.CDC != nil {
+		if err := b.Spec.CDC.Validate(); err != nil {
+			return fmt.Errorf("invalid cdc: %w", err)
+		}
+	}
 	return nil
 }
 
 func (s *PointInTimeRecoveryStorage) Validate() error {
-	hasAbs := s.AzureBlob != nil
-	hasS3 := s.S3 != nil
+	storageTypes := 0
+	for _, enabled := range []bool{s.S3 != nil, s.AzureBlob != nil, s.GCS != nil, s.PersistentVolumeClaim != nil, s.Volume != nil} {
+		if enabled {
+			storageTypes++
+		}
+	}
 
-	if hasAbs == hasS3 {
-		return fmt.Errorf("either s3 or abs must be enabled for Point In Time Recovery")
+	if storageTypes != 1 {
+		return fmt.Errorf("exactly one of s3, abs, gcs, persistentVolumeClaim or volume must be enabled for Point In Time Recovery")
+	}
+	if s.S3 != nil {
+		if err := s.S3.Validate(); err != nil {
+			return fmt.Errorf("invalid 's3': %v", err)
+		}
+	}
+	if s.AzureBlob != nil {
+		if err := s.AzureBlob.Validate(); err != nil {
+			return fmt.Errorf("invalid 'azureBlob': %v", err)
+		}
 	}
 
 	return nil
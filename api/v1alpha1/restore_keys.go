@@ -12,3 +12,25 @@ func (r *Restore) StagingPVCKey() types.NamespacedName {
 		Namespace: r.Namespace,
 	}
 }
+
+// PITRJobKey defines the key for the PITR job used to replay the binary logs after restoring the backup.
+func (r *Restore) PITRJobKey() types.NamespacedName {
+	return types.NamespacedName{
+		Name:      fmt.Sprintf("%s-pitr", r.Name),
+		Namespace: r.Namespace,
+	}
+}
+
+func (r *Restore) RoleKey() types.NamespacedName {
+	return types.NamespacedName{
+		Name:      fmt.Sprintf("%s-role", r.Spec.ServiceAccountKey(r.ObjectMeta).Name),
+		Namespace: r.Namespace,
+	}
+}
+
+func (r *Restore) RoleBindingKey() types.NamespacedName {
+	return types.NamespacedName{
+		Name:      fmt.Sprintf("%s-rolebinding", r.Spec.ServiceAccountKey(r.ObjectMeta).Name),
+		Namespace: r.Namespace,
+	}
+}
@@ -0,0 +1,93 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// RestorePlanBinlog is a binary log to be replayed on top of the backup.
+type RestorePlanBinlog struct {
+	// Name is the path of the binary log in the storage.
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	Name string `json:"name"`
+	// FirstTime is the time of the first event of the binary log.
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	FirstTime metav1.Time `json:"firstTime"`
+	// LastTime is the time of the last event of the binary log.
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	LastTime metav1.Time `json:"lastTime"`
+	// FirstGtid is the first GTID of the binary log.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	FirstGtid string `json:"firstGtid,omitempty"`
+	// LastGtid is the last GTID of the binary log.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	LastGtid string `json:"lastGtid,omitempty"`
+}
+
+// RestorePlanGap is a range of GTIDs missing in the archived binary logs, which cannot be replayed.
+type RestorePlanGap struct {
+	// LastGtid is the last GTID available before the gap.
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	LastGtid string `json:"lastGtid"`
+	// NextGtid is the first GTID available after the gap.
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	NextGtid string `json:"nextGtid"`
+	// StartTime is the time of the last event available before the gap.
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	StartTime metav1.Time `json:"startTime"`
+	// EndTime is the time of the first event available after the gap.
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	EndTime metav1.Time `json:"endTime"`
+}
+
+// RestorePlan describes the backup and the binary logs that a restoration would use. It is computed in dry-run mode,
+// without restoring any data.
+type RestorePlan struct {
+	// TargetRecoveryTime is the point in time recovery objective that the plan has been computed for.
+	// When TargetRecoveryGtid is set, it is the time when the plan was computed.
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	TargetRecoveryTime metav1.Time `json:"targetRecoveryTime"`
+	// TargetRecoveryGtid is the last GTID to be replayed that the plan has been computed for.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	TargetRecoveryGtid string `json:"targetRecoveryGtid,omitempty"`
+	// Backup is the backup closest to, but not after, the target recovery time.
+	// It is the name of the VolumeSnapshot when bootstrapping from VolumeSnapshots.
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	Backup string `json:"backup"`
+	// BackupTime is the time when the backup was taken.
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	BackupTime metav1.Time `json:"backupTime"`
+	// BackupChain are the backups to be applied in order to restore an incremental physical backup,
+	// starting with the full backup and ending with the backup itself.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	BackupChain []string `json:"backupChain,omitempty"`
+	// BackupGtid is the GTID position of the backup, as recorded in its manifest.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	BackupGtid string `json:"backupGtid,omitempty"`
+	// Binlogs are the binary logs to be replayed on top of the backup up to the target recovery time, in order.
+	// Only computed when bootstrapping from a PointInTimeRecovery.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	Binlogs []RestorePlanBinlog `json:"binlogs,omitempty"`
+	// LastRecoverableTime is the latest time that the restoration would reach by replaying the binary logs.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	LastRecoverableTime *metav1.Time `json:"lastRecoverableTime,omitempty"`
+	// Gaps are the GTID gaps in the archived binary logs between the backup and the target recovery time.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	Gaps []RestorePlanGap `json:"gaps,omitempty"`
+	// StrictModeError is the reason why the restoration would fail when strict mode is enabled in the PointInTimeRecovery.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	StrictModeError string `json:"strictModeError,omitempty"`
+	// BinlogsUnavailableReason is the reason why the binary logs could not be added to the plan,
+	// for instance, when they are stored in a volume that is not accessible by the operator.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	BinlogsUnavailableReason string `json:"binlogsUnavailableReason,omitempty"`
+}
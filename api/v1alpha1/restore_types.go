@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"time"
 
+	mariadbrepl "github.com/mariadb-operator/mariadb-operator/v26/pkg/replication"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -13,7 +14,7 @@ import (
 
 // RestoreSource defines a source for restoring a logical backup.
 type RestoreSource struct {
-	// BackupRef is a reference to a Backup object. It has priority over S3 and Volume.
+	// BackupRef is a reference to a Backup object. It has priority over S3, GCS and Volume.
 	// +optional
 	// +operator-sdk:csv:customresourcedefinitions:type=spec
 	BackupRef *LocalObjectReference `json:"backupRef,omitempty" webhook:"inmutableinit"`
@@ -21,28 +22,64 @@ type RestoreSource struct {
 	// +optional
 	// +operator-sdk:csv:customresourcedefinitions:type=spec
 	S3 *S3 `json:"s3,omitempty" webhook:"inmutableinit"`
+	// GCS defines the configuration to restore backups from Google Cloud Storage. It has priority over Volume.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	GCS *GCS `json:"gcs,omitempty" webhook:"inmutableinit"`
 	// Volume is a Kubernetes Volume object that contains a backup.
 	// +optional
 	// +operator-sdk:csv:customresourcedefinitions:type=spec
 	Volume *StorageVolumeSource `json:"volume,omitempty"`
+	// Encryption defines the client-side encryption configuration used to decrypt the backups.
+	// It is inferred from the Backup when BackupRef is provided.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:advanced"}
+	Encryption *Encryption `json:"encryption,omitempty" webhook:"inmutableinit"`
 	// TargetRecoveryTime is a RFC3339 (1970-01-01T00:00:00Z) date and time that defines the point in time recovery objective.
 	// It is used to determine the closest restoration source in time.
 	// +optional
 	// +operator-sdk:csv:customresourcedefinitions:type=spec
 	TargetRecoveryTime *metav1.Time `json:"targetRecoveryTime,omitempty" webhook:"inmutable"`
+	// TargetRecoveryGtid is a GTID (0-10-42) that defines the point in time recovery objective, as an alternative to TargetRecoveryTime.
+	// The binary logs are replayed up to, and including, the event with this GTID.
+	// It is used to determine the closest restoration source, which must not be after the GTID.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	TargetRecoveryGtid *mariadbrepl.Gtid `json:"targetRecoveryGtid,omitempty" webhook:"inmutable"`
+	// StopBeforeTargetRecoveryGtid stops the recovery right before the event with TargetRecoveryGtid, which is not replayed.
+	// It is useful to recover right before an accidental statement, i.e. DROP TABLE.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:booleanSwitch"}
+	StopBeforeTargetRecoveryGtid bool `json:"stopBeforeTargetRecoveryGtid,omitempty" webhook:"inmutable"`
 	// StagingStorage defines the temporary storage used to keep external backups (i.e. S3) while they are being processed.
 	// It defaults to an emptyDir volume, meaning that the backups will be temporarily stored in the node where the Restore Job is scheduled.
 	// +optional
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:booleanSwitch","urn:alm:descriptor:com.tectonic.ui:advanced"}
 	StagingStorage *StagingStorage `json:"stagingStorage,omitempty" webhook:"inmutable"`
+	// SecondaryStorages are used as a fallback when the backups cannot be listed from the primary storage, in the order they are defined.
+	// They are inferred from the Backup when BackupRef is provided.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:advanced"}
+	SecondaryStorages []SecondaryStorage `json:"secondaryStorages,omitempty" webhook:"inmutableinit"`
 }
 
 func (r *RestoreSource) Validate() error {
-	if r.BackupRef == nil && r.S3 == nil && r.Volume == nil {
+	if r.BackupRef == nil && r.S3 == nil && r.GCS == nil && r.Volume == nil {
 		return errors.New("unable to determine restore source")
 	}
-	if r.S3 == nil && r.StagingStorage != nil {
-		return errors.New("'spec.stagingStorage' may only be specified when 'spec.s3' is set")
+	if r.S3 == nil && r.GCS == nil && r.StagingStorage != nil {
+		return errors.New("'spec.stagingStorage' may only be specified when 'spec.s3' or 'spec.gcs' are set")
+	}
+	if r.Encryption != nil {
+		if err := r.Encryption.Validate(); err != nil {
+			return fmt.Errorf("invalid 'spec.encryption': %v", err)
+		}
+	}
+	if err := ValidateSecondaryStorages(r.SecondaryStorages); err != nil {
+		return fmt.Errorf("invalid 'spec.secondaryStorages': %v", err)
+	}
+	if err := validateTargetRecovery(r.TargetRecoveryTime, r.TargetRecoveryGtid, r.StopBeforeTargetRecoveryGtid); err != nil {
+		return err
 	}
 	return nil
 }
@@ -52,7 +89,7 @@ func (r *RestoreSource) IsDefaulted() bool {
 }
 
 func (r *RestoreSource) SetDefaults(restore *Restore) {
-	if r.S3 != nil {
+	if r.S3 != nil || r.GCS != nil {
 		stagingStorage := ptr.Deref(r.StagingStorage, StagingStorage{})
 		r.Volume = ptr.To(stagingStorage.VolumeOrEmptyDir(restore.StagingPVCKey()))
 	}
@@ -65,6 +102,13 @@ func (r *RestoreSource) SetDefaultsWithBackup(backup *Backup) error {
 	}
 	r.Volume = &volume
 	r.S3 = backup.Spec.Storage.S3
+	r.GCS = backup.Spec.Storage.GCS
+	if r.Encryption == nil {
+		r.Encryption = backup.Spec.Encryption
+	}
+	if r.SecondaryStorages == nil {
+		r.SecondaryStorages = backup.Spec.SecondaryStorages
+	}
 	return nil
 }
 
@@ -75,6 +119,37 @@ func (r *RestoreSource) TargetRecoveryTimeOrDefault() time.Time {
 	return time.Now()
 }
 
+// LastRecoveryGtid returns the last GTID to be replayed in order to reach the TargetRecoveryGtid, if any.
+func (r *RestoreSource) LastRecoveryGtid() *mariadbrepl.Gtid {
+	return lastRecoveryGtid(r.TargetRecoveryGtid, r.StopBeforeTargetRecoveryGtid)
+}
+
+func validateTargetRecovery(targetRecoveryTime *metav1.Time, targetRecoveryGtid *mariadbrepl.Gtid, stopBefore bool) error {
+	if targetRecoveryTime != nil && targetRecoveryGtid != nil {
+		return errors.New("'targetRecoveryTime' and 'targetRecoveryGtid' are mutually exclusive")
+	}
+	if stopBefore {
+		if targetRecoveryGtid == nil {
+			return errors.New("'stopBeforeTargetRecoveryGtid' requires 'targetRecoveryGtid' to be set")
+		}
+		if targetRecoveryGtid.SequenceID == 0 {
+			return errors.New("'stopBeforeTargetRecoveryGtid' requires a 'targetRecoveryGtid' sequence greater than 0")
+		}
+	}
+	return nil
+}
+
+func lastRecoveryGtid(targetRecoveryGtid *mariadbrepl.Gtid, stopBefore bool) *mariadbrepl.Gtid {
+	if targetRecoveryGtid == nil {
+		return nil
+	}
+	gtid := *targetRecoveryGtid
+	if stopBefore {
+		gtid.SequenceID--
+	}
+	return &gtid
+}
+
 // RestoreSpec defines the desired state of restore
 type RestoreSpec struct {
 	// JobContainerTemplate defines templates to configure Container objects.
@@ -95,6 +170,24 @@ type RestoreSpec struct {
 	// +optional
 	// +operator-sdk:csv:customresourcedefinitions:type=spec
 	Database string `json:"database,omitempty"`
+	// PointInTimeRecoveryRef is a reference to the PointInTimeRecovery object where the binary logs of the MariaDB are archived.
+	// When set, the binary logs are replayed after restoring the backup, starting from the GTID recorded in the backup manifest
+	// up to TargetRecoveryTime or TargetRecoveryGtid. All the databases of the backup must be restored.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	PointInTimeRecoveryRef *LocalObjectReference `json:"pointInTimeRecoveryRef,omitempty" webhook:"inmutable"`
+	// Tables is a list of table patterns to be restored, in the 'database.table' format, where '*' matches any sequence of characters.
+	// Only the definitions, data and triggers of the matching tables are restored, the rest of the backup is skipped.
+	// IMPORTANT: The databases of the tables must previously exist.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Tables []string `json:"tables,omitempty"`
+	// Parallelism is the number of data files loaded concurrently when restoring a parallel logical backup. It defaults to 4.
+	// It has no effect when restoring regular logical backups, which are loaded sequentially.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:number","urn:alm:descriptor:com.tectonic.ui:advanced"}
+	Parallelism *int32 `json:"parallelism,omitempty"`
 	// LogLevel to be used n the Backup Job. It defaults to 'info'.
 	// +optional
 	// +kubebuilder:default=info
@@ -116,6 +209,30 @@ type RestoreSpec struct {
 	// +optional
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:advanced"}
 	InheritMetadata *Metadata `json:"inheritMetadata,omitempty"`
+	// DryRun computes the restoration plan, including the backup and the binary logs to be used, and reports it in the status
+	// without restoring any data. It is only supported for backups stored in S3 or GCS.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:booleanSwitch"}
+	DryRun bool `json:"dryRun,omitempty" webhook:"inmutable"`
+}
+
+func (r *RestoreSpec) Validate() error {
+	if err := r.RestoreSource.Validate(); err != nil {
+		return err
+	}
+	if r.DryRun && r.BackupRef == nil && r.S3 == nil && r.GCS == nil {
+		return errors.New("'dryRun' is only supported with 'backupRef', 's3' or 'gcs' sources")
+	}
+	for _, table := range r.Tables {
+		if err := validateTablePattern(table); err != nil {
+			return fmt.Errorf("invalid 'spec.tables': %v", err)
+		}
+	}
+	if r.PointInTimeRecoveryRef != nil && (r.Database != "" || len(r.Tables) > 0) {
+		return errors.New("'database' and 'tables' may not be set when 'pointInTimeRecoveryRef' is set, " +
+			"as the binary logs are replayed for all databases")
+	}
+	return nil
 }
 
 // RestoreStatus defines the observed state of restore
@@ -124,6 +241,19 @@ type RestoreStatus struct {
 	// +optional
 	// +operator-sdk:csv:customresourcedefinitions:type=status,xDescriptors={"urn:alm:descriptor:io.kubernetes.conditions"}
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
+	// Progress of the running restoration.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	Progress *BackupProgress `json:"progress,omitempty"`
+	// Plan is the restoration plan computed in dry-run mode.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	Plan *RestorePlan `json:"plan,omitempty"`
+	// RestoredGtid is the GTID of the restored backup, as recorded in its manifest.
+	// It is the starting point to replay the archived binary logs after a logical restoration.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	RestoredGtid string `json:"restoredGtid,omitempty"`
 }
 
 func (r *RestoreStatus) SetCondition(condition metav1.Condition) {
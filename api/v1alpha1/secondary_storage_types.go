@@ -0,0 +1,136 @@
+package v1alpha1
+
+import (
+	"errors"
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// SecondaryStorage defines an additional storage where backups and binary logs are replicated after being uploaded to the primary storage.
+// It is also used as a fallback source when the primary storage is unreachable during a restoration.
+type SecondaryStorage struct {
+	// Name identifies the secondary storage. It must be unique within the secondary storages of the object.
+	// +kubebuilder:validation:Required
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Name string `json:"name"`
+	// S3 defines the configuration to replicate backups to a S3 compatible storage.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	S3 *S3 `json:"s3,omitempty"`
+	// AzureBlob defines the configuration to replicate backups to an Azure Blob compatible storage.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	AzureBlob *AzureBlob `json:"azureBlob,omitempty"`
+	// PersistentVolumeClaim is a reference to an existing PVC where backups will be replicated.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	PersistentVolumeClaim *PersistentVolumeClaimVolumeSource `json:"persistentVolumeClaim,omitempty"`
+	// MaxRetention defines the retention policy for the backups replicated to this storage.
+	// If not provided, the retention of the primary storage is used.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	MaxRetention *metav1.Duration `json:"maxRetention,omitempty"`
+	// Retention defines a grandfather-father-son retention policy for the backups replicated to this storage.
+	// When specified, it takes precedence over MaxRetention. If neither are provided, the retention of the primary storage is used.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Retention *RetentionPolicy `json:"retention,omitempty"`
+}
+
+// Validate determines whether a SecondaryStorage is valid.
+func (s *SecondaryStorage) Validate() error {
+	if s.Name == "" {
+		return errors.New("'name' must be set")
+	}
+	storageTypes := 0
+	for _, enabled := range []bool{s.S3 != nil, s.AzureBlob != nil, s.PersistentVolumeClaim != nil} {
+		if enabled {
+			storageTypes++
+		}
+	}
+	if storageTypes != 1 {
+		return errors.New("exactly one storage type should be provided")
+	}
+	if s.PersistentVolumeClaim != nil && s.PersistentVolumeClaim.ClaimName == "" {
+		return errors.New("'persistentVolumeClaim.claimName' must be set")
+	}
+	if s.S3 != nil {
+		if err := s.S3.Validate(); err != nil {
+			return fmt.Errorf("invalid 's3': %v", err)
+		}
+	}
+	if s.AzureBlob != nil {
+		if err := s.AzureBlob.Validate(); err != nil {
+			return fmt.Errorf("invalid 'azureBlob': %v", err)
+		}
+	}
+	if s.Retention != nil {
+		if err := s.Retention.Validate(); err != nil {
+			return fmt.Errorf("invalid 'retention': %v", err)
+		}
+	}
+	return nil
+}
+
+// HasRetention determines whether the SecondaryStorage overrides the retention of the primary storage.
+func (s *SecondaryStorage) HasRetention() bool {
+	return s.MaxRetention != nil || s.Retention != nil
+}
+
+// ValidateSecondaryStorages determines whether a list of SecondaryStorages is valid.
+func ValidateSecondaryStorages(storages []SecondaryStorage) error {
+	names := make(map[string]struct{}, len(storages))
+	for i, storage := range storages {
+		if err := storage.Validate(); err != nil {
+			return fmt.Errorf("invalid secondary storage at index %d: %v", i, err)
+		}
+		if _, ok := names[storage.Name]; ok {
+			return fmt.Errorf("secondary storage '%s' is duplicated", storage.Name)
+		}
+		names[storage.Name] = struct{}{}
+	}
+	return nil
+}
+
+// SecondaryStorageStatus represents the replication status of a SecondaryStorage.
+type SecondaryStorageStatus struct {
+	// Name of the secondary storage.
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	Name string `json:"name"`
+	// Succeeded indicates whether the last replication to the secondary storage succeeded.
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	Succeeded bool `json:"succeeded"`
+	// FileName is the name of the last file replicated to the secondary storage.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	FileName string `json:"fileName,omitempty"`
+	// LastReplicationTime is the last time that a replication to the secondary storage was attempted.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	LastReplicationTime *metav1.Time `json:"lastReplicationTime,omitempty"`
+	// LastSuccessfulReplicationTime is the last time that a replication to the secondary storage succeeded.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	LastSuccessfulReplicationTime *metav1.Time `json:"lastSuccessfulReplicationTime,omitempty"`
+	// Message contains the error of the last replication, if any.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	Message string `json:"message,omitempty"`
+}
+
+// SetSecondaryStorageStatus adds or updates the status of a SecondaryStorage, identified by its name.
+// The time of the last successful replication is preserved when the replication fails.
+func SetSecondaryStorageStatus(statuses *[]SecondaryStorageStatus, status SecondaryStorageStatus) {
+	for i, s := range *statuses {
+		if s.Name != status.Name {
+			continue
+		}
+		if !status.Succeeded && status.LastSuccessfulReplicationTime == nil {
+			status.LastSuccessfulReplicationTime = s.LastSuccessfulReplicationTime
+		}
+		(*statuses)[i] = status
+		return
+	}
+	*statuses = append(*statuses, status)
+}
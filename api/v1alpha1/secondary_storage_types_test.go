@@ -0,0 +1,138 @@
+package v1alpha1
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("SecondaryStorage types", func() {
+	Context("When validating SecondaryStorages", func() {
+		DescribeTable(
+			"Should validate",
+			func(storages []SecondaryStorage, wantErr bool) {
+				err := ValidateSecondaryStorages(storages)
+				if wantErr {
+					Expect(err).To(HaveOccurred())
+				} else {
+					Expect(err).ToNot(HaveOccurred())
+				}
+			},
+			Entry(
+				"Empty",
+				nil,
+				false,
+			),
+			Entry(
+				"Valid",
+				[]SecondaryStorage{
+					{
+						Name: "s3",
+						S3: &S3{
+							Bucket:   "backups",
+							Endpoint: "s3.amazonaws.com",
+						},
+					},
+					{
+						Name: "pvc",
+						PersistentVolumeClaim: &PersistentVolumeClaimVolumeSource{
+							ClaimName: "backups",
+						},
+						Retention: &RetentionPolicy{
+							KeepDaily: 7,
+						},
+					},
+				},
+				false,
+			),
+			Entry(
+				"No name",
+				[]SecondaryStorage{
+					{
+						S3: &S3{},
+					},
+				},
+				true,
+			),
+			Entry(
+				"No storage type",
+				[]SecondaryStorage{
+					{
+						Name: "none",
+					},
+				},
+				true,
+			),
+			Entry(
+				"Multiple storage types",
+				[]SecondaryStorage{
+					{
+						Name:      "multiple",
+						S3:        &S3{},
+						AzureBlob: &AzureBlob{},
+					},
+				},
+				true,
+			),
+			Entry(
+				"PVC without claim name",
+				[]SecondaryStorage{
+					{
+						Name:                  "pvc",
+						PersistentVolumeClaim: &PersistentVolumeClaimVolumeSource{},
+					},
+				},
+				true,
+			),
+			Entry(
+				"Duplicated name",
+				[]SecondaryStorage{
+					{
+						Name: "s3",
+						S3:   &S3{},
+					},
+					{
+						Name:      "s3",
+						AzureBlob: &AzureBlob{},
+					},
+				},
+				true,
+			),
+		)
+	})
+
+	Context("When setting SecondaryStorage status", func() {
+		It("Should preserve the last successful replication", func() {
+			succeededAt := metav1.NewTime(time.Now().Add(-time.Hour))
+			failedAt := metav1.NewTime(time.Now())
+			var statuses []SecondaryStorageStatus
+
+			SetSecondaryStorageStatus(&statuses, SecondaryStorageStatus{
+				Name:                          "s3",
+				Succeeded:                     true,
+				LastReplicationTime:           &succeededAt,
+				LastSuccessfulReplicationTime: &succeededAt,
+			})
+			SetSecondaryStorageStatus(&statuses, SecondaryStorageStatus{
+				Name:                "s3",
+				Succeeded:           false,
+				LastReplicationTime: &failedAt,
+				Message:             "unreachable",
+			})
+			SetSecondaryStorageStatus(&statuses, SecondaryStorageStatus{
+				Name:                "pvc",
+				Succeeded:           true,
+				LastReplicationTime: &failedAt,
+			})
+
+			Expect(statuses).To(HaveLen(2))
+			Expect(statuses[0].Succeeded).To(BeFalse())
+			Expect(statuses[0].Message).To(Equal("unreachable"))
+			Expect(statuses[0].LastReplicationTime).To(Equal(&failedAt))
+			Expect(statuses[0].LastSuccessfulReplicationTime).To(Equal(&succeededAt))
+			Expect(statuses[1].Name).To(Equal("pvc"))
+		})
+	})
+})
@@ -129,6 +129,11 @@ func (in *AzureBlob) DeepCopyInto(out *AzureBlob) {
 		*out = new(TLSConfig)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.ObjectLock != nil {
+		in, out := &in.ObjectLock, &out.ObjectLock
+		*out = new(ObjectLock)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AzureBlob.
@@ -168,6 +173,139 @@ func (in *Backup) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupCatalog) DeepCopyInto(out *BackupCatalog) {
+	*out = *in
+	if in.Backups != nil {
+		in, out := &in.Backups, &out.Backups
+		*out = make([]BackupCatalogEntry, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	in.LastUpdateTime.DeepCopyInto(&out.LastUpdateTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupCatalog.
+func (in *BackupCatalog) DeepCopy() *BackupCatalog {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupCatalog)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupCatalogEntry) DeepCopyInto(out *BackupCatalogEntry) {
+	*out = *in
+	in.Time.DeepCopyInto(&out.Time)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupCatalogEntry.
+func (in *BackupCatalogEntry) DeepCopy() *BackupCatalogEntry {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupCatalogEntry)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupHook) DeepCopyInto(out *BackupHook) {
+	*out = *in
+	if in.SQL != nil {
+		in, out := &in.SQL, &out.SQL
+		*out = new(string)
+		**out = **in
+	}
+	if in.PodRole != nil {
+		in, out := &in.PodRole, &out.PodRole
+		*out = new(BackupHookPodRole)
+		**out = **in
+	}
+	if in.Exec != nil {
+		in, out := &in.Exec, &out.Exec
+		*out = new(BackupHookExec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.OnError != nil {
+		in, out := &in.OnError, &out.OnError
+		*out = new(BackupHookOnError)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupHook.
+func (in *BackupHook) DeepCopy() *BackupHook {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupHook)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupHookExec) DeepCopyInto(out *BackupHookExec) {
+	*out = *in
+	if in.Command != nil {
+		in, out := &in.Command, &out.Command
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Container != nil {
+		in, out := &in.Container, &out.Container
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupHookExec.
+func (in *BackupHookExec) DeepCopy() *BackupHookExec {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupHookExec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupHooks) DeepCopyInto(out *BackupHooks) {
+	*out = *in
+	if in.Pre != nil {
+		in, out := &in.Pre, &out.Pre
+		*out = make([]BackupHook, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Post != nil {
+		in, out := &in.Post, &out.Post
+		*out = make([]BackupHook, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupHooks.
+func (in *BackupHooks) DeepCopy() *BackupHooks {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupHooks)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BackupList) DeepCopyInto(out *BackupList) {
 	*out = *in
@@ -200,6 +338,32 @@ func (in *BackupList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupProgress) DeepCopyInto(out *BackupProgress) {
+	*out = *in
+	if in.TotalBytes != nil {
+		in, out := &in.TotalBytes, &out.TotalBytes
+		*out = new(int64)
+		**out = **in
+	}
+	in.StartTime.DeepCopyInto(&out.StartTime)
+	in.LastUpdateTime.DeepCopyInto(&out.LastUpdateTime)
+	if in.EstimatedCompletionTime != nil {
+		in, out := &in.EstimatedCompletionTime, &out.EstimatedCompletionTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupProgress.
+func (in *BackupProgress) DeepCopy() *BackupProgress {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupProgress)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BackupSpec) DeepCopyInto(out *BackupSpec) {
 	*out = *in
@@ -207,6 +371,16 @@ func (in *BackupSpec) DeepCopyInto(out *BackupSpec) {
 	in.JobPodTemplate.DeepCopyInto(&out.JobPodTemplate)
 	in.CronJobTemplate.DeepCopyInto(&out.CronJobTemplate)
 	out.MariaDBRef = in.MariaDBRef
+	if in.CompressionLevel != nil {
+		in, out := &in.CompressionLevel, &out.CompressionLevel
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Encryption != nil {
+		in, out := &in.Encryption, &out.Encryption
+		*out = new(Encryption)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.StagingStorage != nil {
 		in, out := &in.StagingStorage, &out.StagingStorage
 		*out = new(StagingStorage)
@@ -219,11 +393,43 @@ func (in *BackupSpec) DeepCopyInto(out *BackupSpec) {
 		**out = **in
 	}
 	out.MaxRetention = in.MaxRetention
+	if in.Retention != nil {
+		in, out := &in.Retention, &out.Retention
+		*out = new(RetentionPolicy)
+		**out = **in
+	}
+	if in.SecondaryStorages != nil {
+		in, out := &in.SecondaryStorages, &out.SecondaryStorages
+		*out = make([]SecondaryStorage, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Throttling != nil {
+		in, out := &in.Throttling, &out.Throttling
+		*out = new(Throttling)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Hooks != nil {
+		in, out := &in.Hooks, &out.Hooks
+		*out = new(BackupHooks)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Databases != nil {
 		in, out := &in.Databases, &out.Databases
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.Tables != nil {
+		in, out := &in.Tables, &out.Tables
+		*out = new(TableSelection)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Parallel != nil {
+		in, out := &in.Parallel, &out.Parallel
+		*out = new(ParallelBackup)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.IgnoreGlobalPriv != nil {
 		in, out := &in.IgnoreGlobalPriv, &out.IgnoreGlobalPriv
 		*out = new(bool)
@@ -256,6 +462,23 @@ func (in *BackupStatus) DeepCopyInto(out *BackupStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.SecondaryStorages != nil {
+		in, out := &in.SecondaryStorages, &out.SecondaryStorages
+		*out = make([]SecondaryStorageStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Progress != nil {
+		in, out := &in.Progress, &out.Progress
+		*out = new(BackupProgress)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Catalog != nil {
+		in, out := &in.Catalog, &out.Catalog
+		*out = new(BackupCatalog)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupStatus.
@@ -276,6 +499,11 @@ func (in *BackupStorage) DeepCopyInto(out *BackupStorage) {
 		*out = new(S3)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.GCS != nil {
+		in, out := &in.GCS, &out.GCS
+		*out = new(GCS)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.PersistentVolumeClaim != nil {
 		in, out := &in.PersistentVolumeClaim, &out.PersistentVolumeClaim
 		*out = new(PersistentVolumeClaimSpec)
@@ -298,6 +526,260 @@ func (in *BackupStorage) DeepCopy() *BackupStorage {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupVerification) DeepCopyInto(out *BackupVerification) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupVerification.
+func (in *BackupVerification) DeepCopy() *BackupVerification {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupVerification)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *BackupVerification) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupVerificationAssertion) DeepCopyInto(out *BackupVerificationAssertion) {
+	*out = *in
+	if in.Database != nil {
+		in, out := &in.Database, &out.Database
+		*out = new(string)
+		**out = **in
+	}
+	if in.Expected != nil {
+		in, out := &in.Expected, &out.Expected
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupVerificationAssertion.
+func (in *BackupVerificationAssertion) DeepCopy() *BackupVerificationAssertion {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupVerificationAssertion)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupVerificationAssertionResult) DeepCopyInto(out *BackupVerificationAssertionResult) {
+	*out = *in
+	if in.Value != nil {
+		in, out := &in.Value, &out.Value
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupVerificationAssertionResult.
+func (in *BackupVerificationAssertionResult) DeepCopy() *BackupVerificationAssertionResult {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupVerificationAssertionResult)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupVerificationList) DeepCopyInto(out *BackupVerificationList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]BackupVerification, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupVerificationList.
+func (in *BackupVerificationList) DeepCopy() *BackupVerificationList {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupVerificationList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *BackupVerificationList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupVerificationMariaDB) DeepCopyInto(out *BackupVerificationMariaDB) {
+	*out = *in
+	if in.Storage != nil {
+		in, out := &in.Storage, &out.Storage
+		*out = new(Storage)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RestoreJob != nil {
+		in, out := &in.RestoreJob, &out.RestoreJob
+		*out = new(Job)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupVerificationMariaDB.
+func (in *BackupVerificationMariaDB) DeepCopy() *BackupVerificationMariaDB {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupVerificationMariaDB)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupVerificationResult) DeepCopyInto(out *BackupVerificationResult) {
+	*out = *in
+	if in.TargetRecoveryTime != nil {
+		in, out := &in.TargetRecoveryTime, &out.TargetRecoveryTime
+		*out = (*in).DeepCopy()
+	}
+	in.StartTime.DeepCopyInto(&out.StartTime)
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Duration != nil {
+		in, out := &in.Duration, &out.Duration
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.Assertions != nil {
+		in, out := &in.Assertions, &out.Assertions
+		*out = make([]BackupVerificationAssertionResult, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupVerificationResult.
+func (in *BackupVerificationResult) DeepCopy() *BackupVerificationResult {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupVerificationResult)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupVerificationSpec) DeepCopyInto(out *BackupVerificationSpec) {
+	*out = *in
+	out.BackupRef = in.BackupRef
+	if in.PointInTimeRecoveryRef != nil {
+		in, out := &in.PointInTimeRecoveryRef, &out.PointInTimeRecoveryRef
+		*out = new(LocalObjectReference)
+		**out = **in
+	}
+	if in.TargetRecoveryTime != nil {
+		in, out := &in.TargetRecoveryTime, &out.TargetRecoveryTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Schedule != nil {
+		in, out := &in.Schedule, &out.Schedule
+		*out = new(Schedule)
+		**out = **in
+	}
+	in.MariaDB.DeepCopyInto(&out.MariaDB)
+	if in.Assertions != nil {
+		in, out := &in.Assertions, &out.Assertions
+		*out = make([]BackupVerificationAssertion, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.InheritMetadata != nil {
+		in, out := &in.InheritMetadata, &out.InheritMetadata
+		*out = new(Metadata)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupVerificationSpec.
+func (in *BackupVerificationSpec) DeepCopy() *BackupVerificationSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupVerificationSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupVerificationStatus) DeepCopyInto(out *BackupVerificationStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.LastScheduleTime != nil {
+		in, out := &in.LastScheduleTime, &out.LastScheduleTime
+		*out = (*in).DeepCopy()
+	}
+	if in.CurrentVerification != nil {
+		in, out := &in.CurrentVerification, &out.CurrentVerification
+		*out = new(BackupVerificationResult)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.LastVerification != nil {
+		in, out := &in.LastVerification, &out.LastVerification
+		*out = new(BackupVerificationResult)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupVerificationStatus.
+func (in *BackupVerificationStatus) DeepCopy() *BackupVerificationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupVerificationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BasicAuth) DeepCopyInto(out *BasicAuth) {
 	*out = *in
@@ -309,7 +791,86 @@ func (in *BasicAuth) DeepCopy() *BasicAuth {
 	if in == nil {
 		return nil
 	}
-	out := new(BasicAuth)
+	out := new(BasicAuth)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BinlogCDC) DeepCopyInto(out *BinlogCDC) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BinlogCDC.
+func (in *BinlogCDC) DeepCopy() *BinlogCDC {
+	if in == nil {
+		return nil
+	}
+	out := new(BinlogCDC)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BinlogCDCStatus) DeepCopyInto(out *BinlogCDCStatus) {
+	*out = *in
+	if in.LastExportedGtid != nil {
+		in, out := &in.LastExportedGtid, &out.LastExportedGtid
+		*out = new(replication.Gtid)
+		**out = **in
+	}
+	if in.LastExportTime != nil {
+		in, out := &in.LastExportTime, &out.LastExportTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BinlogCDCStatus.
+func (in *BinlogCDCStatus) DeepCopy() *BinlogCDCStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(BinlogCDCStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BinlogRetention) DeepCopyInto(out *BinlogRetention) {
+	*out = *in
+	if in.SafetyMargin != nil {
+		in, out := &in.SafetyMargin, &out.SafetyMargin
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BinlogRetention.
+func (in *BinlogRetention) DeepCopy() *BinlogRetention {
+	if in == nil {
+		return nil
+	}
+	out := new(BinlogRetention)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BinlogStreaming) DeepCopyInto(out *BinlogStreaming) {
+	*out = *in
+	if in.FlushInterval != nil {
+		in, out := &in.FlushInterval, &out.FlushInterval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BinlogStreaming.
+func (in *BinlogStreaming) DeepCopy() *BinlogStreaming {
+	if in == nil {
+		return nil
+	}
+	out := new(BinlogStreaming)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -342,15 +903,42 @@ func (in *BootstrapFrom) DeepCopyInto(out *BootstrapFrom) {
 		*out = new(AzureBlob)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.GCS != nil {
+		in, out := &in.GCS, &out.GCS
+		*out = new(GCS)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Volume != nil {
 		in, out := &in.Volume, &out.Volume
 		*out = new(StorageVolumeSource)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Encryption != nil {
+		in, out := &in.Encryption, &out.Encryption
+		*out = new(Encryption)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SecondaryStorages != nil {
+		in, out := &in.SecondaryStorages, &out.SecondaryStorages
+		*out = make([]SecondaryStorage, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Streaming != nil {
+		in, out := &in.Streaming, &out.Streaming
+		*out = new(PhysicalBackupStreaming)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.TargetRecoveryTime != nil {
 		in, out := &in.TargetRecoveryTime, &out.TargetRecoveryTime
 		*out = (*in).DeepCopy()
 	}
+	if in.TargetRecoveryGtid != nil {
+		in, out := &in.TargetRecoveryGtid, &out.TargetRecoveryGtid
+		*out = new(replication.Gtid)
+		**out = **in
+	}
 	if in.StagingStorage != nil {
 		in, out := &in.StagingStorage, &out.StagingStorage
 		*out = new(StagingStorage)
@@ -937,6 +1525,27 @@ func (in *EmptyDirVolumeSource) DeepCopy() *EmptyDirVolumeSource {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Encryption) DeepCopyInto(out *Encryption) {
+	*out = *in
+	out.KeySecretKeyRef = in.KeySecretKeyRef
+	if in.PreviousKeySecretKeyRefs != nil {
+		in, out := &in.PreviousKeySecretKeyRefs, &out.PreviousKeySecretKeyRefs
+		*out = make([]SecretKeySelector, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Encryption.
+func (in *Encryption) DeepCopy() *Encryption {
+	if in == nil {
+		return nil
+	}
+	out := new(Encryption)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *EnvFromSource) DeepCopyInto(out *EnvFromSource) {
 	*out = *in
@@ -1268,6 +1877,183 @@ func (in *ExternalTLS) DeepCopy() *ExternalTLS {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Flashback) DeepCopyInto(out *Flashback) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Flashback.
+func (in *Flashback) DeepCopy() *Flashback {
+	if in == nil {
+		return nil
+	}
+	out := new(Flashback)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Flashback) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FlashbackList) DeepCopyInto(out *FlashbackList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Flashback, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FlashbackList.
+func (in *FlashbackList) DeepCopy() *FlashbackList {
+	if in == nil {
+		return nil
+	}
+	out := new(FlashbackList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *FlashbackList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FlashbackOutput) DeepCopyInto(out *FlashbackOutput) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FlashbackOutput.
+func (in *FlashbackOutput) DeepCopy() *FlashbackOutput {
+	if in == nil {
+		return nil
+	}
+	out := new(FlashbackOutput)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FlashbackSpec) DeepCopyInto(out *FlashbackSpec) {
+	*out = *in
+	in.JobContainerTemplate.DeepCopyInto(&out.JobContainerTemplate)
+	in.JobPodTemplate.DeepCopyInto(&out.JobPodTemplate)
+	out.MariaDBRef = in.MariaDBRef
+	out.PointInTimeRecoveryRef = in.PointInTimeRecoveryRef
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.EndTime != nil {
+		in, out := &in.EndTime, &out.EndTime
+		*out = (*in).DeepCopy()
+	}
+	if in.StartGtid != nil {
+		in, out := &in.StartGtid, &out.StartGtid
+		*out = new(replication.Gtid)
+		**out = **in
+	}
+	if in.EndGtid != nil {
+		in, out := &in.EndGtid, &out.EndGtid
+		*out = new(replication.Gtid)
+		**out = **in
+	}
+	if in.Database != nil {
+		in, out := &in.Database, &out.Database
+		*out = new(string)
+		**out = **in
+	}
+	if in.Table != nil {
+		in, out := &in.Table, &out.Table
+		*out = new(string)
+		**out = **in
+	}
+	out.Output = in.Output
+	if in.InheritMetadata != nil {
+		in, out := &in.InheritMetadata, &out.InheritMetadata
+		*out = new(Metadata)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FlashbackSpec.
+func (in *FlashbackSpec) DeepCopy() *FlashbackSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(FlashbackSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FlashbackStatus) DeepCopyInto(out *FlashbackStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.GeneratedTime != nil {
+		in, out := &in.GeneratedTime, &out.GeneratedTime
+		*out = (*in).DeepCopy()
+	}
+	if in.AppliedTime != nil {
+		in, out := &in.AppliedTime, &out.AppliedTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FlashbackStatus.
+func (in *FlashbackStatus) DeepCopy() *FlashbackStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(FlashbackStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GCS) DeepCopyInto(out *GCS) {
+	*out = *in
+	if in.ServiceAccountKeySecretKeyRef != nil {
+		in, out := &in.ServiceAccountKeySecretKeyRef, &out.ServiceAccountKeySecretKeyRef
+		*out = new(SecretKeySelector)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCS.
+func (in *GCS) DeepCopy() *GCS {
+	if in == nil {
+		return nil
+	}
+	out := new(GCS)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Galera) DeepCopyInto(out *Galera) {
 	*out = *in
@@ -2230,6 +3016,11 @@ func (in *MariaDBPointInTimeRecoveryStatus) DeepCopyInto(out *MariaDBPointInTime
 		*out = new(bool)
 		**out = **in
 	}
+	if in.ArchivalLag != nil {
+		in, out := &in.ArchivalLag, &out.ArchivalLag
+		*out = new(v1.Duration)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MariaDBPointInTimeRecoveryStatus.
@@ -2478,6 +3269,11 @@ func (in *MariaDBStatus) DeepCopyInto(out *MariaDBStatus) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.RestorePlan != nil {
+		in, out := &in.RestorePlan, &out.RestorePlan
+		*out = new(RestorePlan)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MariaDBStatus.
@@ -3462,6 +4258,22 @@ func (in *ObjectFieldSelector) DeepCopy() *ObjectFieldSelector {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ObjectLock) DeepCopyInto(out *ObjectLock) {
+	*out = *in
+	out.RetainUntil = in.RetainUntil
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectLock.
+func (in *ObjectLock) DeepCopy() *ObjectLock {
+	if in == nil {
+		return nil
+	}
+	out := new(ObjectLock)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ObjectReference) DeepCopyInto(out *ObjectReference) {
 	*out = *in
@@ -3477,6 +4289,26 @@ func (in *ObjectReference) DeepCopy() *ObjectReference {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ParallelBackup) DeepCopyInto(out *ParallelBackup) {
+	*out = *in
+	if in.ChunkSize != nil {
+		in, out := &in.ChunkSize, &out.ChunkSize
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ParallelBackup.
+func (in *ParallelBackup) DeepCopy() *ParallelBackup {
+	if in == nil {
+		return nil
+	}
+	out := new(ParallelBackup)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PasswordPlugin) DeepCopyInto(out *PasswordPlugin) {
 	*out = *in
@@ -3575,6 +4407,70 @@ func (in *PhysicalBackup) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PhysicalBackupChain) DeepCopyInto(out *PhysicalBackupChain) {
+	*out = *in
+	if in.PodIndex != nil {
+		in, out := &in.PodIndex, &out.PodIndex
+		*out = new(int)
+		**out = **in
+	}
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Backups != nil {
+		in, out := &in.Backups, &out.Backups
+		*out = make([]PhysicalBackupChainLink, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PhysicalBackupChain.
+func (in *PhysicalBackupChain) DeepCopy() *PhysicalBackupChain {
+	if in == nil {
+		return nil
+	}
+	out := new(PhysicalBackupChain)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PhysicalBackupChainLink) DeepCopyInto(out *PhysicalBackupChainLink) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PhysicalBackupChainLink.
+func (in *PhysicalBackupChainLink) DeepCopy() *PhysicalBackupChainLink {
+	if in == nil {
+		return nil
+	}
+	out := new(PhysicalBackupChainLink)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PhysicalBackupIncremental) DeepCopyInto(out *PhysicalBackupIncremental) {
+	*out = *in
+	if in.MaxIncrementals != nil {
+		in, out := &in.MaxIncrementals, &out.MaxIncrementals
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PhysicalBackupIncremental.
+func (in *PhysicalBackupIncremental) DeepCopy() *PhysicalBackupIncremental {
+	if in == nil {
+		return nil
+	}
+	out := new(PhysicalBackupIncremental)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PhysicalBackupList) DeepCopyInto(out *PhysicalBackupList) {
 	*out = *in
@@ -3690,18 +4586,60 @@ func (in *PhysicalBackupSpec) DeepCopyInto(out *PhysicalBackupSpec) {
 		*out = new(PhysicalBackupTarget)
 		**out = **in
 	}
+	if in.CompressionLevel != nil {
+		in, out := &in.CompressionLevel, &out.CompressionLevel
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Encryption != nil {
+		in, out := &in.Encryption, &out.Encryption
+		*out = new(Encryption)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.StagingStorage != nil {
 		in, out := &in.StagingStorage, &out.StagingStorage
 		*out = new(StagingStorage)
 		(*in).DeepCopyInto(*out)
 	}
-	in.Storage.DeepCopyInto(&out.Storage)
-	if in.Schedule != nil {
-		in, out := &in.Schedule, &out.Schedule
-		*out = new(PhysicalBackupSchedule)
+	in.Storage.DeepCopyInto(&out.Storage)
+	if in.Schedule != nil {
+		in, out := &in.Schedule, &out.Schedule
+		*out = new(PhysicalBackupSchedule)
+		(*in).DeepCopyInto(*out)
+	}
+	out.MaxRetention = in.MaxRetention
+	if in.Retention != nil {
+		in, out := &in.Retention, &out.Retention
+		*out = new(RetentionPolicy)
+		**out = **in
+	}
+	if in.SecondaryStorages != nil {
+		in, out := &in.SecondaryStorages, &out.SecondaryStorages
+		*out = make([]SecondaryStorage, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Streaming != nil {
+		in, out := &in.Streaming, &out.Streaming
+		*out = new(PhysicalBackupStreaming)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Throttling != nil {
+		in, out := &in.Throttling, &out.Throttling
+		*out = new(Throttling)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Hooks != nil {
+		in, out := &in.Hooks, &out.Hooks
+		*out = new(BackupHooks)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Incremental != nil {
+		in, out := &in.Incremental, &out.Incremental
+		*out = new(PhysicalBackupIncremental)
 		(*in).DeepCopyInto(*out)
 	}
-	out.MaxRetention = in.MaxRetention
 	if in.Timeout != nil {
 		in, out := &in.Timeout, &out.Timeout
 		*out = new(v1.Duration)
@@ -3766,6 +4704,28 @@ func (in *PhysicalBackupStatus) DeepCopyInto(out *PhysicalBackupStatus) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.IncrementalChain != nil {
+		in, out := &in.IncrementalChain, &out.IncrementalChain
+		*out = new(PhysicalBackupChain)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SecondaryStorages != nil {
+		in, out := &in.SecondaryStorages, &out.SecondaryStorages
+		*out = make([]SecondaryStorageStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Progress != nil {
+		in, out := &in.Progress, &out.Progress
+		*out = new(BackupProgress)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Catalog != nil {
+		in, out := &in.Catalog, &out.Catalog
+		*out = new(BackupCatalog)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PhysicalBackupStatus.
@@ -3791,6 +4751,11 @@ func (in *PhysicalBackupStorage) DeepCopyInto(out *PhysicalBackupStorage) {
 		*out = new(AzureBlob)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.GCS != nil {
+		in, out := &in.GCS, &out.GCS
+		*out = new(GCS)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.PersistentVolumeClaim != nil {
 		in, out := &in.PersistentVolumeClaim, &out.PersistentVolumeClaim
 		*out = new(PersistentVolumeClaimSpec)
@@ -3818,6 +4783,31 @@ func (in *PhysicalBackupStorage) DeepCopy() *PhysicalBackupStorage {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PhysicalBackupStreaming) DeepCopyInto(out *PhysicalBackupStreaming) {
+	*out = *in
+	if in.PartSize != nil {
+		in, out := &in.PartSize, &out.PartSize
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.MaxPartRetries != nil {
+		in, out := &in.MaxPartRetries, &out.MaxPartRetries
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PhysicalBackupStreaming.
+func (in *PhysicalBackupStreaming) DeepCopy() *PhysicalBackupStreaming {
+	if in == nil {
+		return nil
+	}
+	out := new(PhysicalBackupStreaming)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PhysicalBackupVolumeSnapshot) DeepCopyInto(out *PhysicalBackupVolumeSnapshot) {
 	*out = *in
@@ -4034,13 +5024,59 @@ func (in *PointInTimeRecoveryList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PointInTimeRecoverySpec) DeepCopyInto(out *PointInTimeRecoverySpec) {
 	*out = *in
-	out.PhysicalBackupRef = in.PhysicalBackupRef
+	if in.PhysicalBackupRef != nil {
+		in, out := &in.PhysicalBackupRef, &out.PhysicalBackupRef
+		*out = new(LocalObjectReference)
+		**out = **in
+	}
+	if in.BackupRef != nil {
+		in, out := &in.BackupRef, &out.BackupRef
+		*out = new(LocalObjectReference)
+		**out = **in
+	}
 	in.PointInTimeRecoveryStorage.DeepCopyInto(&out.PointInTimeRecoveryStorage)
+	if in.SecondaryStorages != nil {
+		in, out := &in.SecondaryStorages, &out.SecondaryStorages
+		*out = make([]SecondaryStorage, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.CompressionLevel != nil {
+		in, out := &in.CompressionLevel, &out.CompressionLevel
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Encryption != nil {
+		in, out := &in.Encryption, &out.Encryption
+		*out = new(Encryption)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.ArchiveTimeout != nil {
 		in, out := &in.ArchiveTimeout, &out.ArchiveTimeout
 		*out = new(v1.Duration)
 		**out = **in
 	}
+	if in.Throttling != nil {
+		in, out := &in.Throttling, &out.Throttling
+		*out = new(Throttling)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Streaming != nil {
+		in, out := &in.Streaming, &out.Streaming
+		*out = new(BinlogStreaming)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CDC != nil {
+		in, out := &in.CDC, &out.CDC
+		*out = new(BinlogCDC)
+		**out = **in
+	}
+	if in.Retention != nil {
+		in, out := &in.Retention, &out.Retention
+		*out = new(BinlogRetention)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PointInTimeRecoverySpec.
@@ -4056,11 +5092,35 @@ func (in *PointInTimeRecoverySpec) DeepCopy() *PointInTimeRecoverySpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PointInTimeRecoveryStatus) DeepCopyInto(out *PointInTimeRecoveryStatus) {
 	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.EarliestRecoverableTime != nil {
+		in, out := &in.EarliestRecoverableTime, &out.EarliestRecoverableTime
+		*out = new(string)
+		**out = **in
+	}
 	if in.LastRecoverableTime != nil {
 		in, out := &in.LastRecoverableTime, &out.LastRecoverableTime
 		*out = new(string)
 		**out = **in
 	}
+	if in.SecondaryStorages != nil {
+		in, out := &in.SecondaryStorages, &out.SecondaryStorages
+		*out = make([]SecondaryStorageStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.CDC != nil {
+		in, out := &in.CDC, &out.CDC
+		*out = new(BinlogCDCStatus)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PointInTimeRecoveryStatus.
@@ -4086,6 +5146,21 @@ func (in *PointInTimeRecoveryStorage) DeepCopyInto(out *PointInTimeRecoveryStora
 		*out = new(AzureBlob)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.GCS != nil {
+		in, out := &in.GCS, &out.GCS
+		*out = new(GCS)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PersistentVolumeClaim != nil {
+		in, out := &in.PersistentVolumeClaim, &out.PersistentVolumeClaim
+		*out = new(PersistentVolumeClaimSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Volume != nil {
+		in, out := &in.Volume, &out.Volume
+		*out = new(StorageVolumeSource)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PointInTimeRecoveryStorage.
@@ -4590,6 +5665,80 @@ func (in *RestoreList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RestorePlan) DeepCopyInto(out *RestorePlan) {
+	*out = *in
+	in.TargetRecoveryTime.DeepCopyInto(&out.TargetRecoveryTime)
+	in.BackupTime.DeepCopyInto(&out.BackupTime)
+	if in.BackupChain != nil {
+		in, out := &in.BackupChain, &out.BackupChain
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Binlogs != nil {
+		in, out := &in.Binlogs, &out.Binlogs
+		*out = make([]RestorePlanBinlog, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.LastRecoverableTime != nil {
+		in, out := &in.LastRecoverableTime, &out.LastRecoverableTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Gaps != nil {
+		in, out := &in.Gaps, &out.Gaps
+		*out = make([]RestorePlanGap, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RestorePlan.
+func (in *RestorePlan) DeepCopy() *RestorePlan {
+	if in == nil {
+		return nil
+	}
+	out := new(RestorePlan)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RestorePlanBinlog) DeepCopyInto(out *RestorePlanBinlog) {
+	*out = *in
+	in.FirstTime.DeepCopyInto(&out.FirstTime)
+	in.LastTime.DeepCopyInto(&out.LastTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RestorePlanBinlog.
+func (in *RestorePlanBinlog) DeepCopy() *RestorePlanBinlog {
+	if in == nil {
+		return nil
+	}
+	out := new(RestorePlanBinlog)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RestorePlanGap) DeepCopyInto(out *RestorePlanGap) {
+	*out = *in
+	in.StartTime.DeepCopyInto(&out.StartTime)
+	in.EndTime.DeepCopyInto(&out.EndTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RestorePlanGap.
+func (in *RestorePlanGap) DeepCopy() *RestorePlanGap {
+	if in == nil {
+		return nil
+	}
+	out := new(RestorePlanGap)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RestoreSource) DeepCopyInto(out *RestoreSource) {
 	*out = *in
@@ -4603,20 +5752,42 @@ func (in *RestoreSource) DeepCopyInto(out *RestoreSource) {
 		*out = new(S3)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.GCS != nil {
+		in, out := &in.GCS, &out.GCS
+		*out = new(GCS)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Volume != nil {
 		in, out := &in.Volume, &out.Volume
 		*out = new(StorageVolumeSource)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Encryption != nil {
+		in, out := &in.Encryption, &out.Encryption
+		*out = new(Encryption)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.TargetRecoveryTime != nil {
 		in, out := &in.TargetRecoveryTime, &out.TargetRecoveryTime
 		*out = (*in).DeepCopy()
 	}
+	if in.TargetRecoveryGtid != nil {
+		in, out := &in.TargetRecoveryGtid, &out.TargetRecoveryGtid
+		*out = new(replication.Gtid)
+		**out = **in
+	}
 	if in.StagingStorage != nil {
 		in, out := &in.StagingStorage, &out.StagingStorage
 		*out = new(StagingStorage)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.SecondaryStorages != nil {
+		in, out := &in.SecondaryStorages, &out.SecondaryStorages
+		*out = make([]SecondaryStorage, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RestoreSource.
@@ -4636,6 +5807,21 @@ func (in *RestoreSpec) DeepCopyInto(out *RestoreSpec) {
 	in.JobPodTemplate.DeepCopyInto(&out.JobPodTemplate)
 	in.RestoreSource.DeepCopyInto(&out.RestoreSource)
 	out.MariaDBRef = in.MariaDBRef
+	if in.PointInTimeRecoveryRef != nil {
+		in, out := &in.PointInTimeRecoveryRef, &out.PointInTimeRecoveryRef
+		*out = new(LocalObjectReference)
+		**out = **in
+	}
+	if in.Tables != nil {
+		in, out := &in.Tables, &out.Tables
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Parallelism != nil {
+		in, out := &in.Parallelism, &out.Parallelism
+		*out = new(int32)
+		**out = **in
+	}
 	if in.InheritMetadata != nil {
 		in, out := &in.InheritMetadata, &out.InheritMetadata
 		*out = new(Metadata)
@@ -4663,6 +5849,16 @@ func (in *RestoreStatus) DeepCopyInto(out *RestoreStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Progress != nil {
+		in, out := &in.Progress, &out.Progress
+		*out = new(BackupProgress)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Plan != nil {
+		in, out := &in.Plan, &out.Plan
+		*out = new(RestorePlan)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RestoreStatus.
@@ -4675,6 +5871,21 @@ func (in *RestoreStatus) DeepCopy() *RestoreStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RetentionPolicy) DeepCopyInto(out *RetentionPolicy) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RetentionPolicy.
+func (in *RetentionPolicy) DeepCopy() *RetentionPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(RetentionPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *S3) DeepCopyInto(out *S3) {
 	*out = *in
@@ -4703,6 +5914,11 @@ func (in *S3) DeepCopyInto(out *S3) {
 		*out = new(SSECConfig)
 		**out = **in
 	}
+	if in.ObjectLock != nil {
+		in, out := &in.ObjectLock, &out.ObjectLock
+		*out = new(ObjectLock)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new S3.
@@ -4776,6 +5992,69 @@ func (in *Schedule) DeepCopy() *Schedule {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecondaryStorage) DeepCopyInto(out *SecondaryStorage) {
+	*out = *in
+	if in.S3 != nil {
+		in, out := &in.S3, &out.S3
+		*out = new(S3)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AzureBlob != nil {
+		in, out := &in.AzureBlob, &out.AzureBlob
+		*out = new(AzureBlob)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PersistentVolumeClaim != nil {
+		in, out := &in.PersistentVolumeClaim, &out.PersistentVolumeClaim
+		*out = new(PersistentVolumeClaimVolumeSource)
+		**out = **in
+	}
+	if in.MaxRetention != nil {
+		in, out := &in.MaxRetention, &out.MaxRetention
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.Retention != nil {
+		in, out := &in.Retention, &out.Retention
+		*out = new(RetentionPolicy)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecondaryStorage.
+func (in *SecondaryStorage) DeepCopy() *SecondaryStorage {
+	if in == nil {
+		return nil
+	}
+	out := new(SecondaryStorage)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecondaryStorageStatus) DeepCopyInto(out *SecondaryStorageStatus) {
+	*out = *in
+	if in.LastReplicationTime != nil {
+		in, out := &in.LastReplicationTime, &out.LastReplicationTime
+		*out = (*in).DeepCopy()
+	}
+	if in.LastSuccessfulReplicationTime != nil {
+		in, out := &in.LastSuccessfulReplicationTime, &out.LastSuccessfulReplicationTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecondaryStorageStatus.
+func (in *SecondaryStorageStatus) DeepCopy() *SecondaryStorageStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(SecondaryStorageStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SecretKeySelector) DeepCopyInto(out *SecretKeySelector) {
 	*out = *in
@@ -5424,6 +6703,56 @@ func (in *TLSRequirements) DeepCopy() *TLSRequirements {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TableSelection) DeepCopyInto(out *TableSelection) {
+	*out = *in
+	if in.Include != nil {
+		in, out := &in.Include, &out.Include
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Exclude != nil {
+		in, out := &in.Exclude, &out.Exclude
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TableSelection.
+func (in *TableSelection) DeepCopy() *TableSelection {
+	if in == nil {
+		return nil
+	}
+	out := new(TableSelection)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Throttling) DeepCopyInto(out *Throttling) {
+	*out = *in
+	if in.BandwidthLimit != nil {
+		in, out := &in.BandwidthLimit, &out.BandwidthLimit
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.IOPSLimit != nil {
+		in, out := &in.IOPSLimit, &out.IOPSLimit
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Throttling.
+func (in *Throttling) DeepCopy() *Throttling {
+	if in == nil {
+		return nil
+	}
+	out := new(Throttling)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TopologySpreadConstraint) DeepCopyInto(out *TopologySpreadConstraint) {
 	*out = *in
@@ -0,0 +1,289 @@
+package backup
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"time"
+
+	"github.com/go-logr/logr"
+	mariadbv1alpha1 "github.com/mariadb-operator/mariadb-operator/v26/api/v1alpha1"
+	"github.com/mariadb-operator/mariadb-operator/v26/pkg/azure"
+	"github.com/mariadb-operator/mariadb-operator/v26/pkg/backup"
+	"github.com/mariadb-operator/mariadb-operator/v26/pkg/binlog"
+	"github.com/mariadb-operator/mariadb-operator/v26/pkg/builder"
+	"github.com/mariadb-operator/mariadb-operator/v26/pkg/gcs"
+	"github.com/mariadb-operator/mariadb-operator/v26/pkg/interfaces"
+	"github.com/mariadb-operator/mariadb-operator/v26/pkg/log"
+	mdbminio "github.com/mariadb-operator/mariadb-operator/v26/pkg/minio"
+	"github.com/mariadb-operator/mariadb-operator/v26/pkg/replication"
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+)
+
+var binlogPrefix string
+
+func init() {
+	catalogCommand.Flags().StringVar(&binlogPrefix, "binlog-prefix", "",
+		"Prefix where the binary logs are archived by point-in-time recovery, in the same bucket or container as the backups. "+
+			"When provided, the recoverable time windows and the gaps in the binary log timeline are also listed.")
+}
+
+// catalog is the output of the catalog command.
+type catalog struct {
+	Backups             []mariadbv1alpha1.BackupCatalogEntry `json:"backups"`
+	PointInTimeRecovery *pitrCatalog                         `json:"pointInTimeRecovery,omitempty"`
+}
+
+// pitrCatalog describes the point-in-time recovery capabilities of the backups available in the storage.
+type pitrCatalog struct {
+	EarliestRecoverableTime *time.Time                 `json:"earliestRecoverableTime,omitempty"`
+	LastRecoverableTime     *time.Time                 `json:"lastRecoverableTime,omitempty"`
+	Windows                 []binlog.RecoverableWindow `json:"windows"`
+	Gaps                    []binlog.TimelineGap       `json:"gaps"`
+}
+
+var catalogCommand = &cobra.Command{
+	Use:   "catalog",
+	Short: "Catalog.",
+	Long:  `Lists the backups available in the storage, and their point-in-time recovery capabilities, in JSON format.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := log.SetupLoggerWithCommand(cmd); err != nil {
+			fmt.Printf("error setting up logger: %v\n", err)
+			os.Exit(1)
+		}
+
+		ctx, cancel := newContext()
+		defer cancel()
+
+		backupProcessor, err := getBackupProcessor()
+		if err != nil {
+			logger.Error(err, "error getting backup processor")
+			os.Exit(1)
+		}
+		keyring, err := getEncryptionKeyring()
+		if err != nil {
+			logger.Error(err, "error getting encryption keyring")
+			os.Exit(1)
+		}
+		backupStorage, err := getBackupStorage(backupProcessor, keyring)
+		if err != nil {
+			logger.Error(err, "error getting backup storage")
+			os.Exit(1)
+		}
+		backupNames, err := backupStorage.List(ctx)
+		if err != nil {
+			logger.Error(err, "error listing backup files")
+			os.Exit(1)
+		}
+
+		c := catalog{
+			Backups: backup.GetCatalogEntries(ctx, backupNames, backupProcessor, catalogManifestFn(backupStorage), 0,
+				logger.WithName("catalog")),
+		}
+		if binlogPrefix != "" {
+			pitr, err := getPitrCatalog(ctx, c.Backups, logger.WithName("pitr-catalog"))
+			if err != nil {
+				logger.Error(err, "error getting point-in-time recovery catalog")
+				os.Exit(1)
+			}
+			c.PointInTimeRecovery = pitr
+		}
+
+		bytes, err := json.MarshalIndent(c, "", "  ")
+		if err != nil {
+			logger.Error(err, "error marshaling catalog")
+			os.Exit(1)
+		}
+		fmt.Println(string(bytes))
+	},
+}
+
+// catalogManifestFn pulls the Manifest of a backup, cleaning it up from the staging area afterwards.
+func catalogManifestFn(backupStorage backup.BackupStorage) backup.ManifestFn {
+	return func(ctx context.Context, fileName string) (*backup.Manifest, error) {
+		manifest, err := pullManifest(ctx, backupStorage, fileName)
+		if err != nil {
+			return nil, err
+		}
+		if manifest != nil {
+			if err := cleanupFile(backup.ManifestFileName(fileName), logger.WithName("cleanup")); err != nil && !os.IsNotExist(err) {
+				logger.Error(err, "error cleaning up manifest file", "backup", fileName)
+			}
+		}
+		return manifest, nil
+	}
+}
+
+// handleCatalogStatus reports the backups available in the storage in the Backup or PhysicalBackup status.
+func handleCatalogStatus(ctx context.Context, backupStorage backup.BackupStorage, processor backup.BackupProcessor,
+	backupNames, deletedBackups []string, backupLogger logr.Logger) error {
+	var (
+		key            types.NamespacedName
+		obj            client.Object
+		catalogStatus  **mariadbv1alpha1.BackupCatalog
+		logicalBackup  mariadbv1alpha1.Backup
+		physicalBackup mariadbv1alpha1.PhysicalBackup
+	)
+	switch {
+	case backupContentType == string(mariadbv1alpha1.BackupContentTypeLogical) && backupName != "" && backupNamespace != "":
+		key = types.NamespacedName{Name: backupName, Namespace: backupNamespace}
+		obj, catalogStatus = &logicalBackup, &logicalBackup.Status.Catalog
+	case backupContentType == string(mariadbv1alpha1.BackupContentTypePhysical) && physicalBackupName != "" && physicalBackupNamespace != "":
+		key = types.NamespacedName{Name: physicalBackupName, Namespace: physicalBackupNamespace}
+		obj, catalogStatus = &physicalBackup, &physicalBackup.Status.Catalog
+	default:
+		return nil
+	}
+	logger := backupLogger.WithValues("name", key.Name)
+	logger.Info("handling catalog status")
+
+	availableBackups := slices.DeleteFunc(slices.Clone(backupNames), func(backup string) bool {
+		return slices.Contains(deletedBackups, backup)
+	})
+	entries := backup.GetCatalogEntries(ctx, availableBackups, processor, catalogManifestFn(backupStorage),
+		backup.CatalogMaxEntries, logger)
+
+	k8sClient, err := getK8sClient()
+	if err != nil {
+		return fmt.Errorf("error getting Kubernetes client: %v", err)
+	}
+	if err := k8sClient.Get(ctx, key, obj); err != nil {
+		return fmt.Errorf("error getting backup: %v", err)
+	}
+
+	patch := client.MergeFrom(obj.DeepCopyObject().(client.Object))
+	*catalogStatus = &mariadbv1alpha1.BackupCatalog{
+		Backups:        entries,
+		TotalBackups:   int32(len(availableBackups)),
+		LastUpdateTime: metav1.Now(),
+	}
+	if err := k8sClient.Status().Patch(ctx, obj, patch); err != nil {
+		return fmt.Errorf("error patching backup status: %v", err)
+	}
+
+	logger.Info("patched catalog status", "backups", len(entries), "total-backups", len(availableBackups))
+	return nil
+}
+
+// getPitrCatalog computes the recoverable time windows of the given backups and the gaps in the binary log timeline.
+func getPitrCatalog(ctx context.Context, entries []mariadbv1alpha1.BackupCatalogEntry,
+	logger logr.Logger) (*pitrCatalog, error) {
+	storageClient, err := getBinlogStorageClient()
+	if err != nil {
+		return nil, fmt.Errorf("error getting binlog storage client: %v", err)
+	}
+	binlogIndex, err := getBinlogIndex(ctx, storageClient)
+	if err != nil {
+		return nil, fmt.Errorf("error getting binlog index: %v", err)
+	}
+
+	restorePoints := make([]binlog.RestorePoint, 0, len(entries))
+	for _, entry := range entries {
+		point := binlog.RestorePoint{
+			FileName: entry.FileName,
+			Time:     entry.Time.Time,
+		}
+		if entry.GTID != "" {
+			// TODO: support multiple GTID domain IDs
+			gtid, err := replication.ParseGtid(entry.GTID)
+			if err != nil {
+				logger.Error(err, "error parsing backup GTID", "backup", entry.FileName, "gtid", entry.GTID)
+			} else {
+				point.Gtid = gtid
+			}
+		}
+		restorePoints = append(restorePoints, point)
+	}
+
+	pitr := &pitrCatalog{
+		Windows: binlogIndex.RecoverableWindows(restorePoints, time.Now(), logger),
+		Gaps:    binlogIndex.Gaps(logger),
+	}
+	for _, window := range pitr.Windows {
+		if pitr.EarliestRecoverableTime == nil || window.StartTime.Before(*pitr.EarliestRecoverableTime) {
+			pitr.EarliestRecoverableTime = &window.StartTime
+		}
+		if pitr.LastRecoverableTime == nil || window.EndTime.After(*pitr.LastRecoverableTime) {
+			pitr.LastRecoverableTime = &window.EndTime
+		}
+	}
+	return pitr, nil
+}
+
+// getBinlogStorageClient returns a client for the binary logs archived in the binlog prefix of the backup bucket or container.
+func getBinlogStorageClient() (interfaces.BlobStorage, error) {
+	if s3 {
+		opts := []mdbminio.MinioOpt{
+			mdbminio.WithTLS(s3TLS),
+			mdbminio.WithCACertPath(s3CACertPath),
+			mdbminio.WithRegion(s3Region),
+			mdbminio.WithPrefix(binlogPrefix),
+			mdbminio.WithAllowNestedPrefixes(true),
+		}
+		if ssecKey := os.Getenv(builder.S3SSECCustomerKey); ssecKey != "" {
+			opts = append(opts, mdbminio.WithSSECCustomerKey(ssecKey))
+		}
+		return mdbminio.NewMinioClient(path, s3Bucket, s3Endpoint, opts...)
+	}
+	if abs {
+		opts := []azure.AzBlobOpt{
+			azure.WithTLSEnabled(absTLS),
+			azure.WithTLSCACertPath(absCACertPath),
+			azure.WithPrefix(binlogPrefix),
+			azure.WithAllowNestedPrefixes(true),
+		}
+		if accountKey := os.Getenv(builder.ABSStorageAccountKey); accountKey != "" {
+			opts = append(opts, azure.WithAccountKey(accountKey))
+		}
+		if accountName := os.Getenv(builder.ABSStorageAccountName); accountName != "" {
+			opts = append(opts, azure.WithAccountName(accountName))
+		}
+		return azure.NewAzBlobClient(path, absContainer, absServiceURL, opts...)
+	}
+	if gcsEnabled {
+		opts := []gcs.GCSOpt{
+			gcs.WithEndpoint(gcsEndpoint),
+			gcs.WithPrefix(binlogPrefix),
+			gcs.WithAllowNestedPrefixes(true),
+		}
+		if serviceAccountKey := os.Getenv(builder.GCSServiceAccountKey); serviceAccountKey != "" {
+			opts = append(opts, gcs.WithServiceAccountKey([]byte(serviceAccountKey)))
+		}
+		return gcs.NewGCSClient(path, gcsBucket, opts...)
+	}
+	return nil, errors.New("point-in-time recovery catalog is only supported with S3, Azure Blob Storage or GCS")
+}
+
+func getBinlogIndex(ctx context.Context, storageClient interfaces.BlobStorage) (*binlog.BinlogIndex, error) {
+	exists, err := storageClient.Exists(ctx, binlog.BinlogIndexName)
+	if err != nil {
+		return nil, fmt.Errorf("error checking if binlog index exists: %v", err)
+	}
+	if !exists {
+		return nil, errors.New("binlog index not found")
+	}
+
+	indexReader, err := storageClient.GetObjectWithOptions(ctx, binlog.BinlogIndexName)
+	if err != nil {
+		return nil, fmt.Errorf("error getting binlog index: %v", err)
+	}
+	defer indexReader.Close()
+
+	bytes, err := io.ReadAll(indexReader)
+	if err != nil {
+		return nil, fmt.Errorf("error reading binlog index: %v", err)
+	}
+	var bi binlog.BinlogIndex
+	if err := yaml.Unmarshal(bytes, &bi); err != nil {
+		return nil, fmt.Errorf("error unmarshaling binlog index: %v", err)
+	}
+	return &bi, nil
+}
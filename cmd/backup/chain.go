@@ -0,0 +1,203 @@
+package backup
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/go-logr/logr"
+	mariadbv1alpha1 "github.com/mariadb-operator/mariadb-operator/v26/api/v1alpha1"
+	"github.com/mariadb-operator/mariadb-operator/v26/pkg/backup"
+	ds "github.com/mariadb-operator/mariadb-operator/v26/pkg/datastructures"
+	"github.com/mariadb-operator/mariadb-operator/v26/pkg/replication"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// getChainIndex fetches the chain index from the storage, pulling it into the staging path.
+// It returns nil when incremental backups are not in use, meaning that there is no need to track the chains.
+func getChainIndex(ctx context.Context, backupStorage backup.BackupStorage, stagingPath string) (*backup.ChainIndex, error) {
+	if backupContentType != string(mariadbv1alpha1.BackupContentTypePhysical) {
+		return nil, nil
+	}
+	exists, err := backupStorage.Exists(ctx, backup.ChainIndexFileName)
+	if err != nil {
+		return nil, fmt.Errorf("error checking chain index existence: %v", err)
+	}
+	if !exists {
+		if physicalBackupChain {
+			return &backup.ChainIndex{}, nil
+		}
+		return nil, nil
+	}
+
+	logger.Info("pulling chain index", "file", backup.ChainIndexFileName)
+	if err := backupStorage.Pull(ctx, backup.ChainIndexFileName); err != nil {
+		return nil, fmt.Errorf("error pulling chain index: %v", err)
+	}
+	return backup.ReadChainIndex(backup.GetFilePath(stagingPath, backup.ChainIndexFileName))
+}
+
+// addChainLink adds the backup to the chain index, based on the LSNs recorded by mariadb-backup.
+func addChainLink(chainIndex *backup.ChainIndex, backupTargetFile string) (*mariadbv1alpha1.PhysicalBackupChainLink, error) {
+	if chainIndex == nil || !physicalBackupChain {
+		return nil, nil
+	}
+	checkpointsPath := filepath.Join(physicalBackupDirPath, backup.CheckpointsFileName)
+	bytes, err := os.ReadFile(checkpointsPath)
+	if err != nil {
+		return nil, fmt.Errorf("error reading checkpoints file %s: %v", checkpointsPath, err)
+	}
+	checkpoints, err := backup.ParseCheckpoints(bytes)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing checkpoints file %s: %v", checkpointsPath, err)
+	}
+
+	link := mariadbv1alpha1.PhysicalBackupChainLink{
+		FileName: filepath.Base(backupTargetFile),
+		Type:     mariadbv1alpha1.PhysicalBackupTypeFull,
+		FromLSN:  checkpoints.FromLSN,
+		ToLSN:    checkpoints.ToLSN,
+	}
+	if checkpoints.IsIncremental() {
+		if chainParent == "" {
+			return nil, fmt.Errorf("parent backup must be provided for incremental backup %s", link.FileName)
+		}
+		if _, ok := chainIndex.Get(chainParent); !ok {
+			logger.Info("parent backup not found in chain index", "file", link.FileName, "parent", chainParent)
+		}
+		link.Type = mariadbv1alpha1.PhysicalBackupTypeIncremental
+		link.ParentFileName = filepath.Base(chainParent)
+	}
+	if physicalBackupMeta {
+		if rawGtid, err := getBackupGTID(); err == nil {
+			if gtid, err := replication.ParseGtid(rawGtid); err == nil {
+				link.GTID = gtid.String()
+			}
+		}
+	}
+
+	logger.Info(
+		"adding backup to chain index",
+		"file", link.FileName,
+		"type", link.Type,
+		"parent", link.ParentFileName,
+		"from-lsn", link.FromLSN,
+		"to-lsn", link.ToLSN,
+	)
+	chainIndex.Add(link)
+	return &link, nil
+}
+
+// pushChainIndex removes the deleted backups from the chain index and pushes it to the storage.
+func pushChainIndex(ctx context.Context, backupStorage backup.BackupStorage, chainIndex *backup.ChainIndex,
+	backupNames, deletedBackups []string) error {
+	if chainIndex == nil {
+		return nil
+	}
+	if err := writeChainIndex(ctx, backupStorage, path, chainIndex, backupNames, deletedBackups); err != nil {
+		return err
+	}
+	if err := cleanupFile(backup.ChainIndexFileName, logger.WithName("cleanup")); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("error cleaning up chain index: %v", err)
+	}
+	return nil
+}
+
+// writeChainIndex prunes the chain index, writes it into the staging path and pushes it to the storage.
+func writeChainIndex(ctx context.Context, backupStorage backup.BackupStorage, stagingPath string, chainIndex *backup.ChainIndex,
+	backupNames, deletedBackups []string) error {
+	chainIndex.Prune(ds.Remove(backupNames, func(name string) bool {
+		return slices.Contains(deletedBackups, name)
+	}))
+
+	filePath := backup.GetFilePath(stagingPath, backup.ChainIndexFileName)
+	if err := chainIndex.Write(filePath); err != nil {
+		return fmt.Errorf("error writing chain index: %v", err)
+	}
+	logger.Info("pushing chain index", "file", backup.ChainIndexFileName, "backups", len(chainIndex.Backups))
+	if err := backupStorage.Push(ctx, backup.ChainIndexFileName); err != nil {
+		return fmt.Errorf("error pushing chain index: %v", err)
+	}
+	return nil
+}
+
+// handleBackupChain records the backup in the incremental chain tracked by the PhysicalBackup status.
+func handleBackupChain(ctx context.Context, chainLink *mariadbv1alpha1.PhysicalBackupChainLink, backupLogger logr.Logger) error {
+	if chainLink == nil || physicalBackupName == "" || physicalBackupNamespace == "" {
+		return nil
+	}
+	key := types.NamespacedName{
+		Name:      physicalBackupName,
+		Namespace: physicalBackupNamespace,
+	}
+	logger := backupLogger.WithValues("physicalbackup", key.Name)
+	logger.Info("handling physical backup chain")
+
+	k8sClient, err := getK8sClient()
+	if err != nil {
+		return fmt.Errorf("error getting Kubernetes client: %v", err)
+	}
+	var physicalBackup mariadbv1alpha1.PhysicalBackup
+	if err := k8sClient.Get(ctx, key, &physicalBackup); err != nil {
+		return fmt.Errorf("error getting PhysicalBackup: %v", err)
+	}
+
+	patch := client.MergeFrom(physicalBackup.DeepCopy())
+	if physicalBackup.Status.IncrementalChain == nil {
+		physicalBackup.Status.IncrementalChain = &mariadbv1alpha1.PhysicalBackupChain{}
+	}
+	chain := physicalBackup.Status.IncrementalChain
+	chain.Backups = append(chain.Backups, *chainLink)
+	if err := k8sClient.Status().Patch(ctx, &physicalBackup, patch); err != nil {
+		return fmt.Errorf("error patching PhysicalBackup status: %v", err)
+	}
+
+	logger.Info("patched PhysicalBackup status with chain backup", "file", chainLink.FileName, "type", chainLink.Type)
+	return nil
+}
+
+// getBackupChain returns the backup files needed to restore the target backup, starting with the full backup.
+// Backups that are not tracked by the chain index are considered full backups.
+func getBackupChain(ctx context.Context, backupStorage backup.BackupStorage, backupTargetFile string) ([]string, error) {
+	if backupContentType != string(mariadbv1alpha1.BackupContentTypePhysical) {
+		return []string{backupTargetFile}, nil
+	}
+	chainIndex, err := getChainIndex(ctx, backupStorage, path)
+	if err != nil {
+		return nil, err
+	}
+	if chainIndex == nil {
+		return []string{backupTargetFile}, nil
+	}
+	if _, ok := chainIndex.Get(backupTargetFile); !ok {
+		return []string{backupTargetFile}, nil
+	}
+
+	chain, err := chainIndex.Chain(backupTargetFile)
+	if err != nil {
+		return nil, fmt.Errorf("error getting backup chain: %v", err)
+	}
+	backupFiles := make([]string, len(chain))
+	for i, link := range chain {
+		backupFiles[i] = link.FileName
+	}
+	return backupFiles, nil
+}
+
+// writeIncrementalTargetFile writes the incremental backups to be applied on top of the target file, one per line.
+// The file is removed when there are no incremental backups to avoid applying stale ones.
+func writeIncrementalTargetFile(incrementalFiles []string) error {
+	incrementalTargetFilePath := backup.IncrementalTargetFilePath(targetFilePath)
+	if len(incrementalFiles) == 0 {
+		if err := os.Remove(incrementalTargetFilePath); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		return nil
+	}
+	content := strings.Join(incrementalFiles, "\n") + "\n"
+	return os.WriteFile(incrementalTargetFilePath, []byte(content), 0777)
+}
@@ -2,10 +2,13 @@ package backup
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"os/signal"
 	"path/filepath"
+	"slices"
+	"sync"
 	"syscall"
 	"time"
 
@@ -15,11 +18,15 @@ import (
 	"github.com/mariadb-operator/mariadb-operator/v26/pkg/backup"
 	"github.com/mariadb-operator/mariadb-operator/v26/pkg/builder"
 	mdbcompression "github.com/mariadb-operator/mariadb-operator/v26/pkg/compression"
+	"github.com/mariadb-operator/mariadb-operator/v26/pkg/gcs"
 	"github.com/mariadb-operator/mariadb-operator/v26/pkg/log"
 	"github.com/mariadb-operator/mariadb-operator/v26/pkg/metadata"
 	mdbminio "github.com/mariadb-operator/mariadb-operator/v26/pkg/minio"
+	"github.com/mariadb-operator/mariadb-operator/v26/pkg/multipart"
+	"github.com/mariadb-operator/mariadb-operator/v26/pkg/ratelimit"
 	"github.com/mariadb-operator/mariadb-operator/v26/pkg/replication"
 	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
@@ -34,6 +41,11 @@ var (
 	targetFilePath    string
 	backupContentType string
 	cleanupTargetFile bool
+	mariadbName       string
+	targetPod         string
+	backupName        string
+	backupNamespace   string
+	backupMeta        bool
 
 	s3           bool
 	s3Bucket     string
@@ -50,14 +62,46 @@ var (
 	absCACertPath string
 	absPrefix     string
 
+	gcsEnabled  bool
+	gcsBucket   string
+	gcsEndpoint string
+	gcsPrefix   string
+
+	logicalBackupDirPath   string
+	logicalBackupParallel  bool
+	logicalBackupChunkSize int64
+
 	physicalBackupDirPath   string
 	physicalBackupMeta      bool
 	physicalBackupName      string
 	physicalBackupNamespace string
+	physicalBackupChain     bool
+	chainParent             string
 
 	maxRetention time.Duration
+	keepLast     int32
+	keepHourly   int32
+	keepDaily    int32
+	keepWeekly   int32
+	keepMonthly  int32
+	keepYearly   int32
+
+	compression      string
+	compressionLevel int32
+
+	streaming            bool
+	streamPartSize       int64
+	streamMaxPartRetries int
 
-	compression string
+	bandwidthLimit  int64
+	rateLimiter     *ratelimit.Limiter
+	rateLimiterOnce sync.Once
+
+	objectLockMode        string
+	objectLockRetainUntil time.Duration
+	objectLockLegalHold   bool
+
+	secondaryStoragesRaw string
 )
 
 func init() {
@@ -80,6 +124,8 @@ func init() {
 	RootCmd.PersistentFlags().BoolVar(&cleanupTargetFile, "cleanup-target-file", false,
 		"Whether to clean up the target file after S3 backups are completed."+
 			"This option should be used exclusively with external backups, such as S3.")
+	RootCmd.PersistentFlags().StringVar(&mariadbName, "mariadb-name", "", "Name of the MariaDB to be recorded in the backup manifest.")
+	RootCmd.PersistentFlags().StringVar(&targetPod, "target-pod", "", "Name of the Pod where the backup is taken, to be recorded in the backup manifest.")
 
 	RootCmd.PersistentFlags().BoolVar(&s3, "s3", false, "Enable S3 backup storage.")
 	RootCmd.PersistentFlags().StringVar(&s3Bucket, "s3-bucket", "backups", "Name of the bucket to store backups.")
@@ -96,22 +142,72 @@ func init() {
 	RootCmd.PersistentFlags().StringVar(&absCACertPath, "abs-ca-cert-path", "", "Path to the CA to be trusted when connecting to ABS.")
 	RootCmd.PersistentFlags().StringVar(&absPrefix, "abs-prefix", "", "ABS container prefix to use.")
 
+	RootCmd.PersistentFlags().BoolVar(&gcsEnabled, "gcs", false, "Enable Google Cloud Storage backup storage.")
+	RootCmd.PersistentFlags().StringVar(&gcsBucket, "gcs-bucket", "backups", "Name of the GCS bucket to store backups.")
+	RootCmd.PersistentFlags().StringVar(&gcsEndpoint, "gcs-endpoint", "", "GCS API endpoint to use, including scheme.")
+	RootCmd.PersistentFlags().StringVar(&gcsPrefix, "gcs-prefix", "", "GCS bucket prefix name to use.")
+
 	RootCmd.PersistentFlags().StringVar(&compression, "compression", string(mariadbv1alpha1.CompressNone),
-		"Compression algorithm: none, gzip or bzip2.")
+		"Compression algorithm: none, gzip, bzip2, zstd or lz4.")
+	RootCmd.PersistentFlags().Int32Var(&compressionLevel, "compression-level", 0,
+		"Compression level. Only supported by zstd, ranging from 1 (fastest) to 22 (best compression). If not provided, the default level is used.")
+
+	RootCmd.PersistentFlags().BoolVar(&streaming, "streaming", false,
+		"Stream physical backups between mariadb-backup and the object storage, without staging them in the local filesystem.")
+	RootCmd.PersistentFlags().Int64Var(&streamPartSize, "stream-part-size", multipart.DefaultPartSize,
+		"Size in bytes of the parts uploaded to the object storage when streaming.")
+	RootCmd.PersistentFlags().IntVar(&streamMaxPartRetries, "stream-max-part-retries", multipart.DefaultMaxPartRetries,
+		"Maximum number of retries of each part uploaded to the object storage when streaming.")
+	RootCmd.PersistentFlags().Int64Var(&bandwidthLimit, "bandwidth-limit", 0,
+		"Maximum number of bytes per second transferred to and from the object storages. If not provided, it is not limited.")
+	RootCmd.PersistentFlags().StringVar(&objectLockMode, "object-lock-mode", string(mariadbv1alpha1.ObjectLockModeGovernance),
+		"Retention mode of the objects uploaded to S3 or Azure Blob Storage: Governance or Compliance.")
+	RootCmd.PersistentFlags().DurationVar(&objectLockRetainUntil, "object-lock-retain-until", 0,
+		"Duration, since the upload, during which the objects uploaded to S3 or Azure Blob Storage are locked. If not provided, objects are not locked.")
+	RootCmd.PersistentFlags().BoolVar(&objectLockLegalHold, "object-lock-legal-hold", false,
+		"Place a legal hold on the objects uploaded to S3 or Azure Blob Storage. Only considered when object-lock-retain-until is provided.")
+	RootCmd.PersistentFlags().StringVar(&secondaryStoragesRaw, "secondary-storages", "",
+		"Secondary storages in JSON format where backups are replicated. They are used as a fallback when restoring "+
+			"if the primary storage is unreachable. Settings and credentials are read from environment variables indexed by storage.")
+
+	RootCmd.PersistentFlags().StringVar(&logicalBackupDirPath, "logical-backup-dir-path", "",
+		"Directory path where parallel logical backups are split and extracted. Only considered when backup-content-type is Logical.")
+	RootCmd.Flags().BoolVar(&logicalBackupParallel, "parallel", false,
+		"Split the dump streamed by mariadb-dump by table while it is being taken. Only considered when backup-content-type is Logical.")
+	RootCmd.Flags().Int64Var(&logicalBackupChunkSize, "logical-backup-chunk-size", 0,
+		"Maximum size in bytes of each data file of parallel logical backups. If not provided, each table is stored in a single data file.")
+
+	RootCmd.Flags().StringVar(&backupName, "backup-name", "",
+		"Backup custom resource name to report the backup status. Only considered when backup-content-type is Logical.")
+	RootCmd.Flags().StringVar(&backupNamespace, "backup-namespace", "",
+		"Backup custom resource namespace to report the backup status. Only considered when backup-content-type is Logical.")
+	RootCmd.Flags().BoolVar(&backupMeta, "backup-meta", false,
+		"Enable tracking logical backup metadata in the Backup custom resource. Only considered when backup-content-type is Logical.")
 
 	RootCmd.PersistentFlags().StringVar(&physicalBackupDirPath, "physical-backup-dir-path", "",
 		"Directory path where the physical backup is located. Only considered when backup-content-type is Physical.")
 	RootCmd.Flags().BoolVar(&physicalBackupMeta, "physical-backup-meta", false,
 		"Enable tracking physical backup metadata in the PhysicalBackup custom resource. Only considered when backup-content-type is Physical.")
 	RootCmd.Flags().StringVar(&physicalBackupName, "physical-backup-name", "",
-		"PhysicalBackup custom resource name to track physical backup metadata. Only considered when physical-backup-meta is enabled.")
+		"PhysicalBackup custom resource name to track physical backup metadata and report the backup status.")
 	RootCmd.Flags().StringVar(&physicalBackupNamespace, "physical-backup-namespace", "",
-		"PhysicalBackup custom resource namespace to track physical backup metadata. Only considered when physical-backup-meta is enabled.")
+		"PhysicalBackup custom resource namespace to track physical backup metadata and report the backup status.")
+	RootCmd.Flags().BoolVar(&physicalBackupChain, "physical-backup-chain", false,
+		"Enable tracking the backup in the incremental backup chain. Only considered when backup-content-type is Physical.")
+	RootCmd.Flags().StringVar(&chainParent, "physical-backup-chain-parent", "",
+		"Name of the backup file the incremental backup is based on. Only considered when physical-backup-chain is enabled.")
 
 	RootCmd.Flags().DurationVar(&maxRetention, "max-retention", 30*24*time.Hour,
 		"Defines the retention policy for backups. Older backups will be deleted.")
+	RootCmd.Flags().Int32Var(&keepLast, "keep-last", 0, "Number of most recent backups to keep. Takes precedence over max-retention.")
+	RootCmd.Flags().Int32Var(&keepHourly, "keep-hourly", 0, "Number of hourly backups to keep. Takes precedence over max-retention.")
+	RootCmd.Flags().Int32Var(&keepDaily, "keep-daily", 0, "Number of daily backups to keep. Takes precedence over max-retention.")
+	RootCmd.Flags().Int32Var(&keepWeekly, "keep-weekly", 0, "Number of weekly backups to keep. Takes precedence over max-retention.")
+	RootCmd.Flags().Int32Var(&keepMonthly, "keep-monthly", 0, "Number of monthly backups to keep. Takes precedence over max-retention.")
+	RootCmd.Flags().Int32Var(&keepYearly, "keep-yearly", 0, "Number of yearly backups to keep. Takes precedence over max-retention.")
 
 	RootCmd.AddCommand(restoreCommand)
+	RootCmd.AddCommand(catalogCommand)
 }
 
 var RootCmd = &cobra.Command{
@@ -128,22 +224,43 @@ var RootCmd = &cobra.Command{
 
 		ctx, cancel := newContext()
 		defer cancel()
+		go getProgressTracker().Run(ctx, progressInterval)
+
+		var backupStream *os.File
+		if streaming || logicalBackupParallel {
+			stream, err := openBackupStream(ctx)
+			if err != nil {
+				logger.Error(err, "error opening backup stream")
+				os.Exit(1)
+			}
+			backupStream = stream
+		}
 
 		backupProcessor, err := getBackupProcessor()
 		if err != nil {
 			logger.Error(err, "error getting backup processor")
 			os.Exit(1)
 		}
-		backupStorage, err := getBackupStorage(backupProcessor)
+		keyring, err := getEncryptionKeyring()
+		if err != nil {
+			logger.Error(err, "error getting encryption keyring")
+			os.Exit(1)
+		}
+		backupStorage, err := getBackupStorage(backupProcessor, keyring)
 		if err != nil {
 			logger.Error(err, "error getting backup storage")
 			os.Exit(1)
 		}
-		backupCompressor, err := getBackupCompressor(backupProcessor)
+		backupCompressor, err := getBackupCompressor(backupProcessor, keyring)
 		if err != nil {
 			logger.Error(err, "error getting backup compressor")
 			os.Exit(1)
 		}
+		secondaryStorages, err := getSecondaryStorages()
+		if err != nil {
+			logger.Error(err, "error getting secondary storages")
+			os.Exit(1)
+		}
 
 		logger.Info("reading target file", "file", targetFilePath)
 		backupTargetFile, err := readTargetFile()
@@ -153,14 +270,43 @@ var RootCmd = &cobra.Command{
 		}
 		logger.Info("obtained target backup", "file", backupTargetFile)
 
-		if err := backupCompressor.Compress(backupTargetFile); err != nil {
-			logger.Error(err, "error compressing backup", "file", backupTargetFile)
+		var manifest *backup.Manifest
+		if streaming {
+			manifest, err = streamBackup(ctx, backupStorage, keyring, backupStream, backupTargetFile)
+		} else {
+			manifest, err = stageBackup(ctx, backupStorage, backupCompressor, backupStream, backupTargetFile)
+		}
+		if err != nil {
+			logger.Error(err, "error backing up target backup", "file", backupTargetFile)
+			os.Exit(1)
+		}
+
+		manifestFile := backup.ManifestFileName(backupTargetFile)
+		logger.Info("pushing manifest", "file", manifestFile)
+		if err := backupStorage.Push(ctx, manifestFile); err != nil {
+			logger.Error(err, "error pushing manifest", "file", manifestFile)
 			os.Exit(1)
 		}
 
-		logger.Info("pushing target backup", "file", backupTargetFile)
-		if err := backupStorage.Push(ctx, backupTargetFile); err != nil {
-			logger.Error(err, "error pushing target backup", "file", backupTargetFile)
+		logger.Info("verifying stored backup size", "file", backupTargetFile, "size", manifest.Size)
+		verifyErr := verifyStoredBackupSize(ctx, backupStorage, backupTargetFile, manifest)
+		if err := handleIntegrityStatus(ctx, backupTargetFile, verifyErr, logger.WithName("integrity")); err != nil {
+			logger.Error(err, "error handling integrity status")
+			os.Exit(1)
+		}
+		if verifyErr != nil {
+			logger.Error(verifyErr, "error verifying stored backup size", "file", backupTargetFile)
+			os.Exit(1)
+		}
+
+		chainIndex, err := getChainIndex(ctx, backupStorage, path)
+		if err != nil {
+			logger.Error(err, "error getting chain index")
+			os.Exit(1)
+		}
+		chainLink, err := addChainLink(chainIndex, backupTargetFile)
+		if err != nil {
+			logger.Error(err, "error adding backup to chain index", "file", backupTargetFile)
 			os.Exit(1)
 		}
 
@@ -170,27 +316,104 @@ var RootCmd = &cobra.Command{
 			logger.Error(err, "error listing backup files")
 			os.Exit(1)
 		}
-		oldBackups := backupProcessor.GetOldBackupFiles(backupNames, maxRetention, logger.WithName("backup-cleanup"))
+		oldBackups := backupProcessor.GetOldBackupFiles(backupNames, getRetentionPolicy(), logger.WithName("backup-cleanup"))
+		if chainIndex != nil {
+			oldBackups = chainIndex.ProtectDependencies(backupNames, oldBackups, logger.WithName("backup-cleanup"))
+		}
+		oldBackups = backup.SkipLockedBackupFiles(ctx, backupStorage, oldBackups, logger.WithName("backup-cleanup"))
 		logger.Info("old backups to delete", "backups", len(oldBackups))
+		var deletedBackups []string
 		for _, backup := range oldBackups {
-			logger.V(1).Info("deleting old backup", "backup", backup)
+			logger.Info("deleting old backup", "backup", backup)
 			if err := backupStorage.Delete(ctx, backup); err != nil {
 				logger.Error(err, "error removing old backup", "backup", backup)
+				continue
 			}
+			if err := deleteManifest(ctx, backupStorage, backup); err != nil {
+				logger.Error(err, "error removing manifest of old backup", "backup", backup)
+			}
+			deletedBackups = append(deletedBackups, backup)
+		}
+
+		if err := pushChainIndex(ctx, backupStorage, chainIndex, backupNames, deletedBackups); err != nil {
+			logger.Error(err, "error pushing chain index")
+			os.Exit(1)
+		}
+		if err := handleCatalogStatus(ctx, backupStorage, backupProcessor, backupNames, deletedBackups,
+			logger.WithName("catalog")); err != nil {
+			logger.Error(err, "error handling catalog status")
 		}
 
-		if err := cleanupFile(backupTargetFile, logger.WithName("cleanup")); err != nil && os.IsNotExist(err) {
-			logger.Error(err, "error cleaning up target file", "file", backupTargetFile)
+		secondaryStatuses := replicateBackup(ctx, secondaryStorages, backupProcessor, keyring, backupTargetFile, chainLink,
+			logger.WithName("secondary-storage"))
+		if err := handleSecondaryStorageStatus(ctx, secondaryStatuses, logger.WithName("secondary-storage")); err != nil {
+			logger.Error(err, "error handling secondary storage status")
 			os.Exit(1)
 		}
 
-		if err := handleBackupMeta(ctx, logger.WithName("backup-meta")); err != nil {
+		if !streaming {
+			if err := cleanupFile(backupTargetFile, logger.WithName("cleanup")); err != nil && os.IsNotExist(err) {
+				logger.Error(err, "error cleaning up target file", "file", backupTargetFile)
+				os.Exit(1)
+			}
+		}
+		if err := cleanupFile(manifestFile, logger.WithName("cleanup")); err != nil && os.IsNotExist(err) {
+			logger.Error(err, "error cleaning up manifest file", "file", manifestFile)
+			os.Exit(1)
+		}
+
+		if err := handleBackupMeta(ctx, backupStorage, backupProcessor, manifest, backupNames, deletedBackups,
+			logger.WithName("backup-meta")); err != nil {
 			logger.Error(err, "error handling backup meta")
 			os.Exit(1)
 		}
+		if err := handleBackupChain(ctx, chainLink, logger.WithName("backup-chain")); err != nil {
+			logger.Error(err, "error handling backup chain")
+			os.Exit(1)
+		}
 	},
 }
 
+// stageBackup compresses the backup staged in the local filesystem, writes its Manifest and pushes it to the backup storage.
+// Parallel backups are split from the dump stream and bundled into the backup target file beforehand.
+func stageBackup(ctx context.Context, backupStorage backup.BackupStorage, backupCompressor mdbcompression.BackupCompressor,
+	dumpStream *os.File, backupTargetFile string) (*backup.Manifest, error) {
+	var backupInfo *backup.BackupInfo
+	if isParallelBackup(backupTargetFile) {
+		info, err := bundleParallelBackup(ctx, dumpStream, backupTargetFile)
+		if err != nil {
+			return nil, fmt.Errorf("error bundling parallel backup: %v", err)
+		}
+		backupInfo = info
+	} else {
+		backupInfo = getBackupInfo(backupTargetFile)
+	}
+
+	uncompressedSize, err := getFileSize(backupTargetFile)
+	if err != nil {
+		return nil, fmt.Errorf("error getting backup size: %v", err)
+	}
+	tracker := getProgressTracker()
+	tracker.StartPhase(ctx, mariadbv1alpha1.BackupProgressPhaseCompress, uncompressedSize)
+	if err := backupCompressor.Compress(backupTargetFile); err != nil {
+		return nil, fmt.Errorf("error compressing backup: %v", err)
+	}
+
+	logger.Info("writing manifest", "file", backup.ManifestFileName(backupTargetFile))
+	manifest, err := writeManifest(backupTargetFile, backupInfo, uncompressedSize)
+	if err != nil {
+		return nil, fmt.Errorf("error writing manifest: %v", err)
+	}
+
+	logger.Info("pushing target backup", "file", backupTargetFile)
+	tracker.StartPhase(ctx, mariadbv1alpha1.BackupProgressPhaseUpload, manifest.Size)
+	if err := backupStorage.Push(ctx, backupTargetFile); err != nil {
+		return nil, fmt.Errorf("error pushing target backup: %v", err)
+	}
+	tracker.EndPhase(ctx)
+	return manifest, nil
+}
+
 func newContext() (context.Context, context.CancelFunc) {
 	return signal.NotifyContext(context.Background(), []os.Signal{
 		syscall.SIGINT,
@@ -218,7 +441,38 @@ func getBackupProcessor() (backup.BackupProcessor, error) {
 	}
 }
 
-func getBackupStorage(processor backup.BackupProcessor) (backup.BackupStorage, error) {
+func getObjectMetadata(keyring *mdbcompression.Keyring) map[string]string {
+	if !keyring.CanEncrypt() {
+		return nil
+	}
+	return map[string]string{
+		mdbcompression.EncryptionKeyIDMetadataKey: keyring.ActiveKeyID(),
+	}
+}
+
+func getObjectLock() (*mariadbv1alpha1.ObjectLock, error) {
+	if objectLockRetainUntil == 0 {
+		return nil, nil
+	}
+	objectLock := &mariadbv1alpha1.ObjectLock{
+		Mode:        mariadbv1alpha1.ObjectLockMode(objectLockMode),
+		RetainUntil: metav1.Duration{Duration: objectLockRetainUntil},
+		LegalHold:   objectLockLegalHold,
+	}
+	if err := objectLock.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid object lock: %v", err)
+	}
+	logger.Info("configuring object lock", "mode", objectLock.Mode, "retain-until", objectLockRetainUntil.String(),
+		"legal-hold", objectLockLegalHold)
+	return objectLock, nil
+}
+
+func getBackupStorage(processor backup.BackupProcessor, keyring *mdbcompression.Keyring) (backup.BackupStorage, error) {
+	objectMetadata := getObjectMetadata(keyring)
+	objectLock, err := getObjectLock()
+	if err != nil {
+		return nil, err
+	}
 	if s3 {
 		logger.Info("configuring S3 backup storage")
 		opts := []mdbminio.MinioOpt{
@@ -226,6 +480,10 @@ func getBackupStorage(processor backup.BackupProcessor) (backup.BackupStorage, e
 			mdbminio.WithCACertPath(s3CACertPath),
 			mdbminio.WithRegion(s3Region),
 			mdbminio.WithPrefix(s3Prefix),
+			mdbminio.WithUserMetadata(objectMetadata),
+			mdbminio.WithRateLimiter(getRateLimiter()),
+			mdbminio.WithProgressTracker(getProgressTracker()),
+			mdbminio.WithObjectLock(objectLock),
 		}
 		if ssecKey := os.Getenv(builder.S3SSECCustomerKey); ssecKey != "" {
 			logger.Info("configuring S3 SSE-C encryption")
@@ -245,6 +503,10 @@ func getBackupStorage(processor backup.BackupProcessor) (backup.BackupStorage, e
 			azure.WithTLSEnabled(absTLS),
 			azure.WithTLSCACertPath(absCACertPath),
 			azure.WithPrefix(absPrefix),
+			azure.WithMetadata(objectMetadata),
+			azure.WithRateLimiter(getRateLimiter()),
+			azure.WithProgressTracker(getProgressTracker()),
+			azure.WithObjectLock(objectLock),
 		}
 		if accountKey := os.Getenv(builder.ABSStorageAccountKey); accountKey != "" {
 			opts = append(opts, azure.WithAccountKey(accountKey))
@@ -261,16 +523,85 @@ func getBackupStorage(processor backup.BackupProcessor) (backup.BackupStorage, e
 			opts...,
 		)
 	}
+	if gcsEnabled {
+		logger.Info("configuring GCS backup storage")
+		opts := []gcs.GCSOpt{
+			gcs.WithEndpoint(gcsEndpoint),
+			gcs.WithPrefix(gcsPrefix),
+			gcs.WithMetadata(objectMetadata),
+			gcs.WithRateLimiter(getRateLimiter()),
+			gcs.WithProgressTracker(getProgressTracker()),
+		}
+		if serviceAccountKey := os.Getenv(builder.GCSServiceAccountKey); serviceAccountKey != "" {
+			opts = append(opts, gcs.WithServiceAccountKey([]byte(serviceAccountKey)))
+		}
+		return backup.NewBlobBackupStorageWithGCS(
+			path,
+			gcsBucket,
+			processor,
+			opts...,
+		)
+	}
 	logger.Info("configuring filesystem backup storage")
 	return backup.NewFileSystemBackupStorage(path, processor, logger.WithName("file-system-storage")), nil
 }
 
-func getBackupCompressor(processor backup.BackupProcessor) (mdbcompression.BackupCompressor, error) {
+// getRateLimiter returns the Limiter shared by all the storage clients, so the bandwidth limit applies to their aggregated throughput.
+func getRateLimiter() *ratelimit.Limiter {
+	rateLimiterOnce.Do(func() {
+		rateLimiter = ratelimit.NewLimiter(bandwidthLimit)
+		if rateLimiter != nil {
+			logger.Info("limiting storage bandwidth", "bytes-per-second", rateLimiter.BytesPerSecond())
+		}
+	})
+	return rateLimiter
+}
+
+func getBackupCompressor(processor backup.BackupProcessor, keyring *mdbcompression.Keyring) (mdbcompression.BackupCompressor, error) {
 	calg := mariadbv1alpha1.CompressAlgorithm(compression)
 	if err := calg.Validate(); err != nil {
 		return nil, fmt.Errorf("compression algorithm not supported: %v", err)
 	}
-	return mdbcompression.NewBackupCompressor(calg, path, processor.GetUncompressedBackupFile, logger)
+	opts := []mdbcompression.CompressorOpt{
+		mdbcompression.WithCompressionLevel(getCompressionLevel()),
+	}
+	if keyring.CanEncrypt() {
+		logger.Info("configuring client-side encryption", "key-id", keyring.ActiveKeyID())
+		opts = append(opts, mdbcompression.WithKeyring(keyring))
+	}
+	if tracker := getProgressTracker(); tracker != nil {
+		opts = append(opts, mdbcompression.WithReaderWrapper(tracker.Reader))
+	}
+	return mdbcompression.NewBackupCompressor(
+		calg,
+		path,
+		processor.GetUncompressedBackupFile,
+		logger,
+		opts...,
+	)
+}
+
+func getEncryptionKeyring() (*mdbcompression.Keyring, error) {
+	return mdbcompression.NewKeyringFromEnv(builder.EncryptionKey, builder.EncryptionPreviousKeyPrefix,
+		builder.EncryptionAllowUnencrypted)
+}
+
+func getRetentionPolicy() backup.RetentionPolicy {
+	return backup.NewRetentionPolicy(maxRetention, &mariadbv1alpha1.RetentionPolicy{
+		KeepLast:    keepLast,
+		KeepHourly:  keepHourly,
+		KeepDaily:   keepDaily,
+		KeepWeekly:  keepWeekly,
+		KeepMonthly: keepMonthly,
+		KeepYearly:  keepYearly,
+	})
+}
+
+func getCompressionLevel() *int32 {
+	if compressionLevel == 0 {
+		return nil
+	}
+	return &compressionLevel
 }
 
 func readTargetFile() (string, error) {
@@ -282,7 +613,7 @@ func readTargetFile() (string, error) {
 }
 
 func cleanupFile(fileName string, logger logr.Logger) error {
-	if (!s3 && !abs) || !cleanupTargetFile {
+	if (!s3 && !abs && !gcsEnabled) || !cleanupTargetFile {
 		return nil
 	}
 	filePath := backup.GetFilePath(path, fileName)
@@ -291,13 +622,64 @@ func cleanupFile(fileName string, logger logr.Logger) error {
 	return os.Remove(filePath)
 }
 
-func handleBackupMeta(ctx context.Context, backupLogger logr.Logger) error {
-	if backupContentType != string(mariadbv1alpha1.BackupContentTypePhysical) {
+func handleBackupMeta(ctx context.Context, backupStorage backup.BackupStorage, processor backup.BackupProcessor,
+	manifest *backup.Manifest, backupNames, deletedBackups []string, backupLogger logr.Logger) error {
+	switch {
+	case backupContentType == string(mariadbv1alpha1.BackupContentTypeLogical) && backupMeta && backupName != "" && backupNamespace != "":
+		return handleLogicalBackupMeta(ctx, backupStorage, processor, manifest, backupNames, deletedBackups, backupLogger)
+	case backupContentType == string(mariadbv1alpha1.BackupContentTypePhysical) && physicalBackupMeta &&
+		physicalBackupName != "" && physicalBackupNamespace != "":
+		return handlePhysicalBackupMeta(ctx, backupStorage, processor, backupNames, deletedBackups, backupLogger)
+	default:
 		return nil
 	}
-	if !physicalBackupMeta || physicalBackupName == "" || physicalBackupNamespace == "" {
-		return nil
+}
+
+// handleLogicalBackupMeta annotates the Backup with the GTID of the consistent snapshot taken by mariadb-dump,
+// as recorded in the backup Manifest.
+func handleLogicalBackupMeta(ctx context.Context, backupStorage backup.BackupStorage, processor backup.BackupProcessor,
+	manifest *backup.Manifest, backupNames, deletedBackups []string, backupLogger logr.Logger) error {
+	key := types.NamespacedName{
+		Name:      backupName,
+		Namespace: backupNamespace,
+	}
+	logger := backupLogger.WithValues("backup", key.Name)
+	logger.Info("handling logical backup meta")
+
+	if manifest == nil || manifest.GTID == "" {
+		return errors.New("GTID not found in backup manifest")
+	}
+	// TODO: support multiple GTID domain IDs
+	gtid, err := replication.ParseGtid(manifest.GTID)
+	if err != nil {
+		return fmt.Errorf("error parsing GTID %s: %v", manifest.GTID, err)
+	}
+
+	k8sClient, err := getK8sClient()
+	if err != nil {
+		return fmt.Errorf("error getting Kubernetes client: %v", err)
 	}
+	var logicalBackup mariadbv1alpha1.Backup
+	if err := k8sClient.Get(ctx, key, &logicalBackup); err != nil {
+		return fmt.Errorf("error getting Backup: %v", err)
+	}
+
+	patch := client.MergeFrom(logicalBackup.DeepCopy())
+	if logicalBackup.Annotations == nil {
+		logicalBackup.Annotations = make(map[string]string)
+	}
+	logicalBackup.Annotations[metadata.LastGtidAnnotation] = gtid.String()
+	setOldestBackupAnnotations(ctx, logicalBackup.Annotations, backupStorage, processor, backupNames, deletedBackups, logger)
+	if err := k8sClient.Patch(ctx, &logicalBackup, patch); err != nil {
+		return fmt.Errorf("error patching Backup: %v", err)
+	}
+
+	logger.Info("patched Backup with GTID", "gtid", gtid.String())
+	return nil
+}
+
+func handlePhysicalBackupMeta(ctx context.Context, backupStorage backup.BackupStorage, processor backup.BackupProcessor,
+	backupNames, deletedBackups []string, backupLogger logr.Logger) error {
 	key := types.NamespacedName{
 		Name:      physicalBackupName,
 		Namespace: physicalBackupNamespace,
@@ -329,6 +711,7 @@ func handleBackupMeta(ctx context.Context, backupLogger logr.Logger) error {
 		physicalBackup.Annotations = make(map[string]string)
 	}
 	physicalBackup.Annotations[metadata.LastGtidAnnotation] = gtid.String()
+	setOldestBackupAnnotations(ctx, physicalBackup.Annotations, backupStorage, processor, backupNames, deletedBackups, logger)
 	if err := k8sClient.Patch(ctx, &physicalBackup, patch); err != nil {
 		return fmt.Errorf("error patching PhysicalBackup: %v", err)
 	}
@@ -337,6 +720,24 @@ func handleBackupMeta(ctx context.Context, backupLogger logr.Logger) error {
 	return nil
 }
 
+// setOldestBackupAnnotations sets the annotations of the Backup or PhysicalBackup with the GTID and time of the oldest backup
+// available in the storage, which determine the binary logs that are no longer needed for point-in-time recovery.
+// The annotations are removed when the GTID of the oldest backup is unknown, so binary logs are not purged.
+func setOldestBackupAnnotations(ctx context.Context, annotations map[string]string, backupStorage backup.BackupStorage,
+	processor backup.BackupProcessor, backupNames, deletedBackups []string, logger logr.Logger) {
+	availableBackups := slices.DeleteFunc(slices.Clone(backupNames), func(backup string) bool {
+		return slices.Contains(deletedBackups, backup)
+	})
+	oldest := backup.GetOldestCatalogEntry(ctx, availableBackups, processor, catalogManifestFn(backupStorage), logger)
+	if oldest == nil || oldest.GTID == "" {
+		delete(annotations, metadata.OldestGtidAnnotation)
+		delete(annotations, metadata.OldestTimeAnnotation)
+		return
+	}
+	annotations[metadata.OldestGtidAnnotation] = oldest.GTID
+	annotations[metadata.OldestTimeAnnotation] = oldest.Time.UTC().Format(time.RFC3339)
+}
+
 func getK8sClient() (client.Client, error) {
 	restConfig, err := ctrl.GetConfig()
 	if err != nil {
@@ -350,6 +751,17 @@ func getK8sClient() (client.Client, error) {
 }
 
 func getBackupGTID() (string, error) {
+	if streaming {
+		// Streamed backups are not extracted, the GTID is read from the backup information recorded by mariadb-backup instead.
+		info, err := readBackupInfo("")
+		if err != nil {
+			return "", fmt.Errorf("error reading backup info: %v", err)
+		}
+		if info.GTID == "" {
+			return "", errors.New("GTID not found in backup info")
+		}
+		return info.GTID, nil
+	}
 	metaFilePath := filepath.Join(physicalBackupDirPath, replication.MariaDBOperatorFileName)
 	bytes, err := os.ReadFile(metaFilePath)
 	if err != nil {
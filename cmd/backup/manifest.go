@@ -0,0 +1,196 @@
+package backup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/go-logr/logr"
+	mariadbv1alpha1 "github.com/mariadb-operator/mariadb-operator/v26/api/v1alpha1"
+	"github.com/mariadb-operator/mariadb-operator/v26/pkg/backup"
+	condition "github.com/mariadb-operator/mariadb-operator/v26/pkg/condition"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// getBackupInfo reads the server version and GTID recorded by mariadb-dump or mariadb-backup.
+// This information is informative, an empty BackupInfo is returned when it is not available.
+func getBackupInfo(backupTargetFile string) *backup.BackupInfo {
+	info, err := readBackupInfo(backupTargetFile)
+	if err != nil {
+		logger.Info("unable to read backup info", "file", backupTargetFile, "err", err)
+		return &backup.BackupInfo{}
+	}
+	return info
+}
+
+func readBackupInfo(backupTargetFile string) (*backup.BackupInfo, error) {
+	if backupContentType == string(mariadbv1alpha1.BackupContentTypePhysical) {
+		if physicalBackupDirPath == "" {
+			return nil, errors.New("physical backup directory not provided")
+		}
+		bytes, err := os.ReadFile(filepath.Join(physicalBackupDirPath, backup.XtrabackupInfoFileName))
+		if err != nil {
+			return nil, err
+		}
+		return backup.ParseXtrabackupInfo(bytes)
+	}
+
+	dumpFilePath := backup.GetFilePath(path, backupTargetFile)
+	if isParallelBackup(backupTargetFile) {
+		dumpFilePath = filepath.Join(logicalBackupDirPath, backup.ParallelBackupSchemaFileName)
+	}
+	file, err := os.Open(dumpFilePath)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	return backup.ReadDumpInfo(file)
+}
+
+func getFileSize(fileName string) (int64, error) {
+	info, err := os.Stat(backup.GetFilePath(path, fileName))
+	if err != nil {
+		return 0, err
+	}
+	return info.Size(), nil
+}
+
+// writeManifest computes the Manifest of the backup target file and writes it next to it.
+func writeManifest(backupTargetFile string, info *backup.BackupInfo, uncompressedSize int64) (*backup.Manifest, error) {
+	manifest, err := backup.NewManifestFromFile(
+		backup.GetFilePath(path, backupTargetFile),
+		manifestOpts(info, uncompressedSize)...,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if err := saveManifest(backupTargetFile, manifest); err != nil {
+		return nil, err
+	}
+	return manifest, nil
+}
+
+func manifestOpts(info *backup.BackupInfo, uncompressedSize int64) []backup.ManifestOpt {
+	return []backup.ManifestOpt{
+		backup.WithManifestUncompressedSize(uncompressedSize),
+		backup.WithManifestCompression(mariadbv1alpha1.CompressAlgorithm(compression)),
+		backup.WithManifestGTID(info.GTID),
+		backup.WithManifestServerVersion(info.ServerVersion),
+		backup.WithManifestMariaDB(mariadbName),
+		backup.WithManifestTargetPod(targetPod),
+	}
+}
+
+// saveManifest writes the Manifest next to the backup target file, to be pushed afterwards.
+func saveManifest(backupTargetFile string, manifest *backup.Manifest) error {
+	if err := manifest.Write(backup.GetFilePath(path, backup.ManifestFileName(backupTargetFile))); err != nil {
+		return fmt.Errorf("error writing manifest: %v", err)
+	}
+	return nil
+}
+
+// verifyStoredBackupSize checks that the backup that has just been pushed has the size recorded in its Manifest.
+// It does not read the backup back from the storage, the checksum is verified when restoring it.
+func verifyStoredBackupSize(ctx context.Context, backupStorage backup.BackupStorage, backupTargetFile string,
+	manifest *backup.Manifest) error {
+	size, err := backupStorage.Size(ctx, backupTargetFile)
+	if err != nil {
+		return fmt.Errorf("error getting size of stored backup: %v", err)
+	}
+	return manifest.VerifySize(size)
+}
+
+// verifyBackupFile verifies a pulled backup file against its Manifest.
+// Backups taken before manifests were introduced are not verified.
+func verifyBackupFile(ctx context.Context, backupStorage backup.BackupStorage, backupFile string) error {
+	manifest, err := pullManifest(ctx, backupStorage, backupFile)
+	if err != nil {
+		return err
+	}
+	if manifest == nil {
+		logger.Info("manifest not found, skipping integrity verification", "file", backupFile)
+		return nil
+	}
+
+	logger.Info("verifying backup integrity", "file", backupFile, "sha256", manifest.SHA256, "size", manifest.Size,
+		"gtid", manifest.GTID, "server-version", manifest.ServerVersion, "mariadb", manifest.MariaDB)
+	return manifest.VerifyFile(backup.GetFilePath(path, backupFile))
+}
+
+// pullManifest pulls and reads the Manifest of a backup. It returns nil when the backup does not have a Manifest.
+func pullManifest(ctx context.Context, backupStorage backup.BackupStorage, backupFile string) (*backup.Manifest, error) {
+	manifestFile := backup.ManifestFileName(backupFile)
+	exists, err := backupStorage.Exists(ctx, manifestFile)
+	if err != nil {
+		return nil, fmt.Errorf("error checking manifest existence: %v", err)
+	}
+	if !exists {
+		return nil, nil
+	}
+	if err := backupStorage.Pull(ctx, manifestFile); err != nil {
+		return nil, fmt.Errorf("error pulling manifest: %v", err)
+	}
+	return backup.ReadManifest(backup.GetFilePath(path, manifestFile))
+}
+
+// deleteManifest deletes the Manifest of a backup, if it exists.
+func deleteManifest(ctx context.Context, backupStorage backup.BackupStorage, backupFile string) error {
+	manifestFile := backup.ManifestFileName(backupFile)
+	exists, err := backupStorage.Exists(ctx, manifestFile)
+	if err != nil {
+		return fmt.Errorf("error checking manifest existence: %v", err)
+	}
+	if !exists {
+		return nil
+	}
+	return backupStorage.Delete(ctx, manifestFile)
+}
+
+// handleIntegrityStatus reports the integrity verification of the latest backup in the Backup or PhysicalBackup status.
+func handleIntegrityStatus(ctx context.Context, backupTargetFile string, verifyErr error, backupLogger logr.Logger) error {
+	var (
+		key         types.NamespacedName
+		obj         client.Object
+		conditioner condition.Conditioner
+	)
+	switch {
+	case backupContentType == string(mariadbv1alpha1.BackupContentTypeLogical) && backupName != "" && backupNamespace != "":
+		var logicalBackup mariadbv1alpha1.Backup
+		key = types.NamespacedName{Name: backupName, Namespace: backupNamespace}
+		obj, conditioner = &logicalBackup, &logicalBackup.Status
+	case backupContentType == string(mariadbv1alpha1.BackupContentTypePhysical) && physicalBackupName != "" && physicalBackupNamespace != "":
+		var physicalBackup mariadbv1alpha1.PhysicalBackup
+		key = types.NamespacedName{Name: physicalBackupName, Namespace: physicalBackupNamespace}
+		obj, conditioner = &physicalBackup, &physicalBackup.Status
+	default:
+		return nil
+	}
+	logger := backupLogger.WithValues("name", key.Name)
+	logger.Info("handling integrity status")
+
+	k8sClient, err := getK8sClient()
+	if err != nil {
+		return fmt.Errorf("error getting Kubernetes client: %v", err)
+	}
+	if err := k8sClient.Get(ctx, key, obj); err != nil {
+		return fmt.Errorf("error getting backup: %v", err)
+	}
+
+	patch := client.MergeFrom(obj.DeepCopyObject().(client.Object))
+	fileName := filepath.Base(backupTargetFile)
+	if verifyErr != nil {
+		condition.SetIntegrityVerificationFailed(conditioner, fileName, verifyErr)
+	} else {
+		condition.SetIntegrityVerified(conditioner, fileName)
+	}
+	if err := k8sClient.Status().Patch(ctx, obj, patch); err != nil {
+		return fmt.Errorf("error patching backup status: %v", err)
+	}
+
+	logger.Info("patched integrity status", "file", fileName, "verified", verifyErr == nil)
+	return nil
+}
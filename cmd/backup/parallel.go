@@ -0,0 +1,103 @@
+package backup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	mariadbv1alpha1 "github.com/mariadb-operator/mariadb-operator/v26/api/v1alpha1"
+	"github.com/mariadb-operator/mariadb-operator/v26/pkg/backup"
+)
+
+// bundleParallelBackup splits the dump streamed by mariadb-dump by table while it is being taken,
+// and bundles the resulting files together with a manifest into the backup target file.
+// The dump is never staged as a whole and the split files are removed as they are archived, bounding the disk usage to the size of the backup.
+// It returns the backup information recorded in the dump.
+func bundleParallelBackup(ctx context.Context, dumpStream *os.File, backupTargetFile string) (*backup.BackupInfo, error) {
+	if logicalBackupDirPath == "" {
+		return nil, errors.New("logical backup directory must be provided for parallel backups")
+	}
+	if dumpStream == nil {
+		return nil, errors.New("dump stream must be provided for parallel backups")
+	}
+	defer cleanupStream(dumpStream)
+
+	logger.Info("splitting dump stream", "file", dumpStream.Name(), "chunk-size", logicalBackupChunkSize)
+	source := &exitCodeReader{
+		ctx:          ctx,
+		reader:       dumpStream,
+		exitCodePath: backup.StreamExitCodePath(dumpStream.Name()),
+	}
+	manifest, err := backup.SplitDump(source, logicalBackupDirPath, logicalBackupChunkSize)
+	if err != nil {
+		return nil, fmt.Errorf("error splitting dump: %v", err)
+	}
+	if err := manifest.Write(filepath.Join(logicalBackupDirPath, backup.ParallelBackupManifestFileName)); err != nil {
+		return nil, fmt.Errorf("error writing manifest: %v", err)
+	}
+	logger.Info("split dump", "files", len(manifest.Files), "databases", manifest.Databases, "gtid", manifest.GTID)
+
+	// The preamble of the dump, where the backup information is recorded, is kept in the schema file.
+	info := getBackupInfo(backupTargetFile)
+
+	archivePath := backup.GetFilePath(path, backupTargetFile)
+	logger.Info("bundling parallel backup", "file", archivePath)
+	if err := backup.ArchiveDir(logicalBackupDirPath, archivePath); err != nil {
+		return nil, fmt.Errorf("error bundling parallel backup: %v", err)
+	}
+	if err := os.RemoveAll(logicalBackupDirPath); err != nil {
+		return nil, fmt.Errorf("error cleaning up logical backup directory: %v", err)
+	}
+	return info, nil
+}
+
+// cleanupStream closes and removes the named pipe used to stream the dump, together with the exit code recorded by mariadb-dump.
+func cleanupStream(stream *os.File) {
+	if err := stream.Close(); err != nil {
+		logger.Error(err, "error closing dump stream", "file", stream.Name())
+	}
+	for _, filePath := range []string{stream.Name(), backup.StreamExitCodePath(stream.Name())} {
+		if err := os.Remove(filePath); err != nil && !os.IsNotExist(err) {
+			logger.Error(err, "error removing dump stream file", "file", filePath)
+		}
+	}
+}
+
+// extractParallelBackup extracts a parallel backup into the logical backup directory, verifying the checksums of the manifest.
+// It returns the directory where the backup has been extracted.
+func extractParallelBackup(backupFile string) (string, error) {
+	if !isParallelBackup(backupFile) {
+		return backupFile, nil
+	}
+	if logicalBackupDirPath == "" {
+		return "", errors.New("logical backup directory must be provided for parallel backups")
+	}
+	if err := os.RemoveAll(logicalBackupDirPath); err != nil {
+		return "", fmt.Errorf("error cleaning up logical backup directory: %v", err)
+	}
+
+	archivePath := backup.GetFilePath(path, backupFile)
+	logger.Info("extracting parallel backup", "file", archivePath, "dir-path", logicalBackupDirPath)
+	if err := backup.ExtractArchive(archivePath, logicalBackupDirPath); err != nil {
+		return "", fmt.Errorf("error extracting parallel backup: %v", err)
+	}
+	if err := os.Remove(archivePath); err != nil {
+		return "", fmt.Errorf("error removing parallel backup archive: %v", err)
+	}
+
+	manifest, err := backup.ReadParallelBackupManifest(filepath.Join(logicalBackupDirPath, backup.ParallelBackupManifestFileName))
+	if err != nil {
+		return "", err
+	}
+	logger.Info("verifying parallel backup", "files", len(manifest.Files), "databases", manifest.Databases, "gtid", manifest.GTID)
+	if err := manifest.Verify(logicalBackupDirPath); err != nil {
+		return "", fmt.Errorf("error verifying parallel backup: %v", err)
+	}
+	return logicalBackupDirPath, nil
+}
+
+func isParallelBackup(backupFile string) bool {
+	return backupContentType == string(mariadbv1alpha1.BackupContentTypeLogical) && backup.IsParallelBackupFile(backupFile)
+}
@@ -0,0 +1,101 @@
+package backup
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	mariadbv1alpha1 "github.com/mariadb-operator/mariadb-operator/v26/api/v1alpha1"
+	"github.com/mariadb-operator/mariadb-operator/v26/pkg/progress"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var (
+	progressInterval time.Duration
+	restoreName      string
+	restoreNamespace string
+
+	progressTracker     *progress.Tracker
+	progressTrackerOnce sync.Once
+)
+
+func init() {
+	RootCmd.PersistentFlags().DurationVar(&progressInterval, "progress-interval", 10*time.Second,
+		"Interval at which the progress is reported in the status of the backup or restore custom resource.")
+
+	restoreCommand.Flags().StringVar(&restoreName, "restore-name", "",
+		"Restore custom resource name to report the restoration progress.")
+	restoreCommand.Flags().StringVar(&restoreNamespace, "restore-namespace", "",
+		"Restore custom resource namespace to report the restoration progress.")
+}
+
+// getProgressTracker returns the Tracker shared by all the storage clients and compressors.
+// It returns nil when there is no custom resource to report the progress to, as progress reporting is best effort.
+func getProgressTracker() *progress.Tracker {
+	progressTrackerOnce.Do(func() {
+		key, _, _, ok := progressObject()
+		if !ok {
+			return
+		}
+		k8sClient, err := getK8sClient()
+		if err != nil {
+			logger.Error(err, "error getting Kubernetes client, progress will not be reported")
+			return
+		}
+		logger.Info("reporting progress", "name", key.Name, "interval", progressInterval.String())
+		progressTracker = progress.NewTracker(
+			func(ctx context.Context, progress *mariadbv1alpha1.BackupProgress) error {
+				return patchProgress(ctx, k8sClient, progress)
+			},
+			logger.WithName("progress"),
+		)
+	})
+	return progressTracker
+}
+
+// progressObject returns the custom resource where the progress is reported, along with a function that sets it in its status.
+func progressObject() (types.NamespacedName, client.Object, func(*mariadbv1alpha1.BackupProgress), bool) {
+	switch {
+	case restoreName != "" && restoreNamespace != "":
+		var restore mariadbv1alpha1.Restore
+		return types.NamespacedName{Name: restoreName, Namespace: restoreNamespace}, &restore,
+			func(p *mariadbv1alpha1.BackupProgress) { restore.Status.Progress = p }, true
+	case backupContentType == string(mariadbv1alpha1.BackupContentTypeLogical) && backupName != "" && backupNamespace != "":
+		var logicalBackup mariadbv1alpha1.Backup
+		return types.NamespacedName{Name: backupName, Namespace: backupNamespace}, &logicalBackup,
+			func(p *mariadbv1alpha1.BackupProgress) { logicalBackup.Status.Progress = p }, true
+	case backupContentType == string(mariadbv1alpha1.BackupContentTypePhysical) && physicalBackupName != "" && physicalBackupNamespace != "":
+		var physicalBackup mariadbv1alpha1.PhysicalBackup
+		return types.NamespacedName{Name: physicalBackupName, Namespace: physicalBackupNamespace}, &physicalBackup,
+			func(p *mariadbv1alpha1.BackupProgress) { physicalBackup.Status.Progress = p }, true
+	default:
+		return types.NamespacedName{}, nil, nil, false
+	}
+}
+
+func patchProgress(ctx context.Context, k8sClient client.Client, progress *mariadbv1alpha1.BackupProgress) error {
+	key, obj, setProgress, ok := progressObject()
+	if !ok {
+		return nil
+	}
+	if err := k8sClient.Get(ctx, key, obj); err != nil {
+		return fmt.Errorf("error getting object: %v", err)
+	}
+
+	patch := client.MergeFrom(obj.DeepCopyObject().(client.Object))
+	setProgress(progress)
+	if err := k8sClient.Status().Patch(ctx, obj, patch); err != nil {
+		return fmt.Errorf("error patching progress: %v", err)
+	}
+	return nil
+}
+
+// nextRestorePhase returns the phase that follows the download of the backup, which is performed by mariadb or mariadb-backup.
+func nextRestorePhase() mariadbv1alpha1.BackupProgressPhase {
+	if backupContentType == string(mariadbv1alpha1.BackupContentTypePhysical) {
+		return mariadbv1alpha1.BackupProgressPhasePrepare
+	}
+	return mariadbv1alpha1.BackupProgressPhaseApply
+}
@@ -1,22 +1,37 @@
 package backup
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"time"
 
+	"github.com/go-logr/logr"
 	mariadbv1alpha1 "github.com/mariadb-operator/mariadb-operator/v26/api/v1alpha1"
 	"github.com/mariadb-operator/mariadb-operator/v26/pkg/backup"
 	mdbcompression "github.com/mariadb-operator/mariadb-operator/v26/pkg/compression"
 	"github.com/mariadb-operator/mariadb-operator/v26/pkg/log"
+	"github.com/mariadb-operator/mariadb-operator/v26/pkg/replication"
 	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
-var targetTimeRaw string
+var (
+	targetTimeRaw string
+	targetGtidRaw string
+	restoreTables []string
+)
 
 func init() {
 	restoreCommand.Flags().StringVar(&targetTimeRaw, "target-time", "",
 		"RFC3339 (1970-01-01T00:00:00Z) date and time that defines the backup target time.")
+	restoreCommand.Flags().StringVar(&targetGtidRaw, "target-gtid", "",
+		"GTID (0-10-42) that defines the backup target. The most recent backup whose manifest GTID is not after it is restored. "+
+			"It takes precedence over target-time.")
+	restoreCommand.Flags().StringSliceVar(&restoreTables, "tables", nil,
+		"Table patterns in the 'database.table' format to be restored, where '*' matches any sequence of characters. "+
+			"Only considered when backup-content-type is Logical.")
 }
 
 var restoreCommand = &cobra.Command{
@@ -32,6 +47,17 @@ var restoreCommand = &cobra.Command{
 
 		ctx, cancel := newContext()
 		defer cancel()
+		go getProgressTracker().Run(ctx, progressInterval)
+
+		var restoreStream *os.File
+		if streaming {
+			stream, err := openRestoreStream()
+			if err != nil {
+				logger.Error(err, "error opening restore stream")
+				os.Exit(1)
+			}
+			restoreStream = stream
+		}
 
 		if err := cleanupStaleStagingArea(); err != nil {
 			logger.Error(err, "error cleaning up stale staging area")
@@ -44,7 +70,13 @@ var restoreCommand = &cobra.Command{
 			os.Exit(1)
 		}
 
-		backupStorage, err := getBackupStorage(backupProcessor)
+		keyring, err := getEncryptionKeyring()
+		if err != nil {
+			logger.Error(err, "error getting encryption keyring")
+			os.Exit(1)
+		}
+
+		backupStorage, err := getBackupStorage(backupProcessor, keyring)
 		if err != nil {
 			logger.Error(err, "error getting backup storage")
 			os.Exit(1)
@@ -57,41 +89,95 @@ var restoreCommand = &cobra.Command{
 		}
 		logger.Info("obtained target time", "time", targetTime.String())
 
-		backupFileNames, err := backupStorage.List(ctx)
+		backupStorage, backupFileNames, err := getRestoreStorage(ctx, backupStorage, backupProcessor, keyring)
 		if err != nil {
 			logger.Error(err, "error listing backup files")
 			os.Exit(1)
 		}
 
-		backupTargetFile, err := backupProcessor.GetBackupTargetFile(backupFileNames, targetTime, logger.WithName("target-recovery-time"))
+		backupTargetFile, err := getBackupTargetFile(ctx, backupStorage, backupProcessor, backupFileNames, targetTime)
 		if err != nil {
 			logger.Error(err, "error reading getting target backup")
 			os.Exit(1)
 		}
 		logger.Info("obtained target backup", "file", backupTargetFile)
 
-		logger.Info("pulling target backup", "file", backupTargetFile, "prefix", s3Prefix)
-		if err := backupStorage.Pull(ctx, backupTargetFile); err != nil {
-			logger.Error(err, "error pulling target backup", "file", backupTargetFile, "prefix", s3Prefix)
+		backupChain, err := getBackupChain(ctx, backupStorage, backupTargetFile)
+		if err != nil {
+			logger.Error(err, "error getting backup chain", "file", backupTargetFile)
 			os.Exit(1)
 		}
+		if len(backupChain) > 1 {
+			logger.Info("obtained incremental backup chain", "backups", backupChain)
+		}
+
+		tracker := getProgressTracker()
+		tracker.StartPhase(ctx, mariadbv1alpha1.BackupProgressPhaseDownload, 0)
+		if streaming {
+			if err := streamRestore(ctx, backupStorage, backupProcessor, keyring, backupChain, restoreStream); err != nil {
+				logger.Error(err, "error streaming backup chain", "file", backupTargetFile)
+				os.Exit(1)
+			}
+			tracker.EndPhase(ctx)
+			tracker.StartPhase(ctx, nextRestorePhase(), 0)
+			return
+		}
 
-		backupCompressor, err := getBackupCompressorWithFile(backupTargetFile, backupProcessor)
+		var backupFiles []string
+		for _, backupFile := range backupChain {
+			logger.Info("pulling target backup", "file", backupFile, "prefix", s3Prefix)
+			if err := backupStorage.Pull(ctx, backupFile); err != nil {
+				logger.Error(err, "error pulling target backup", "file", backupFile, "prefix", s3Prefix)
+				os.Exit(1)
+			}
+			if err := verifyBackupFile(ctx, backupStorage, backupFile); err != nil {
+				logger.Error(err, "error verifying backup integrity", "file", backupFile)
+				os.Exit(1)
+			}
+
+			backupCompressor, err := getBackupCompressorWithFile(backupFile, backupProcessor, keyring)
+			if err != nil {
+				logger.Error(err, "error getting backup compressor")
+				os.Exit(1)
+			}
+			backupFile, err = backupCompressor.Decompress(backupFile)
+			if err != nil {
+				logger.Error(err, "error decompressing backup", "file", backupFile)
+				os.Exit(1)
+			}
+			backupFiles = append(backupFiles, backupFile)
+		}
+		tracker.EndPhase(ctx)
+
+		backupFiles[0], err = extractParallelBackup(backupFiles[0])
 		if err != nil {
-			logger.Error(err, "error getting backup compressor")
+			logger.Error(err, "error extracting parallel backup", "file", backupFiles[0])
 			os.Exit(1)
 		}
-		backupTargetFile, err = backupCompressor.Decompress(backupTargetFile)
-		if err != nil {
-			logger.Error(err, "error decompressing backup", "file", backupTargetFile)
+
+		if err := filterTables(backupFiles[0]); err != nil {
+			logger.Error(err, "error filtering tables", "file", backupFiles[0])
+			os.Exit(1)
+		}
+		if err := handleRestoredGtid(ctx, backupStorage, backupTargetFile, logger.WithName("restored-gtid")); err != nil {
+			logger.Error(err, "error handling restored GTID", "file", backupTargetFile)
 			os.Exit(1)
 		}
 
-		logger.Info("writing target file", "file", targetFilePath, "file-content", backupTargetFile)
-		if err := writeTargetFile(backupTargetFile); err != nil {
-			logger.Error(err, "error writing target file", "file", backupTargetFile)
+		logger.Info("writing target file", "file", targetFilePath, "file-content", backupFiles[0])
+		if err := writeTargetFile(backupFiles[0]); err != nil {
+			logger.Error(err, "error writing target file", "file", backupFiles[0])
 			os.Exit(1)
 		}
+		if backupContentType == string(mariadbv1alpha1.BackupContentTypePhysical) {
+			incrementalTargetFilePath := backup.IncrementalTargetFilePath(targetFilePath)
+			logger.Info("writing incremental target file", "file", incrementalTargetFilePath, "backups", len(backupFiles)-1)
+			if err := writeIncrementalTargetFile(backupFiles[1:]); err != nil {
+				logger.Error(err, "error writing incremental target file", "file", incrementalTargetFilePath)
+				os.Exit(1)
+			}
+		}
+		tracker.StartPhase(ctx, nextRestorePhase(), 0)
 	},
 }
 
@@ -129,14 +215,78 @@ func getTargetTime() (time.Time, error) {
 	return backup.ParseBackupDate(targetTimeRaw)
 }
 
+func getBackupTargetFile(ctx context.Context, backupStorage backup.BackupStorage, backupProcessor backup.BackupProcessor,
+	backupFileNames []string, targetTime time.Time) (string, error) {
+	if targetGtidRaw == "" {
+		return backupProcessor.GetBackupTargetFile(backupFileNames, targetTime, logger.WithName("target-recovery-time"))
+	}
+	targetGtid, err := replication.ParseGtid(targetGtidRaw)
+	if err != nil {
+		return "", fmt.Errorf("error parsing target GTID: %v", err)
+	}
+	logger.Info("obtained target GTID", "gtid", targetGtid.String())
+
+	return backup.GetBackupTargetFileWithGtid(ctx, backupFileNames, backupProcessor, catalogManifestFn(backupStorage), targetGtid,
+		logger.WithName("target-recovery-gtid"))
+}
+
+// handleRestoredGtid reports the GTID of the logical backup being restored in the Restore status,
+// so the archived binary logs can be replayed starting from it. Backups without a GTID in their Manifest are not reported.
+func handleRestoredGtid(ctx context.Context, backupStorage backup.BackupStorage, backupTargetFile string,
+	restoreLogger logr.Logger) error {
+	if backupContentType != string(mariadbv1alpha1.BackupContentTypeLogical) || restoreName == "" || restoreNamespace == "" {
+		return nil
+	}
+	logger := restoreLogger.WithValues("restore", restoreName)
+
+	manifest, err := catalogManifestFn(backupStorage)(ctx, backupTargetFile)
+	if err != nil {
+		return fmt.Errorf("error getting manifest: %v", err)
+	}
+	if manifest == nil || manifest.GTID == "" {
+		logger.Info("GTID not found in backup manifest. Skipping...", "file", backupTargetFile)
+		return nil
+	}
+
+	k8sClient, err := getK8sClient()
+	if err != nil {
+		return fmt.Errorf("error getting Kubernetes client: %v", err)
+	}
+	key := types.NamespacedName{
+		Name:      restoreName,
+		Namespace: restoreNamespace,
+	}
+	var restore mariadbv1alpha1.Restore
+	if err := k8sClient.Get(ctx, key, &restore); err != nil {
+		return fmt.Errorf("error getting Restore: %v", err)
+	}
+
+	patch := client.MergeFrom(restore.DeepCopy())
+	restore.Status.RestoredGtid = manifest.GTID
+	if err := k8sClient.Status().Patch(ctx, &restore, patch); err != nil {
+		return fmt.Errorf("error patching Restore status: %v", err)
+	}
+
+	logger.Info("patched Restore with GTID", "gtid", manifest.GTID)
+	return nil
+}
+
 func writeTargetFile(backupTargetFile string) error {
 	return os.WriteFile(targetFilePath, []byte(backupTargetFile), 0777)
 }
 
-func getBackupCompressorWithFile(fileName string, processor backup.BackupProcessor) (mdbcompression.BackupCompressor, error) {
+func getBackupCompressorWithFile(fileName string, processor backup.BackupProcessor,
+	keyring *mdbcompression.Keyring) (mdbcompression.BackupCompressor, error) {
 	calg, err := processor.ParseCompressionAlgorithm(fileName)
 	if err != nil {
 		return nil, fmt.Errorf("error parsing compression algorithm: %v", err)
 	}
-	return mdbcompression.NewBackupCompressor(calg, path, processor.GetUncompressedBackupFile, logger)
+	// Backups are decrypted using the key recorded in the file, unencrypted backups are left untouched.
+	return mdbcompression.NewBackupCompressor(
+		calg,
+		path,
+		processor.GetUncompressedBackupFile,
+		logger,
+		mdbcompression.WithKeyring(keyring),
+	)
 }
@@ -0,0 +1,233 @@
+package backup
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/go-logr/logr"
+	mariadbv1alpha1 "github.com/mariadb-operator/mariadb-operator/v26/api/v1alpha1"
+	"github.com/mariadb-operator/mariadb-operator/v26/pkg/backup"
+	mdbcompression "github.com/mariadb-operator/mariadb-operator/v26/pkg/compression"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// getSecondaryStorages parses the secondary storages provided in JSON format.
+func getSecondaryStorages() ([]mariadbv1alpha1.SecondaryStorage, error) {
+	if secondaryStoragesRaw == "" {
+		return nil, nil
+	}
+	var storages []mariadbv1alpha1.SecondaryStorage
+	if err := json.Unmarshal([]byte(secondaryStoragesRaw), &storages); err != nil {
+		return nil, fmt.Errorf("error unmarshaling secondary storages: %v", err)
+	}
+	if err := mariadbv1alpha1.ValidateSecondaryStorages(storages); err != nil {
+		return nil, err
+	}
+	return storages, nil
+}
+
+func getSecondaryBackupStorage(index int, storage *mariadbv1alpha1.SecondaryStorage, stagingPath string,
+	processor backup.BackupProcessor, keyring *mdbcompression.Keyring) (backup.BackupStorage, error) {
+	return backup.NewSecondaryBackupStorage(
+		index,
+		storage,
+		stagingPath,
+		processor,
+		logger.WithName("secondary-storage").WithValues("name", storage.Name),
+		backup.WithSecondaryStorageMetadata(getObjectMetadata(keyring)),
+		backup.WithSecondaryStorageRateLimiter(getRateLimiter()),
+	)
+}
+
+// replicateBackup copies the backup and its manifest to the secondary storages and applies their retention policy.
+// Errors are reported in the returned statuses, as the backup has already been stored in the primary storage.
+func replicateBackup(ctx context.Context, storages []mariadbv1alpha1.SecondaryStorage, processor backup.BackupProcessor,
+	keyring *mdbcompression.Keyring, backupTargetFile string, chainLink *mariadbv1alpha1.PhysicalBackupChainLink,
+	backupLogger logr.Logger) []mariadbv1alpha1.SecondaryStorageStatus {
+	statuses := make([]mariadbv1alpha1.SecondaryStorageStatus, len(storages))
+	for i, storage := range storages {
+		logger := backupLogger.WithValues("name", storage.Name)
+		logger.Info("replicating backup", "file", backupTargetFile)
+
+		now := metav1.NewTime(time.Now())
+		statuses[i] = mariadbv1alpha1.SecondaryStorageStatus{
+			Name:                storage.Name,
+			FileName:            filepath.Base(backupTargetFile),
+			LastReplicationTime: &now,
+		}
+		if err := replicateBackupToStorage(ctx, i, &storage, processor, keyring, backupTargetFile, chainLink, logger); err != nil {
+			logger.Error(err, "error replicating backup", "file", backupTargetFile)
+			statuses[i].Message = err.Error()
+			continue
+		}
+		statuses[i].Succeeded = true
+		statuses[i].LastSuccessfulReplicationTime = &now
+	}
+	return statuses
+}
+
+func replicateBackupToStorage(ctx context.Context, index int, storage *mariadbv1alpha1.SecondaryStorage,
+	processor backup.BackupProcessor, keyring *mdbcompression.Keyring, backupTargetFile string,
+	chainLink *mariadbv1alpha1.PhysicalBackupChainLink, logger logr.Logger) error {
+	backupStorage, err := getSecondaryBackupStorage(index, storage, path, processor, keyring)
+	if err != nil {
+		return fmt.Errorf("error getting secondary storage: %v", err)
+	}
+	for _, file := range []string{backupTargetFile, backup.ManifestFileName(backupTargetFile)} {
+		logger.Info("pushing file", "file", file)
+		if err := backupStorage.Push(ctx, file); err != nil {
+			return fmt.Errorf("error pushing file %s: %v", file, err)
+		}
+	}
+
+	// The chain index of the secondary storage is staged in a dedicated directory,
+	// as the staging path may be the primary storage itself.
+	indexStagingPath := filepath.Join(path, fmt.Sprintf(".secondary-storage-%d", index))
+	if err := os.MkdirAll(indexStagingPath, 0755); err != nil {
+		return fmt.Errorf("error creating chain index staging path: %v", err)
+	}
+	defer func() {
+		if err := os.RemoveAll(indexStagingPath); err != nil {
+			logger.Error(err, "error cleaning up chain index staging path", "path", indexStagingPath)
+		}
+	}()
+	indexStorage, err := getSecondaryBackupStorage(index, storage, indexStagingPath, processor, keyring)
+	if err != nil {
+		return fmt.Errorf("error getting secondary storage: %v", err)
+	}
+	chainIndex, err := getChainIndex(ctx, indexStorage, indexStagingPath)
+	if err != nil {
+		return fmt.Errorf("error getting chain index: %v", err)
+	}
+	if chainIndex != nil && chainLink != nil {
+		chainIndex.Add(*chainLink)
+	}
+
+	backupNames, err := backupStorage.List(ctx)
+	if err != nil {
+		return fmt.Errorf("error listing backup files: %v", err)
+	}
+	oldBackups := processor.GetOldBackupFiles(backupNames, getSecondaryRetentionPolicy(storage), logger)
+	if chainIndex != nil {
+		oldBackups = chainIndex.ProtectDependencies(backupNames, oldBackups, logger)
+	}
+	oldBackups = backup.SkipLockedBackupFiles(ctx, backupStorage, oldBackups, logger)
+	logger.Info("old backups to delete", "backups", len(oldBackups))
+	var deletedBackups []string
+	for _, backup := range oldBackups {
+		logger.Info("deleting old backup", "backup", backup)
+		if err := backupStorage.Delete(ctx, backup); err != nil {
+			logger.Error(err, "error removing old backup", "backup", backup)
+			continue
+		}
+		if err := deleteManifest(ctx, backupStorage, backup); err != nil {
+			logger.Error(err, "error removing manifest of old backup", "backup", backup)
+		}
+		deletedBackups = append(deletedBackups, backup)
+	}
+
+	if chainIndex != nil {
+		if err := writeChainIndex(ctx, indexStorage, indexStagingPath, chainIndex, backupNames, deletedBackups); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// getSecondaryRetentionPolicy returns the retention policy of a secondary storage, defaulting to the one of the primary storage.
+func getSecondaryRetentionPolicy(storage *mariadbv1alpha1.SecondaryStorage) backup.RetentionPolicy {
+	if !storage.HasRetention() {
+		return getRetentionPolicy()
+	}
+	retention := ptr.Deref(storage.MaxRetention, metav1.Duration{Duration: maxRetention})
+	return backup.NewRetentionPolicy(retention.Duration, storage.Retention)
+}
+
+// handleSecondaryStorageStatus reports the replication to the secondary storages in the Backup or PhysicalBackup status.
+func handleSecondaryStorageStatus(ctx context.Context, statuses []mariadbv1alpha1.SecondaryStorageStatus,
+	backupLogger logr.Logger) error {
+	if len(statuses) == 0 {
+		return nil
+	}
+	var (
+		key             types.NamespacedName
+		obj             client.Object
+		storageStatuses *[]mariadbv1alpha1.SecondaryStorageStatus
+		logicalBackup   mariadbv1alpha1.Backup
+		physicalBackup  mariadbv1alpha1.PhysicalBackup
+	)
+	switch {
+	case backupContentType == string(mariadbv1alpha1.BackupContentTypeLogical) && backupName != "" && backupNamespace != "":
+		key = types.NamespacedName{Name: backupName, Namespace: backupNamespace}
+		obj, storageStatuses = &logicalBackup, &logicalBackup.Status.SecondaryStorages
+	case backupContentType == string(mariadbv1alpha1.BackupContentTypePhysical) && physicalBackupName != "" && physicalBackupNamespace != "":
+		key = types.NamespacedName{Name: physicalBackupName, Namespace: physicalBackupNamespace}
+		obj, storageStatuses = &physicalBackup, &physicalBackup.Status.SecondaryStorages
+	default:
+		return nil
+	}
+	logger := backupLogger.WithValues("name", key.Name)
+	logger.Info("handling secondary storage status")
+
+	k8sClient, err := getK8sClient()
+	if err != nil {
+		return fmt.Errorf("error getting Kubernetes client: %v", err)
+	}
+	if err := k8sClient.Get(ctx, key, obj); err != nil {
+		return fmt.Errorf("error getting backup: %v", err)
+	}
+
+	patch := client.MergeFrom(obj.DeepCopyObject().(client.Object))
+	for _, status := range statuses {
+		mariadbv1alpha1.SetSecondaryStorageStatus(storageStatuses, status)
+	}
+	if err := k8sClient.Status().Patch(ctx, obj, patch); err != nil {
+		return fmt.Errorf("error patching backup status: %v", err)
+	}
+
+	logger.Info("patched secondary storage status", "storages", len(statuses))
+	return nil
+}
+
+// getRestoreStorage lists the backups from the primary storage, falling back to the secondary storages in order
+// when the primary storage is unreachable.
+func getRestoreStorage(ctx context.Context, primary backup.BackupStorage, processor backup.BackupProcessor,
+	keyring *mdbcompression.Keyring) (backup.BackupStorage, []string, error) {
+	backupFileNames, primaryErr := primary.List(ctx)
+	if primaryErr == nil {
+		return primary, backupFileNames, nil
+	}
+	storages, err := getSecondaryStorages()
+	if err != nil {
+		return nil, nil, errors.Join(primaryErr, err)
+	}
+	if len(storages) == 0 {
+		return nil, nil, primaryErr
+	}
+	logger.Error(primaryErr, "error listing backup files from primary storage, falling back to secondary storages")
+
+	errs := []error{primaryErr}
+	for i, storage := range storages {
+		backupStorage, err := getSecondaryBackupStorage(i, &storage, path, processor, keyring)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("error getting secondary storage '%s': %v", storage.Name, err))
+			continue
+		}
+		backupFileNames, err := backupStorage.List(ctx)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("error listing backup files from secondary storage '%s': %v", storage.Name, err))
+			continue
+		}
+		logger.Info("restoring from secondary storage", "name", storage.Name)
+		return backupStorage, backupFileNames, nil
+	}
+	return nil, nil, errors.Join(errs...)
+}
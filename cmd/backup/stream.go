@@ -0,0 +1,313 @@
+package backup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"time"
+
+	mariadbv1alpha1 "github.com/mariadb-operator/mariadb-operator/v26/api/v1alpha1"
+	"github.com/mariadb-operator/mariadb-operator/v26/pkg/backup"
+	mdbcompression "github.com/mariadb-operator/mariadb-operator/v26/pkg/compression"
+	"github.com/mariadb-operator/mariadb-operator/v26/pkg/interfaces"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+const streamPollInterval = 1 * time.Second
+
+// openBackupStream waits for mariadb-backup or mariadb-dump to create the named pipe and opens it for reading.
+// It is opened before anything else, so the backup fails if mariadb-operator exits prematurely.
+func openBackupStream(ctx context.Context) (*os.File, error) {
+	fifoPath := backup.StreamFifoPath(path, 0)
+	logger.Info("waiting for backup stream", "file", fifoPath)
+
+	// The target file is written after creating the named pipe.
+	if err := waitForFile(ctx, targetFilePath); err != nil {
+		return nil, fmt.Errorf("error waiting for target file: %v", err)
+	}
+	fifo, err := os.Open(fifoPath)
+	if err != nil {
+		return nil, fmt.Errorf("error opening backup stream: %v", err)
+	}
+	return fifo, nil
+}
+
+// streamBackup compresses the backup read from the named pipe and uploads it to the object storage in parts,
+// computing its Manifest on the fly. The upload is only completed when mariadb-backup has succeeded.
+func streamBackup(ctx context.Context, backupStorage backup.BackupStorage, keyring *mdbcompression.Keyring,
+	fifo *os.File, backupTargetFile string) (*backup.Manifest, error) {
+	streamingStorage, ok := backupStorage.(backup.StreamingBackupStorage)
+	if !ok {
+		return nil, errors.New("backup storage does not support streaming")
+	}
+	compressor, err := getStreamCompressor(keyring)
+	if err != nil {
+		return nil, fmt.Errorf("error getting compressor: %v", err)
+	}
+
+	source := &countingReader{
+		reader: &exitCodeReader{
+			ctx:          ctx,
+			reader:       fifo,
+			exitCodePath: backup.StreamExitCodePath(fifo.Name()),
+		},
+	}
+	pr, pw := io.Pipe()
+	defer pr.Close()
+	go func() {
+		pw.CloseWithError(compressor.Compress(ctx, pw, source))
+	}()
+
+	manifestWriter := backup.NewManifestWriter()
+	logger.Info("streaming target backup", "file", backupTargetFile, "part-size", streamPartSize,
+		"max-part-retries", streamMaxPartRetries)
+	// The backup is taken, compressed and uploaded concurrently, the progress is tracked by the bytes uploaded.
+	tracker := getProgressTracker()
+	tracker.StartPhase(ctx, mariadbv1alpha1.BackupProgressPhaseUpload, 0)
+	if err := streamingStorage.PushStream(ctx, backupTargetFile, io.TeeReader(pr, manifestWriter), interfaces.StreamOpts{
+		PartSize:       streamPartSize,
+		MaxPartRetries: streamMaxPartRetries,
+	}); err != nil {
+		return nil, fmt.Errorf("error pushing backup stream: %v", err)
+	}
+	tracker.EndPhase(ctx)
+
+	// The backup information is written by mariadb-backup once the backup has been completed.
+	info := getBackupInfo(backupTargetFile)
+	manifest := manifestWriter.Manifest(filepath.Base(backupTargetFile), manifestOpts(info, source.size)...)
+	if err := saveManifest(backupTargetFile, manifest); err != nil {
+		return nil, err
+	}
+	return manifest, nil
+}
+
+// openRestoreStream creates the named pipe of the full backup and opens it for writing, which blocks until mariadb-backup reads it.
+// It is opened before anything else, so mariadb-backup fails if mariadb-operator exits prematurely.
+func openRestoreStream() (*os.File, error) {
+	fifoPath := backup.StreamFifoPath(path, 0)
+	if err := createFifo(fifoPath); err != nil {
+		return nil, err
+	}
+	logger.Info("writing target file", "file", targetFilePath, "file-content", fifoPath)
+	if err := writeTargetFile(fifoPath); err != nil {
+		return nil, fmt.Errorf("error writing target file: %v", err)
+	}
+
+	logger.Info("waiting for restore stream to be read", "file", fifoPath)
+	fifo, err := os.OpenFile(fifoPath, os.O_WRONLY, 0)
+	if err != nil {
+		return nil, fmt.Errorf("error opening restore stream: %v", err)
+	}
+	return fifo, nil
+}
+
+// streamRestore streams the backup chain into the named pipes read by mariadb-backup, one per backup.
+// Each named pipe is marked as verified once the backup has been completely written and it matches its Manifest.
+func streamRestore(ctx context.Context, backupStorage backup.BackupStorage, processor backup.BackupProcessor,
+	keyring *mdbcompression.Keyring, backupChain []string, fifo *os.File) error {
+	streamingStorage, ok := backupStorage.(backup.StreamingBackupStorage)
+	if !ok {
+		return errors.New("backup storage does not support streaming")
+	}
+
+	fifoPaths := []string{fifo.Name()}
+	for i := 1; i < len(backupChain); i++ {
+		fifoPath := backup.StreamFifoPath(path, i)
+		if err := createFifo(fifoPath); err != nil {
+			abortStreams(fifoPaths[1:])
+			return err
+		}
+		fifoPaths = append(fifoPaths, fifoPath)
+	}
+	incrementalTargetFilePath := backup.IncrementalTargetFilePath(targetFilePath)
+	logger.Info("writing incremental target file", "file", incrementalTargetFilePath, "backups", len(fifoPaths)-1)
+	if err := writeIncrementalTargetFile(fifoPaths[1:]); err != nil {
+		abortStreams(fifoPaths[1:])
+		return fmt.Errorf("error writing incremental target file: %v", err)
+	}
+
+	for i, backupFile := range backupChain {
+		if i > 0 {
+			logger.Info("waiting for restore stream to be read", "file", fifoPaths[i])
+			var err error
+			if fifo, err = os.OpenFile(fifoPaths[i], os.O_WRONLY, 0); err != nil {
+				abortStreams(fifoPaths[i:])
+				return fmt.Errorf("error opening restore stream: %v", err)
+			}
+		}
+		logger.Info("streaming target backup", "file", backupFile, "stream", fifoPaths[i])
+		err := streamBackupFile(ctx, streamingStorage, processor, keyring, backupFile, fifo)
+		if closeErr := fifo.Close(); closeErr != nil && err == nil {
+			err = fmt.Errorf("error closing restore stream: %v", closeErr)
+		}
+		if err != nil {
+			abortStreams(fifoPaths[i+1:])
+			return fmt.Errorf("error streaming backup %s: %v", backupFile, err)
+		}
+	}
+	return nil
+}
+
+// streamBackupFile pulls a backup, verifying it against its Manifest, and writes it decompressed into the named pipe.
+func streamBackupFile(ctx context.Context, backupStorage backup.StreamingBackupStorage, processor backup.BackupProcessor,
+	keyring *mdbcompression.Keyring, backupFile string, fifo *os.File) error {
+	manifest, err := pullManifest(ctx, backupStorage, backupFile)
+	if err != nil {
+		return err
+	}
+	calg, err := processor.ParseCompressionAlgorithm(backupFile)
+	if err != nil {
+		return fmt.Errorf("error parsing compression algorithm: %v", err)
+	}
+	// Backups are decrypted using the key recorded in the stream, unencrypted backups are left untouched.
+	compressor, err := mdbcompression.NewCompressor(calg, mdbcompression.WithKeyring(keyring))
+	if err != nil {
+		return fmt.Errorf("error getting compressor: %v", err)
+	}
+
+	reader, err := backupStorage.PullStream(ctx, backupFile)
+	if err != nil {
+		return fmt.Errorf("error pulling backup stream: %v", err)
+	}
+	defer reader.Close()
+
+	var (
+		source   io.Reader = reader
+		verifier *backup.ManifestVerifier
+	)
+	if manifest != nil {
+		verifier = manifest.NewVerifier()
+		source = io.TeeReader(reader, verifier)
+	}
+	if err := compressor.Decompress(ctx, fifo, source); err != nil {
+		return fmt.Errorf("error decompressing backup: %v", err)
+	}
+	// Decompressors may stop reading before the end of the backup, the remaining bytes are read to compute the checksum.
+	if _, err := io.Copy(io.Discard, source); err != nil {
+		return fmt.Errorf("error reading backup: %v", err)
+	}
+
+	if verifier != nil {
+		logger.Info("verifying backup integrity", "file", backupFile, "sha256", manifest.SHA256, "size", manifest.Size,
+			"gtid", manifest.GTID, "server-version", manifest.ServerVersion, "mariadb", manifest.MariaDB)
+		if err := verifier.Verify(); err != nil {
+			return err
+		}
+	} else {
+		logger.Info("manifest not found, skipping integrity verification", "file", backupFile)
+	}
+	return os.WriteFile(backup.StreamVerifiedPath(fifo.Name()), nil, 0644)
+}
+
+// abortStreams unblocks mariadb-backup in case it is waiting for any of the named pipes and removes them,
+// so it fails instead of waiting for backups that will never be streamed.
+func abortStreams(fifoPaths []string) {
+	for _, fifoPath := range fifoPaths {
+		// Opening a named pipe for reading and writing does not block, and it unblocks any reader waiting for a writer.
+		fifo, err := os.OpenFile(fifoPath, os.O_RDWR, 0)
+		if err != nil && !os.IsNotExist(err) {
+			logger.Error(err, "error opening stream", "file", fifoPath)
+		}
+		if err := os.Remove(fifoPath); err != nil && !os.IsNotExist(err) {
+			logger.Error(err, "error removing stream", "file", fifoPath)
+		}
+		if fifo != nil {
+			fifo.Close()
+		}
+	}
+}
+
+func createFifo(fifoPath string) error {
+	for _, filePath := range []string{fifoPath, backup.StreamVerifiedPath(fifoPath)} {
+		if err := os.Remove(filePath); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("error removing stale file %s: %v", filePath, err)
+		}
+	}
+	if err := syscall.Mkfifo(fifoPath, 0666); err != nil {
+		return fmt.Errorf("error creating stream %s: %v", fifoPath, err)
+	}
+	return nil
+}
+
+func getStreamCompressor(keyring *mdbcompression.Keyring) (mdbcompression.Compressor, error) {
+	calg := mariadbv1alpha1.CompressAlgorithm(compression)
+	if err := calg.Validate(); err != nil {
+		return nil, fmt.Errorf("compression algorithm not supported: %v", err)
+	}
+	opts := []mdbcompression.CompressorOpt{
+		mdbcompression.WithCompressionLevel(getCompressionLevel()),
+	}
+	if keyring.CanEncrypt() {
+		logger.Info("configuring client-side encryption", "key-id", keyring.ActiveKeyID())
+		opts = append(opts, mdbcompression.WithKeyring(keyring))
+	}
+	return mdbcompression.NewCompressor(calg, opts...)
+}
+
+func waitForFile(ctx context.Context, filePath string) error {
+	return wait.PollUntilContextCancel(ctx, streamPollInterval, true, func(ctx context.Context) (bool, error) {
+		if _, err := os.Stat(filePath); err != nil {
+			if os.IsNotExist(err) {
+				return false, nil
+			}
+			return false, err
+		}
+		return true, nil
+	})
+}
+
+// exitCodeReader reads from a named pipe and, once the writer has closed it, checks the exit code recorded by the writer.
+// An error is returned instead of io.EOF when the writer has failed, as the stream may be incomplete.
+type exitCodeReader struct {
+	ctx          context.Context
+	reader       io.Reader
+	exitCodePath string
+}
+
+func (r *exitCodeReader) Read(p []byte) (int, error) {
+	n, err := r.reader.Read(p)
+	if !errors.Is(err, io.EOF) {
+		return n, err
+	}
+	exitCode, err := r.readExitCode()
+	if err != nil {
+		return n, fmt.Errorf("error reading exit code: %v", err)
+	}
+	if exitCode != "0" {
+		return n, fmt.Errorf("backup process exited with code %s", exitCode)
+	}
+	return n, io.EOF
+}
+
+func (r *exitCodeReader) readExitCode() (string, error) {
+	var exitCode string
+	// The exit code is written right after closing the named pipe.
+	err := wait.PollUntilContextCancel(r.ctx, streamPollInterval, true, func(ctx context.Context) (bool, error) {
+		bytes, err := os.ReadFile(r.exitCodePath)
+		if err != nil {
+			if os.IsNotExist(err) {
+				return false, nil
+			}
+			return false, err
+		}
+		exitCode = strings.TrimSpace(string(bytes))
+		return exitCode != "", nil
+	})
+	return exitCode, err
+}
+
+type countingReader struct {
+	reader io.Reader
+	size   int64
+}
+
+func (r *countingReader) Read(p []byte) (int, error) {
+	n, err := r.reader.Read(p)
+	r.size += int64(n)
+	return n, err
+}
@@ -0,0 +1,33 @@
+package backup
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	mariadbv1alpha1 "github.com/mariadb-operator/mariadb-operator/v26/api/v1alpha1"
+	"github.com/mariadb-operator/mariadb-operator/v26/pkg/backup"
+)
+
+// filterTables keeps the statements of the logical backup that belong to the tables to be restored.
+// The backup can either be a dump file or a directory where a parallel backup has been extracted.
+func filterTables(backupFile string) error {
+	if backupContentType != string(mariadbv1alpha1.BackupContentTypeLogical) || len(restoreTables) == 0 {
+		return nil
+	}
+	backupPath := backup.GetFilePath(path, backupFile)
+	info, err := os.Stat(backupPath)
+	if err != nil {
+		return fmt.Errorf("error getting backup info: %v", err)
+	}
+	logger.Info("filtering tables", "path", backupPath, "tables", restoreTables)
+
+	if !info.IsDir() {
+		return backup.FilterDumpFile(backupPath, restoreTables)
+	}
+	manifest, err := backup.ReadParallelBackupManifest(filepath.Join(backupPath, backup.ParallelBackupManifestFileName))
+	if err != nil {
+		return err
+	}
+	return backup.FilterParallelBackup(backupPath, manifest, restoreTables)
+}
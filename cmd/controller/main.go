@@ -27,6 +27,7 @@ import (
 	"github.com/mariadb-operator/mariadb-operator/v26/pkg/controller/deployment"
 	"github.com/mariadb-operator/mariadb-operator/v26/pkg/controller/endpoints"
 	"github.com/mariadb-operator/mariadb-operator/v26/pkg/controller/galera"
+	"github.com/mariadb-operator/mariadb-operator/v26/pkg/controller/hook"
 	"github.com/mariadb-operator/mariadb-operator/v26/pkg/controller/maintenance"
 	"github.com/mariadb-operator/mariadb-operator/v26/pkg/controller/pvc"
 	"github.com/mariadb-operator/mariadb-operator/v26/pkg/controller/rbac"
@@ -82,10 +83,11 @@ var (
 	maxscaleMaxConcurrentReconciles       int
 	physicalBackupMaxConcurrentReconciles int
 
-	requeueConnection time.Duration
-	requeueSql        time.Duration
-	requeueSqlJob     time.Duration
-	requeueMaxScale   time.Duration
+	requeueConnection         time.Duration
+	requeueSql                time.Duration
+	requeueSqlJob             time.Duration
+	requeueBackupVerification time.Duration
+	requeueMaxScale           time.Duration
 
 	requeueSqlMaxOffset time.Duration
 
@@ -155,6 +157,8 @@ func init() {
 	rootCmd.Flags().DurationVar(&requeueSqlMaxOffset, "requeue-sql-max-offset", 1*time.Hour,
 		"Maximum offset added to the interval at which SQL objects are requeued.")
 	rootCmd.Flags().DurationVar(&requeueSqlJob, "requeue-sqljob", 30*time.Second, "The interval at which SqlJobs are requeued.")
+	rootCmd.Flags().DurationVar(&requeueBackupVerification, "requeue-backupverification", 30*time.Second,
+		"The interval at which BackupVerifications are requeued while a verification is in progress.")
 	rootCmd.Flags().DurationVar(&requeueMaxScale, "requeue-maxscale", 1*time.Hour, "The interval at which MaxScales are requeued.")
 
 	rootCmd.Flags().DurationVar(&syncPeriod, "sync-period", 10*time.Hour, "The interval at which watched resources are reconciled.")
@@ -301,6 +305,7 @@ var rootCmd = &cobra.Command{
 		serviceReconciler := service.NewServiceReconciler(client)
 		endpointsReconciler := endpoints.NewEndpointsReconciler(client, builder)
 		batchReconciler := batch.NewBatchReconciler(client, builder)
+		hookReconciler := hook.NewHookReconciler(client, hook.NewPodExecutor(restConfig, kubeClientset), hook.WithRefResolver(refResolver))
 		rbacReconciler := rbac.NewRBACReconciler(client, builder)
 		authReconciler := auth.NewAuthReconciler(client, builder)
 		deployReconciler := deployment.NewDeploymentReconciler(client)
@@ -428,6 +433,7 @@ var rootCmd = &cobra.Command{
 			ConditionComplete: conditionComplete,
 			RBACReconciler:    rbacReconciler,
 			BatchReconciler:   batchReconciler,
+			HookReconciler:    hookReconciler,
 		}).SetupWithManager(mgr); err != nil {
 			setupLog.Error(err, "Unable to create controller", "controller", "Backup")
 			os.Exit(1)
@@ -442,6 +448,7 @@ var rootCmd = &cobra.Command{
 			ConditionComplete: conditionComplete,
 			RBACReconciler:    rbacReconciler,
 			PVCReconciler:     pvcReconciler,
+			HookReconciler:    hookReconciler,
 			BackupProcessor:   backupProcessor,
 		}).SetupWithManager(ctx, mgr, ctrlcontroller.Options{MaxConcurrentReconciles: physicalBackupMaxConcurrentReconciles}); err != nil {
 			setupLog.Error(err, "Unable to create controller", "controller", "PhysicalBackup")
@@ -507,6 +514,28 @@ var rootCmd = &cobra.Command{
 			setupLog.Error(err, "Unable to create controller", "controller", "SqlJob")
 			os.Exit(1)
 		}
+		if err = (&controller.BackupVerificationReconciler{
+			Client:          client,
+			Scheme:          scheme,
+			Builder:         builder,
+			RefResolver:     refResolver,
+			BackupProcessor: backupProcessor,
+			RequeueInterval: requeueBackupVerification,
+		}).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "Unable to create controller", "controller", "BackupVerification")
+			os.Exit(1)
+		}
+		if err = (&controller.FlashbackReconciler{
+			Client:            client,
+			Scheme:            scheme,
+			Builder:           builder,
+			RefResolver:       refResolver,
+			ConditionComplete: conditionComplete,
+			RBACReconciler:    rbacReconciler,
+		}).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "Unable to create controller", "controller", "Flashback")
+			os.Exit(1)
+		}
 		if err = podReplicationController.SetupWithManager(mgr); err != nil {
 			setupLog.Error(err, "Unable to create controller", "controller", "PodReplication")
 			os.Exit(1)
@@ -549,6 +578,14 @@ var rootCmd = &cobra.Command{
 				setupLog.Error(err, "Unable to create webhook", "webhook", "PointInTimeRecovery")
 				os.Exit(1)
 			}
+			if err = webhookv1alpha1.SetupBackupVerificationWebhookWithManager(mgr); err != nil {
+				setupLog.Error(err, "Unable to create webhook", "webhook", "BackupVerification")
+				os.Exit(1)
+			}
+			if err = webhookv1alpha1.SetupFlashbackWebhookWithManager(mgr); err != nil {
+				setupLog.Error(err, "Unable to create webhook", "webhook", "Flashback")
+				os.Exit(1)
+			}
 			if err = webhookv1alpha1.SetupUserWebhookWithManager(mgr); err != nil {
 				setupLog.Error(err, "Unable to create webhook", "webhook", "User")
 				os.Exit(1)
@@ -96,6 +96,14 @@ var webhookCmd = &cobra.Command{
 			setupLog.Error(err, "Unable to create webhook", "webhook", "PointInTimeRecovery")
 			os.Exit(1)
 		}
+		if err = webhookv1alpha1.SetupBackupVerificationWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "Unable to create webhook", "webhook", "BackupVerification")
+			os.Exit(1)
+		}
+		if err = webhookv1alpha1.SetupFlashbackWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "Unable to create webhook", "webhook", "Flashback")
+			os.Exit(1)
+		}
 		if err = webhookv1alpha1.SetupUserWebhookWithManager(mgr); err != nil {
 			setupLog.Error(err, "Unable to create webhook", "webhook", "User")
 			os.Exit(1)
@@ -0,0 +1,123 @@
+package pitr
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	mariadbv1alpha1 "github.com/mariadb-operator/mariadb-operator/v26/api/v1alpha1"
+	"github.com/mariadb-operator/mariadb-operator/v26/pkg/binlog"
+	"github.com/mariadb-operator/mariadb-operator/v26/pkg/log"
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	corev1ac "k8s.io/client-go/applyconfigurations/core/v1"
+	metav1ac "k8s.io/client-go/applyconfigurations/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var (
+	sqlFilePath        string
+	flashbackName      string
+	flashbackNamespace string
+)
+
+func init() {
+	flashbackCommand.Flags().StringVar(&sqlFilePath, "sql-file-path", "/binlogs/flashback.sql",
+		"Path to the file that contains the reverse SQL generated by mariadb-binlog.")
+	flashbackCommand.Flags().StringVar(&flashbackName, "flashback-name", "", "Name of the Flashback.")
+	flashbackCommand.Flags().StringVar(&flashbackNamespace, "flashback-namespace", "", "Namespace of the Flashback.")
+}
+
+var flashbackCommand = &cobra.Command{
+	Use:   "flashback",
+	Short: "Flashback.",
+	Long: `Stores the reverse SQL generated by mariadb-binlog, wrapped in a single transaction,
+in the ConfigMap or Secret defined by a Flashback, so it can be reviewed.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := log.SetupLoggerWithCommand(cmd); err != nil {
+			fmt.Printf("Error setting up logger: %v\n", err)
+			os.Exit(1)
+		}
+		if flashbackName == "" || flashbackNamespace == "" {
+			logger.Error(nil, "Flashback name and namespace must be set")
+			os.Exit(1)
+		}
+
+		ctx, cancel := newContext()
+		defer cancel()
+
+		sql, err := os.ReadFile(sqlFilePath)
+		if err != nil {
+			logger.Error(err, "Error reading reverse SQL file", "file-path", sqlFilePath)
+			os.Exit(1)
+		}
+		sql, err = binlog.FlashbackTransaction(sql)
+		if err != nil {
+			logger.Error(err, "Error wrapping reverse SQL in a transaction", "file-path", sqlFilePath)
+			os.Exit(1)
+		}
+		logger.Info("Storing reverse SQL", "file-path", sqlFilePath, "size", len(sql))
+
+		if err := storeFlashbackOutput(ctx, sql); err != nil {
+			logger.Error(err, "Error storing reverse SQL")
+			os.Exit(1)
+		}
+	},
+}
+
+func storeFlashbackOutput(ctx context.Context, sql []byte) error {
+	k8sClient, err := newKubernetesClient()
+	if err != nil {
+		return err
+	}
+	key := types.NamespacedName{
+		Name:      flashbackName,
+		Namespace: flashbackNamespace,
+	}
+	var flashback mariadbv1alpha1.Flashback
+	if err := k8sClient.Get(ctx, key, &flashback); err != nil {
+		return fmt.Errorf("error getting Flashback: %v", err)
+	}
+
+	outputKey := flashback.OutputKey()
+	labels, annotations := map[string]string{}, map[string]string{}
+	if flashback.Spec.InheritMetadata != nil {
+		labels = flashback.Spec.InheritMetadata.Labels
+		annotations = flashback.Spec.InheritMetadata.Annotations
+	}
+	ownerRef := metav1ac.OwnerReference().
+		WithAPIVersion(mariadbv1alpha1.GroupVersion.String()).
+		WithKind(mariadbv1alpha1.FlashbackKind).
+		WithName(flashback.Name).
+		WithUID(flashback.UID).
+		WithController(true).
+		WithBlockOwnerDeletion(true)
+
+	// Server-side apply allows the Job to create or update the output object with the create and patch verbs only.
+	var obj runtime.ApplyConfiguration
+	switch flashback.OutputKind() {
+	case mariadbv1alpha1.FlashbackOutputSecret:
+		obj = corev1ac.Secret(outputKey.Name, outputKey.Namespace).
+			WithLabels(labels).
+			WithAnnotations(annotations).
+			WithOwnerReferences(ownerRef).
+			WithData(map[string][]byte{
+				flashback.OutputDataKey(): sql,
+			})
+	default:
+		obj = corev1ac.ConfigMap(outputKey.Name, outputKey.Namespace).
+			WithLabels(labels).
+			WithAnnotations(annotations).
+			WithOwnerReferences(ownerRef).
+			WithData(map[string]string{
+				flashback.OutputDataKey(): string(sql),
+			})
+	}
+	if err := k8sClient.Apply(ctx, obj, client.FieldOwner("mariadb-operator"), client.ForceOwnership); err != nil {
+		return fmt.Errorf("error storing reverse SQL in %s %s: %v", flashback.OutputKind(), outputKey.Name, err)
+	}
+	logger.Info("Reverse SQL stored", "kind", flashback.OutputKind(), "name", outputKey.Name)
+	return nil
+}
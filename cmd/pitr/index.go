@@ -0,0 +1,276 @@
+package pitr
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/go-logr/logr"
+	mariadbv1alpha1 "github.com/mariadb-operator/mariadb-operator/v26/api/v1alpha1"
+	"github.com/mariadb-operator/mariadb-operator/v26/pkg/binlog"
+	"github.com/mariadb-operator/mariadb-operator/v26/pkg/builder"
+	mariadbcompression "github.com/mariadb-operator/mariadb-operator/v26/pkg/compression"
+	conditions "github.com/mariadb-operator/mariadb-operator/v26/pkg/condition"
+	"github.com/mariadb-operator/mariadb-operator/v26/pkg/interfaces"
+	"github.com/mariadb-operator/mariadb-operator/v26/pkg/log"
+	"github.com/mariadb-operator/mariadb-operator/v26/pkg/ratelimit"
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+)
+
+var (
+	scheme = runtime.NewScheme()
+
+	pitrName      string
+	pitrNamespace string
+)
+
+func init() {
+	utilruntime.Must(mariadbv1alpha1.AddToScheme(scheme))
+
+	indexCommand.PersistentFlags().StringVar(&pitrName, "pitr-name", "",
+		"Name of the PointInTimeRecovery to be marked with a Degraded condition when the binlog index is inconsistent.")
+	indexCommand.PersistentFlags().StringVar(&pitrNamespace, "pitr-namespace", "", "Namespace of the PointInTimeRecovery.")
+
+	indexCommand.AddCommand(indexRebuildCommand)
+	indexCommand.AddCommand(indexVerifyCommand)
+}
+
+var indexCommand = &cobra.Command{
+	Use:   "index",
+	Short: "Index.",
+	Long:  `Manages the binlog index stored in object storage.`,
+}
+
+var indexRebuildCommand = &cobra.Command{
+	Use:   "rebuild",
+	Short: "Rebuild.",
+	Long: `Rebuilds the binlog index from the binary logs archived in object storage, ` +
+		`and reports the inconsistencies found in the rebuilt index in JSON format.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := log.SetupLoggerWithCommand(cmd); err != nil {
+			fmt.Printf("Error setting up logger: %v\n", err)
+			os.Exit(1)
+		}
+		calg, err := getCompressionAlgorithm()
+		if err != nil {
+			logger.Error(err, "Error getting compression algorithm", "compression", compression)
+			os.Exit(1)
+		}
+		keyring, err := mariadbcompression.NewKeyringFromEnv(builder.EncryptionKey, builder.EncryptionPreviousKeyPrefix,
+			builder.EncryptionAllowUnencrypted)
+		if err != nil {
+			logger.Error(err, "Error getting encryption keyring")
+			os.Exit(1)
+		}
+		rateLimiter = ratelimit.NewLimiter(bandwidthLimit)
+
+		ctx, cancel := newContext()
+		defer cancel()
+
+		storageClient, err := getStorageClient()
+		if err != nil {
+			logger.Error(err, "Error getting storage client")
+			os.Exit(1)
+		}
+		objects, err := listBinlogObjects(ctx, storageClient)
+		if err != nil {
+			logger.Error(err, "Error listing binlogs")
+			os.Exit(1)
+		}
+
+		logger.Info("Rebuilding binlog index", "binlogs", len(objects))
+		index, err := rebuildBinlogIndex(ctx, objects, calg, keyring, storageClient, logger.WithName("rebuild"))
+		if err != nil {
+			logger.Error(err, "Error rebuilding binlog index")
+			os.Exit(1)
+		}
+		if err := putBinlogIndex(ctx, index, storageClient); err != nil {
+			logger.Error(err, "Error putting binlog index")
+			os.Exit(1)
+		}
+		logger.Info("Binlog index rebuilt")
+
+		if _, err := reportBinlogIndex(ctx, index, objects); err != nil {
+			logger.Error(err, "Error reporting binlog index")
+			os.Exit(1)
+		}
+	},
+}
+
+var indexVerifyCommand = &cobra.Command{
+	Use:   "verify",
+	Short: "Verify.",
+	Long: `Verifies the binlog index against the binary logs archived in object storage, ` +
+		`reporting the inconsistencies found in JSON format. It exits with a non-zero code when the index is degraded.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := log.SetupLoggerWithCommand(cmd); err != nil {
+			fmt.Printf("Error setting up logger: %v\n", err)
+			os.Exit(1)
+		}
+		rateLimiter = ratelimit.NewLimiter(bandwidthLimit)
+
+		ctx, cancel := newContext()
+		defer cancel()
+
+		storageClient, err := getStorageClient()
+		if err != nil {
+			logger.Error(err, "Error getting storage client")
+			os.Exit(1)
+		}
+		index, err := getBinlogIndex(ctx, storageClient)
+		if err != nil {
+			logger.Error(err, "Error getting binlog index")
+			os.Exit(1)
+		}
+		objects, err := listBinlogObjects(ctx, storageClient)
+		if err != nil {
+			logger.Error(err, "Error listing binlogs")
+			os.Exit(1)
+		}
+
+		report, err := reportBinlogIndex(ctx, index, objects)
+		if err != nil {
+			logger.Error(err, "Error reporting binlog index")
+			os.Exit(1)
+		}
+		if report.IsDegraded() {
+			os.Exit(1)
+		}
+	},
+}
+
+// listBinlogObjects lists the binlogs archived in the storage.
+func listBinlogObjects(ctx context.Context, storageClient interfaces.BlobStorage) ([]binlog.BinlogObject, error) {
+	objectNames, err := storageClient.ListObjectsWithOptions(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error listing objects: %v", err)
+	}
+	for i, name := range objectNames {
+		objectNames[i] = strings.TrimPrefix(name, storageClient.GetPrefix())
+	}
+	return binlog.ListBinlogObjects(objectNames), nil
+}
+
+// rebuildBinlogIndex pulls every archived binlog to derive its metadata. Binlogs that cannot be pulled are skipped,
+// so they are reported as unindexed. Streamed binlogs are kept from the current index, if any, until they are archived.
+func rebuildBinlogIndex(ctx context.Context, objects []binlog.BinlogObject, calg mariadbv1alpha1.CompressAlgorithm,
+	keyring *mariadbcompression.Keyring, storageClient interfaces.BlobStorage, logger logr.Logger) (*binlog.BinlogIndex, error) {
+	index := binlog.NewBinlogIndex()
+
+	for _, object := range objects {
+		binlogPath := object.ObjectStoragePath()
+		if err := pullBinlog(ctx, binlogPath, calg, keyring, storageClient, logger); err != nil {
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			logger.Error(err, "Error pulling binlog. Skipping...", "binlog", binlogPath)
+			continue
+		}
+		fileName := filepath.Join(path, binlogPath)
+		meta, err := binlog.GetBinlogMetadata(fileName, logger.V(1))
+		if err != nil {
+			logger.Error(err, "Error getting binlog metadata. Skipping...", "binlog", binlogPath)
+		} else {
+			meta.ServerId = object.ServerId
+			index.Add(object.ServerId, *meta)
+		}
+		if err := os.Remove(fileName); err != nil {
+			return nil, fmt.Errorf("error removing binlog file %s: %v", fileName, err)
+		}
+	}
+
+	currentIndex, err := getBinlogIndex(ctx, storageClient)
+	if err != nil {
+		logger.Info("Unable to get current binlog index. Streamed binlogs will not be kept", "err", err)
+		return index, nil
+	}
+	for _, binlogs := range currentIndex.Binlogs {
+		for _, meta := range binlogs {
+			if meta.Chunks > 0 && !index.Exists(meta.ServerId, meta.BinlogFilename) {
+				index.Add(meta.ServerId, meta)
+			}
+		}
+	}
+	return index, nil
+}
+
+func putBinlogIndex(ctx context.Context, index *binlog.BinlogIndex, storageClient interfaces.BlobStorage) error {
+	indexBytes, err := yaml.Marshal(index)
+	if err != nil {
+		return fmt.Errorf("error marshaling binlog index: %v", err)
+	}
+	if err := storageClient.PutObjectWithOptions(ctx, binlog.BinlogIndexName, bytes.NewReader(indexBytes),
+		int64(len(indexBytes))); err != nil {
+		return fmt.Errorf("error putting binlog index: %v", err)
+	}
+	return nil
+}
+
+// reportBinlogIndex verifies the binlog index, printing the report and marking the PointInTimeRecovery accordingly when provided.
+func reportBinlogIndex(ctx context.Context, index *binlog.BinlogIndex, objects []binlog.BinlogObject) (*binlog.IndexReport, error) {
+	report := index.Verify(objects, logger.WithName("verify"))
+
+	bytes, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("error marshaling report: %v", err)
+	}
+	fmt.Println(string(bytes))
+
+	if report.IsDegraded() {
+		logger.Info(report.Summary())
+	}
+	if pitrName == "" || pitrNamespace == "" {
+		return report, nil
+	}
+	if err := patchPITRCondition(ctx, report); err != nil {
+		return nil, fmt.Errorf("error patching PointInTimeRecovery: %v", err)
+	}
+	return report, nil
+}
+
+func patchPITRCondition(ctx context.Context, report *binlog.IndexReport) error {
+	k8sClient, err := newKubernetesClient()
+	if err != nil {
+		return err
+	}
+	key := types.NamespacedName{
+		Name:      pitrName,
+		Namespace: pitrNamespace,
+	}
+	var pitr mariadbv1alpha1.PointInTimeRecovery
+	if err := k8sClient.Get(ctx, key, &pitr); err != nil {
+		return fmt.Errorf("error getting PointInTimeRecovery: %v", err)
+	}
+
+	patch := client.MergeFrom(pitr.DeepCopy())
+	if report.IsDegraded() {
+		conditions.SetBinlogIndexInconsistent(&pitr.Status, report.Summary())
+	} else {
+		conditions.SetBinlogIndexConsistent(&pitr.Status, report.Summary())
+	}
+	return k8sClient.Status().Patch(ctx, &pitr, patch)
+}
+
+func newKubernetesClient() (client.Client, error) {
+	restConfig, err := ctrl.GetConfig()
+	if err != nil {
+		return nil, fmt.Errorf("error getting REST config: %v", err)
+	}
+	k8sClient, err := client.New(restConfig, client.Options{Scheme: scheme})
+	if err != nil {
+		return nil, fmt.Errorf("error creating Kubernetes client: %v", err)
+	}
+	return k8sClient, nil
+}
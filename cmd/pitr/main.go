@@ -15,12 +15,16 @@ import (
 	"github.com/go-logr/logr"
 	mariadbv1alpha1 "github.com/mariadb-operator/mariadb-operator/v26/api/v1alpha1"
 	"github.com/mariadb-operator/mariadb-operator/v26/pkg/azure"
+	"github.com/mariadb-operator/mariadb-operator/v26/pkg/backup"
 	"github.com/mariadb-operator/mariadb-operator/v26/pkg/binlog"
 	"github.com/mariadb-operator/mariadb-operator/v26/pkg/builder"
 	mariadbcompression "github.com/mariadb-operator/mariadb-operator/v26/pkg/compression"
+	"github.com/mariadb-operator/mariadb-operator/v26/pkg/filesystem"
+	"github.com/mariadb-operator/mariadb-operator/v26/pkg/gcs"
 	"github.com/mariadb-operator/mariadb-operator/v26/pkg/interfaces"
 	"github.com/mariadb-operator/mariadb-operator/v26/pkg/log"
 	mariadbminio "github.com/mariadb-operator/mariadb-operator/v26/pkg/minio"
+	"github.com/mariadb-operator/mariadb-operator/v26/pkg/ratelimit"
 	mariadbrepl "github.com/mariadb-operator/mariadb-operator/v26/pkg/replication"
 	"github.com/spf13/cobra"
 	"k8s.io/apimachinery/pkg/util/wait"
@@ -36,7 +40,9 @@ var (
 	targetFilePath string
 
 	startGtidRaw  string
+	startTimeRaw  string
 	targetTimeRaw string
+	targetGtidRaw string
 	strictMode    bool
 
 	s3           bool
@@ -54,8 +60,22 @@ var (
 	absCACertPath string
 	absPrefix     string
 
+	gcsEnabled  bool
+	gcsBucket   string
+	gcsEndpoint string
+	gcsPrefix   string
+
+	fsEnabled bool
+	fsPath    string
+
 	compression string
 
+	bandwidthLimit int64
+	// rateLimiter is shared by all the storage clients, so the bandwidth limit applies to their aggregated throughput.
+	rateLimiter *ratelimit.Limiter
+
+	secondaryStoragesRaw string
+
 	pullBackoff = wait.Backoff{
 		Steps:    10,
 		Duration: 1 * time.Second,
@@ -63,26 +83,30 @@ var (
 )
 
 func init() {
-	RootCmd.Flags().StringVar(&path, "path", "/binlogs", "Directory path where the binary log files will be pulled.")
+	RootCmd.PersistentFlags().StringVar(&path, "path", "/binlogs", "Directory path where the binary log files will be pulled.")
 	RootCmd.Flags().StringVar(&targetFilePath, "target-file-path", "/binlogs/0-binlog-target.txt",
 		"Path to a file that contains the names of the binlog target files.")
 
 	RootCmd.Flags().StringVar(&startGtidRaw, "start-gtid", "",
 		"Initial GTID (global transaction ID) from which the binlogs will be pulled.")
+	RootCmd.Flags().StringVar(&startTimeRaw, "start-time", "",
+		"RFC3339 (1970-01-01T00:00:00Z) date and time from which the binlogs will be pulled. It is only used when start-gtid is not set.")
 	RootCmd.Flags().StringVar(&targetTimeRaw, "target-time", "",
 		"RFC3339 (1970-01-01T00:00:00Z) date and time that defines the recovery point-in-time.")
+	RootCmd.Flags().StringVar(&targetGtidRaw, "target-gtid", "",
+		"GTID (global transaction ID) of the last event to be replayed. It takes precedence over target-time, which is not required when set.")
 	RootCmd.Flags().BoolVar(&strictMode, "strict-mode", false,
 		"Strict mode that controls the behavior when a point-in-time restoration cannot reach the exact target time."+
 			"When enabled, returns an error and avoids replaying binary logs if target time is not reached."+
 			"When disabled (default), replays available binary logs until the last recoverable time.")
 
-	RootCmd.Flags().BoolVar(&s3, "s3", false, "Enable S3 binlog storage.")
-	RootCmd.Flags().StringVar(&s3Bucket, "s3-bucket", "binlogs", "Name of the bucket to store binary logs.")
-	RootCmd.Flags().StringVar(&s3Prefix, "s3-prefix", "", "S3 bucket prefix name to use.")
-	RootCmd.Flags().StringVar(&s3Endpoint, "s3-endpoint", "s3.amazonaws.com", "S3 API endpoint without scheme.")
-	RootCmd.Flags().StringVar(&s3Region, "s3-region", "us-east-1", "S3 region name to use.")
-	RootCmd.Flags().BoolVar(&s3TLS, "s3-tls", false, "Enable S3 TLS connections.")
-	RootCmd.Flags().StringVar(&s3CACertPath, "s3-ca-cert-path", "", "Path to the CA to be trusted when connecting to S3.")
+	RootCmd.PersistentFlags().BoolVar(&s3, "s3", false, "Enable S3 binlog storage.")
+	RootCmd.PersistentFlags().StringVar(&s3Bucket, "s3-bucket", "binlogs", "Name of the bucket to store binary logs.")
+	RootCmd.PersistentFlags().StringVar(&s3Prefix, "s3-prefix", "", "S3 bucket prefix name to use.")
+	RootCmd.PersistentFlags().StringVar(&s3Endpoint, "s3-endpoint", "s3.amazonaws.com", "S3 API endpoint without scheme.")
+	RootCmd.PersistentFlags().StringVar(&s3Region, "s3-region", "us-east-1", "S3 region name to use.")
+	RootCmd.PersistentFlags().BoolVar(&s3TLS, "s3-tls", false, "Enable S3 TLS connections.")
+	RootCmd.PersistentFlags().StringVar(&s3CACertPath, "s3-ca-cert-path", "", "Path to the CA to be trusted when connecting to S3.")
 
 	RootCmd.PersistentFlags().BoolVar(&abs, "abs", false, "Enable Azure Blob backup storage.")
 	RootCmd.PersistentFlags().StringVar(&absContainer, "abs-container", "backups", "Name of the container to store backups.")
@@ -91,8 +115,27 @@ func init() {
 	RootCmd.PersistentFlags().StringVar(&absCACertPath, "abs-ca-cert-path", "", "Path to the CA to be trusted when connecting to ABS.")
 	RootCmd.PersistentFlags().StringVar(&absPrefix, "abs-prefix", "", "ABS container prefix to use.")
 
-	RootCmd.Flags().StringVar(&compression, "compression", string(mariadbv1alpha1.CompressNone),
-		"Compression algorithm: none, gzip or bzip2.")
+	RootCmd.PersistentFlags().BoolVar(&gcsEnabled, "gcs", false, "Enable Google Cloud Storage backup storage.")
+	RootCmd.PersistentFlags().StringVar(&gcsBucket, "gcs-bucket", "backups", "Name of the GCS bucket to store backups.")
+	RootCmd.PersistentFlags().StringVar(&gcsEndpoint, "gcs-endpoint", "", "GCS API endpoint to use, including scheme.")
+	RootCmd.PersistentFlags().StringVar(&gcsPrefix, "gcs-prefix", "", "GCS bucket prefix name to use.")
+
+	RootCmd.PersistentFlags().BoolVar(&fsEnabled, "fs", false, "Enable filesystem binlog storage, typically a mounted PVC or volume.")
+	RootCmd.PersistentFlags().StringVar(&fsPath, "fs-path", "",
+		"Directory path where the binary logs are stored when using filesystem storage.")
+
+	RootCmd.PersistentFlags().StringVar(&compression, "compression", string(mariadbv1alpha1.CompressNone),
+		"Compression algorithm: none, gzip, bzip2, zstd or lz4.")
+
+	RootCmd.PersistentFlags().Int64Var(&bandwidthLimit, "bandwidth-limit", 0,
+		"Maximum number of bytes per second transferred from the object storages. If not provided, it is not limited.")
+
+	RootCmd.Flags().StringVar(&secondaryStoragesRaw, "secondary-storages", "",
+		"Secondary storages in JSON format where binary logs are replicated. They are used as a fallback "+
+			"if the primary storage is unreachable. Settings and credentials are read from environment variables indexed by storage.")
+
+	RootCmd.AddCommand(indexCommand)
+	RootCmd.AddCommand(flashbackCommand)
 }
 
 var RootCmd = &cobra.Command{
@@ -105,42 +148,78 @@ var RootCmd = &cobra.Command{
 			fmt.Printf("Error setting up logger: %v\n", err)
 			os.Exit(1)
 		}
+		var (
+			startGtid  *mariadbrepl.Gtid
+			startTime  time.Time
+			targetGtid *mariadbrepl.Gtid
+			targetTime time.Time
+			err        error
+		)
 		// TODO: support multiple GTID domain IDs
-		startGtid, err := mariadbrepl.ParseGtid(startGtidRaw)
-		if err != nil {
-			logger.Error(err, "Error parsing start GTID", "gtid", startGtidRaw)
-			os.Exit(1)
+		if startGtidRaw != "" || startTimeRaw == "" {
+			startGtid, err = mariadbrepl.ParseGtid(startGtidRaw)
+			if err != nil {
+				logger.Error(err, "Error parsing start GTID", "gtid", startGtidRaw)
+				os.Exit(1)
+			}
+		} else {
+			startTime, err = time.Parse(time.RFC3339, startTimeRaw)
+			if err != nil {
+				logger.Error(err, "Error parsing start time", "time", startTimeRaw)
+				os.Exit(1)
+			}
 		}
-		targetTime, err := time.Parse(time.RFC3339, targetTimeRaw)
-		if err != nil {
-			logger.Error(err, "Error parsing target time", "time", targetTimeRaw)
-			os.Exit(1)
+		if targetGtidRaw != "" {
+			targetGtid, err = mariadbrepl.ParseGtid(targetGtidRaw)
+			if err != nil {
+				logger.Error(err, "Error parsing target GTID", "gtid", targetGtidRaw)
+				os.Exit(1)
+			}
+		} else {
+			targetTime, err = time.Parse(time.RFC3339, targetTimeRaw)
+			if err != nil {
+				logger.Error(err, "Error parsing target time", "time", targetTimeRaw)
+				os.Exit(1)
+			}
 		}
 		calg, err := getCompressionAlgorithm()
 		if err != nil {
 			logger.Error(err, "Error getting compression algorithm", "compression", compression)
 			os.Exit(1)
 		}
-		logger.Info("Starting point-in-time recovery")
-
-		ctx, cancel := newContext()
-		defer cancel()
-
-		storageClient, err := getStorageClient()
+		keyring, err := mariadbcompression.NewKeyringFromEnv(builder.EncryptionKey, builder.EncryptionPreviousKeyPrefix,
+			builder.EncryptionAllowUnencrypted)
 		if err != nil {
-			logger.Error(err, "Error getting S3 client")
+			logger.Error(err, "Error getting encryption keyring")
 			os.Exit(1)
 		}
+		rateLimiter = ratelimit.NewLimiter(bandwidthLimit)
+		logger.Info("Starting point-in-time recovery", "bandwidth-limit", rateLimiter.BytesPerSecond())
 
-		logger.Info("Getting binlog index from object storage")
-		binlogIndex, err := getBinlogIndex(ctx, storageClient)
+		ctx, cancel := newContext()
+		defer cancel()
+
+		storageClient, binlogIndex, err := getStorageClientWithIndex(ctx)
 		if err != nil {
 			logger.Error(err, "Error getting binlog index")
 			os.Exit(1)
 		}
+		if startGtid == nil {
+			startGtid, err = binlogIndex.StartGtidAt(startTime)
+			if err != nil {
+				logger.Error(err, "Error getting start GTID", "time", startTimeRaw)
+				os.Exit(1)
+			}
+			logger.Info("Got start GTID", "time", startTimeRaw, "gtid", startGtid)
+		}
 
 		logger.Info("Building binlog timeline")
-		binlogMetas, err := binlogIndex.BuildTimeline(startGtid, targetTime, strictMode, logger.WithName("binlog-timeline"))
+		var binlogMetas []binlog.BinlogMetadata
+		if targetGtid != nil {
+			binlogMetas, err = binlogIndex.BuildTimelineWithTargetGtid(startGtid, targetGtid, strictMode, logger.WithName("binlog-timeline"))
+		} else {
+			binlogMetas, err = binlogIndex.BuildTimeline(startGtid, targetTime, strictMode, logger.WithName("binlog-timeline"))
+		}
 		if err != nil {
 			logger.Error(err, "Error getting binlog timeline")
 			os.Exit(1)
@@ -149,7 +228,7 @@ var RootCmd = &cobra.Command{
 		logger.Info("Got binlog timeline", "path", binlogPath)
 
 		logger.Info("Pulling binlogs into staging area", "staging-path", path, "compression", calg)
-		if err := pullBinlogs(ctx, binlogPath, calg, storageClient, logger.WithName("storage")); err != nil {
+		if err := pullBinlogs(ctx, binlogMetas, calg, keyring, storageClient, logger.WithName("storage")); err != nil {
 			logger.Error(err, "Error pulling binlogs")
 			os.Exit(1)
 		}
@@ -179,8 +258,51 @@ func getStorageClient() (interfaces.BlobStorage, error) {
 	if s3 {
 		return getS3Client()
 	}
+	if gcsEnabled {
+		return getGCSClient()
+	}
+	if fsEnabled {
+		return getFileSystemClient()
+	}
+
+	return nil, fmt.Errorf("error getting a storage client, none configured. Either abs, s3, gcs or fs must be configured")
+}
+
+// getFileSystemClient retrieves a filesystem client
+// @WARN: should not be used directly, see `getStorageClient`
+func getFileSystemClient() (*filesystem.FileSystemClient, error) {
+	logger.Info("configuring filesystem binlog storage")
+	client, err := filesystem.NewFileSystemClient(
+		path,
+		fsPath,
+		filesystem.WithAllowNestedPrefixes(true),
+		filesystem.WithRateLimiter(rateLimiter),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error getting filesystem client: %v", err)
+	}
+	return client, nil
+}
+
+// getGCSClient retrieves a Google Cloud Storage client
+// @WARN: should not be used directly, see `getStorageClient`
+func getGCSClient() (*gcs.GCSClient, error) {
+	logger.Info("configuring GCS backup storage")
+	opts := []gcs.GCSOpt{
+		gcs.WithEndpoint(gcsEndpoint),
+		gcs.WithPrefix(gcsPrefix),
+		gcs.WithAllowNestedPrefixes(true),
+		gcs.WithRateLimiter(rateLimiter),
+	}
+	if serviceAccountKey := os.Getenv(builder.GCSServiceAccountKey); serviceAccountKey != "" {
+		opts = append(opts, gcs.WithServiceAccountKey([]byte(serviceAccountKey)))
+	}
 
-	return nil, fmt.Errorf("error getting a storage client, none configured. Either abs or s3 must be configured")
+	client, err := gcs.NewGCSClient(path, gcsBucket, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("error getting GCS client: %v", err)
+	}
+	return client, nil
 }
 
 // getABSClient retrieves an Azure Blob Storage client
@@ -192,6 +314,7 @@ func getABSClient() (*azure.AzBlobClient, error) {
 		azure.WithTLSCACertPath(absCACertPath),
 		azure.WithPrefix(absPrefix),
 		azure.WithAllowNestedPrefixes(true),
+		azure.WithRateLimiter(rateLimiter),
 	}
 	if accountKey := os.Getenv(builder.ABSStorageAccountKey); accountKey != "" {
 		opts = append(opts, azure.WithAccountKey(accountKey))
@@ -211,6 +334,7 @@ func getS3Client() (*mariadbminio.Client, error) {
 		mariadbminio.WithRegion(s3Region),
 		mariadbminio.WithPrefix(s3Prefix),
 		mariadbminio.WithAllowNestedPrefixes(true),
+		mariadbminio.WithRateLimiter(rateLimiter),
 	}
 
 	if ssecKey := os.Getenv(builder.S3SSECCustomerKey); ssecKey != "" {
@@ -259,35 +383,78 @@ func getBinlogTimeline(binlogMetas []binlog.BinlogMetadata) []string {
 	return binlogPath
 }
 
-func pullBinlogs(ctx context.Context, binlogPath []string, calg mariadbv1alpha1.CompressAlgorithm, storageClient interfaces.BlobStorage,
-	logger logr.Logger) error {
-	for _, binlog := range binlogPath {
-		if err := pullBinlog(ctx, binlog, calg, storageClient, logger); err != nil {
+func pullBinlogs(ctx context.Context, binlogMetas []binlog.BinlogMetadata, calg mariadbv1alpha1.CompressAlgorithm,
+	keyring *mariadbcompression.Keyring, storageClient interfaces.BlobStorage, logger logr.Logger) error {
+	for _, meta := range binlogMetas {
+		binlog := meta.ObjectStoragePath()
+		if meta.Chunks > 0 {
+			if err := pullBinlogChunks(ctx, &meta, calg, keyring, storageClient, logger); err != nil {
+				return fmt.Errorf("error pulling binlog chunks %s: %v", binlog, err)
+			}
+			continue
+		}
+		if err := pullBinlog(ctx, binlog, calg, keyring, storageClient, logger); err != nil {
 			return fmt.Errorf("error pulling binlog %s: %v", binlog, err)
 		}
 	}
 	return nil
 }
 
-func pullBinlog(ctx context.Context, binlog string, calg mariadbv1alpha1.CompressAlgorithm, storageClient interfaces.BlobStorage,
-	logger logr.Logger) error {
-	logger.Info("Pulling binlog", "binlog", binlog)
+// pullBinlogChunks pulls a binlog that has been streamed but not yet archived, concatenating its chunks into a single binlog file.
+// It fails when any of the chunks is missing or the resulting binlog does not match the metadata recorded when streaming it.
+func pullBinlogChunks(ctx context.Context, meta *binlog.BinlogMetadata, calg mariadbv1alpha1.CompressAlgorithm,
+	keyring *mariadbcompression.Keyring, storageClient interfaces.BlobStorage, logger logr.Logger) error {
+	binlogPath := meta.ObjectStoragePath()
+	logger.Info("Pulling binlog chunks", "binlog", binlogPath, "chunks", meta.Chunks)
 
-	ext, err := calg.Extension()
-	if err != nil {
-		return fmt.Errorf("error getting extension for compression algorithm %s: %v", calg, err)
+	plainFileName := filepath.Join(path, binlogPath)
+	plainFileDir := filepath.Dir(plainFileName)
+	if err := os.MkdirAll(plainFileDir, os.ModePerm); err != nil {
+		return fmt.Errorf("error creating binlog dir %s: %v", plainFileDir, err)
 	}
-	compressedFileName := binlog
-	if ext != "" {
-		compressedFileName = fmt.Sprintf("%s.%s", binlog, ext)
+
+	pullIsRetriable := func(err error) bool {
+		if ctx.Err() != nil {
+			return false
+		}
+		return err != nil
 	}
+	getChunk := func(ctx context.Context, chunk int) (io.ReadCloser, mariadbcompression.Compressor, error) {
+		compressedFileName, calg, err := binlog.FindCompressedObject(ctx, meta.ChunkObjectStoragePath(chunk), calg, storageClient)
+		if err != nil {
+			return nil, nil, err
+		}
+		compressor, err := mariadbcompression.NewCompressor(calg, mariadbcompression.WithKeyring(keyring))
+		if err != nil {
+			return nil, nil, fmt.Errorf("error getting compressor: %v", err)
+		}
+		var compressedFile io.ReadCloser
+		if err := retry.OnError(pullBackoff, pullIsRetriable, func() error {
+			compressedFile, err = storageClient.GetObjectWithOptions(ctx, compressedFileName)
+			return err
+		}); err != nil {
+			return nil, nil, fmt.Errorf("error pulling binlog chunk %s: %v", compressedFileName, err)
+		}
+		return compressedFile, compressor, nil
+	}
+	if _, err := binlog.PullChunks(ctx, meta, plainFileName, getChunk, logger); err != nil {
+		_ = os.Remove(plainFileName)
+		return err
+	}
+	return nil
+}
+
+func pullBinlog(ctx context.Context, binlogPath string, calg mariadbv1alpha1.CompressAlgorithm, keyring *mariadbcompression.Keyring,
+	storageClient interfaces.BlobStorage, logger logr.Logger) error {
+	logger.Info("Pulling binlog", "binlog", binlogPath)
 
-	exists, err := storageClient.Exists(ctx, compressedFileName)
+	compressedFileName, calg, err := binlog.FindCompressedObject(ctx, binlogPath, calg, storageClient)
 	if err != nil {
-		return fmt.Errorf("error determining if %s exists: %v", compressedFileName, err)
+		return err
 	}
-	if !exists {
-		return fmt.Errorf("binlog file %s not found", compressedFileName)
+	manifest, err := getBinlogManifest(ctx, compressedFileName, storageClient, logger)
+	if err != nil {
+		return err
 	}
 
 	pullIsRetriable := func(err error) bool {
@@ -305,7 +472,7 @@ func pullBinlog(ctx context.Context, binlog string, calg mariadbv1alpha1.Compres
 	}
 	defer compressedFile.Close()
 
-	plainFileName := filepath.Join(path, binlog)
+	plainFileName := filepath.Join(path, binlogPath)
 	plainFileDir := filepath.Dir(plainFileName)
 	if err := os.MkdirAll(plainFileDir, os.ModePerm); err != nil {
 		return fmt.Errorf("error creating binlog dir %s: %v", plainFileDir, err)
@@ -316,7 +483,8 @@ func pullBinlog(ctx context.Context, binlog string, calg mariadbv1alpha1.Compres
 	}
 	defer plainFile.Close()
 
-	compressor, err := mariadbcompression.NewCompressor(calg)
+	// Binlogs are decrypted using the key recorded in the file, unencrypted binlogs are left untouched.
+	compressor, err := mariadbcompression.NewCompressor(calg, mariadbcompression.WithKeyring(keyring))
 	if err != nil {
 		return fmt.Errorf("error getting compressor: %v", err)
 	}
@@ -329,9 +497,30 @@ func pullBinlog(ctx context.Context, binlog string, calg mariadbv1alpha1.Compres
 			"compression", calg,
 		)
 	}
-	if err := compressor.Decompress(ctx, plainFile, compressedFile); err != nil {
+	var reader io.Reader = compressedFile
+	var verifier *backup.ManifestVerifier
+	if manifest != nil {
+		verifier = manifest.NewVerifier()
+		reader = io.TeeReader(compressedFile, verifier)
+	}
+	if err := compressor.Decompress(ctx, plainFile, reader); err != nil {
 		return fmt.Errorf("error decompressing file %s into %s: %v", compressedFileName, plainFileName, err)
 	}
+	if manifest == nil {
+		return nil
+	}
+
+	plainStat, err := plainFile.Stat()
+	if err != nil {
+		return fmt.Errorf("error stat binlog file %s: %v", plainFileName, err)
+	}
+	logger.Info("Verifying binlog integrity", "binlog", compressedFileName, "sha256", manifest.SHA256)
+	if err := verifyBinlog(manifest, verifier, reader, plainStat.Size()); err != nil {
+		if err := os.Remove(plainFileName); err != nil {
+			logger.Error(err, "Error removing binlog file", "file", plainFileName)
+		}
+		return fmt.Errorf("error verifying binlog integrity: %v", err)
+	}
 	return nil
 }
 
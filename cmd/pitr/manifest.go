@@ -0,0 +1,58 @@
+package pitr
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/go-logr/logr"
+	"github.com/mariadb-operator/mariadb-operator/v26/pkg/backup"
+	"github.com/mariadb-operator/mariadb-operator/v26/pkg/interfaces"
+	"k8s.io/client-go/util/retry"
+)
+
+// getBinlogManifest fetches the Manifest of a compressed binlog from the storage.
+// It returns nil for binlogs archived before manifests were introduced, which are not verified.
+func getBinlogManifest(ctx context.Context, compressedFileName string, storageClient interfaces.BlobStorage,
+	logger logr.Logger) (*backup.Manifest, error) {
+	manifestName := backup.ManifestFileName(compressedFileName)
+	exists, err := storageClient.Exists(ctx, manifestName)
+	if err != nil {
+		return nil, fmt.Errorf("error determining if %s exists: %v", manifestName, err)
+	}
+	if !exists {
+		logger.Info("Manifest not found, skipping integrity verification", "binlog", compressedFileName)
+		return nil, nil
+	}
+
+	var manifestBytes []byte
+	if err := retry.OnError(pullBackoff, func(err error) bool { return ctx.Err() == nil && err != nil }, func() error {
+		reader, err := storageClient.GetObjectWithOptions(ctx, manifestName)
+		if err != nil {
+			return err
+		}
+		defer reader.Close()
+		manifestBytes, err = io.ReadAll(reader)
+		return err
+	}); err != nil {
+		return nil, fmt.Errorf("error pulling manifest %s: %v", manifestName, err)
+	}
+	return backup.ParseManifest(manifestBytes)
+}
+
+// verifyBinlog verifies a compressed binlog, read through the verifier, and its decompressed size against the Manifest.
+func verifyBinlog(manifest *backup.Manifest, verifier *backup.ManifestVerifier, compressedFile io.Reader,
+	uncompressedSize int64) error {
+	// Drain any trailing bytes not consumed by the decompressor, so they are accounted in the checksum.
+	if _, err := io.Copy(io.Discard, compressedFile); err != nil {
+		return fmt.Errorf("error reading binlog: %v", err)
+	}
+	if err := verifier.Verify(); err != nil {
+		return err
+	}
+	if manifest.UncompressedSize > 0 && manifest.UncompressedSize != uncompressedSize {
+		return fmt.Errorf("unexpected uncompressed size of %s, expected: %d got: %d",
+			manifest.FileName, manifest.UncompressedSize, uncompressedSize)
+	}
+	return nil
+}
@@ -0,0 +1,76 @@
+package pitr
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	mariadbv1alpha1 "github.com/mariadb-operator/mariadb-operator/v26/api/v1alpha1"
+	"github.com/mariadb-operator/mariadb-operator/v26/pkg/backup"
+	"github.com/mariadb-operator/mariadb-operator/v26/pkg/binlog"
+	"github.com/mariadb-operator/mariadb-operator/v26/pkg/interfaces"
+)
+
+// getSecondaryStorages parses the secondary storages provided in JSON format.
+func getSecondaryStorages() ([]mariadbv1alpha1.SecondaryStorage, error) {
+	if secondaryStoragesRaw == "" {
+		return nil, nil
+	}
+	var storages []mariadbv1alpha1.SecondaryStorage
+	if err := json.Unmarshal([]byte(secondaryStoragesRaw), &storages); err != nil {
+		return nil, fmt.Errorf("error unmarshaling secondary storages: %v", err)
+	}
+	if err := mariadbv1alpha1.ValidateSecondaryStorages(storages); err != nil {
+		return nil, err
+	}
+	return storages, nil
+}
+
+// getStorageClientWithIndex returns a storage client along with the binlog index stored in it.
+// The primary storage is used by default, falling back to the secondary storages in order when it is unreachable.
+func getStorageClientWithIndex(ctx context.Context) (interfaces.BlobStorage, *binlog.BinlogIndex, error) {
+	storageClient, binlogIndex, primaryErr := getPrimaryStorageClientWithIndex(ctx)
+	if primaryErr == nil {
+		return storageClient, binlogIndex, nil
+	}
+	storages, err := getSecondaryStorages()
+	if err != nil {
+		return nil, nil, errors.Join(primaryErr, err)
+	}
+	if len(storages) == 0 {
+		return nil, nil, primaryErr
+	}
+	logger.Error(primaryErr, "Error getting binlog index from primary storage, falling back to secondary storages")
+
+	errs := []error{primaryErr}
+	for i, storage := range storages {
+		storageClient, err := backup.NewSecondaryBlobStorage(i, &storage, path, backup.WithSecondaryStorageNestedPrefixes(true),
+			backup.WithSecondaryStorageRateLimiter(rateLimiter))
+		if err != nil {
+			errs = append(errs, fmt.Errorf("error getting secondary storage '%s': %v", storage.Name, err))
+			continue
+		}
+		binlogIndex, err := getBinlogIndex(ctx, storageClient)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("error getting binlog index from secondary storage '%s': %v", storage.Name, err))
+			continue
+		}
+		logger.Info("Using secondary storage", "name", storage.Name)
+		return storageClient, binlogIndex, nil
+	}
+	return nil, nil, errors.Join(errs...)
+}
+
+func getPrimaryStorageClientWithIndex(ctx context.Context) (interfaces.BlobStorage, *binlog.BinlogIndex, error) {
+	storageClient, err := getStorageClient()
+	if err != nil {
+		return nil, nil, fmt.Errorf("error getting storage client: %v", err)
+	}
+	logger.Info("Getting binlog index from object storage")
+	binlogIndex, err := getBinlogIndex(ctx, storageClient)
+	if err != nil {
+		return nil, nil, err
+	}
+	return storageClient, binlogIndex, nil
+}
@@ -10,12 +10,17 @@ import (
 	"github.com/mariadb-operator/mariadb-operator/v26/pkg/builder"
 	condition "github.com/mariadb-operator/mariadb-operator/v26/pkg/condition"
 	"github.com/mariadb-operator/mariadb-operator/v26/pkg/controller/batch"
+	"github.com/mariadb-operator/mariadb-operator/v26/pkg/controller/hook"
 	"github.com/mariadb-operator/mariadb-operator/v26/pkg/controller/rbac"
 	"github.com/mariadb-operator/mariadb-operator/v26/pkg/interfaces"
+	"github.com/mariadb-operator/mariadb-operator/v26/pkg/metrics"
+	"github.com/mariadb-operator/mariadb-operator/v26/pkg/progress"
 	"github.com/mariadb-operator/mariadb-operator/v26/pkg/refresolver"
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -31,13 +36,15 @@ type BackupReconciler struct {
 	ConditionComplete *condition.Complete
 	RBACReconciler    *rbac.RBACReconciler
 	BatchReconciler   *batch.BatchReconciler
+	HookReconciler    *hook.HookReconciler
 }
 
 //+kubebuilder:rbac:groups=k8s.mariadb.com,resources=backups,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=k8s.mariadb.com,resources=backups/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=k8s.mariadb.com,resources=backups/finalizers,verbs=update
-//+kubebuilder:rbac:groups=batch,resources=jobs,verbs=list;watch;create;patch
-//+kubebuilder:rbac:groups=batch,resources=cronjobs,verbs=list;watch;create;patch
+//+kubebuilder:rbac:groups=batch,resources=jobs,verbs=get;list;watch;create;patch;delete
+//+kubebuilder:rbac:groups=batch,resources=cronjobs,verbs=get;list;watch;create;patch
+//+kubebuilder:rbac:groups="",resources=pods/exec,verbs=create
 //+kubebuilder:rbac:groups="",resources=serviceaccounts,verbs=list;watch;create;patch
 //+kubebuilder:rbac:groups="",resources=persistentvolumeclaims,verbs=list;watch;create;patch
 
@@ -46,6 +53,9 @@ type BackupReconciler struct {
 func (r *BackupReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	var backup mariadbv1alpha1.Backup
 	if err := r.Get(ctx, req.NamespacedName, &backup); err != nil {
+		if apierrors.IsNotFound(err) {
+			metrics.DeleteBackupProgress(mariadbv1alpha1.BackupKind, req.Namespace, req.Name)
+		}
 		return ctrl.Result{}, client.IgnoreNotFound(err)
 	}
 	mariaDb, err := r.RefResolver.MariaDBObject(ctx, &backup.Spec.MariaDBRef, backup.Namespace)
@@ -70,8 +80,8 @@ func (r *BackupReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctr
 		return ctrl.Result{}, fmt.Errorf("error defaulting Backup: %v", err)
 	}
 
-	if err := r.reconcileServiceAccount(ctx, &backup); err != nil {
-		return ctrl.Result{}, fmt.Errorf("error reconciling ServiceAccount: %v", err)
+	if err := r.reconcileRBAC(ctx, &backup); err != nil {
+		return ctrl.Result{}, fmt.Errorf("error reconciling RBAC: %v", err)
 	}
 
 	var batchErr *multierror.Error
@@ -92,6 +102,17 @@ func (r *BackupReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctr
 	if err := batchErr.ErrorOrNil(); err != nil {
 		return ctrl.Result{}, fmt.Errorf("error creating Job: %v", err)
 	}
+
+	jobs, err := r.backupJobs(ctx, &backup)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("error listing Jobs: %v", err)
+	}
+	if err := r.reconcileHooks(ctx, &backup, mariaDb, jobs); err != nil {
+		return ctrl.Result{}, fmt.Errorf("error reconciling hooks: %v", err)
+	}
+	if err := r.reconcileProgress(ctx, &backup, jobs); err != nil {
+		return ctrl.Result{}, fmt.Errorf("error reconciling progress: %v", err)
+	}
 	return ctrl.Result{}, nil
 }
 
@@ -106,10 +127,110 @@ func (r *BackupReconciler) setDefaults(ctx context.Context, backup *mariadbv1alp
 	})
 }
 
-func (r *BackupReconciler) reconcileServiceAccount(ctx context.Context, backup *mariadbv1alpha1.Backup) error {
+func (r *BackupReconciler) reconcileRBAC(ctx context.Context, backup *mariadbv1alpha1.Backup) error {
 	key := backup.Spec.ServiceAccountKey(backup.ObjectMeta)
-	_, err := r.RBACReconciler.ReconcileServiceAccount(ctx, key, backup, backup.Spec.InheritMetadata)
-	return err
+	sa, err := r.RBACReconciler.ReconcileServiceAccount(ctx, key, backup, backup.Spec.InheritMetadata)
+	if err != nil {
+		return fmt.Errorf("error reconciling ServiceAccount: %v", err)
+	}
+
+	// The Backup Job reports the integrity verification of the latest backup and the backup progress in the Backup status.
+	rules := []rbacv1.PolicyRule{
+		{
+			APIGroups: []string{
+				mariadbv1alpha1.GroupVersion.Group,
+			},
+			Resources: []string{
+				"backups",
+				"backups/status",
+			},
+			Verbs: []string{
+				"get",
+				"patch",
+				"update",
+			},
+		},
+	}
+	role, err := r.RBACReconciler.ReconcileRole(ctx, backup.RoleKey(), backup, backup.Spec.InheritMetadata, rules)
+	if err != nil {
+		return fmt.Errorf("error reconciling Role: %v", err)
+	}
+
+	roleRef := rbacv1.RoleRef{
+		APIGroup: rbacv1.GroupName,
+		Kind:     "Role",
+		Name:     role.Name,
+	}
+	if err := r.RBACReconciler.ReconcileRoleBinding(
+		ctx,
+		backup.RoleBindingKey(),
+		backup,
+		backup.Spec.InheritMetadata,
+		sa,
+		roleRef,
+	); err != nil {
+		return fmt.Errorf("error reconciling RoleBinding: %v", err)
+	}
+	return nil
+}
+
+func (r *BackupReconciler) reconcileHooks(ctx context.Context, backup *mariadbv1alpha1.Backup,
+	mariadb interfaces.MariaDBObject, jobs []batchv1.Job) error {
+	return r.HookReconciler.ReconcileJobs(ctx, jobs, backup.Spec.Hooks, mariadb, backup.Spec.Schedule != nil,
+		func(c metav1.Condition) error {
+			return r.patchStatus(ctx, backup, func(status condition.Conditioner) {
+				status.SetCondition(c)
+			})
+		})
+}
+
+// reconcileProgress reports the initial phase of a running Job, as the subsequent ones are reported by the Job itself,
+// and exposes the progress as metrics.
+func (r *BackupReconciler) reconcileProgress(ctx context.Context, backup *mariadbv1alpha1.Backup, jobs []batchv1.Job) error {
+	job := progress.RunningJob(jobs)
+	if job == nil {
+		metrics.DeleteBackupProgress(mariadbv1alpha1.BackupKind, backup.Namespace, backup.Name)
+		return nil
+	}
+
+	backupProgress, changed := progress.ForJob(job, backup.Status.Progress, mariadbv1alpha1.BackupProgressPhaseDump, time.Now())
+	if changed {
+		patch := client.MergeFrom(backup.DeepCopy())
+		backup.Status.Progress = backupProgress
+		if err := r.Client.Status().Patch(ctx, backup, patch); err != nil {
+			return fmt.Errorf("error patching progress: %v", err)
+		}
+	}
+	metrics.RecordBackupProgress(mariadbv1alpha1.BackupKind, backup.Namespace, backup.Name, backupProgress)
+	return nil
+}
+
+// backupJobs returns the Jobs that perform the backup, which are created either by the Backup or by its CronJob.
+func (r *BackupReconciler) backupJobs(ctx context.Context, backup *mariadbv1alpha1.Backup) ([]batchv1.Job, error) {
+	key := client.ObjectKeyFromObject(backup)
+	if backup.Spec.Schedule == nil {
+		var job batchv1.Job
+		if err := r.Get(ctx, key, &job); err != nil {
+			return nil, client.IgnoreNotFound(err)
+		}
+		return []batchv1.Job{job}, nil
+	}
+
+	var cronJob batchv1.CronJob
+	if err := r.Get(ctx, key, &cronJob); err != nil {
+		return nil, client.IgnoreNotFound(err)
+	}
+	var jobList batchv1.JobList
+	if err := r.List(ctx, &jobList, client.InNamespace(backup.Namespace)); err != nil {
+		return nil, err
+	}
+	var jobs []batchv1.Job
+	for _, job := range jobList.Items {
+		if owner := metav1.GetControllerOf(&job); owner != nil && owner.UID == cronJob.UID {
+			jobs = append(jobs, job)
+		}
+	}
+	return jobs, nil
 }
 
 func (r *BackupReconciler) patch(ctx context.Context, backup *mariadbv1alpha1.Backup, patcher func(*mariadbv1alpha1.Backup)) error {
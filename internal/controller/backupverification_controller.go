@@ -0,0 +1,429 @@
+package controller
+
+import (
+	"context"
+	dbsql "database/sql"
+	"errors"
+	"fmt"
+	"path"
+	"time"
+
+	"github.com/go-logr/logr"
+	mariadbv1alpha1 "github.com/mariadb-operator/mariadb-operator/v26/api/v1alpha1"
+	"github.com/mariadb-operator/mariadb-operator/v26/pkg/backup"
+	"github.com/mariadb-operator/mariadb-operator/v26/pkg/builder"
+	condition "github.com/mariadb-operator/mariadb-operator/v26/pkg/condition"
+	"github.com/mariadb-operator/mariadb-operator/v26/pkg/metrics"
+	mdbpvc "github.com/mariadb-operator/mariadb-operator/v26/pkg/pvc"
+	"github.com/mariadb-operator/mariadb-operator/v26/pkg/refresolver"
+	"github.com/mariadb-operator/mariadb-operator/v26/pkg/sql"
+	mdbsnapshot "github.com/mariadb-operator/mariadb-operator/v26/pkg/volumesnapshot"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// BackupVerificationReconciler reconciles a BackupVerification object
+type BackupVerificationReconciler struct {
+	client.Client
+	Scheme          *runtime.Scheme
+	Builder         *builder.Builder
+	RefResolver     *refresolver.RefResolver
+	BackupProcessor backup.BackupProcessor
+	RequeueInterval time.Duration
+}
+
+//+kubebuilder:rbac:groups=k8s.mariadb.com,resources=backupverifications,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=k8s.mariadb.com,resources=backupverifications/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=k8s.mariadb.com,resources=backupverifications/finalizers,verbs=update
+//+kubebuilder:rbac:groups=k8s.mariadb.com,resources=mariadbs,verbs=get;list;watch;create;patch;delete
+//+kubebuilder:rbac:groups="",resources=persistentvolumeclaims,verbs=list;watch;delete
+
+// Reconcile is part of the main kubernetes reconciliation loop which aims to
+// move the current state of the cluster closer to the desired state.
+func (r *BackupVerificationReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	var verification mariadbv1alpha1.BackupVerification
+	if err := r.Get(ctx, req.NamespacedName, &verification); err != nil {
+		if apierrors.IsNotFound(err) {
+			metrics.DeleteBackupVerification(req.Namespace, req.Name)
+		}
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	logger := log.FromContext(ctx).WithName("backupverification")
+
+	if verification.IsVerifying() {
+		return r.reconcileVerification(ctx, &verification, logger)
+	}
+	return r.reconcileSchedule(ctx, &verification, logger)
+}
+
+func (r *BackupVerificationReconciler) reconcileSchedule(ctx context.Context, verification *mariadbv1alpha1.BackupVerification,
+	logger logr.Logger) (ctrl.Result, error) {
+	now := time.Now()
+	lastScheduleTime := verification.Status.LastScheduleTime
+
+	if verification.Spec.Schedule == nil {
+		if lastScheduleTime != nil {
+			return ctrl.Result{}, nil
+		}
+		return r.startVerification(ctx, verification, now, logger)
+	}
+	schedule := verification.Spec.Schedule
+
+	if schedule.Suspend {
+		return ctrl.Result{}, nil
+	}
+	if lastScheduleTime == nil {
+		return r.startVerification(ctx, verification, now, logger)
+	}
+
+	cronSchedule, err := mariadbv1alpha1.CronParser.Parse(schedule.Cron)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("error parsing cron schedule: %v", err)
+	}
+	nextTime := cronSchedule.Next(lastScheduleTime.Time)
+
+	if now.Before(nextTime) {
+		return ctrl.Result{RequeueAfter: nextTime.Sub(now)}, nil
+	}
+	return r.startVerification(ctx, verification, now, logger)
+}
+
+func (r *BackupVerificationReconciler) startVerification(ctx context.Context, verification *mariadbv1alpha1.BackupVerification,
+	now time.Time, logger logr.Logger) (ctrl.Result, error) {
+	targetRecoveryTime := ptr.Deref(verification.Spec.TargetRecoveryTime, metav1.Time{Time: now})
+
+	_, fileName, err := r.resolveBackup(ctx, verification, targetRecoveryTime.Time, logger)
+	if err != nil {
+		msg := fmt.Sprintf("Error resolving backup: %v", err)
+		if patchErr := r.patchStatus(ctx, verification, func(status *mariadbv1alpha1.BackupVerificationStatus) {
+			condition.SetBackupVerificationFailed(status, msg)
+		}); patchErr != nil {
+			return ctrl.Result{}, fmt.Errorf("error patching BackupVerification status: %v", patchErr)
+		}
+		return ctrl.Result{}, errors.New(msg)
+	}
+	key := verification.MariaDBKey(now)
+
+	logger.Info("Starting backup verification", "mariadb", key.Name, "file", fileName,
+		"target-time", targetRecoveryTime.Format(time.RFC3339))
+
+	if err := r.patchStatus(ctx, verification, func(status *mariadbv1alpha1.BackupVerificationStatus) {
+		status.LastScheduleTime = &metav1.Time{Time: now}
+		status.CurrentVerification = &mariadbv1alpha1.BackupVerificationResult{
+			MariaDB:            key.Name,
+			BackupFileName:     fileName,
+			TargetRecoveryTime: &targetRecoveryTime,
+			StartTime:          metav1.Time{Time: now},
+		}
+		condition.SetBackupVerifying(status, fileName)
+	}); err != nil {
+		return ctrl.Result{}, fmt.Errorf("error patching BackupVerification status: %v", err)
+	}
+	return r.reconcileVerification(ctx, verification, logger)
+}
+
+func (r *BackupVerificationReconciler) reconcileVerification(ctx context.Context, verification *mariadbv1alpha1.BackupVerification,
+	logger logr.Logger) (ctrl.Result, error) {
+	current := verification.Status.CurrentVerification
+	timeout := ptr.Deref(verification.Spec.Timeout, metav1.Duration{Duration: time.Hour}).Duration
+
+	if time.Since(current.StartTime.Time) > timeout {
+		return ctrl.Result{}, r.completeVerification(ctx, verification, false,
+			fmt.Sprintf("Verification timed out after %s", timeout), nil, logger)
+	}
+
+	key := types.NamespacedName{
+		Name:      current.MariaDB,
+		Namespace: verification.Namespace,
+	}
+	var mariadb mariadbv1alpha1.MariaDB
+	if err := r.Get(ctx, key, &mariadb); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return ctrl.Result{}, fmt.Errorf("error getting MariaDB: %v", err)
+		}
+		if err := r.createMariaDB(ctx, verification, key, logger); err != nil {
+			return ctrl.Result{}, fmt.Errorf("error creating MariaDB: %v", err)
+		}
+		return ctrl.Result{RequeueAfter: r.RequeueInterval}, nil
+	}
+
+	if err := mariadb.ReplayBinlogsError(); err != nil {
+		return ctrl.Result{}, r.completeVerification(ctx, verification, false,
+			fmt.Sprintf("Error replaying binary logs: %v", err), nil, logger)
+	}
+	if mariadb.HasSkippedBinlogReplay() {
+		return ctrl.Result{}, r.completeVerification(ctx, verification, false,
+			"Binary log replay was skipped", nil, logger)
+	}
+	if !mariadb.IsReady() || !mariadb.HasRestoredBackup() || mariadb.HasPendingBinlogReplay() {
+		logger.V(1).Info("Waiting for backup to be restored", "mariadb", mariadb.Name)
+		return ctrl.Result{RequeueAfter: r.RequeueInterval}, nil
+	}
+
+	results, err := r.runAssertions(ctx, verification, &mariadb)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("error running assertions: %v", err)
+	}
+	failed := 0
+	for _, result := range results {
+		if !result.Succeeded {
+			failed++
+		}
+	}
+	if failed > 0 {
+		return ctrl.Result{}, r.completeVerification(ctx, verification, false,
+			fmt.Sprintf("%d out of %d assertions failed", failed, len(results)), results, logger)
+	}
+	return ctrl.Result{}, r.completeVerification(ctx, verification, true,
+		fmt.Sprintf("Backup restored and %d assertions succeeded", len(results)), results, logger)
+}
+
+func (r *BackupVerificationReconciler) createMariaDB(ctx context.Context, verification *mariadbv1alpha1.BackupVerification,
+	key types.NamespacedName, logger logr.Logger) error {
+	current := verification.Status.CurrentVerification
+	targetRecoveryTime := ptr.Deref(current.TargetRecoveryTime, current.StartTime)
+
+	source, _, err := r.resolveSource(ctx, verification)
+	if err != nil {
+		return err
+	}
+	mariadb, err := r.Builder.BuildBackupVerificationMariaDB(key, verification, source, targetRecoveryTime.Time)
+	if err != nil {
+		return fmt.Errorf("error building MariaDB: %v", err)
+	}
+
+	logger.Info("Creating ephemeral MariaDB", "mariadb", key.Name)
+	if err := r.Create(ctx, mariadb); err != nil && !apierrors.IsAlreadyExists(err) {
+		return err
+	}
+	return nil
+}
+
+func (r *BackupVerificationReconciler) completeVerification(ctx context.Context, verification *mariadbv1alpha1.BackupVerification,
+	succeeded bool, message string, assertions []mariadbv1alpha1.BackupVerificationAssertionResult, logger logr.Logger) error {
+	result := verification.Status.CurrentVerification.DeepCopy()
+	result.Assertions = assertions
+	result.Complete(succeeded, message, time.Now())
+
+	if err := r.cleanupMariaDB(ctx, verification, result.MariaDB, logger); err != nil {
+		return fmt.Errorf("error cleaning up MariaDB: %v", err)
+	}
+
+	if err := r.patchStatus(ctx, verification, func(status *mariadbv1alpha1.BackupVerificationStatus) {
+		status.CurrentVerification = nil
+		status.LastVerification = result
+		if succeeded {
+			condition.SetBackupVerified(status, result.BackupFileName)
+		} else {
+			condition.SetBackupVerificationFailed(status, message)
+		}
+	}); err != nil {
+		return fmt.Errorf("error patching BackupVerification status: %v", err)
+	}
+	metrics.RecordBackupVerification(verification.Namespace, verification.Name, succeeded,
+		result.Duration.Duration, result.CompletionTime.Time)
+
+	logger.Info("Backup verification completed", "file", result.BackupFileName, "succeeded", succeeded,
+		"duration", result.Duration.Duration.String(), "message", message)
+	return nil
+}
+
+// cleanupMariaDB deletes the ephemeral MariaDB along with its storage PVCs.
+// PVCs are explicitly deleted, as PVC retention policies are not available in all Kubernetes versions.
+func (r *BackupVerificationReconciler) cleanupMariaDB(ctx context.Context, verification *mariadbv1alpha1.BackupVerification,
+	name string, logger logr.Logger) error {
+	mariadb := mariadbv1alpha1.MariaDB{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: verification.Namespace,
+		},
+	}
+	logger.Info("Deleting ephemeral MariaDB", "mariadb", name)
+	if err := r.Delete(ctx, &mariadb); err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("error deleting MariaDB: %v", err)
+	}
+
+	pvcs, err := mdbpvc.ListStoragePVCs(ctx, r.Client, &mariadb)
+	if err != nil {
+		return err
+	}
+	for _, pvc := range pvcs {
+		if err := r.Delete(ctx, &pvc); err != nil && !apierrors.IsNotFound(err) {
+			return fmt.Errorf("error deleting PVC '%s': %v", pvc.Name, err)
+		}
+	}
+	return nil
+}
+
+func (r *BackupVerificationReconciler) runAssertions(ctx context.Context, verification *mariadbv1alpha1.BackupVerification,
+	mariadb *mariadbv1alpha1.MariaDB) ([]mariadbv1alpha1.BackupVerificationAssertionResult, error) {
+	results := make([]mariadbv1alpha1.BackupVerificationAssertionResult, len(verification.Spec.Assertions))
+
+	for i, assertion := range verification.Spec.Assertions {
+		var opts []sql.Opt
+		if assertion.Database != nil {
+			opts = append(opts, sql.WithDatabase(*assertion.Database))
+		}
+		sqlClient, err := sql.NewClientWithMariaDB(ctx, mariadb, r.RefResolver, opts...)
+		if err != nil {
+			return nil, fmt.Errorf("error connecting to MariaDB: %v", err)
+		}
+		results[i] = runAssertion(ctx, sqlClient, assertion)
+		sqlClient.Close()
+	}
+	return results, nil
+}
+
+func runAssertion(ctx context.Context, sqlClient *sql.Client,
+	assertion mariadbv1alpha1.BackupVerificationAssertion) mariadbv1alpha1.BackupVerificationAssertionResult {
+	result := mariadbv1alpha1.BackupVerificationAssertionResult{
+		Name: assertion.Name,
+	}
+
+	var value dbsql.NullString
+	if err := sqlClient.QueryRow(ctx, assertion.Query).Scan(&value); err != nil {
+		if errors.Is(err, dbsql.ErrNoRows) {
+			result.Message = "Query returned no rows"
+		} else {
+			result.Message = fmt.Sprintf("Error executing query: %v", err)
+		}
+		return result
+	}
+	if value.Valid {
+		result.Value = ptr.To(value.String)
+	}
+
+	if assertion.Expected != nil && (!value.Valid || value.String != *assertion.Expected) {
+		result.Message = fmt.Sprintf("Expected '%s', got '%s'", *assertion.Expected, ptr.Deref(result.Value, "NULL"))
+		return result
+	}
+	result.Succeeded = true
+	return result
+}
+
+// resolveBackup returns the MariaDB the backup was taken from, and the name of the backup file closest to the target recovery time.
+// The file name is only available when the backup is stored in object storage or in VolumeSnapshots.
+func (r *BackupVerificationReconciler) resolveBackup(ctx context.Context, verification *mariadbv1alpha1.BackupVerification,
+	targetRecoveryTime time.Time, logger logr.Logger) (*mariadbv1alpha1.MariaDB, string, error) {
+	source, backupObj, err := r.resolveSource(ctx, verification)
+	if err != nil {
+		return nil, "", err
+	}
+
+	var fileName string
+	switch b := backupObj.(type) {
+	case *mariadbv1alpha1.PhysicalBackup:
+		if b.Spec.Storage.VolumeSnapshot != nil {
+			fileName, err = r.getTargetVolumeSnapshot(ctx, b, targetRecoveryTime, logger)
+		} else {
+			storage := b.Spec.Storage
+			fileName, err = r.getBackupTargetFile(ctx, storage.S3, storage.AzureBlob, storage.GCS, b.Namespace,
+				backup.NewPhysicalBackupProcessor(), targetRecoveryTime, logger)
+		}
+	case *mariadbv1alpha1.Backup:
+		storage := b.Spec.Storage
+		fileName, err = r.getBackupTargetFile(ctx, storage.S3, nil, storage.GCS, b.Namespace,
+			backup.NewLogicalBackupProcessor(), targetRecoveryTime, logger)
+	}
+	if err != nil {
+		return nil, "", err
+	}
+	return source, fileName, nil
+}
+
+// resolveSource returns the MariaDB the backup was taken from, and the backup object.
+func (r *BackupVerificationReconciler) resolveSource(ctx context.Context,
+	verification *mariadbv1alpha1.BackupVerification) (*mariadbv1alpha1.MariaDB, client.Object, error) {
+	backupRef := verification.Spec.BackupRef.LocalReference()
+
+	if verification.BackupKind() == mariadbv1alpha1.PhysicalBackupKind {
+		physicalBackup, err := r.RefResolver.PhysicalBackup(ctx, backupRef, verification.Namespace)
+		if err != nil {
+			return nil, nil, fmt.Errorf("error getting PhysicalBackup: %v", err)
+		}
+		if verification.Spec.PointInTimeRecoveryRef != nil {
+			pitr, err := r.RefResolver.PointInTimeRecovery(ctx, verification.Spec.PointInTimeRecoveryRef, verification.Namespace)
+			if err != nil {
+				return nil, nil, fmt.Errorf("error getting PointInTimeRecovery: %v", err)
+			}
+			if pitr.Spec.PhysicalBackupRef == nil || pitr.Spec.PhysicalBackupRef.Name != physicalBackup.Name {
+				return nil, nil, fmt.Errorf("PointInTimeRecovery '%s' does not refer to PhysicalBackup '%s'", pitr.Name, physicalBackup.Name)
+			}
+		}
+		mariadb, err := r.RefResolver.MariaDB(ctx, &physicalBackup.Spec.MariaDBRef, verification.Namespace)
+		if err != nil {
+			return nil, nil, fmt.Errorf("error getting MariaDB: %v", err)
+		}
+		return mariadb, physicalBackup, nil
+	}
+
+	logicalBackup, err := r.RefResolver.Backup(ctx, backupRef, verification.Namespace)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error getting Backup: %v", err)
+	}
+	mariadb, err := r.RefResolver.MariaDB(ctx, &logicalBackup.Spec.MariaDBRef, verification.Namespace)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error getting MariaDB: %v", err)
+	}
+	return mariadb, logicalBackup, nil
+}
+
+func (r *BackupVerificationReconciler) getBackupTargetFile(ctx context.Context, s3 *mariadbv1alpha1.S3,
+	abs *mariadbv1alpha1.AzureBlob, gcs *mariadbv1alpha1.GCS, namespace string, processor backup.BackupProcessor,
+	targetRecoveryTime time.Time, logger logr.Logger) (string, error) {
+	if s3 == nil && abs == nil && gcs == nil {
+		logger.V(1).Info("Backup not stored in object storage. Unable to determine the backup file")
+		return "", nil
+	}
+	storageClient, err := newBlobStorageClient(ctx, r.RefResolver, s3, abs, gcs, namespace)
+	if err != nil {
+		return "", fmt.Errorf("error getting storage client: %v", err)
+	}
+	fileNames, err := backup.NewBlobBackupStorage(storageClient, processor).List(ctx)
+	if err != nil {
+		return "", fmt.Errorf("error listing backup files: %v", err)
+	}
+	fileName, err := processor.GetBackupTargetFile(fileNames, targetRecoveryTime, logger)
+	if err != nil {
+		return "", fmt.Errorf("error getting target backup file: %v", err)
+	}
+	return path.Base(fileName), nil
+}
+
+func (r *BackupVerificationReconciler) getTargetVolumeSnapshot(ctx context.Context, physicalBackup *mariadbv1alpha1.PhysicalBackup,
+	targetRecoveryTime time.Time, logger logr.Logger) (string, error) {
+	snapshotList, err := mdbsnapshot.ListReadyVolumeSnapshots(ctx, r.Client, physicalBackup)
+	if err != nil {
+		return "", fmt.Errorf("error listing ready VolumeSnapshots: %v", err)
+	}
+	snapshotNames := make([]string, len(snapshotList.Items))
+	for i, snapshot := range snapshotList.Items {
+		snapshotNames[i] = snapshot.Name
+	}
+	targetSnapshot, err := r.BackupProcessor.GetBackupTargetFile(snapshotNames, targetRecoveryTime, logger)
+	if err != nil {
+		return "", fmt.Errorf("error getting target VolumeSnapshot: %v", err)
+	}
+	return targetSnapshot, nil
+}
+
+func (r *BackupVerificationReconciler) patchStatus(ctx context.Context, verification *mariadbv1alpha1.BackupVerification,
+	patcher func(*mariadbv1alpha1.BackupVerificationStatus)) error {
+	patch := client.MergeFrom(verification.DeepCopy())
+	patcher(&verification.Status)
+	return r.Client.Status().Patch(ctx, verification, patch)
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *BackupVerificationReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&mariadbv1alpha1.BackupVerification{}).
+		Owns(&mariadbv1alpha1.MariaDB{}).
+		Complete(r)
+}
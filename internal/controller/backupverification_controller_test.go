@@ -0,0 +1,130 @@
+package controller
+
+import (
+	"fmt"
+
+	mariadbv1alpha1 "github.com/mariadb-operator/mariadb-operator/v26/api/v1alpha1"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("BackupVerification", Label("basic"), func() {
+	BeforeEach(func() {
+		By("Waiting for MariaDB to be ready")
+		expectMariadbReady(testCtx, k8sClient, testMdbkey)
+	})
+
+	It("should restore a Backup into an ephemeral MariaDB", func() {
+		key := types.NamespacedName{
+			Name:      "backupverification-test",
+			Namespace: testNamespace,
+		}
+		backupKey := types.NamespacedName{
+			Name:      fmt.Sprintf("%s-%s", key.Name, "backup"),
+			Namespace: testNamespace,
+		}
+
+		By("Creating Backup")
+		backup := getBackupWithPVCStorage(backupKey)
+		Expect(k8sClient.Create(testCtx, backup)).To(Succeed())
+		DeferCleanup(func() {
+			Expect(k8sClient.Delete(testCtx, backup)).To(Succeed())
+		})
+
+		By("Expecting Backup to complete eventually")
+		Eventually(func() bool {
+			if err := k8sClient.Get(testCtx, backupKey, backup); err != nil {
+				return false
+			}
+			return backup.IsComplete()
+		}, testTimeout, testInterval).Should(BeTrue())
+
+		By("Creating BackupVerification")
+		verification := &mariadbv1alpha1.BackupVerification{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      key.Name,
+				Namespace: key.Namespace,
+			},
+			Spec: mariadbv1alpha1.BackupVerificationSpec{
+				BackupRef: mariadbv1alpha1.TypedLocalObjectReference{
+					Name: backupKey.Name,
+					Kind: mariadbv1alpha1.BackupKind,
+				},
+				Assertions: []mariadbv1alpha1.BackupVerificationAssertion{
+					{
+						Name:  "server",
+						Query: "SELECT 1",
+					},
+				},
+				InheritMetadata: &mariadbv1alpha1.Metadata{
+					Labels: map[string]string{
+						"k8s.mariadb.com/test": "test",
+					},
+				},
+			},
+		}
+		Expect(k8sClient.Create(testCtx, verification)).To(Succeed())
+		DeferCleanup(func() {
+			Expect(k8sClient.Delete(testCtx, verification)).To(Succeed())
+		})
+
+		By("Expecting BackupVerification to start verifying eventually")
+		Eventually(func(g Gomega) bool {
+			g.Expect(k8sClient.Get(testCtx, key, verification)).To(Succeed())
+			return verification.IsVerifying() && verification.Status.LastScheduleTime != nil
+		}, testTimeout, testInterval).Should(BeTrue())
+
+		By("Expecting to create an ephemeral MariaDB eventually")
+		Eventually(func(g Gomega) bool {
+			g.Expect(k8sClient.Get(testCtx, key, verification)).To(Succeed())
+			current := verification.Status.CurrentVerification
+			if current == nil {
+				return false
+			}
+			var mdb mariadbv1alpha1.MariaDB
+			mdbKey := types.NamespacedName{
+				Name:      current.MariaDB,
+				Namespace: key.Namespace,
+			}
+			g.Expect(k8sClient.Get(testCtx, mdbKey, &mdb)).To(Succeed())
+
+			g.Expect(mdb.Spec.Replicas).To(BeEquivalentTo(1))
+			g.Expect(mdb.Spec.BootstrapFrom).NotTo(BeNil())
+			g.Expect(mdb.Spec.BootstrapFrom.BackupRef).NotTo(BeNil())
+			g.Expect(mdb.Spec.BootstrapFrom.BackupRef.Name).To(Equal(backupKey.Name))
+			g.Expect(mdb.Labels).To(HaveKeyWithValue("k8s.mariadb.com/test", "test"))
+			g.Expect(metav1.IsControlledBy(&mdb, verification)).To(BeTrue())
+			return true
+		}, testTimeout, testInterval).Should(BeTrue())
+
+		By("Expecting BackupVerification to complete eventually")
+		Eventually(func(g Gomega) bool {
+			g.Expect(k8sClient.Get(testCtx, key, verification)).To(Succeed())
+			last := verification.Status.LastVerification
+			if last == nil {
+				return false
+			}
+			g.Expect(last.Succeeded).To(BeTrue())
+			g.Expect(last.Assertions).To(HaveLen(1))
+			g.Expect(last.Assertions[0].Succeeded).To(BeTrue())
+			return !verification.IsVerifying()
+		}, testHighTimeout, testInterval).Should(BeTrue())
+
+		By("Expecting ephemeral MariaDB to be deleted eventually")
+		Eventually(func() bool {
+			var mdbList mariadbv1alpha1.MariaDBList
+			if err := k8sClient.List(testCtx, &mdbList, client.InNamespace(key.Namespace)); err != nil {
+				return false
+			}
+			for _, mdb := range mdbList.Items {
+				if metav1.IsControlledBy(&mdb, verification) {
+					return false
+				}
+			}
+			return true
+		}, testTimeout, testInterval).Should(BeTrue())
+	})
+})
@@ -0,0 +1,116 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	mariadbv1alpha1 "github.com/mariadb-operator/mariadb-operator/v26/api/v1alpha1"
+	"github.com/mariadb-operator/mariadb-operator/v26/pkg/azure"
+	"github.com/mariadb-operator/mariadb-operator/v26/pkg/gcs"
+	"github.com/mariadb-operator/mariadb-operator/v26/pkg/interfaces"
+	"github.com/mariadb-operator/mariadb-operator/v26/pkg/minio"
+	"github.com/mariadb-operator/mariadb-operator/v26/pkg/refresolver"
+	"k8s.io/utils/ptr"
+)
+
+// newBlobStorageClient returns a client for the configured object storage. Exactly one of s3, abs or gcs is expected.
+func newBlobStorageClient(ctx context.Context, refResolver *refresolver.RefResolver, s3 *mariadbv1alpha1.S3,
+	abs *mariadbv1alpha1.AzureBlob, gcsStorage *mariadbv1alpha1.GCS, namespace string) (interfaces.BlobStorage, error) {
+	if abs != nil {
+		return newABSClient(ctx, refResolver, abs, namespace)
+	}
+
+	if s3 != nil {
+		return newS3Client(ctx, refResolver, s3, namespace)
+	}
+
+	if gcsStorage != nil {
+		return newGCSClient(ctx, refResolver, gcsStorage, namespace)
+	}
+
+	return nil, fmt.Errorf("error getting a storage client, none configured. Either abs, s3 or gcs must be configure")
+}
+
+// newABSClient retrieves a configured Azure Blob Storage client
+// This should not be used directly, see `newBlobStorageClient`
+func newABSClient(ctx context.Context, refResolver *refresolver.RefResolver, abs *mariadbv1alpha1.AzureBlob,
+	namespace string) (*azure.AzBlobClient, error) {
+	if abs == nil {
+		return nil, fmt.Errorf("error getting azure blob storage client. No abs config found")
+	}
+
+	opts := []azure.AzBlobOpt{
+		azure.WithPrefix(abs.Prefix),
+		azure.WithAccountName(abs.StorageAccountName),
+		azure.WithObjectLock(abs.ObjectLock),
+	}
+
+	// If `storageAccountKey` is not set, we rely on DefaultAzureCredential
+	if abs.StorageAccountKey != nil {
+		accountKey, err := refResolver.SecretKeyRef(ctx, *abs.StorageAccountKey, namespace)
+		if err != nil {
+			return nil, fmt.Errorf("error getting CA cert: %v", err)
+		}
+		opts = append(opts, azure.WithAccountKey(accountKey))
+	}
+
+	tls := ptr.Deref(abs.TLS, mariadbv1alpha1.TLSConfig{})
+	if tls.Enabled {
+		opts = append(opts, azure.WithTLSEnabled(true))
+		caCertBytes, err := refResolver.SecretKeyRef(ctx, *abs.TLS.CASecretKeyRef, namespace)
+		if err != nil {
+			return nil, fmt.Errorf("error getting CA cert: %v", err)
+		}
+		opts = append(opts, azure.WithTLSCACertBytes([]byte(caCertBytes)))
+	}
+
+	return azure.NewAzBlobClient(
+		"",
+		abs.ContainerName,
+		abs.ServiceURL,
+		opts...,
+	)
+}
+
+// newS3Client retrieves a configured S3 client
+// @WARN: This should not be used directly, see `newBlobStorageClient`
+func newS3Client(ctx context.Context, refResolver *refresolver.RefResolver, s3 *mariadbv1alpha1.S3,
+	namespace string) (*minio.Client, error) {
+	if s3 == nil {
+		return nil, errors.New("error getting s3 client. No s3 config found")
+	}
+
+	return minio.NewMinioClientFromS3Config(
+		ctx,
+		*refResolver,
+		*s3,
+		"",
+		namespace,
+	)
+}
+
+// newGCSClient retrieves a configured Google Cloud Storage client
+// @WARN: This should not be used directly, see `newBlobStorageClient`
+func newGCSClient(ctx context.Context, refResolver *refresolver.RefResolver, gcsStorage *mariadbv1alpha1.GCS,
+	namespace string) (*gcs.GCSClient, error) {
+	if gcsStorage == nil {
+		return nil, errors.New("error getting gcs client. No gcs config found")
+	}
+
+	opts := []gcs.GCSOpt{
+		gcs.WithEndpoint(gcsStorage.Endpoint),
+		gcs.WithPrefix(gcsStorage.Prefix),
+	}
+
+	// If `serviceAccountKeySecretKeyRef` is not set, we rely on workload identity
+	if gcsStorage.ServiceAccountKeySecretKeyRef != nil {
+		serviceAccountKey, err := refResolver.SecretKeyRef(ctx, *gcsStorage.ServiceAccountKeySecretKeyRef, namespace)
+		if err != nil {
+			return nil, fmt.Errorf("error getting service account key: %v", err)
+		}
+		opts = append(opts, gcs.WithServiceAccountKey([]byte(serviceAccountKey)))
+	}
+
+	return gcs.NewGCSClient("", gcsStorage.Bucket, opts...)
+}
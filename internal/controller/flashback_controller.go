@@ -0,0 +1,264 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/go-multierror"
+	mariadbv1alpha1 "github.com/mariadb-operator/mariadb-operator/v26/api/v1alpha1"
+	"github.com/mariadb-operator/mariadb-operator/v26/pkg/builder"
+	condition "github.com/mariadb-operator/mariadb-operator/v26/pkg/condition"
+	"github.com/mariadb-operator/mariadb-operator/v26/pkg/controller/rbac"
+	jobpkg "github.com/mariadb-operator/mariadb-operator/v26/pkg/job"
+	"github.com/mariadb-operator/mariadb-operator/v26/pkg/refresolver"
+	batchv1 "k8s.io/api/batch/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// FlashbackReconciler reconciles a Flashback object
+type FlashbackReconciler struct {
+	client.Client
+	Scheme            *runtime.Scheme
+	Builder           *builder.Builder
+	RefResolver       *refresolver.RefResolver
+	ConditionComplete *condition.Complete
+	RBACReconciler    *rbac.RBACReconciler
+}
+
+//+kubebuilder:rbac:groups=k8s.mariadb.com,resources=flashbacks,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=k8s.mariadb.com,resources=flashbacks/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=k8s.mariadb.com,resources=flashbacks/finalizers,verbs=update
+//+kubebuilder:rbac:groups=batch,resources=jobs,verbs=get;list;watch;create;patch
+//+kubebuilder:rbac:groups="",resources=serviceaccounts,verbs=list;watch;create;patch
+
+// Reconcile is part of the main kubernetes reconciliation loop which aims to
+// move the current state of the cluster closer to the desired state.
+func (r *FlashbackReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	var flashback mariadbv1alpha1.Flashback
+	if err := r.Get(ctx, req.NamespacedName, &flashback); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	if flashback.IsComplete() {
+		return ctrl.Result{}, nil
+	}
+
+	mariadb, err := r.RefResolver.MariaDB(ctx, &flashback.Spec.MariaDBRef, flashback.Namespace)
+	if err != nil {
+		var mariaDbErr *multierror.Error
+		mariaDbErr = multierror.Append(mariaDbErr, err)
+
+		err = r.patchStatus(ctx, &flashback, r.ConditionComplete.PatcherRefResolver(err, mariadb))
+		mariaDbErr = multierror.Append(mariaDbErr, err)
+
+		return ctrl.Result{}, fmt.Errorf("error getting MariaDB: %v", mariaDbErr)
+	}
+	pitr, err := r.RefResolver.PointInTimeRecovery(ctx, &flashback.Spec.PointInTimeRecoveryRef, flashback.Namespace)
+	if err != nil {
+		var pitrErr *multierror.Error
+		pitrErr = multierror.Append(pitrErr, err)
+
+		err = r.patchStatus(ctx, &flashback, r.ConditionComplete.PatcherRefResolver(err, pitr))
+		pitrErr = multierror.Append(pitrErr, err)
+
+		return ctrl.Result{}, fmt.Errorf("error getting PointInTimeRecovery: %v", pitrErr)
+	}
+
+	if err := r.patch(ctx, &flashback, func(f *mariadbv1alpha1.Flashback) {
+		f.SetDefaults(mariadb)
+	}); err != nil {
+		return ctrl.Result{}, fmt.Errorf("error defaulting Flashback: %v", err)
+	}
+
+	if err := r.reconcileRBAC(ctx, &flashback); err != nil {
+		return ctrl.Result{}, fmt.Errorf("error reconciling RBAC: %v", err)
+	}
+
+	if !flashback.IsGenerated() {
+		if err := r.reconcileGenerate(ctx, &flashback, pitr, mariadb); err != nil {
+			return ctrl.Result{}, fmt.Errorf("error generating reverse SQL: %v", err)
+		}
+		return ctrl.Result{}, nil
+	}
+	if err := r.reconcileApply(ctx, &flashback, mariadb); err != nil {
+		return ctrl.Result{}, fmt.Errorf("error applying reverse SQL: %v", err)
+	}
+	return ctrl.Result{}, nil
+}
+
+func (r *FlashbackReconciler) reconcileGenerate(ctx context.Context, flashback *mariadbv1alpha1.Flashback,
+	pitr *mariadbv1alpha1.PointInTimeRecovery, mariadb *mariadbv1alpha1.MariaDB) error {
+	desiredJob, err := r.Builder.BuildFlashbackJob(flashback.GenerateJobKey(), flashback, pitr, mariadb)
+	if err != nil {
+		return fmt.Errorf("error building Job: %v", err)
+	}
+	job, err := r.reconcileJob(ctx, desiredJob)
+	if err != nil {
+		var jobErr *multierror.Error
+		jobErr = multierror.Append(jobErr, err)
+
+		err = r.patchStatus(ctx, flashback, func(c condition.Conditioner) {
+			condition.SetFlashbackGenerationError(c, "Error creating Job")
+		})
+		jobErr = multierror.Append(jobErr, err)
+
+		return jobErr
+	}
+
+	return r.patchStatus(ctx, flashback, func(c condition.Conditioner) {
+		switch {
+		case jobpkg.IsJobFailed(job):
+			condition.SetFlashbackGenerationError(c, "Failed")
+		case jobpkg.IsJobComplete(job):
+			condition.SetFlashbackGenerated(c)
+			condition.SetFlashbackPendingApproval(c)
+			flashback.Status.GeneratedTime = &metav1.Time{Time: time.Now()}
+			log.FromContext(ctx).Info("Reverse SQL generated", "output", flashback.OutputKey().Name, "kind", flashback.OutputKind())
+		default:
+			condition.SetFlashbackGenerating(c)
+		}
+	})
+}
+
+func (r *FlashbackReconciler) reconcileApply(ctx context.Context, flashback *mariadbv1alpha1.Flashback,
+	mariadb *mariadbv1alpha1.MariaDB) error {
+	if !flashback.Spec.Approved {
+		return r.patchStatus(ctx, flashback, func(c condition.Conditioner) {
+			condition.SetFlashbackPendingApproval(c)
+		})
+	}
+
+	desiredJob, err := r.Builder.BuildFlashbackApplyJob(flashback.ApplyJobKey(), flashback, mariadb)
+	if err != nil {
+		return fmt.Errorf("error building Job: %v", err)
+	}
+	job, err := r.reconcileJob(ctx, desiredJob)
+	if err != nil {
+		var jobErr *multierror.Error
+		jobErr = multierror.Append(jobErr, err)
+
+		err = r.patchStatus(ctx, flashback, r.ConditionComplete.PatcherFailed("Error creating Job"))
+		jobErr = multierror.Append(jobErr, err)
+
+		return jobErr
+	}
+
+	return r.patchStatus(ctx, flashback, func(c condition.Conditioner) {
+		condition.SetCompleteWithJob(c, job)
+		if jobpkg.IsJobComplete(job) {
+			flashback.Status.AppliedTime = &metav1.Time{Time: time.Now()}
+			log.FromContext(ctx).Info("Reverse SQL applied", "mariadb", mariadb.Name)
+		}
+	})
+}
+
+func (r *FlashbackReconciler) reconcileJob(ctx context.Context, desiredJob *batchv1.Job) (*batchv1.Job, error) {
+	key := client.ObjectKeyFromObject(desiredJob)
+	var existingJob batchv1.Job
+	if err := r.Get(ctx, key, &existingJob); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return nil, fmt.Errorf("error getting Job: %v", err)
+		}
+		if err := r.Create(ctx, desiredJob); err != nil {
+			return nil, fmt.Errorf("error creating Job: %v", err)
+		}
+		return desiredJob, nil
+	}
+
+	patch := client.MergeFrom(existingJob.DeepCopy())
+	existingJob.Spec.BackoffLimit = desiredJob.Spec.BackoffLimit
+
+	if err := r.Patch(ctx, &existingJob, patch); err != nil {
+		return nil, fmt.Errorf("error patching Job: %v", err)
+	}
+	return &existingJob, nil
+}
+
+func (r *FlashbackReconciler) reconcileRBAC(ctx context.Context, flashback *mariadbv1alpha1.Flashback) error {
+	key := flashback.Spec.ServiceAccountKey(flashback.ObjectMeta)
+	sa, err := r.RBACReconciler.ReconcileServiceAccount(ctx, key, flashback, flashback.Spec.InheritMetadata)
+	if err != nil {
+		return fmt.Errorf("error reconciling ServiceAccount: %v", err)
+	}
+
+	// The Flashback Job stores the reverse SQL in the ConfigMap or Secret defined by the Flashback using server-side apply.
+	rules := []rbacv1.PolicyRule{
+		{
+			APIGroups: []string{
+				mariadbv1alpha1.GroupVersion.Group,
+			},
+			Resources: []string{
+				"flashbacks",
+			},
+			Verbs: []string{
+				"get",
+			},
+		},
+		{
+			APIGroups: []string{
+				"",
+			},
+			Resources: []string{
+				"configmaps",
+				"secrets",
+			},
+			Verbs: []string{
+				"create",
+				"patch",
+			},
+		},
+	}
+	role, err := r.RBACReconciler.ReconcileRole(ctx, flashback.RoleKey(), flashback, flashback.Spec.InheritMetadata, rules)
+	if err != nil {
+		return fmt.Errorf("error reconciling Role: %v", err)
+	}
+
+	roleRef := rbacv1.RoleRef{
+		APIGroup: rbacv1.GroupName,
+		Kind:     "Role",
+		Name:     role.Name,
+	}
+	if err := r.RBACReconciler.ReconcileRoleBinding(
+		ctx,
+		flashback.RoleBindingKey(),
+		flashback,
+		flashback.Spec.InheritMetadata,
+		sa,
+		roleRef,
+	); err != nil {
+		return fmt.Errorf("error reconciling RoleBinding: %v", err)
+	}
+	return nil
+}
+
+func (r *FlashbackReconciler) patchStatus(ctx context.Context, flashback *mariadbv1alpha1.Flashback,
+	patcher condition.Patcher) error {
+	patch := client.MergeFrom(flashback.DeepCopy())
+	patcher(&flashback.Status)
+
+	if err := r.Client.Status().Patch(ctx, flashback, patch); err != nil {
+		return fmt.Errorf("error patching Flashback status: %v", err)
+	}
+	return nil
+}
+
+func (r *FlashbackReconciler) patch(ctx context.Context, flashback *mariadbv1alpha1.Flashback,
+	patcher func(*mariadbv1alpha1.Flashback)) error {
+	patch := client.MergeFrom(flashback.DeepCopy())
+	patcher(flashback)
+	return r.Patch(ctx, flashback, patch)
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *FlashbackReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&mariadbv1alpha1.Flashback{}).
+		Owns(&batchv1.Job{}).
+		Complete(r)
+}
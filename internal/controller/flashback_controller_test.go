@@ -0,0 +1,107 @@
+package controller
+
+import (
+	"time"
+
+	mariadbv1alpha1 "github.com/mariadb-operator/mariadb-operator/v26/api/v1alpha1"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	batchv1 "k8s.io/api/batch/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+)
+
+var _ = Describe("Flashback", Label("basic"), func() {
+	BeforeEach(func() {
+		By("Waiting for MariaDB to be ready")
+		expectMariadbReady(testCtx, k8sClient, testMdbkey)
+	})
+
+	It("should generate the reverse SQL and wait for approval", func() {
+		key := types.NamespacedName{
+			Name:      "flashback-test",
+			Namespace: testNamespace,
+		}
+		pitrKey := types.NamespacedName{
+			Name:      "flashback-test-pitr",
+			Namespace: testNamespace,
+		}
+
+		By("Creating PointInTimeRecovery")
+		pitr := buildTestPitr(
+			pitrKey,
+			types.NamespacedName{Name: "flashback-test-physicalbackup", Namespace: testNamespace},
+			withTestPitrS3Storage("test-binlogs", "flashback"),
+		)
+		Expect(k8sClient.Create(testCtx, pitr)).To(Succeed())
+		DeferCleanup(func() {
+			Expect(k8sClient.Delete(testCtx, pitr)).To(Succeed())
+		})
+
+		By("Creating Flashback")
+		now := time.Now()
+		flashback := &mariadbv1alpha1.Flashback{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      key.Name,
+				Namespace: key.Namespace,
+			},
+			Spec: mariadbv1alpha1.FlashbackSpec{
+				MariaDBRef: mariadbv1alpha1.MariaDBRef{
+					ObjectReference: mariadbv1alpha1.ObjectReference{
+						Name: testMdbkey.Name,
+					},
+				},
+				PointInTimeRecoveryRef: mariadbv1alpha1.LocalObjectReference{
+					Name: pitrKey.Name,
+				},
+				StartTime: &metav1.Time{Time: now.Add(-10 * time.Minute)},
+				EndTime:   &metav1.Time{Time: now},
+				Database:  ptr.To("test"),
+				InheritMetadata: &mariadbv1alpha1.Metadata{
+					Labels: map[string]string{
+						"k8s.mariadb.com/test": "test",
+					},
+				},
+			},
+		}
+		Expect(k8sClient.Create(testCtx, flashback)).To(Succeed())
+		DeferCleanup(func() {
+			Expect(k8sClient.Delete(testCtx, flashback)).To(Succeed())
+		})
+
+		By("Expecting to create a ServiceAccount and a Role eventually")
+		Eventually(func(g Gomega) bool {
+			g.Expect(k8sClient.Get(testCtx, key, flashback)).To(Succeed())
+			var role rbacv1.Role
+			g.Expect(k8sClient.Get(testCtx, flashback.RoleKey(), &role)).To(Succeed())
+			g.Expect(metav1.IsControlledBy(&role, flashback)).To(BeTrue())
+			return true
+		}, testTimeout, testInterval).Should(BeTrue())
+
+		By("Expecting to create the generation Job eventually")
+		Eventually(func(g Gomega) bool {
+			var job batchv1.Job
+			g.Expect(k8sClient.Get(testCtx, flashback.GenerateJobKey(), &job)).To(Succeed())
+
+			g.Expect(job.Labels).To(HaveKeyWithValue("k8s.mariadb.com/test", "test"))
+			g.Expect(metav1.IsControlledBy(&job, flashback)).To(BeTrue())
+			g.Expect(job.Spec.Template.Spec.InitContainers).To(HaveLen(2))
+			g.Expect(job.Spec.Template.Spec.Containers).To(HaveLen(1))
+			return true
+		}, testTimeout, testInterval).Should(BeTrue())
+
+		By("Expecting Flashback to be generating")
+		Eventually(func(g Gomega) bool {
+			g.Expect(k8sClient.Get(testCtx, key, flashback)).To(Succeed())
+			return !flashback.IsGenerated() && !flashback.IsComplete()
+		}, testTimeout, testInterval).Should(BeTrue())
+
+		By("Expecting not to create the apply Job without approval")
+		Consistently(func() bool {
+			var job batchv1.Job
+			return k8sClient.Get(testCtx, flashback.ApplyJobKey(), &job) != nil
+		}, 5*time.Second, testInterval).Should(BeTrue())
+	})
+})
@@ -35,6 +35,7 @@ import (
 	"github.com/mariadb-operator/mariadb-operator/v26/pkg/environment"
 	"github.com/mariadb-operator/mariadb-operator/v26/pkg/health"
 	kadapter "github.com/mariadb-operator/mariadb-operator/v26/pkg/kubernetes/adapter"
+	"github.com/mariadb-operator/mariadb-operator/v26/pkg/metadata"
 	mdbpod "github.com/mariadb-operator/mariadb-operator/v26/pkg/pod"
 	"github.com/mariadb-operator/mariadb-operator/v26/pkg/refresolver"
 	sts "github.com/mariadb-operator/mariadb-operator/v26/pkg/statefulset"
@@ -44,7 +45,6 @@ import (
 	policyv1 "k8s.io/api/policy/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/intstr"
@@ -145,6 +145,10 @@ func (r *MariaDBReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 			Name:      "Suspend",
 			Reconcile: r.reconcileSuspend,
 		},
+		{
+			Name:      "Restore plan",
+			Reconcile: r.reconcileRestorePlan,
+		},
 		{
 			Name:      "Secret",
 			Reconcile: r.reconcileSecret,
@@ -426,6 +430,9 @@ func (r *MariaDBReconciler) reconcileStatefulSet(ctx context.Context, mariadb *m
 		if err != nil {
 			return ctrl.Result{}, fmt.Errorf("error getting PointInTimeRecovery: %v", err)
 		}
+		if err := r.reconcilePITRStorage(ctx, pitr); err != nil {
+			return ctrl.Result{}, fmt.Errorf("error reconciling PointInTimeRecovery storage: %v", err)
+		}
 	}
 
 	desiredSts, err := r.Builder.BuildMariadbStatefulSet(mariadb, key, updateAnnotations, pitr)
@@ -536,8 +543,11 @@ func (r *MariaDBReconciler) reconcileRestore(ctx context.Context, mdb *mariadbv1
 		}
 		return ctrl.Result{}, r.patchStatus(ctx, mdb, func(status *mariadbv1alpha1.MariaDBStatus) error {
 			if existingRestore.IsComplete() {
-				if err := r.Delete(ctx, &existingRestore); err != nil {
-					return err
+				// the Restore is kept until the binlogs are replayed, as it reports the GTID of the restored backup.
+				if !mdb.HasPendingBinlogReplay() {
+					if err := r.Delete(ctx, &existingRestore); err != nil {
+						return err
+					}
 				}
 				condition.SetRestoredBackup(status)
 			} else {
@@ -574,13 +584,40 @@ func (r *MariaDBReconciler) reconcileRestore(ctx context.Context, mdb *mariadbv1
 		return ctrl.Result{}, fmt.Errorf("error patching status: %v", err)
 	}
 
-	restore, err := r.Builder.BuildRestore(mdb, mdb.RestoreKey())
+	restore, err := r.buildRestore(ctx, mdb)
 	if err != nil {
 		return ctrl.Result{}, fmt.Errorf("error building restore: %v", err)
 	}
 	return ctrl.Result{}, r.Create(ctx, restore)
 }
 
+// buildRestore builds the Restore used to bootstrap from a logical backup. When bootstrapping from a PointInTimeRecovery,
+// the logical Backup referenced by the PointInTimeRecovery is restored, and the archived binlogs are replayed afterwards.
+func (r *MariaDBReconciler) buildRestore(ctx context.Context, mdb *mariadbv1alpha1.MariaDB) (*mariadbv1alpha1.Restore, error) {
+	if mdb.Spec.BootstrapFrom.PointInTimeRecoveryRef == nil {
+		return r.Builder.BuildRestore(mdb, mdb.RestoreKey())
+	}
+	pitr, err := r.RefResolver.PointInTimeRecovery(ctx, mdb.Spec.BootstrapFrom.PointInTimeRecoveryRef, mdb.Namespace)
+	if err != nil {
+		return nil, fmt.Errorf("error getting PointInTimeRecovery: %v", err)
+	}
+	if !pitr.IsLogical() {
+		return nil, errors.New("PointInTimeRecovery must reference a logical Backup to be restored by a Restore resource")
+	}
+
+	mdbWithBackup := mdb.DeepCopy()
+	bootstrapFrom := mdbWithBackup.Spec.BootstrapFrom
+	bootstrapFrom.PointInTimeRecoveryRef = nil
+	bootstrapFrom.BackupRef = &mariadbv1alpha1.TypedLocalObjectReference{
+		Name: pitr.Spec.BackupRef.Name,
+		Kind: mariadbv1alpha1.BackupKind,
+	}
+	// the volume is used to stage the binlogs, the backup volume is inferred from the Backup by the Restore.
+	bootstrapFrom.Volume = nil
+
+	return r.Builder.BuildRestore(mdbWithBackup, mdb.RestoreKey())
+}
+
 func (r *MariaDBReconciler) reconcileDefaultPDB(ctx context.Context, mariadb *mariadbv1alpha1.MariaDB) error {
 	if mariadb.Spec.PodDisruptionBudget == nil {
 		return nil
@@ -993,7 +1030,14 @@ func (r *MariaDBReconciler) setSpecDefaults(ctx context.Context, mariadb *mariad
 			if err != nil {
 				return fmt.Errorf("error getting PointInTimeRecovery: %v", err)
 			}
-			pb, err := r.RefResolver.PhysicalBackup(ctx, &pitr.Spec.PhysicalBackupRef, mdb.Namespace)
+			if pitr.IsLogical() {
+				logger.V(1).Info("Setting bootstrapFrom defaults with logical Backup")
+				// the logical Backup is restored by the Restore resource, and the binary logs are replayed afterwards
+				mdb.Spec.BootstrapFrom.BackupContentType = mariadbv1alpha1.BackupContentTypeLogical
+				mdb.Spec.BootstrapFrom.SetDefaults(mdb)
+				return nil
+			}
+			pb, err := r.RefResolver.PhysicalBackup(ctx, pitr.Spec.PhysicalBackupRef, mdb.Namespace)
 			if err != nil {
 				return fmt.Errorf("error getting PhysicalBackup: %v", err)
 			}
@@ -1014,7 +1058,7 @@ func (r *MariaDBReconciler) setSpecDefaults(ctx context.Context, mariadb *mariad
 
 		if physicalBackup != nil {
 			if physicalBackup.Spec.Storage.VolumeSnapshot != nil {
-				targetSnapshot, err := r.getTargetVolumeSnapshot(ctx, physicalBackup, mdb.Spec.BootstrapFrom.TargetRecoveryTime)
+				targetSnapshot, err := r.getTargetVolumeSnapshot(ctx, physicalBackup, mdb.Spec.BootstrapFrom)
 				if err != nil {
 					return fmt.Errorf("error getting target VolumeSnapshot: %v", err)
 				}
@@ -1037,13 +1081,13 @@ func (r *MariaDBReconciler) setSpecDefaults(ctx context.Context, mariadb *mariad
 	})
 }
 
-func (r *MariaDBReconciler) getTargetVolumeSnapshot(ctx context.Context, backup *mariadbv1alpha1.PhysicalBackup,
-	targetRecoveryTime *metav1.Time) (string, error) {
-	if backup.Spec.Storage.VolumeSnapshot == nil {
+func (r *MariaDBReconciler) getTargetVolumeSnapshot(ctx context.Context, physicalBackup *mariadbv1alpha1.PhysicalBackup,
+	bootstrapFrom *mariadbv1alpha1.BootstrapFrom) (string, error) {
+	if physicalBackup.Spec.Storage.VolumeSnapshot == nil {
 		return "", errors.New("VolumeSnapshot must be used as storage in PhysicalBackup")
 	}
 
-	snapshotList, err := mdbsnapshot.ListReadyVolumeSnapshots(ctx, r.Client, backup)
+	snapshotList, err := mdbsnapshot.ListReadyVolumeSnapshots(ctx, r.Client, physicalBackup)
 	if err != nil {
 		return "", fmt.Errorf("error listing ready VolumeSnapshots: %v", err)
 	}
@@ -1052,10 +1096,25 @@ func (r *MariaDBReconciler) getTargetVolumeSnapshot(ctx context.Context, backup
 		snapshotNames[i] = snapshot.Name
 	}
 
-	recoveryTime := ptr.Deref(targetRecoveryTime, metav1.Time{Time: time.Now()})
 	logger := log.FromContext(ctx).WithName("snapshot")
 
-	targetSnapshot, err := r.BackupProcessor.GetBackupTargetFile(snapshotNames, recoveryTime.Time, logger)
+	var targetSnapshot string
+	if targetGtid := bootstrapFrom.LastRecoveryGtid(); targetGtid != nil {
+		manifestFn := func(ctx context.Context, snapshotName string) (*backup.Manifest, error) {
+			for _, snapshot := range snapshotList.Items {
+				if snapshot.Name == snapshotName {
+					return &backup.Manifest{
+						GTID: snapshot.Annotations[metadata.GtidAnnotation],
+					}, nil
+				}
+			}
+			return nil, nil
+		}
+		targetSnapshot, err = backup.GetBackupTargetFileWithGtid(ctx, snapshotNames, r.BackupProcessor, manifestFn, targetGtid, logger)
+	} else {
+		recoveryTime := bootstrapFrom.TargetRecoveryTimeOrDefault()
+		targetSnapshot, err = r.BackupProcessor.GetBackupTargetFile(snapshotNames, recoveryTime, logger)
+	}
 	if err != nil {
 		return "", fmt.Errorf("error getting target VolumeSnapshot: %v", err)
 	}
@@ -33,7 +33,8 @@ import (
 func (r *MariaDBReconciler) reconcileInit(ctx context.Context, mariadb *mariadbv1alpha1.MariaDB) (ctrl.Result, error) {
 	logger := log.FromContext(ctx).WithName("init")
 
-	if mariadb.Spec.BootstrapFrom != nil && mariadb.Spec.BootstrapFrom.PointInTimeRecoveryRef != nil {
+	if mariadb.Spec.BootstrapFrom != nil && mariadb.Spec.BootstrapFrom.PointInTimeRecoveryRef != nil &&
+		mariadb.Spec.BootstrapFrom.BackupContentType != mariadbv1alpha1.BackupContentTypeLogical {
 		return r.reconcilePointInTimeRecoveryInit(ctx, mariadb, logger.WithName("pitr"))
 	} else if mariadb.Spec.BootstrapFrom != nil && mariadb.Spec.BootstrapFrom.BackupContentType == mariadbv1alpha1.BackupContentTypePhysical {
 		return r.reconcilePhysicalBackupInit(ctx, mariadb, mariadb.Spec.BootstrapFrom, logger.WithName("physicalbackup"))
@@ -58,7 +59,10 @@ func (r *MariaDBReconciler) reconcilePointInTimeRecoveryInit(ctx context.Context
 		}
 	}
 
-	pb, err := r.RefResolver.PhysicalBackup(ctx, &pitr.Spec.PhysicalBackupRef, pitr.Namespace)
+	if pitr.IsLogical() {
+		return ctrl.Result{}, errors.New("PointInTimeRecovery based on a logical Backup must be restored by a Restore resource")
+	}
+	pb, err := r.RefResolver.PhysicalBackup(ctx, pitr.Spec.PhysicalBackupRef, pitr.Namespace)
 	if err != nil {
 		return ctrl.Result{}, fmt.Errorf("error getting PhysicalBackup: %v", err)
 	}
@@ -162,6 +166,10 @@ func (r *MariaDBReconciler) reconcilePITRInitError(ctx context.Context, mariadb
 		logger.V(1).Info("Strict mode not enabled, skipping target recovery time validation. Proceeding to bootstrap...")
 		return ctrl.Result{}, nil
 	}
+	if mariadb.Spec.BootstrapFrom.TargetRecoveryGtid != nil {
+		logger.V(1).Info("Target recovery GTID set, skipping target recovery time validation. Proceeding to bootstrap...")
+		return ctrl.Result{}, nil
+	}
 	if pitr.Status.LastRecoverableTime == nil {
 		logger.V(1).Info("Last recoverable time not tracked, skipping target recovery time validation. Proceeding to bootstrap...")
 		return ctrl.Result{}, nil
@@ -524,5 +532,5 @@ func shouldProvisionPhysicalBackupStagingPVC(mariadb *mariadbv1alpha1.MariaDB) b
 		return false
 	}
 	return b.BackupContentType == mariadbv1alpha1.BackupContentTypePhysical &&
-		(b.S3 != nil || b.AzureBlob != nil) && b.StagingStorage != nil && b.StagingStorage.PersistentVolumeClaim != nil
+		(b.S3 != nil || b.AzureBlob != nil || b.GCS != nil) && b.StagingStorage != nil && b.StagingStorage.PersistentVolumeClaim != nil
 }
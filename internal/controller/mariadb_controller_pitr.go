@@ -4,14 +4,12 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"io"
 	"time"
 
 	"github.com/go-logr/logr"
 	volumesnapshotv1 "github.com/kubernetes-csi/external-snapshotter/client/v8/apis/volumesnapshot/v1"
 	mariadbv1alpha1 "github.com/mariadb-operator/mariadb-operator/v26/api/v1alpha1"
 	agentclient "github.com/mariadb-operator/mariadb-operator/v26/pkg/agent/client"
-	"github.com/mariadb-operator/mariadb-operator/v26/pkg/azure"
 	"github.com/mariadb-operator/mariadb-operator/v26/pkg/binlog"
 	"github.com/mariadb-operator/mariadb-operator/v26/pkg/builder"
 	condition "github.com/mariadb-operator/mariadb-operator/v26/pkg/condition"
@@ -20,7 +18,6 @@ import (
 	"github.com/mariadb-operator/mariadb-operator/v26/pkg/interfaces"
 	jobpkg "github.com/mariadb-operator/mariadb-operator/v26/pkg/job"
 	"github.com/mariadb-operator/mariadb-operator/v26/pkg/metadata"
-	"github.com/mariadb-operator/mariadb-operator/v26/pkg/minio"
 	"github.com/mariadb-operator/mariadb-operator/v26/pkg/replication"
 	"github.com/mariadb-operator/mariadb-operator/v26/pkg/sql"
 	batchv1 "k8s.io/api/batch/v1"
@@ -32,10 +29,13 @@ import (
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/log"
-	"sigs.k8s.io/yaml"
 )
 
-var errSkipBinlogReplay = errors.New("skip binlog replay")
+var (
+	errSkipBinlogReplay = errors.New("skip binlog replay")
+	// errPITRFileSystemStorage is returned when the binlogs are kept in a volume, which is only mounted by the MariaDB and Job Pods.
+	errPITRFileSystemStorage = errors.New("binlogs stored in a volume are not accessible by the operator")
+)
 
 func (r *MariaDBReconciler) reconcilePITR(ctx context.Context, mdb *mariadbv1alpha1.MariaDB) (ctrl.Result, error) {
 	logger := log.FromContext(ctx).WithName("pitr")
@@ -47,9 +47,15 @@ func (r *MariaDBReconciler) reconcilePITR(ctx context.Context, mdb *mariadbv1alp
 		return ctrl.Result{}, nil
 	}
 
-	logger = logger.WithValues(
-		"target-time", mdb.Spec.BootstrapFrom.TargetRecoveryTimeOrDefault().Format(time.RFC3339),
-	)
+	if gtid := mdb.Spec.BootstrapFrom.LastRecoveryGtid(); gtid != nil {
+		logger = logger.WithValues(
+			"target-gtid", gtid.String(),
+		)
+	} else {
+		logger = logger.WithValues(
+			"target-time", mdb.Spec.BootstrapFrom.TargetRecoveryTimeOrDefault().Format(time.RFC3339),
+		)
+	}
 	if !mdb.IsReplayingBinlogs() || mdb.ReplayBinlogsError() != nil {
 		result, err := r.reconcileReplayBinlogsError(ctx, mdb, logger)
 
@@ -62,6 +68,9 @@ func (r *MariaDBReconciler) reconcilePITR(ctx context.Context, mdb *mariadbv1alp
 					return ctrl.Result{}, fmt.Errorf("error patching MariaDB status: %v", err)
 				}
 			}
+			if err := r.cleanupPITRRestore(ctx, mdb); err != nil {
+				return ctrl.Result{}, fmt.Errorf("error cleaning up PITR Restore: %v", err)
+			}
 			return ctrl.Result{}, nil
 		}
 		if !result.IsZero() || err != nil {
@@ -113,6 +122,9 @@ func (r *MariaDBReconciler) reconcilePITR(ctx context.Context, mdb *mariadbv1alp
 	if err := r.cleanupPITRStagingPVC(ctx, mdb); err != nil {
 		return ctrl.Result{}, fmt.Errorf("error cleaning up PITR stating PVC: %v", err)
 	}
+	if err := r.cleanupPITRRestore(ctx, mdb); err != nil {
+		return ctrl.Result{}, fmt.Errorf("error cleaning up PITR Restore: %v", err)
+	}
 	return ctrl.Result{}, nil
 }
 
@@ -120,7 +132,18 @@ func (r *MariaDBReconciler) getStartGtid(ctx context.Context, mdb *mariadbv1alph
 	logger logr.Logger) (*replication.Gtid, error) {
 	var rawGtid string
 
-	if mdb.Spec.BootstrapFrom != nil && mdb.Spec.BootstrapFrom.VolumeSnapshotRef != nil {
+	if mdb.Spec.BootstrapFrom != nil && mdb.Spec.BootstrapFrom.BackupContentType == mariadbv1alpha1.BackupContentTypeLogical {
+		// the GTID of the server does not match the one of the logical backup, as the restoration generates new GTIDs.
+		var restore mariadbv1alpha1.Restore
+		if err := r.Get(ctx, mdb.RestoreKey(), &restore); err != nil {
+			return nil, fmt.Errorf("error getting Restore: %v", err)
+		}
+		if restore.Status.RestoredGtid == "" {
+			return nil, fmt.Errorf("restored GTID not found in Restore %s", restore.Name)
+		}
+		logger.V(1).Info("Got GTID from Restore", "gtid", restore.Status.RestoredGtid, "restore", restore.Name)
+		rawGtid = restore.Status.RestoredGtid
+	} else if mdb.Spec.BootstrapFrom != nil && mdb.Spec.BootstrapFrom.VolumeSnapshotRef != nil {
 		key := types.NamespacedName{
 			Name:      mdb.Spec.BootstrapFrom.VolumeSnapshotRef.Name,
 			Namespace: mdb.Namespace,
@@ -163,6 +186,11 @@ func (r *MariaDBReconciler) getStartGtid(ctx context.Context, mdb *mariadbv1alph
 	}
 	defer client.Close()
 
+	return parseGtid(ctx, rawGtid, client, logger)
+}
+
+// parseGtid parses a raw GTID, which may contain multiple domains, using the gtid_domain_id of the server.
+func parseGtid(ctx context.Context, rawGtid string, client *sql.Client, logger logr.Logger) (*replication.Gtid, error) {
 	domainId, err := client.GtidDomainId(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("error getting gtid_domain_id: %v", err)
@@ -186,6 +214,10 @@ func (r *MariaDBReconciler) reconcileReplayBinlogsError(ctx context.Context, mar
 	if err != nil {
 		return ctrl.Result{}, fmt.Errorf("error getting PointInTimeRecoveryRef: %v", err)
 	}
+	if pitr.Spec.PointInTimeRecoveryStorage.IsFileSystem() {
+		logger.Info("Binlogs stored in a volume. Skipping binlog timeline validation...")
+		return ctrl.Result{}, nil
+	}
 	storageClient, err := r.getStorageClient(ctx, pitr)
 	if err != nil {
 		return ctrl.Result{}, fmt.Errorf("error getting S3 client: %v", err)
@@ -219,27 +251,28 @@ func (r *MariaDBReconciler) reconcileReplayBinlogsError(ctx context.Context, mar
 
 func (r *MariaDBReconciler) validateBinlogTimeline(ctx context.Context, mdb *mariadbv1alpha1.MariaDB, startGtid *replication.Gtid,
 	strictMode bool, storageClient interfaces.BlobStorage, logger logr.Logger) error {
-	indexReader, err := storageClient.GetObjectWithOptions(ctx, binlog.BinlogIndexName)
+	index, err := readBinlogIndex(ctx, storageClient)
 	if err != nil {
-		return fmt.Errorf("error getting binlog index: %v", err)
-	}
-	defer indexReader.Close()
-	indexBytes, err := io.ReadAll(indexReader)
-	if err != nil {
-		return fmt.Errorf("error reading binlog index: %v", err)
-	}
-	var index binlog.BinlogIndex
-	if err := yaml.Unmarshal(indexBytes, &index); err != nil {
-		return fmt.Errorf("error unmarshalling binlog index: %v", err)
+		return err
 	}
 
-	targetTime := mdb.Spec.BootstrapFrom.TargetRecoveryTimeOrDefault()
-	binlogMetas, err := index.BuildTimeline(startGtid, targetTime, strictMode, logger)
+	var (
+		binlogMetas []binlog.BinlogMetadata
+		target      string
+	)
+	if targetGtid := mdb.Spec.BootstrapFrom.LastRecoveryGtid(); targetGtid != nil {
+		target = fmt.Sprintf("target GTID %s", targetGtid.String())
+		binlogMetas, err = index.BuildTimelineWithTargetGtid(startGtid, targetGtid, strictMode, logger)
+	} else {
+		targetTime := mdb.Spec.BootstrapFrom.TargetRecoveryTimeOrDefault()
+		target = fmt.Sprintf("target time %s", targetTime.Format(time.RFC3339))
+		binlogMetas, err = index.BuildTimeline(startGtid, targetTime, strictMode, logger)
+	}
 	if err != nil {
 		return fmt.Errorf(
-			"error getting binlog timeline between GTID %s and target time %s: %w",
+			"error getting binlog timeline between GTID %s and %s: %w",
 			startGtid.String(),
-			targetTime.Format(time.RFC3339),
+			target,
 			err,
 		)
 	}
@@ -330,74 +363,27 @@ func (r *MariaDBReconciler) cleanupPITRJob(ctx context.Context, mariadb *mariadb
 func (r *MariaDBReconciler) getStorageClient(ctx context.Context,
 	pitr *mariadbv1alpha1.PointInTimeRecovery) (interfaces.BlobStorage, error) {
 	storage := pitr.Spec.PointInTimeRecoveryStorage
-
-	if storage.AzureBlob != nil {
-		return r.getABSClient(ctx, pitr)
-	}
-
-	if storage.S3 != nil {
-		return r.getS3Client(ctx, pitr)
+	if storage.IsFileSystem() {
+		return nil, errPITRFileSystemStorage
 	}
-
-	return nil, fmt.Errorf("error getting a storage client, none configured. Either abs or s3 must be configure")
-
+	return newBlobStorageClient(ctx, r.RefResolver, storage.S3, storage.AzureBlob, storage.GCS, pitr.Namespace)
 }
 
-// getABSClient retrieves a configured Azure Blob Storage client
-// This should not be used directly, see `getStorageClient`
-func (r *MariaDBReconciler) getABSClient(ctx context.Context, pitr *mariadbv1alpha1.PointInTimeRecovery) (*azure.AzBlobClient, error) {
-	abs := pitr.Spec.PointInTimeRecoveryStorage.AzureBlob
-	if abs == nil {
-		return nil, fmt.Errorf("error getting azure blob storage client. No abs config found")
-	}
-
-	opts := []azure.AzBlobOpt{
-		azure.WithPrefix(abs.Prefix),
-		azure.WithAccountName(abs.StorageAccountName),
-	}
-
-	// If `storageAccountKey` is not set, we rely on DefaultAzureCredential
-	if abs.StorageAccountKey != nil {
-		accountKey, err := r.RefResolver.SecretKeyRef(ctx, *abs.StorageAccountKey, pitr.Namespace)
-		if err != nil {
-			return nil, fmt.Errorf("error getting CA cert: %v", err)
-		}
-		opts = append(opts, azure.WithAccountKey(accountKey))
+// reconcilePITRStorage creates the PVC where the binlogs are archived. It is not owned by any object, so the binlogs are
+// retained after deleting the MariaDB and the PointInTimeRecovery, as it happens with object storages.
+func (r *MariaDBReconciler) reconcilePITRStorage(ctx context.Context, pitr *mariadbv1alpha1.PointInTimeRecovery) error {
+	storage := pitr.Spec.PointInTimeRecoveryStorage
+	if storage.PersistentVolumeClaim == nil {
+		return nil
 	}
-
-	tls := ptr.Deref(abs.TLS, mariadbv1alpha1.TLSConfig{})
-	if tls.Enabled {
-		opts = append(opts, azure.WithTLSEnabled(true))
-		caCertBytes, err := r.RefResolver.SecretKeyRef(ctx, *abs.TLS.CASecretKeyRef, pitr.Namespace)
-		if err != nil {
-			return nil, fmt.Errorf("error getting CA cert: %v", err)
-		}
-		opts = append(opts, azure.WithTLSCACertBytes([]byte(caCertBytes)))
+	pvc, err := r.Builder.BuildBackupStoragePVC(pitr.StoragePVCKey(), storage.PersistentVolumeClaim, nil)
+	if err != nil {
+		return fmt.Errorf("error building PointInTimeRecovery storage PVC: %v", err)
 	}
-
-	return azure.NewAzBlobClient(
-		"",
-		abs.ContainerName,
-		abs.ServiceURL,
-		opts...,
-	)
-}
-
-// getS3Client retrieves a configured S3 client
-// @WARN: This should not be used directly, see `getStorageClient`
-func (r *MariaDBReconciler) getS3Client(ctx context.Context, pitr *mariadbv1alpha1.PointInTimeRecovery) (*minio.Client, error) {
-	s3 := pitr.Spec.PointInTimeRecoveryStorage.S3
-	if s3 == nil {
-		return nil, errors.New("error getting s3 client. No s3 config found")
+	if err := r.PVCReconciler.Reconcile(ctx, client.ObjectKeyFromObject(pvc), pvc); err != nil {
+		return fmt.Errorf("error creating PointInTimeRecovery storage PVC: %v", err)
 	}
-
-	return minio.NewMinioClientFromS3Config(
-		ctx,
-		*r.RefResolver,
-		*s3,
-		"",
-		pitr.Namespace,
-	)
+	return nil
 }
 
 func (r *MariaDBReconciler) shouldReconcilePITR(ctx context.Context, mdb *mariadbv1alpha1.MariaDB, logger logr.Logger) (bool, error) {
@@ -407,8 +393,8 @@ func (r *MariaDBReconciler) shouldReconcilePITR(ctx context.Context, mdb *mariad
 		logger.V(1).Info("Operation in progress. Skipping PITR reconciliation...")
 		return false, nil
 	}
-	if !mdb.HasRestoredPhysicalBackup() {
-		logger.V(1).Info("PhysicalBackup not restored. Skipping PITR reconciliation...")
+	if !mdb.HasRestoredBackup() {
+		logger.V(1).Info("Backup not restored. Skipping PITR reconciliation...")
 		return false, nil
 	}
 	if mdb.HasReplayedBinlogs() || mdb.HasSkippedBinlogReplay() {
@@ -452,6 +438,21 @@ func (r *MariaDBReconciler) cleanupPITRStagingPVC(ctx context.Context, mariadb *
 	return r.Delete(ctx, &pvc)
 }
 
+// cleanupPITRRestore deletes the Restore used to restore the logical backup, which is kept until the binlogs are replayed.
+func (r *MariaDBReconciler) cleanupPITRRestore(ctx context.Context, mariadb *mariadbv1alpha1.MariaDB) error {
+	if mariadb.Spec.BootstrapFrom.BackupContentType != mariadbv1alpha1.BackupContentTypeLogical {
+		return nil
+	}
+	var restore mariadbv1alpha1.Restore
+	if err := r.Get(ctx, mariadb.RestoreKey(), &restore); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	return r.Delete(ctx, &restore)
+}
+
 func shouldProvisionPITRStagingPVC(mariadb *mariadbv1alpha1.MariaDB) bool {
 	b := mariadb.Spec.BootstrapFrom
 	if b == nil {
@@ -133,7 +133,7 @@ func buildTestPitr(pitrKey, physicalBackupKey types.NamespacedName, opts ...test
 			Namespace: pitrKey.Namespace,
 		},
 		Spec: mariadbv1alpha1.PointInTimeRecoverySpec{
-			PhysicalBackupRef: mariadbv1alpha1.LocalObjectReference{
+			PhysicalBackupRef: &mariadbv1alpha1.LocalObjectReference{
 				Name: physicalBackupKey.Name,
 			},
 		},
@@ -29,6 +29,10 @@ func (r *MariaDBReconciler) reconcileStatus(ctx context.Context, mdb *mariadbv1a
 			return nil
 		})
 	}
+	// the Ready condition is managed by the restore plan phase
+	if mdb.IsBootstrapDryRun() {
+		return ctrl.Result{}, nil
+	}
 	logger := log.FromContext(ctx).WithName("status").V(1)
 
 	var sts appsv1.StatefulSet
@@ -8,8 +8,11 @@ import (
 
 	"github.com/go-logr/logr"
 	mariadbv1alpha1 "github.com/mariadb-operator/mariadb-operator/v26/api/v1alpha1"
+	"github.com/mariadb-operator/mariadb-operator/v26/pkg/builder"
 	jobpkg "github.com/mariadb-operator/mariadb-operator/v26/pkg/job"
 	"github.com/mariadb-operator/mariadb-operator/v26/pkg/metadata"
+	"github.com/mariadb-operator/mariadb-operator/v26/pkg/metrics"
+	"github.com/mariadb-operator/mariadb-operator/v26/pkg/progress"
 	"github.com/mariadb-operator/mariadb-operator/v26/pkg/statefulset"
 	mdbtime "github.com/mariadb-operator/mariadb-operator/v26/pkg/time"
 	"github.com/mariadb-operator/mariadb-operator/v26/pkg/wait"
@@ -34,9 +37,15 @@ func (r *PhysicalBackupReconciler) reconcileJobs(ctx context.Context, backup *ma
 	if err != nil {
 		return ctrl.Result{}, fmt.Errorf("error listing Jobs: %v", err)
 	}
+	if err := r.reconcileHooks(ctx, backup, mariadb, jobList); err != nil {
+		return ctrl.Result{}, fmt.Errorf("error reconciling hooks: %v", err)
+	}
 	if err := r.reconcileJobStatus(ctx, backup, jobList, logger); err != nil {
 		return ctrl.Result{}, fmt.Errorf("error reconciling status: %v", err)
 	}
+	if err := r.reconcileProgress(ctx, backup, jobList); err != nil {
+		return ctrl.Result{}, fmt.Errorf("error reconciling progress: %v", err)
+	}
 
 	schedule := ptr.Deref(backup.Spec.Schedule, mariadbv1alpha1.PhysicalBackupSchedule{})
 	if schedule.Suspend {
@@ -50,7 +59,7 @@ func (r *PhysicalBackupReconciler) reconcileJobs(ctx context.Context, backup *ma
 		return result, err
 	}
 
-	if err := r.reconcileRBAC(ctx, backup, mariadb); err != nil {
+	if err := r.reconcileRBAC(ctx, backup); err != nil {
 		return ctrl.Result{}, fmt.Errorf("error reconciling ServiceAccount: %v", err)
 	}
 	if err := r.reconcileStorage(ctx, backup); err != nil {
@@ -93,6 +102,38 @@ func (r *PhysicalBackupReconciler) indexJobs(ctx context.Context, mgr manager.Ma
 		})
 }
 
+func (r *PhysicalBackupReconciler) reconcileHooks(ctx context.Context, backup *mariadbv1alpha1.PhysicalBackup,
+	mariadb *mariadbv1alpha1.MariaDB, jobList *batchv1.JobList) error {
+	return r.HookReconciler.ReconcileJobs(ctx, jobList.Items, backup.Spec.Hooks, mariadb, backup.Spec.Schedule != nil,
+		func(c metav1.Condition) error {
+			return r.patchStatus(ctx, backup, func(status *mariadbv1alpha1.PhysicalBackupStatus) {
+				status.SetCondition(c)
+			})
+		})
+}
+
+// reconcileProgress reports the initial phase of a running Job, as the subsequent ones are reported by the Job itself,
+// and exposes the progress as metrics.
+func (r *PhysicalBackupReconciler) reconcileProgress(ctx context.Context, backup *mariadbv1alpha1.PhysicalBackup,
+	jobList *batchv1.JobList) error {
+	job := progress.RunningJob(jobList.Items)
+	if job == nil {
+		metrics.DeleteBackupProgress(mariadbv1alpha1.PhysicalBackupKind, backup.Namespace, backup.Name)
+		return nil
+	}
+
+	backupProgress, changed := progress.ForJob(job, backup.Status.Progress, mariadbv1alpha1.BackupProgressPhaseDump, time.Now())
+	if changed {
+		if err := r.patchStatus(ctx, backup, func(status *mariadbv1alpha1.PhysicalBackupStatus) {
+			status.Progress = backupProgress
+		}); err != nil {
+			return fmt.Errorf("error patching progress: %v", err)
+		}
+	}
+	metrics.RecordBackupProgress(mariadbv1alpha1.PhysicalBackupKind, backup.Namespace, backup.Name, backupProgress)
+	return nil
+}
+
 func (r *PhysicalBackupReconciler) reconcileJobStatus(ctx context.Context, backup *mariadbv1alpha1.PhysicalBackup,
 	jobList *batchv1.JobList, parentLogger logr.Logger) error {
 	logger := parentLogger.WithName("status").V(1)
@@ -247,50 +288,50 @@ func (r *PhysicalBackupReconciler) waitForRunningJobs(ctx context.Context, backu
 	return ctrl.Result{}, nil
 }
 
-func (r *PhysicalBackupReconciler) reconcileRBAC(ctx context.Context, backup *mariadbv1alpha1.PhysicalBackup,
-	mariadb *mariadbv1alpha1.MariaDB) error {
+func (r *PhysicalBackupReconciler) reconcileRBAC(ctx context.Context, backup *mariadbv1alpha1.PhysicalBackup) error {
 	key := backup.ServiceAccountKey()
 	sa, err := r.RBACReconciler.ReconcileServiceAccount(ctx, key, backup, backup.Spec.InheritMetadata)
 	if err != nil {
 		return fmt.Errorf("error reconciling ServiceAccount: %v", err)
 	}
 
-	if mariadb.IsPointInTimeRecoveryEnabled() {
-		rules := []rbacv1.PolicyRule{
-			{
-				APIGroups: []string{
-					mariadbv1alpha1.GroupVersion.Group,
-				},
-				Resources: []string{
-					"physicalbackups",
-				},
-				Verbs: []string{
-					"get",
-					"patch",
-					"update",
-				},
+	// The PhysicalBackup Job keeps track of the backup GTID and the incremental backup chain in the PhysicalBackup object,
+	// and reports the integrity verification of the latest backup and the backup progress in the PhysicalBackup status.
+	rules := []rbacv1.PolicyRule{
+		{
+			APIGroups: []string{
+				mariadbv1alpha1.GroupVersion.Group,
 			},
-		}
-		role, err := r.RBACReconciler.ReconcileRole(ctx, backup.RoleKey(), backup, backup.Spec.InheritMetadata, rules)
-		if err != nil {
-			return fmt.Errorf("error reconciling Role: %v", err)
-		}
+			Resources: []string{
+				"physicalbackups",
+				"physicalbackups/status",
+			},
+			Verbs: []string{
+				"get",
+				"patch",
+				"update",
+			},
+		},
+	}
+	role, err := r.RBACReconciler.ReconcileRole(ctx, backup.RoleKey(), backup, backup.Spec.InheritMetadata, rules)
+	if err != nil {
+		return fmt.Errorf("error reconciling Role: %v", err)
+	}
 
-		roleRef := rbacv1.RoleRef{
-			APIGroup: rbacv1.GroupName,
-			Kind:     "Role",
-			Name:     role.Name,
-		}
-		if err := r.RBACReconciler.ReconcileRoleBinding(
-			ctx,
-			backup.RoleBindingKey(),
-			backup,
-			backup.Spec.InheritMetadata,
-			sa,
-			roleRef,
-		); err != nil {
-			return fmt.Errorf("error reconciling RoleBinding: %v", err)
-		}
+	roleRef := rbacv1.RoleRef{
+		APIGroup: rbacv1.GroupName,
+		Kind:     "Role",
+		Name:     role.Name,
+	}
+	if err := r.RBACReconciler.ReconcileRoleBinding(
+		ctx,
+		backup.RoleBindingKey(),
+		backup,
+		backup.Spec.InheritMetadata,
+		sa,
+		roleRef,
+	); err != nil {
+		return fmt.Errorf("error reconciling RoleBinding: %v", err)
 	}
 	return nil
 }
@@ -358,7 +399,24 @@ func (r *PhysicalBackupReconciler) createJob(ctx context.Context, backup *mariad
 		return ctrl.Result{}, fmt.Errorf("error getting backup file name: %v", err)
 	}
 
-	job, err := r.Builder.BuildPhysicalBackupJob(backupKey, backup, mariadb, &targetPod, backupFileName)
+	incrementalParent, err := backup.IncrementalParent(*podIndex, now)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("error getting incremental parent: %v", err)
+	}
+	if incrementalParent != nil {
+		logger.Info("Scheduling incremental backup", "parent", incrementalParent.FileName)
+	} else if backup.IsIncrementalEnabled() {
+		logger.Info("Scheduling full backup to start a new incremental chain")
+	}
+
+	job, err := r.Builder.BuildPhysicalBackupJob(
+		backupKey,
+		backup,
+		mariadb,
+		&targetPod,
+		backupFileName,
+		builder.WithIncrementalParent(incrementalParent),
+	)
 	if err != nil {
 		return ctrl.Result{}, fmt.Errorf("error building Job: %v", err)
 	}
@@ -375,6 +433,17 @@ func (r *PhysicalBackupReconciler) createJob(ctx context.Context, backup *mariad
 				Time: schedule.Next(now),
 			}
 		}
+		if !backup.IsIncrementalEnabled() {
+			status.IncrementalChain = nil
+		} else if incrementalParent == nil {
+			// The backups of the chain are added by the PhysicalBackup Job once they are completed.
+			status.IncrementalChain = &mariadbv1alpha1.PhysicalBackupChain{
+				PodIndex: podIndex,
+				StartTime: &metav1.Time{
+					Time: now,
+				},
+			}
+		}
 	}); err != nil {
 		return ctrl.Result{}, fmt.Errorf("error patching status: %v", err)
 	}
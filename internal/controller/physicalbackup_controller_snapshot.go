@@ -4,11 +4,13 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
 	"time"
 
 	"github.com/go-logr/logr"
 	volumesnapshotv1 "github.com/kubernetes-csi/external-snapshotter/client/v8/apis/volumesnapshot/v1"
 	mariadbv1alpha1 "github.com/mariadb-operator/mariadb-operator/v26/api/v1alpha1"
+	backuppkg "github.com/mariadb-operator/mariadb-operator/v26/pkg/backup"
 	"github.com/mariadb-operator/mariadb-operator/v26/pkg/builder"
 	"github.com/mariadb-operator/mariadb-operator/v26/pkg/metadata"
 	"github.com/mariadb-operator/mariadb-operator/v26/pkg/predicate"
@@ -50,6 +52,12 @@ func (r *PhysicalBackupReconciler) reconcileSnapshots(ctx context.Context, backu
 	if err := r.reconcileSnapshotStatus(ctx, backup, snapshotList, logger); err != nil {
 		return ctrl.Result{}, fmt.Errorf("error reconciling status: %v", err)
 	}
+	if err := r.patchOldestSnapshot(ctx, backup, mariadb, snapshotList); err != nil {
+		return ctrl.Result{}, fmt.Errorf("error patching oldest VolumeSnapshot: %v", err)
+	}
+	if err := r.patchSnapshotCatalog(ctx, backup, snapshotList, logger); err != nil {
+		return ctrl.Result{}, fmt.Errorf("error patching catalog: %v", err)
+	}
 
 	schedule := ptr.Deref(backup.Spec.Schedule, mariadbv1alpha1.PhysicalBackupSchedule{})
 	if schedule.Suspend {
@@ -210,7 +218,9 @@ func (r *PhysicalBackupReconciler) cleanupSnapshots(ctx context.Context, backup
 		maxRetention = mariadbv1alpha1.DefaultPhysicalBackupMaxRetention
 	}
 
-	oldSnapshotNames := r.BackupProcessor.GetOldBackupFiles(readySnapshotNames, maxRetention.Duration, logger)
+	retentionPolicy := backuppkg.NewRetentionPolicy(maxRetention.Duration, backup.Spec.Retention)
+
+	oldSnapshotNames := r.BackupProcessor.GetOldBackupFiles(readySnapshotNames, retentionPolicy, logger)
 	for _, snapshotName := range oldSnapshotNames {
 		key := types.NamespacedName{
 			Name:      snapshotName,
@@ -228,7 +238,7 @@ func (r *PhysicalBackupReconciler) cleanupSnapshots(ctx context.Context, backup
 		if err != nil && !apierrors.IsNotFound(err) {
 			return fmt.Errorf("error deleting VolumeSnapshot \"%s\": %v", snapshot.Name, err)
 		}
-		logger.V(1).Info("Deleted old Snapshot", "snapshot", key.Name, "physicalbackup", backup.Name)
+		logger.Info("Deleted old Snapshot", "snapshot", key.Name, "physicalbackup", backup.Name)
 	}
 
 	return nil
@@ -413,6 +423,91 @@ func (r *PhysicalBackupReconciler) waitForProvisionedSnapshots(ctx context.Conte
 	return ctrl.Result{}, nil
 }
 
+// patchOldestSnapshot annotates the PhysicalBackup with the GTID and time of the oldest ready VolumeSnapshot,
+// which determine the binary logs that are no longer needed for point-in-time recovery.
+// The annotations are removed when the GTID of the oldest VolumeSnapshot is unknown, so binary logs are not purged.
+func (r *PhysicalBackupReconciler) patchOldestSnapshot(ctx context.Context, backup *mariadbv1alpha1.PhysicalBackup,
+	mariadb *mariadbv1alpha1.MariaDB, snapshotList *volumesnapshotv1.VolumeSnapshotList) error {
+	if !mariadb.IsPointInTimeRecoveryEnabled() {
+		return nil
+	}
+	var oldest *volumesnapshotv1.VolumeSnapshot
+	for i, snapshot := range snapshotList.Items {
+		if !mdbsnapshot.IsVolumeSnapshotReady(&snapshot) || snapshot.DeletionTimestamp != nil {
+			continue
+		}
+		if oldest == nil || snapshot.CreationTimestamp.Before(&oldest.CreationTimestamp) {
+			oldest = &snapshotList.Items[i]
+		}
+	}
+	var gtid, oldestTime string
+	if oldest != nil && oldest.Annotations[metadata.GtidAnnotation] != "" {
+		gtid = oldest.Annotations[metadata.GtidAnnotation]
+		oldestTime = oldest.CreationTimestamp.UTC().Format(time.RFC3339)
+	}
+	if backup.Annotations[metadata.OldestGtidAnnotation] == gtid && backup.Annotations[metadata.OldestTimeAnnotation] == oldestTime {
+		return nil
+	}
+
+	return r.patch(ctx, backup, func(pb *mariadbv1alpha1.PhysicalBackup) {
+		if gtid == "" {
+			delete(pb.Annotations, metadata.OldestGtidAnnotation)
+			delete(pb.Annotations, metadata.OldestTimeAnnotation)
+			return
+		}
+		if pb.Annotations == nil {
+			pb.Annotations = make(map[string]string)
+		}
+		pb.Annotations[metadata.OldestGtidAnnotation] = gtid
+		pb.Annotations[metadata.OldestTimeAnnotation] = oldestTime
+	})
+}
+
+// patchSnapshotCatalog reports the ready VolumeSnapshots in the catalog of the PhysicalBackup status.
+// Unlike the backups taken by Jobs, there is no Job with access to the snapshots, so the catalog is computed by the controller.
+func (r *PhysicalBackupReconciler) patchSnapshotCatalog(ctx context.Context, backup *mariadbv1alpha1.PhysicalBackup,
+	snapshotList *volumesnapshotv1.VolumeSnapshotList, logger logr.Logger) error {
+	snapshots := make(map[string]*volumesnapshotv1.VolumeSnapshot)
+	var snapshotNames []string
+	for i, snapshot := range snapshotList.Items {
+		if !mdbsnapshot.IsVolumeSnapshotReady(&snapshot) || snapshot.DeletionTimestamp != nil {
+			continue
+		}
+		snapshots[snapshot.Name] = &snapshotList.Items[i]
+		snapshotNames = append(snapshotNames, snapshot.Name)
+	}
+	manifestFn := func(ctx context.Context, snapshotName string) (*backuppkg.Manifest, error) {
+		snapshot, ok := snapshots[snapshotName]
+		if !ok {
+			return nil, nil
+		}
+		manifest := &backuppkg.Manifest{
+			FileName: snapshotName,
+			GTID:     snapshot.Annotations[metadata.GtidAnnotation],
+		}
+		if status := snapshot.Status; status != nil && status.RestoreSize != nil {
+			manifest.Size = status.RestoreSize.Value()
+		}
+		return manifest, nil
+	}
+	entries := backuppkg.GetCatalogEntries(ctx, snapshotNames, r.BackupProcessor, manifestFn, backuppkg.CatalogMaxEntries,
+		logger.WithName("catalog").V(1))
+
+	if catalog := backup.Status.Catalog; catalog != nil && catalog.TotalBackups == int32(len(snapshotNames)) &&
+		slices.EqualFunc(catalog.Backups, entries, func(a, b mariadbv1alpha1.BackupCatalogEntry) bool {
+			return a.FileName == b.FileName && a.Size == b.Size && a.GTID == b.GTID
+		}) {
+		return nil
+	}
+	return r.patchStatus(ctx, backup, func(status *mariadbv1alpha1.PhysicalBackupStatus) {
+		status.Catalog = &mariadbv1alpha1.BackupCatalog{
+			Backups:        entries,
+			TotalBackups:   int32(len(snapshotNames)),
+			LastUpdateTime: metav1.Now(),
+		}
+	})
+}
+
 func (r *PhysicalBackupReconciler) patchPhysicalBackup(ctx context.Context, backup *mariadbv1alpha1.PhysicalBackup,
 	mariadb *mariadbv1alpha1.MariaDB, gtid *string, logger logr.Logger) error {
 	if !mariadb.IsPointInTimeRecoveryEnabled() || gtid == nil {
@@ -96,4 +96,12 @@ func testPhysicalBackupVolumeSnapshot(backup *mariadbv1alpha1.PhysicalBackup) {
 		}
 		return backup.IsComplete()
 	}, testTimeout, testInterval).Should(BeTrue())
+
+	By("Expecting PhysicalBackup catalog to list the VolumeSnapshot eventually")
+	Eventually(func() bool {
+		if err := k8sClient.Get(testCtx, key, backup); err != nil {
+			return false
+		}
+		return backup.Status.Catalog != nil && len(backup.Status.Catalog.Backups) > 0
+	}, testTimeout, testInterval).Should(BeTrue())
 }
@@ -4,19 +4,31 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
+	"github.com/go-logr/logr"
 	"github.com/hashicorp/go-multierror"
 	mariadbv1alpha1 "github.com/mariadb-operator/mariadb-operator/v26/api/v1alpha1"
+	"github.com/mariadb-operator/mariadb-operator/v26/pkg/backup"
 	"github.com/mariadb-operator/mariadb-operator/v26/pkg/builder"
 	condition "github.com/mariadb-operator/mariadb-operator/v26/pkg/condition"
 	"github.com/mariadb-operator/mariadb-operator/v26/pkg/controller/batch"
 	"github.com/mariadb-operator/mariadb-operator/v26/pkg/controller/rbac"
+	replicationctrl "github.com/mariadb-operator/mariadb-operator/v26/pkg/controller/replication"
+	jobpkg "github.com/mariadb-operator/mariadb-operator/v26/pkg/job"
+	"github.com/mariadb-operator/mariadb-operator/v26/pkg/metrics"
+	"github.com/mariadb-operator/mariadb-operator/v26/pkg/progress"
 	"github.com/mariadb-operator/mariadb-operator/v26/pkg/refresolver"
+	"github.com/mariadb-operator/mariadb-operator/v26/pkg/sql"
 	batchv1 "k8s.io/api/batch/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
 )
 
 // RestoreReconciler reconciles a restore object
@@ -33,7 +45,7 @@ type RestoreReconciler struct {
 //+kubebuilder:rbac:groups=k8s.mariadb.com,resources=restores,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=k8s.mariadb.com,resources=restores/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=k8s.mariadb.com,resources=restores/finalizers,verbs=update
-//+kubebuilder:rbac:groups=batch,resources=jobs,verbs=list;watch;create;patch
+//+kubebuilder:rbac:groups=batch,resources=jobs,verbs=get;list;watch;create;patch
 //+kubebuilder:rbac:groups="",resources=serviceaccounts,verbs=list;watch;create;patch
 //+kubebuilder:rbac:groups="",resources=persistentvolumeclaims,verbs=list;watch;create;patch
 
@@ -42,6 +54,9 @@ type RestoreReconciler struct {
 func (r *RestoreReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	var restore mariadbv1alpha1.Restore
 	if err := r.Get(ctx, req.NamespacedName, &restore); err != nil {
+		if apierrors.IsNotFound(err) {
+			metrics.DeleteBackupProgress(mariadbv1alpha1.RestoreKind, req.Namespace, req.Name)
+		}
 		return ctrl.Result{}, client.IgnoreNotFound(err)
 	}
 
@@ -74,8 +89,12 @@ func (r *RestoreReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 		return ctrl.Result{}, fmt.Errorf("error initializing source: %v", sourceErr)
 	}
 
-	if err := r.reconcileServiceAccount(ctx, &restore); err != nil {
-		return ctrl.Result{}, fmt.Errorf("error reconciling ServiceAccount: %v", err)
+	if restore.Spec.DryRun {
+		return ctrl.Result{}, r.reconcileDryRun(ctx, &restore)
+	}
+
+	if err := r.reconcileRBAC(ctx, &restore); err != nil {
+		return ctrl.Result{}, fmt.Errorf("error reconciling RBAC: %v", err)
 	}
 
 	if err := r.BatchReconciler.Reconcile(ctx, &restore, mariadb); err != nil {
@@ -85,7 +104,15 @@ func (r *RestoreReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 		return ctrl.Result{}, fmt.Errorf("error reconciling batch: %v", err)
 	}
 
-	patcher, err := r.ConditionComplete.PatcherWithJob(ctx, err, req.NamespacedName)
+	jobKey, err := r.reconcilePITR(ctx, &restore, mariadb)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return ctrl.Result{}, client.IgnoreNotFound(err)
+		}
+		return ctrl.Result{}, fmt.Errorf("error reconciling PITR: %v", err)
+	}
+
+	patcher, err := r.ConditionComplete.PatcherWithJob(ctx, err, jobKey)
 	if err != nil {
 		if apierrors.IsNotFound(err) {
 			return ctrl.Result{}, client.IgnoreNotFound(err)
@@ -99,9 +126,148 @@ func (r *RestoreReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 		}
 		return ctrl.Result{}, fmt.Errorf("error patching restore status: %v", err)
 	}
+
+	if err := r.reconcileProgress(ctx, &restore); err != nil {
+		return ctrl.Result{}, fmt.Errorf("error reconciling progress: %v", err)
+	}
 	return ctrl.Result{}, nil
 }
 
+// reconcilePITR replays the binary logs after the restore Job completes, when a PointInTimeRecovery is referenced.
+// It returns the key of the Job that determines whether the Restore is complete.
+func (r *RestoreReconciler) reconcilePITR(ctx context.Context, restore *mariadbv1alpha1.Restore,
+	mariadb *mariadbv1alpha1.MariaDB) (types.NamespacedName, error) {
+	key := client.ObjectKeyFromObject(restore)
+	if restore.Spec.PointInTimeRecoveryRef == nil {
+		return key, nil
+	}
+	var restoreJob batchv1.Job
+	if err := r.Get(ctx, key, &restoreJob); err != nil {
+		return key, err
+	}
+	if !jobpkg.IsJobComplete(&restoreJob) {
+		return key, nil
+	}
+	logger := log.FromContext(ctx).WithName("pitr")
+	pitrKey := restore.PITRJobKey()
+
+	var pitrJob batchv1.Job
+	if err := r.Get(ctx, pitrKey, &pitrJob); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return pitrKey, fmt.Errorf("error getting PointInTimeRecovery Job: %v", err)
+		}
+		sqlClient, err := sql.NewClientWithMariaDB(ctx, mariadb, r.RefResolver)
+		if err != nil {
+			return pitrKey, fmt.Errorf("error getting SQL client: %v", err)
+		}
+		defer sqlClient.Close()
+
+		if err := replicationctrl.PauseGtidStrictMode(ctx, mariadb, sqlClient, r.Client, logger); err != nil {
+			return pitrKey, fmt.Errorf("error pausing gtid_strict_mode: %v", err)
+		}
+		if err := r.createPITRJob(ctx, restore, mariadb, sqlClient, logger); err != nil {
+			return pitrKey, err
+		}
+		return pitrKey, nil
+	}
+
+	repl := ptr.Deref(mariadb.Status.Replication, mariadbv1alpha1.ReplicationStatus{})
+	if jobpkg.IsJobComplete(&pitrJob) && ptr.Deref(repl.GtidStrictModePaused, false) {
+		sqlClient, err := sql.NewClientWithMariaDB(ctx, mariadb, r.RefResolver)
+		if err != nil {
+			return pitrKey, fmt.Errorf("error getting SQL client: %v", err)
+		}
+		defer sqlClient.Close()
+
+		if err := replicationctrl.ResumeGtidStrictMode(ctx, mariadb, sqlClient, r.Client, logger); err != nil {
+			return pitrKey, fmt.Errorf("error resuming gtid_strict_mode: %v", err)
+		}
+	}
+	return pitrKey, nil
+}
+
+func (r *RestoreReconciler) createPITRJob(ctx context.Context, restore *mariadbv1alpha1.Restore, mariadb *mariadbv1alpha1.MariaDB,
+	sqlClient *sql.Client, logger logr.Logger) error {
+	// the GTID of the server does not match the one of the logical backup, as the restoration generates new GTIDs.
+	if restore.Status.RestoredGtid == "" {
+		return fmt.Errorf("restored GTID not found in Restore %s", restore.Name)
+	}
+	startGtid, err := parseGtid(ctx, restore.Status.RestoredGtid, sqlClient, logger)
+	if err != nil {
+		return fmt.Errorf("error getting start GTID: %v", err)
+	}
+	pitr, err := r.RefResolver.PointInTimeRecovery(ctx, restore.Spec.PointInTimeRecoveryRef, restore.Namespace)
+	if err != nil {
+		return fmt.Errorf("error getting PointInTimeRecovery: %v", err)
+	}
+	pitrJob, err := r.Builder.BuildPITRJob(
+		restore.PITRJobKey(),
+		pitr,
+		mariadb,
+		builder.WithStartGtid(startGtid),
+		builder.WithRestore(restore),
+	)
+	if err != nil {
+		return fmt.Errorf("error building PointInTimeRecovery Job: %v", err)
+	}
+	logger.Info("Creating PointInTimeRecovery job", "name", pitrJob.Name, "start-gtid", startGtid)
+	return r.Create(ctx, pitrJob)
+}
+
+func (r *RestoreReconciler) reconcileDryRun(ctx context.Context, restore *mariadbv1alpha1.Restore) error {
+	if restore.IsComplete() {
+		return nil
+	}
+	logger := log.FromContext(ctx).WithName("restore-plan")
+	logger.Info("Computing restore plan")
+
+	plan, err := r.getRestorePlan(ctx, restore, logger)
+	if err != nil {
+		var planErr *multierror.Error
+		planErr = multierror.Append(planErr, err)
+
+		patchErr := r.patchStatus(ctx, restore, r.ConditionComplete.PatcherFailed(fmt.Sprintf("error getting restore plan: %v", err)))
+		planErr = multierror.Append(planErr, patchErr)
+
+		return fmt.Errorf("error getting restore plan: %v", planErr)
+	}
+
+	if err := r.patchStatus(ctx, restore, func(c condition.Conditioner) {
+		restore.Status.Plan = plan
+		condition.SetCompleteDryRun(c)
+	}); err != nil {
+		return fmt.Errorf("error patching restore status: %v", err)
+	}
+	logger.Info("Restore plan computed", "backup", plan.Backup)
+	return nil
+}
+
+func (r *RestoreReconciler) getRestorePlan(ctx context.Context, restore *mariadbv1alpha1.Restore,
+	logger logr.Logger) (*mariadbv1alpha1.RestorePlan, error) {
+	if restore.Spec.S3 == nil && restore.Spec.GCS == nil {
+		return nil, errors.New("dry run is only supported for backups stored in S3 or GCS")
+	}
+	storageClient, err := newBlobStorageClient(ctx, r.RefResolver, restore.Spec.S3, nil, restore.Spec.GCS, restore.Namespace)
+	if err != nil {
+		return nil, fmt.Errorf("error getting backup storage client: %v", err)
+	}
+	plan, err := getRestorePlan(ctx, storageClient, backup.NewLogicalBackupProcessor(), restore.Spec.TargetRecoveryTimeOrDefault(),
+		restore.Spec.LastRecoveryGtid(), logger)
+	if err != nil {
+		return nil, err
+	}
+	if restore.Spec.PointInTimeRecoveryRef != nil {
+		pitr, err := r.RefResolver.PointInTimeRecovery(ctx, restore.Spec.PointInTimeRecoveryRef, restore.Namespace)
+		if err != nil {
+			return nil, fmt.Errorf("error getting PointInTimeRecovery: %v", err)
+		}
+		if err := addBinlogsToRestorePlan(ctx, r.RefResolver, plan, pitr, logger); err != nil {
+			return nil, err
+		}
+	}
+	return plan, nil
+}
+
 func (r *RestoreReconciler) setDefaults(ctx context.Context, restore *mariadbv1alpha1.Restore,
 	mariadb *mariadbv1alpha1.MariaDB) error {
 	if err := r.patch(ctx, restore, func(r *mariadbv1alpha1.Restore) error {
@@ -154,10 +320,79 @@ func (r *RestoreReconciler) setDefaults(ctx context.Context, restore *mariadbv1a
 	return nil
 }
 
-func (r *RestoreReconciler) reconcileServiceAccount(ctx context.Context, restore *mariadbv1alpha1.Restore) error {
+func (r *RestoreReconciler) reconcileRBAC(ctx context.Context, restore *mariadbv1alpha1.Restore) error {
 	key := restore.Spec.ServiceAccountKey(restore.ObjectMeta)
-	_, err := r.RBACReconciler.ReconcileServiceAccount(ctx, key, restore, restore.Spec.InheritMetadata)
-	return err
+	sa, err := r.RBACReconciler.ReconcileServiceAccount(ctx, key, restore, restore.Spec.InheritMetadata)
+	if err != nil {
+		return fmt.Errorf("error reconciling ServiceAccount: %v", err)
+	}
+
+	// The Restore Job reports the restoration progress in the Restore status.
+	rules := []rbacv1.PolicyRule{
+		{
+			APIGroups: []string{
+				mariadbv1alpha1.GroupVersion.Group,
+			},
+			Resources: []string{
+				"restores",
+				"restores/status",
+			},
+			Verbs: []string{
+				"get",
+				"patch",
+				"update",
+			},
+		},
+	}
+	role, err := r.RBACReconciler.ReconcileRole(ctx, restore.RoleKey(), restore, restore.Spec.InheritMetadata, rules)
+	if err != nil {
+		return fmt.Errorf("error reconciling Role: %v", err)
+	}
+
+	roleRef := rbacv1.RoleRef{
+		APIGroup: rbacv1.GroupName,
+		Kind:     "Role",
+		Name:     role.Name,
+	}
+	if err := r.RBACReconciler.ReconcileRoleBinding(
+		ctx,
+		restore.RoleBindingKey(),
+		restore,
+		restore.Spec.InheritMetadata,
+		sa,
+		roleRef,
+	); err != nil {
+		return fmt.Errorf("error reconciling RoleBinding: %v", err)
+	}
+	return nil
+}
+
+// reconcileProgress reports the initial phase of a running Job, as the subsequent ones are reported by the Job itself,
+// and exposes the progress as metrics.
+func (r *RestoreReconciler) reconcileProgress(ctx context.Context, restore *mariadbv1alpha1.Restore) error {
+	var job batchv1.Job
+	if err := r.Get(ctx, client.ObjectKeyFromObject(restore), &job); err != nil {
+		if apierrors.IsNotFound(err) {
+			metrics.DeleteBackupProgress(mariadbv1alpha1.RestoreKind, restore.Namespace, restore.Name)
+			return nil
+		}
+		return fmt.Errorf("error getting Job: %v", err)
+	}
+	if progress.RunningJob([]batchv1.Job{job}) == nil {
+		metrics.DeleteBackupProgress(mariadbv1alpha1.RestoreKind, restore.Namespace, restore.Name)
+		return nil
+	}
+
+	restoreProgress, changed := progress.ForJob(&job, restore.Status.Progress, mariadbv1alpha1.BackupProgressPhaseDownload, time.Now())
+	if changed {
+		patch := client.MergeFrom(restore.DeepCopy())
+		restore.Status.Progress = restoreProgress
+		if err := r.Client.Status().Patch(ctx, restore, patch); err != nil {
+			return fmt.Errorf("error patching progress: %v", err)
+		}
+	}
+	metrics.RecordBackupProgress(mariadbv1alpha1.RestoreKind, restore.Namespace, restore.Name, restoreProgress)
+	return nil
 }
 
 func (r *RestoreReconciler) patchStatus(ctx context.Context, restore *mariadbv1alpha1.Restore,
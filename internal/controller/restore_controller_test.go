@@ -3,6 +3,7 @@ package controller
 import (
 	"fmt"
 
+	"github.com/go-logr/logr"
 	mariadbv1alpha1 "github.com/mariadb-operator/mariadb-operator/v26/api/v1alpha1"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -390,3 +391,21 @@ func testS3BackupRestore(key types.NamespacedName, bucket, prefix string, stagin
 		return restore.IsComplete()
 	}, testTimeout, testInterval).Should(BeTrue())
 }
+
+var _ = Describe("Restore plan", Label("basic"), func() {
+	It("should skip binlogs stored in a volume", func() {
+		pitr := &mariadbv1alpha1.PointInTimeRecovery{
+			Spec: mariadbv1alpha1.PointInTimeRecoverySpec{
+				PointInTimeRecoveryStorage: mariadbv1alpha1.PointInTimeRecoveryStorage{
+					PersistentVolumeClaim: &mariadbv1alpha1.PersistentVolumeClaimSpec{},
+				},
+			},
+		}
+		plan := &mariadbv1alpha1.RestorePlan{
+			Backup: "backup.2026-01-01T00:00:00Z.sql",
+		}
+		Expect(addBinlogsToRestorePlan(testCtx, nil, plan, pitr, logr.Discard())).To(Succeed())
+		Expect(plan.Binlogs).To(BeEmpty())
+		Expect(plan.BinlogsUnavailableReason).NotTo(BeEmpty())
+	})
+})
@@ -0,0 +1,249 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"time"
+
+	"github.com/go-logr/logr"
+	volumesnapshotv1 "github.com/kubernetes-csi/external-snapshotter/client/v8/apis/volumesnapshot/v1"
+	mariadbv1alpha1 "github.com/mariadb-operator/mariadb-operator/v26/api/v1alpha1"
+	"github.com/mariadb-operator/mariadb-operator/v26/pkg/backup"
+	"github.com/mariadb-operator/mariadb-operator/v26/pkg/binlog"
+	condition "github.com/mariadb-operator/mariadb-operator/v26/pkg/condition"
+	"github.com/mariadb-operator/mariadb-operator/v26/pkg/interfaces"
+	"github.com/mariadb-operator/mariadb-operator/v26/pkg/metadata"
+	"github.com/mariadb-operator/mariadb-operator/v26/pkg/refresolver"
+	"github.com/mariadb-operator/mariadb-operator/v26/pkg/replication"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/yaml"
+)
+
+// reconcileRestorePlan computes the restoration plan of the bootstrap source when bootstrapping in dry-run mode,
+// halting the reconciliation before any data is restored.
+func (r *MariaDBReconciler) reconcileRestorePlan(ctx context.Context, mdb *mariadbv1alpha1.MariaDB) (ctrl.Result, error) {
+	if !mdb.IsBootstrapDryRun() {
+		return ctrl.Result{}, nil
+	}
+	logger := log.FromContext(ctx).WithName("restore-plan")
+	bootstrapFrom := mdb.Spec.BootstrapFrom
+
+	plan := mdb.Status.RestorePlan
+	if plan == nil || (bootstrapFrom.TargetRecoveryTime != nil && !plan.TargetRecoveryTime.Equal(bootstrapFrom.TargetRecoveryTime)) ||
+		plan.TargetRecoveryGtid != gtidString(bootstrapFrom.LastRecoveryGtid()) {
+		logger.Info("Computing restore plan")
+
+		newPlan, err := r.getBootstrapRestorePlan(ctx, mdb, logger)
+		if err != nil {
+			return ctrl.Result{}, fmt.Errorf("error getting restore plan: %v", err)
+		}
+		if err := r.patchStatus(ctx, mdb, func(status *mariadbv1alpha1.MariaDBStatus) error {
+			status.RestorePlan = newPlan
+			condition.SetReadyRestoreDryRun(status)
+			return nil
+		}); err != nil {
+			return ctrl.Result{}, fmt.Errorf("error patching MariaDB status: %v", err)
+		}
+		logger.Info("Restore plan computed", "backup", newPlan.Backup, "binlogs", len(newPlan.Binlogs))
+	}
+
+	logger.V(1).Info("Bootstrap dry run enabled. Skipping...")
+	return ctrl.Result{RequeueAfter: 10 * time.Second}, nil
+}
+
+func (r *MariaDBReconciler) getBootstrapRestorePlan(ctx context.Context, mdb *mariadbv1alpha1.MariaDB,
+	logger logr.Logger) (*mariadbv1alpha1.RestorePlan, error) {
+	bootstrapFrom := mdb.Spec.BootstrapFrom
+	targetRecoveryTime := bootstrapFrom.TargetRecoveryTimeOrDefault()
+	targetRecoveryGtid := bootstrapFrom.LastRecoveryGtid()
+
+	var pitr *mariadbv1alpha1.PointInTimeRecovery
+	if bootstrapFrom.PointInTimeRecoveryRef != nil {
+		p, err := r.RefResolver.PointInTimeRecovery(ctx, bootstrapFrom.PointInTimeRecoveryRef, mdb.Namespace)
+		if err != nil {
+			return nil, fmt.Errorf("error getting PointInTimeRecovery: %v", err)
+		}
+		pitr = p
+	}
+
+	var plan *mariadbv1alpha1.RestorePlan
+	if bootstrapFrom.VolumeSnapshotRef != nil {
+		p, err := r.getVolumeSnapshotRestorePlan(ctx, mdb, targetRecoveryTime, targetRecoveryGtid)
+		if err != nil {
+			return nil, err
+		}
+		plan = p
+	} else {
+		s3, abs, gcs := bootstrapFrom.S3, bootstrapFrom.AzureBlob, bootstrapFrom.GCS
+		processor := backup.NewLogicalBackupProcessor()
+		if (pitr != nil && !pitr.IsLogical()) || bootstrapFrom.BackupContentType == mariadbv1alpha1.BackupContentTypePhysical {
+			processor = backup.NewPhysicalBackupProcessor()
+		}
+
+		backupRef := bootstrapFrom.BackupRef
+		if pitr != nil && pitr.IsLogical() {
+			backupRef = &mariadbv1alpha1.TypedLocalObjectReference{
+				Name: pitr.Spec.BackupRef.Name,
+				Kind: mariadbv1alpha1.BackupKind,
+			}
+		}
+		if backupRef != nil && (backupRef.Kind == "" || backupRef.Kind == mariadbv1alpha1.BackupKind) {
+			b, err := r.RefResolver.Backup(ctx, backupRef.LocalReference(), mdb.Namespace)
+			if err != nil {
+				return nil, fmt.Errorf("error getting Backup: %v", err)
+			}
+			s3, abs, gcs = b.Spec.Storage.S3, nil, b.Spec.Storage.GCS
+		}
+		if s3 == nil && abs == nil && gcs == nil {
+			return nil, errors.New("dry run is only supported for backups stored in S3, Azure Blob Storage or GCS, and for VolumeSnapshots")
+		}
+
+		storageClient, err := newBlobStorageClient(ctx, r.RefResolver, s3, abs, gcs, mdb.Namespace)
+		if err != nil {
+			return nil, fmt.Errorf("error getting backup storage client: %v", err)
+		}
+		p, err := getRestorePlan(ctx, storageClient, processor, targetRecoveryTime, targetRecoveryGtid, logger)
+		if err != nil {
+			return nil, err
+		}
+		plan = p
+	}
+
+	if pitr != nil {
+		if err := addBinlogsToRestorePlan(ctx, r.RefResolver, plan, pitr, logger); err != nil {
+			return nil, err
+		}
+	}
+	return plan, nil
+}
+
+// addBinlogsToRestorePlan adds the binary logs to be replayed on top of the backup to the restore plan.
+// Binary logs stored in a volume are not accessible by the operator, so they are skipped and the reason is reported in the plan.
+func addBinlogsToRestorePlan(ctx context.Context, refResolver *refresolver.RefResolver, plan *mariadbv1alpha1.RestorePlan,
+	pitr *mariadbv1alpha1.PointInTimeRecovery, logger logr.Logger) error {
+	storage := pitr.Spec.PointInTimeRecoveryStorage
+	if storage.IsFileSystem() {
+		logger.Info("Binlogs stored in a volume. Skipping binlogs in restore plan...")
+		plan.BinlogsUnavailableReason = errPITRFileSystemStorage.Error()
+		return nil
+	}
+	storageClient, err := newBlobStorageClient(ctx, refResolver, storage.S3, storage.AzureBlob, storage.GCS, pitr.Namespace)
+	if err != nil {
+		return fmt.Errorf("error getting binlog storage client: %v", err)
+	}
+	index, err := readBinlogIndex(ctx, storageClient)
+	if err != nil {
+		return err
+	}
+	if err := index.AddToRestorePlan(plan, logger.WithName("binlog")); err != nil {
+		return fmt.Errorf("error adding binlogs to restore plan: %v", err)
+	}
+	if !pitr.Spec.StrictMode {
+		plan.StrictModeError = ""
+	}
+	return nil
+}
+
+func (r *MariaDBReconciler) getVolumeSnapshotRestorePlan(ctx context.Context, mdb *mariadbv1alpha1.MariaDB,
+	targetRecoveryTime time.Time, targetRecoveryGtid *replication.Gtid) (*mariadbv1alpha1.RestorePlan, error) {
+	key := types.NamespacedName{
+		Name:      mdb.Spec.BootstrapFrom.VolumeSnapshotRef.Name,
+		Namespace: mdb.Namespace,
+	}
+	var snapshot volumesnapshotv1.VolumeSnapshot
+	if err := r.Get(ctx, key, &snapshot); err != nil {
+		return nil, fmt.Errorf("error getting VolumeSnapshot: %v", err)
+	}
+	return &mariadbv1alpha1.RestorePlan{
+		TargetRecoveryTime: metav1.NewTime(targetRecoveryTime.UTC()),
+		Backup:             snapshot.Name,
+		BackupTime:         metav1.NewTime(snapshot.CreationTimestamp.UTC()),
+		BackupGtid:         snapshot.Annotations[metadata.GtidAnnotation],
+		TargetRecoveryGtid: gtidString(targetRecoveryGtid),
+	}, nil
+}
+
+// getRestorePlan computes the restoration plan of the backups available in the object storage, without restoring any data.
+func getRestorePlan(ctx context.Context, storageClient interfaces.BlobStorage, processor backup.BackupProcessor,
+	targetRecoveryTime time.Time, targetRecoveryGtid *replication.Gtid, logger logr.Logger) (*mariadbv1alpha1.RestorePlan, error) {
+	objectNames, err := storageClient.ListObjectsWithOptions(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error listing backups: %v", err)
+	}
+	var (
+		backupFileNames []string
+		chainIndex      *backup.ChainIndex
+	)
+	for _, name := range objectNames {
+		fileName := storageClient.UnprefixedFilename(name)
+		if processor.IsValidBackupFile(fileName) {
+			backupFileNames = append(backupFileNames, fileName)
+		}
+		if path.Base(fileName) == backup.ChainIndexFileName {
+			bytes, err := readObject(ctx, storageClient, fileName)
+			if err != nil {
+				return nil, fmt.Errorf("error getting chain index: %v", err)
+			}
+			if chainIndex, err = backup.ParseChainIndex(bytes); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	manifestFn := func(ctx context.Context, fileName string) (*backup.Manifest, error) {
+		manifestFileName := backup.ManifestFileName(fileName)
+		exists, err := storageClient.Exists(ctx, manifestFileName)
+		if err != nil {
+			return nil, fmt.Errorf("error checking if manifest exists: %v", err)
+		}
+		if !exists {
+			return nil, nil
+		}
+		bytes, err := readObject(ctx, storageClient, manifestFileName)
+		if err != nil {
+			return nil, fmt.Errorf("error getting manifest: %v", err)
+		}
+		return backup.ParseManifest(bytes)
+	}
+
+	plan, err := backup.GetRestorePlan(ctx, backupFileNames, processor, manifestFn, chainIndex, targetRecoveryTime,
+		targetRecoveryGtid, logger)
+	if err != nil {
+		return nil, fmt.Errorf("error getting restore plan: %v", err)
+	}
+	return plan, nil
+}
+
+func readBinlogIndex(ctx context.Context, storageClient interfaces.BlobStorage) (*binlog.BinlogIndex, error) {
+	indexBytes, err := readObject(ctx, storageClient, binlog.BinlogIndexName)
+	if err != nil {
+		return nil, fmt.Errorf("error getting binlog index: %v", err)
+	}
+	var index binlog.BinlogIndex
+	if err := yaml.Unmarshal(indexBytes, &index); err != nil {
+		return nil, fmt.Errorf("error unmarshalling binlog index: %v", err)
+	}
+	return &index, nil
+}
+
+func readObject(ctx context.Context, storageClient interfaces.BlobStorage, fileName string) ([]byte, error) {
+	reader, err := storageClient.GetObjectWithOptions(ctx, fileName)
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+	return io.ReadAll(reader)
+}
+
+func gtidString(gtid *replication.Gtid) string {
+	if gtid == nil {
+		return ""
+	}
+	return gtid.String()
+}
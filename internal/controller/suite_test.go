@@ -20,6 +20,7 @@ import (
 	"github.com/mariadb-operator/mariadb-operator/v26/pkg/controller/deployment"
 	"github.com/mariadb-operator/mariadb-operator/v26/pkg/controller/endpoints"
 	"github.com/mariadb-operator/mariadb-operator/v26/pkg/controller/galera"
+	"github.com/mariadb-operator/mariadb-operator/v26/pkg/controller/hook"
 	"github.com/mariadb-operator/mariadb-operator/v26/pkg/controller/maintenance"
 	"github.com/mariadb-operator/mariadb-operator/v26/pkg/controller/pvc"
 	"github.com/mariadb-operator/mariadb-operator/v26/pkg/controller/rbac"
@@ -148,6 +149,7 @@ var _ = BeforeSuite(func() {
 	serviceReconciler := service.NewServiceReconciler(client)
 	endpointsReconciler := endpoints.NewEndpointsReconciler(client, builder)
 	batchReconciler := batch.NewBatchReconciler(client, builder)
+	hookReconciler := hook.NewHookReconciler(client, hook.NewPodExecutor(cfg, kubeClientset), hook.WithRefResolver(refResolver))
 	authReconciler := auth.NewAuthReconciler(client, builder)
 	rbacReconciler := rbac.NewRBACReconciler(client, builder)
 	deployReconciler := deployment.NewDeploymentReconciler(client)
@@ -270,6 +272,7 @@ var _ = BeforeSuite(func() {
 		ConditionComplete: conditionComplete,
 		RBACReconciler:    rbacReconciler,
 		BatchReconciler:   batchReconciler,
+		HookReconciler:    hookReconciler,
 	}).SetupWithManager(k8sManager)
 	Expect(err).ToNot(HaveOccurred())
 
@@ -283,6 +286,7 @@ var _ = BeforeSuite(func() {
 		ConditionComplete: conditionComplete,
 		RBACReconciler:    rbacReconciler,
 		PVCReconciler:     pvcReconciler,
+		HookReconciler:    hookReconciler,
 		BackupProcessor:   backupProcessor,
 	}).SetupWithManager(testCtx, k8sManager, ctrlcontroller.Options{MaxConcurrentReconciles: 10})
 	Expect(err).ToNot(HaveOccurred())
@@ -333,6 +337,26 @@ var _ = BeforeSuite(func() {
 	}).SetupWithManager(k8sManager)
 	Expect(err).ToNot(HaveOccurred())
 
+	err = (&BackupVerificationReconciler{
+		Client:          client,
+		Scheme:          scheme,
+		Builder:         builder,
+		RefResolver:     refResolver,
+		BackupProcessor: backupProcessor,
+		RequeueInterval: 5 * time.Second,
+	}).SetupWithManager(k8sManager)
+	Expect(err).ToNot(HaveOccurred())
+
+	err = (&FlashbackReconciler{
+		Client:            client,
+		Scheme:            scheme,
+		Builder:           builder,
+		RefResolver:       refResolver,
+		ConditionComplete: conditionComplete,
+		RBACReconciler:    rbacReconciler,
+	}).SetupWithManager(k8sManager)
+	Expect(err).ToNot(HaveOccurred())
+
 	err = podReplicationController.SetupWithManager(k8sManager)
 	Expect(err).ToNot(HaveOccurred())
 
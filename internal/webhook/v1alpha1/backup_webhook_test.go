@@ -288,6 +288,162 @@ var _ = Describe("Backup webhook", func() {
 				},
 				true,
 			),
+			Entry(
+				"Invalid parallel chunk size",
+				&v1alpha1.Backup{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "backup-invalid-parallel-chunk-size",
+						Namespace: testNamespace,
+					},
+					Spec: v1alpha1.BackupSpec{
+						Compression: v1alpha1.CompressGzip,
+						Storage: v1alpha1.BackupStorage{
+							S3: &v1alpha1.S3{
+								Bucket:   "test",
+								Endpoint: "test",
+							},
+						},
+						Parallel: &v1alpha1.ParallelBackup{
+							Enabled:   true,
+							ChunkSize: ptr.To(resource.MustParse("0")),
+						},
+						MariaDBRef: v1alpha1.MariaDBRef{
+							ObjectReference: v1alpha1.ObjectReference{
+								Name: "mariadb-webhook",
+							},
+							WaitForIt: true,
+						},
+						BackoffLimit:  10,
+						RestartPolicy: corev1.RestartPolicyOnFailure,
+					},
+				},
+				true,
+			),
+			Entry(
+				"Parallel with skip comments",
+				&v1alpha1.Backup{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "backup-parallel-skip-comments",
+						Namespace: testNamespace,
+					},
+					Spec: v1alpha1.BackupSpec{
+						Compression: v1alpha1.CompressGzip,
+						Storage: v1alpha1.BackupStorage{
+							S3: &v1alpha1.S3{
+								Bucket:   "test",
+								Endpoint: "test",
+							},
+						},
+						Parallel: &v1alpha1.ParallelBackup{
+							Enabled: true,
+						},
+						JobContainerTemplate: v1alpha1.JobContainerTemplate{
+							Args: []string{"--skip-comments"},
+						},
+						MariaDBRef: v1alpha1.MariaDBRef{
+							ObjectReference: v1alpha1.ObjectReference{
+								Name: "mariadb-webhook",
+							},
+							WaitForIt: true,
+						},
+						BackoffLimit:  10,
+						RestartPolicy: corev1.RestartPolicyOnFailure,
+					},
+				},
+				true,
+			),
+			Entry(
+				"Parallel with restart policy OnFailure",
+				&v1alpha1.Backup{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "backup-parallel-restart-on-failure",
+						Namespace: testNamespace,
+					},
+					Spec: v1alpha1.BackupSpec{
+						Compression: v1alpha1.CompressGzip,
+						Storage: v1alpha1.BackupStorage{
+							S3: &v1alpha1.S3{
+								Bucket:   "test",
+								Endpoint: "test",
+							},
+						},
+						Parallel: &v1alpha1.ParallelBackup{
+							Enabled: true,
+						},
+						MariaDBRef: v1alpha1.MariaDBRef{
+							ObjectReference: v1alpha1.ObjectReference{
+								Name: "mariadb-webhook",
+							},
+							WaitForIt: true,
+						},
+						BackoffLimit:  10,
+						RestartPolicy: corev1.RestartPolicyOnFailure,
+					},
+				},
+				true,
+			),
+			Entry(
+				"Parallel with restart policy Never",
+				&v1alpha1.Backup{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "backup-parallel-restart-never",
+						Namespace: testNamespace,
+					},
+					Spec: v1alpha1.BackupSpec{
+						Compression: v1alpha1.CompressGzip,
+						Storage: v1alpha1.BackupStorage{
+							S3: &v1alpha1.S3{
+								Bucket:   "test",
+								Endpoint: "test",
+							},
+						},
+						Parallel: &v1alpha1.ParallelBackup{
+							Enabled: true,
+						},
+						MariaDBRef: v1alpha1.MariaDBRef{
+							ObjectReference: v1alpha1.ObjectReference{
+								Name: "mariadb-webhook",
+							},
+							WaitForIt: true,
+						},
+						BackoffLimit:  10,
+						RestartPolicy: corev1.RestartPolicyNever,
+					},
+				},
+				false,
+			),
+			Entry(
+				"Invalid table pattern",
+				&v1alpha1.Backup{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "backup-invalid-table-pattern",
+						Namespace: testNamespace,
+					},
+					Spec: v1alpha1.BackupSpec{
+						Compression: v1alpha1.CompressGzip,
+						Storage: v1alpha1.BackupStorage{
+							S3: &v1alpha1.S3{
+								Bucket:   "test",
+								Endpoint: "test",
+							},
+						},
+						Tables: &v1alpha1.TableSelection{
+							Include: []string{
+								"users",
+							},
+						},
+						MariaDBRef: v1alpha1.MariaDBRef{
+							ObjectReference: v1alpha1.ObjectReference{
+								Name: "mariadb-webhook",
+							},
+							WaitForIt: true,
+						},
+						BackoffLimit:  10,
+						RestartPolicy: corev1.RestartPolicyOnFailure,
+					},
+				},
+				true,
+			),
 			Entry(
 				"Valid",
 				&v1alpha1.Backup{
@@ -0,0 +1,67 @@
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+
+	mariadbv1alpha1 "github.com/mariadb-operator/mariadb-operator/v26/api/v1alpha1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// log is for logging in this package.
+var backupverificationlog = logf.Log.WithName("backupverification-resource")
+
+// SetupBackupVerificationWebhookWithManager registers the webhook for BackupVerification in the manager.
+func SetupBackupVerificationWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr, &mariadbv1alpha1.BackupVerification{}).
+		WithValidator(&BackupVerificationCustomValidator{}).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/validate-k8s-mariadb-com-v1alpha1-backupverification,mutating=false,failurePolicy=fail,sideEffects=None,groups=k8s.mariadb.com,resources=backupverifications,verbs=create;update,versions=v1alpha1,name=vbackupverification-v1alpha1.kb.io,admissionReviewVersions=v1
+
+// BackupVerificationCustomValidator struct is responsible for validating the BackupVerification resource
+// when it is created, updated, or deleted.
+type BackupVerificationCustomValidator struct{}
+
+var _ admission.Validator[*mariadbv1alpha1.BackupVerification] = &BackupVerificationCustomValidator{}
+
+// ValidateUpdate implements webhook.CustomValidator so a webhook will be registered for the type BackupVerification.
+func (v *BackupVerificationCustomValidator) ValidateUpdate(ctx context.Context,
+	oldVerification, verification *mariadbv1alpha1.BackupVerification) (admission.Warnings, error) {
+	backupverificationlog.V(1).Info("Validation for BackupVerification upon update", "name", verification.GetName())
+
+	if err := immutableWebhook.ValidateUpdate(verification, oldVerification); err != nil {
+		return nil, err
+	}
+
+	return validateBackupVerification(verification)
+}
+
+// ValidateCreate implements webhook.CustomValidator so a webhook will be registered for the type BackupVerification.
+func (v *BackupVerificationCustomValidator) ValidateCreate(_ context.Context,
+	verification *mariadbv1alpha1.BackupVerification) (admission.Warnings, error) {
+	backupverificationlog.Info("Validation for BackupVerification upon creation", "name", verification.GetName())
+
+	return validateBackupVerification(verification)
+}
+
+// ValidateDelete implements webhook.CustomValidator so a webhook will be registered for the type BackupVerification.
+func (v *BackupVerificationCustomValidator) ValidateDelete(ctx context.Context,
+	verification *mariadbv1alpha1.BackupVerification) (admission.Warnings, error) {
+	return nil, nil
+}
+
+func validateBackupVerification(verification *mariadbv1alpha1.BackupVerification) (admission.Warnings, error) {
+	if err := verification.Validate(); err != nil {
+		return nil, field.Invalid(
+			field.NewPath("spec"),
+			verification.Spec,
+			fmt.Sprintf("invalid BackupVerification: %v", err),
+		)
+	}
+	return nil, nil
+}
@@ -0,0 +1,220 @@
+package v1alpha1
+
+import (
+	"github.com/mariadb-operator/mariadb-operator/v26/api/v1alpha1"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("BackupVerification Webhook", func() {
+	Context("When creating BackupVerification", func() {
+		key := types.NamespacedName{
+			Name:      "backupverification-create",
+			Namespace: testNamespace,
+		}
+
+		DescribeTable(
+			"Should validate",
+			func(verification *v1alpha1.BackupVerification, wantErr bool) {
+				_ = k8sClient.Delete(testCtx, verification)
+				err := k8sClient.Create(testCtx, verification)
+				if wantErr {
+					Expect(err).To(HaveOccurred())
+				} else {
+					Expect(err).ToNot(HaveOccurred())
+				}
+			},
+			Entry(
+				"No backupRef name",
+				&v1alpha1.BackupVerification{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      key.Name,
+						Namespace: key.Namespace,
+					},
+					Spec: v1alpha1.BackupVerificationSpec{
+						BackupRef: v1alpha1.TypedLocalObjectReference{
+							Kind: v1alpha1.BackupKind,
+						},
+					},
+				},
+				true,
+			),
+			Entry(
+				"Unsupported kind",
+				&v1alpha1.BackupVerification{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      key.Name,
+						Namespace: key.Namespace,
+					},
+					Spec: v1alpha1.BackupVerificationSpec{
+						BackupRef: v1alpha1.TypedLocalObjectReference{
+							Name: "backup",
+							Kind: "Restore",
+						},
+					},
+				},
+				true,
+			),
+			Entry(
+				"PointInTimeRecovery with logical Backup",
+				&v1alpha1.BackupVerification{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      key.Name,
+						Namespace: key.Namespace,
+					},
+					Spec: v1alpha1.BackupVerificationSpec{
+						BackupRef: v1alpha1.TypedLocalObjectReference{
+							Name: "backup",
+							Kind: v1alpha1.BackupKind,
+						},
+						PointInTimeRecoveryRef: &v1alpha1.LocalObjectReference{
+							Name: "pitr",
+						},
+					},
+				},
+				true,
+			),
+			Entry(
+				"Invalid schedule",
+				&v1alpha1.BackupVerification{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      key.Name,
+						Namespace: key.Namespace,
+					},
+					Spec: v1alpha1.BackupVerificationSpec{
+						BackupRef: v1alpha1.TypedLocalObjectReference{
+							Name: "backup",
+						},
+						Schedule: &v1alpha1.Schedule{
+							Cron: "foo",
+						},
+					},
+				},
+				true,
+			),
+			Entry(
+				"Duplicated assertions",
+				&v1alpha1.BackupVerification{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      key.Name,
+						Namespace: key.Namespace,
+					},
+					Spec: v1alpha1.BackupVerificationSpec{
+						BackupRef: v1alpha1.TypedLocalObjectReference{
+							Name: "backup",
+						},
+						Assertions: []v1alpha1.BackupVerificationAssertion{
+							{
+								Name:  "users",
+								Query: "SELECT COUNT(*) FROM users",
+							},
+							{
+								Name:  "users",
+								Query: "SELECT COUNT(*) FROM app.users",
+							},
+						},
+					},
+				},
+				true,
+			),
+			Entry(
+				"Valid",
+				&v1alpha1.BackupVerification{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      key.Name,
+						Namespace: key.Namespace,
+					},
+					Spec: v1alpha1.BackupVerificationSpec{
+						BackupRef: v1alpha1.TypedLocalObjectReference{
+							Name: "physicalbackup",
+							Kind: v1alpha1.PhysicalBackupKind,
+						},
+						PointInTimeRecoveryRef: &v1alpha1.LocalObjectReference{
+							Name: "pitr",
+						},
+						Schedule: &v1alpha1.Schedule{
+							Cron: "0 0 * * *",
+						},
+						Assertions: []v1alpha1.BackupVerificationAssertion{
+							{
+								Name:  "users",
+								Query: "SELECT COUNT(*) FROM users",
+							},
+						},
+					},
+				},
+				false,
+			),
+		)
+	})
+
+	Context("When updating a BackupVerification", Ordered, func() {
+		key := types.NamespacedName{
+			Name:      "backupverification-update",
+			Namespace: testNamespace,
+		}
+		BeforeAll(func() {
+			verification := v1alpha1.BackupVerification{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      key.Name,
+					Namespace: key.Namespace,
+				},
+				Spec: v1alpha1.BackupVerificationSpec{
+					BackupRef: v1alpha1.TypedLocalObjectReference{
+						Name: "backup",
+					},
+				},
+			}
+			Expect(k8sClient.Create(testCtx, &verification)).To(Succeed())
+		})
+
+		DescribeTable(
+			"Should validate",
+			func(patchFn func(verification *v1alpha1.BackupVerification), wantErr bool) {
+				var verification v1alpha1.BackupVerification
+				Expect(k8sClient.Get(testCtx, key, &verification)).To(Succeed())
+
+				patch := client.MergeFrom(verification.DeepCopy())
+				patchFn(&verification)
+
+				err := k8sClient.Patch(testCtx, &verification, patch)
+				if wantErr {
+					Expect(err).To(HaveOccurred())
+				} else {
+					Expect(err).ToNot(HaveOccurred())
+				}
+			},
+			Entry(
+				"Updating backupRef",
+				func(verification *v1alpha1.BackupVerification) {
+					verification.Spec.BackupRef.Name = "another-backup"
+				},
+				true,
+			),
+			Entry(
+				"Updating schedule",
+				func(verification *v1alpha1.BackupVerification) {
+					verification.Spec.Schedule = &v1alpha1.Schedule{
+						Cron: "0 0 * * *",
+					}
+				},
+				false,
+			),
+			Entry(
+				"Adding assertions",
+				func(verification *v1alpha1.BackupVerification) {
+					verification.Spec.Assertions = []v1alpha1.BackupVerificationAssertion{
+						{
+							Name:  "users",
+							Query: "SELECT COUNT(*) FROM users",
+						},
+					}
+				},
+				false,
+			),
+		)
+	})
+})
@@ -0,0 +1,74 @@
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+
+	mariadbv1alpha1 "github.com/mariadb-operator/mariadb-operator/v26/api/v1alpha1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// log is for logging in this package.
+var flashbacklog = logf.Log.WithName("flashback-resource")
+
+// SetupFlashbackWebhookWithManager registers the webhook for Flashback in the manager.
+func SetupFlashbackWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr, &mariadbv1alpha1.Flashback{}).
+		WithValidator(&FlashbackCustomValidator{}).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/validate-k8s-mariadb-com-v1alpha1-flashback,mutating=false,failurePolicy=fail,sideEffects=None,groups=k8s.mariadb.com,resources=flashbacks,verbs=create;update,versions=v1alpha1,name=vflashback-v1alpha1.kb.io,admissionReviewVersions=v1
+
+// FlashbackCustomValidator struct is responsible for validating the Flashback resource
+// when it is created, updated, or deleted.
+type FlashbackCustomValidator struct{}
+
+var _ admission.Validator[*mariadbv1alpha1.Flashback] = &FlashbackCustomValidator{}
+
+// ValidateUpdate implements webhook.CustomValidator so a webhook will be registered for the type Flashback.
+func (v *FlashbackCustomValidator) ValidateUpdate(ctx context.Context,
+	oldFlashback, flashback *mariadbv1alpha1.Flashback) (admission.Warnings, error) {
+	flashbacklog.V(1).Info("Validation for Flashback upon update", "name", flashback.GetName())
+
+	if err := immutableWebhook.ValidateUpdate(flashback, oldFlashback); err != nil {
+		return nil, err
+	}
+	if oldFlashback.Spec.Approved && !flashback.Spec.Approved && oldFlashback.Status.AppliedTime != nil {
+		return nil, field.Invalid(
+			field.NewPath("spec").Child("approved"),
+			flashback.Spec.Approved,
+			"'approved' cannot be unset after the reverse SQL has been applied",
+		)
+	}
+
+	return validateFlashback(flashback)
+}
+
+// ValidateCreate implements webhook.CustomValidator so a webhook will be registered for the type Flashback.
+func (v *FlashbackCustomValidator) ValidateCreate(_ context.Context,
+	flashback *mariadbv1alpha1.Flashback) (admission.Warnings, error) {
+	flashbacklog.Info("Validation for Flashback upon creation", "name", flashback.GetName())
+
+	return validateFlashback(flashback)
+}
+
+// ValidateDelete implements webhook.CustomValidator so a webhook will be registered for the type Flashback.
+func (v *FlashbackCustomValidator) ValidateDelete(ctx context.Context,
+	flashback *mariadbv1alpha1.Flashback) (admission.Warnings, error) {
+	return nil, nil
+}
+
+func validateFlashback(flashback *mariadbv1alpha1.Flashback) (admission.Warnings, error) {
+	if err := flashback.Validate(); err != nil {
+		return nil, field.Invalid(
+			field.NewPath("spec"),
+			flashback.Spec,
+			fmt.Sprintf("invalid Flashback: %v", err),
+		)
+	}
+	return nil, nil
+}
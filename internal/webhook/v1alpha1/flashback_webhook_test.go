@@ -0,0 +1,202 @@
+package v1alpha1
+
+import (
+	"time"
+
+	"github.com/mariadb-operator/mariadb-operator/v26/api/v1alpha1"
+	"github.com/mariadb-operator/mariadb-operator/v26/pkg/replication"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("Flashback Webhook", func() {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	mariadbRef := v1alpha1.MariaDBRef{
+		ObjectReference: v1alpha1.ObjectReference{
+			Name: "mariadb",
+		},
+	}
+	pitrRef := v1alpha1.LocalObjectReference{
+		Name: "pitr",
+	}
+
+	Context("When creating Flashback", func() {
+		key := types.NamespacedName{
+			Name:      "flashback-create",
+			Namespace: testNamespace,
+		}
+		objMeta := metav1.ObjectMeta{
+			Name:      key.Name,
+			Namespace: key.Namespace,
+		}
+
+		DescribeTable(
+			"Should validate",
+			func(flashback *v1alpha1.Flashback, wantErr bool) {
+				_ = k8sClient.Delete(testCtx, flashback)
+				err := k8sClient.Create(testCtx, flashback)
+				if wantErr {
+					Expect(err).To(HaveOccurred())
+				} else {
+					Expect(err).ToNot(HaveOccurred())
+				}
+			},
+			Entry(
+				"No start",
+				&v1alpha1.Flashback{
+					ObjectMeta: objMeta,
+					Spec: v1alpha1.FlashbackSpec{
+						MariaDBRef:             mariadbRef,
+						PointInTimeRecoveryRef: pitrRef,
+						EndTime:                &metav1.Time{Time: now},
+					},
+				},
+				true,
+			),
+			Entry(
+				"Both startTime and startGtid",
+				&v1alpha1.Flashback{
+					ObjectMeta: objMeta,
+					Spec: v1alpha1.FlashbackSpec{
+						MariaDBRef:             mariadbRef,
+						PointInTimeRecoveryRef: pitrRef,
+						StartTime:              &metav1.Time{Time: now.Add(-time.Hour)},
+						StartGtid:              &replication.Gtid{DomainID: 0, ServerID: 10, SequenceID: 5},
+						EndTime:                &metav1.Time{Time: now},
+					},
+				},
+				true,
+			),
+			Entry(
+				"startTime after endTime",
+				&v1alpha1.Flashback{
+					ObjectMeta: objMeta,
+					Spec: v1alpha1.FlashbackSpec{
+						MariaDBRef:             mariadbRef,
+						PointInTimeRecoveryRef: pitrRef,
+						StartTime:              &metav1.Time{Time: now},
+						EndTime:                &metav1.Time{Time: now.Add(-time.Hour)},
+					},
+				},
+				true,
+			),
+			Entry(
+				"Table without database",
+				&v1alpha1.Flashback{
+					ObjectMeta: objMeta,
+					Spec: v1alpha1.FlashbackSpec{
+						MariaDBRef:             mariadbRef,
+						PointInTimeRecoveryRef: pitrRef,
+						StartTime:              &metav1.Time{Time: now.Add(-time.Hour)},
+						EndTime:                &metav1.Time{Time: now},
+						Table:                  ptr.To("users"),
+					},
+				},
+				true,
+			),
+			Entry(
+				"Valid time window",
+				&v1alpha1.Flashback{
+					ObjectMeta: objMeta,
+					Spec: v1alpha1.FlashbackSpec{
+						MariaDBRef:             mariadbRef,
+						PointInTimeRecoveryRef: pitrRef,
+						StartTime:              &metav1.Time{Time: now.Add(-time.Hour)},
+						EndTime:                &metav1.Time{Time: now},
+						Database:               ptr.To("app"),
+						Table:                  ptr.To("users"),
+						Output: v1alpha1.FlashbackOutput{
+							Kind: v1alpha1.FlashbackOutputSecret,
+						},
+					},
+				},
+				false,
+			),
+			Entry(
+				"Valid GTID window",
+				&v1alpha1.Flashback{
+					ObjectMeta: objMeta,
+					Spec: v1alpha1.FlashbackSpec{
+						MariaDBRef:             mariadbRef,
+						PointInTimeRecoveryRef: pitrRef,
+						StartGtid:              &replication.Gtid{DomainID: 0, ServerID: 10, SequenceID: 5},
+						EndGtid:                &replication.Gtid{DomainID: 0, ServerID: 10, SequenceID: 42},
+					},
+				},
+				false,
+			),
+		)
+	})
+
+	Context("When updating a Flashback", Ordered, func() {
+		key := types.NamespacedName{
+			Name:      "flashback-update",
+			Namespace: testNamespace,
+		}
+		BeforeAll(func() {
+			flashback := v1alpha1.Flashback{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      key.Name,
+					Namespace: key.Namespace,
+				},
+				Spec: v1alpha1.FlashbackSpec{
+					MariaDBRef:             mariadbRef,
+					PointInTimeRecoveryRef: pitrRef,
+					StartTime:              &metav1.Time{Time: now.Add(-time.Hour)},
+					EndTime:                &metav1.Time{Time: now},
+				},
+			}
+			Expect(k8sClient.Create(testCtx, &flashback)).To(Succeed())
+		})
+
+		DescribeTable(
+			"Should validate",
+			func(patchFn func(flashback *v1alpha1.Flashback), wantErr bool) {
+				var flashback v1alpha1.Flashback
+				Expect(k8sClient.Get(testCtx, key, &flashback)).To(Succeed())
+
+				patch := client.MergeFrom(flashback.DeepCopy())
+				patchFn(&flashback)
+
+				err := k8sClient.Patch(testCtx, &flashback, patch)
+				if wantErr {
+					Expect(err).To(HaveOccurred())
+				} else {
+					Expect(err).ToNot(HaveOccurred())
+				}
+			},
+			Entry(
+				"Updating mariaDbRef",
+				func(flashback *v1alpha1.Flashback) {
+					flashback.Spec.MariaDBRef.Name = "another-mariadb"
+				},
+				true,
+			),
+			Entry(
+				"Updating window",
+				func(flashback *v1alpha1.Flashback) {
+					flashback.Spec.StartTime = &metav1.Time{Time: now.Add(-2 * time.Hour)}
+				},
+				true,
+			),
+			Entry(
+				"Updating output",
+				func(flashback *v1alpha1.Flashback) {
+					flashback.Spec.Output.Kind = v1alpha1.FlashbackOutputSecret
+				},
+				true,
+			),
+			Entry(
+				"Approving",
+				func(flashback *v1alpha1.Flashback) {
+					flashback.Spec.Approved = true
+				},
+				false,
+			),
+		)
+	})
+})
@@ -4,6 +4,7 @@ import (
 	"time"
 
 	"github.com/mariadb-operator/mariadb-operator/v26/api/v1alpha1"
+	"github.com/mariadb-operator/mariadb-operator/v26/pkg/replication"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	corev1 "k8s.io/api/core/v1"
@@ -77,6 +78,88 @@ var _ = Describe("v1alpha1.MariaDB webhook", func() {
 				},
 				true,
 			),
+			Entry(
+				"Dry run BootstrapFrom",
+				&v1alpha1.MariaDB{
+					ObjectMeta: meta,
+					Spec: v1alpha1.MariaDBSpec{
+						BootstrapFrom: &v1alpha1.BootstrapFrom{
+							BackupRef: &v1alpha1.TypedLocalObjectReference{
+								Name: "backup-webhook",
+							},
+							DryRun: true,
+						},
+						Storage: v1alpha1.Storage{
+							Size: ptr.To(resource.MustParse("100Mi")),
+						},
+					},
+				},
+				false,
+			),
+			Entry(
+				"Target recovery GTID BootstrapFrom",
+				&v1alpha1.MariaDB{
+					ObjectMeta: meta,
+					Spec: v1alpha1.MariaDBSpec{
+						BootstrapFrom: &v1alpha1.BootstrapFrom{
+							BackupRef: &v1alpha1.TypedLocalObjectReference{
+								Name: "backup-webhook",
+							},
+							TargetRecoveryGtid: &replication.Gtid{
+								DomainID:   0,
+								ServerID:   10,
+								SequenceID: 42,
+							},
+							StopBeforeTargetRecoveryGtid: true,
+						},
+						Storage: v1alpha1.Storage{
+							Size: ptr.To(resource.MustParse("100Mi")),
+						},
+					},
+				},
+				false,
+			),
+			Entry(
+				"Invalid target recovery GTID BootstrapFrom",
+				&v1alpha1.MariaDB{
+					ObjectMeta: meta,
+					Spec: v1alpha1.MariaDBSpec{
+						BootstrapFrom: &v1alpha1.BootstrapFrom{
+							BackupRef: &v1alpha1.TypedLocalObjectReference{
+								Name: "backup-webhook",
+							},
+							TargetRecoveryGtid: &replication.Gtid{
+								DomainID:   0,
+								ServerID:   10,
+								SequenceID: 0,
+							},
+							StopBeforeTargetRecoveryGtid: true,
+						},
+						Storage: v1alpha1.Storage{
+							Size: ptr.To(resource.MustParse("100Mi")),
+						},
+					},
+				},
+				true,
+			),
+			Entry(
+				"Invalid dry run BootstrapFrom",
+				&v1alpha1.MariaDB{
+					ObjectMeta: meta,
+					Spec: v1alpha1.MariaDBSpec{
+						BootstrapFrom: &v1alpha1.BootstrapFrom{
+							Volume: &v1alpha1.StorageVolumeSource{
+								EmptyDir: &v1alpha1.EmptyDirVolumeSource{},
+							},
+							DryRun: true,
+						},
+						Storage: v1alpha1.Storage{
+							Size: ptr.To(resource.MustParse("100Mi")),
+						},
+					},
+				},
+				true,
+			),
 			Entry(
 				"Valid Galera",
 				&v1alpha1.MariaDB{
@@ -439,6 +439,62 @@ var _ = Describe("PhysicalBackup webhook", func() {
 				},
 				true,
 			),
+			Entry(
+				"Invalid incremental with volume snapshot",
+				&v1alpha1.PhysicalBackup{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "physicalbackup-invalid-incremental-volumesnapshot",
+						Namespace: testNamespace,
+					},
+					Spec: v1alpha1.PhysicalBackupSpec{
+						Storage: v1alpha1.PhysicalBackupStorage{
+							VolumeSnapshot: &v1alpha1.PhysicalBackupVolumeSnapshot{
+								VolumeSnapshotClassName: "test",
+							},
+						},
+						Incremental: &v1alpha1.PhysicalBackupIncremental{
+							FullCron: "0 0 * * 0",
+						},
+						MariaDBRef: v1alpha1.MariaDBRef{
+							ObjectReference: v1alpha1.ObjectReference{
+								Name: "mariadb-webhook",
+							},
+							WaitForIt: true,
+						},
+					},
+				},
+				true,
+			),
+			Entry(
+				"Invalid incremental full cron",
+				&v1alpha1.PhysicalBackup{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "physicalbackup-invalid-incremental-cron",
+						Namespace: testNamespace,
+					},
+					Spec: v1alpha1.PhysicalBackupSpec{
+						Schedule: &v1alpha1.PhysicalBackupSchedule{
+							Cron: "0 * * * *",
+						},
+						Compression: v1alpha1.CompressGzip,
+						Storage: v1alpha1.PhysicalBackupStorage{
+							Volume: &v1alpha1.StorageVolumeSource{
+								EmptyDir: &v1alpha1.EmptyDirVolumeSource{},
+							},
+						},
+						Incremental: &v1alpha1.PhysicalBackupIncremental{
+							FullCron: "foo",
+						},
+						MariaDBRef: v1alpha1.MariaDBRef{
+							ObjectReference: v1alpha1.ObjectReference{
+								Name: "mariadb-webhook",
+							},
+							WaitForIt: true,
+						},
+					},
+				},
+				true,
+			),
 			Entry(
 				"Invalid staging storage",
 				&v1alpha1.PhysicalBackup{
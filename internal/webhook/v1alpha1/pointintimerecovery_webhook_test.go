@@ -1,9 +1,13 @@
 package v1alpha1
 
 import (
+	"time"
+
 	"github.com/mariadb-operator/mariadb-operator/v26/api/v1alpha1"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -35,6 +39,9 @@ var _ = Describe("PointInTimeRecovery Webhook", func() {
 						Namespace: key.Namespace,
 					},
 					Spec: v1alpha1.PointInTimeRecoverySpec{
+						PhysicalBackupRef: &v1alpha1.LocalObjectReference{
+							Name: "physicalbackup",
+						},
 						Compression: v1alpha1.CompressGzip,
 					},
 				},
@@ -49,6 +56,9 @@ var _ = Describe("PointInTimeRecovery Webhook", func() {
 						Namespace: key.Namespace,
 					},
 					Spec: v1alpha1.PointInTimeRecoverySpec{
+						PhysicalBackupRef: &v1alpha1.LocalObjectReference{
+							Name: "physicalbackup",
+						},
 						Compression: v1alpha1.CompressGzip,
 						PointInTimeRecoveryStorage: v1alpha1.PointInTimeRecoveryStorage{
 							S3:        &v1alpha1.S3{},
@@ -67,6 +77,9 @@ var _ = Describe("PointInTimeRecovery Webhook", func() {
 						Namespace: key.Namespace,
 					},
 					Spec: v1alpha1.PointInTimeRecoverySpec{
+						PhysicalBackupRef: &v1alpha1.LocalObjectReference{
+							Name: "physicalbackup",
+						},
 						Compression: v1alpha1.CompressGzip,
 						PointInTimeRecoveryStorage: v1alpha1.PointInTimeRecoveryStorage{
 							S3: &v1alpha1.S3{},
@@ -84,6 +97,9 @@ var _ = Describe("PointInTimeRecovery Webhook", func() {
 						Namespace: key.Namespace,
 					},
 					Spec: v1alpha1.PointInTimeRecoverySpec{
+						PhysicalBackupRef: &v1alpha1.LocalObjectReference{
+							Name: "physicalbackup",
+						},
 						Compression: v1alpha1.CompressGzip,
 						PointInTimeRecoveryStorage: v1alpha1.PointInTimeRecoveryStorage{
 							AzureBlob: &v1alpha1.AzureBlob{},
@@ -92,6 +108,327 @@ var _ = Describe("PointInTimeRecovery Webhook", func() {
 				},
 				false,
 			),
+
+			Entry(
+				"With PVC",
+				&v1alpha1.PointInTimeRecovery{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      key.Name,
+						Namespace: key.Namespace,
+					},
+					Spec: v1alpha1.PointInTimeRecoverySpec{
+						PhysicalBackupRef: &v1alpha1.LocalObjectReference{
+							Name: "physicalbackup",
+						},
+						Compression: v1alpha1.CompressGzip,
+						PointInTimeRecoveryStorage: v1alpha1.PointInTimeRecoveryStorage{
+							PersistentVolumeClaim: &v1alpha1.PersistentVolumeClaimSpec{
+								AccessModes: []corev1.PersistentVolumeAccessMode{
+									corev1.ReadWriteMany,
+								},
+								Resources: corev1.VolumeResourceRequirements{
+									Requests: corev1.ResourceList{
+										corev1.ResourceStorage: resource.MustParse("1Gi"),
+									},
+								},
+							},
+						},
+					},
+				},
+				false,
+			),
+
+			Entry(
+				"With PVC secondary storage",
+				&v1alpha1.PointInTimeRecovery{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      key.Name,
+						Namespace: key.Namespace,
+					},
+					Spec: v1alpha1.PointInTimeRecoverySpec{
+						PhysicalBackupRef: &v1alpha1.LocalObjectReference{
+							Name: "physicalbackup",
+						},
+						Compression: v1alpha1.CompressGzip,
+						PointInTimeRecoveryStorage: v1alpha1.PointInTimeRecoveryStorage{
+							S3: &v1alpha1.S3{
+								Bucket:   "test",
+								Endpoint: "test",
+							},
+						},
+						SecondaryStorages: []v1alpha1.SecondaryStorage{
+							{
+								Name: "pvc",
+								PersistentVolumeClaim: &v1alpha1.PersistentVolumeClaimVolumeSource{
+									ClaimName: "binlogs",
+								},
+							},
+						},
+					},
+				},
+				false,
+			),
+
+			Entry(
+				"With secondary storage retention",
+				&v1alpha1.PointInTimeRecovery{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      key.Name,
+						Namespace: key.Namespace,
+					},
+					Spec: v1alpha1.PointInTimeRecoverySpec{
+						PhysicalBackupRef: &v1alpha1.LocalObjectReference{
+							Name: "physicalbackup",
+						},
+						Compression: v1alpha1.CompressGzip,
+						PointInTimeRecoveryStorage: v1alpha1.PointInTimeRecoveryStorage{
+							S3: &v1alpha1.S3{
+								Bucket:   "test",
+								Endpoint: "test",
+							},
+						},
+						SecondaryStorages: []v1alpha1.SecondaryStorage{
+							{
+								Name: "pvc",
+								PersistentVolumeClaim: &v1alpha1.PersistentVolumeClaimVolumeSource{
+									ClaimName: "binlogs",
+								},
+								Retention: &v1alpha1.RetentionPolicy{
+									KeepDaily: 7,
+								},
+							},
+						},
+					},
+				},
+				false,
+			),
+
+			Entry(
+				"Both PVC and volume",
+				&v1alpha1.PointInTimeRecovery{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      key.Name,
+						Namespace: key.Namespace,
+					},
+					Spec: v1alpha1.PointInTimeRecoverySpec{
+						PhysicalBackupRef: &v1alpha1.LocalObjectReference{
+							Name: "physicalbackup",
+						},
+						Compression: v1alpha1.CompressGzip,
+						PointInTimeRecoveryStorage: v1alpha1.PointInTimeRecoveryStorage{
+							PersistentVolumeClaim: &v1alpha1.PersistentVolumeClaimSpec{},
+							Volume: &v1alpha1.StorageVolumeSource{
+								EmptyDir: &v1alpha1.EmptyDirVolumeSource{},
+							},
+						},
+					},
+				},
+				true,
+			),
+
+			Entry(
+				"With streaming",
+				&v1alpha1.PointInTimeRecovery{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      key.Name,
+						Namespace: key.Namespace,
+					},
+					Spec: v1alpha1.PointInTimeRecoverySpec{
+						PhysicalBackupRef: &v1alpha1.LocalObjectReference{
+							Name: "physicalbackup",
+						},
+						Compression: v1alpha1.CompressGzip,
+						PointInTimeRecoveryStorage: v1alpha1.PointInTimeRecoveryStorage{
+							S3: &v1alpha1.S3{},
+						},
+						Streaming: &v1alpha1.BinlogStreaming{
+							Enabled:       true,
+							FlushInterval: &metav1.Duration{Duration: 5 * time.Second},
+						},
+					},
+				},
+				false,
+			),
+
+			Entry(
+				"Streaming with too short flush interval",
+				&v1alpha1.PointInTimeRecovery{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      key.Name,
+						Namespace: key.Namespace,
+					},
+					Spec: v1alpha1.PointInTimeRecoverySpec{
+						PhysicalBackupRef: &v1alpha1.LocalObjectReference{
+							Name: "physicalbackup",
+						},
+						Compression: v1alpha1.CompressGzip,
+						PointInTimeRecoveryStorage: v1alpha1.PointInTimeRecoveryStorage{
+							S3: &v1alpha1.S3{},
+						},
+						Streaming: &v1alpha1.BinlogStreaming{
+							Enabled:       true,
+							FlushInterval: &metav1.Duration{Duration: 100 * time.Millisecond},
+						},
+					},
+				},
+				true,
+			),
+
+			Entry(
+				"With CDC",
+				&v1alpha1.PointInTimeRecovery{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      key.Name,
+						Namespace: key.Namespace,
+					},
+					Spec: v1alpha1.PointInTimeRecoverySpec{
+						PhysicalBackupRef: &v1alpha1.LocalObjectReference{
+							Name: "physicalbackup",
+						},
+						Compression: v1alpha1.CompressGzip,
+						PointInTimeRecoveryStorage: v1alpha1.PointInTimeRecoveryStorage{
+							S3: &v1alpha1.S3{},
+						},
+						CDC: &v1alpha1.BinlogCDC{
+							Enabled: true,
+							Prefix:  "exports/cdc",
+						},
+					},
+				},
+				false,
+			),
+
+			Entry(
+				"CDC with binlog prefix",
+				&v1alpha1.PointInTimeRecovery{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      key.Name,
+						Namespace: key.Namespace,
+					},
+					Spec: v1alpha1.PointInTimeRecoverySpec{
+						PhysicalBackupRef: &v1alpha1.LocalObjectReference{
+							Name: "physicalbackup",
+						},
+						Compression: v1alpha1.CompressGzip,
+						PointInTimeRecoveryStorage: v1alpha1.PointInTimeRecoveryStorage{
+							S3: &v1alpha1.S3{},
+						},
+						CDC: &v1alpha1.BinlogCDC{
+							Enabled: true,
+							Prefix:  "exports/server-10",
+						},
+					},
+				},
+				true,
+			),
+
+			Entry(
+				"With PhysicalBackup retention",
+				&v1alpha1.PointInTimeRecovery{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      key.Name,
+						Namespace: key.Namespace,
+					},
+					Spec: v1alpha1.PointInTimeRecoverySpec{
+						PhysicalBackupRef: &v1alpha1.LocalObjectReference{
+							Name: "physicalbackup",
+						},
+						Compression: v1alpha1.CompressGzip,
+						PointInTimeRecoveryStorage: v1alpha1.PointInTimeRecoveryStorage{
+							S3: &v1alpha1.S3{},
+						},
+						Retention: &v1alpha1.BinlogRetention{
+							Mode:         v1alpha1.BinlogRetentionModePhysicalBackup,
+							SafetyMargin: &metav1.Duration{Duration: 2 * time.Hour},
+						},
+					},
+				},
+				false,
+			),
+
+			Entry(
+				"With logical Backup",
+				&v1alpha1.PointInTimeRecovery{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      key.Name,
+						Namespace: key.Namespace,
+					},
+					Spec: v1alpha1.PointInTimeRecoverySpec{
+						BackupRef: &v1alpha1.LocalObjectReference{
+							Name: "backup",
+						},
+						Compression: v1alpha1.CompressGzip,
+						PointInTimeRecoveryStorage: v1alpha1.PointInTimeRecoveryStorage{
+							S3: &v1alpha1.S3{},
+						},
+					},
+				},
+				false,
+			),
+
+			Entry(
+				"Both PhysicalBackup and Backup",
+				&v1alpha1.PointInTimeRecovery{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      key.Name,
+						Namespace: key.Namespace,
+					},
+					Spec: v1alpha1.PointInTimeRecoverySpec{
+						PhysicalBackupRef: &v1alpha1.LocalObjectReference{
+							Name: "physicalbackup",
+						},
+						BackupRef: &v1alpha1.LocalObjectReference{
+							Name: "backup",
+						},
+						Compression: v1alpha1.CompressGzip,
+						PointInTimeRecoveryStorage: v1alpha1.PointInTimeRecoveryStorage{
+							S3: &v1alpha1.S3{},
+						},
+					},
+				},
+				true,
+			),
+
+			Entry(
+				"No base backup",
+				&v1alpha1.PointInTimeRecovery{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      key.Name,
+						Namespace: key.Namespace,
+					},
+					Spec: v1alpha1.PointInTimeRecoverySpec{
+						Compression: v1alpha1.CompressGzip,
+						PointInTimeRecoveryStorage: v1alpha1.PointInTimeRecoveryStorage{
+							S3: &v1alpha1.S3{},
+						},
+					},
+				},
+				true,
+			),
+
+			Entry(
+				"Retention with negative safety margin",
+				&v1alpha1.PointInTimeRecovery{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      key.Name,
+						Namespace: key.Namespace,
+					},
+					Spec: v1alpha1.PointInTimeRecoverySpec{
+						PhysicalBackupRef: &v1alpha1.LocalObjectReference{
+							Name: "physicalbackup",
+						},
+						Compression: v1alpha1.CompressGzip,
+						PointInTimeRecoveryStorage: v1alpha1.PointInTimeRecoveryStorage{
+							S3: &v1alpha1.S3{},
+						},
+						Retention: &v1alpha1.BinlogRetention{
+							Mode:         v1alpha1.BinlogRetentionModePhysicalBackup,
+							SafetyMargin: &metav1.Duration{Duration: -time.Hour},
+						},
+					},
+				},
+				true,
+			),
 		)
 	})
 
@@ -107,6 +444,9 @@ var _ = Describe("PointInTimeRecovery Webhook", func() {
 					Namespace: key.Namespace,
 				},
 				Spec: v1alpha1.PointInTimeRecoverySpec{
+					PhysicalBackupRef: &v1alpha1.LocalObjectReference{
+						Name: "physicalbackup",
+					},
 					Compression: v1alpha1.CompressGzip,
 					PointInTimeRecoveryStorage: v1alpha1.PointInTimeRecoveryStorage{
 						S3: &v1alpha1.S3{},
@@ -153,6 +493,13 @@ var _ = Describe("PointInTimeRecovery Webhook", func() {
 				},
 				true,
 			),
+			Entry(
+				"Removing base backup",
+				func(pitr *v1alpha1.PointInTimeRecovery) {
+					pitr.Spec.PhysicalBackupRef = nil
+				},
+				true,
+			),
 			Entry(
 				"No storage",
 				func(pitr *v1alpha1.PointInTimeRecovery) {
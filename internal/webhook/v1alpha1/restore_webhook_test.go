@@ -4,6 +4,7 @@ import (
 	"time"
 
 	"github.com/mariadb-operator/mariadb-operator/v26/api/v1alpha1"
+	"github.com/mariadb-operator/mariadb-operator/v26/pkg/replication"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	corev1 "k8s.io/api/core/v1"
@@ -168,6 +169,204 @@ var _ = Describe("Restore webhook", func() {
 				},
 				false,
 			),
+			Entry(
+				"Invalid table pattern",
+				&v1alpha1.Restore{
+					ObjectMeta: objMeta,
+					Spec: v1alpha1.RestoreSpec{
+						RestoreSource: v1alpha1.RestoreSource{
+							BackupRef: &v1alpha1.LocalObjectReference{
+								Name: "backup-webhook",
+							},
+						},
+						MariaDBRef: v1alpha1.MariaDBRef{
+							ObjectReference: v1alpha1.ObjectReference{
+								Name: "mariadb-webhook",
+							},
+							WaitForIt: true,
+						},
+						Tables: []string{
+							"db.users",
+							"db.users; DROP DATABASE db",
+						},
+						BackoffLimit: 10,
+					},
+				},
+				true,
+			),
+			Entry(
+				"Dry run with S3 source",
+				&v1alpha1.Restore{
+					ObjectMeta: objMeta,
+					Spec: v1alpha1.RestoreSpec{
+						RestoreSource: v1alpha1.RestoreSource{
+							S3: &v1alpha1.S3{
+								Bucket:   "test",
+								Endpoint: "test",
+							},
+						},
+						MariaDBRef: v1alpha1.MariaDBRef{
+							ObjectReference: v1alpha1.ObjectReference{
+								Name: "mariadb-webhook",
+							},
+							WaitForIt: true,
+						},
+						BackoffLimit: 10,
+						DryRun:       true,
+					},
+				},
+				false,
+			),
+			Entry(
+				"Target recovery GTID",
+				&v1alpha1.Restore{
+					ObjectMeta: objMeta,
+					Spec: v1alpha1.RestoreSpec{
+						RestoreSource: v1alpha1.RestoreSource{
+							S3: &v1alpha1.S3{
+								Bucket:   "test",
+								Endpoint: "test",
+							},
+							TargetRecoveryGtid: &replication.Gtid{
+								DomainID:   0,
+								ServerID:   10,
+								SequenceID: 42,
+							},
+							StopBeforeTargetRecoveryGtid: true,
+						},
+						MariaDBRef: v1alpha1.MariaDBRef{
+							ObjectReference: v1alpha1.ObjectReference{
+								Name: "mariadb-webhook",
+							},
+							WaitForIt: true,
+						},
+						BackoffLimit: 10,
+					},
+				},
+				false,
+			),
+			Entry(
+				"Target recovery GTID and time",
+				&v1alpha1.Restore{
+					ObjectMeta: objMeta,
+					Spec: v1alpha1.RestoreSpec{
+						RestoreSource: v1alpha1.RestoreSource{
+							S3: &v1alpha1.S3{
+								Bucket:   "test",
+								Endpoint: "test",
+							},
+							TargetRecoveryTime: &metav1.Time{Time: time.Now()},
+							TargetRecoveryGtid: &replication.Gtid{
+								DomainID:   0,
+								ServerID:   10,
+								SequenceID: 42,
+							},
+						},
+						MariaDBRef: v1alpha1.MariaDBRef{
+							ObjectReference: v1alpha1.ObjectReference{
+								Name: "mariadb-webhook",
+							},
+							WaitForIt: true,
+						},
+						BackoffLimit: 10,
+					},
+				},
+				true,
+			),
+			Entry(
+				"Stop before target recovery GTID without GTID",
+				&v1alpha1.Restore{
+					ObjectMeta: objMeta,
+					Spec: v1alpha1.RestoreSpec{
+						RestoreSource: v1alpha1.RestoreSource{
+							S3: &v1alpha1.S3{
+								Bucket:   "test",
+								Endpoint: "test",
+							},
+							StopBeforeTargetRecoveryGtid: true,
+						},
+						MariaDBRef: v1alpha1.MariaDBRef{
+							ObjectReference: v1alpha1.ObjectReference{
+								Name: "mariadb-webhook",
+							},
+							WaitForIt: true,
+						},
+						BackoffLimit: 10,
+					},
+				},
+				true,
+			),
+			Entry(
+				"Dry run with Volume source",
+				&v1alpha1.Restore{
+					ObjectMeta: objMeta,
+					Spec: v1alpha1.RestoreSpec{
+						RestoreSource: v1alpha1.RestoreSource{
+							Volume: &v1alpha1.StorageVolumeSource{
+								EmptyDir: &v1alpha1.EmptyDirVolumeSource{},
+							},
+						},
+						MariaDBRef: v1alpha1.MariaDBRef{
+							ObjectReference: v1alpha1.ObjectReference{
+								Name: "mariadb-webhook",
+							},
+							WaitForIt: true,
+						},
+						BackoffLimit: 10,
+						DryRun:       true,
+					},
+				},
+				true,
+			),
+			Entry(
+				"Point-in-time recovery",
+				&v1alpha1.Restore{
+					ObjectMeta: objMeta,
+					Spec: v1alpha1.RestoreSpec{
+						RestoreSource: v1alpha1.RestoreSource{
+							BackupRef: &v1alpha1.LocalObjectReference{
+								Name: "backup-webhook",
+							},
+						},
+						PointInTimeRecoveryRef: &v1alpha1.LocalObjectReference{
+							Name: "pitr-webhook",
+						},
+						MariaDBRef: v1alpha1.MariaDBRef{
+							ObjectReference: v1alpha1.ObjectReference{
+								Name: "mariadb-webhook",
+							},
+							WaitForIt: true,
+						},
+						BackoffLimit: 10,
+					},
+				},
+				false,
+			),
+			Entry(
+				"Point-in-time recovery with tables",
+				&v1alpha1.Restore{
+					ObjectMeta: objMeta,
+					Spec: v1alpha1.RestoreSpec{
+						RestoreSource: v1alpha1.RestoreSource{
+							BackupRef: &v1alpha1.LocalObjectReference{
+								Name: "backup-webhook",
+							},
+						},
+						PointInTimeRecoveryRef: &v1alpha1.LocalObjectReference{
+							Name: "pitr-webhook",
+						},
+						Tables: []string{"db.users"},
+						MariaDBRef: v1alpha1.MariaDBRef{
+							ObjectReference: v1alpha1.ObjectReference{
+								Name: "mariadb-webhook",
+							},
+							WaitForIt: true,
+						},
+						BackoffLimit: 10,
+					},
+				},
+				true,
+			),
 			Entry(
 				"S3 and staging storage",
 				&v1alpha1.Restore{
@@ -122,6 +122,12 @@ var _ = BeforeSuite(func() {
 	err = SetupPointInTimeRecoveryWebhookWithManager(mgr)
 	Expect(err).NotTo(HaveOccurred())
 
+	err = SetupBackupVerificationWebhookWithManager(mgr)
+	Expect(err).NotTo(HaveOccurred())
+
+	err = SetupFlashbackWebhookWithManager(mgr)
+	Expect(err).NotTo(HaveOccurred())
+
 	err = SetupConnectionWebhookWithManager(mgr)
 	Expect(err).NotTo(HaveOccurred())
 
@@ -1,9 +1,11 @@
 package azure
 
 import (
+	"bytes"
 	"context"
 	"crypto/tls"
 	"crypto/x509"
+	"encoding/base64"
 	"errors"
 	"fmt"
 	"io"
@@ -15,9 +17,17 @@ import (
 
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/streaming"
 	"github.com/Azure/azure-sdk-for-go/sdk/azidentity"
 	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/blob"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/blockblob"
 	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/container"
+	mariadbv1alpha1 "github.com/mariadb-operator/mariadb-operator/v26/api/v1alpha1"
+	"github.com/mariadb-operator/mariadb-operator/v26/pkg/interfaces"
+	"github.com/mariadb-operator/mariadb-operator/v26/pkg/multipart"
+	"github.com/mariadb-operator/mariadb-operator/v26/pkg/progress"
+	"github.com/mariadb-operator/mariadb-operator/v26/pkg/ratelimit"
 	"k8s.io/utils/ptr"
 )
 
@@ -29,11 +39,16 @@ type AzBlobOpts struct {
 
 	Prefix              string // A prefix relative to the container root to be applied to blob names. Perform All operations under here
 	AllowNestedPrefixes bool
+	Metadata            map[string]string // Metadata to be added to the uploaded blobs
 
 	// TLS Opts
 	TLSEnabled    bool
 	TLSCACert     []byte
 	TLSCACertPath string
+
+	RateLimiter     *ratelimit.Limiter          // Limits the throughput of uploads and downloads
+	ProgressTracker *progress.Tracker           // Keeps track of the bytes uploaded and downloaded
+	ObjectLock      *mariadbv1alpha1.ObjectLock // Immutability policy to be applied to the uploaded blobs
 }
 
 type AzBlobOpt func(o *AzBlobOpts)
@@ -56,6 +71,12 @@ func WithAllowNestedPrefixes(allowNestedPrefixes bool) AzBlobOpt {
 	}
 }
 
+func WithMetadata(metadata map[string]string) AzBlobOpt {
+	return func(o *AzBlobOpts) {
+		o.Metadata = metadata
+	}
+}
+
 func WithAccountName(accountName string) AzBlobOpt {
 	return func(o *AzBlobOpts) {
 		o.AccountName = accountName
@@ -86,6 +107,24 @@ func WithTLSCACertBytes(tlsCACert []byte) AzBlobOpt {
 	}
 }
 
+func WithRateLimiter(limiter *ratelimit.Limiter) AzBlobOpt {
+	return func(o *AzBlobOpts) {
+		o.RateLimiter = limiter
+	}
+}
+
+func WithProgressTracker(tracker *progress.Tracker) AzBlobOpt {
+	return func(o *AzBlobOpts) {
+		o.ProgressTracker = tracker
+	}
+}
+
+func WithObjectLock(objectLock *mariadbv1alpha1.ObjectLock) AzBlobOpt {
+	return func(o *AzBlobOpts) {
+		o.ObjectLock = objectLock
+	}
+}
+
 type AzBlobClient struct {
 	*azblob.Client
 
@@ -151,8 +190,56 @@ func NewAzBlobClient(basePath, containerName, serviceURL string, azOpts ...AzBlo
 // PutObjectWithOptions will upload the given reader to Azure
 // `size` is ignored and is passed to satisfy the interface
 func (c *AzBlobClient) PutObjectWithOptions(ctx context.Context, fileName string, reader io.Reader, size int64) error {
-	_, err := c.UploadStream(ctx, c.ContainerName, c.PrefixedFileName(fileName), reader, nil)
+	var uploadOpts *azblob.UploadStreamOptions
+	if len(c.Opts.Metadata) > 0 {
+		uploadOpts = &azblob.UploadStreamOptions{
+			Metadata: c.metadata(),
+		}
+	}
+	if _, err := c.UploadStream(ctx, c.ContainerName, c.PrefixedFileName(fileName), reader, uploadOpts); err != nil {
+		return err
+	}
+	// Stream uploads do not support setting the immutability policy, it must be set once the blob has been committed.
+	return c.setImmutabilityPolicy(ctx, fileName)
+}
+
+// PutObjectStreamWithOptions uploads the given reader as a block blob, staging each block independently and committing them at the end.
+// Blocks are retried individually, and uncommitted blocks are garbage collected by Azure if the upload does not complete.
+func (c *AzBlobClient) PutObjectStreamWithOptions(ctx context.Context, fileName string, reader io.Reader,
+	opts interfaces.StreamOpts) error {
+	blobClient := c.ServiceClient().
+		NewContainerClient(c.ContainerName).
+		NewBlockBlobClient(c.PrefixedFileName(fileName))
+
+	var blockIDs []string
+	_, err := multipart.Upload(ctx, reader, opts, func(ctx context.Context, part multipart.Part) error {
+		if len(part.Data) == 0 {
+			return nil
+		}
+		// Block IDs must have the same length within a blob.
+		blockID := base64.StdEncoding.EncodeToString([]byte(fmt.Sprintf("%010d", part.Number)))
+		if _, err := blobClient.StageBlock(ctx, blockID, streaming.NopCloser(bytes.NewReader(part.Data)), nil); err != nil {
+			return err
+		}
+		blockIDs = append(blockIDs, blockID)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
 
+	commitOpts := &blockblob.CommitBlockListOptions{}
+	if len(c.Opts.Metadata) > 0 {
+		commitOpts.Metadata = c.metadata()
+	}
+	if lock := c.Opts.ObjectLock; lock != nil {
+		commitOpts.ImmutabilityPolicyMode = ptr.To(immutabilityPolicyMode(lock.ModeOrDefault()))
+		commitOpts.ImmutabilityPolicyExpiryTime = ptr.To(time.Now().Add(lock.RetainUntil.Duration))
+		if lock.LegalHold {
+			commitOpts.LegalHold = ptr.To(true)
+		}
+	}
+	_, err = blobClient.CommitBlockList(ctx, blockIDs, commitOpts)
 	return err
 }
 
@@ -208,6 +295,20 @@ func (c *AzBlobClient) RemoveWithOptions(ctx context.Context, fileName string) e
 	return err
 }
 
+// IsLocked determines whether the blob is protected by an unexpired immutability policy or by a legal hold.
+func (c *AzBlobClient) IsLocked(ctx context.Context, fileName string) (bool, error) {
+	props, err := c.ServiceClient().
+		NewContainerClient(c.ContainerName).
+		NewBlobClient(c.PrefixedFileName(fileName)).GetProperties(ctx, nil)
+	if err != nil {
+		return false, ignoreNotFound(err)
+	}
+	if ptr.Deref(props.LegalHold, false) {
+		return true, nil
+	}
+	return props.ImmutabilityPolicyExpiresOn != nil && props.ImmutabilityPolicyExpiresOn.After(time.Now()), nil
+}
+
 func (c *AzBlobClient) Exists(ctx context.Context, fileName string) (bool, error) {
 	_, err := c.ServiceClient().
 		NewContainerClient(c.ContainerName).
@@ -220,6 +321,16 @@ func (c *AzBlobClient) Exists(ctx context.Context, fileName string) (bool, error
 	return true, nil
 }
 
+func (c *AzBlobClient) Size(ctx context.Context, fileName string) (int64, error) {
+	props, err := c.ServiceClient().
+		NewContainerClient(c.ContainerName).
+		NewBlobClient(c.PrefixedFileName(fileName)).GetProperties(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	return ptr.Deref(props.ContentLength, 0), nil
+}
+
 func (c *AzBlobClient) PrefixedFileName(fileName string) string {
 	if c.Opts.AllowNestedPrefixes {
 		return c.GetPrefix() + fileName
@@ -278,6 +389,36 @@ func (c *AzBlobClient) IsNotFound(err error) bool {
 	return getStatusCodeFromErr(err) == http.StatusNotFound
 }
 
+func (c *AzBlobClient) setImmutabilityPolicy(ctx context.Context, fileName string) error {
+	lock := c.Opts.ObjectLock
+	if lock == nil {
+		return nil
+	}
+	blobClient := c.ServiceClient().
+		NewContainerClient(c.ContainerName).
+		NewBlobClient(c.PrefixedFileName(fileName))
+
+	if _, err := blobClient.SetImmutabilityPolicy(ctx, time.Now().Add(lock.RetainUntil.Duration), &blob.SetImmutabilityPolicyOptions{
+		Mode: ptr.To(immutabilityPolicyMode(lock.ModeOrDefault())),
+	}); err != nil {
+		return fmt.Errorf("error setting immutability policy: %v", err)
+	}
+	if lock.LegalHold {
+		if _, err := blobClient.SetLegalHold(ctx, true, nil); err != nil {
+			return fmt.Errorf("error setting legal hold: %v", err)
+		}
+	}
+	return nil
+}
+
+func (c *AzBlobClient) metadata() map[string]*string {
+	metadata := make(map[string]*string, len(c.Opts.Metadata))
+	for k, v := range c.Opts.Metadata {
+		metadata[k] = ptr.To(v)
+	}
+	return metadata
+}
+
 func (c *AzBlobClient) getFilePath(fileName string) string {
 	if filepath.IsAbs(fileName) {
 		return fileName
@@ -286,6 +427,13 @@ func (c *AzBlobClient) getFilePath(fileName string) string {
 }
 
 // ===============
+func immutabilityPolicyMode(mode mariadbv1alpha1.ObjectLockMode) blob.ImmutabilityPolicySetting {
+	if mode == mariadbv1alpha1.ObjectLockModeCompliance {
+		return blob.ImmutabilityPolicySettingLocked
+	}
+	return blob.ImmutabilityPolicySettingUnlocked
+}
+
 func getStatusCodeFromErr(err error) int {
 	if err == nil {
 		return 0
@@ -309,7 +457,7 @@ func ignoreNotFound(err error) error {
 }
 
 func getClientOptions(opts *AzBlobOpts) (*azblob.ClientOptions, error) {
-	if !opts.TLSEnabled {
+	if !opts.TLSEnabled && opts.RateLimiter == nil && opts.ProgressTracker == nil {
 		return &azblob.ClientOptions{}, nil
 	}
 
@@ -321,7 +469,7 @@ func getClientOptions(opts *AzBlobOpts) (*azblob.ClientOptions, error) {
 	return &azblob.ClientOptions{
 		ClientOptions: policy.ClientOptions{
 			Transport: &http.Client{
-				Transport: transport,
+				Transport: opts.ProgressTracker.Transport(opts.RateLimiter.Transport(transport)),
 			},
 			Telemetry: policy.TelemetryOptions{
 				Disabled: true,
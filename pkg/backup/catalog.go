@@ -0,0 +1,103 @@
+package backup
+
+import (
+	"context"
+	"path"
+	"sort"
+	"time"
+
+	"github.com/go-logr/logr"
+	mariadbv1alpha1 "github.com/mariadb-operator/mariadb-operator/v26/api/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// CatalogMaxEntries is the maximum number of backups listed by the catalog reported in the backup status.
+const CatalogMaxEntries = 30
+
+// ManifestFn returns the Manifest of a backup file, or nil if the backup does not have one.
+type ManifestFn func(ctx context.Context, fileName string) (*Manifest, error)
+
+// GetCatalogEntries returns the catalog entries of the given backup files, sorted from the most recent to the oldest.
+// Only the most recent maxEntries backups are returned, or all of them if maxEntries is 0.
+// The size, GTID and target Pod are read from the Manifests, backups without a Manifest are listed without them.
+func GetCatalogEntries(ctx context.Context, backupFileNames []string, processor BackupProcessor, manifestFn ManifestFn,
+	maxEntries int, logger logr.Logger) []mariadbv1alpha1.BackupCatalogEntry {
+	type datedBackup struct {
+		fileName string
+		date     time.Time
+	}
+	var backups []datedBackup
+	for _, fileName := range backupFileNames {
+		date, err := processor.parseDateInBackupFile(fileName)
+		if err != nil {
+			logger.Error(err, "error parsing backup date. Skipping", "backup", fileName)
+			continue
+		}
+		backups = append(backups, datedBackup{
+			fileName: fileName,
+			date:     date,
+		})
+	}
+	sort.SliceStable(backups, func(i, j int) bool {
+		return backups[i].date.After(backups[j].date)
+	})
+	if maxEntries > 0 && len(backups) > maxEntries {
+		backups = backups[:maxEntries]
+	}
+
+	entries := make([]mariadbv1alpha1.BackupCatalogEntry, 0, len(backups))
+	for _, backup := range backups {
+		entries = append(entries, newCatalogEntry(ctx, backup.fileName, backup.date, processor, manifestFn, logger))
+	}
+	return entries
+}
+
+// GetOldestCatalogEntry returns the catalog entry of the oldest backup file, or nil if there are no backups.
+func GetOldestCatalogEntry(ctx context.Context, backupFileNames []string, processor BackupProcessor, manifestFn ManifestFn,
+	logger logr.Logger) *mariadbv1alpha1.BackupCatalogEntry {
+	var (
+		oldestFileName string
+		oldestDate     time.Time
+	)
+	for _, fileName := range backupFileNames {
+		date, err := processor.parseDateInBackupFile(fileName)
+		if err != nil {
+			logger.Error(err, "error parsing backup date. Skipping", "backup", fileName)
+			continue
+		}
+		if oldestFileName == "" || date.Before(oldestDate) {
+			oldestFileName = fileName
+			oldestDate = date
+		}
+	}
+	if oldestFileName == "" {
+		return nil
+	}
+	entry := newCatalogEntry(ctx, oldestFileName, oldestDate, processor, manifestFn, logger)
+	return &entry
+}
+
+func newCatalogEntry(ctx context.Context, fileName string, date time.Time, processor BackupProcessor, manifestFn ManifestFn,
+	logger logr.Logger) mariadbv1alpha1.BackupCatalogEntry {
+	entry := mariadbv1alpha1.BackupCatalogEntry{
+		FileName: fileName,
+		Time:     metav1.NewTime(date),
+	}
+	if calg, err := processor.ParseCompressionAlgorithm(path.Base(fileName)); err == nil {
+		entry.Compression = calg
+	}
+
+	manifest, err := manifestFn(ctx, fileName)
+	if err != nil {
+		logger.Error(err, "error getting manifest. Listing backup without it", "backup", fileName)
+	}
+	if manifest != nil {
+		entry.Size = manifest.Size
+		entry.GTID = manifest.GTID
+		entry.TargetPod = manifest.TargetPod
+		if manifest.Compression != "" {
+			entry.Compression = manifest.Compression
+		}
+	}
+	return entry
+}
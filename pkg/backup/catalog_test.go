@@ -0,0 +1,171 @@
+package backup
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	mariadbv1alpha1 "github.com/mariadb-operator/mariadb-operator/v26/api/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestGetCatalogEntries(t *testing.T) {
+	manifests := map[string]*Manifest{
+		"backup.2023-12-22T12:00:00Z.sql.gz": {
+			Size:        1024,
+			Compression: mariadbv1alpha1.CompressGzip,
+			GTID:        "0-10-42",
+		},
+		"physicalbackup-20231222120000.xb.zst": {
+			Size:      2048,
+			GTID:      "0-10-84",
+			TargetPod: "mariadb-1",
+		},
+	}
+	manifestFn := func(ctx context.Context, fileName string) (*Manifest, error) {
+		if fileName == "backup.2023-12-21T10:00:00Z.sql" {
+			return nil, errors.New("test error")
+		}
+		return manifests[fileName], nil
+	}
+	mustParseTime := func(s string) metav1.Time {
+		tt, err := time.Parse(time.RFC3339, s)
+		if err != nil {
+			t.Fatalf("unexpected error parsing time: %v", err)
+		}
+		return metav1.NewTime(tt)
+	}
+
+	tests := []struct {
+		name        string
+		processor   BackupProcessor
+		backupFiles []string
+		maxEntries  int
+		wantEntries []mariadbv1alpha1.BackupCatalogEntry
+	}{
+		{
+			name:        "no backups",
+			processor:   NewLogicalBackupProcessor(),
+			wantEntries: []mariadbv1alpha1.BackupCatalogEntry{},
+		},
+		{
+			name:      "logical backups",
+			processor: NewLogicalBackupProcessor(),
+			backupFiles: []string{
+				"backup.2023-12-21T10:00:00Z.sql",
+				"backup.2023-12-22T12:00:00Z.sql.gz",
+				"backup.invalid.sql",
+				"backup.2023-12-20T10:00:00Z.sql.bz2",
+			},
+			wantEntries: []mariadbv1alpha1.BackupCatalogEntry{
+				{
+					FileName:    "backup.2023-12-22T12:00:00Z.sql.gz",
+					Time:        mustParseTime("2023-12-22T12:00:00Z"),
+					Size:        1024,
+					Compression: mariadbv1alpha1.CompressGzip,
+					GTID:        "0-10-42",
+				},
+				{
+					FileName:    "backup.2023-12-21T10:00:00Z.sql",
+					Time:        mustParseTime("2023-12-21T10:00:00Z"),
+					Compression: mariadbv1alpha1.CompressNone,
+				},
+				{
+					FileName:    "backup.2023-12-20T10:00:00Z.sql.bz2",
+					Time:        mustParseTime("2023-12-20T10:00:00Z"),
+					Compression: mariadbv1alpha1.CompressBzip2,
+				},
+			},
+		},
+		{
+			name:      "max entries",
+			processor: NewLogicalBackupProcessor(),
+			backupFiles: []string{
+				"backup.2023-12-21T10:00:00Z.sql",
+				"backup.2023-12-22T12:00:00Z.sql.gz",
+				"backup.2023-12-20T10:00:00Z.sql.bz2",
+			},
+			maxEntries: 1,
+			wantEntries: []mariadbv1alpha1.BackupCatalogEntry{
+				{
+					FileName:    "backup.2023-12-22T12:00:00Z.sql.gz",
+					Time:        mustParseTime("2023-12-22T12:00:00Z"),
+					Size:        1024,
+					Compression: mariadbv1alpha1.CompressGzip,
+					GTID:        "0-10-42",
+				},
+			},
+		},
+		{
+			name:      "physical backups",
+			processor: NewPhysicalBackupProcessor(),
+			backupFiles: []string{
+				"physicalbackup-20231221100000.xb",
+				"physicalbackup-20231222120000.xb.zst",
+			},
+			wantEntries: []mariadbv1alpha1.BackupCatalogEntry{
+				{
+					FileName:    "physicalbackup-20231222120000.xb.zst",
+					Time:        mustParseTime("2023-12-22T12:00:00Z"),
+					Size:        2048,
+					Compression: mariadbv1alpha1.CompressZstd,
+					GTID:        "0-10-84",
+					TargetPod:   "mariadb-1",
+				},
+				{
+					FileName:    "physicalbackup-20231221100000.xb",
+					Time:        mustParseTime("2023-12-21T10:00:00Z"),
+					Compression: mariadbv1alpha1.CompressNone,
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entries := GetCatalogEntries(context.Background(), tt.backupFiles, tt.processor, manifestFn, tt.maxEntries,
+				logr.Discard())
+			if !reflect.DeepEqual(entries, tt.wantEntries) {
+				t.Errorf("unexpected catalog entries, expected: %v got: %v", tt.wantEntries, entries)
+			}
+		})
+	}
+}
+
+func TestGetOldestCatalogEntry(t *testing.T) {
+	manifestFn := func(ctx context.Context, fileName string) (*Manifest, error) {
+		if fileName == "physicalbackup-20231220100000.xb" {
+			return &Manifest{
+				Size: 1024,
+				GTID: "0-10-21",
+			}, nil
+		}
+		return nil, nil
+	}
+	processor := NewPhysicalBackupProcessor()
+
+	entry := GetOldestCatalogEntry(context.Background(), nil, processor, manifestFn, logr.Discard())
+	if entry != nil {
+		t.Errorf("expected no entry, got: %v", entry)
+	}
+
+	entry = GetOldestCatalogEntry(context.Background(), []string{
+		"physicalbackup-20231221100000.xb",
+		"physicalbackup-invalid.xb",
+		"physicalbackup-20231220100000.xb",
+		"physicalbackup-20231222120000.xb.zst",
+	}, processor, manifestFn, logr.Discard())
+	wantEntry := &mariadbv1alpha1.BackupCatalogEntry{
+		FileName:    "physicalbackup-20231220100000.xb",
+		Time:        metav1.NewTime(time.Date(2023, 12, 20, 10, 0, 0, 0, time.UTC)),
+		Size:        1024,
+		Compression: mariadbv1alpha1.CompressNone,
+		GTID:        "0-10-21",
+	}
+	if !reflect.DeepEqual(entry, wantEntry) {
+		t.Errorf("unexpected oldest catalog entry, expected: %v got: %v", wantEntry, entry)
+	}
+}
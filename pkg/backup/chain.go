@@ -0,0 +1,222 @@
+package backup
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/go-logr/logr"
+	mariadbv1alpha1 "github.com/mariadb-operator/mariadb-operator/v26/api/v1alpha1"
+)
+
+const (
+	// ChainIndexFileName is the name of the file, stored alongside the backups, that tracks the incremental backup chains.
+	ChainIndexFileName = "physicalbackup-chain.json"
+	// CheckpointsFileName is the name of the file where mariadb-backup records the LSNs of a backup.
+	CheckpointsFileName = "xtrabackup_checkpoints"
+)
+
+// ChainIndex tracks the full and incremental physical backups that compose the incremental backup chains.
+type ChainIndex struct {
+	Backups []mariadbv1alpha1.PhysicalBackupChainLink `json:"backups"`
+}
+
+// ReadChainIndex reads a ChainIndex from a file. An empty ChainIndex is returned if the file does not exist.
+func ReadChainIndex(filePath string) (*ChainIndex, error) {
+	bytes, err := os.ReadFile(filePath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return &ChainIndex{}, nil
+		}
+		return nil, fmt.Errorf("error reading chain index file %s: %v", filePath, err)
+	}
+	index, err := ParseChainIndex(bytes)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing chain index file %s: %v", filePath, err)
+	}
+	return index, nil
+}
+
+// ParseChainIndex parses the contents of a chain index file.
+func ParseChainIndex(bytes []byte) (*ChainIndex, error) {
+	var index ChainIndex
+	if err := json.Unmarshal(bytes, &index); err != nil {
+		return nil, fmt.Errorf("error unmarshaling chain index: %v", err)
+	}
+	return &index, nil
+}
+
+// Write writes the ChainIndex into a file.
+func (c *ChainIndex) Write(filePath string) error {
+	bytes, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return fmt.Errorf("error marshaling chain index: %v", err)
+	}
+	return os.WriteFile(filePath, bytes, 0644)
+}
+
+// Add adds a backup to the index, replacing any previous backup with the same file name.
+func (c *ChainIndex) Add(link mariadbv1alpha1.PhysicalBackupChainLink) {
+	link.FileName = path.Base(link.FileName)
+	for i, b := range c.Backups {
+		if b.FileName == link.FileName {
+			c.Backups[i] = link
+			return
+		}
+	}
+	c.Backups = append(c.Backups, link)
+}
+
+// Get gets a backup from the index.
+func (c *ChainIndex) Get(fileName string) (*mariadbv1alpha1.PhysicalBackupChainLink, bool) {
+	baseName := path.Base(fileName)
+	for i, b := range c.Backups {
+		if b.FileName == baseName {
+			return &c.Backups[i], true
+		}
+	}
+	return nil, false
+}
+
+// Chain returns the backups needed to restore a given backup, starting with the full backup and ending with the backup itself.
+func (c *ChainIndex) Chain(fileName string) ([]mariadbv1alpha1.PhysicalBackupChainLink, error) {
+	link, ok := c.Get(fileName)
+	if !ok {
+		return nil, fmt.Errorf("backup %s not found in chain index", fileName)
+	}
+	chain := []mariadbv1alpha1.PhysicalBackupChainLink{*link}
+
+	for link.Type == mariadbv1alpha1.PhysicalBackupTypeIncremental {
+		if len(chain) > len(c.Backups) {
+			return nil, fmt.Errorf("cycle detected in chain of backup %s", fileName)
+		}
+		if link.ParentFileName == "" {
+			return nil, fmt.Errorf("incremental backup %s has no parent", link.FileName)
+		}
+		parent, ok := c.Get(link.ParentFileName)
+		if !ok {
+			return nil, fmt.Errorf("parent backup %s of incremental backup %s not found in chain index", link.ParentFileName, link.FileName)
+		}
+		chain = append([]mariadbv1alpha1.PhysicalBackupChainLink{*parent}, chain...)
+		link = parent
+	}
+	return chain, nil
+}
+
+// ProtectDependencies removes from the old backups the ones that are needed to restore any of the backups being kept.
+func (c *ChainIndex) ProtectDependencies(backupFileNames, oldBackupFileNames []string, logger logr.Logger) []string {
+	isOld := make(map[string]bool, len(oldBackupFileNames))
+	for _, f := range oldBackupFileNames {
+		isOld[path.Base(f)] = true
+	}
+	protected := make(map[string]string)
+	for _, f := range backupFileNames {
+		baseName := path.Base(f)
+		if isOld[baseName] {
+			continue
+		}
+		if _, ok := c.Get(baseName); !ok {
+			continue
+		}
+		chain, err := c.Chain(baseName)
+		if err != nil {
+			logger.Error(err, "error getting backup chain", "file", baseName)
+			continue
+		}
+		for _, link := range chain[:len(chain)-1] {
+			protected[link.FileName] = baseName
+		}
+	}
+
+	var oldBackups []string
+	for _, f := range oldBackupFileNames {
+		if dependant, ok := protected[path.Base(f)]; ok {
+			logger.Info("Keeping backup needed by incremental backup", "file", f, "incremental", dependant)
+			continue
+		}
+		oldBackups = append(oldBackups, f)
+	}
+	return oldBackups
+}
+
+// Prune removes from the index the backups that are no longer available.
+func (c *ChainIndex) Prune(backupFileNames []string) {
+	exists := make(map[string]bool, len(backupFileNames))
+	for _, f := range backupFileNames {
+		exists[path.Base(f)] = true
+	}
+	var backups []mariadbv1alpha1.PhysicalBackupChainLink
+	for _, b := range c.Backups {
+		if exists[b.FileName] {
+			backups = append(backups, b)
+		}
+	}
+	c.Backups = backups
+}
+
+// Checkpoints are the LSNs of a physical backup, as recorded by mariadb-backup.
+type Checkpoints struct {
+	BackupType string
+	FromLSN    int64
+	ToLSN      int64
+}
+
+// IsIncremental determines whether the backup is incremental.
+func (c *Checkpoints) IsIncremental() bool {
+	return c.BackupType == "incremental"
+}
+
+// ParseCheckpoints parses the contents of a xtrabackup_checkpoints file.
+func ParseCheckpoints(data []byte) (*Checkpoints, error) {
+	var checkpoints Checkpoints
+	var hasToLSN bool
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		key, value, ok := strings.Cut(scanner.Text(), "=")
+		if !ok {
+			continue
+		}
+		key = strings.TrimSpace(key)
+		value = strings.TrimSpace(value)
+
+		switch key {
+		case "backup_type":
+			checkpoints.BackupType = value
+		case "from_lsn", "to_lsn":
+			lsn, err := strconv.ParseInt(value, 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("error parsing %s: %v", key, err)
+			}
+			if key == "from_lsn" {
+				checkpoints.FromLSN = lsn
+			} else {
+				checkpoints.ToLSN = lsn
+				hasToLSN = true
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("error scanning checkpoints: %v", err)
+	}
+	if checkpoints.BackupType == "" {
+		return nil, errors.New("backup_type not found in checkpoints")
+	}
+	if !hasToLSN {
+		return nil, errors.New("to_lsn not found in checkpoints")
+	}
+	return &checkpoints, nil
+}
+
+// IncrementalTargetFilePath returns the path of the file that contains the incremental backups to be applied on top of the target file.
+func IncrementalTargetFilePath(targetFilePath string) string {
+	ext := filepath.Ext(targetFilePath)
+	return strings.TrimSuffix(targetFilePath, ext) + "-incremental" + ext
+}
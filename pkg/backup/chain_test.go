@@ -0,0 +1,359 @@
+package backup
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	mariadbv1alpha1 "github.com/mariadb-operator/mariadb-operator/v26/api/v1alpha1"
+)
+
+func TestChainIndexChain(t *testing.T) {
+	index := testChainIndex()
+	tests := []struct {
+		name      string
+		fileName  string
+		wantFiles []string
+		wantErr   bool
+	}{
+		{
+			name:     "not found",
+			fileName: "physicalbackup-20250103000000.xb.gz",
+			wantErr:  true,
+		},
+		{
+			name:     "full",
+			fileName: "physicalbackup-20250101000000.xb.gz",
+			wantFiles: []string{
+				"physicalbackup-20250101000000.xb.gz",
+			},
+		},
+		{
+			name:     "incremental",
+			fileName: "physicalbackup-20250101120000.xb.gz",
+			wantFiles: []string{
+				"physicalbackup-20250101000000.xb.gz",
+				"physicalbackup-20250101060000.xb.gz",
+				"physicalbackup-20250101120000.xb.gz",
+			},
+		},
+		{
+			name:     "prefixed incremental",
+			fileName: "mariadb/physicalbackup-20250101060000.xb.gz",
+			wantFiles: []string{
+				"physicalbackup-20250101000000.xb.gz",
+				"physicalbackup-20250101060000.xb.gz",
+			},
+		},
+		{
+			name:     "missing parent",
+			fileName: "physicalbackup-20250102060000.xb.gz",
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			chain, err := index.Chain(tt.fileName)
+			if tt.wantErr && err == nil {
+				t.Fatal("expected error, got nil")
+			}
+			if !tt.wantErr && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			var files []string
+			for _, link := range chain {
+				files = append(files, link.FileName)
+			}
+			if !reflect.DeepEqual(tt.wantFiles, files) {
+				t.Fatalf("unexpected chain, expected: %v got: %v", tt.wantFiles, files)
+			}
+		})
+	}
+}
+
+func TestChainIndexProtectDependencies(t *testing.T) {
+	index := testChainIndex()
+	tests := []struct {
+		name        string
+		backupFiles []string
+		oldBackups  []string
+		wantBackups []string
+	}{
+		{
+			name: "no old backups",
+			backupFiles: []string{
+				"physicalbackup-20250101000000.xb.gz",
+				"physicalbackup-20250101060000.xb.gz",
+			},
+			oldBackups:  nil,
+			wantBackups: nil,
+		},
+		{
+			name: "whole chain expired",
+			backupFiles: []string{
+				"physicalbackup-20250101000000.xb.gz",
+				"physicalbackup-20250101060000.xb.gz",
+				"physicalbackup-20250101120000.xb.gz",
+				"physicalbackup-20250102000000.xb.gz",
+			},
+			oldBackups: []string{
+				"physicalbackup-20250101000000.xb.gz",
+				"physicalbackup-20250101060000.xb.gz",
+				"physicalbackup-20250101120000.xb.gz",
+			},
+			wantBackups: []string{
+				"physicalbackup-20250101000000.xb.gz",
+				"physicalbackup-20250101060000.xb.gz",
+				"physicalbackup-20250101120000.xb.gz",
+			},
+		},
+		{
+			name: "retained incremental",
+			backupFiles: []string{
+				"physicalbackup-20250101000000.xb.gz",
+				"physicalbackup-20250101060000.xb.gz",
+				"physicalbackup-20250101120000.xb.gz",
+				"physicalbackup-20250102000000.xb.gz",
+			},
+			oldBackups: []string{
+				"physicalbackup-20250101000000.xb.gz",
+				"physicalbackup-20250101060000.xb.gz",
+			},
+			wantBackups: nil,
+		},
+		{
+			name: "retained intermediate incremental",
+			backupFiles: []string{
+				"prefix/physicalbackup-20250101000000.xb.gz",
+				"prefix/physicalbackup-20250101060000.xb.gz",
+				"prefix/physicalbackup-20250101120000.xb.gz",
+			},
+			oldBackups: []string{
+				"prefix/physicalbackup-20250101000000.xb.gz",
+				"prefix/physicalbackup-20250101120000.xb.gz",
+			},
+			wantBackups: []string{
+				"prefix/physicalbackup-20250101120000.xb.gz",
+			},
+		},
+		{
+			name: "backups not in index",
+			backupFiles: []string{
+				"physicalbackup-20241201000000.xb.gz",
+				"physicalbackup-20241202000000.xb.gz",
+			},
+			oldBackups: []string{
+				"physicalbackup-20241201000000.xb.gz",
+			},
+			wantBackups: []string{
+				"physicalbackup-20241201000000.xb.gz",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			backups := index.ProtectDependencies(tt.backupFiles, tt.oldBackups, logger)
+			if !reflect.DeepEqual(tt.wantBackups, backups) {
+				t.Fatalf("unexpected backup files, expected: %v got: %v", tt.wantBackups, backups)
+			}
+		})
+	}
+}
+
+func TestChainIndexPrune(t *testing.T) {
+	index := testChainIndex()
+	index.Prune([]string{
+		"physicalbackup-20250101000000.xb.gz",
+		"prefix/physicalbackup-20250102000000.xb.gz",
+	})
+
+	var files []string
+	for _, link := range index.Backups {
+		files = append(files, link.FileName)
+	}
+	wantFiles := []string{
+		"physicalbackup-20250101000000.xb.gz",
+		"physicalbackup-20250102000000.xb.gz",
+	}
+	if !reflect.DeepEqual(wantFiles, files) {
+		t.Fatalf("unexpected backup files, expected: %v got: %v", wantFiles, files)
+	}
+}
+
+func TestChainIndexReadWrite(t *testing.T) {
+	filePath := filepath.Join(t.TempDir(), ChainIndexFileName)
+
+	index, err := ReadChainIndex(filePath)
+	if err != nil {
+		t.Fatalf("unexpected error reading non existing index: %v", err)
+	}
+	if len(index.Backups) != 0 {
+		t.Fatalf("expected empty index, got: %v", index.Backups)
+	}
+
+	index = testChainIndex()
+	index.Add(mariadbv1alpha1.PhysicalBackupChainLink{
+		FileName: "/backup/physicalbackup-20250102000000.xb.gz",
+		Type:     mariadbv1alpha1.PhysicalBackupTypeFull,
+		ToLSN:    5000,
+		GTID:     "0-10-20",
+	})
+	if err := index.Write(filePath); err != nil {
+		t.Fatalf("unexpected error writing index: %v", err)
+	}
+
+	readIndex, err := ReadChainIndex(filePath)
+	if err != nil {
+		t.Fatalf("unexpected error reading index: %v", err)
+	}
+	if !reflect.DeepEqual(index, readIndex) {
+		t.Fatalf("unexpected index, expected: %v got: %v", index, readIndex)
+	}
+	link, ok := readIndex.Get("physicalbackup-20250102000000.xb.gz")
+	if !ok {
+		t.Fatal("expected backup to be found in index")
+	}
+	if link.ToLSN != 5000 || link.GTID != "0-10-20" {
+		t.Fatalf("unexpected backup: %v", link)
+	}
+}
+
+func TestParseCheckpoints(t *testing.T) {
+	tests := []struct {
+		name            string
+		data            string
+		wantCheckpoints *Checkpoints
+		wantErr         bool
+	}{
+		{
+			name:    "empty",
+			data:    "",
+			wantErr: true,
+		},
+		{
+			name: "full",
+			data: `backup_type = full-backuped
+from_lsn = 0
+to_lsn = 1234567
+last_lsn = 1234567
+recover_binlog_info = 0
+`,
+			wantCheckpoints: &Checkpoints{
+				BackupType: "full-backuped",
+				FromLSN:    0,
+				ToLSN:      1234567,
+			},
+		},
+		{
+			name: "incremental",
+			data: `backup_type = incremental
+from_lsn = 1234567
+to_lsn = 2345678
+last_lsn = 2345678
+`,
+			wantCheckpoints: &Checkpoints{
+				BackupType: "incremental",
+				FromLSN:    1234567,
+				ToLSN:      2345678,
+			},
+		},
+		{
+			name: "missing to_lsn",
+			data: `backup_type = full-backuped
+from_lsn = 0
+`,
+			wantErr: true,
+		},
+		{
+			name: "invalid lsn",
+			data: `backup_type = full-backuped
+from_lsn = 0
+to_lsn = foo
+`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checkpoints, err := ParseCheckpoints([]byte(tt.data))
+			if tt.wantErr && err == nil {
+				t.Fatal("expected error, got nil")
+			}
+			if !tt.wantErr && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(tt.wantCheckpoints, checkpoints) {
+				t.Fatalf("unexpected checkpoints, expected: %v got: %v", tt.wantCheckpoints, checkpoints)
+			}
+		})
+	}
+}
+
+func TestIncrementalTargetFilePath(t *testing.T) {
+	tests := []struct {
+		name           string
+		targetFilePath string
+		wantFilePath   string
+	}{
+		{
+			name:           "with extension",
+			targetFilePath: "/backup/0-backup-target.txt",
+			wantFilePath:   "/backup/0-backup-target-incremental.txt",
+		},
+		{
+			name:           "without extension",
+			targetFilePath: "/backup/target",
+			wantFilePath:   "/backup/target-incremental",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filePath := IncrementalTargetFilePath(tt.targetFilePath)
+			if filePath != tt.wantFilePath {
+				t.Fatalf("unexpected file path, expected: %v got: %v", tt.wantFilePath, filePath)
+			}
+		})
+	}
+}
+
+func testChainIndex() *ChainIndex {
+	return &ChainIndex{
+		Backups: []mariadbv1alpha1.PhysicalBackupChainLink{
+			{
+				FileName: "physicalbackup-20250101000000.xb.gz",
+				Type:     mariadbv1alpha1.PhysicalBackupTypeFull,
+				ToLSN:    1000,
+			},
+			{
+				FileName:       "physicalbackup-20250101060000.xb.gz",
+				ParentFileName: "physicalbackup-20250101000000.xb.gz",
+				Type:           mariadbv1alpha1.PhysicalBackupTypeIncremental,
+				FromLSN:        1000,
+				ToLSN:          2000,
+			},
+			{
+				FileName:       "physicalbackup-20250101120000.xb.gz",
+				ParentFileName: "physicalbackup-20250101060000.xb.gz",
+				Type:           mariadbv1alpha1.PhysicalBackupTypeIncremental,
+				FromLSN:        2000,
+				ToLSN:          3000,
+			},
+			{
+				FileName: "physicalbackup-20250102000000.xb.gz",
+				Type:     mariadbv1alpha1.PhysicalBackupTypeFull,
+				ToLSN:    4000,
+			},
+			{
+				FileName:       "physicalbackup-20250102060000.xb.gz",
+				ParentFileName: "physicalbackup-20250101180000.xb.gz",
+				Type:           mariadbv1alpha1.PhysicalBackupTypeIncremental,
+				FromLSN:        4000,
+				ToLSN:          4500,
+			},
+		},
+	}
+}
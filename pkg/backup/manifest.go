@@ -0,0 +1,313 @@
+package backup
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	mariadbv1alpha1 "github.com/mariadb-operator/mariadb-operator/v26/api/v1alpha1"
+)
+
+const (
+	// ManifestAPIVersion is the version of the Manifest format.
+	ManifestAPIVersion = "v1"
+	// XtrabackupInfoFileName is the name of the file where mariadb-backup records the information of a physical backup.
+	XtrabackupInfoFileName = "xtrabackup_info"
+
+	manifestSuffix = ".manifest.json"
+)
+
+var errStopReadingDump = errors.New("stop reading dump")
+
+// xtrabackupGtidRegex matches the GTID within the binlog_pos field of the xtrabackup_info file.
+// For example: filename 'mariadb-bin.000001', position '385', GTID of the last change '0-10-42'
+var xtrabackupGtidRegex = regexp.MustCompile(`GTID of the last change '([^']*)'`)
+
+// Manifest records the integrity information of a backup artifact, such as a backup file or an archived binary log.
+// It is stored next to the artifact and verified before the artifact is restored.
+type Manifest struct {
+	APIVersion       string                            `json:"apiVersion"`
+	FileName         string                            `json:"fileName"`
+	SHA256           string                            `json:"sha256"`
+	Size             int64                             `json:"size"`
+	UncompressedSize int64                             `json:"uncompressedSize,omitempty"`
+	Compression      mariadbv1alpha1.CompressAlgorithm `json:"compression,omitempty"`
+	GTID             string                            `json:"gtid,omitempty"`
+	ServerVersion    string                            `json:"serverVersion,omitempty"`
+	MariaDB          string                            `json:"mariadb,omitempty"`
+	TargetPod        string                            `json:"targetPod,omitempty"`
+	CreatedAt        time.Time                         `json:"createdAt"`
+}
+
+// ManifestOpt sets optional fields of a Manifest.
+type ManifestOpt func(*Manifest)
+
+func WithManifestUncompressedSize(size int64) ManifestOpt {
+	return func(m *Manifest) {
+		m.UncompressedSize = size
+	}
+}
+
+func WithManifestCompression(calg mariadbv1alpha1.CompressAlgorithm) ManifestOpt {
+	return func(m *Manifest) {
+		m.Compression = calg
+	}
+}
+
+func WithManifestGTID(gtid string) ManifestOpt {
+	return func(m *Manifest) {
+		m.GTID = gtid
+	}
+}
+
+func WithManifestServerVersion(serverVersion string) ManifestOpt {
+	return func(m *Manifest) {
+		m.ServerVersion = serverVersion
+	}
+}
+
+func WithManifestMariaDB(mariadb string) ManifestOpt {
+	return func(m *Manifest) {
+		m.MariaDB = mariadb
+	}
+}
+
+func WithManifestTargetPod(targetPod string) ManifestOpt {
+	return func(m *Manifest) {
+		m.TargetPod = targetPod
+	}
+}
+
+// NewManifest computes the size and checksum of the artifact read from r, and returns its Manifest.
+func NewManifest(fileName string, r io.Reader, opts ...ManifestOpt) (*Manifest, error) {
+	w := NewManifestWriter()
+	if _, err := io.Copy(w, r); err != nil {
+		return nil, fmt.Errorf("error computing checksum of %s: %v", fileName, err)
+	}
+	return w.Manifest(fileName, opts...), nil
+}
+
+// NewManifestFromFile returns the Manifest of a file.
+func NewManifestFromFile(filePath string, opts ...ManifestOpt) (*Manifest, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("error opening file %s: %v", filePath, err)
+	}
+	defer file.Close()
+
+	return NewManifest(filepath.Base(filePath), file, opts...)
+}
+
+// ManifestFileName returns the name of the Manifest of an artifact.
+func ManifestFileName(fileName string) string {
+	return fileName + manifestSuffix
+}
+
+// IsManifestFile determines whether a file is a Manifest.
+func IsManifestFile(fileName string) bool {
+	return strings.HasSuffix(fileName, manifestSuffix)
+}
+
+// ParseManifest parses a Manifest.
+func ParseManifest(bytes []byte) (*Manifest, error) {
+	var manifest Manifest
+	if err := json.Unmarshal(bytes, &manifest); err != nil {
+		return nil, fmt.Errorf("error unmarshaling manifest: %v", err)
+	}
+	if manifest.SHA256 == "" {
+		return nil, errors.New("manifest checksum must be set")
+	}
+	return &manifest, nil
+}
+
+// ReadManifest reads a Manifest from a file.
+func ReadManifest(filePath string) (*Manifest, error) {
+	bytes, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("error reading manifest file %s: %v", filePath, err)
+	}
+	manifest, err := ParseManifest(bytes)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing manifest file %s: %v", filePath, err)
+	}
+	return manifest, nil
+}
+
+// Marshal returns the JSON encoding of the Manifest.
+func (m *Manifest) Marshal() ([]byte, error) {
+	bytes, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("error marshaling manifest: %v", err)
+	}
+	return bytes, nil
+}
+
+// Write writes the Manifest into a file.
+func (m *Manifest) Write(filePath string) error {
+	bytes, err := m.Marshal()
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filePath, bytes, 0644)
+}
+
+// Verify checks that the artifact read from r matches the size and checksum recorded in the Manifest.
+func (m *Manifest) Verify(r io.Reader) error {
+	verifier := m.NewVerifier()
+	if _, err := io.Copy(verifier, r); err != nil {
+		return fmt.Errorf("error computing checksum of %s: %v", m.FileName, err)
+	}
+	return verifier.Verify()
+}
+
+// VerifyFile checks that a file matches the size and checksum recorded in the Manifest.
+func (m *Manifest) VerifyFile(filePath string) error {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return fmt.Errorf("error opening file %s: %v", filePath, err)
+	}
+	defer file.Close()
+
+	return m.Verify(file)
+}
+
+// VerifySize checks that the size of a stored artifact matches the size recorded in the Manifest.
+// It is a cheap check to be performed right after uploading the artifact, without reading it back.
+func (m *Manifest) VerifySize(size int64) error {
+	if size != m.Size {
+		return fmt.Errorf("unexpected size of %s, expected: %d got: %d", m.FileName, m.Size, size)
+	}
+	return nil
+}
+
+// NewVerifier returns a ManifestVerifier, which allows verifying an artifact while it is being read.
+func (m *Manifest) NewVerifier() *ManifestVerifier {
+	return &ManifestVerifier{
+		manifest: m,
+		hash:     sha256.New(),
+	}
+}
+
+// ManifestVerifier computes the size and checksum of the bytes written to it, to be compared against a Manifest.
+type ManifestVerifier struct {
+	manifest *Manifest
+	hash     hash.Hash
+	size     int64
+}
+
+// Write implements io.Writer.
+func (v *ManifestVerifier) Write(p []byte) (int, error) {
+	n, err := v.hash.Write(p)
+	v.size += int64(n)
+	return n, err
+}
+
+// Verify checks that the bytes written so far match the size and checksum recorded in the Manifest.
+func (v *ManifestVerifier) Verify() error {
+	if v.size != v.manifest.Size {
+		return fmt.Errorf("unexpected size of %s, expected: %d got: %d", v.manifest.FileName, v.manifest.Size, v.size)
+	}
+	if checksum := hex.EncodeToString(v.hash.Sum(nil)); checksum != v.manifest.SHA256 {
+		return fmt.Errorf("unexpected checksum of %s, expected: %s got: %s", v.manifest.FileName, v.manifest.SHA256, checksum)
+	}
+	return nil
+}
+
+// ManifestWriter computes the size and checksum of the bytes written to it, to build the Manifest of an artifact being streamed.
+type ManifestWriter struct {
+	hash hash.Hash
+	size int64
+}
+
+// NewManifestWriter returns a new ManifestWriter.
+func NewManifestWriter() *ManifestWriter {
+	return &ManifestWriter{
+		hash: sha256.New(),
+	}
+}
+
+// Write implements io.Writer.
+func (w *ManifestWriter) Write(p []byte) (int, error) {
+	n, err := w.hash.Write(p)
+	w.size += int64(n)
+	return n, err
+}
+
+// Manifest returns the Manifest of the bytes written so far.
+func (w *ManifestWriter) Manifest(fileName string, opts ...ManifestOpt) *Manifest {
+	m := &Manifest{
+		APIVersion: ManifestAPIVersion,
+		FileName:   fileName,
+		SHA256:     hex.EncodeToString(w.hash.Sum(nil)),
+		Size:       w.size,
+		CreatedAt:  time.Now().UTC(),
+	}
+	for _, setOpt := range opts {
+		setOpt(m)
+	}
+	return m
+}
+
+// BackupInfo is the information about the server a backup was taken from.
+type BackupInfo struct {
+	GTID          string
+	ServerVersion string
+}
+
+// ReadDumpInfo reads the BackupInfo from the header of a mariadb-dump output.
+func ReadDumpInfo(r io.Reader) (*BackupInfo, error) {
+	var info BackupInfo
+	err := readDumpLines(r, func(line string) error {
+		trimmed := strings.TrimRight(line, "\r\n")
+		if version, ok := strings.CutPrefix(trimmed, "-- Server version"); ok {
+			info.ServerVersion = strings.TrimSpace(version)
+		}
+		if gtid, ok := strings.CutPrefix(trimmed, "-- SET GLOBAL gtid_slave_pos="); ok {
+			info.GTID = strings.Trim(gtid, "';")
+		}
+		// The header ends before the first database or table.
+		if isDumpSectionMarker(trimmed) {
+			return errStopReadingDump
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, errStopReadingDump) {
+		return nil, err
+	}
+	return &info, nil
+}
+
+// ParseXtrabackupInfo parses the BackupInfo from the xtrabackup_info file written by mariadb-backup.
+func ParseXtrabackupInfo(bytes []byte) (*BackupInfo, error) {
+	var info BackupInfo
+	scanner := bufio.NewScanner(strings.NewReader(string(bytes)))
+	for scanner.Scan() {
+		key, value, ok := strings.Cut(scanner.Text(), "=")
+		if !ok {
+			continue
+		}
+		value = strings.TrimSpace(value)
+		switch strings.TrimSpace(key) {
+		case "server_version":
+			info.ServerVersion = value
+		case "binlog_pos":
+			if match := xtrabackupGtidRegex.FindStringSubmatch(value); match != nil {
+				info.GTID = match[1]
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("error reading xtrabackup_info: %v", err)
+	}
+	return &info, nil
+}
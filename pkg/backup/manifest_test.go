@@ -0,0 +1,180 @@
+package backup
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	mariadbv1alpha1 "github.com/mariadb-operator/mariadb-operator/v26/api/v1alpha1"
+)
+
+func TestManifestVerify(t *testing.T) {
+	dir := t.TempDir()
+	filePath := filepath.Join(dir, "backup.2025-01-01T00:00:00Z.gzip.sql")
+	if err := os.WriteFile(filePath, []byte(testDump), 0644); err != nil {
+		t.Fatalf("unexpected error writing backup file: %v", err)
+	}
+
+	manifest, err := NewManifestFromFile(
+		filePath,
+		WithManifestUncompressedSize(int64(len(testDump))),
+		WithManifestCompression(mariadbv1alpha1.CompressGzip),
+		WithManifestGTID("0-10-42"),
+		WithManifestServerVersion("11.4.2-MariaDB-ubu2404-log"),
+		WithManifestMariaDB("mariadb"),
+	)
+	if err != nil {
+		t.Fatalf("unexpected error creating manifest: %v", err)
+	}
+	if manifest.FileName != "backup.2025-01-01T00:00:00Z.gzip.sql" {
+		t.Fatalf("unexpected file name: %v", manifest.FileName)
+	}
+	if manifest.Size != int64(len(testDump)) {
+		t.Fatalf("unexpected size, expected: %d got: %d", len(testDump), manifest.Size)
+	}
+
+	manifestPath := filepath.Join(dir, ManifestFileName(manifest.FileName))
+	if err := manifest.Write(manifestPath); err != nil {
+		t.Fatalf("unexpected error writing manifest: %v", err)
+	}
+	readManifest, err := ReadManifest(manifestPath)
+	if err != nil {
+		t.Fatalf("unexpected error reading manifest: %v", err)
+	}
+	if *readManifest != *manifest {
+		t.Fatalf("unexpected manifest, expected: %v got: %v", manifest, readManifest)
+	}
+	if err := readManifest.VerifyFile(filePath); err != nil {
+		t.Fatalf("unexpected error verifying backup: %v", err)
+	}
+
+	if err := readManifest.VerifySize(int64(len(testDump))); err != nil {
+		t.Fatalf("unexpected error verifying backup size: %v", err)
+	}
+	if err := readManifest.VerifySize(int64(len(testDump) - 1)); err == nil {
+		t.Fatal("expected error verifying size of truncated backup, got nil")
+	}
+
+	verifier := readManifest.NewVerifier()
+	if _, err := verifier.Write([]byte(testDump[:10])); err != nil {
+		t.Fatalf("unexpected error writing to verifier: %v", err)
+	}
+	if err := verifier.Verify(); err == nil {
+		t.Fatal("expected error verifying truncated backup, got nil")
+	}
+
+	tampered := strings.Replace(testDump, "(5),(6)", "(7),(8)", 1)
+	if err := os.WriteFile(filePath, []byte(tampered), 0644); err != nil {
+		t.Fatalf("unexpected error tampering backup file: %v", err)
+	}
+	if err := readManifest.VerifyFile(filePath); err == nil {
+		t.Fatal("expected error verifying tampered backup, got nil")
+	}
+}
+
+func TestIsManifestFile(t *testing.T) {
+	tests := []struct {
+		name         string
+		fileName     string
+		wantManifest bool
+	}{
+		{
+			name:         "empty",
+			fileName:     "",
+			wantManifest: false,
+		},
+		{
+			name:         "backup",
+			fileName:     "backup.2025-01-01T00:00:00Z.sql",
+			wantManifest: false,
+		},
+		{
+			name:         "parallel backup manifest",
+			fileName:     ParallelBackupManifestFileName,
+			wantManifest: false,
+		},
+		{
+			name:         "backup manifest",
+			fileName:     ManifestFileName("backup.2025-01-01T00:00:00Z.sql"),
+			wantManifest: true,
+		},
+		{
+			name:         "binlog manifest",
+			fileName:     ManifestFileName("server-10/mariadb-bin.000001.gz"),
+			wantManifest: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if manifest := IsManifestFile(tt.fileName); manifest != tt.wantManifest {
+				t.Fatalf("unexpected manifest file, expected: %v got: %v", tt.wantManifest, manifest)
+			}
+		})
+	}
+}
+
+func TestReadDumpInfo(t *testing.T) {
+	info, err := ReadDumpInfo(strings.NewReader(testDump))
+	if err != nil {
+		t.Fatalf("unexpected error reading dump info: %v", err)
+	}
+	if info.ServerVersion != "11.4.2-MariaDB-ubu2404-log" {
+		t.Fatalf("unexpected server version: %v", info.ServerVersion)
+	}
+	if info.GTID != "0-10-42" {
+		t.Fatalf("unexpected GTID: %v", info.GTID)
+	}
+}
+
+func TestParseXtrabackupInfo(t *testing.T) {
+	tests := []struct {
+		name              string
+		info              string
+		wantGTID          string
+		wantServerVersion string
+	}{
+		{
+			name:              "empty",
+			info:              "",
+			wantGTID:          "",
+			wantServerVersion: "",
+		},
+		{
+			name: "no binlog",
+			info: `uuid = 5d5d6c8c-0000-0000-0000-000000000000
+tool_name = mariadb-backup
+server_version = 11.4.2-MariaDB-ubu2404-log
+`,
+			wantGTID:          "",
+			wantServerVersion: "11.4.2-MariaDB-ubu2404-log",
+		},
+		{
+			name: "binlog",
+			info: `uuid = 5d5d6c8c-0000-0000-0000-000000000000
+tool_name = mariadb-backup
+server_version = 11.4.2-MariaDB-ubu2404-log
+binlog_pos = filename 'mariadb-bin.000001', position '385', GTID of the last change '0-10-42'
+innodb_from_lsn = 0
+`,
+			wantGTID:          "0-10-42",
+			wantServerVersion: "11.4.2-MariaDB-ubu2404-log",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			info, err := ParseXtrabackupInfo([]byte(tt.info))
+			if err != nil {
+				t.Fatalf("unexpected error parsing xtrabackup_info: %v", err)
+			}
+			if info.GTID != tt.wantGTID {
+				t.Fatalf("unexpected GTID, expected: %v got: %v", tt.wantGTID, info.GTID)
+			}
+			if info.ServerVersion != tt.wantServerVersion {
+				t.Fatalf("unexpected server version, expected: %v got: %v", tt.wantServerVersion, info.ServerVersion)
+			}
+		})
+	}
+}
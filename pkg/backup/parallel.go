@@ -0,0 +1,569 @@
+package backup
+
+import (
+	"archive/tar"
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"io/fs"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+const (
+	// ParallelBackupManifestFileName is the name of the file that describes the contents of a parallel backup.
+	ParallelBackupManifestFileName = "manifest.json"
+	// ParallelBackupSchemaFileName is the name of the file with the statements to be executed before loading the data.
+	ParallelBackupSchemaFileName = "schema.sql"
+	// ParallelBackupDataDir is the name of the directory with the data files, which can be loaded concurrently.
+	ParallelBackupDataDir = "data"
+	// ParallelBackupPostDataFileName is the name of the file with the statements to be executed after loading the data.
+	ParallelBackupPostDataFileName = "post-data.sql"
+
+	parallelBackupExtension = "tar"
+)
+
+// ParallelBackupFileType is the type of a file within a parallel backup.
+type ParallelBackupFileType string
+
+const (
+	// ParallelBackupFileTypeSchema contains the databases and tables.
+	ParallelBackupFileTypeSchema ParallelBackupFileType = "schema"
+	// ParallelBackupFileTypeData contains the rows of a table, or a chunk of them.
+	ParallelBackupFileTypeData ParallelBackupFileType = "data"
+	// ParallelBackupFileTypePostData contains the triggers, views, routines and events.
+	ParallelBackupFileTypePostData ParallelBackupFileType = "post-data"
+)
+
+// ParallelBackupFile is a file within a parallel backup.
+type ParallelBackupFile struct {
+	Name     string                 `json:"name"`
+	Type     ParallelBackupFileType `json:"type"`
+	Database string                 `json:"database,omitempty"`
+	Table    string                 `json:"table,omitempty"`
+	Chunk    int                    `json:"chunk,omitempty"`
+	Size     int64                  `json:"size"`
+	SHA256   string                 `json:"sha256"`
+}
+
+// ParallelBackupManifest describes the contents of a parallel backup.
+type ParallelBackupManifest struct {
+	GTID      string               `json:"gtid,omitempty"`
+	Databases []string             `json:"databases,omitempty"`
+	Files     []ParallelBackupFile `json:"files"`
+}
+
+// IsParallelBackupFile determines whether a logical backup file is a parallel backup archive.
+func IsParallelBackupFile(fileName string) bool {
+	parts := strings.Split(path.Base(fileName), ".")
+	return len(parts) >= 3 && parts[2] == parallelBackupExtension
+}
+
+// ReadParallelBackupManifest reads a ParallelBackupManifest from a file.
+func ReadParallelBackupManifest(filePath string) (*ParallelBackupManifest, error) {
+	bytes, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("error reading manifest file %s: %v", filePath, err)
+	}
+	var manifest ParallelBackupManifest
+	if err := json.Unmarshal(bytes, &manifest); err != nil {
+		return nil, fmt.Errorf("error unmarshaling manifest file %s: %v", filePath, err)
+	}
+	return &manifest, nil
+}
+
+// Write writes the ParallelBackupManifest into a file.
+func (m *ParallelBackupManifest) Write(filePath string) error {
+	bytes, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return fmt.Errorf("error marshaling manifest: %v", err)
+	}
+	return os.WriteFile(filePath, bytes, 0644)
+}
+
+// Verify checks that the files in a directory match the sizes and checksums recorded in the manifest.
+func (m *ParallelBackupManifest) Verify(dir string) error {
+	for _, f := range m.Files {
+		filePath := filepath.Join(dir, filepath.FromSlash(f.Name))
+		size, checksum, err := checksumFile(filePath)
+		if err != nil {
+			return fmt.Errorf("error getting checksum of file %s: %v", f.Name, err)
+		}
+		if size != f.Size {
+			return fmt.Errorf("unexpected size of file %s, expected: %d got: %d", f.Name, f.Size, size)
+		}
+		if checksum != f.SHA256 {
+			return fmt.Errorf("unexpected checksum of file %s, expected: %s got: %s", f.Name, f.SHA256, checksum)
+		}
+	}
+	return nil
+}
+
+// SplitDump splits a mariadb-dump output into a schema file, a data file per table and a post-data file, all of them written into dir.
+// Tables bigger than chunkSize bytes are split into multiple data files, chunkSize 0 means no limit.
+// The dump is split by the comments written before each section, so it fails when they are not found, for instance when using --skip-comments.
+func SplitDump(r io.Reader, dir string, chunkSize int64) (*ParallelBackupManifest, error) {
+	if err := os.MkdirAll(filepath.Join(dir, ParallelBackupDataDir), 0755); err != nil {
+		return nil, fmt.Errorf("error creating data directory: %v", err)
+	}
+	s := &dumpSplitter{
+		dir:        dir,
+		chunkSize:  chunkSize,
+		inPreamble: true,
+		manifest:   &ParallelBackupManifest{},
+	}
+	defer s.closeAll()
+
+	if err := readDumpLines(r, s.process); err != nil {
+		return nil, err
+	}
+	if err := s.finish(); err != nil {
+		return nil, err
+	}
+	return s.manifest, nil
+}
+
+// ArchiveDir moves the regular files of a directory into a tar archive.
+// Each file is removed right after being archived, so the files and the archive do not take up twice the disk space.
+func ArchiveDir(dir, archivePath string) error {
+	file, err := os.Create(archivePath)
+	if err != nil {
+		return fmt.Errorf("error creating archive: %v", err)
+	}
+	defer file.Close()
+
+	tw := tar.NewWriter(file)
+	if err := filepath.WalkDir(dir, func(filePath string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		name, err := filepath.Rel(dir, filePath)
+		if err != nil {
+			return err
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		header, err := tar.FileInfoHeader(info, "")
+		if err != nil {
+			return err
+		}
+		header.Name = filepath.ToSlash(name)
+		if err := tw.WriteHeader(header); err != nil {
+			return err
+		}
+		if err := archiveFile(tw, filePath); err != nil {
+			return err
+		}
+		return os.Remove(filePath)
+	}); err != nil {
+		return fmt.Errorf("error archiving directory %s: %v", dir, err)
+	}
+	if err := tw.Close(); err != nil {
+		return fmt.Errorf("error closing archive: %v", err)
+	}
+	return file.Close()
+}
+
+func archiveFile(tw *tar.Writer, filePath string) error {
+	f, err := os.Open(filePath)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	_, err = io.Copy(tw, f)
+	return err
+}
+
+// ExtractArchive extracts a tar archive into a directory.
+func ExtractArchive(archivePath, dir string) error {
+	file, err := os.Open(archivePath)
+	if err != nil {
+		return fmt.Errorf("error opening archive: %v", err)
+	}
+	defer file.Close()
+
+	tr := tar.NewReader(file)
+	for {
+		header, err := tr.Next()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return fmt.Errorf("error reading archive: %v", err)
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+		name := filepath.FromSlash(header.Name)
+		if !filepath.IsLocal(name) {
+			return fmt.Errorf("invalid file %s in archive", header.Name)
+		}
+		if err := extractFile(tr, filepath.Join(dir, name)); err != nil {
+			return fmt.Errorf("error extracting file %s: %v", header.Name, err)
+		}
+	}
+}
+
+func extractFile(r io.Reader, filePath string) error {
+	if err := os.MkdirAll(filepath.Dir(filePath), 0755); err != nil {
+		return err
+	}
+	file, err := os.Create(filePath)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	if _, err := io.Copy(file, r); err != nil {
+		return err
+	}
+	return file.Close()
+}
+
+// readDumpLines calls fn with every line of a dump, including the line terminator.
+func readDumpLines(r io.Reader, fn func(line string) error) error {
+	reader := bufio.NewReaderSize(r, 1024*1024)
+	for {
+		line, err := reader.ReadString('\n')
+		if len(line) > 0 {
+			if err := fn(line); err != nil {
+				return err
+			}
+		}
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return fmt.Errorf("error reading dump: %v", err)
+		}
+	}
+}
+
+func checksumFile(filePath string) (int64, string, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return 0, "", err
+	}
+	defer file.Close()
+
+	h := sha256.New()
+	size, err := io.Copy(h, file)
+	if err != nil {
+		return 0, "", err
+	}
+	return size, hex.EncodeToString(h.Sum(nil)), nil
+}
+
+type dumpSection int
+
+const (
+	dumpSectionSchema dumpSection = iota
+	dumpSectionData
+	dumpSectionPostData
+)
+
+type dumpSplitter struct {
+	dir       string
+	chunkSize int64
+	manifest  *ParallelBackupManifest
+
+	inPreamble bool
+	preamble   strings.Builder
+	prevLine   string
+	section    dumpSection
+	database   string
+	table      string
+	chunk      int
+
+	schema    *dumpFile
+	postData  *dumpFile
+	data      *dumpFile
+	dataFiles []ParallelBackupFile
+}
+
+func (s *dumpSplitter) process(line string) error {
+	trimmed := strings.TrimRight(line, "\r\n")
+	isMarker := s.prevLine == "--" && strings.HasPrefix(trimmed, "-- ")
+	s.prevLine = trimmed
+
+	if isMarker {
+		if err := s.startSection(trimmed); err != nil {
+			return err
+		}
+	}
+	if s.inPreamble {
+		if gtid, ok := strings.CutPrefix(trimmed, "-- SET GLOBAL gtid_slave_pos="); ok {
+			s.manifest.GTID = strings.Trim(gtid, "';")
+		}
+		s.preamble.WriteString(line)
+		return nil
+	}
+	if db, ok := parseUseStatement(trimmed); ok {
+		s.database = db
+	}
+
+	switch s.section {
+	case dumpSectionSchema:
+		// The schema file keeps the statements in the original order, including the ones to create and use the databases.
+		return s.schema.write(line, "")
+	case dumpSectionData:
+		switch {
+		case isInsertStatement(trimmed):
+			return s.writeData(line)
+		case trimmed == "" || isTableLockStatement(trimmed):
+			// Data files are loaded concurrently, table locks would serialize them.
+			return nil
+		default:
+			return s.postData.write(line, s.database)
+		}
+	default:
+		return s.postData.write(line, s.database)
+	}
+}
+
+func (s *dumpSplitter) startSection(marker string) error {
+	var section dumpSection
+	switch {
+	case strings.HasPrefix(marker, "-- Current Database: "):
+		section = dumpSectionSchema
+		if db, ok := parseIdentifier(marker); ok {
+			if !slices.Contains(s.manifest.Databases, db) {
+				s.manifest.Databases = append(s.manifest.Databases, db)
+			}
+		}
+	case strings.HasPrefix(marker, "-- Table structure for table "),
+		strings.HasPrefix(marker, "-- Temporary table structure for view "),
+		strings.HasPrefix(marker, "-- Temporary view structure for view "):
+		section = dumpSectionSchema
+	case strings.HasPrefix(marker, "-- Dumping data for table "):
+		section = dumpSectionData
+		table, _ := parseIdentifier(marker)
+		if err := s.closeData(); err != nil {
+			return err
+		}
+		s.table = table
+		s.chunk = 0
+	default:
+		if s.inPreamble {
+			return nil
+		}
+		section = dumpSectionPostData
+	}
+	if s.inPreamble {
+		if err := s.endPreamble(); err != nil {
+			return err
+		}
+	}
+	if s.section == dumpSectionData && section != dumpSectionData {
+		if err := s.closeData(); err != nil {
+			return err
+		}
+	}
+	s.section = section
+	return nil
+}
+
+func (s *dumpSplitter) endPreamble() error {
+	s.inPreamble = false
+	var err error
+	s.schema, err = s.createFile(ParallelBackupFile{
+		Name: ParallelBackupSchemaFileName,
+		Type: ParallelBackupFileTypeSchema,
+	})
+	if err != nil {
+		return err
+	}
+	s.postData, err = s.createFile(ParallelBackupFile{
+		Name: ParallelBackupPostDataFileName,
+		Type: ParallelBackupFileTypePostData,
+	})
+	return err
+}
+
+func (s *dumpSplitter) writeData(line string) error {
+	if s.data != nil && s.chunkSize > 0 && s.data.contentSize > 0 && s.data.contentSize+int64(len(line)) > s.chunkSize {
+		if err := s.closeData(); err != nil {
+			return err
+		}
+		s.chunk++
+	}
+	if s.data == nil {
+		var err error
+		s.data, err = s.createFile(ParallelBackupFile{
+			Name:     s.dataFileName(),
+			Type:     ParallelBackupFileTypeData,
+			Database: s.database,
+			Table:    s.table,
+			Chunk:    s.chunk,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return s.data.write(line, s.database)
+}
+
+func (s *dumpSplitter) dataFileName() string {
+	name := fmt.Sprintf("%s.%d.sql", url.PathEscape(s.table), s.chunk)
+	if s.database != "" {
+		name = fmt.Sprintf("%s.%s", url.PathEscape(s.database), name)
+	}
+	return path.Join(ParallelBackupDataDir, name)
+}
+
+func (s *dumpSplitter) createFile(meta ParallelBackupFile) (*dumpFile, error) {
+	f, err := newDumpFile(s.dir, meta)
+	if err != nil {
+		return nil, fmt.Errorf("error creating file %s: %v", meta.Name, err)
+	}
+	if err := f.writeRaw(s.preamble.String()); err != nil {
+		return nil, fmt.Errorf("error writing preamble to file %s: %v", meta.Name, err)
+	}
+	return f, nil
+}
+
+func (s *dumpSplitter) closeData() error {
+	if s.data == nil {
+		return nil
+	}
+	meta, err := s.data.close()
+	if err != nil {
+		return err
+	}
+	s.dataFiles = append(s.dataFiles, *meta)
+	s.data = nil
+	return nil
+}
+
+func (s *dumpSplitter) finish() error {
+	if s.inPreamble {
+		// Otherwise, the whole dump would be copied as preamble into the schema and post-data files.
+		return errors.New("no sections found in the dump. Make sure that comments are not disabled, for instance via --skip-comments or --compact")
+	}
+	if err := s.closeData(); err != nil {
+		return err
+	}
+	schema, err := s.schema.close()
+	if err != nil {
+		return err
+	}
+	postData, err := s.postData.close()
+	if err != nil {
+		return err
+	}
+	s.schema = nil
+	s.postData = nil
+
+	s.manifest.Files = append([]ParallelBackupFile{*schema}, s.dataFiles...)
+	s.manifest.Files = append(s.manifest.Files, *postData)
+	return nil
+}
+
+func (s *dumpSplitter) closeAll() {
+	for _, f := range []*dumpFile{s.schema, s.postData, s.data} {
+		if f != nil {
+			f.file.Close()
+		}
+	}
+}
+
+type dumpFile struct {
+	meta        ParallelBackupFile
+	file        *os.File
+	writer      *bufio.Writer
+	hash        hash.Hash
+	size        int64
+	contentSize int64
+	database    string
+}
+
+func newDumpFile(dir string, meta ParallelBackupFile) (*dumpFile, error) {
+	file, err := os.Create(filepath.Join(dir, filepath.FromSlash(meta.Name)))
+	if err != nil {
+		return nil, err
+	}
+	return &dumpFile{
+		meta:   meta,
+		file:   file,
+		writer: bufio.NewWriter(file),
+		hash:   sha256.New(),
+	}, nil
+}
+
+// write writes a line, switching to the given database first if needed.
+func (f *dumpFile) write(line, database string) error {
+	if db, ok := parseUseStatement(strings.TrimRight(line, "\r\n")); ok {
+		f.database = db
+	} else if database != "" && database != f.database {
+		if err := f.writeRaw(fmt.Sprintf("USE %s;\n", quoteIdentifier(database))); err != nil {
+			return err
+		}
+		f.database = database
+	}
+	f.contentSize += int64(len(line))
+	return f.writeRaw(line)
+}
+
+func (f *dumpFile) writeRaw(s string) error {
+	n, err := io.WriteString(io.MultiWriter(f.writer, f.hash), s)
+	f.size += int64(n)
+	return err
+}
+
+func (f *dumpFile) close() (*ParallelBackupFile, error) {
+	if err := f.writer.Flush(); err != nil {
+		return nil, fmt.Errorf("error flushing file %s: %v", f.meta.Name, err)
+	}
+	if err := f.file.Close(); err != nil {
+		return nil, fmt.Errorf("error closing file %s: %v", f.meta.Name, err)
+	}
+	meta := f.meta
+	meta.Size = f.size
+	meta.SHA256 = hex.EncodeToString(f.hash.Sum(nil))
+	return &meta, nil
+}
+
+func isInsertStatement(line string) bool {
+	return strings.HasPrefix(line, "INSERT ") || strings.HasPrefix(line, "REPLACE ")
+}
+
+func isTableLockStatement(line string) bool {
+	return strings.HasPrefix(line, "LOCK TABLES ") ||
+		line == "UNLOCK TABLES;" ||
+		(strings.HasPrefix(line, "/*!40000 ALTER TABLE ") && strings.HasSuffix(line, "KEYS */;"))
+}
+
+func parseUseStatement(line string) (string, bool) {
+	if !strings.HasPrefix(line, "USE `") || !strings.HasSuffix(line, "`;") {
+		return "", false
+	}
+	return parseIdentifier(line)
+}
+
+// parseIdentifier parses the backtick quoted identifier of a line.
+func parseIdentifier(line string) (string, bool) {
+	start := strings.Index(line, "`")
+	end := strings.LastIndex(line, "`")
+	if start == -1 || end <= start {
+		return "", false
+	}
+	return strings.ReplaceAll(line[start+1:end], "``", "`"), true
+}
+
+func quoteIdentifier(identifier string) string {
+	return "`" + strings.ReplaceAll(identifier, "`", "``") + "`"
+}
@@ -0,0 +1,303 @@
+package backup
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const testDump = `/*M!999999\- enable the sandbox mode */
+-- MariaDB dump 10.19  Distrib 11.4.2-MariaDB, for debian-linux-gnu (x86_64)
+--
+-- Host: mariadb    Database:
+-- ------------------------------------------------------
+-- Server version	11.4.2-MariaDB-ubu2404-log
+
+/*!40101 SET @OLD_CHARACTER_SET_CLIENT=@@CHARACTER_SET_CLIENT */;
+/*!40014 SET @OLD_FOREIGN_KEY_CHECKS=@@FOREIGN_KEY_CHECKS, FOREIGN_KEY_CHECKS=0 */;
+
+--
+-- GTID to start replication from
+--
+
+-- SET GLOBAL gtid_slave_pos='0-10-42';
+
+--
+-- Current Database: ` + "`db`" + `
+--
+
+CREATE DATABASE /*!32312 IF NOT EXISTS*/ ` + "`db`" + ` /*!40100 DEFAULT CHARACTER SET utf8mb4 */;
+
+USE ` + "`db`" + `;
+
+--
+-- Table structure for table ` + "`users`" + `
+--
+
+DROP TABLE IF EXISTS ` + "`users`" + `;
+CREATE TABLE ` + "`users`" + ` (
+  ` + "`id`" + ` int(11) NOT NULL
+) ENGINE=InnoDB;
+
+--
+-- Dumping data for table ` + "`users`" + `
+--
+
+LOCK TABLES ` + "`users`" + ` WRITE;
+/*!40000 ALTER TABLE ` + "`users`" + ` DISABLE KEYS */;
+INSERT INTO ` + "`users`" + ` VALUES (1),(2);
+INSERT INTO ` + "`users`" + ` VALUES (3),(4);
+INSERT INTO ` + "`users`" + ` VALUES (5),(6);
+/*!40000 ALTER TABLE ` + "`users`" + ` ENABLE KEYS */;
+UNLOCK TABLES;
+/*!50003 CREATE*/ /*!50003 TRIGGER users_ai AFTER INSERT ON users FOR EACH ROW SET @n = 1 */;
+
+--
+-- Table structure for table ` + "`empty`" + `
+--
+
+CREATE TABLE ` + "`empty`" + ` (
+  ` + "`id`" + ` int(11) NOT NULL
+) ENGINE=InnoDB;
+
+--
+-- Dumping data for table ` + "`empty`" + `
+--
+
+LOCK TABLES ` + "`empty`" + ` WRITE;
+UNLOCK TABLES;
+
+--
+-- Dumping routines for database 'db'
+--
+
+CREATE PROCEDURE ` + "`noop`" + `() SELECT 1 ;
+/*!40014 SET FOREIGN_KEY_CHECKS=@OLD_FOREIGN_KEY_CHECKS */;
+
+-- Dump completed on 2025-01-01  0:00:00
+`
+
+func TestSplitDump(t *testing.T) {
+	tests := []struct {
+		name          string
+		chunkSize     int64
+		wantDataFiles []string
+	}{
+		{
+			name:      "no chunks",
+			chunkSize: 0,
+			wantDataFiles: []string{
+				"data/db.users.0.sql",
+			},
+		},
+		{
+			name:      "chunks",
+			chunkSize: 80,
+			wantDataFiles: []string{
+				"data/db.users.0.sql",
+				"data/db.users.1.sql",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			manifest, err := SplitDump(strings.NewReader(testDump), dir, tt.chunkSize)
+			if err != nil {
+				t.Fatalf("unexpected error splitting dump: %v", err)
+			}
+			if manifest.GTID != "0-10-42" {
+				t.Fatalf("unexpected GTID, expected: %v got: %v", "0-10-42", manifest.GTID)
+			}
+			if !reflect.DeepEqual([]string{"db"}, manifest.Databases) {
+				t.Fatalf("unexpected databases, expected: %v got: %v", []string{"db"}, manifest.Databases)
+			}
+
+			var files, dataFiles []string
+			for _, f := range manifest.Files {
+				files = append(files, f.Name)
+				if f.Type == ParallelBackupFileTypeData {
+					dataFiles = append(dataFiles, f.Name)
+					if f.Database != "db" || f.Table != "users" {
+						t.Fatalf("unexpected data file metadata: %v", f)
+					}
+				}
+			}
+			if files[0] != ParallelBackupSchemaFileName || files[len(files)-1] != ParallelBackupPostDataFileName {
+				t.Fatalf("unexpected file order: %v", files)
+			}
+			if !reflect.DeepEqual(tt.wantDataFiles, dataFiles) {
+				t.Fatalf("unexpected data files, expected: %v got: %v", tt.wantDataFiles, dataFiles)
+			}
+			if err := manifest.Verify(dir); err != nil {
+				t.Fatalf("unexpected error verifying manifest: %v", err)
+			}
+
+			schema := readTestFile(t, filepath.Join(dir, ParallelBackupSchemaFileName))
+			assertContains(t, schema, "SET @OLD_FOREIGN_KEY_CHECKS")
+			assertContains(t, schema, "CREATE TABLE `users`")
+			assertNotContains(t, schema, "INSERT INTO")
+			assertNotContains(t, schema, "TRIGGER")
+
+			var data string
+			for _, f := range dataFiles {
+				content := readTestFile(t, filepath.Join(dir, f))
+				assertContains(t, content, "SET @OLD_FOREIGN_KEY_CHECKS")
+				assertContains(t, content, "USE `db`;")
+				assertNotContains(t, content, "LOCK TABLES")
+				data += content
+			}
+			for _, values := range []string{"(1),(2)", "(3),(4)", "(5),(6)"} {
+				assertContains(t, data, values)
+			}
+
+			postData := readTestFile(t, filepath.Join(dir, ParallelBackupPostDataFileName))
+			assertContains(t, postData, "USE `db`;")
+			assertContains(t, postData, "TRIGGER users_ai")
+			assertContains(t, postData, "CREATE PROCEDURE `noop`")
+			assertNotContains(t, postData, "INSERT INTO")
+			if strings.Index(postData, "USE `db`;") > strings.Index(postData, "TRIGGER users_ai") {
+				t.Fatal("expected database to be selected before creating the trigger")
+			}
+		})
+	}
+}
+
+func TestParallelBackupManifestVerify(t *testing.T) {
+	dir := t.TempDir()
+	manifest, err := SplitDump(strings.NewReader(testDump), dir, 0)
+	if err != nil {
+		t.Fatalf("unexpected error splitting dump: %v", err)
+	}
+	manifestPath := filepath.Join(dir, ParallelBackupManifestFileName)
+	if err := manifest.Write(manifestPath); err != nil {
+		t.Fatalf("unexpected error writing manifest: %v", err)
+	}
+	readManifest, err := ReadParallelBackupManifest(manifestPath)
+	if err != nil {
+		t.Fatalf("unexpected error reading manifest: %v", err)
+	}
+	if !reflect.DeepEqual(manifest, readManifest) {
+		t.Fatalf("unexpected manifest, expected: %v got: %v", manifest, readManifest)
+	}
+
+	dataPath := filepath.Join(dir, "data", "db.users.0.sql")
+	if err := os.WriteFile(dataPath, []byte("INSERT INTO `users` VALUES (7);\n"), 0644); err != nil {
+		t.Fatalf("unexpected error tampering data file: %v", err)
+	}
+	if err := readManifest.Verify(dir); err == nil {
+		t.Fatal("expected error verifying tampered backup, got nil")
+	}
+
+	if err := os.Remove(dataPath); err != nil {
+		t.Fatalf("unexpected error removing data file: %v", err)
+	}
+	if err := readManifest.Verify(dir); err == nil {
+		t.Fatal("expected error verifying incomplete backup, got nil")
+	}
+}
+
+func TestArchiveDir(t *testing.T) {
+	dir := t.TempDir()
+	manifest, err := SplitDump(strings.NewReader(testDump), dir, 0)
+	if err != nil {
+		t.Fatalf("unexpected error splitting dump: %v", err)
+	}
+	archivePath := filepath.Join(t.TempDir(), "backup.2025-01-01T00:00:00Z.tar")
+	if err := ArchiveDir(dir, archivePath); err != nil {
+		t.Fatalf("unexpected error archiving directory: %v", err)
+	}
+
+	extractDir := t.TempDir()
+	if err := ExtractArchive(archivePath, extractDir); err != nil {
+		t.Fatalf("unexpected error extracting archive: %v", err)
+	}
+	if err := manifest.Verify(extractDir); err != nil {
+		t.Fatalf("unexpected error verifying extracted archive: %v", err)
+	}
+	for _, f := range manifest.Files {
+		if _, err := os.Stat(filepath.Join(dir, filepath.FromSlash(f.Name))); !os.IsNotExist(err) {
+			t.Fatalf("expected file %s to be removed after being archived, got: %v", f.Name, err)
+		}
+	}
+}
+
+func TestSplitDumpWithoutComments(t *testing.T) {
+	dump := `/*!40101 SET @OLD_CHARACTER_SET_CLIENT=@@CHARACTER_SET_CLIENT */;
+CREATE TABLE ` + "`users`" + ` (
+  ` + "`id`" + ` int(11) NOT NULL
+) ENGINE=InnoDB;
+INSERT INTO ` + "`users`" + ` VALUES (1),(2);
+`
+	if _, err := SplitDump(strings.NewReader(dump), t.TempDir(), 0); err == nil {
+		t.Fatal("expected error splitting dump without comments, got nil")
+	}
+}
+
+func TestIsParallelBackupFile(t *testing.T) {
+	tests := []struct {
+		name         string
+		fileName     string
+		wantParallel bool
+	}{
+		{
+			name:         "empty",
+			fileName:     "",
+			wantParallel: false,
+		},
+		{
+			name:         "sql",
+			fileName:     "backup.2025-01-01T00:00:00Z.sql",
+			wantParallel: false,
+		},
+		{
+			name:         "compressed sql",
+			fileName:     "backup.2025-01-01T00:00:00Z.sql.gz",
+			wantParallel: false,
+		},
+		{
+			name:         "tar",
+			fileName:     "backup.2025-01-01T00:00:00Z.tar",
+			wantParallel: true,
+		},
+		{
+			name:         "compressed tar with path",
+			fileName:     "/backup/backup.2025-01-01T00:00:00Z.tar.zst",
+			wantParallel: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if parallel := IsParallelBackupFile(tt.fileName); parallel != tt.wantParallel {
+				t.Fatalf("unexpected parallel backup file, expected: %v got: %v", tt.wantParallel, parallel)
+			}
+		})
+	}
+}
+
+func readTestFile(t *testing.T, filePath string) string {
+	t.Helper()
+	bytes, err := os.ReadFile(filePath)
+	if err != nil {
+		t.Fatalf("unexpected error reading file %s: %v", filePath, err)
+	}
+	return string(bytes)
+}
+
+func assertContains(t *testing.T, s, substr string) {
+	t.Helper()
+	if !strings.Contains(s, substr) {
+		t.Fatalf("expected %q to be contained in:\n%s", substr, s)
+	}
+}
+
+func assertNotContains(t *testing.T, s, substr string) {
+	t.Helper()
+	if strings.Contains(s, substr) {
+		t.Fatalf("expected %q not to be contained in:\n%s", substr, s)
+	}
+}
@@ -0,0 +1,131 @@
+package backup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"sort"
+	"time"
+
+	"github.com/go-logr/logr"
+	mariadbv1alpha1 "github.com/mariadb-operator/mariadb-operator/v26/api/v1alpha1"
+	"github.com/mariadb-operator/mariadb-operator/v26/pkg/replication"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// GetBackupTargetFileWithGtid returns the most recent backup file whose GTID, as recorded in its Manifest, is not after the target GTID.
+// Backups without a Manifest or without a GTID in the target domain are skipped.
+func GetBackupTargetFileWithGtid(ctx context.Context, backupFileNames []string, processor BackupProcessor, manifestFn ManifestFn,
+	targetGtid *replication.Gtid, backupLogger logr.Logger) (string, error) {
+	if targetGtid == nil {
+		return "", errors.New("target GTID must be set")
+	}
+	logger := backupLogger.WithValues(
+		"target-gtid", targetGtid.String(),
+	)
+	type datedBackup struct {
+		fileName string
+		date     time.Time
+	}
+	var backups []datedBackup
+	for _, fileName := range backupFileNames {
+		date, err := processor.parseDateInBackupFile(fileName)
+		if err != nil {
+			logger.Error(err, "error parsing backup date. Skipping", "backup", fileName)
+			continue
+		}
+		backups = append(backups, datedBackup{
+			fileName: fileName,
+			date:     date,
+		})
+	}
+	sort.SliceStable(backups, func(i, j int) bool {
+		return backups[i].date.After(backups[j].date)
+	})
+
+	for _, backup := range backups {
+		manifest, err := manifestFn(ctx, backup.fileName)
+		if err != nil {
+			logger.Error(err, "error getting manifest. Skipping", "backup", backup.fileName)
+			continue
+		}
+		if manifest == nil || manifest.GTID == "" {
+			logger.V(1).Info("Backup does not have a GTID. Skipping", "backup", backup.fileName)
+			continue
+		}
+		gtid, err := replication.ParseGtidWithDomainId(manifest.GTID, targetGtid.DomainID, logger)
+		if err != nil {
+			logger.V(1).Info("Unable to get backup GTID. Skipping", "backup", backup.fileName, "err", err)
+			continue
+		}
+		isAfter, err := gtid.GreaterThan(targetGtid)
+		if err != nil {
+			logger.V(1).Info("Unable to compare backup GTID. Skipping", "backup", backup.fileName, "err", err)
+			continue
+		}
+		if isAfter {
+			logger.V(1).Info("Backup is after target recovery GTID. Skipping", "backup", backup.fileName, "gtid", gtid.String())
+			continue
+		}
+		return backup.fileName, nil
+	}
+	return "", errors.New("no valid backup files were found")
+}
+
+// GetRestorePlan computes the backup that a restoration to the target recovery time, or to the target recovery GTID when provided,
+// would use, without restoring any data.
+// The GTID of the backup is read from its Manifest, and the incremental backup chain from the chain index, which is optional.
+func GetRestorePlan(ctx context.Context, backupFileNames []string, processor BackupProcessor, manifestFn ManifestFn,
+	chainIndex *ChainIndex, targetRecoveryTime time.Time, targetRecoveryGtid *replication.Gtid,
+	logger logr.Logger) (*mariadbv1alpha1.RestorePlan, error) {
+	var (
+		backupFileName string
+		err            error
+	)
+	if targetRecoveryGtid != nil {
+		backupFileName, err = GetBackupTargetFileWithGtid(ctx, backupFileNames, processor, manifestFn, targetRecoveryGtid, logger)
+	} else {
+		backupFileName, err = processor.GetBackupTargetFile(backupFileNames, targetRecoveryTime, logger)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error getting backup target file: %v", err)
+	}
+	backupTime, err := processor.parseDateInBackupFile(backupFileName)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing backup date: %v", err)
+	}
+
+	plan := &mariadbv1alpha1.RestorePlan{
+		TargetRecoveryTime: metav1.NewTime(targetRecoveryTime.UTC()),
+		Backup:             backupFileName,
+		BackupTime:         metav1.NewTime(backupTime.UTC()),
+	}
+	if targetRecoveryGtid != nil {
+		plan.TargetRecoveryGtid = targetRecoveryGtid.String()
+	}
+
+	if chainIndex != nil {
+		if _, ok := chainIndex.Get(backupFileName); ok {
+			chain, err := chainIndex.Chain(backupFileName)
+			if err != nil {
+				return nil, fmt.Errorf("error getting backup chain: %v", err)
+			}
+			if len(chain) > 1 {
+				dir := path.Dir(backupFileName)
+				for _, link := range chain {
+					plan.BackupChain = append(plan.BackupChain, path.Join(dir, link.FileName))
+				}
+			}
+		}
+	}
+
+	manifest, err := manifestFn(ctx, backupFileName)
+	if err != nil {
+		logger.Error(err, "error getting manifest. Computing plan without backup GTID", "backup", backupFileName)
+	}
+	if manifest != nil {
+		plan.BackupGtid = manifest.GTID
+	}
+	return plan, nil
+}
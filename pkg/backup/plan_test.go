@@ -0,0 +1,309 @@
+package backup
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	mariadbv1alpha1 "github.com/mariadb-operator/mariadb-operator/v26/api/v1alpha1"
+	"github.com/mariadb-operator/mariadb-operator/v26/pkg/replication"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestGetRestorePlan(t *testing.T) {
+	manifests := map[string]*Manifest{
+		"backup.2023-12-22T12:00:00Z.sql.gz": {
+			GTID: "0-10-42",
+		},
+		"physicalbackup-20231222120000.xb": {
+			GTID: "0-10-84",
+		},
+	}
+	manifestFn := func(ctx context.Context, fileName string) (*Manifest, error) {
+		if fileName == "backup.2023-12-21T10:00:00Z.sql" {
+			return nil, errors.New("test error")
+		}
+		return manifests[fileName], nil
+	}
+	chainIndex := &ChainIndex{
+		Backups: []mariadbv1alpha1.PhysicalBackupChainLink{
+			{
+				FileName: "physicalbackup-20231222100000.xb",
+				Type:     mariadbv1alpha1.PhysicalBackupTypeFull,
+			},
+			{
+				FileName:       "physicalbackup-20231222110000.xb",
+				ParentFileName: "physicalbackup-20231222100000.xb",
+				Type:           mariadbv1alpha1.PhysicalBackupTypeIncremental,
+			},
+			{
+				FileName:       "physicalbackup-20231222120000.xb",
+				ParentFileName: "physicalbackup-20231222110000.xb",
+				Type:           mariadbv1alpha1.PhysicalBackupTypeIncremental,
+			},
+		},
+	}
+	mustParseTime := func(s string) time.Time {
+		tt, err := time.Parse(time.RFC3339, s)
+		if err != nil {
+			t.Fatalf("unexpected error parsing time: %v", err)
+		}
+		return tt
+	}
+
+	tests := []struct {
+		name           string
+		processor      BackupProcessor
+		backupFiles    []string
+		chainIndex     *ChainIndex
+		targetRecovery time.Time
+		targetGtid     *replication.Gtid
+		wantPlan       *mariadbv1alpha1.RestorePlan
+		wantErr        bool
+	}{
+		{
+			name:           "no backups",
+			processor:      NewLogicalBackupProcessor(),
+			targetRecovery: mustParseTime("2023-12-22T13:00:00Z"),
+			wantErr:        true,
+		},
+		{
+			name:      "logical backup with manifest",
+			processor: NewLogicalBackupProcessor(),
+			backupFiles: []string{
+				"backup.2023-12-21T10:00:00Z.sql",
+				"backup.2023-12-22T12:00:00Z.sql.gz",
+				"backup.2023-12-23T10:00:00Z.sql.bz2",
+			},
+			targetRecovery: mustParseTime("2023-12-22T13:00:00Z"),
+			wantPlan: &mariadbv1alpha1.RestorePlan{
+				TargetRecoveryTime: metav1.NewTime(mustParseTime("2023-12-22T13:00:00Z")),
+				Backup:             "backup.2023-12-22T12:00:00Z.sql.gz",
+				BackupTime:         metav1.NewTime(mustParseTime("2023-12-22T12:00:00Z")),
+				BackupGtid:         "0-10-42",
+			},
+		},
+		{
+			name:      "logical backup with manifest error",
+			processor: NewLogicalBackupProcessor(),
+			backupFiles: []string{
+				"backup.2023-12-21T10:00:00Z.sql",
+				"backup.2023-12-22T12:00:00Z.sql.gz",
+			},
+			targetRecovery: mustParseTime("2023-12-21T11:00:00Z"),
+			wantPlan: &mariadbv1alpha1.RestorePlan{
+				TargetRecoveryTime: metav1.NewTime(mustParseTime("2023-12-21T11:00:00Z")),
+				Backup:             "backup.2023-12-21T10:00:00Z.sql",
+				BackupTime:         metav1.NewTime(mustParseTime("2023-12-21T10:00:00Z")),
+			},
+		},
+		{
+			name:      "incremental physical backup",
+			processor: NewPhysicalBackupProcessor(),
+			backupFiles: []string{
+				"physicalbackup-20231222100000.xb",
+				"physicalbackup-20231222110000.xb",
+				"physicalbackup-20231222120000.xb",
+			},
+			chainIndex:     chainIndex,
+			targetRecovery: mustParseTime("2023-12-22T13:00:00Z"),
+			wantPlan: &mariadbv1alpha1.RestorePlan{
+				TargetRecoveryTime: metav1.NewTime(mustParseTime("2023-12-22T13:00:00Z")),
+				Backup:             "physicalbackup-20231222120000.xb",
+				BackupTime:         metav1.NewTime(mustParseTime("2023-12-22T12:00:00Z")),
+				BackupChain: []string{
+					"physicalbackup-20231222100000.xb",
+					"physicalbackup-20231222110000.xb",
+					"physicalbackup-20231222120000.xb",
+				},
+				BackupGtid: "0-10-84",
+			},
+		},
+		{
+			name:      "full physical backup",
+			processor: NewPhysicalBackupProcessor(),
+			backupFiles: []string{
+				"physicalbackup-20231222100000.xb",
+				"physicalbackup-20231222110000.xb",
+			},
+			chainIndex:     chainIndex,
+			targetRecovery: mustParseTime("2023-12-22T10:30:00Z"),
+			wantPlan: &mariadbv1alpha1.RestorePlan{
+				TargetRecoveryTime: metav1.NewTime(mustParseTime("2023-12-22T10:30:00Z")),
+				Backup:             "physicalbackup-20231222100000.xb",
+				BackupTime:         metav1.NewTime(mustParseTime("2023-12-22T10:00:00Z")),
+			},
+		},
+		{
+			name:      "physical backup without chain index",
+			processor: NewPhysicalBackupProcessor(),
+			backupFiles: []string{
+				"physicalbackup-20231222120000.xb",
+			},
+			targetRecovery: mustParseTime("2023-12-22T13:00:00Z"),
+			wantPlan: &mariadbv1alpha1.RestorePlan{
+				TargetRecoveryTime: metav1.NewTime(mustParseTime("2023-12-22T13:00:00Z")),
+				Backup:             "physicalbackup-20231222120000.xb",
+				BackupTime:         metav1.NewTime(mustParseTime("2023-12-22T12:00:00Z")),
+				BackupGtid:         "0-10-84",
+			},
+		},
+		{
+			name:      "logical backup with target GTID",
+			processor: NewLogicalBackupProcessor(),
+			backupFiles: []string{
+				"backup.2023-12-21T10:00:00Z.sql",
+				"backup.2023-12-22T12:00:00Z.sql.gz",
+				"backup.2023-12-23T10:00:00Z.sql.bz2",
+			},
+			targetRecovery: mustParseTime("2023-12-24T10:00:00Z"),
+			targetGtid: &replication.Gtid{
+				DomainID:   0,
+				ServerID:   10,
+				SequenceID: 50,
+			},
+			wantPlan: &mariadbv1alpha1.RestorePlan{
+				TargetRecoveryTime: metav1.NewTime(mustParseTime("2023-12-24T10:00:00Z")),
+				TargetRecoveryGtid: "0-10-50",
+				Backup:             "backup.2023-12-22T12:00:00Z.sql.gz",
+				BackupTime:         metav1.NewTime(mustParseTime("2023-12-22T12:00:00Z")),
+				BackupGtid:         "0-10-42",
+			},
+		},
+		{
+			name:      "target GTID before backups",
+			processor: NewLogicalBackupProcessor(),
+			backupFiles: []string{
+				"backup.2023-12-22T12:00:00Z.sql.gz",
+			},
+			targetRecovery: mustParseTime("2023-12-24T10:00:00Z"),
+			targetGtid: &replication.Gtid{
+				DomainID:   0,
+				ServerID:   10,
+				SequenceID: 10,
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plan, err := GetRestorePlan(context.Background(), tt.backupFiles, tt.processor, manifestFn, tt.chainIndex,
+				tt.targetRecovery, tt.targetGtid, logr.Discard())
+			if tt.wantErr && err == nil {
+				t.Fatal("expecting error to be non nil")
+			}
+			if !tt.wantErr && err != nil {
+				t.Fatalf("expecting error to be nil, got: %v", err)
+			}
+			if !reflect.DeepEqual(plan, tt.wantPlan) {
+				t.Errorf("unexpected plan:\nwant: %v\ngot:  %v", tt.wantPlan, plan)
+			}
+		})
+	}
+}
+
+func TestGetBackupTargetFileWithGtid(t *testing.T) {
+	manifests := map[string]*Manifest{
+		"physicalbackup-20231222100000.xb": {
+			GTID: "0-10-10",
+		},
+		"physicalbackup-20231222110000.xb": {
+			GTID: "0-10-20,1-10-5",
+		},
+		"physicalbackup-20231222120000.xb": {},
+		"physicalbackup-20231222130000.xb": {
+			GTID: "0-10-30",
+		},
+	}
+	manifestFn := func(ctx context.Context, fileName string) (*Manifest, error) {
+		if fileName == "physicalbackup-20231222140000.xb" {
+			return nil, errors.New("test error")
+		}
+		return manifests[fileName], nil
+	}
+	backupFiles := []string{
+		"physicalbackup-20231222100000.xb",
+		"physicalbackup-20231222110000.xb",
+		"physicalbackup-20231222120000.xb",
+		"physicalbackup-20231222130000.xb",
+		"physicalbackup-20231222140000.xb",
+		"physicalbackup-20231222150000.xb",
+	}
+
+	tests := []struct {
+		name       string
+		targetGtid *replication.Gtid
+		wantFile   string
+		wantErr    bool
+	}{
+		{
+			name:    "nil GTID",
+			wantErr: true,
+		},
+		{
+			name: "GTID before backups",
+			targetGtid: &replication.Gtid{
+				DomainID:   0,
+				ServerID:   10,
+				SequenceID: 5,
+			},
+			wantErr: true,
+		},
+		{
+			name: "GTID matching backup",
+			targetGtid: &replication.Gtid{
+				DomainID:   0,
+				ServerID:   10,
+				SequenceID: 20,
+			},
+			wantFile: "physicalbackup-20231222110000.xb",
+		},
+		{
+			name: "GTID between backups",
+			targetGtid: &replication.Gtid{
+				DomainID:   0,
+				ServerID:   10,
+				SequenceID: 25,
+			},
+			wantFile: "physicalbackup-20231222110000.xb",
+		},
+		{
+			name: "GTID after backups",
+			targetGtid: &replication.Gtid{
+				DomainID:   0,
+				ServerID:   10,
+				SequenceID: 100,
+			},
+			wantFile: "physicalbackup-20231222130000.xb",
+		},
+		{
+			name: "GTID in another domain",
+			targetGtid: &replication.Gtid{
+				DomainID:   1,
+				ServerID:   10,
+				SequenceID: 100,
+			},
+			wantFile: "physicalbackup-20231222110000.xb",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			file, err := GetBackupTargetFileWithGtid(context.Background(), backupFiles, NewPhysicalBackupProcessor(), manifestFn,
+				tt.targetGtid, logr.Discard())
+			if tt.wantErr && err == nil {
+				t.Fatal("expecting error to be non nil")
+			}
+			if !tt.wantErr && err != nil {
+				t.Fatalf("expecting error to be nil, got: %v", err)
+			}
+			if file != tt.wantFile {
+				t.Errorf("unexpected file: want %s, got %s", tt.wantFile, file)
+			}
+		})
+	}
+}
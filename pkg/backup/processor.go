@@ -15,7 +15,7 @@ import (
 
 type BackupProcessor interface {
 	GetBackupTargetFile(backupFileNames []string, targetRecoveryTime time.Time, logger logr.Logger) (string, error)
-	GetOldBackupFiles(backupFileNames []string, maxRetention time.Duration, logger logr.Logger) []string
+	GetOldBackupFiles(backupFileNames []string, policy RetentionPolicy, logger logr.Logger) []string
 	IsValidBackupFile(fileName string) bool
 	ParseCompressionAlgorithm(fileName string) (mariadbv1alpha1.CompressAlgorithm, error)
 	GetUncompressedBackupFile(compressedBackupFile string) (string, error)
@@ -74,27 +74,16 @@ func (p *LogicalBackupProcessor) GetBackupTargetFile(backupFileNames []string, t
 }
 
 // GetOldBackupFiles determines which backup files should be deleted according with the retention policy.
-func (p *LogicalBackupProcessor) GetOldBackupFiles(backupFileNames []string, maxRetention time.Duration, logger logr.Logger) []string {
-	var oldBackups []string
-	now := now()
-	for _, file := range backupFileNames {
-		backupDate, err := p.parseDateInBackupFile(file)
-		if err != nil {
-			logger.Error(err, "error parsing backup date. Skipping", "file", file)
-			continue
-		}
-		if now.Sub(backupDate) > maxRetention {
-			oldBackups = append(oldBackups, file)
-		}
-	}
-	return oldBackups
+func (p *LogicalBackupProcessor) GetOldBackupFiles(backupFileNames []string, policy RetentionPolicy, logger logr.Logger) []string {
+	return getOldBackupFiles(backupFileNames, policy, p.parseDateInBackupFile, logger)
 }
 
 // IsValidBackupFile determines whether a backup file name is valid.
 func (p *LogicalBackupProcessor) IsValidBackupFile(fileName string) bool {
 	// Must start with "backup." and contain ".sql" either as suffix (uncompressed)
 	// or before the compression extension (e.g. ".sql.gz", ".sql.bz2").
-	if !strings.HasPrefix(fileName, "backup.") || !strings.Contains(fileName, ".sql") {
+	// Parallel backups are archives that contain ".tar" instead (e.g. ".tar", ".tar.gz").
+	if !strings.HasPrefix(fileName, "backup.") || (!strings.Contains(fileName, ".sql") && !strings.Contains(fileName, ".tar")) {
 		return false
 	}
 	_, err := p.ParseCompressionAlgorithm(fileName)
@@ -106,7 +95,8 @@ func (p *LogicalBackupProcessor) IsValidBackupFile(fileName string) bool {
 }
 
 // ParseCompressionAlrogrithm gets the compression algorithm from the backup file name.
-// Supports both new format (backup.timestamp.sql.gz) and legacy format (backup.timestamp.gzip.sql).
+// Supports both new format (backup.timestamp.sql.gz) and legacy format (backup.timestamp.gzip.sql),
+// as well as parallel backup archives (backup.timestamp.tar.gz).
 func (p *LogicalBackupProcessor) ParseCompressionAlgorithm(fileName string) (mariadbv1alpha1.CompressAlgorithm, error) {
 	parts := strings.Split(fileName, ".")
 	// No compression: backup.timestamp.sql
@@ -118,7 +108,7 @@ func (p *LogicalBackupProcessor) ParseCompressionAlgorithm(fileName string) (mar
 	}
 
 	// New format: backup.timestamp.sql.gz (extension at the end)
-	if parts[2] == "sql" {
+	if parts[2] == "sql" || parts[2] == parallelBackupExtension {
 		return mariadbv1alpha1.CompressionFromExtension(parts[3])
 	}
 
@@ -131,7 +121,8 @@ func (p *LogicalBackupProcessor) ParseCompressionAlgorithm(fileName string) (mar
 }
 
 // GetUncompressedBackupFile get the backup file without compression extension.
-// Supports both new format (backup.timestamp.sql.gz) and legacy format (backup.timestamp.gzip.sql).
+// Supports both new format (backup.timestamp.sql.gz) and legacy format (backup.timestamp.gzip.sql),
+// as well as parallel backup archives (backup.timestamp.tar.gz).
 func (p *LogicalBackupProcessor) GetUncompressedBackupFile(compressedBackupFile string) (string, error) {
 	parts := strings.Split(compressedBackupFile, ".")
 	if len(parts) != 4 {
@@ -139,7 +130,7 @@ func (p *LogicalBackupProcessor) GetUncompressedBackupFile(compressedBackupFile
 	}
 
 	// New format: backup.timestamp.sql.gz -> backup.timestamp.sql
-	if parts[2] == "sql" {
+	if parts[2] == "sql" || parts[2] == parallelBackupExtension {
 		if _, err := mariadbv1alpha1.CompressionFromExtension(parts[3]); err != nil {
 			return "", err
 		}
@@ -233,20 +224,8 @@ func (p *PhysicalBackupProcessor) GetBackupTargetFile(backupFileNames []string,
 }
 
 // GetOldBackupFiles determines which backup files should be deleted according with the retention policy.
-func (p *PhysicalBackupProcessor) GetOldBackupFiles(backupFileNames []string, maxRetention time.Duration, logger logr.Logger) []string {
-	var oldBackups []string
-	now := now()
-	for _, file := range backupFileNames {
-		backupDate, err := p.parseDateInBackupFile(file)
-		if err != nil {
-			logger.Error(err, "error parsing backup date. Skipping", "file", file)
-			continue
-		}
-		if now.Sub(backupDate) > maxRetention {
-			oldBackups = append(oldBackups, file)
-		}
-	}
-	return oldBackups
+func (p *PhysicalBackupProcessor) GetOldBackupFiles(backupFileNames []string, policy RetentionPolicy, logger logr.Logger) []string {
+	return getOldBackupFiles(backupFileNames, policy, p.parseDateInBackupFile, logger)
 }
 
 // IsValidBackupFile determines whether a backup file name is valid.
@@ -138,6 +138,18 @@ func TestLogicalGetTargetFile(t *testing.T) {
 			wantFile:       "backup.2023-12-18T16:07:00Z.sql",
 			wantErr:        false,
 		},
+		{
+			name: "mixed parallel backups",
+			backupFiles: []string{
+				"backup.2023-12-18T15:58:00Z.sql.gz",
+				"backup.2023-12-18T16:00:00Z.tar.gz",
+				"backup.2023-12-18T16:03:00Z.sql.gz",
+				"backup.2023-12-18T16:07:00Z.tar.gz",
+			},
+			targetRecovery: mustParseDate(t, "2023-12-18T16:02:00Z"),
+			wantFile:       "backup.2023-12-18T16:00:00Z.tar.gz",
+			wantErr:        false,
+		},
 	}
 
 	for _, tt := range tests {
@@ -279,7 +291,7 @@ func TestLogicalGetOldBackupFiles(t *testing.T) {
 				now = previousNowFn
 			})
 
-			backups := p.GetOldBackupFiles(tt.backupFiles, tt.maxRetention, logger)
+			backups := p.GetOldBackupFiles(tt.backupFiles, NewMaxRetentionPolicy(tt.maxRetention), logger)
 			if !reflect.DeepEqual(tt.wantBackups, backups) {
 				t.Fatalf("unexpected backup files, expected: %v got: %v", tt.wantBackups, backups)
 			}
@@ -344,6 +356,21 @@ func TestLogicalIsValidBackupFile(t *testing.T) {
 			backupFile: "backup.2023-12-18T16:14:00Z.sql.bz2",
 			wantValid:  true,
 		},
+		{
+			name:       "valid parallel",
+			backupFile: "backup.2023-12-18T16:14:00Z.tar",
+			wantValid:  true,
+		},
+		{
+			name:       "valid parallel with zstd compression",
+			backupFile: "backup.2023-12-18T16:14:00Z.tar.zst",
+			wantValid:  true,
+		},
+		{
+			name:       "invalid parallel compression",
+			backupFile: "backup.2023-12-18T16:14:00Z.tar.foo",
+			wantValid:  false,
+		},
 	}
 
 	for _, tt := range tests {
@@ -418,12 +445,36 @@ func TestLogicalParseCompressionAlgorithm(t *testing.T) {
 			wantCompress: mariadbv1alpha1.CompressBzip2,
 			wantErr:      false,
 		},
+		{
+			name:         "new format compression zst",
+			fileName:     "backup.2023-12-22T13:00:00Z.sql.zst",
+			wantCompress: mariadbv1alpha1.CompressZstd,
+			wantErr:      false,
+		},
+		{
+			name:         "new format compression lz4",
+			fileName:     "backup.2023-12-22T13:00:00Z.sql.lz4",
+			wantCompress: mariadbv1alpha1.CompressLz4,
+			wantErr:      false,
+		},
 		{
 			name:         "new format invalid extension",
 			fileName:     "backup.2023-12-22T13:00:00Z.sql.foo",
 			wantCompress: mariadbv1alpha1.CompressAlgorithm(""),
 			wantErr:      true,
 		},
+		{
+			name:         "parallel no compression",
+			fileName:     "backup.2023-12-22T13:00:00Z.tar",
+			wantCompress: mariadbv1alpha1.CompressNone,
+			wantErr:      false,
+		},
+		{
+			name:         "parallel compression gz",
+			fileName:     "backup.2023-12-22T13:00:00Z.tar.gz",
+			wantCompress: mariadbv1alpha1.CompressGzip,
+			wantErr:      false,
+		},
 	}
 
 	for _, tt := range tests {
@@ -510,6 +561,12 @@ func TestLogicalGetUncompressedBackupFile(t *testing.T) {
 			wantFileName: "",
 			wantErr:      true,
 		},
+		{
+			name:         "parallel compression lz4",
+			fileName:     "backup.2023-12-22T13:00:00Z.tar.lz4",
+			wantFileName: "backup.2023-12-22T13:00:00Z.tar",
+			wantErr:      false,
+		},
 	}
 
 	for _, tt := range tests {
@@ -785,7 +842,7 @@ func TestPhysicalGetOldBackupFiles(t *testing.T) {
 				now = previousNowFn
 			})
 
-			backups := p.GetOldBackupFiles(tt.backupFiles, tt.maxRetention, logger)
+			backups := p.GetOldBackupFiles(tt.backupFiles, NewMaxRetentionPolicy(tt.maxRetention), logger)
 			if !reflect.DeepEqual(tt.wantBackups, backups) {
 				t.Fatalf("unexpected backup files, expected: %v got: %v", tt.wantBackups, backups)
 			}
@@ -923,6 +980,18 @@ func TestPhysicalParseCompressionAlgorithm(t *testing.T) {
 			wantCompress: mariadbv1alpha1.CompressBzip2,
 			wantErr:      false,
 		},
+		{
+			name:         "zstd",
+			fileName:     "physicalbackup-20231222130000.xb.zst",
+			wantCompress: mariadbv1alpha1.CompressZstd,
+			wantErr:      false,
+		},
+		{
+			name:         "lz4 and prefix",
+			fileName:     "mariadb/physicalbackup-20231222130000.xb.lz4",
+			wantCompress: mariadbv1alpha1.CompressLz4,
+			wantErr:      false,
+		},
 	}
 
 	for _, tt := range tests {
@@ -1255,7 +1324,7 @@ func TestSnapshotGetOldBackupFiles(t *testing.T) {
 				now = previousNowFn
 			})
 
-			backups := p.GetOldBackupFiles(tt.backupFiles, tt.maxRetention, logger)
+			backups := p.GetOldBackupFiles(tt.backupFiles, NewMaxRetentionPolicy(tt.maxRetention), logger)
 			if !reflect.DeepEqual(tt.wantBackups, backups) {
 				t.Fatalf("unexpected backup files, expected: %v got: %v", tt.wantBackups, backups)
 			}
@@ -0,0 +1,224 @@
+package backup
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/go-logr/logr"
+	mariadbv1alpha1 "github.com/mariadb-operator/mariadb-operator/v26/api/v1alpha1"
+)
+
+// RetentionPolicy determines which backups should be kept.
+// When any of the Keep* rules is set, a grandfather-father-son policy is evaluated. Otherwise, MaxRetention is used.
+type RetentionPolicy struct {
+	MaxRetention time.Duration
+	KeepLast     int
+	KeepHourly   int
+	KeepDaily    int
+	KeepWeekly   int
+	KeepMonthly  int
+	KeepYearly   int
+}
+
+// NewMaxRetentionPolicy creates a RetentionPolicy that keeps the backups newer than maxRetention.
+func NewMaxRetentionPolicy(maxRetention time.Duration) RetentionPolicy {
+	return RetentionPolicy{
+		MaxRetention: maxRetention,
+	}
+}
+
+// NewRetentionPolicy creates a RetentionPolicy from the API types.
+// The grandfather-father-son policy takes precedence over maxRetention when provided.
+func NewRetentionPolicy(maxRetention time.Duration, retention *mariadbv1alpha1.RetentionPolicy) RetentionPolicy {
+	policy := NewMaxRetentionPolicy(maxRetention)
+	if retention != nil {
+		policy.KeepLast = int(retention.KeepLast)
+		policy.KeepHourly = int(retention.KeepHourly)
+		policy.KeepDaily = int(retention.KeepDaily)
+		policy.KeepWeekly = int(retention.KeepWeekly)
+		policy.KeepMonthly = int(retention.KeepMonthly)
+		policy.KeepYearly = int(retention.KeepYearly)
+	}
+	return policy
+}
+
+// IsGFS determines whether the grandfather-father-son rules should be evaluated.
+func (r RetentionPolicy) IsGFS() bool {
+	return r.KeepLast > 0 || r.KeepHourly > 0 || r.KeepDaily > 0 || r.KeepWeekly > 0 || r.KeepMonthly > 0 || r.KeepYearly > 0
+}
+
+// MaxAge returns the age beyond which the policy keeps no backups, assuming that there is at least a backup per period.
+// It is used to bound the retention of continuously archived data, such as binary logs, and it is zero when the policy
+// cannot be bounded in time, i.e. when only KeepLast is set.
+func (r RetentionPolicy) MaxAge() time.Duration {
+	if !r.IsGFS() {
+		return r.MaxRetention
+	}
+	var maxAge time.Duration
+	for _, rule := range r.rules() {
+		if rule.keep <= 0 || rule.period == 0 {
+			continue
+		}
+		maxAge = max(maxAge, time.Duration(rule.keep)*rule.period)
+	}
+	return maxAge
+}
+
+type retentionRule struct {
+	name      string
+	keep      int
+	period    time.Duration
+	periodKey func(t time.Time) string
+}
+
+func (r RetentionPolicy) rules() []retentionRule {
+	return []retentionRule{
+		{
+			name: "last",
+			keep: r.KeepLast,
+			periodKey: func(t time.Time) string {
+				return t.Format(time.RFC3339Nano)
+			},
+		},
+		{
+			name:   "hourly",
+			keep:   r.KeepHourly,
+			period: time.Hour,
+			periodKey: func(t time.Time) string {
+				return t.Format("2006-01-02T15")
+			},
+		},
+		{
+			name:   "daily",
+			keep:   r.KeepDaily,
+			period: 24 * time.Hour,
+			periodKey: func(t time.Time) string {
+				return t.Format("2006-01-02")
+			},
+		},
+		{
+			name:   "weekly",
+			keep:   r.KeepWeekly,
+			period: 7 * 24 * time.Hour,
+			periodKey: func(t time.Time) string {
+				year, week := t.ISOWeek()
+				return fmt.Sprintf("%d-W%02d", year, week)
+			},
+		},
+		{
+			name:   "monthly",
+			keep:   r.KeepMonthly,
+			period: 31 * 24 * time.Hour,
+			periodKey: func(t time.Time) string {
+				return t.Format("2006-01")
+			},
+		},
+		{
+			name:   "yearly",
+			keep:   r.KeepYearly,
+			period: 366 * 24 * time.Hour,
+			periodKey: func(t time.Time) string {
+				return t.Format("2006")
+			},
+		},
+	}
+}
+
+// SkipLockedBackupFiles filters out the old backup files that are locked in the storage, for instance by S3 Object Lock.
+// Locked backups cannot be deleted until their retention expires, so they are kept and evaluated again in the next cleanup.
+func SkipLockedBackupFiles(ctx context.Context, storage BackupStorage, oldBackupFiles []string, logger logr.Logger) []string {
+	var backups []string
+	for _, file := range oldBackupFiles {
+		locked, err := storage.IsLocked(ctx, file)
+		if err != nil {
+			logger.Error(err, "error checking whether the backup is locked. Skipping", "file", file)
+			continue
+		}
+		if locked {
+			logger.Info("Skipping locked backup", "file", file)
+			continue
+		}
+		backups = append(backups, file)
+	}
+	return backups
+}
+
+type datedBackup struct {
+	fileName string
+	date     time.Time
+}
+
+// getOldBackupFiles determines which backup files should be deleted according with the retention policy.
+// Backups whose date cannot be parsed are never deleted.
+func getOldBackupFiles(backupFileNames []string, policy RetentionPolicy, parseDateFn func(fileName string) (time.Time, error),
+	logger logr.Logger) []string {
+	var backups []datedBackup
+	for _, file := range backupFileNames {
+		backupDate, err := parseDateFn(file)
+		if err != nil {
+			logger.Error(err, "error parsing backup date. Skipping", "file", file)
+			continue
+		}
+		backups = append(backups, datedBackup{
+			fileName: file,
+			date:     backupDate,
+		})
+	}
+
+	if !policy.IsGFS() {
+		return getMaxRetentionOldBackupFiles(backups, policy.MaxRetention, logger)
+	}
+	return getGFSOldBackupFiles(backups, policy, logger)
+}
+
+func getMaxRetentionOldBackupFiles(backups []datedBackup, maxRetention time.Duration, logger logr.Logger) []string {
+	var oldBackups []string
+	now := now()
+	for _, backup := range backups {
+		if now.Sub(backup.date) > maxRetention {
+			logger.V(1).Info("Backup exceeds max retention", "file", backup.fileName, "max-retention", maxRetention.String())
+			oldBackups = append(oldBackups, backup.fileName)
+		}
+	}
+	return oldBackups
+}
+
+func getGFSOldBackupFiles(backups []datedBackup, policy RetentionPolicy, logger logr.Logger) []string {
+	sort.SliceStable(backups, func(i, j int) bool {
+		return backups[i].date.After(backups[j].date)
+	})
+	reasons := make(map[string][]string, len(backups))
+
+	for _, rule := range policy.rules() {
+		if rule.keep <= 0 {
+			continue
+		}
+		kept := 0
+		lastPeriod := ""
+		for _, backup := range backups {
+			if kept >= rule.keep {
+				break
+			}
+			period := rule.periodKey(backup.date.UTC())
+			if period == lastPeriod {
+				continue
+			}
+			lastPeriod = period
+			kept++
+			reasons[backup.fileName] = append(reasons[backup.fileName], rule.name)
+		}
+	}
+
+	var oldBackups []string
+	for _, backup := range backups {
+		if keepReasons, ok := reasons[backup.fileName]; ok {
+			logger.Info("Keeping backup", "file", backup.fileName, "rules", keepReasons)
+			continue
+		}
+		logger.Info("Backup not matched by any retention rule", "file", backup.fileName)
+		oldBackups = append(oldBackups, backup.fileName)
+	}
+	return oldBackups
+}
@@ -0,0 +1,304 @@
+package backup
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	mariadbv1alpha1 "github.com/mariadb-operator/mariadb-operator/v26/api/v1alpha1"
+)
+
+func TestLogicalGetOldBackupFilesWithRetention(t *testing.T) {
+	p := NewLogicalBackupProcessor()
+	previousNowFn := now
+	tests := []struct {
+		name        string
+		policy      RetentionPolicy
+		backupFiles []string
+		wantBackups []string
+	}{
+		{
+			name: "no backups",
+			policy: RetentionPolicy{
+				KeepLast: 2,
+			},
+			backupFiles: nil,
+			wantBackups: nil,
+		},
+		{
+			name: "keep last",
+			policy: RetentionPolicy{
+				KeepLast: 2,
+			},
+			backupFiles: []string{
+				"backup.2023-12-21T10:00:00Z.sql",
+				"backup.2023-12-22T12:00:00Z.sql",
+				"backup.2023-12-20T10:00:00Z.sql",
+				"backup.2023-12-22T10:00:00Z.sql",
+			},
+			wantBackups: []string{
+				"backup.2023-12-21T10:00:00Z.sql",
+				"backup.2023-12-20T10:00:00Z.sql",
+			},
+		},
+		{
+			name: "keep hourly",
+			policy: RetentionPolicy{
+				KeepHourly: 2,
+			},
+			backupFiles: []string{
+				"backup.2023-12-22T10:00:00Z.sql",
+				"backup.2023-12-22T10:30:00Z.sql",
+				"backup.2023-12-22T11:00:00Z.sql",
+				"backup.2023-12-22T11:45:00Z.sql",
+				"backup.2023-12-22T12:00:00Z.sql",
+			},
+			wantBackups: []string{
+				"backup.2023-12-22T11:00:00Z.sql",
+				"backup.2023-12-22T10:30:00Z.sql",
+				"backup.2023-12-22T10:00:00Z.sql",
+			},
+		},
+		{
+			name: "keep daily",
+			policy: RetentionPolicy{
+				KeepDaily: 3,
+			},
+			backupFiles: []string{
+				"backup.2023-12-19T10:00:00Z.sql.gz",
+				"backup.2023-12-20T10:00:00Z.sql.gz",
+				"backup.2023-12-20T22:00:00Z.sql.gz",
+				"backup.2023-12-21T10:00:00Z.sql.gz",
+				"backup.2023-12-22T08:00:00Z.sql.gz",
+				"backup.2023-12-22T20:00:00Z.sql.gz",
+			},
+			wantBackups: []string{
+				"backup.2023-12-22T08:00:00Z.sql.gz",
+				"backup.2023-12-20T10:00:00Z.sql.gz",
+				"backup.2023-12-19T10:00:00Z.sql.gz",
+			},
+		},
+		{
+			name: "keep yearly",
+			policy: RetentionPolicy{
+				KeepYearly: 2,
+			},
+			backupFiles: []string{
+				"backup.2021-06-01T00:00:00Z.sql",
+				"backup.2022-03-01T00:00:00Z.sql",
+				"backup.2022-12-31T00:00:00Z.sql",
+				"backup.2023-12-22T00:00:00Z.sql",
+			},
+			wantBackups: []string{
+				"backup.2022-03-01T00:00:00Z.sql",
+				"backup.2021-06-01T00:00:00Z.sql",
+			},
+		},
+		{
+			name: "grandfather-father-son",
+			policy: RetentionPolicy{
+				KeepDaily:   2,
+				KeepWeekly:  2,
+				KeepMonthly: 2,
+			},
+			backupFiles: []string{
+				"backup.2023-10-15T00:00:00Z.sql",
+				"backup.2023-11-10T00:00:00Z.sql",
+				"backup.2023-11-28T00:00:00Z.sql",
+				"backup.2023-12-05T00:00:00Z.sql",
+				"backup.2023-12-14T00:00:00Z.sql",
+				"backup.2023-12-20T00:00:00Z.sql",
+				"backup.2023-12-21T00:00:00Z.sql",
+				"backup.2023-12-22T00:00:00Z.sql",
+			},
+			wantBackups: []string{
+				"backup.2023-12-20T00:00:00Z.sql",
+				"backup.2023-12-05T00:00:00Z.sql",
+				"backup.2023-11-10T00:00:00Z.sql",
+				"backup.2023-10-15T00:00:00Z.sql",
+			},
+		},
+		{
+			name: "invalid backups",
+			policy: RetentionPolicy{
+				KeepLast: 1,
+			},
+			backupFiles: []string{
+				"backup.foo.sql",
+				"backup.2023-12-22T10:00:00Z.sql",
+				"backup.2023-12-21T10:00:00Z.sql",
+			},
+			wantBackups: []string{
+				"backup.2023-12-21T10:00:00Z.sql",
+			},
+		},
+		{
+			name:   "precedence over max retention",
+			policy: NewRetentionPolicy(1*time.Hour, &mariadbv1alpha1.RetentionPolicy{KeepLast: 3}),
+			backupFiles: []string{
+				"backup.2023-12-20T10:00:00Z.sql",
+				"backup.2023-12-21T10:00:00Z.sql",
+				"backup.2023-12-22T10:00:00Z.sql",
+			},
+			wantBackups: nil,
+		},
+		{
+			name:   "fallback to max retention",
+			policy: NewRetentionPolicy(48*time.Hour, nil),
+			backupFiles: []string{
+				"backup.2023-12-20T10:00:00Z.sql",
+				"backup.2023-12-21T10:00:00Z.sql",
+				"backup.2023-12-22T10:00:00Z.sql",
+			},
+			wantBackups: []string{
+				"backup.2023-12-20T10:00:00Z.sql",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			now = testTimeFn(mustParseDate(t, "2023-12-22T22:10:00Z"))
+			t.Cleanup(func() {
+				now = previousNowFn
+			})
+
+			backups := p.GetOldBackupFiles(tt.backupFiles, tt.policy, logger)
+			if !reflect.DeepEqual(tt.wantBackups, backups) {
+				t.Fatalf("unexpected backup files, expected: %v got: %v", tt.wantBackups, backups)
+			}
+		})
+	}
+}
+
+func TestPhysicalGetOldBackupFilesWithRetention(t *testing.T) {
+	tests := []struct {
+		name        string
+		processor   BackupProcessor
+		policy      RetentionPolicy
+		backupFiles []string
+		wantBackups []string
+	}{
+		{
+			name:      "keep daily",
+			processor: NewPhysicalBackupProcessor(),
+			policy: RetentionPolicy{
+				KeepDaily: 2,
+			},
+			backupFiles: []string{
+				"physicalbackup-20231220100000.xb.gz",
+				"physicalbackup-20231221100000.xb.gz",
+				"physicalbackup-20231222080000.xb.gz",
+				"physicalbackup-20231222200000.xb.gz",
+			},
+			wantBackups: []string{
+				"physicalbackup-20231222080000.xb.gz",
+				"physicalbackup-20231220100000.xb.gz",
+			},
+		},
+		{
+			name: "snapshots keep weekly",
+			processor: NewPhysicalBackupProcessor(
+				WithPhysicalBackupValidationFn(mariadbv1alpha1.IsValidPhysicalBackup),
+				WithPhysicalBackupParseDateFn(mariadbv1alpha1.ParsePhysicalBackupTime),
+			),
+			policy: RetentionPolicy{
+				KeepLast:   1,
+				KeepWeekly: 2,
+			},
+			backupFiles: []string{
+				"snapshot-20231205000000",
+				"snapshot-20231214000000",
+				"snapshot-20231220000000",
+				"snapshot-20231222000000",
+			},
+			wantBackups: []string{
+				"snapshot-20231220000000",
+				"snapshot-20231205000000",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			backups := tt.processor.GetOldBackupFiles(tt.backupFiles, tt.policy, logger)
+			if !reflect.DeepEqual(tt.wantBackups, backups) {
+				t.Fatalf("unexpected backup files, expected: %v got: %v", tt.wantBackups, backups)
+			}
+		})
+	}
+}
+
+type lockedBackupStorage struct {
+	BackupStorage
+	locked map[string]bool
+	errs   map[string]error
+}
+
+func (s *lockedBackupStorage) IsLocked(ctx context.Context, fileName string) (bool, error) {
+	return s.locked[fileName], s.errs[fileName]
+}
+
+func TestSkipLockedBackupFiles(t *testing.T) {
+	storage := &lockedBackupStorage{
+		locked: map[string]bool{
+			"backup.2023-12-20T10:00:00Z.sql": true,
+		},
+		errs: map[string]error{
+			"backup.2023-12-21T10:00:00Z.sql": errors.New("forbidden"),
+		},
+	}
+	backups := SkipLockedBackupFiles(context.Background(), storage, []string{
+		"backup.2023-12-19T10:00:00Z.sql",
+		"backup.2023-12-20T10:00:00Z.sql",
+		"backup.2023-12-21T10:00:00Z.sql",
+		"backup.2023-12-22T10:00:00Z.sql",
+	}, logger)
+
+	wantBackups := []string{
+		"backup.2023-12-19T10:00:00Z.sql",
+		"backup.2023-12-22T10:00:00Z.sql",
+	}
+	if !reflect.DeepEqual(wantBackups, backups) {
+		t.Fatalf("unexpected backup files, expected: %v got: %v", wantBackups, backups)
+	}
+}
+
+func TestRetentionPolicyMaxAge(t *testing.T) {
+	tests := []struct {
+		name   string
+		policy RetentionPolicy
+		want   time.Duration
+	}{
+		{
+			name:   "max retention",
+			policy: NewMaxRetentionPolicy(48 * time.Hour),
+			want:   48 * time.Hour,
+		},
+		{
+			name: "longest rule",
+			policy: NewRetentionPolicy(48*time.Hour, &mariadbv1alpha1.RetentionPolicy{
+				KeepHourly: 24,
+				KeepDaily:  7,
+				KeepWeekly: 4,
+			}),
+			want: 28 * 24 * time.Hour,
+		},
+		{
+			name: "keep last",
+			policy: NewRetentionPolicy(48*time.Hour, &mariadbv1alpha1.RetentionPolicy{
+				KeepLast: 5,
+			}),
+			want: 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.policy.MaxAge(); got != tt.want {
+				t.Fatalf("unexpected max age, expected: %v got: %v", tt.want, got)
+			}
+		})
+	}
+}
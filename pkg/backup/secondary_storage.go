@@ -0,0 +1,266 @@
+package backup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/go-logr/logr"
+	mariadbv1alpha1 "github.com/mariadb-operator/mariadb-operator/v26/api/v1alpha1"
+	"github.com/mariadb-operator/mariadb-operator/v26/pkg/azure"
+	"github.com/mariadb-operator/mariadb-operator/v26/pkg/filesystem"
+	"github.com/mariadb-operator/mariadb-operator/v26/pkg/interfaces"
+	mariadbminio "github.com/mariadb-operator/mariadb-operator/v26/pkg/minio"
+	"github.com/mariadb-operator/mariadb-operator/v26/pkg/ratelimit"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+	"k8s.io/utils/ptr"
+)
+
+// Settings of the secondary storages that are provided via environment variables.
+// See SecondaryStorageEnv for building the full environment variable name.
+const (
+	SecondaryStorageS3AccessKeyID     = "S3_ACCESS_KEY_ID"
+	SecondaryStorageS3SecretAccessKey = "S3_SECRET_ACCESS_KEY"
+	SecondaryStorageS3SessionToken    = "S3_SESSION_TOKEN"
+	SecondaryStorageS3SSECCustomerKey = "S3_SSEC_CUSTOMER_KEY"
+	SecondaryStorageABSAccountKey     = "ABS_STORAGE_ACCOUNT_KEY"
+	SecondaryStorageCACertPath        = "CA_CERT_PATH"
+	SecondaryStoragePath              = "PATH"
+)
+
+// SecondaryStorageEnv returns the name of the environment variable holding a setting of the secondary storage with the given index.
+func SecondaryStorageEnv(index int, setting string) string {
+	return fmt.Sprintf("MARIADB_OPERATOR_SECONDARY_STORAGE_%d_%s", index, setting)
+}
+
+type SecondaryStorageOpts struct {
+	Metadata            map[string]string
+	AllowNestedPrefixes bool
+	RateLimiter         *ratelimit.Limiter
+	Getenv              func(string) string
+}
+
+type SecondaryStorageOpt func(*SecondaryStorageOpts)
+
+// WithSecondaryStorageMetadata sets the metadata to be attached to the objects uploaded to the secondary storage.
+func WithSecondaryStorageMetadata(metadata map[string]string) SecondaryStorageOpt {
+	return func(opts *SecondaryStorageOpts) {
+		opts.Metadata = metadata
+	}
+}
+
+// WithSecondaryStorageNestedPrefixes allows object names with nested prefixes in the secondary storage.
+func WithSecondaryStorageNestedPrefixes(allow bool) SecondaryStorageOpt {
+	return func(opts *SecondaryStorageOpts) {
+		opts.AllowNestedPrefixes = allow
+	}
+}
+
+// WithSecondaryStorageRateLimiter limits the throughput of the transfers to and from the secondary storage.
+func WithSecondaryStorageRateLimiter(limiter *ratelimit.Limiter) SecondaryStorageOpt {
+	return func(opts *SecondaryStorageOpts) {
+		opts.RateLimiter = limiter
+	}
+}
+
+// WithSecondaryStorageGetenv sets the function used to read the settings of the secondary storage from the environment.
+func WithSecondaryStorageGetenv(getenv func(string) string) SecondaryStorageOpt {
+	return func(opts *SecondaryStorageOpts) {
+		opts.Getenv = getenv
+	}
+}
+
+func newSecondaryStorageOpts(userOpts ...SecondaryStorageOpt) SecondaryStorageOpts {
+	opts := SecondaryStorageOpts{
+		Getenv: os.Getenv,
+	}
+	for _, setOpt := range userOpts {
+		setOpt(&opts)
+	}
+	return opts
+}
+
+// NewSecondaryBlobStorage returns a storage client for the secondary storage with the given index.
+// Credentials, CA certificates and PVC mount paths are read from the environment variables named after SecondaryStorageEnv.
+func NewSecondaryBlobStorage(index int, storage *mariadbv1alpha1.SecondaryStorage, basePath string,
+	userOpts ...SecondaryStorageOpt) (interfaces.BlobStorage, error) {
+	opts := newSecondaryStorageOpts(userOpts...)
+	getenv := func(setting string) string {
+		return opts.Getenv(SecondaryStorageEnv(index, setting))
+	}
+
+	if storage.S3 != nil {
+		s3 := storage.S3
+		tls := ptr.Deref(s3.TLS, mariadbv1alpha1.TLSConfig{})
+		minioOpts := []mariadbminio.MinioOpt{
+			mariadbminio.WithTLS(tls.Enabled),
+			mariadbminio.WithCACertPath(getenv(SecondaryStorageCACertPath)),
+			mariadbminio.WithRegion(s3.Region),
+			mariadbminio.WithPrefix(s3.Prefix),
+			mariadbminio.WithAllowNestedPrefixes(opts.AllowNestedPrefixes),
+			mariadbminio.WithUserMetadata(opts.Metadata),
+			mariadbminio.WithRateLimiter(opts.RateLimiter),
+			mariadbminio.WithObjectLock(s3.ObjectLock),
+		}
+		if accessKeyID := getenv(SecondaryStorageS3AccessKeyID); accessKeyID != "" {
+			minioOpts = append(minioOpts, mariadbminio.WithCredsProviders(&credentials.Static{
+				Value: credentials.Value{
+					AccessKeyID:     accessKeyID,
+					SecretAccessKey: getenv(SecondaryStorageS3SecretAccessKey),
+					SessionToken:    getenv(SecondaryStorageS3SessionToken),
+					SignerType:      credentials.SignatureDefault,
+				},
+			}))
+		}
+		if ssecKey := getenv(SecondaryStorageS3SSECCustomerKey); ssecKey != "" {
+			minioOpts = append(minioOpts, mariadbminio.WithSSECCustomerKey(ssecKey))
+		}
+		client, err := mariadbminio.NewMinioClient(basePath, s3.Bucket, s3.Endpoint, minioOpts...)
+		if err != nil {
+			return nil, fmt.Errorf("error creating S3 client: %v", err)
+		}
+		return client, nil
+	}
+
+	if storage.AzureBlob != nil {
+		abs := storage.AzureBlob
+		tls := ptr.Deref(abs.TLS, mariadbv1alpha1.TLSConfig{})
+		absOpts := []azure.AzBlobOpt{
+			azure.WithAccountName(abs.StorageAccountName),
+			azure.WithTLSEnabled(tls.Enabled),
+			azure.WithPrefix(abs.Prefix),
+			azure.WithAllowNestedPrefixes(opts.AllowNestedPrefixes),
+			azure.WithMetadata(opts.Metadata),
+			azure.WithRateLimiter(opts.RateLimiter),
+			azure.WithObjectLock(abs.ObjectLock),
+		}
+		if caCertPath := getenv(SecondaryStorageCACertPath); caCertPath != "" {
+			absOpts = append(absOpts, azure.WithTLSCACertPath(caCertPath))
+		}
+		if accountKey := getenv(SecondaryStorageABSAccountKey); accountKey != "" {
+			absOpts = append(absOpts, azure.WithAccountKey(accountKey))
+		}
+		client, err := azure.NewAzBlobClient(basePath, abs.ContainerName, abs.ServiceURL, absOpts...)
+		if err != nil {
+			return nil, fmt.Errorf("error creating Azure Blob client: %v", err)
+		}
+		return client, nil
+	}
+
+	if storage.PersistentVolumeClaim != nil {
+		rootPath := getenv(SecondaryStoragePath)
+		if rootPath == "" {
+			return nil, fmt.Errorf("path of secondary storage '%s' not provided", storage.Name)
+		}
+		client, err := filesystem.NewFileSystemClient(
+			basePath,
+			rootPath,
+			filesystem.WithAllowNestedPrefixes(opts.AllowNestedPrefixes),
+			filesystem.WithRateLimiter(opts.RateLimiter),
+		)
+		if err != nil {
+			return nil, fmt.Errorf("error creating filesystem client: %v", err)
+		}
+		return client, nil
+	}
+
+	return nil, fmt.Errorf("secondary storage '%s' does not define a storage type", storage.Name)
+}
+
+// NewSecondaryBackupStorage returns a BackupStorage for the secondary storage with the given index.
+// Backups are replicated from the staging path, where they are also pulled to when restoring.
+func NewSecondaryBackupStorage(index int, storage *mariadbv1alpha1.SecondaryStorage, stagingPath string,
+	processor BackupProcessor, logger logr.Logger, userOpts ...SecondaryStorageOpt) (BackupStorage, error) {
+	if storage.PersistentVolumeClaim != nil {
+		opts := newSecondaryStorageOpts(userOpts...)
+		basePath := opts.Getenv(SecondaryStorageEnv(index, SecondaryStoragePath))
+		if basePath == "" {
+			return nil, fmt.Errorf("path of secondary storage '%s' not provided", storage.Name)
+		}
+		return NewReplicaFileSystemBackupStorage(basePath, stagingPath, processor, logger), nil
+	}
+
+	client, err := NewSecondaryBlobStorage(index, storage, stagingPath, userOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return NewBlobBackupStorage(client, processor), nil
+}
+
+// ReplicaFileSystemBackupStorage is a filesystem BackupStorage where backups are copied to and from a staging path.
+type ReplicaFileSystemBackupStorage struct {
+	*FileSystemBackupStorage
+	stagingPath string
+}
+
+func NewReplicaFileSystemBackupStorage(basePath, stagingPath string, processor BackupProcessor,
+	logger logr.Logger) BackupStorage {
+	return &ReplicaFileSystemBackupStorage{
+		FileSystemBackupStorage: &FileSystemBackupStorage{
+			basePath:  basePath,
+			processor: processor,
+			logger:    logger,
+		},
+		stagingPath: stagingPath,
+	}
+}
+
+func (r *ReplicaFileSystemBackupStorage) Push(ctx context.Context, fileName string) error {
+	return copyFile(GetFilePath(r.stagingPath, fileName), r.replicaFilePath(fileName))
+}
+
+func (r *ReplicaFileSystemBackupStorage) Pull(ctx context.Context, fileName string) error {
+	return copyFile(r.replicaFilePath(fileName), GetFilePath(r.stagingPath, fileName))
+}
+
+func (r *ReplicaFileSystemBackupStorage) Delete(ctx context.Context, fileName string) error {
+	return os.Remove(r.replicaFilePath(fileName))
+}
+
+func (r *ReplicaFileSystemBackupStorage) Exists(ctx context.Context, fileName string) (bool, error) {
+	if _, err := os.Stat(r.replicaFilePath(fileName)); err != nil {
+		if os.IsNotExist(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+func (r *ReplicaFileSystemBackupStorage) replicaFilePath(fileName string) string {
+	return filepath.Join(r.basePath, filepath.Base(fileName))
+}
+
+// copyFile copies a file atomically, the destination is never left partially written.
+func copyFile(src, dst string) (err error) {
+	if filepath.Clean(src) == filepath.Clean(dst) {
+		return nil
+	}
+	srcFile, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer srcFile.Close()
+
+	tmpFile, err := os.CreateTemp(filepath.Dir(dst), filepath.Base(dst)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			err = errors.Join(err, os.Remove(tmpFile.Name()))
+		}
+	}()
+
+	if _, err = io.Copy(tmpFile, srcFile); err != nil {
+		_ = tmpFile.Close()
+		return err
+	}
+	if err = tmpFile.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmpFile.Name(), dst)
+}
@@ -0,0 +1,207 @@
+package backup
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	mariadbv1alpha1 "github.com/mariadb-operator/mariadb-operator/v26/api/v1alpha1"
+)
+
+func TestSecondaryStorageEnv(t *testing.T) {
+	tests := []struct {
+		name    string
+		index   int
+		setting string
+		want    string
+	}{
+		{
+			name:    "S3 access key",
+			index:   0,
+			setting: SecondaryStorageS3AccessKeyID,
+			want:    "MARIADB_OPERATOR_SECONDARY_STORAGE_0_S3_ACCESS_KEY_ID",
+		},
+		{
+			name:    "path",
+			index:   2,
+			setting: SecondaryStoragePath,
+			want:    "MARIADB_OPERATOR_SECONDARY_STORAGE_2_PATH",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SecondaryStorageEnv(tt.index, tt.setting); got != tt.want {
+				t.Errorf("unexpected env, expected: %v got: %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestReplicaFileSystemBackupStorage(t *testing.T) {
+	ctx := context.Background()
+	stagingPath := t.TempDir()
+	replicaPath := t.TempDir()
+	backupFile := "backup.2025-01-01T00:00:00Z.gzip.sql"
+	content := []byte(testDump)
+
+	if err := os.WriteFile(filepath.Join(stagingPath, backupFile), content, 0644); err != nil {
+		t.Fatalf("unexpected error writing backup file: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(replicaPath, "lost+found"), nil, 0644); err != nil {
+		t.Fatalf("unexpected error writing file: %v", err)
+	}
+
+	storage := NewReplicaFileSystemBackupStorage(replicaPath, stagingPath, NewLogicalBackupProcessor(), logger)
+
+	if err := storage.Push(ctx, backupFile); err != nil {
+		t.Fatalf("unexpected error pushing backup: %v", err)
+	}
+	exists, err := storage.Exists(ctx, backupFile)
+	if err != nil {
+		t.Fatalf("unexpected error checking backup existence: %v", err)
+	}
+	if !exists {
+		t.Fatal("expected backup to exist in replica")
+	}
+	backups, err := storage.List(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error listing backups: %v", err)
+	}
+	if !slices.Equal(backups, []string{backupFile}) {
+		t.Fatalf("unexpected backups, expected: %v got: %v", []string{backupFile}, backups)
+	}
+
+	if err := os.Remove(filepath.Join(stagingPath, backupFile)); err != nil {
+		t.Fatalf("unexpected error removing staged backup: %v", err)
+	}
+	if err := storage.Pull(ctx, backupFile); err != nil {
+		t.Fatalf("unexpected error pulling backup: %v", err)
+	}
+	pulled, err := os.ReadFile(filepath.Join(stagingPath, backupFile))
+	if err != nil {
+		t.Fatalf("unexpected error reading pulled backup: %v", err)
+	}
+	if string(pulled) != string(content) {
+		t.Fatal("unexpected content in pulled backup")
+	}
+
+	if err := storage.Delete(ctx, backupFile); err != nil {
+		t.Fatalf("unexpected error deleting backup: %v", err)
+	}
+	exists, err = storage.Exists(ctx, backupFile)
+	if err != nil {
+		t.Fatalf("unexpected error checking backup existence: %v", err)
+	}
+	if exists {
+		t.Fatal("expected backup to be deleted from replica")
+	}
+}
+
+func TestNewSecondaryBackupStorage(t *testing.T) {
+	env := map[string]string{
+		SecondaryStorageEnv(1, SecondaryStoragePath): "/backup-secondary/1",
+	}
+	getenv := func(name string) string {
+		return env[name]
+	}
+
+	tests := []struct {
+		name    string
+		index   int
+		storage mariadbv1alpha1.SecondaryStorage
+		wantErr bool
+	}{
+		{
+			name:  "PVC",
+			index: 1,
+			storage: mariadbv1alpha1.SecondaryStorage{
+				Name: "pvc",
+				PersistentVolumeClaim: &mariadbv1alpha1.PersistentVolumeClaimVolumeSource{
+					ClaimName: "backups",
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name:  "PVC without path",
+			index: 0,
+			storage: mariadbv1alpha1.SecondaryStorage{
+				Name: "pvc",
+				PersistentVolumeClaim: &mariadbv1alpha1.PersistentVolumeClaimVolumeSource{
+					ClaimName: "backups",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name:  "S3",
+			index: 0,
+			storage: mariadbv1alpha1.SecondaryStorage{
+				Name: "s3",
+				S3: &mariadbv1alpha1.S3{
+					Bucket:   "backups",
+					Endpoint: "minio:9000",
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name:  "no storage",
+			index: 0,
+			storage: mariadbv1alpha1.SecondaryStorage{
+				Name: "none",
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewSecondaryBackupStorage(tt.index, &tt.storage, t.TempDir(), NewLogicalBackupProcessor(), logger,
+				WithSecondaryStorageGetenv(getenv))
+			if tt.wantErr && err == nil {
+				t.Fatal("expected error, got nil")
+			}
+			if !tt.wantErr && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestNewSecondaryBlobStoragePVC(t *testing.T) {
+	storage := mariadbv1alpha1.SecondaryStorage{
+		Name: "pvc",
+		PersistentVolumeClaim: &mariadbv1alpha1.PersistentVolumeClaimVolumeSource{
+			ClaimName: "binlogs",
+		},
+	}
+	rootPath := t.TempDir()
+	env := map[string]string{
+		SecondaryStorageEnv(0, SecondaryStoragePath): rootPath,
+	}
+	getenv := func(name string) string {
+		return env[name]
+	}
+
+	if _, err := NewSecondaryBlobStorage(1, &storage, t.TempDir(), WithSecondaryStorageGetenv(getenv)); err == nil {
+		t.Fatal("expected error getting secondary storage without path, got nil")
+	}
+
+	client, err := NewSecondaryBlobStorage(0, &storage, t.TempDir(), WithSecondaryStorageGetenv(getenv),
+		WithSecondaryStorageNestedPrefixes(true))
+	if err != nil {
+		t.Fatalf("unexpected error getting secondary storage: %v", err)
+	}
+	ctx := context.Background()
+	content := []byte("binlog")
+	if err := client.PutObjectWithOptions(ctx, "server-10/mariadb-repl-bin.000001", bytes.NewReader(content),
+		int64(len(content))); err != nil {
+		t.Fatalf("unexpected error putting object: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(rootPath, "server-10", "mariadb-repl-bin.000001")); err != nil {
+		t.Fatalf("expected object to be stored in the secondary storage path: %v", err)
+	}
+}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/go-logr/logr"
 	"github.com/mariadb-operator/mariadb-operator/v26/pkg/azure"
+	"github.com/mariadb-operator/mariadb-operator/v26/pkg/gcs"
 	"github.com/mariadb-operator/mariadb-operator/v26/pkg/interfaces"
 	mariadbminio "github.com/mariadb-operator/mariadb-operator/v26/pkg/minio"
 )
@@ -16,6 +17,9 @@ type BackupStorage interface {
 	Push(ctx context.Context, fileName string) error
 	Pull(ctx context.Context, fileName string) error
 	Delete(ctx context.Context, fileName string) error
+	Exists(ctx context.Context, fileName string) (bool, error)
+	Size(ctx context.Context, fileName string) (int64, error)
+	IsLocked(ctx context.Context, fileName string) (bool, error)
 	shouldProcessBackupFile(fileName string, logger logr.Logger) bool
 }
 
@@ -60,6 +64,28 @@ func (f *FileSystemBackupStorage) Delete(ctx context.Context, fileName string) e
 	return os.Remove(GetFilePath(f.basePath, fileName))
 }
 
+func (f *FileSystemBackupStorage) Exists(ctx context.Context, fileName string) (bool, error) {
+	if _, err := os.Stat(GetFilePath(f.basePath, fileName)); err != nil {
+		if os.IsNotExist(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+func (f *FileSystemBackupStorage) Size(ctx context.Context, fileName string) (int64, error) {
+	info, err := os.Stat(GetFilePath(f.basePath, fileName))
+	if err != nil {
+		return 0, err
+	}
+	return info.Size(), nil
+}
+
+func (f *FileSystemBackupStorage) IsLocked(ctx context.Context, fileName string) (bool, error) {
+	return false, nil
+}
+
 func (f *FileSystemBackupStorage) shouldProcessBackupFile(fileName string, logger logr.Logger) bool {
 	logger.V(1).Info("processing backup file", "file", fileName)
 	if f.processor.IsValidBackupFile(fileName) {
@@ -74,6 +100,14 @@ type BlobBackupStorage struct {
 	client    interfaces.BlobStorage
 }
 
+// NewBlobBackupStorage creates a BackupStorage backed by an already configured object storage client.
+func NewBlobBackupStorage(client interfaces.BlobStorage, processor BackupProcessor) BackupStorage {
+	return &BlobBackupStorage{
+		client:    client,
+		processor: processor,
+	}
+}
+
 func NewBlobBackupStorageWithS3(basePath, bucket, endpoint string, processor BackupProcessor,
 	mOpts ...mariadbminio.MinioOpt) (BackupStorage, error) {
 	client, err := mariadbminio.NewMinioClient(basePath, bucket, endpoint, mOpts...)
@@ -100,12 +134,36 @@ func NewBlobBackupStorageWithABS(basePath, containerName, serviceURL string, pro
 	}, nil
 }
 
+func NewBlobBackupStorageWithGCS(basePath, bucket string, processor BackupProcessor,
+	gcsOpts ...gcs.GCSOpt) (BackupStorage, error) {
+	client, err := gcs.NewGCSClient(basePath, bucket, gcsOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("error creating GCS client: %v", err)
+	}
+
+	return &BlobBackupStorage{
+		client:    client,
+		processor: processor,
+	}, nil
+}
+
 func (s *BlobBackupStorage) Delete(ctx context.Context, fileName string) error {
 	return s.client.RemoveWithOptions(ctx, fileName)
 }
 
 func (s *BlobBackupStorage) List(ctx context.Context) ([]string, error) {
-	return s.client.ListObjectsWithOptions(ctx)
+	objectNames, err := s.client.ListObjectsWithOptions(ctx)
+	if err != nil {
+		return nil, err
+	}
+	// Other files, such as the chain index, may be stored alongside the backups.
+	var fileNames []string
+	for _, name := range objectNames {
+		if s.processor.IsValidBackupFile(s.client.UnprefixedFilename(name)) {
+			fileNames = append(fileNames, name)
+		}
+	}
+	return fileNames, nil
 }
 
 func (s *BlobBackupStorage) Push(ctx context.Context, fileName string) error {
@@ -116,6 +174,18 @@ func (s *BlobBackupStorage) Pull(ctx context.Context, fileName string) error {
 	return s.client.FGetObjectWithOptions(ctx, fileName)
 }
 
+func (s *BlobBackupStorage) Exists(ctx context.Context, fileName string) (bool, error) {
+	return s.client.Exists(ctx, fileName)
+}
+
+func (s *BlobBackupStorage) Size(ctx context.Context, fileName string) (int64, error) {
+	return s.client.Size(ctx, fileName)
+}
+
+func (s *BlobBackupStorage) IsLocked(ctx context.Context, fileName string) (bool, error) {
+	return s.client.IsLocked(ctx, fileName)
+}
+
 func (s *BlobBackupStorage) shouldProcessBackupFile(fileName string, logger logr.Logger) bool {
 	logger.V(1).Info("processing backup file", "file", fileName)
 	if s.processor.IsValidBackupFile(s.client.UnprefixedFilename(fileName)) {
@@ -0,0 +1,49 @@
+package backup
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"path/filepath"
+
+	"github.com/mariadb-operator/mariadb-operator/v26/pkg/interfaces"
+)
+
+const (
+	streamExitCodeSuffix = ".exitcode"
+	streamVerifiedSuffix = ".verified"
+)
+
+// StreamingBackupStorage is a BackupStorage that is able to upload and download backups as streams,
+// without staging them in the local filesystem.
+type StreamingBackupStorage interface {
+	BackupStorage
+	PushStream(ctx context.Context, fileName string, reader io.Reader, opts interfaces.StreamOpts) error
+	PullStream(ctx context.Context, fileName string) (io.ReadCloser, error)
+}
+
+func (s *BlobBackupStorage) PushStream(ctx context.Context, fileName string, reader io.Reader, opts interfaces.StreamOpts) error {
+	return s.client.PutObjectStreamWithOptions(ctx, fileName, reader, opts)
+}
+
+func (s *BlobBackupStorage) PullStream(ctx context.Context, fileName string) (io.ReadCloser, error) {
+	return s.client.GetObjectWithOptions(ctx, fileName)
+}
+
+// StreamFifoPath returns the path of the named pipe used to stream the backup with the given index in the chain.
+// mariadb-backup and mariadb-operator run in different containers and exchange the backup stream via named pipes.
+func StreamFifoPath(basePath string, index int) string {
+	return filepath.Join(basePath, fmt.Sprintf("stream-%d.fifo", index))
+}
+
+// StreamExitCodePath returns the path of the file where the exit code of the process writing to a named pipe is recorded.
+// It allows the reader to tell apart a complete stream from a stream interrupted by an error.
+func StreamExitCodePath(fifoPath string) string {
+	return fifoPath + streamExitCodeSuffix
+}
+
+// StreamVerifiedPath returns the path of the file that signals that the backup written to a named pipe has been verified
+// against its Manifest. It is written before closing the named pipe.
+func StreamVerifiedPath(fifoPath string) string {
+	return fifoPath + streamVerifiedSuffix
+}
@@ -0,0 +1,171 @@
+package backup
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+var dumpSectionMarkers = []string{
+	"-- Current Database: ",
+	"-- Table structure for table ",
+	"-- Temporary table structure for view ",
+	"-- Temporary view structure for view ",
+	"-- Dumping data for table ",
+	"-- Dumping routines for database ",
+	"-- Dumping events for database ",
+	"-- Final view structure for view ",
+}
+
+// MatchTable determines whether a table matches any of the patterns.
+// Patterns have the 'database.table' format, where '*' matches any sequence of characters.
+func MatchTable(patterns []string, database, table string) bool {
+	for _, pattern := range patterns {
+		dbPattern, tablePattern, ok := strings.Cut(pattern, ".")
+		if !ok {
+			continue
+		}
+		if matchPattern(dbPattern, database) && matchPattern(tablePattern, table) {
+			return true
+		}
+	}
+	return false
+}
+
+func matchPattern(pattern, name string) bool {
+	match, err := path.Match(pattern, name)
+	return err == nil && match
+}
+
+// FilterDump writes the statements of a mariadb-dump output that belong to the tables matching the patterns.
+// Only table definitions, data and triggers are kept. Databases, views, routines and events are skipped.
+func FilterDump(r io.Reader, w io.Writer, patterns []string) error {
+	writer := bufio.NewWriter(w)
+	f := &dumpFilter{
+		patterns:   patterns,
+		writer:     writer,
+		inPreamble: true,
+	}
+	if err := readDumpLines(r, f.process); err != nil {
+		return err
+	}
+	return writer.Flush()
+}
+
+// FilterParallelBackup keeps the files of an extracted parallel backup that belong to the tables matching the patterns.
+// Data files of other tables are removed, and the schema and post-data files are filtered with FilterDump.
+func FilterParallelBackup(dir string, manifest *ParallelBackupManifest, patterns []string) error {
+	for _, f := range manifest.Files {
+		filePath := filepath.Join(dir, filepath.FromSlash(f.Name))
+		switch f.Type {
+		case ParallelBackupFileTypeData:
+			if MatchTable(patterns, f.Database, f.Table) {
+				continue
+			}
+			if err := os.Remove(filePath); err != nil {
+				return fmt.Errorf("error removing data file %s: %v", f.Name, err)
+			}
+		default:
+			if err := FilterDumpFile(filePath, patterns); err != nil {
+				return fmt.Errorf("error filtering file %s: %v", f.Name, err)
+			}
+		}
+	}
+	return nil
+}
+
+// FilterDumpFile filters a mariadb-dump output file in place with FilterDump.
+func FilterDumpFile(filePath string, patterns []string) error {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	tmpFilePath := filePath + ".tmp"
+	tmpFile, err := os.Create(tmpFilePath)
+	if err != nil {
+		return err
+	}
+	defer tmpFile.Close()
+
+	if err := FilterDump(file, tmpFile, patterns); err != nil {
+		return err
+	}
+	if err := tmpFile.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmpFilePath, filePath)
+}
+
+type dumpFilter struct {
+	patterns []string
+	writer   io.Writer
+
+	inPreamble bool
+	prevLine   string
+	keep       bool
+	database   string
+	selectedDB string
+}
+
+func (f *dumpFilter) process(line string) error {
+	trimmed := strings.TrimRight(line, "\r\n")
+	isMarker := strings.HasPrefix(trimmed, "-- ") && (f.prevLine == "--" || isDumpSectionMarker(trimmed))
+	f.prevLine = trimmed
+
+	if isMarker {
+		f.startSection(trimmed)
+	}
+	// Databases are selected right before writing the statements of the kept sections.
+	if db, ok := parseUseStatement(trimmed); ok {
+		f.database = db
+		return nil
+	}
+	if f.inPreamble {
+		return f.write(line)
+	}
+	if !f.keep {
+		return nil
+	}
+	if f.database != "" && f.database != f.selectedDB {
+		if err := f.write(fmt.Sprintf("USE %s;\n", quoteIdentifier(f.database))); err != nil {
+			return err
+		}
+		f.selectedDB = f.database
+	}
+	return f.write(line)
+}
+
+func (f *dumpFilter) startSection(marker string) {
+	switch {
+	case strings.HasPrefix(marker, "-- Table structure for table "),
+		strings.HasPrefix(marker, "-- Dumping data for table "):
+		table, _ := parseIdentifier(marker)
+		f.keep = MatchTable(f.patterns, f.database, table)
+	case f.inPreamble && !isDumpSectionMarker(marker):
+		return
+	default:
+		// Databases are expected to exist already, they might even be dropped if the dump was taken with --add-drop-database.
+		f.keep = false
+	}
+	f.inPreamble = false
+}
+
+func (f *dumpFilter) write(s string) error {
+	_, err := io.WriteString(f.writer, s)
+	return err
+}
+
+func isDumpSectionMarker(line string) bool {
+	for _, marker := range dumpSectionMarkers {
+		if strings.HasPrefix(line, marker) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,181 @@
+package backup
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestMatchTable(t *testing.T) {
+	tests := []struct {
+		name      string
+		patterns  []string
+		database  string
+		table     string
+		wantMatch bool
+	}{
+		{
+			name:      "no patterns",
+			patterns:  nil,
+			database:  "db",
+			table:     "users",
+			wantMatch: false,
+		},
+		{
+			name:      "exact",
+			patterns:  []string{"db.users"},
+			database:  "db",
+			table:     "users",
+			wantMatch: true,
+		},
+		{
+			name:      "different table",
+			patterns:  []string{"db.users"},
+			database:  "db",
+			table:     "orders",
+			wantMatch: false,
+		},
+		{
+			name:      "all tables",
+			patterns:  []string{"db.*"},
+			database:  "db",
+			table:     "orders",
+			wantMatch: true,
+		},
+		{
+			name:      "all databases",
+			patterns:  []string{"*.users"},
+			database:  "other",
+			table:     "users",
+			wantMatch: true,
+		},
+		{
+			name:      "prefix",
+			patterns:  []string{"db.orders", "db.user*"},
+			database:  "db",
+			table:     "users_archive",
+			wantMatch: true,
+		},
+		{
+			name:      "wildcard does not span databases",
+			patterns:  []string{"d*"},
+			database:  "db",
+			table:     "users",
+			wantMatch: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if match := MatchTable(tt.patterns, tt.database, tt.table); match != tt.wantMatch {
+				t.Fatalf("unexpected match, expected: %v got: %v", tt.wantMatch, match)
+			}
+		})
+	}
+}
+
+func TestFilterDump(t *testing.T) {
+	tests := []struct {
+		name            string
+		patterns        []string
+		wantContains    []string
+		wantNotContains []string
+	}{
+		{
+			name:     "table",
+			patterns: []string{"db.users"},
+			wantContains: []string{
+				"SET @OLD_FOREIGN_KEY_CHECKS",
+				"USE `db`;",
+				"CREATE TABLE `users`",
+				"INSERT INTO `users` VALUES (5),(6);",
+				"TRIGGER users_ai",
+			},
+			wantNotContains: []string{
+				"CREATE DATABASE",
+				"CREATE TABLE `empty`",
+				"CREATE PROCEDURE",
+			},
+		},
+		{
+			name:     "wildcard",
+			patterns: []string{"*.*"},
+			wantContains: []string{
+				"CREATE TABLE `users`",
+				"CREATE TABLE `empty`",
+			},
+			wantNotContains: []string{
+				"CREATE DATABASE",
+				"CREATE PROCEDURE",
+			},
+		},
+		{
+			name:     "no matches",
+			patterns: []string{"other.users"},
+			wantContains: []string{
+				"SET @OLD_FOREIGN_KEY_CHECKS",
+			},
+			wantNotContains: []string{
+				"USE `db`;",
+				"CREATE TABLE",
+				"INSERT INTO",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var sb strings.Builder
+			if err := FilterDump(strings.NewReader(testDump), &sb, tt.patterns); err != nil {
+				t.Fatalf("unexpected error filtering dump: %v", err)
+			}
+			dump := sb.String()
+			for _, s := range tt.wantContains {
+				assertContains(t, dump, s)
+			}
+			for _, s := range tt.wantNotContains {
+				assertNotContains(t, dump, s)
+			}
+		})
+	}
+}
+
+func TestFilterParallelBackup(t *testing.T) {
+	dir := t.TempDir()
+	manifest, err := SplitDump(strings.NewReader(testDump), dir, 0)
+	if err != nil {
+		t.Fatalf("unexpected error splitting dump: %v", err)
+	}
+
+	if err := FilterParallelBackup(dir, manifest, []string{"db.empty"}); err != nil {
+		t.Fatalf("unexpected error filtering parallel backup: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "data", "db.users.0.sql")); !os.IsNotExist(err) {
+		t.Fatalf("expected data file to be removed, got: %v", err)
+	}
+	schema := readTestFile(t, filepath.Join(dir, ParallelBackupSchemaFileName))
+	assertContains(t, schema, "CREATE TABLE `empty`")
+	assertNotContains(t, schema, "CREATE TABLE `users`")
+	assertNotContains(t, schema, "CREATE DATABASE")
+
+	postData := readTestFile(t, filepath.Join(dir, ParallelBackupPostDataFileName))
+	assertNotContains(t, postData, "TRIGGER users_ai")
+	assertNotContains(t, postData, "CREATE PROCEDURE")
+
+	dir = t.TempDir()
+	manifest, err = SplitDump(strings.NewReader(testDump), dir, 0)
+	if err != nil {
+		t.Fatalf("unexpected error splitting dump: %v", err)
+	}
+	if err := FilterParallelBackup(dir, manifest, []string{"db.users"}); err != nil {
+		t.Fatalf("unexpected error filtering parallel backup: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "data", "db.users.0.sql")); err != nil {
+		t.Fatalf("expected data file to be kept, got: %v", err)
+	}
+	postData = readTestFile(t, filepath.Join(dir, ParallelBackupPostDataFileName))
+	assertContains(t, postData, "USE `db`;")
+	assertContains(t, postData, "TRIGGER users_ai")
+	assertNotContains(t, postData, "CREATE PROCEDURE")
+}
@@ -10,18 +10,23 @@ import (
 	"os"
 	"path"
 	"path/filepath"
+	"slices"
 	"sort"
 	"time"
 
 	"github.com/go-logr/logr"
 	mariadbv1alpha1 "github.com/mariadb-operator/mariadb-operator/v26/api/v1alpha1"
 	"github.com/mariadb-operator/mariadb-operator/v26/pkg/azure"
+	"github.com/mariadb-operator/mariadb-operator/v26/pkg/backup"
 	mariadbcompression "github.com/mariadb-operator/mariadb-operator/v26/pkg/compression"
 	conditions "github.com/mariadb-operator/mariadb-operator/v26/pkg/condition"
 	"github.com/mariadb-operator/mariadb-operator/v26/pkg/environment"
+	"github.com/mariadb-operator/mariadb-operator/v26/pkg/filesystem"
+	"github.com/mariadb-operator/mariadb-operator/v26/pkg/gcs"
 	"github.com/mariadb-operator/mariadb-operator/v26/pkg/interfaces"
 	"github.com/mariadb-operator/mariadb-operator/v26/pkg/metadata"
 	mariadbminio "github.com/mariadb-operator/mariadb-operator/v26/pkg/minio"
+	"github.com/mariadb-operator/mariadb-operator/v26/pkg/ratelimit"
 	"github.com/mariadb-operator/mariadb-operator/v26/pkg/refresolver"
 	"github.com/mariadb-operator/mariadb-operator/v26/pkg/replication"
 	"github.com/mariadb-operator/mariadb-operator/v26/pkg/sql"
@@ -38,6 +43,11 @@ var (
 	BinlogIndexName        = "index.yaml"
 	archiveInterval        = 10 * time.Minute
 	defaultArchivalTimeout = metav1.Duration{Duration: time.Hour}
+	// streamingArchiveInterval is the interval between archive cycles when streaming is enabled.
+	// Streaming blocks the cycle until the active binary log is rotated, so the next cycle archives it shortly after.
+	streamingArchiveInterval = 10 * time.Second
+
+	errStreamingStopped = errors.New("binary log streaming stopped")
 )
 
 type Archiver struct {
@@ -81,6 +91,18 @@ func (a *Archiver) Start(ctx context.Context) error {
 				continue
 			}
 			archiveErr := a.archiveBinaryLogs(ctx, mdb)
+			if archiveErr == nil {
+				streaming, streamErr := a.streamBinaryLogs(ctx, mdb)
+				if streaming {
+					ticker.Reset(streamingArchiveInterval)
+				} else {
+					ticker.Reset(archiveInterval)
+				}
+				archiveErr = streamErr
+			}
+			if ctx.Err() != nil {
+				continue
+			}
 
 			if err := a.updateStatusWithError(ctx, mdb, archiveErr); err != nil {
 				return fmt.Errorf("error updating status with error: %v", err)
@@ -148,7 +170,9 @@ func (a *Archiver) archiveBinaryLogs(ctx context.Context, mdb *mariadbv1alpha1.M
 	if err != nil {
 		return err
 	}
-	storageClient, err := a.getStorageClient(&pitr.Spec.PointInTimeRecoveryStorage, a.env)
+	// The limiter is shared by all the storage clients, so the bandwidth limit applies to their aggregated throughput.
+	rateLimiter := ratelimit.NewLimiter(pitr.Spec.Throttling.BandwidthLimitBytes())
+	storageClient, err := a.getStorageClient(&pitr.Spec.PointInTimeRecoveryStorage, a.env, nil, rateLimiter)
 	if err != nil {
 		return err
 	}
@@ -161,12 +185,12 @@ func (a *Archiver) archiveBinaryLogs(ctx context.Context, mdb *mariadbv1alpha1.M
 		return errors.New("binary log storage is not ready for archival. Archival must start from a clean state")
 	}
 
-	isConfigured, err := a.physicalBackupConfigured(ctx, &pitr.Spec.PhysicalBackupRef, mdb)
+	isConfigured, err := a.baseBackupConfigured(ctx, pitr)
 	if err != nil {
-		return fmt.Errorf("error checking PhysicalBackup: %v", err)
+		return fmt.Errorf("error checking %s: %v", pitr.BaseBackupKind(), err)
 	}
 	if !isConfigured {
-		return errors.New("PhysicalBackup not configured. Skipping binary log archival...") //nolint:staticcheck
+		return fmt.Errorf("%s not configured. Skipping binary log archival...", pitr.BaseBackupKind()) //nolint:staticcheck
 	}
 
 	sqlClient, err := sql.NewLocalClientWithPodEnv(ctx, a.env)
@@ -186,6 +210,7 @@ func (a *Archiver) archiveBinaryLogs(ctx context.Context, mdb *mariadbv1alpha1.M
 		a.logger.V(1).Info("Only active binary log is available. Skipping binary log archival...")
 		return nil
 	}
+	localBinlogs := binlogs
 	// skip active binary log
 	binlogs = binlogs[:len(binlogs)-1]
 
@@ -193,33 +218,539 @@ func (a *Archiver) archiveBinaryLogs(ctx context.Context, mdb *mariadbv1alpha1.M
 		return fmt.Errorf("error resetting binary logs: %v", err)
 	}
 
-	compressor, err := a.getCompressor(pitr.Spec.Compression)
+	lastArchivedBinlog := ptr.Deref(mdb.Status.PointInTimeRecovery, mariadbv1alpha1.MariaDBPointInTimeRecoveryStatus{}).LastArchivedBinaryLog
+
+	uploader, compressor, objectMetadata, err := a.getUploader(pitr, storageClient, rateLimiter)
 	if err != nil {
 		return err
 	}
+
+	archiveTimeout := ptr.Deref(pitr.Spec.ArchiveTimeout, defaultArchivalTimeout).Duration
+	timeOutCtx, cancel := context.WithTimeout(ctx, archiveTimeout)
+	defer cancel()
+
+	archived, archiveErr := a.archiveBinaryLogsUntilTimeout(timeOutCtx, binlogs, mdb, pitr, uploader)
+	// The progress is recorded even if the archival did not complete, so it is resumed in the next archive cycle
+	// instead of starting over, and the binary logs pending to be archived are reported.
+	if err := a.updateStatus(ctx, binlogs[:archived], storageClient, sqlClient); err != nil {
+		return errors.Join(archiveErr, err)
+	}
+	if archiveErr != nil {
+		return archiveErr
+	}
+	a.logger.Info("Binlog archival done")
+
+	if err := a.removeStreamedChunks(ctx, binlogs, lastArchivedBinlog, uploader, pitr, sqlClient); err != nil {
+		return fmt.Errorf("error removing streamed binary log chunks: %v", err)
+	}
+	if err := a.consolidateStreamedChunks(ctx, localBinlogs, mdb, pitr, uploader, storageClient, sqlClient); err != nil {
+		return fmt.Errorf("error consolidating streamed binary log chunks: %v", err)
+	}
+	baseBackup, oldestGtid, err := a.getOldestBackupGtid(ctx, pitr, sqlClient)
+	if err != nil {
+		return fmt.Errorf("error getting oldest backup GTID: %v", err)
+	}
+	cdcSink := NewBlobStorageCDCSink(uploader.storageClient, uploader.compressor, pitr)
+	if err := a.applyRetention(ctx, pitr, uploader, cdcSink, storageClient, baseBackup, oldestGtid); err != nil {
+		return fmt.Errorf("error applying binary log retention: %v", err)
+	}
+	if err := a.exportCDCEvents(ctx, binlogs, pitr, cdcSink, sqlClient); err != nil {
+		return fmt.Errorf("error exporting CDC events: %v", err)
+	}
+
+	// The replication has its own timeout, as the archival to the primary storage may have used up most of the previous one.
+	replicationCtx, cancelReplication := context.WithTimeout(ctx, archiveTimeout)
+	defer cancelReplication()
+
+	return a.replicateBinaryLogs(replicationCtx, binlogs, mdb, pitr, compressor, objectMetadata, rateLimiter, oldestGtid)
+}
+
+// getUploader returns an Uploader that compresses and, when configured, encrypts the binary logs.
+func (a *Archiver) getUploader(pitr *mariadbv1alpha1.PointInTimeRecovery, storageClient interfaces.BlobStorage,
+	rateLimiter *ratelimit.Limiter) (*Uploader, mariadbcompression.Compressor, map[string]string, error) {
+	compressor, err := a.getCompressor(pitr.Spec.Compression, pitr.Spec.CompressionLevel)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	uploaderStorageClient := storageClient
+	var (
+		objectMetadata map[string]string
+		uploaderOpts   []UploaderOpt
+	)
+	if pitr.Spec.Encryption != nil {
+		keyring, err := a.getKeyring()
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		a.logger.V(1).Info("Encrypting binary logs", "key-id", keyring.ActiveKeyID())
+		compressor = mariadbcompression.NewEncryptedCompressor(compressor, keyring)
+		uploaderOpts = append(uploaderOpts, WithUploaderKeyring(keyring))
+
+		objectMetadata = map[string]string{
+			mariadbcompression.EncryptionKeyIDMetadataKey: keyring.ActiveKeyID(),
+		}
+		uploaderStorageClient, err = a.getStorageClient(&pitr.Spec.PointInTimeRecoveryStorage, a.env, objectMetadata, rateLimiter)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+	}
 	uploader := NewUploader(
 		a.dataDir,
-		storageClient,
+		uploaderStorageClient,
 		compressor,
 		a.logger.WithName("uploader"),
+		uploaderOpts...,
 	)
+	return uploader, compressor, objectMetadata, nil
+}
 
-	timeOutCtx, cancel := context.WithTimeout(ctx, ptr.Deref(pitr.Spec.ArchiveTimeout, defaultArchivalTimeout).Duration)
-	defer cancel()
+// removeStreamedChunks removes the chunks of the binary logs archived in this cycle,
+// which have been superseded by the complete binary logs.
+func (a *Archiver) removeStreamedChunks(ctx context.Context, binlogs []string, lastArchivedBinlog string, uploader *Uploader,
+	pitr *mariadbv1alpha1.PointInTimeRecovery, sqlClient *sql.Client) error {
+	if !pitr.Spec.Streaming.IsEnabled() {
+		return nil
+	}
+	serverId, err := sqlClient.ServerId(ctx)
+	if err != nil {
+		return fmt.Errorf("error getting server_id: %v", err)
+	}
+	archivedBinlogs, err := binlogsAfter(binlogs, lastArchivedBinlog)
+	if err != nil {
+		return err
+	}
+	for _, binlog := range archivedBinlogs {
+		meta := &BinlogMetadata{
+			ServerId:       *serverId,
+			BinlogFilename: binlog,
+		}
+		if err := uploader.RemoveChunks(ctx, meta, pitr); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// consolidateStreamedChunks replaces the binary logs that have only been streamed in chunks by complete binary logs,
+// when they are no longer available in this Pod to be archived, for example, the ones streamed by a previous primary before a failover.
+// The chunks are removed once the binlog index references the complete binary logs.
+// Binary logs that cannot be consolidated, for instance, because of a missing chunk, are skipped and retried in the next archive cycle.
+func (a *Archiver) consolidateStreamedChunks(ctx context.Context, localBinlogs []string, mdb *mariadbv1alpha1.MariaDB,
+	pitr *mariadbv1alpha1.PointInTimeRecovery, uploader *Uploader, storageClient interfaces.BlobStorage, sqlClient *sql.Client) error {
+	serverId, err := sqlClient.ServerId(ctx)
+	if err != nil {
+		return fmt.Errorf("error getting server_id: %v", err)
+	}
+	index, err := a.getBinlogIndex(ctx, storageClient)
+	if err != nil {
+		return err
+	}
 
+	var consolidated []BinlogMetadata
+	for _, binlogs := range index.Binlogs {
+		for _, meta := range binlogs {
+			if meta.Chunks == 0 || (meta.ServerId == *serverId && slices.Contains(localBinlogs, meta.BinlogFilename)) {
+				continue
+			}
+			consolidatedMeta, err := uploader.ConsolidateChunks(ctx, &meta, mdb, pitr)
+			if err != nil {
+				a.logger.Error(err, "Error consolidating binary log chunks", "binlog", meta.ObjectStoragePath())
+				continue
+			}
+			index.Add(meta.ServerId, *consolidatedMeta)
+			consolidated = append(consolidated, meta)
+		}
+	}
+	if len(consolidated) == 0 {
+		return nil
+	}
+	if err := a.putBinlogIndex(ctx, index, storageClient); err != nil {
+		return err
+	}
+	for _, meta := range consolidated {
+		if err := uploader.RemoveChunks(ctx, &meta, pitr); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// getOldestBackupGtid returns the base backup along with the GTID of the oldest backup retained by it,
+// which is nil when it has not been tracked yet.
+func (a *Archiver) getOldestBackupGtid(ctx context.Context, pitr *mariadbv1alpha1.PointInTimeRecovery,
+	sqlClient *sql.Client) (client.Object, *replication.Gtid, error) {
+	backup, err := a.getBaseBackup(ctx, pitr)
+	if err != nil {
+		return nil, nil, err
+	}
+	oldestGtidRaw, ok := backup.GetAnnotations()[metadata.OldestGtidAnnotation]
+	if !ok {
+		a.logger.V(1).Info(
+			"Oldest GTID annotation not found in base backup. Skipping binary log retention...",
+			"kind", pitr.BaseBackupKind(),
+			"backup", backup.GetName(),
+		)
+		return backup, nil, nil
+	}
+	gtidDomainId, err := sqlClient.GtidDomainId(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error getting gtid_domain_id: %v", err)
+	}
+	oldestGtid, err := replication.ParseGtidWithDomainId(oldestGtidRaw, *gtidDomainId, a.logger)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error parsing GTID: %v", err)
+	}
+	return backup, oldestGtid, nil
+}
+
+// applyRetention purges the archived binary logs that are no longer needed by the oldest retained base backup,
+// along with their CDC events, when the PhysicalBackup retention mode is configured, and reports the earliest recoverable time.
+func (a *Archiver) applyRetention(ctx context.Context, pitr *mariadbv1alpha1.PointInTimeRecovery, uploader *Uploader,
+	cdcSink *BlobStorageCDCSink, storageClient interfaces.BlobStorage, backup client.Object, oldestGtid *replication.Gtid) error {
+	if oldestGtid == nil {
+		return nil
+	}
+	index, err := a.getBinlogIndex(ctx, storageClient)
+	if err != nil {
+		return err
+	}
+
+	if pitr.Spec.Retention.IsPhysicalBackupMode() {
+		purgeable := index.PurgeableBinlogs(oldestGtid, pitr.Spec.Retention.GetSafetyMargin(), a.logger.WithName("retention"))
+		if err := a.purgeBinlogs(ctx, index, purgeable, pitr, uploader, cdcSink, storageClient, a.logger); err != nil {
+			return err
+		}
+	}
+
+	earliestRecoverableTime := a.getEarliestRecoverableTime(index, backup, oldestGtid)
+	if ptr.Equal(earliestRecoverableTime, pitr.Status.EarliestRecoverableTime) {
+		return nil
+	}
+	if err := a.patchPITRStatus(ctx, pitr, func(status *mariadbv1alpha1.PointInTimeRecoveryStatus) {
+		status.EarliestRecoverableTime = earliestRecoverableTime
+	}); err != nil {
+		return fmt.Errorf("error patching PITR status: %v", err)
+	}
+	return nil
+}
+
+// applySecondaryRetention purges the binary logs of a secondary storage. When the secondary storage defines its own retention,
+// the binary logs older than the maximum age of the retention, plus the safety margin, are purged. Otherwise, the retention of the
+// primary storage is followed, purging the binary logs no longer needed by the oldest retained base backup.
+// The binlog index of the secondary storage is used, as it may differ from the primary one, for instance, after being unreachable.
+func (a *Archiver) applySecondaryRetention(ctx context.Context, pitr *mariadbv1alpha1.PointInTimeRecovery,
+	storage *mariadbv1alpha1.SecondaryStorage, index *BinlogIndex, oldestGtid *replication.Gtid, uploader *Uploader,
+	storageClient interfaces.BlobStorage, logger logr.Logger) error {
+	safetyMargin := pitr.Spec.Retention.GetSafetyMargin()
+
+	if storage.HasRetention() {
+		policy := backup.NewRetentionPolicy(ptr.Deref(storage.MaxRetention, metav1.Duration{}).Duration, storage.Retention)
+		maxAge := policy.MaxAge()
+		if maxAge <= 0 {
+			logger.V(1).Info("Retention of secondary storage is not bounded in time. Skipping purge", "storage", storage.Name)
+			return nil
+		}
+		expired := index.ExpiredBinlogs(time.Now().Add(-maxAge - safetyMargin))
+		return a.purgeBinlogs(ctx, index, expired, pitr, uploader, nil, storageClient, logger)
+	}
+
+	if oldestGtid == nil || !pitr.Spec.Retention.IsPhysicalBackupMode() {
+		return nil
+	}
+	purgeable := index.PurgeableBinlogs(oldestGtid, safetyMargin, logger.WithName("retention"))
+	return a.purgeBinlogs(ctx, index, purgeable, pitr, uploader, nil, storageClient, logger)
+}
+
+// purgeBinlogs removes the purgeable binary logs from the storage and from its binlog index, along with their CDC events
+// when a CDC sink is provided. Binary logs that are locked or that cannot be found in the storage are kept in the index.
+func (a *Archiver) purgeBinlogs(ctx context.Context, index *BinlogIndex, purgeable []BinlogMetadata,
+	pitr *mariadbv1alpha1.PointInTimeRecovery, uploader *Uploader, cdcSink *BlobStorageCDCSink,
+	storageClient interfaces.BlobStorage, logger logr.Logger) error {
+	var purged int
+	for _, meta := range purgeable {
+		removed, err := uploader.Remove(ctx, &meta, pitr)
+		if err != nil {
+			return fmt.Errorf("error removing binlog %s: %v", meta.ObjectStoragePath(), err)
+		}
+		if !removed {
+			continue
+		}
+		if cdcSink != nil {
+			// the events are removed regardless of whether CDC is currently enabled, as they may have been exported before.
+			if removed, err := cdcSink.Remove(ctx, &meta); err != nil {
+				return fmt.Errorf("error removing CDC events of binlog %s: %v", meta.ObjectStoragePath(), err)
+			} else if !removed {
+				logger.V(1).Info("CDC events are locked. Skipping removal...", "binlog", meta.ObjectStoragePath())
+			}
+		}
+		index.Remove(meta.ServerId, meta.BinlogFilename)
+		purged++
+		logger.Info("Binary log purged", "binlog", meta.ObjectStoragePath())
+	}
+	if purged > 0 {
+		return a.putBinlogIndex(ctx, index, storageClient)
+	}
+	return nil
+}
+
+// exportCDCEvents exports the row events of the archived binary logs when change data capture is enabled.
+// The last exported binary log is recorded as checkpoint after every binary log, so the binary logs of this server up to it
+// are not decoded again in the next archive cycle. The binary logs of other servers, for instance after a switchover,
+// are decoded and only the transactions after the last exported GTID are exported.
+func (a *Archiver) exportCDCEvents(ctx context.Context, binlogs []string, pitr *mariadbv1alpha1.PointInTimeRecovery,
+	cdcSink CDCSink, sqlClient *sql.Client) error {
+	if !pitr.Spec.CDC.IsEnabled() {
+		return nil
+	}
+	serverId, err := sqlClient.ServerId(ctx)
+	if err != nil {
+		return fmt.Errorf("error getting server_id: %v", err)
+	}
+	status := ptr.Deref(pitr.Status.CDC, mariadbv1alpha1.BinlogCDCStatus{})
+
+	pendingBinlogs := binlogs
+	if status.LastExportedServerId == *serverId {
+		pendingBinlogs, err = binlogsAfter(binlogs, status.LastExportedBinaryLog)
+		if err != nil {
+			return err
+		}
+	}
+	exporter := NewCDCExporter(a.dataDir, cdcSink, a.logger.WithName("cdc"))
+
+	for _, binlog := range pendingBinlogs {
+		gtid, err := exporter.Export(ctx, *serverId, binlog, status.LastExportedGtid)
+		if err != nil {
+			return fmt.Errorf("error exporting binary log %s: %v", binlog, err)
+		}
+		status.LastExportedServerId = *serverId
+		status.LastExportedBinaryLog = binlog
+		if gtid != nil {
+			status.LastExportedGtid = gtid
+			status.LastExportTime = ptr.To(metav1.NewTime(time.Now()))
+		}
+
+		if err := a.patchPITRStatus(ctx, pitr, func(s *mariadbv1alpha1.PointInTimeRecoveryStatus) {
+			s.CDC = status.DeepCopy()
+		}); err != nil {
+			return fmt.Errorf("error patching PITR status: %v", err)
+		}
+	}
+	return nil
+}
+
+// getEarliestRecoverableTime returns the time of the oldest retained physical backup, provided that a binlog timeline can be built from it.
+func (a *Archiver) getEarliestRecoverableTime(index *BinlogIndex, backup client.Object,
+	oldestGtid *replication.Gtid) *string {
+	oldestTime, ok := backup.GetAnnotations()[metadata.OldestTimeAnnotation]
+	if !ok {
+		return nil
+	}
+	if _, err := index.BuildTimeline(oldestGtid, time.Now(), false, a.logger.WithName("binlog-timeline").V(1)); err != nil {
+		a.logger.V(1).Info(
+			"Unable to build binlog timeline from oldest backup. Skipping earliest recoverable time tracking...",
+			"err", err,
+			"gtid", oldestGtid.String(),
+			"backup", backup.GetName(),
+		)
+		return nil
+	}
+	return &oldestTime
+}
+
+// streamBinaryLogs streams the active binary log when streaming is enabled, returning once it has been rotated, so it can be archived.
+// It returns whether the streaming is enabled.
+func (a *Archiver) streamBinaryLogs(ctx context.Context, mdb *mariadbv1alpha1.MariaDB) (bool, error) {
+	pitr, err := a.getPointInTimeRecovery(ctx, mdb)
+	if err != nil {
+		return false, err
+	}
+	if !pitr.Spec.Streaming.IsEnabled() {
+		return false, nil
+	}
+	rateLimiter := ratelimit.NewLimiter(pitr.Spec.Throttling.BandwidthLimitBytes())
+	storageClient, err := a.getStorageClient(&pitr.Spec.PointInTimeRecoveryStorage, a.env, nil, rateLimiter)
+	if err != nil {
+		return true, err
+	}
+	uploader, _, _, err := a.getUploader(pitr, storageClient, rateLimiter)
+	if err != nil {
+		return true, err
+	}
+	backup, err := a.getBaseBackup(ctx, pitr)
+	if err != nil {
+		return true, err
+	}
+
+	sqlClient, err := sql.NewLocalClientWithPodEnv(ctx, a.env)
+	if err != nil {
+		return true, fmt.Errorf("error getting SQL client: %v", err)
+	}
+	defer sqlClient.Close()
+
+	binlogs, err := a.getBinaryLogs(ctx, sqlClient)
+	if err != nil {
+		return true, fmt.Errorf("error getting binary logs: %v", err)
+	}
+	if len(binlogs) == 0 {
+		return true, errors.New("no binary logs were found")
+	}
+	activeBinlog := binlogs[len(binlogs)-1]
+
+	serverId, err := sqlClient.ServerId(ctx)
+	if err != nil {
+		return true, fmt.Errorf("error getting server_id: %v", err)
+	}
+	gtidDomainId, err := sqlClient.GtidDomainId(ctx)
+	if err != nil {
+		return true, fmt.Errorf("error getting gtid_domain_id: %v", err)
+	}
+	index, err := a.getBinlogIndex(ctx, storageClient)
+	if err != nil {
+		return true, err
+	}
+	previous, _ := index.Get(*serverId, activeBinlog)
+
+	streamer := NewStreamer(a.env, uploader, pitr.Spec.Streaming.GetFlushInterval(), a.logger.WithName("streamer"))
+	err = streamer.Stream(ctx, activeBinlog, previous, pitr, func(ctx context.Context, meta *BinlogMetadata) error {
+		return a.updateStreamingStatus(ctx, meta, index, storageClient, backup, *gtidDomainId, pitr)
+	})
+	if errors.Is(err, errStreamingStopped) {
+		return true, nil
+	}
+	return true, err
+}
+
+// updateStreamingStatus adds the streamed chunks to the binlog index and updates the last recoverable time accordingly.
+// It stops the streaming when the binary logs should no longer be archived from this Pod, for example, after a switchover.
+func (a *Archiver) updateStreamingStatus(ctx context.Context, meta *BinlogMetadata, index *BinlogIndex,
+	storageClient interfaces.BlobStorage, backup client.Object, gtidDomainId uint32,
+	pitr *mariadbv1alpha1.PointInTimeRecovery) error {
+	mdb, err := a.getMariaDB(ctx)
+	if err != nil {
+		return err
+	}
+	if !a.shouldArchiveBinlogs(mdb) {
+		return errStreamingStopped
+	}
+
+	index.Add(meta.ServerId, *meta)
+	if err := a.putBinlogIndex(ctx, index, storageClient); err != nil {
+		return err
+	}
+	lastRecoverableTime, err := a.getLastRecoverableTime(index, backup, gtidDomainId)
+	if err != nil {
+		return fmt.Errorf("error getting last recoverable time: %v", err)
+	}
+	if lastRecoverableTime == nil {
+		return nil
+	}
+	lastRecoverableTimeRaw := lastRecoverableTime.Format(time.RFC3339)
+	if ptr.Deref(pitr.Status.LastRecoverableTime, "") == lastRecoverableTimeRaw {
+		return nil
+	}
+	if err := a.patchPITRStatus(ctx, pitr, func(status *mariadbv1alpha1.PointInTimeRecoveryStatus) {
+		status.LastRecoverableTime = ptr.To(lastRecoverableTimeRaw)
+	}); err != nil {
+		return fmt.Errorf("error patching PITR status: %v", err)
+	}
+	return nil
+}
+
+// archiveBinaryLogsUntilTimeout archives the binary logs in order, returning how many of them have been archived.
+func (a *Archiver) archiveBinaryLogsUntilTimeout(ctx context.Context, binlogs []string, mdb *mariadbv1alpha1.MariaDB,
+	pitr *mariadbv1alpha1.PointInTimeRecovery, uploader *Uploader) (int, error) {
 	for i := 0; i < len(binlogs); i++ {
 		select {
-		case <-timeOutCtx.Done():
-			return fmt.Errorf("archival timed out: %w", timeOutCtx.Err())
+		case <-ctx.Done():
+			return i, fmt.Errorf("archival timed out: %w", ctx.Err())
 		default:
-			if err := a.archiveBinaryLog(timeOutCtx, binlogs[i], mdb, pitr, uploader); err != nil {
-				return err
+			if err := a.archiveBinaryLog(ctx, binlogs[i], mdb, pitr, uploader); err != nil {
+				return i, err
 			}
 		}
 	}
-	a.logger.Info("Binlog archival done")
+	return len(binlogs), nil
+}
+
+// replicateBinaryLogs copies the archived binary logs and the binlog index to the secondary storages, and applies their retention.
+// Binary logs already present in a secondary storage are skipped, allowing it to catch up after being unreachable.
+// Replication errors are reported in the PointInTimeRecovery status and they do not affect the archival to the primary storage.
+func (a *Archiver) replicateBinaryLogs(ctx context.Context, binlogs []string, mdb *mariadbv1alpha1.MariaDB,
+	pitr *mariadbv1alpha1.PointInTimeRecovery, compressor mariadbcompression.Compressor, objectMetadata map[string]string,
+	rateLimiter *ratelimit.Limiter, oldestGtid *replication.Gtid) error {
+	if len(pitr.Spec.SecondaryStorages) == 0 {
+		return nil
+	}
+	lastBinlogMeta, err := GetBinlogMetadata(filepath.Join(a.dataDir, binlogs[len(binlogs)-1]), a.logger)
+	if err != nil {
+		return fmt.Errorf("error getting last binlog %s metadata: %v", binlogs[len(binlogs)-1], err)
+	}
+
+	statuses := make([]mariadbv1alpha1.SecondaryStorageStatus, len(pitr.Spec.SecondaryStorages))
+	for i, storage := range pitr.Spec.SecondaryStorages {
+		logger := a.logger.WithValues("secondary-storage", storage.Name)
+		logger.Info("Replicating binary logs")
+
+		now := metav1.NewTime(time.Now())
+		statuses[i] = mariadbv1alpha1.SecondaryStorageStatus{
+			Name:                storage.Name,
+			FileName:            binlogs[len(binlogs)-1],
+			LastReplicationTime: &now,
+		}
+		if err := a.replicateBinaryLogsToStorage(ctx, i, &storage, binlogs, lastBinlogMeta.ServerId, mdb, pitr, compressor,
+			objectMetadata, rateLimiter, oldestGtid, logger); err != nil {
+			logger.Error(err, "Error replicating binary logs")
+			statuses[i].Message = err.Error()
+			continue
+		}
+		statuses[i].Succeeded = true
+		statuses[i].LastSuccessfulReplicationTime = &now
+	}
 
-	return a.updateStatus(ctx, binlogs, storageClient, sqlClient)
+	if err := a.patchPITRStatus(ctx, pitr, func(status *mariadbv1alpha1.PointInTimeRecoveryStatus) {
+		for _, s := range statuses {
+			mariadbv1alpha1.SetSecondaryStorageStatus(&status.SecondaryStorages, s)
+		}
+	}); err != nil {
+		return fmt.Errorf("error patching PITR status: %v", err)
+	}
+	return nil
+}
+
+func (a *Archiver) replicateBinaryLogsToStorage(ctx context.Context, index int, storage *mariadbv1alpha1.SecondaryStorage,
+	binlogs []string, serverId uint32, mdb *mariadbv1alpha1.MariaDB, pitr *mariadbv1alpha1.PointInTimeRecovery,
+	compressor mariadbcompression.Compressor, objectMetadata map[string]string, rateLimiter *ratelimit.Limiter,
+	oldestGtid *replication.Gtid, logger logr.Logger) error {
+	storageClient, err := backup.NewSecondaryBlobStorage(
+		index,
+		storage,
+		a.dataDir,
+		backup.WithSecondaryStorageNestedPrefixes(true),
+		backup.WithSecondaryStorageMetadata(objectMetadata),
+		backup.WithSecondaryStorageRateLimiter(rateLimiter),
+	)
+	if err != nil {
+		return fmt.Errorf("error getting secondary storage client: %v", err)
+	}
+	uploader := NewUploader(
+		a.dataDir,
+		storageClient,
+		compressor,
+		logger.WithName("uploader"),
+	)
+	for _, binlog := range binlogs {
+		if err := uploader.Upload(ctx, binlog, mdb, pitr); err != nil {
+			return fmt.Errorf("error uploading binary log %s: %v", binlog, err)
+		}
+	}
+	binlogIndex, err := a.updateBinlogIndex(ctx, binlogs, serverId, storageClient)
+	if err != nil {
+		return fmt.Errorf("error updating binlog index: %v", err)
+	}
+	if err := a.applySecondaryRetention(ctx, pitr, storage, binlogIndex, oldestGtid, uploader, storageClient, logger); err != nil {
+		return fmt.Errorf("error applying binary log retention: %v", err)
+	}
+	return nil
 }
 
 func (a *Archiver) getMariaDB(ctx context.Context) (*mariadbv1alpha1.MariaDB, error) {
@@ -246,27 +777,80 @@ func (a *Archiver) getPointInTimeRecovery(ctx context.Context, mdb *mariadbv1alp
 }
 
 func (a *Archiver) getStorageClient(storage *mariadbv1alpha1.PointInTimeRecoveryStorage,
-	env *environment.PodEnvironment) (interfaces.BlobStorage, error) {
+	env *environment.PodEnvironment, objectMetadata map[string]string, rateLimiter *ratelimit.Limiter) (interfaces.BlobStorage, error) {
 	if storage.AzureBlob != nil {
-		return a.getABSClient(storage.AzureBlob, env)
+		return a.getABSClient(storage.AzureBlob, env, objectMetadata, rateLimiter)
 	}
 
 	if storage.S3 != nil {
-		return a.getS3Client(storage.S3, env)
+		return a.getS3Client(storage.S3, env, objectMetadata, rateLimiter)
 	}
 
-	return nil, errors.New("error getting a storage client, none configured. Either abs or s3 must be configured")
+	if storage.GCS != nil {
+		return a.getGCSClient(storage.GCS, env, objectMetadata, rateLimiter)
+	}
+
+	if storage.IsFileSystem() {
+		return a.getFileSystemClient(env, rateLimiter)
+	}
+
+	return nil, errors.New("error getting a storage client, none configured. Either abs, s3, gcs, persistentVolumeClaim or volume must be configured")
+}
+
+// getFileSystemClient retrieves a client for the volume mounted in the Pod
+// @WARN: should not be used directly, see `getStorageClient`
+func (a *Archiver) getFileSystemClient(env *environment.PodEnvironment,
+	rateLimiter *ratelimit.Limiter) (*filesystem.FileSystemClient, error) {
+	if env.MariadbOperatorPITRStoragePath == "" {
+		return nil, errors.New("filesystem storage path is not available in the environment")
+	}
+	client, err := filesystem.NewFileSystemClient(
+		a.dataDir,
+		env.MariadbOperatorPITRStoragePath,
+		filesystem.WithAllowNestedPrefixes(true),
+		filesystem.WithRateLimiter(rateLimiter),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error getting filesystem client: %v", err)
+	}
+	return client, nil
+}
+
+// getGCSClient retrieves a Google Cloud Storage client
+// @WARN: should not be used directly, see `getStorageClient`
+func (a *Archiver) getGCSClient(gcsStorage *mariadbv1alpha1.GCS, env *environment.PodEnvironment,
+	objectMetadata map[string]string, rateLimiter *ratelimit.Limiter) (*gcs.GCSClient, error) {
+	opts := []gcs.GCSOpt{
+		gcs.WithEndpoint(gcsStorage.Endpoint),
+		gcs.WithPrefix(gcsStorage.Prefix),
+		gcs.WithAllowNestedPrefixes(true),
+		gcs.WithMetadata(objectMetadata),
+		gcs.WithRateLimiter(rateLimiter),
+	}
+	if env.MariadbOperatorGCSServiceAccountKey != "" {
+		opts = append(opts, gcs.WithServiceAccountKey([]byte(env.MariadbOperatorGCSServiceAccountKey)))
+	}
+
+	client, err := gcs.NewGCSClient(a.dataDir, gcsStorage.Bucket, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("error getting GCS client: %v", err)
+	}
+	return client, nil
 }
 
 // getABSClient retrieves an Azure Blob Storage client
 // @WARN: should not be used directly, see `getStorageClient`
-func (a *Archiver) getABSClient(abs *mariadbv1alpha1.AzureBlob, env *environment.PodEnvironment) (*azure.AzBlobClient, error) {
+func (a *Archiver) getABSClient(abs *mariadbv1alpha1.AzureBlob, env *environment.PodEnvironment,
+	objectMetadata map[string]string, rateLimiter *ratelimit.Limiter) (*azure.AzBlobClient, error) {
 	tls := ptr.Deref(abs.TLS, mariadbv1alpha1.TLSConfig{})
 	opts := []azure.AzBlobOpt{
 		azure.WithAccountName(abs.StorageAccountName),
 		azure.WithTLSEnabled(tls.Enabled),
 		azure.WithAllowNestedPrefixes(true),
 		azure.WithPrefix(abs.Prefix),
+		azure.WithMetadata(objectMetadata),
+		azure.WithRateLimiter(rateLimiter),
+		azure.WithObjectLock(abs.ObjectLock),
 	}
 	if env.MariadbOperatorABSCAPath != "" {
 		opts = append(opts, azure.WithTLSCACertPath(env.MariadbOperatorABSCAPath))
@@ -286,13 +870,17 @@ func (a *Archiver) getABSClient(abs *mariadbv1alpha1.AzureBlob, env *environment
 
 // getS3Client retrieves an S3 client
 // @WARN: should not be used directly, see `getStorageClient`
-func (a *Archiver) getS3Client(s3 *mariadbv1alpha1.S3, env *environment.PodEnvironment) (*mariadbminio.Client, error) {
+func (a *Archiver) getS3Client(s3 *mariadbv1alpha1.S3, env *environment.PodEnvironment,
+	objectMetadata map[string]string, rateLimiter *ratelimit.Limiter) (*mariadbminio.Client, error) {
 	tls := ptr.Deref(s3.TLS, mariadbv1alpha1.TLSConfig{})
 	minioOpts := []mariadbminio.MinioOpt{
 		mariadbminio.WithTLS(tls.Enabled),
 		mariadbminio.WithRegion(s3.Region),
 		mariadbminio.WithPrefix(s3.Prefix),
 		mariadbminio.WithAllowNestedPrefixes(true),
+		mariadbminio.WithUserMetadata(objectMetadata),
+		mariadbminio.WithRateLimiter(rateLimiter),
+		mariadbminio.WithObjectLock(s3.ObjectLock),
 	}
 	if env.MariadbOperatorS3CAPath != "" {
 		minioOpts = append(minioOpts, mariadbminio.WithCACertPath(env.MariadbOperatorS3CAPath))
@@ -310,14 +898,25 @@ func (a *Archiver) getS3Client(s3 *mariadbv1alpha1.S3, env *environment.PodEnvir
 	return client, nil
 }
 
-func (a *Archiver) getCompressor(calg mariadbv1alpha1.CompressAlgorithm) (mariadbcompression.Compressor, error) {
+func (a *Archiver) getCompressor(calg mariadbv1alpha1.CompressAlgorithm, level *int32) (mariadbcompression.Compressor, error) {
 	if calg == mariadbv1alpha1.CompressAlgorithm("") {
 		calg = mariadbv1alpha1.CompressNone
 	}
 	if err := calg.Validate(); err != nil {
 		return nil, fmt.Errorf("compression algorithm not supported: %v", err)
 	}
-	return mariadbcompression.NewCompressor(calg)
+	return mariadbcompression.NewCompressor(calg, mariadbcompression.WithCompressionLevel(level))
+}
+
+func (a *Archiver) getKeyring() (*mariadbcompression.Keyring, error) {
+	if a.env.MariadbOperatorEncryptionKey == "" {
+		return nil, errors.New("encryption is enabled but the encryption key is not available in the environment")
+	}
+	keyring, err := mariadbcompression.NewKeyringFromEncodedKeys(a.env.MariadbOperatorEncryptionKey)
+	if err != nil {
+		return nil, fmt.Errorf("error getting encryption keyring: %v", err)
+	}
+	return keyring, nil
 }
 
 func (a *Archiver) checkStorageReadyForArchival(ctx context.Context, mdb *mariadbv1alpha1.MariaDB,
@@ -347,19 +946,30 @@ func (a *Archiver) checkStorageReadyForArchival(ctx context.Context, mdb *mariad
 	return true, nil
 }
 
-func (a *Archiver) physicalBackupConfigured(ctx context.Context, ref *mariadbv1alpha1.LocalObjectReference,
-	mdb *mariadbv1alpha1.MariaDB) (bool, error) {
-	key := types.NamespacedName{
-		Name:      ref.Name,
-		Namespace: mdb.Namespace,
-	}
-	var physicalBackup mariadbv1alpha1.PhysicalBackup
-	if err := a.client.Get(ctx, key, &physicalBackup); err != nil {
+func (a *Archiver) baseBackupConfigured(ctx context.Context, pitr *mariadbv1alpha1.PointInTimeRecovery) (bool, error) {
+	if _, err := a.getBaseBackup(ctx, pitr); err != nil {
 		return false, err
 	}
 	return true, nil
 }
 
+// getBaseBackup returns the PhysicalBackup or logical Backup used as base backup by the PointInTimeRecovery,
+// whose annotations track the GTIDs of the backups available in the storage.
+func (a *Archiver) getBaseBackup(ctx context.Context, pitr *mariadbv1alpha1.PointInTimeRecovery) (client.Object, error) {
+	if pitr.IsLogical() {
+		backup, err := a.refResolver.Backup(ctx, pitr.Spec.BackupRef, a.env.PodNamespace)
+		if err != nil {
+			return nil, fmt.Errorf("error getting Backup: %v", err)
+		}
+		return backup, nil
+	}
+	backup, err := a.refResolver.PhysicalBackup(ctx, pitr.Spec.PhysicalBackupRef, a.env.PodNamespace)
+	if err != nil {
+		return nil, fmt.Errorf("error getting PhysicalBackup: %v", err)
+	}
+	return backup, nil
+}
+
 func (a *Archiver) getBinaryLogs(ctx context.Context, sqlClient *sql.Client) ([]string, error) {
 	binaryLogIndex, err := sqlClient.BinaryLogIndex(ctx)
 	if err != nil {
@@ -452,13 +1062,16 @@ func (a *Archiver) updateStatus(ctx context.Context, binlogs []string, storageCl
 	if err != nil {
 		return err
 	}
+	if len(binlogs) == 0 {
+		return a.updateArchivalLag(ctx, mdb, sqlClient)
+	}
 	pitr, err := a.getPointInTimeRecovery(ctx, mdb)
 	if err != nil {
 		return err
 	}
-	backup, err := a.refResolver.PhysicalBackup(ctx, &pitr.Spec.PhysicalBackupRef, a.env.PodNamespace)
+	backup, err := a.getBaseBackup(ctx, pitr)
 	if err != nil {
-		return fmt.Errorf("error getting PhysicalBackup: %v", err)
+		return err
 	}
 	gtidDomainId, err := sqlClient.GtidDomainId(ctx)
 	if err != nil {
@@ -469,7 +1082,16 @@ func (a *Archiver) updateStatus(ctx context.Context, binlogs []string, storageCl
 	if err != nil {
 		return fmt.Errorf("error getting PITR status: %v", err)
 	}
-	binlogIndex, err := a.updateBinlogIndex(ctx, binlogs, pitrStatus.ServerId, storageClient)
+	if err := a.setArchivalLag(ctx, pitrStatus, sqlClient); err != nil {
+		return fmt.Errorf("error getting archival lag: %v", err)
+	}
+	// binlogs archived in previous cycles are not added again, as they might have been purged from the index.
+	lastArchivedBinlog := ptr.Deref(mdb.Status.PointInTimeRecovery, mariadbv1alpha1.MariaDBPointInTimeRecoveryStatus{}).LastArchivedBinaryLog
+	newBinlogs, err := binlogsAfter(binlogs, lastArchivedBinlog)
+	if err != nil {
+		return err
+	}
+	binlogIndex, err := a.updateBinlogIndex(ctx, newBinlogs, pitrStatus.ServerId, storageClient)
 	if err != nil {
 		return fmt.Errorf("error updating binlog index: %v", err)
 	}
@@ -493,6 +1115,97 @@ func (a *Archiver) updateStatus(ctx context.Context, binlogs []string, storageCl
 	return nil
 }
 
+// updateArchivalLag updates the binary logs pending to be archived when none of them could be archived.
+func (a *Archiver) updateArchivalLag(ctx context.Context, mdb *mariadbv1alpha1.MariaDB, sqlClient *sql.Client) error {
+	pitrStatus := ptr.Deref(mdb.Status.PointInTimeRecovery, mariadbv1alpha1.MariaDBPointInTimeRecoveryStatus{})
+	if err := a.setArchivalLag(ctx, &pitrStatus, sqlClient); err != nil {
+		return fmt.Errorf("error getting archival lag: %v", err)
+	}
+	if err := a.patchMariadbStatus(ctx, mdb, func(status *mariadbv1alpha1.MariaDBStatus) {
+		status.PointInTimeRecovery = &pitrStatus
+	}); err != nil {
+		return fmt.Errorf("error patching MariaDB PITR status: %v", err)
+	}
+	return nil
+}
+
+// setArchivalLag sets the binary logs that have been closed after the last archived one, including the ones rotated
+// while the archival was in progress, allowing to detect when the archival is not able to keep up.
+func (a *Archiver) setArchivalLag(ctx context.Context, status *mariadbv1alpha1.MariaDBPointInTimeRecoveryStatus,
+	sqlClient *sql.Client) error {
+	status.PendingBinaryLogs = 0
+	status.PendingBytes = 0
+	status.ArchivalLag = nil
+
+	pendingBinlogs, err := a.getPendingBinaryLogs(ctx, status.LastArchivedBinaryLog, sqlClient)
+	if err != nil {
+		return err
+	}
+	if len(pendingBinlogs) == 0 {
+		return nil
+	}
+	for _, binlog := range pendingBinlogs {
+		info, err := os.Stat(filepath.Join(a.dataDir, binlog))
+		if err != nil {
+			return fmt.Errorf("error getting binary log %s info: %v", binlog, err)
+		}
+		status.PendingBytes += info.Size()
+	}
+	status.PendingBinaryLogs = int32(len(pendingBinlogs))
+
+	lastPendingBinlog := pendingBinlogs[len(pendingBinlogs)-1]
+	meta, err := GetBinlogMetadata(filepath.Join(a.dataDir, lastPendingBinlog), a.logger)
+	if err != nil {
+		return fmt.Errorf("error getting binary log %s metadata: %v", lastPendingBinlog, err)
+	}
+	if !status.LastArchivedTime.IsZero() && meta.LastTime.After(status.LastArchivedTime.Time) {
+		status.ArchivalLag = &metav1.Duration{Duration: meta.LastTime.Sub(status.LastArchivedTime.Time)}
+	}
+	a.logger.Info(
+		"Binary logs pending to be archived",
+		"pending-binlogs", status.PendingBinaryLogs,
+		"pending-bytes", status.PendingBytes,
+		"lag", ptr.Deref(status.ArchivalLag, metav1.Duration{}).Duration,
+	)
+	return nil
+}
+
+// getPendingBinaryLogs returns the binary logs, excluding the active one, that are more recent than the last archived binary log.
+func (a *Archiver) getPendingBinaryLogs(ctx context.Context, lastArchivedBinlog string, sqlClient *sql.Client) ([]string, error) {
+	binlogs, err := a.getBinaryLogs(ctx, sqlClient)
+	if err != nil {
+		return nil, fmt.Errorf("error getting binary logs: %v", err)
+	}
+	if len(binlogs) <= 1 {
+		return nil, nil
+	}
+	// skip active binary log
+	return binlogsAfter(binlogs[:len(binlogs)-1], lastArchivedBinlog)
+}
+
+// binlogsAfter returns the binary logs that are more recent than the last archived binary log.
+func binlogsAfter(binlogs []string, lastArchivedBinlog string) ([]string, error) {
+	if lastArchivedBinlog == "" {
+		return binlogs, nil
+	}
+	archivedNum, err := ParseBinlogNum(lastArchivedBinlog)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing binlog number in %s: %v", lastArchivedBinlog, err)
+	}
+
+	var newerBinlogs []string
+	for _, binlog := range binlogs {
+		num, err := ParseBinlogNum(binlog)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing binlog number in %s: %v", binlog, err)
+		}
+		if archivedNum.LessThan(num) {
+			newerBinlogs = append(newerBinlogs, binlog)
+		}
+	}
+	return newerBinlogs, nil
+}
+
 func (a *Archiver) updateStatusWithError(ctx context.Context, mdb *mariadbv1alpha1.MariaDB, archiveErr error) error {
 	if archiveErr != nil {
 		a.logger.Error(archiveErr, "Error archiving binary logs")
@@ -544,29 +1257,9 @@ func (a *Archiver) getPointInTimeRecoveryStatus(lastBinlog string,
 
 func (a *Archiver) updateBinlogIndex(ctx context.Context, binlogs []string, serverId uint32,
 	storageClient interfaces.BlobStorage) (*BinlogIndex, error) {
-	var index *BinlogIndex
-	exists, err := storageClient.Exists(ctx, BinlogIndexName)
+	index, err := a.getBinlogIndex(ctx, storageClient)
 	if err != nil {
-		return nil, fmt.Errorf("error checking if binlog index exists: %v", err)
-	}
-	if exists {
-		indexReader, err := storageClient.GetObjectWithOptions(ctx, BinlogIndexName)
-		if err != nil {
-			return nil, fmt.Errorf("error getting binlog index: %v", err)
-		}
-		defer indexReader.Close()
-
-		bytes, err := io.ReadAll(indexReader)
-		if err != nil {
-			return nil, fmt.Errorf("error reading binlog index: %v", err)
-		}
-		var bi BinlogIndex
-		if err := yaml.Unmarshal(bytes, &bi); err != nil {
-			return nil, fmt.Errorf("error unmarshaling binlog index: %v", err)
-		}
-		index = &bi
-	} else {
-		index = NewBinlogIndex()
+		return nil, err
 	}
 
 	for _, binlog := range binlogs {
@@ -582,24 +1275,55 @@ func (a *Archiver) updateBinlogIndex(ctx context.Context, binlogs []string, serv
 		index.Add(serverId, *meta)
 	}
 
-	indexBytes, err := yaml.Marshal(index)
+	if err := a.putBinlogIndex(ctx, index, storageClient); err != nil {
+		return nil, err
+	}
+	return index, nil
+}
+
+func (a *Archiver) getBinlogIndex(ctx context.Context, storageClient interfaces.BlobStorage) (*BinlogIndex, error) {
+	exists, err := storageClient.Exists(ctx, BinlogIndexName)
+	if err != nil {
+		return nil, fmt.Errorf("error checking if binlog index exists: %v", err)
+	}
+	if !exists {
+		return NewBinlogIndex(), nil
+	}
+	indexReader, err := storageClient.GetObjectWithOptions(ctx, BinlogIndexName)
+	if err != nil {
+		return nil, fmt.Errorf("error getting binlog index: %v", err)
+	}
+	defer indexReader.Close()
+
+	bytes, err := io.ReadAll(indexReader)
 	if err != nil {
-		return nil, fmt.Errorf("error marshaling binlog index: %v", err)
+		return nil, fmt.Errorf("error reading binlog index: %v", err)
+	}
+	var bi BinlogIndex
+	if err := yaml.Unmarshal(bytes, &bi); err != nil {
+		return nil, fmt.Errorf("error unmarshaling binlog index: %v", err)
 	}
+	return &bi, nil
+}
 
+func (a *Archiver) putBinlogIndex(ctx context.Context, index *BinlogIndex, storageClient interfaces.BlobStorage) error {
+	indexBytes, err := yaml.Marshal(index)
+	if err != nil {
+		return fmt.Errorf("error marshaling binlog index: %v", err)
+	}
 	if err := storageClient.PutObjectWithOptions(ctx, BinlogIndexName, bytes.NewReader(indexBytes), int64(len(indexBytes))); err != nil {
-		return nil, fmt.Errorf("error putting binlog index: %v", err)
+		return fmt.Errorf("error putting binlog index: %v", err)
 	}
-	return index, nil
+	return nil
 }
 
-func (a *Archiver) getLastRecoverableTime(binlogIndex *BinlogIndex, backup *mariadbv1alpha1.PhysicalBackup,
+func (a *Archiver) getLastRecoverableTime(binlogIndex *BinlogIndex, backup client.Object,
 	gtidDomainId uint32) (*metav1.Time, error) {
-	lastGtid, ok := backup.Annotations[metadata.LastGtidAnnotation]
+	lastGtid, ok := backup.GetAnnotations()[metadata.LastGtidAnnotation]
 	if !ok {
 		a.logger.Info(
-			"Last GTID annotation not found in PhysicalBackup. Skipping last recoverable time tracking...",
-			"physicalbackup", backup.Name,
+			"Last GTID annotation not found in base backup. Skipping last recoverable time tracking...",
+			"backup", backup.GetName(),
 		)
 		return nil, nil
 	}
@@ -621,7 +1345,7 @@ func (a *Archiver) getLastRecoverableTime(binlogIndex *BinlogIndex, backup *mari
 			"Unable to build current binlog timeline. Skipping last recoverable time tracking...",
 			"err", err,
 			"gtid", gtid.String(),
-			"physicalbackup", backup.Name,
+			"backup", backup.GetName(),
 		)
 		return nil, nil
 	}
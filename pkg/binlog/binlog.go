@@ -27,41 +27,136 @@ type BinlogIndex struct {
 	Binlogs map[string][]BinlogMetadata `json:"binlogs"`
 }
 
+// Exists determines whether a complete binlog is present in the index. Binlogs that have only been streamed in chunks are not considered.
 func (b *BinlogIndex) Exists(serverId uint32, binlog string) bool {
 	binlogs, ok := b.Binlogs[serverKey(serverId)]
 	if !ok {
 		return false
 	}
 	return datastructures.Any(binlogs, func(meta BinlogMetadata) bool {
-		return meta.BinlogFilename == binlog
+		return meta.BinlogFilename == binlog && meta.Chunks == 0
 	})
 }
 
+// Get returns the metadata of a binlog present in the index.
+func (b *BinlogIndex) Get(serverId uint32, binlog string) (*BinlogMetadata, bool) {
+	for _, meta := range b.Binlogs[serverKey(serverId)] {
+		if meta.BinlogFilename == binlog {
+			return &meta, true
+		}
+	}
+	return nil, false
+}
+
 func NewBinlogIndex() *BinlogIndex {
 	return &BinlogIndex{
 		APIVersion: BinlogIndexV1,
 	}
 }
 
+// Add adds a binlog to the index, replacing the existing metadata when the binlog is already present.
 func (b *BinlogIndex) Add(serverId uint32, meta BinlogMetadata) {
 	if b.Binlogs == nil {
 		b.Binlogs = make(map[string][]BinlogMetadata)
 	}
-	b.Binlogs[serverKey(serverId)] = append(b.Binlogs[serverKey(serverId)], meta)
+	key := serverKey(serverId)
+	for i, m := range b.Binlogs[key] {
+		if m.BinlogFilename == meta.BinlogFilename {
+			b.Binlogs[key][i] = meta
+			return
+		}
+	}
+	b.Binlogs[key] = append(b.Binlogs[key], meta)
 }
 
 func (b *BinlogIndex) BuildTimeline(starGtid *mariadbrepl.Gtid, targetTime time.Time, strictMode bool,
 	logger logr.Logger) ([]BinlogMetadata, error) {
-	return b.buildTimelineWithBinlogs(nil, starGtid, targetTime, strictMode, logger)
+	return b.buildTimelineWithBinlogs(nil, starGtid, timelineTarget{time: targetTime}, strictMode, logger)
+}
+
+// BuildTimelineWithTargetGtid builds the binlog timeline up to and including the transaction with the target GTID.
+func (b *BinlogIndex) BuildTimelineWithTargetGtid(starGtid, targetGtid *mariadbrepl.Gtid, strictMode bool,
+	logger logr.Logger) ([]BinlogMetadata, error) {
+	if targetGtid == nil {
+		return nil, errors.New("target GTID must be set")
+	}
+	return b.buildTimelineWithBinlogs(nil, starGtid, timelineTarget{gtid: targetGtid}, strictMode, logger)
+}
+
+// StartGtidAt returns the first GTID of the binlog with events at or after the given time that has the lowest GTID across all servers.
+// It can be used as starting point of a binlog timeline when only a start time is known.
+func (b *BinlogIndex) StartGtidAt(startTime time.Time) (*mariadbrepl.Gtid, error) {
+	var startBinlog *BinlogMetadata
+	for _, key := range b.serverKeys() {
+		for _, binlog := range b.sortedBinlogs(key) {
+			if binlog.FirstGtid == nil || binlog.LastGtid == nil || binlog.LastTime.Time.Before(startTime) {
+				continue
+			}
+			if startBinlog == nil {
+				startBinlog = &binlog
+				break
+			}
+			lessThan, err := binlog.FirstGtid.LessThan(startBinlog.FirstGtid)
+			if err != nil {
+				return nil, fmt.Errorf("error comparing GTIDs %s and %s: %v", binlog.FirstGtid, startBinlog.FirstGtid, err)
+			}
+			if lessThan {
+				startBinlog = &binlog
+			}
+			break
+		}
+	}
+	if startBinlog == nil {
+		return nil, fmt.Errorf("binlogs after %s not found: %w", startTime.Format(time.RFC3339), ErrNoBinlogs)
+	}
+	return startBinlog.FirstGtid, nil
 }
 
-func (b *BinlogIndex) buildTimelineWithBinlogs(binlogs []BinlogMetadata, startGtid *mariadbrepl.Gtid, targetTime time.Time,
+// timelineTarget is the point in time recovery objective of a binlog timeline, either a time or a GTID.
+type timelineTarget struct {
+	time time.Time
+	gtid *mariadbrepl.Gtid
+}
+
+// untilTime returns the time used to filter binlogs, which is zero when the target is a GTID.
+func (t timelineTarget) untilTime() time.Time {
+	if t.gtid != nil {
+		return time.Time{}
+	}
+	return t.time
+}
+
+// isAfter determines whether a binlog starts after the target.
+func (t timelineTarget) isAfter(binlog *BinlogMetadata) (bool, error) {
+	if t.gtid != nil {
+		return binlog.FirstGtid.GreaterThan(t.gtid)
+	}
+	return binlog.FirstTime.After(t.time), nil
+}
+
+// isReachedBy determines whether a binlog reaches the target.
+func (t timelineTarget) isReachedBy(binlog *BinlogMetadata) (bool, error) {
+	if t.gtid != nil {
+		lessThan, err := binlog.LastGtid.LessThan(t.gtid)
+		return !lessThan, err
+	}
+	return binlog.LastTime.Time.Equal(t.time), nil
+}
+
+func (t timelineTarget) String() string {
+	if t.gtid != nil {
+		return t.gtid.String()
+	}
+	return t.time.Format(time.RFC3339)
+}
+
+func (b *BinlogIndex) buildTimelineWithBinlogs(binlogs []BinlogMetadata, startGtid *mariadbrepl.Gtid, target timelineTarget,
 	strictMode bool, binlogLogger logr.Logger) ([]BinlogMetadata, error) {
 	currentServerKey := serverKey(startGtid.ServerID)
 	logger := binlogLogger.WithValues(
 		"num-binlogs", len(binlogs),
 		"start-gtid", startGtid.String(),
-		"target-time", targetTime.Format(time.RFC3339),
+		"target", target.String(),
 		"strict-mode", strictMode,
 		"server", currentServerKey,
 	)
@@ -73,20 +168,38 @@ func (b *BinlogIndex) buildTimelineWithBinlogs(binlogs []BinlogMetadata, startGt
 	}
 	hasReachedTargetTime := false
 	var currentTime metav1.Time
+	var currentGtid *mariadbrepl.Gtid
 
-	for _, binlog := range binlogsToProcess {
-		// next binlog is out of time range, done!
-		if binlog.FirstTime.After(targetTime) {
+	isAfterTarget := func(binlog *BinlogMetadata) (bool, error) {
+		isAfter, err := target.isAfter(binlog)
+		if err != nil {
+			return false, fmt.Errorf("error determining whether binlog %s is after target: %v", binlog.BinlogFilename, err)
+		}
+		if isAfter {
 			logger.V(1).Info(
-				"Next binlog is out of time range. Done.",
+				"Next binlog is out of range. Done.",
 				"binlog", binlog.BinlogFilename,
 				"time", binlog.FirstTime.Format(time.RFC3339),
 			)
-			hasReachedTargetTime = true
-			break
 		}
+		return isAfter, nil
+	}
 
-		shouldFilter, err := shouldFilterBinlog(&binlog, startGtid, targetTime, logger)
+	for _, binlog := range binlogsToProcess {
+		// next binlog is out of time range, done!
+		// GTID targets are checked after bridging gaps, as binlogs after a failover may have higher sequence numbers.
+		if target.gtid == nil {
+			isAfter, err := isAfterTarget(&binlog)
+			if err != nil {
+				return nil, err
+			}
+			if isAfter {
+				hasReachedTargetTime = true
+				break
+			}
+		}
+
+		shouldFilter, err := shouldFilterBinlog(&binlog, startGtid, target.untilTime(), logger)
 		if err != nil {
 			return nil, fmt.Errorf("error determining whether binlog %s should be filtered: %v", binlog.BinlogFilename, err)
 		}
@@ -110,23 +223,39 @@ func (b *BinlogIndex) buildTimelineWithBinlogs(binlogs []BinlogMetadata, startGt
 					"binlog", binlog.BinlogFilename,
 					"gtid", binlog.FirstGtid.String(),
 				)
-				nextGtid, err := b.findNextGtidInOtherServer(&lastBinlog, currentServerKey, targetTime, logger.WithName("gtid-gap"))
+				nextGtid, err := b.findNextGtidInOtherServer(&lastBinlog, currentServerKey, target.untilTime(), logger.WithName("gtid-gap"))
 				if err != nil {
 					return nil, fmt.Errorf("unable to find next GTID: %v", err)
 				}
 				if nextGtid == nil {
 					break // stop processing binlogs when a gap is detected
 				}
-				return b.buildTimelineWithBinlogs(binlogs, nextGtid, targetTime, strictMode, logger)
+				return b.buildTimelineWithBinlogs(binlogs, nextGtid, target, strictMode, logger)
+			}
+		}
+
+		if target.gtid != nil {
+			isAfter, err := isAfterTarget(&binlog)
+			if err != nil {
+				return nil, err
+			}
+			if isAfter {
+				hasReachedTargetTime = true
+				break
 			}
 		}
 
 		binlogs = append(binlogs, binlog)
 		currentTime = binlog.LastTime
+		currentGtid = binlog.LastGtid
 
-		if binlog.LastTime.Time.Equal(targetTime) {
+		isReached, err := target.isReachedBy(&binlog)
+		if err != nil {
+			return nil, fmt.Errorf("error determining whether binlog %s reaches target: %v", binlog.BinlogFilename, err)
+		}
+		if isReached {
 			logger.V(1).Info(
-				"Found binlog with exact target time. Done.",
+				"Found binlog reaching target. Done.",
 				"binlog", binlog.BinlogFilename,
 				"time", binlog.LastTime.Format(time.RFC3339),
 			)
@@ -139,16 +268,24 @@ func (b *BinlogIndex) buildTimelineWithBinlogs(binlogs []BinlogMetadata, startGt
 	}
 	if !hasReachedTargetTime {
 		if strictMode {
+			if target.gtid != nil {
+				return nil, fmt.Errorf(
+					"timeline did not reach target GTID: %s, last recoverable GTID: %s",
+					target.gtid.String(),
+					currentGtid.String(),
+				)
+			}
 			return nil, fmt.Errorf(
 				"timeline did not reach target time: %s, last recoverable time: %s",
-				targetTime.Format(time.RFC3339),
+				target.time.Format(time.RFC3339),
 				currentTime.Format(time.RFC3339),
 			)
 		}
 		logger.Info(
-			"Timeline did not reach target time.",
-			"target-time", targetTime.Format(time.RFC3339),
+			"Timeline did not reach target.",
+			"target", target.String(),
 			"last-recoverable-time", currentTime.Format(time.RFC3339),
+			"last-recoverable-gtid", currentGtid.String(),
 		)
 	}
 	return binlogs, nil
@@ -223,7 +360,7 @@ func shouldFilterBinlog(binlog *BinlogMetadata, fromGtid *mariadbrepl.Gtid, unti
 		return true, nil
 	}
 
-	if binlog.FirstTime.After(untilTime) {
+	if !untilTime.IsZero() && binlog.FirstTime.After(untilTime) {
 		logger.Info("Skipping binlog, as it is out of time range")
 		return true, nil
 	}
@@ -291,70 +428,105 @@ type BinlogMetadata struct {
 	LastGtid       *mariadbrepl.Gtid   `json:"lastGtid,omitempty"`
 	RotateEvent    bool                `json:"rotateEvent"`
 	StopEvent      bool                `json:"stopEvent"`
+	// Chunks is the number of chunks in which the binlog has been streamed. It is zero for complete binlogs.
+	Chunks int `json:"chunks,omitempty"`
 }
 
 func (b *BinlogMetadata) ObjectStoragePath() string {
 	return fmt.Sprintf("%s/%s", serverKey(b.ServerId), b.BinlogFilename)
 }
 
+// ChunkObjectStoragePath returns the path of a streamed chunk of the binlog, starting from 1.
+func (b *BinlogMetadata) ChunkObjectStoragePath(chunk int) string {
+	return ChunkObjectStoragePath(b.ServerId, b.BinlogFilename, chunk)
+}
+
+// ChunkObjectStoragePath returns the path of a streamed binlog chunk, starting from 1.
+func ChunkObjectStoragePath(serverId uint32, binlog string, chunk int) string {
+	return fmt.Sprintf("%s/%s.chunk-%06d", serverKey(serverId), binlog, chunk)
+}
+
 func GetBinlogMetadata(binlogPath string, logger logr.Logger) (*BinlogMetadata, error) {
 	parser := replication.NewBinlogParser()
 	parser.SetFlavor(mysql.MariaDBFlavor)
 	parser.SetVerifyChecksum(false)
 	parser.SetRawMode(true)
 
-	meta := BinlogMetadata{
-		BinlogFilename: filepath.Base(binlogPath),
-	}
-	var (
-		rawFormatDescriptionEvent []byte
-		rawGtidListEvent          []byte
-		firstRawGtidEvent         []byte
-		lastRawGtidEvent          []byte
-	)
-
+	builder := newMetadataBuilder(filepath.Base(binlogPath))
 	if err := parser.ParseFile(binlogPath, 0, func(e *replication.BinlogEvent) error {
-		meta.ServerId = e.Header.ServerID
-		meta.LogPosition = e.Header.LogPos
-
-		// See: https://mariadb.com/docs/server/reference/clientserver-protocol/replication-protocol
-		switch e.Header.EventType {
-		case replication.FORMAT_DESCRIPTION_EVENT:
-			rawFormatDescriptionEvent = e.RawData
-		case replication.MARIADB_GTID_LIST_EVENT:
-			rawGtidListEvent = e.RawData
-		case replication.MARIADB_GTID_EVENT:
-			if firstRawGtidEvent == nil {
-				firstRawGtidEvent = e.RawData
-			}
-			lastRawGtidEvent = e.RawData
-		case replication.ROTATE_EVENT:
-			meta.RotateEvent = true
-		case replication.STOP_EVENT:
-			meta.StopEvent = true
-		}
-		if meta.FirstTime == (metav1.Time{}) {
-			meta.FirstTime = metav1.NewTime(time.Unix(int64(e.Header.Timestamp), 0))
-		}
-		meta.LastTime = metav1.NewTime(time.Unix(int64(e.Header.Timestamp), 0))
-
+		builder.addEvent(e)
 		return nil
 	}); err != nil {
 		return nil, fmt.Errorf("error getting binlog metadata: %v", err)
 	}
+	return builder.build()
+}
+
+// metadataBuilder builds the BinlogMetadata incrementally from raw binlog events.
+type metadataBuilder struct {
+	meta                      BinlogMetadata
+	rawFormatDescriptionEvent []byte
+	rawGtidListEvent          []byte
+	firstRawGtidEvent         []byte
+	lastRawGtidEvent          []byte
+}
+
+func newMetadataBuilder(binlog string) *metadataBuilder {
+	return &metadataBuilder{
+		meta: BinlogMetadata{
+			BinlogFilename: binlog,
+		},
+	}
+}
+
+// newMetadataBuilderFrom returns a builder that continues from previously built metadata.
+func newMetadataBuilderFrom(meta BinlogMetadata) *metadataBuilder {
+	return &metadataBuilder{
+		meta: meta,
+	}
+}
+
+func (b *metadataBuilder) addEvent(e *replication.BinlogEvent) {
+	b.meta.ServerId = e.Header.ServerID
+	b.meta.LogPosition = e.Header.LogPos
+
+	// See: https://mariadb.com/docs/server/reference/clientserver-protocol/replication-protocol
+	switch e.Header.EventType {
+	case replication.FORMAT_DESCRIPTION_EVENT:
+		b.rawFormatDescriptionEvent = e.RawData
+	case replication.MARIADB_GTID_LIST_EVENT:
+		b.rawGtidListEvent = e.RawData
+	case replication.MARIADB_GTID_EVENT:
+		if b.firstRawGtidEvent == nil {
+			b.firstRawGtidEvent = e.RawData
+		}
+		b.lastRawGtidEvent = e.RawData
+	case replication.ROTATE_EVENT:
+		b.meta.RotateEvent = true
+	case replication.STOP_EVENT:
+		b.meta.StopEvent = true
+	}
+	if b.meta.FirstTime == (metav1.Time{}) {
+		b.meta.FirstTime = metav1.NewTime(time.Unix(int64(e.Header.Timestamp), 0))
+	}
+	b.meta.LastTime = metav1.NewTime(time.Unix(int64(e.Header.Timestamp), 0))
+}
+
+func (b *metadataBuilder) build() (*BinlogMetadata, error) {
+	meta := b.meta
 
-	if rawFormatDescriptionEvent != nil {
+	if b.rawFormatDescriptionEvent != nil {
 		formatDescription := &replication.FormatDescriptionEvent{}
-		if err := formatDescription.Decode(rawFormatDescriptionEvent[replication.EventHeaderSize:]); err != nil {
+		if err := formatDescription.Decode(b.rawFormatDescriptionEvent[replication.EventHeaderSize:]); err != nil {
 			return nil, fmt.Errorf("error decoding format description event: %v", err)
 		}
 		meta.ServerVersion = formatDescription.ServerVersion
 		meta.BinlogVersion = formatDescription.Version
 	}
 
-	if rawGtidListEvent != nil {
+	if b.rawGtidListEvent != nil {
 		listEvent := &replication.MariadbGTIDListEvent{}
-		if err := listEvent.Decode(rawGtidListEvent[replication.EventHeaderSize:]); err != nil {
+		if err := listEvent.Decode(b.rawGtidListEvent[replication.EventHeaderSize:]); err != nil {
 			return nil, fmt.Errorf("error decoding GTID list event: %v", err)
 		}
 		prevGtids := make([]*mariadbrepl.Gtid, len(listEvent.GTIDs))
@@ -369,8 +541,8 @@ func GetBinlogMetadata(binlogPath string, logger logr.Logger) (*BinlogMetadata,
 		meta.PreviousGtids = prevGtids
 	}
 
-	if firstRawGtidEvent != nil {
-		firstGtid, err := decodeGTIDEvent(firstRawGtidEvent, meta.ServerId)
+	if b.firstRawGtidEvent != nil && meta.FirstGtid == nil {
+		firstGtid, err := decodeGTIDEvent(b.firstRawGtidEvent, meta.ServerId)
 		if err != nil {
 			return nil, fmt.Errorf("error decoding first GTID event: %v", err)
 		}
@@ -381,8 +553,8 @@ func GetBinlogMetadata(binlogPath string, logger logr.Logger) (*BinlogMetadata,
 		}
 		meta.FirstGtid = gtid
 	}
-	if lastRawGtidEvent != nil {
-		lastGtid, err := decodeGTIDEvent(lastRawGtidEvent, meta.ServerId)
+	if b.lastRawGtidEvent != nil {
+		lastGtid, err := decodeGTIDEvent(b.lastRawGtidEvent, meta.ServerId)
 		if err != nil {
 			return nil, fmt.Errorf("error decoding last GTID event: %v", err)
 		}
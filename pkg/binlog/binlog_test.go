@@ -243,6 +243,114 @@ func TestBuildTimeline(t *testing.T) {
 	}
 }
 
+func TestBuildTimelineWithTargetGtid(t *testing.T) {
+	tests := []struct {
+		name       string
+		indexFile  *BinlogIndex
+		startGtid  *mariadbrepl.Gtid
+		targetGtid *mariadbrepl.Gtid
+		strictMode bool
+		wantPath   []string
+		wantErr    bool
+	}{
+		{
+			name:       "last GTID of binlog",
+			indexFile:  mustParseTestFile(t, "gap.yaml"),
+			startGtid:  mustParseGtid(t, "0-10-1"),
+			targetGtid: mustParseGtid(t, "0-10-18"),
+			strictMode: true,
+			wantPath: []string{
+				"server-10/mariadb-repl-bin.000001",
+			},
+		},
+		{
+			name:       "GTID in the middle of binlog",
+			indexFile:  mustParseTestFile(t, "gap.yaml"),
+			startGtid:  mustParseGtid(t, "0-10-1"),
+			targetGtid: mustParseGtid(t, "0-10-20"),
+			strictMode: true,
+			wantPath: []string{
+				"server-10/mariadb-repl-bin.000001",
+				"server-10/mariadb-repl-bin.000002",
+			},
+		},
+		{
+			name:       "GTID after gap",
+			indexFile:  mustParseTestFile(t, "gap.yaml"),
+			startGtid:  mustParseGtid(t, "0-10-1"),
+			targetGtid: mustParseGtid(t, "0-10-60"),
+			strictMode: false,
+			wantPath: []string{
+				"server-10/mariadb-repl-bin.000001",
+				"server-10/mariadb-repl-bin.000002",
+			},
+		},
+		{
+			name:       "GTID after gap - strict",
+			indexFile:  mustParseTestFile(t, "gap.yaml"),
+			startGtid:  mustParseGtid(t, "0-10-1"),
+			targetGtid: mustParseGtid(t, "0-10-60"),
+			strictMode: true,
+			wantErr:    true,
+		},
+		{
+			name:       "start GTID after gap",
+			indexFile:  mustParseTestFile(t, "gap.yaml"),
+			startGtid:  mustParseGtid(t, "0-10-55"),
+			targetGtid: mustParseGtid(t, "0-10-60"),
+			strictMode: true,
+			wantPath: []string{
+				"server-10/mariadb-repl-bin.000004",
+			},
+		},
+		{
+			name:       "GTID not archived yet - strict",
+			indexFile:  mustParseTestFile(t, "gap.yaml"),
+			startGtid:  mustParseGtid(t, "0-10-55"),
+			targetGtid: mustParseGtid(t, "0-10-100"),
+			strictMode: true,
+			wantErr:    true,
+		},
+		{
+			name:       "failover to server-11",
+			indexFile:  mustParseTestFile(t, "failover-1205-1208.yaml"),
+			startGtid:  mustParseGtid(t, "0-10-1"),
+			targetGtid: mustParseGtid(t, "0-11-95"),
+			strictMode: true,
+			wantPath: []string{
+				"server-10/mariadb-repl-bin.000002",
+				"server-10/mariadb-repl-bin.000003",
+				"server-10/mariadb-repl-bin.000004",
+				"server-10/mariadb-repl-bin.000005",
+				"server-10/mariadb-repl-bin.000006",
+				"server-11/mariadb-repl-bin.000001",
+				"server-11/mariadb-repl-bin.000002",
+			},
+		},
+		{
+			name:       "different domain",
+			indexFile:  mustParseTestFile(t, "gap.yaml"),
+			startGtid:  mustParseGtid(t, "0-10-1"),
+			targetGtid: mustParseGtid(t, "1-10-20"),
+			wantErr:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			binlogMetas, err := tt.indexFile.BuildTimelineWithTargetGtid(tt.startGtid, tt.targetGtid, tt.strictMode, logr.Discard())
+			if tt.wantErr {
+				assert.Error(t, err, "expected error")
+			} else {
+				assert.NoError(t, err, "unexpected error")
+				if diff := cmp.Diff(getBinlogTimeline(binlogMetas), tt.wantPath); diff != "" {
+					t.Errorf("unexpected binlog timeline (-want +got):\n%s", diff)
+				}
+			}
+		})
+	}
+}
+
 func TestErrNoBinlogs(t *testing.T) {
 	binlogIndex := &BinlogIndex{
 		APIVersion: BinlogIndexV1,
@@ -262,6 +370,45 @@ func TestErrNoBinlogs(t *testing.T) {
 	assert.Nil(t, result)
 }
 
+func TestStartGtidAt(t *testing.T) {
+	tests := []struct {
+		name      string
+		startTime time.Time
+		wantGtid  *mariadbrepl.Gtid
+		wantErr   bool
+	}{
+		{
+			name:      "before failover",
+			startTime: mustParseDate(t, "2026-02-04T12:04:10Z"),
+			wantGtid:  mustParseGtid(t, "0-10-19"),
+		},
+		{
+			name:      "after failover",
+			startTime: mustParseDate(t, "2026-02-04T12:06:00Z"),
+			wantGtid:  mustParseGtid(t, "0-11-106"),
+		},
+		{
+			name:      "after last binlog",
+			startTime: mustParseDate(t, "2026-02-05T00:00:00Z"),
+			wantErr:   true,
+		},
+	}
+	index := mustParseTestFile(t, "failover-1205-1208.yaml")
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gtid, err := index.StartGtidAt(tt.startTime)
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.True(t, errors.Is(err, ErrNoBinlogs))
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantGtid, gtid)
+		})
+	}
+}
+
 func mustParseTestFile(t *testing.T, file string) *BinlogIndex {
 	t.Helper()
 	testFile := filepath.Join("test", file)
@@ -301,3 +448,35 @@ func getBinlogTimeline(binlogMetas []BinlogMetadata) []string {
 	}
 	return path
 }
+
+func TestBinlogIndexChunks(t *testing.T) {
+	index := NewBinlogIndex()
+	index.Add(10, BinlogMetadata{
+		ServerId:       10,
+		BinlogFilename: "mariadb-repl-bin.000001",
+	})
+	index.Add(10, BinlogMetadata{
+		ServerId:       10,
+		BinlogFilename: "mariadb-repl-bin.000002",
+		LogPosition:    350,
+		Chunks:         2,
+	})
+	assert.True(t, index.Exists(10, "mariadb-repl-bin.000001"))
+	assert.False(t, index.Exists(10, "mariadb-repl-bin.000002"), "streamed binlogs should not be considered archived")
+
+	meta, ok := index.Get(10, "mariadb-repl-bin.000002")
+	assert.True(t, ok)
+	assert.Equal(t, 2, meta.Chunks)
+	_, ok = index.Get(11, "mariadb-repl-bin.000002")
+	assert.False(t, ok)
+
+	index.Add(10, BinlogMetadata{
+		ServerId:       10,
+		BinlogFilename: "mariadb-repl-bin.000002",
+		LogPosition:    500,
+		RotateEvent:    true,
+	})
+	assert.True(t, index.Exists(10, "mariadb-repl-bin.000002"))
+	assert.Len(t, index.Binlogs["server-10"], 2, "existing binlogs should be replaced")
+	assert.Equal(t, uint32(500), index.Binlogs["server-10"][1].LogPosition)
+}
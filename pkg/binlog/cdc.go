@@ -0,0 +1,335 @@
+package binlog
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"path/filepath"
+	"time"
+	"unicode/utf8"
+
+	"github.com/go-logr/logr"
+	"github.com/go-mysql-org/go-mysql/mysql"
+	"github.com/go-mysql-org/go-mysql/replication"
+	mariadbv1alpha1 "github.com/mariadb-operator/mariadb-operator/v26/api/v1alpha1"
+	"github.com/mariadb-operator/mariadb-operator/v26/pkg/compression"
+	"github.com/mariadb-operator/mariadb-operator/v26/pkg/interfaces"
+	"github.com/mariadb-operator/mariadb-operator/v26/pkg/multipart"
+	mariadbrepl "github.com/mariadb-operator/mariadb-operator/v26/pkg/replication"
+)
+
+// CDCOperation is the kind of row change captured from a binlog.
+type CDCOperation string
+
+const (
+	CDCOperationInsert CDCOperation = "insert"
+	CDCOperationUpdate CDCOperation = "update"
+	CDCOperationDelete CDCOperation = "delete"
+)
+
+// CDCEvent is a row change decoded from a binlog. It is exported as a line of newline-delimited JSON.
+type CDCEvent struct {
+	Database  string         `json:"database"`
+	Table     string         `json:"table"`
+	Operation CDCOperation   `json:"operation"`
+	Before    map[string]any `json:"before,omitempty"`
+	After     map[string]any `json:"after,omitempty"`
+	Gtid      string         `json:"gtid"`
+	Timestamp time.Time      `json:"timestamp"`
+}
+
+// CDCSink is a destination of the exported CDC events.
+type CDCSink interface {
+	// Write stores the newline-delimited JSON events of a binlog, replacing the ones previously written for the same binlog.
+	// The events are read from the reader as they are decoded, so they must not be buffered entirely in memory.
+	Write(ctx context.Context, meta *BinlogMetadata, reader io.Reader) error
+}
+
+// BlobStorageCDCSink writes the CDC events to a prefix of a blob storage, one object per binlog.
+// The events are compressed, and encrypted when configured, in the same way as the binlogs.
+type BlobStorageCDCSink struct {
+	storageClient interfaces.BlobStorage
+	compressor    compression.Compressor
+	calg          mariadbv1alpha1.CompressAlgorithm
+	prefix        string
+}
+
+func NewBlobStorageCDCSink(storageClient interfaces.BlobStorage, compressor compression.Compressor,
+	pitr *mariadbv1alpha1.PointInTimeRecovery) *BlobStorageCDCSink {
+	return &BlobStorageCDCSink{
+		storageClient: storageClient,
+		compressor:    compressor,
+		calg:          pitr.Spec.Compression,
+		prefix:        pitr.Spec.CDC.GetPrefix(),
+	}
+}
+
+func (s *BlobStorageCDCSink) Write(ctx context.Context, meta *BinlogMetadata, reader io.Reader) error {
+	objectName, err := CDCObjectStoragePath(s.prefix, meta, s.calg)
+	if err != nil {
+		return err
+	}
+	pr, pw := io.Pipe()
+	defer pr.Close()
+	go func() {
+		pw.CloseWithError(s.compressor.Compress(ctx, pw, reader))
+	}()
+
+	if err := s.storageClient.PutObjectStreamWithOptions(ctx, objectName, pr, interfaces.StreamOpts{
+		PartSize:       multipart.DefaultPartSize,
+		MaxPartRetries: multipart.DefaultMaxPartRetries,
+	}); err != nil {
+		return fmt.Errorf("error putting CDC events %s: %v", objectName, err)
+	}
+	return nil
+}
+
+// Remove removes the CDC events of a binlog, if any. It returns false when the events are locked and therefore were not removed.
+func (s *BlobStorageCDCSink) Remove(ctx context.Context, meta *BinlogMetadata) (bool, error) {
+	objectName, err := CDCObjectStoragePath(s.prefix, meta, s.calg)
+	if err != nil {
+		return false, err
+	}
+	exists, err := s.storageClient.Exists(ctx, objectName)
+	if err != nil {
+		return false, fmt.Errorf("error determining if CDC events %s exist: %v", objectName, err)
+	}
+	if !exists {
+		return true, nil
+	}
+	locked, err := s.storageClient.IsLocked(ctx, objectName)
+	if err != nil {
+		return false, fmt.Errorf("error determining if CDC events %s are locked: %v", objectName, err)
+	}
+	if locked {
+		return false, nil
+	}
+	if err := s.storageClient.RemoveWithOptions(ctx, objectName); err != nil {
+		return false, fmt.Errorf("error removing CDC events %s: %v", objectName, err)
+	}
+	return true, nil
+}
+
+// CDCObjectStoragePath returns the path where the CDC events of a binlog are exported.
+func CDCObjectStoragePath(prefix string, meta *BinlogMetadata, calg mariadbv1alpha1.CompressAlgorithm) (string, error) {
+	return withCompressionExtension(path.Join(prefix, meta.ObjectStoragePath()+".ndjson"), calg)
+}
+
+// CDCExporter decodes the row events of the binlogs and exports them to a sink.
+type CDCExporter struct {
+	dataDir string
+	sink    CDCSink
+	logger  logr.Logger
+}
+
+func NewCDCExporter(dataDir string, sink CDCSink, logger logr.Logger) *CDCExporter {
+	return &CDCExporter{
+		dataDir: dataDir,
+		sink:    sink,
+		logger:  logger,
+	}
+}
+
+// Export exports the row events of a binlog of the given server whose transactions are after the checkpoint GTID.
+// The events are streamed to the sink while the binlog is decoded, and nothing is written when there are no events to export.
+// It returns the GTID of the last exported transaction, which is nil when there was nothing to export.
+func (e *CDCExporter) Export(ctx context.Context, serverId uint32, binlog string,
+	checkpoint *mariadbrepl.Gtid) (*mariadbrepl.Gtid, error) {
+	binlogPath := filepath.Join(e.dataDir, binlog)
+	meta := &BinlogMetadata{
+		ServerId:       serverId,
+		BinlogFilename: binlog,
+	}
+	logger := e.logger.WithValues("binlog", binlog)
+	if checkpoint != nil {
+		logger = logger.WithValues("checkpoint", checkpoint.String())
+	}
+
+	pr, pw := io.Pipe()
+	defer pr.Close()
+	writer := bufio.NewWriter(pw)
+	encoder := newCDCEncoder(checkpoint, writer)
+
+	parseErr := make(chan error, 1)
+	go func() {
+		parser := replication.NewBinlogParser()
+		parser.SetFlavor(mysql.MariaDBFlavor)
+		parser.SetVerifyChecksum(false)
+
+		err := parser.ParseFile(binlogPath, 0, encoder.addEvent)
+		if err == nil {
+			err = writer.Flush()
+		}
+		pw.CloseWithError(err)
+		parseErr <- err
+	}()
+
+	// the sink is only written after the first event is decoded, to avoid exporting empty objects.
+	reader := bufio.NewReader(pr)
+	_, peekErr := reader.Peek(1)
+	if peekErr == nil {
+		if err := e.sink.Write(ctx, meta, reader); err != nil {
+			pr.CloseWithError(err)
+			<-parseErr
+			return nil, err
+		}
+	}
+	if err := <-parseErr; err != nil {
+		return nil, fmt.Errorf("error decoding binlog %s: %v", binlog, err)
+	}
+	if peekErr != nil && !errors.Is(peekErr, io.EOF) {
+		return nil, fmt.Errorf("error reading CDC events of binlog %s: %v", binlog, peekErr)
+	}
+
+	if encoder.lastGtid == nil {
+		logger.V(1).Info("No transactions to export. Skipping...")
+		return nil, nil
+	}
+	logger.Info("CDC events exported", "events", encoder.events, "gtid", encoder.lastGtid.String())
+	return encoder.lastGtid, nil
+}
+
+// cdcEncoder encodes the row events of the transactions after the checkpoint GTID as newline-delimited JSON.
+type cdcEncoder struct {
+	checkpoint *mariadbrepl.Gtid
+	gtid       *mariadbrepl.Gtid
+	skip       bool
+	lastGtid   *mariadbrepl.Gtid
+	events     int
+	writer     io.Writer
+}
+
+func newCDCEncoder(checkpoint *mariadbrepl.Gtid, writer io.Writer) *cdcEncoder {
+	return &cdcEncoder{
+		checkpoint: checkpoint,
+		writer:     writer,
+	}
+}
+
+func (c *cdcEncoder) addEvent(e *replication.BinlogEvent) error {
+	switch ev := e.Event.(type) {
+	case *replication.MariadbGTIDEvent:
+		gtid, err := toMariadbGtid(&ev.GTID)
+		if err != nil {
+			return err
+		}
+		skip, err := c.isCheckpointed(gtid)
+		if err != nil {
+			return err
+		}
+		c.gtid = gtid
+		c.skip = skip
+		if !skip {
+			c.lastGtid = gtid
+		}
+	case *replication.RowsEvent:
+		if c.gtid == nil || c.skip {
+			return nil
+		}
+		return c.addRowsEvent(ev, e.Header.EventType, time.Unix(int64(e.Header.Timestamp), 0).UTC())
+	}
+	return nil
+}
+
+// isCheckpointed determines whether a transaction has already been exported.
+// Transactions of other GTID domains are not comparable with the checkpoint, and they are always exported.
+func (c *cdcEncoder) isCheckpointed(gtid *mariadbrepl.Gtid) (bool, error) {
+	if c.checkpoint == nil || c.checkpoint.DomainID != gtid.DomainID {
+		return false, nil
+	}
+	greaterThan, err := gtid.GreaterThan(c.checkpoint)
+	if err != nil {
+		return false, fmt.Errorf("error comparing GTIDs %s and %s: %v", gtid, c.checkpoint, err)
+	}
+	return !greaterThan, nil
+}
+
+func (c *cdcEncoder) addRowsEvent(ev *replication.RowsEvent, eventType replication.EventType, timestamp time.Time) error {
+	operation, ok := cdcOperation(eventType)
+	if !ok || ev.Table == nil {
+		return nil
+	}
+	columns := ev.Table.ColumnNameString()
+
+	// update events contain pairs of rows: the before image followed by the after image.
+	step := 1
+	if operation == CDCOperationUpdate {
+		step = 2
+	}
+	for i := 0; i+step-1 < len(ev.Rows); i += step {
+		event := CDCEvent{
+			Database:  string(ev.Table.Schema),
+			Table:     string(ev.Table.Table),
+			Operation: operation,
+			Gtid:      c.gtid.String(),
+			Timestamp: timestamp,
+		}
+		switch operation {
+		case CDCOperationInsert:
+			event.After = rowImage(ev, i, columns)
+		case CDCOperationUpdate:
+			event.Before = rowImage(ev, i, columns)
+			event.After = rowImage(ev, i+1, columns)
+		case CDCOperationDelete:
+			event.Before = rowImage(ev, i, columns)
+		}
+
+		data, err := json.Marshal(event)
+		if err != nil {
+			return fmt.Errorf("error marshaling CDC event: %v", err)
+		}
+		if _, err := c.writer.Write(append(data, '\n')); err != nil {
+			return fmt.Errorf("error writing CDC event: %v", err)
+		}
+		c.events++
+	}
+	return nil
+}
+
+// cdcOperation returns the operation of a rows event type. Partial JSON updates are not supported by MariaDB.
+func cdcOperation(eventType replication.EventType) (CDCOperation, bool) {
+	switch eventType {
+	case replication.WRITE_ROWS_EVENTv0, replication.WRITE_ROWS_EVENTv1, replication.WRITE_ROWS_EVENTv2,
+		replication.MARIADB_WRITE_ROWS_COMPRESSED_EVENT_V1:
+		return CDCOperationInsert, true
+	case replication.UPDATE_ROWS_EVENTv0, replication.UPDATE_ROWS_EVENTv1, replication.UPDATE_ROWS_EVENTv2,
+		replication.MARIADB_UPDATE_ROWS_COMPRESSED_EVENT_V1:
+		return CDCOperationUpdate, true
+	case replication.DELETE_ROWS_EVENTv0, replication.DELETE_ROWS_EVENTv1, replication.DELETE_ROWS_EVENTv2,
+		replication.MARIADB_DELETE_ROWS_COMPRESSED_EVENT_V1:
+		return CDCOperationDelete, true
+	default:
+		return "", false
+	}
+}
+
+// rowImage returns the columns of a row indexed by name. Columns are named after their position, as in mariadb-binlog,
+// when the column names are not available, and the columns not present in the image are omitted.
+func rowImage(ev *replication.RowsEvent, row int, columns []string) map[string]any {
+	skipped := make(map[int]bool)
+	if row < len(ev.SkippedColumns) {
+		for _, col := range ev.SkippedColumns[row] {
+			skipped[col] = true
+		}
+	}
+	image := make(map[string]any, len(ev.Rows[row]))
+	for i, value := range ev.Rows[row] {
+		if skipped[i] {
+			continue
+		}
+		name := fmt.Sprintf("@%d", i+1)
+		if i < len(columns) {
+			name = columns[i]
+		}
+		// text values are decoded as bytes, which would otherwise be encoded as base64.
+		if b, ok := value.([]byte); ok && utf8.Valid(b) {
+			value = string(b)
+		}
+		image[name] = value
+	}
+	return image
+}
@@ -0,0 +1,236 @@
+package binlog
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/go-mysql-org/go-mysql/replication"
+	mariadbv1alpha1 "github.com/mariadb-operator/mariadb-operator/v26/api/v1alpha1"
+	"github.com/mariadb-operator/mariadb-operator/v26/pkg/compression"
+	"github.com/mariadb-operator/mariadb-operator/v26/pkg/filesystem"
+	mariadbrepl "github.com/mariadb-operator/mariadb-operator/v26/pkg/replication"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCDCEncoder(t *testing.T) {
+	usersTable := &replication.TableMapEvent{
+		Schema: []byte("app"),
+		Table:  []byte("users"),
+		ColumnName: [][]byte{
+			[]byte("id"),
+			[]byte("name"),
+		},
+	}
+	ordersTable := &replication.TableMapEvent{
+		Schema: []byte("app"),
+		Table:  []byte("orders"),
+	}
+	events := []*replication.BinlogEvent{
+		testGtidEvent(1, 100, false),
+		testEvent(replication.WRITE_ROWS_EVENTv1, 0, 110, &replication.RowsEvent{
+			Table: usersTable,
+			Rows:  [][]any{{int32(1), []byte("alice")}},
+		}),
+		testGtidEvent(2, 200, false),
+		testEvent(replication.UPDATE_ROWS_EVENTv1, 0, 210, &replication.RowsEvent{
+			Table: usersTable,
+			Rows: [][]any{
+				{int32(1), []byte("alice")},
+				{int32(1), []byte("bob")},
+			},
+		}),
+		testGtidEvent(3, 300, true),
+		testEvent(replication.QUERY_EVENT, 0, 310, &replication.QueryEvent{Query: []byte("CREATE TABLE t (id INT)")}),
+		testGtidEvent(4, 400, false),
+		testEvent(replication.DELETE_ROWS_EVENTv1, 0, 410, &replication.RowsEvent{
+			Table:          ordersTable,
+			Rows:           [][]any{{int32(7), nil}},
+			SkippedColumns: [][]int{{1}},
+		}),
+	}
+
+	tests := []struct {
+		name         string
+		checkpoint   *mariadbrepl.Gtid
+		wantLines    []string
+		wantLastGtid string
+	}{
+		{
+			name: "no checkpoint",
+			wantLines: []string{
+				`{"database":"app","table":"users","operation":"insert","after":{"id":1,"name":"alice"},` +
+					`"gtid":"0-10-1","timestamp":"2026-01-01T00:01:50Z"}`,
+				`{"database":"app","table":"users","operation":"update","before":{"id":1,"name":"alice"},` +
+					`"after":{"id":1,"name":"bob"},"gtid":"0-10-2","timestamp":"2026-01-01T00:03:30Z"}`,
+				`{"database":"app","table":"orders","operation":"delete","before":{"@1":7},` +
+					`"gtid":"0-10-4","timestamp":"2026-01-01T00:06:50Z"}`,
+			},
+			wantLastGtid: "0-10-4",
+		},
+		{
+			name:       "checkpoint",
+			checkpoint: mustParseGtid(t, "0-10-2"),
+			wantLines: []string{
+				`{"database":"app","table":"orders","operation":"delete","before":{"@1":7},` +
+					`"gtid":"0-10-4","timestamp":"2026-01-01T00:06:50Z"}`,
+			},
+			wantLastGtid: "0-10-4",
+		},
+		{
+			name:       "checkpoint in another domain",
+			checkpoint: mustParseGtid(t, "1-10-100"),
+			wantLines: []string{
+				`{"database":"app","table":"users","operation":"insert","after":{"id":1,"name":"alice"},` +
+					`"gtid":"0-10-1","timestamp":"2026-01-01T00:01:50Z"}`,
+				`{"database":"app","table":"users","operation":"update","before":{"id":1,"name":"alice"},` +
+					`"after":{"id":1,"name":"bob"},"gtid":"0-10-2","timestamp":"2026-01-01T00:03:30Z"}`,
+				`{"database":"app","table":"orders","operation":"delete","before":{"@1":7},` +
+					`"gtid":"0-10-4","timestamp":"2026-01-01T00:06:50Z"}`,
+			},
+			wantLastGtid: "0-10-4",
+		},
+		{
+			name:       "fully exported",
+			checkpoint: mustParseGtid(t, "0-10-4"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			encoder := newCDCEncoder(tt.checkpoint, &buf)
+			for _, e := range events {
+				assert.NoError(t, encoder.addEvent(e))
+			}
+
+			var lines []string
+			if data := strings.TrimSuffix(buf.String(), "\n"); data != "" {
+				lines = strings.Split(data, "\n")
+			}
+			assert.Equal(t, tt.wantLines, lines)
+			assert.Equal(t, len(tt.wantLines), encoder.events)
+			if tt.wantLastGtid == "" {
+				assert.Nil(t, encoder.lastGtid)
+			} else {
+				assert.Equal(t, tt.wantLastGtid, encoder.lastGtid.String())
+			}
+		})
+	}
+}
+
+func TestCDCExporter(t *testing.T) {
+	dataDir := t.TempDir()
+	data, _ := testTransactions(binlogHeaderSize, 1, 2)
+	data = append(append([]byte{}, replication.BinLogFileHeader...), data...)
+	assert.NoError(t, os.WriteFile(filepath.Join(dataDir, "mariadb-repl-bin.000001"), data, 0644))
+
+	sink := &testCDCSink{}
+	exporter := NewCDCExporter(dataDir, sink, logr.Discard())
+
+	gtid, err := exporter.Export(context.Background(), 10, "mariadb-repl-bin.000001", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "0-10-2", gtid.String())
+	assert.Zero(t, sink.writes, "transactions without row events should not be written")
+
+	gtid, err = exporter.Export(context.Background(), 10, "mariadb-repl-bin.000001", mustParseGtid(t, "0-10-2"))
+	assert.NoError(t, err)
+	assert.Nil(t, gtid)
+
+	_, err = exporter.Export(context.Background(), 10, "mariadb-repl-bin.000002", nil)
+	assert.Error(t, err)
+}
+
+func TestBlobStorageCDCSink(t *testing.T) {
+	ctx := context.Background()
+	storageClient, err := filesystem.NewFileSystemClient(t.TempDir(), t.TempDir(), filesystem.WithAllowNestedPrefixes(true))
+	assert.NoError(t, err)
+	compressor, err := compression.NewCompressor(mariadbv1alpha1.CompressGzip)
+	assert.NoError(t, err)
+	pitr := &mariadbv1alpha1.PointInTimeRecovery{
+		Spec: mariadbv1alpha1.PointInTimeRecoverySpec{
+			Compression: mariadbv1alpha1.CompressGzip,
+			CDC: &mariadbv1alpha1.BinlogCDC{
+				Enabled: true,
+			},
+		},
+	}
+	sink := NewBlobStorageCDCSink(storageClient, compressor, pitr)
+	meta := &BinlogMetadata{
+		ServerId:       10,
+		BinlogFilename: "mariadb-repl-bin.000001",
+	}
+	events := `{"database":"app","table":"users","operation":"delete","before":{"id":1},"gtid":"0-10-1"}` + "\n"
+
+	assert.NoError(t, sink.Write(ctx, meta, strings.NewReader(events)))
+
+	objectName := "cdc/server-10/mariadb-repl-bin.000001.ndjson.gz"
+	reader, err := storageClient.GetObjectWithOptions(ctx, objectName)
+	assert.NoError(t, err)
+	var data bytes.Buffer
+	assert.NoError(t, compressor.Decompress(ctx, &data, reader))
+	assert.NoError(t, reader.Close())
+	assert.Equal(t, events, data.String())
+
+	removed, err := sink.Remove(ctx, meta)
+	assert.NoError(t, err)
+	assert.True(t, removed)
+	exists, err := storageClient.Exists(ctx, objectName)
+	assert.NoError(t, err)
+	assert.False(t, exists)
+
+	removed, err = sink.Remove(ctx, meta)
+	assert.NoError(t, err)
+	assert.True(t, removed, "missing events should be considered removed")
+}
+
+func TestCDCObjectStoragePath(t *testing.T) {
+	meta := &BinlogMetadata{
+		ServerId:       10,
+		BinlogFilename: "mariadb-repl-bin.000001",
+	}
+	tests := []struct {
+		prefix string
+		calg   mariadbv1alpha1.CompressAlgorithm
+		want   string
+	}{
+		{
+			prefix: "cdc",
+			want:   "cdc/server-10/mariadb-repl-bin.000001.ndjson",
+		},
+		{
+			prefix: "exports/cdc",
+			calg:   mariadbv1alpha1.CompressNone,
+			want:   "exports/cdc/server-10/mariadb-repl-bin.000001.ndjson",
+		},
+		{
+			prefix: "cdc",
+			calg:   mariadbv1alpha1.CompressGzip,
+			want:   "cdc/server-10/mariadb-repl-bin.000001.ndjson.gz",
+		},
+	}
+	for _, tt := range tests {
+		objectName, err := CDCObjectStoragePath(tt.prefix, meta, tt.calg)
+		assert.NoError(t, err)
+		assert.Equal(t, tt.want, objectName)
+
+		_, ok := parseBinlogObjectName(objectName)
+		assert.False(t, ok, "exported events should not be considered binlogs")
+	}
+}
+
+type testCDCSink struct {
+	writes int
+	data   bytes.Buffer
+}
+
+func (s *testCDCSink) Write(ctx context.Context, meta *BinlogMetadata, reader io.Reader) error {
+	s.writes++
+	_, err := io.Copy(&s.data, reader)
+	return err
+}
@@ -0,0 +1,149 @@
+package binlog
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/go-logr/logr"
+	mariadbv1alpha1 "github.com/mariadb-operator/mariadb-operator/v26/api/v1alpha1"
+	"github.com/mariadb-operator/mariadb-operator/v26/pkg/compression"
+)
+
+// GetChunkFunc returns a reader of a compressed chunk of a streamed binlog, along with the compressor to decompress it.
+// It must return an error when the chunk is not found.
+type GetChunkFunc func(ctx context.Context, chunk int) (io.ReadCloser, compression.Compressor, error)
+
+// PullChunks concatenates the chunks of a streamed binlog into a file, returning the metadata of the resulting binlog.
+// The resulting binlog is parsed and compared with the metadata of the streamed binlog,
+// so missing, truncated or corrupted chunks are detected before the binlog is used.
+func PullChunks(ctx context.Context, meta *BinlogMetadata, fileName string, getChunk GetChunkFunc,
+	logger logr.Logger) (*BinlogMetadata, error) {
+	if meta.Chunks == 0 {
+		return nil, fmt.Errorf("binlog %s has not been streamed in chunks", meta.BinlogFilename)
+	}
+	file, err := os.Create(fileName)
+	if err != nil {
+		return nil, fmt.Errorf("error creating binlog file %s: %v", fileName, err)
+	}
+	defer file.Close()
+
+	for chunk := 1; chunk <= meta.Chunks; chunk++ {
+		if err := pullChunk(ctx, chunk, file, getChunk); err != nil {
+			return nil, fmt.Errorf("error pulling chunk %d of %d of binlog %s: %v", chunk, meta.Chunks, meta.BinlogFilename, err)
+		}
+	}
+	if err := file.Close(); err != nil {
+		return nil, fmt.Errorf("error closing binlog file %s: %v", fileName, err)
+	}
+
+	pulled, err := GetBinlogMetadata(fileName, logger)
+	if err != nil {
+		return nil, fmt.Errorf("error verifying chunks of binlog %s: %v", meta.BinlogFilename, err)
+	}
+	if err := verifyChunks(meta, pulled); err != nil {
+		return nil, fmt.Errorf("error verifying chunks of binlog %s: %v", meta.BinlogFilename, err)
+	}
+	pulled.ServerId = meta.ServerId
+	pulled.BinlogFilename = meta.BinlogFilename
+	return pulled, nil
+}
+
+func pullChunk(ctx context.Context, chunk int, w io.Writer, getChunk GetChunkFunc) error {
+	reader, compressor, err := getChunk(ctx, chunk)
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+
+	if err := compressor.Decompress(ctx, w, reader); err != nil {
+		return fmt.Errorf("error decompressing chunk: %v", err)
+	}
+	return nil
+}
+
+// verifyChunks checks that the binlog pulled from the chunks ends at the position and GTID recorded when streaming it.
+func verifyChunks(meta, pulled *BinlogMetadata) error {
+	if pulled.LogPosition != meta.LogPosition {
+		return fmt.Errorf("expected binlog to end at position %d, got %d", meta.LogPosition, pulled.LogPosition)
+	}
+	if gtidString(pulled) != gtidString(meta) {
+		return fmt.Errorf("expected last GTID %q, got %q", gtidString(meta), gtidString(pulled))
+	}
+	return nil
+}
+
+func gtidString(meta *BinlogMetadata) string {
+	if meta.LastGtid == nil {
+		return ""
+	}
+	return meta.LastGtid.String()
+}
+
+// ConsolidateChunks uploads a streamed binlog as a complete binlog by concatenating its chunks, returning the metadata of the complete binlog.
+// The chunks are not removed, as they are still referenced by the binlog index until it is updated with the returned metadata.
+func (u *Uploader) ConsolidateChunks(ctx context.Context, meta *BinlogMetadata, mdb *mariadbv1alpha1.MariaDB,
+	pitr *mariadbv1alpha1.PointInTimeRecovery) (*BinlogMetadata, error) {
+	objectName, err := getObjectName(meta.BinlogFilename, meta, pitr)
+	if err != nil {
+		return nil, fmt.Errorf("error getting object name: %v", err)
+	}
+	u.logger.Info("Consolidating binary log chunks", "binlog", meta.BinlogFilename, "chunks", meta.Chunks, "object", objectName)
+
+	tmpFile, err := os.CreateTemp(u.dataDir, meta.BinlogFilename+".*.chunks")
+	if err != nil {
+		return nil, fmt.Errorf("creating temp file in %s: %v", u.dataDir, err)
+	}
+	_ = tmpFile.Close()
+	defer os.Remove(tmpFile.Name())
+
+	consolidated, err := PullChunks(ctx, meta, tmpFile.Name(), u.getChunk(meta, pitr), u.logger)
+	if err != nil {
+		return nil, err
+	}
+	if err := u.uploadFile(ctx, meta.BinlogFilename, tmpFile.Name(), objectName, consolidated, mdb, pitr); err != nil {
+		return nil, err
+	}
+	return consolidated, nil
+}
+
+// getChunk returns a GetChunkFunc that finds the chunks with any compression extension,
+// as they may have been streamed before changing the compression.
+func (u *Uploader) getChunk(meta *BinlogMetadata, pitr *mariadbv1alpha1.PointInTimeRecovery) GetChunkFunc {
+	return func(ctx context.Context, chunk int) (io.ReadCloser, compression.Compressor, error) {
+		objectName, calg, err := FindCompressedObject(ctx, meta.ChunkObjectStoragePath(chunk), pitr.Spec.Compression, u.storageClient)
+		if err != nil {
+			return nil, nil, err
+		}
+		compressor, err := u.getDecompressor(calg, pitr)
+		if err != nil {
+			return nil, nil, fmt.Errorf("error getting compressor: %v", err)
+		}
+		reader, err := u.storageClient.GetObjectWithOptions(ctx, objectName)
+		if err != nil {
+			return nil, nil, fmt.Errorf("error getting binlog chunk %s: %v", objectName, err)
+		}
+		return reader, compressor, nil
+	}
+}
+
+// getDecompressor returns the compressor to decompress an object compressed with the given algorithm.
+func (u *Uploader) getDecompressor(calg mariadbv1alpha1.CompressAlgorithm,
+	pitr *mariadbv1alpha1.PointInTimeRecovery) (compression.Compressor, error) {
+	currentCalg := pitr.Spec.Compression
+	if currentCalg == "" {
+		currentCalg = mariadbv1alpha1.CompressNone
+	}
+	if calg == currentCalg {
+		return u.compressor, nil
+	}
+	if pitr.Spec.Encryption != nil {
+		if u.keyring == nil {
+			return nil, errors.New("encryption keyring not provided")
+		}
+		return compression.NewCompressor(calg, compression.WithKeyring(u.keyring))
+	}
+	return compression.NewCompressor(calg)
+}
@@ -0,0 +1,234 @@
+package binlog
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/go-mysql-org/go-mysql/replication"
+	mariadbv1alpha1 "github.com/mariadb-operator/mariadb-operator/v26/api/v1alpha1"
+	"github.com/mariadb-operator/mariadb-operator/v26/pkg/backup"
+	"github.com/mariadb-operator/mariadb-operator/v26/pkg/compression"
+	"github.com/mariadb-operator/mariadb-operator/v26/pkg/filesystem"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestPullChunks(t *testing.T) {
+	chunk1, pos := testTransactions(binlogHeaderSize, 1, 2)
+	chunk1 = append(append([]byte{}, replication.BinLogFileHeader...), chunk1...)
+	chunk2, pos := testTransactions(pos, 3)
+	meta := &BinlogMetadata{
+		ServerId:       10,
+		BinlogFilename: "mariadb-repl-bin.000001",
+		LogPosition:    pos,
+		Chunks:         2,
+		LastGtid:       mustParseGtid(t, "0-10-3"),
+	}
+
+	tests := []struct {
+		name    string
+		chunks  [][]byte
+		meta    *BinlogMetadata
+		wantErr bool
+	}{
+		{
+			name:   "complete",
+			chunks: [][]byte{chunk1, chunk2},
+			meta:   meta,
+		},
+		{
+			name:    "missing chunk",
+			chunks:  [][]byte{chunk1},
+			meta:    meta,
+			wantErr: true,
+		},
+		{
+			name:    "truncated chunk",
+			chunks:  [][]byte{chunk1, chunk2[:len(chunk2)-4]},
+			meta:    meta,
+			wantErr: true,
+		},
+		{
+			name:    "missing transactions",
+			chunks:  [][]byte{chunk1, chunk1[binlogHeaderSize:]},
+			meta:    meta,
+			wantErr: true,
+		},
+		{
+			name:    "not streamed",
+			chunks:  [][]byte{chunk1, chunk2},
+			meta:    &BinlogMetadata{BinlogFilename: "mariadb-repl-bin.000001"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fileName := filepath.Join(t.TempDir(), tt.meta.BinlogFilename)
+			pulled, err := PullChunks(context.Background(), tt.meta, fileName, testGetChunk(t, tt.chunks), logr.Discard())
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, uint32(10), pulled.ServerId)
+			assert.Equal(t, "mariadb-repl-bin.000001", pulled.BinlogFilename)
+			assert.Equal(t, pos, pulled.LogPosition)
+			assert.Equal(t, "0-10-1", pulled.FirstGtid.String())
+			assert.Equal(t, "0-10-3", pulled.LastGtid.String())
+			assert.Zero(t, pulled.Chunks)
+
+			data, err := os.ReadFile(fileName)
+			assert.NoError(t, err)
+			assert.Equal(t, append(append([]byte{}, chunk1...), chunk2...), data)
+		})
+	}
+}
+
+func TestConsolidateChunks(t *testing.T) {
+	ctx := context.Background()
+	storageClient, err := filesystem.NewFileSystemClient(t.TempDir(), t.TempDir(), filesystem.WithAllowNestedPrefixes(true))
+	assert.NoError(t, err)
+	compressor, err := compression.NewCompressor(mariadbv1alpha1.CompressGzip)
+	assert.NoError(t, err)
+	uploader := NewUploader(t.TempDir(), storageClient, compressor, logr.Discard())
+	mdb := &mariadbv1alpha1.MariaDB{ObjectMeta: metav1.ObjectMeta{Name: "mariadb"}}
+	pitr := &mariadbv1alpha1.PointInTimeRecovery{
+		Spec: mariadbv1alpha1.PointInTimeRecoverySpec{
+			Compression: mariadbv1alpha1.CompressGzip,
+		},
+	}
+
+	chunk1, pos := testTransactions(binlogHeaderSize, 1)
+	chunk1 = append(append([]byte{}, replication.BinLogFileHeader...), chunk1...)
+	chunk2, pos := testTransactions(pos, 2, 3)
+	meta := &BinlogMetadata{
+		ServerId:       10,
+		BinlogFilename: "mariadb-repl-bin.000001",
+		LogPosition:    pos,
+		Chunks:         2,
+		LastGtid:       mustParseGtid(t, "0-10-3"),
+	}
+	for i, data := range [][]byte{chunk1, chunk2} {
+		assert.NoError(t, uploader.UploadChunk(ctx, meta.ServerId, meta.BinlogFilename, i+1, data, pitr))
+	}
+
+	missing := *meta
+	missing.BinlogFilename = "mariadb-repl-bin.000002"
+	_, err = uploader.ConsolidateChunks(ctx, &missing, mdb, pitr)
+	assert.Error(t, err, "binlog without chunks should not be consolidated")
+
+	consolidated, err := uploader.ConsolidateChunks(ctx, meta, mdb, pitr)
+	assert.NoError(t, err)
+	assert.Zero(t, consolidated.Chunks)
+	assert.Equal(t, "0-10-3", consolidated.LastGtid.String())
+
+	objectName := "server-10/mariadb-repl-bin.000001.gz"
+	reader, err := storageClient.GetObjectWithOptions(ctx, objectName)
+	assert.NoError(t, err)
+	defer reader.Close()
+	var data bytes.Buffer
+	assert.NoError(t, compressor.Decompress(ctx, &data, reader))
+	assert.Equal(t, append(append([]byte{}, chunk1...), chunk2...), data.Bytes())
+
+	exists, err := storageClient.Exists(ctx, backup.ManifestFileName(objectName))
+	assert.NoError(t, err)
+	assert.True(t, exists, "manifest should be uploaded together with the consolidated binlog")
+
+	assert.NoError(t, uploader.RemoveChunks(ctx, meta, pitr))
+	for chunk := 1; chunk <= meta.Chunks; chunk++ {
+		name, err := getChunkObjectName(meta.ServerId, meta.BinlogFilename, chunk, pitr.Spec.Compression)
+		assert.NoError(t, err)
+		exists, err := storageClient.Exists(ctx, name)
+		assert.NoError(t, err)
+		assert.False(t, exists)
+	}
+}
+
+func TestConsolidateChunksAfterCompressionChange(t *testing.T) {
+	ctx := context.Background()
+	storageClient, err := filesystem.NewFileSystemClient(t.TempDir(), t.TempDir(), filesystem.WithAllowNestedPrefixes(true))
+	assert.NoError(t, err)
+	mdb := &mariadbv1alpha1.MariaDB{ObjectMeta: metav1.ObjectMeta{Name: "mariadb"}}
+
+	chunk1, pos := testTransactions(binlogHeaderSize, 1)
+	chunk1 = append(append([]byte{}, replication.BinLogFileHeader...), chunk1...)
+	chunk2, pos := testTransactions(pos, 2)
+	meta := &BinlogMetadata{
+		ServerId:       10,
+		BinlogFilename: "mariadb-repl-bin.000001",
+		LogPosition:    pos,
+		Chunks:         2,
+		LastGtid:       mustParseGtid(t, "0-10-2"),
+	}
+
+	// the first chunk is streamed with gzip, and the compression is changed to zstd before streaming the second one.
+	for i, calg := range []mariadbv1alpha1.CompressAlgorithm{mariadbv1alpha1.CompressGzip, mariadbv1alpha1.CompressZstd} {
+		compressor, err := compression.NewCompressor(calg)
+		assert.NoError(t, err)
+		uploader := NewUploader(t.TempDir(), storageClient, compressor, logr.Discard())
+		pitr := &mariadbv1alpha1.PointInTimeRecovery{
+			Spec: mariadbv1alpha1.PointInTimeRecoverySpec{
+				Compression: calg,
+			},
+		}
+		data := [][]byte{chunk1, chunk2}[i]
+		assert.NoError(t, uploader.UploadChunk(ctx, meta.ServerId, meta.BinlogFilename, i+1, data, pitr))
+	}
+
+	compressor, err := compression.NewCompressor(mariadbv1alpha1.CompressZstd)
+	assert.NoError(t, err)
+	uploader := NewUploader(t.TempDir(), storageClient, compressor, logr.Discard())
+	pitr := &mariadbv1alpha1.PointInTimeRecovery{
+		Spec: mariadbv1alpha1.PointInTimeRecoverySpec{
+			Compression: mariadbv1alpha1.CompressZstd,
+		},
+	}
+	consolidated, err := uploader.ConsolidateChunks(ctx, meta, mdb, pitr)
+	assert.NoError(t, err)
+	assert.Equal(t, "0-10-2", consolidated.LastGtid.String())
+
+	reader, err := storageClient.GetObjectWithOptions(ctx, "server-10/mariadb-repl-bin.000001.zst")
+	assert.NoError(t, err)
+	defer reader.Close()
+	var data bytes.Buffer
+	assert.NoError(t, compressor.Decompress(ctx, &data, reader))
+	assert.Equal(t, append(append([]byte{}, chunk1...), chunk2...), data.Bytes())
+}
+
+func testGetChunk(t *testing.T, chunks [][]byte) GetChunkFunc {
+	compressor, err := compression.NewCompressor(mariadbv1alpha1.CompressNone)
+	assert.NoError(t, err)
+
+	return func(ctx context.Context, chunk int) (io.ReadCloser, compression.Compressor, error) {
+		if chunk > len(chunks) {
+			return nil, nil, errors.New("chunk not found")
+		}
+		return io.NopCloser(bytes.NewReader(chunks[chunk-1])), compressor, nil
+	}
+}
+
+// testTransactions returns the raw events of a transaction per GTID sequence number written from the given position,
+// along with the position after them.
+func testTransactions(pos uint32, seqs ...uint64) ([]byte, uint32) {
+	var data []byte
+	for _, seq := range seqs {
+		gtid := testGtidEvent(seq, 0, false).RawData
+		xid := append(testEvent(replication.XID_EVENT, 0, 0, nil).RawData, make([]byte, 8)...)
+
+		for _, raw := range [][]byte{gtid, xid} {
+			pos += uint32(len(raw))
+			binary.LittleEndian.PutUint32(raw[5:], 10)
+			binary.LittleEndian.PutUint32(raw[9:], uint32(len(raw)))
+			binary.LittleEndian.PutUint32(raw[13:], pos)
+			data = append(data, raw...)
+		}
+	}
+	return data, pos
+}
@@ -0,0 +1,58 @@
+package binlog
+
+import (
+	"bufio"
+	"bytes"
+	"regexp"
+)
+
+var (
+	// flashbackTxStatementRegex matches the statements that start or end the transactions decoded by mariadb-binlog,
+	// including the rollback it adds at the end of the log file.
+	flashbackTxStatementRegex = regexp.MustCompile(
+		`^(BEGIN|START TRANSACTION|COMMIT|ROLLBACK /\* added by mysqlbinlog \*/ ?)(/\*!\*/;|;)?$`,
+	)
+	// flashbackGtidSessionRegex matches the session variables set by mariadb-binlog for each GTID event,
+	// which cannot be modified inside a transaction.
+	flashbackGtidSessionRegex = regexp.MustCompile(
+		`^/\*M?!\d+ SET @@session\.(gtid_domain_id|gtid_seq_no|server_id|skip_parallel_replication|skip_replication)=\d+\*//\*!\*/;$`,
+	)
+	flashbackDelimiter = "/*!*/;"
+)
+
+// FlashbackTransaction rewrites the reverse SQL generated by mariadb-binlog to be applied in a single transaction,
+// so a failure in any of the reverted transactions leaves the data untouched. The per-transaction statements and
+// GTID session variables are removed, as the reverse SQL is applied as a new transaction with a GTID of the server.
+func FlashbackTransaction(sql []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteString("START TRANSACTION;\n")
+
+	scanner := bufio.NewScanner(bytes.NewReader(sql))
+	scanner.Buffer(make([]byte, 0, 64*1024), len(sql)+1)
+	skipDelimiter := false
+	for scanner.Scan() {
+		line := scanner.Text()
+		if skipDelimiter {
+			skipDelimiter = false
+			if line == flashbackDelimiter {
+				continue
+			}
+		}
+		if match := flashbackTxStatementRegex.FindStringSubmatch(line); match != nil {
+			// statements without delimiter are terminated in the next line.
+			skipDelimiter = match[2] == ""
+			continue
+		}
+		if flashbackGtidSessionRegex.MatchString(line) {
+			continue
+		}
+		buf.WriteString(line)
+		buf.WriteByte('\n')
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	buf.WriteString("COMMIT;\n")
+	return buf.Bytes(), nil
+}
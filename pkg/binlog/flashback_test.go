@@ -0,0 +1,66 @@
+package binlog
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFlashbackTransaction(t *testing.T) {
+	sql := `/*!50530 SET @@SESSION.PSEUDO_SLAVE_MODE=1*/;
+/*!40019 SET @@session.max_delayed_threads=0*/;
+DELIMITER /*!*/;
+#260228 16:10:42 server id 10  end_log_pos 4521 CRC32 0x5a1c2b3d  GTID 0-10-2 trans
+/*M!100101 SET @@session.skip_parallel_replication=0*//*!*/;
+/*M!100001 SET @@session.gtid_domain_id=0*//*!*/;
+/*M!100001 SET @@session.server_id=10*//*!*/;
+/*M!100001 SET @@session.gtid_seq_no=2*//*!*/;
+START TRANSACTION
+/*!*/;
+BINLOG '
+ZG9lcw==
+'/*!*/;
+### DELETE FROM ` + "`db`.`t`" + `
+COMMIT/*!*/;
+#260228 16:10:41 server id 10  end_log_pos 4321 CRC32 0x5a1c2b3c  GTID 0-10-1 trans
+/*M!100001 SET @@session.gtid_seq_no=1*//*!*/;
+BEGIN
+/*!*/;
+BINLOG '
+aW5zZXJ0
+'/*!*/;
+COMMIT
+/*!*/;
+DELIMITER ;
+# End of log file
+ROLLBACK /* added by mysqlbinlog */;
+/*!50003 SET COMPLETION_TYPE=@OLD_COMPLETION_TYPE*/;
+/*!50530 SET @@SESSION.PSEUDO_SLAVE_MODE=0*/;
+`
+	want := `START TRANSACTION;
+/*!50530 SET @@SESSION.PSEUDO_SLAVE_MODE=1*/;
+/*!40019 SET @@session.max_delayed_threads=0*/;
+DELIMITER /*!*/;
+#260228 16:10:42 server id 10  end_log_pos 4521 CRC32 0x5a1c2b3d  GTID 0-10-2 trans
+BINLOG '
+ZG9lcw==
+'/*!*/;
+### DELETE FROM ` + "`db`.`t`" + `
+#260228 16:10:41 server id 10  end_log_pos 4321 CRC32 0x5a1c2b3c  GTID 0-10-1 trans
+BINLOG '
+aW5zZXJ0
+'/*!*/;
+DELIMITER ;
+# End of log file
+/*!50003 SET COMPLETION_TYPE=@OLD_COMPLETION_TYPE*/;
+/*!50530 SET @@SESSION.PSEUDO_SLAVE_MODE=0*/;
+COMMIT;
+`
+	got, err := FlashbackTransaction([]byte(sql))
+	assert.NoError(t, err)
+	assert.Equal(t, want, string(got))
+
+	got, err = FlashbackTransaction(nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "START TRANSACTION;\nCOMMIT;\n", string(got))
+}
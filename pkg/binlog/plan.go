@@ -0,0 +1,100 @@
+package binlog
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	mariadbv1alpha1 "github.com/mariadb-operator/mariadb-operator/v26/api/v1alpha1"
+	mariadbrepl "github.com/mariadb-operator/mariadb-operator/v26/pkg/replication"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// AddToRestorePlan adds to the restore plan the binary logs to be replayed on top of its backup up until its target recovery time,
+// or its target recovery GTID when set, as well as the gaps in the timeline. The error that the timeline would raise in strict mode is recorded in the plan.
+func (b *BinlogIndex) AddToRestorePlan(plan *mariadbv1alpha1.RestorePlan, logger logr.Logger) error {
+	if plan.BackupGtid == "" {
+		return errors.New("backup GTID not found")
+	}
+	// TODO: support multiple GTID domain IDs
+	startGtid, err := mariadbrepl.ParseGtid(plan.BackupGtid)
+	if err != nil {
+		return fmt.Errorf("error parsing backup GTID: %v", err)
+	}
+	targetTime := plan.TargetRecoveryTime.Time
+	var targetGtid *mariadbrepl.Gtid
+	if plan.TargetRecoveryGtid != "" {
+		if targetGtid, err = mariadbrepl.ParseGtid(plan.TargetRecoveryGtid); err != nil {
+			return fmt.Errorf("error parsing target recovery GTID: %v", err)
+		}
+	}
+	buildTimeline := func(strictMode bool) ([]BinlogMetadata, error) {
+		timelineLogger := logger.WithName("binlog-timeline").V(1)
+		if targetGtid != nil {
+			return b.BuildTimelineWithTargetGtid(startGtid, targetGtid, strictMode, timelineLogger)
+		}
+		return b.BuildTimeline(startGtid, targetTime, strictMode, timelineLogger)
+	}
+
+	binlogs, err := buildTimeline(false)
+	if err != nil && !errors.Is(err, ErrNoBinlogs) {
+		return fmt.Errorf("error building binlog timeline: %v", err)
+	}
+	plan.Binlogs = nil
+	plan.LastRecoverableTime = nil
+	for _, binlog := range binlogs {
+		planBinlog := mariadbv1alpha1.RestorePlanBinlog{
+			Name:      binlog.ObjectStoragePath(),
+			FirstTime: metav1.NewTime(binlog.FirstTime.UTC()),
+			LastTime:  metav1.NewTime(binlog.LastTime.UTC()),
+		}
+		if binlog.FirstGtid != nil {
+			planBinlog.FirstGtid = binlog.FirstGtid.String()
+		}
+		if binlog.LastGtid != nil {
+			planBinlog.LastGtid = binlog.LastGtid.String()
+		}
+		plan.Binlogs = append(plan.Binlogs, planBinlog)
+	}
+	if len(binlogs) > 0 {
+		lastRecoverableTime := binlogs[len(binlogs)-1].LastTime.UTC()
+		if lastRecoverableTime.After(targetTime) {
+			lastRecoverableTime = targetTime.UTC()
+		}
+		plan.LastRecoverableTime = &metav1.Time{Time: lastRecoverableTime}
+	}
+
+	plan.Gaps = nil
+	for _, gap := range b.Gaps(logger) {
+		if !gap.EndTime.After(plan.BackupTime.Time) || !gap.StartTime.Before(targetTime) {
+			continue
+		}
+		if targetGtid != nil && !isGapBeforeGtid(gap, targetGtid) {
+			continue
+		}
+		plan.Gaps = append(plan.Gaps, mariadbv1alpha1.RestorePlanGap{
+			LastGtid:  gap.LastGtid,
+			NextGtid:  gap.NextGtid,
+			StartTime: metav1.NewTime(gap.StartTime),
+			EndTime:   metav1.NewTime(gap.EndTime),
+		})
+	}
+
+	plan.StrictModeError = ""
+	if _, err := buildTimeline(true); err != nil {
+		plan.StrictModeError = err.Error()
+	}
+	return nil
+}
+
+func isGapBeforeGtid(gap TimelineGap, gtid *mariadbrepl.Gtid) bool {
+	lastGtid, err := mariadbrepl.ParseGtid(gap.LastGtid)
+	if err != nil {
+		return true
+	}
+	isBefore, err := lastGtid.LessThan(gtid)
+	if err != nil {
+		return true
+	}
+	return isBefore
+}
@@ -0,0 +1,232 @@
+package binlog
+
+import (
+	"testing"
+
+	"github.com/go-logr/logr"
+	mariadbv1alpha1 "github.com/mariadb-operator/mariadb-operator/v26/api/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestAddToRestorePlan(t *testing.T) {
+	newTime := func(s string) metav1.Time {
+		return metav1.NewTime(mustParseDate(t, s))
+	}
+	newTimePtr := func(s string) *metav1.Time {
+		tt := newTime(s)
+		return &tt
+	}
+
+	tests := []struct {
+		name      string
+		indexFile *BinlogIndex
+		plan      *mariadbv1alpha1.RestorePlan
+		wantPlan  *mariadbv1alpha1.RestorePlan
+		wantErr   bool
+	}{
+		{
+			name:      "no backup GTID",
+			indexFile: mustParseTestFile(t, "gap.yaml"),
+			plan: &mariadbv1alpha1.RestorePlan{
+				TargetRecoveryTime: newTime("2026-02-04T12:08:00Z"),
+				Backup:             "physicalbackup-20260204120000.xb",
+				BackupTime:         newTime("2026-02-04T12:00:00Z"),
+			},
+			wantErr: true,
+		},
+		{
+			name:      "binlogs until target time",
+			indexFile: mustParseTestFile(t, "gap.yaml"),
+			plan: &mariadbv1alpha1.RestorePlan{
+				TargetRecoveryTime: newTime("2026-02-04T12:08:00Z"),
+				Backup:             "physicalbackup-20260204120000.xb",
+				BackupTime:         newTime("2026-02-04T12:00:00Z"),
+				BackupGtid:         "0-10-1",
+			},
+			wantPlan: &mariadbv1alpha1.RestorePlan{
+				TargetRecoveryTime: newTime("2026-02-04T12:08:00Z"),
+				Backup:             "physicalbackup-20260204120000.xb",
+				BackupTime:         newTime("2026-02-04T12:00:00Z"),
+				BackupGtid:         "0-10-1",
+				Binlogs: []mariadbv1alpha1.RestorePlanBinlog{
+					{
+						Name:      "server-10/mariadb-repl-bin.000001",
+						FirstTime: newTime("2026-02-04T12:00:00Z"),
+						LastTime:  newTime("2026-02-04T12:05:00Z"),
+						FirstGtid: "0-10-1",
+						LastGtid:  "0-10-18",
+					},
+					{
+						Name:      "server-10/mariadb-repl-bin.000002",
+						FirstTime: newTime("2026-02-04T12:05:00Z"),
+						LastTime:  newTime("2026-02-04T12:10:00Z"),
+						FirstGtid: "0-10-19",
+						LastGtid:  "0-10-35",
+					},
+				},
+				LastRecoverableTime: newTimePtr("2026-02-04T12:08:00Z"),
+			},
+		},
+		{
+			name:      "gap before target time",
+			indexFile: mustParseTestFile(t, "gap.yaml"),
+			plan: &mariadbv1alpha1.RestorePlan{
+				TargetRecoveryTime: newTime("2026-02-04T12:18:00Z"),
+				Backup:             "physicalbackup-20260204120000.xb",
+				BackupTime:         newTime("2026-02-04T12:00:00Z"),
+				BackupGtid:         "0-10-1",
+			},
+			wantPlan: &mariadbv1alpha1.RestorePlan{
+				TargetRecoveryTime: newTime("2026-02-04T12:18:00Z"),
+				Backup:             "physicalbackup-20260204120000.xb",
+				BackupTime:         newTime("2026-02-04T12:00:00Z"),
+				BackupGtid:         "0-10-1",
+				Binlogs: []mariadbv1alpha1.RestorePlanBinlog{
+					{
+						Name:      "server-10/mariadb-repl-bin.000001",
+						FirstTime: newTime("2026-02-04T12:00:00Z"),
+						LastTime:  newTime("2026-02-04T12:05:00Z"),
+						FirstGtid: "0-10-1",
+						LastGtid:  "0-10-18",
+					},
+					{
+						Name:      "server-10/mariadb-repl-bin.000002",
+						FirstTime: newTime("2026-02-04T12:05:00Z"),
+						LastTime:  newTime("2026-02-04T12:10:00Z"),
+						FirstGtid: "0-10-19",
+						LastGtid:  "0-10-35",
+					},
+				},
+				LastRecoverableTime: newTimePtr("2026-02-04T12:10:00Z"),
+				Gaps: []mariadbv1alpha1.RestorePlanGap{
+					{
+						LastGtid:  "0-10-35",
+						NextGtid:  "0-10-53",
+						StartTime: newTime("2026-02-04T12:10:00Z"),
+						EndTime:   newTime("2026-02-04T12:15:00Z"),
+					},
+				},
+				StrictModeError: "timeline did not reach target time: 2026-02-04T12:18:00Z, last recoverable time: 2026-02-04T12:10:00Z",
+			},
+		},
+		{
+			name:      "gap before backup",
+			indexFile: mustParseTestFile(t, "gap.yaml"),
+			plan: &mariadbv1alpha1.RestorePlan{
+				TargetRecoveryTime: newTime("2026-02-04T12:18:00Z"),
+				Backup:             "physicalbackup-20260204121600.xb",
+				BackupTime:         newTime("2026-02-04T12:16:00Z"),
+				BackupGtid:         "0-10-55",
+			},
+			wantPlan: &mariadbv1alpha1.RestorePlan{
+				TargetRecoveryTime: newTime("2026-02-04T12:18:00Z"),
+				Backup:             "physicalbackup-20260204121600.xb",
+				BackupTime:         newTime("2026-02-04T12:16:00Z"),
+				BackupGtid:         "0-10-55",
+				Binlogs: []mariadbv1alpha1.RestorePlanBinlog{
+					{
+						Name:      "server-10/mariadb-repl-bin.000004",
+						FirstTime: newTime("2026-02-04T12:15:00Z"),
+						LastTime:  newTime("2026-02-04T12:20:00Z"),
+						FirstGtid: "0-10-53",
+						LastGtid:  "0-10-69",
+					},
+				},
+				LastRecoverableTime: newTimePtr("2026-02-04T12:18:00Z"),
+				StrictModeError:     "timeline did not reach target time: 2026-02-04T12:18:00Z, last recoverable time: 2026-02-04T12:20:00Z",
+			},
+		},
+		{
+			name:      "binlogs until target GTID",
+			indexFile: mustParseTestFile(t, "gap.yaml"),
+			plan: &mariadbv1alpha1.RestorePlan{
+				TargetRecoveryTime: newTime("2026-02-04T13:00:00Z"),
+				TargetRecoveryGtid: "0-10-20",
+				Backup:             "physicalbackup-20260204120000.xb",
+				BackupTime:         newTime("2026-02-04T12:00:00Z"),
+				BackupGtid:         "0-10-1",
+			},
+			wantPlan: &mariadbv1alpha1.RestorePlan{
+				TargetRecoveryTime: newTime("2026-02-04T13:00:00Z"),
+				TargetRecoveryGtid: "0-10-20",
+				Backup:             "physicalbackup-20260204120000.xb",
+				BackupTime:         newTime("2026-02-04T12:00:00Z"),
+				BackupGtid:         "0-10-1",
+				Binlogs: []mariadbv1alpha1.RestorePlanBinlog{
+					{
+						Name:      "server-10/mariadb-repl-bin.000001",
+						FirstTime: newTime("2026-02-04T12:00:00Z"),
+						LastTime:  newTime("2026-02-04T12:05:00Z"),
+						FirstGtid: "0-10-1",
+						LastGtid:  "0-10-18",
+					},
+					{
+						Name:      "server-10/mariadb-repl-bin.000002",
+						FirstTime: newTime("2026-02-04T12:05:00Z"),
+						LastTime:  newTime("2026-02-04T12:10:00Z"),
+						FirstGtid: "0-10-19",
+						LastGtid:  "0-10-35",
+					},
+				},
+				LastRecoverableTime: newTimePtr("2026-02-04T12:10:00Z"),
+			},
+		},
+		{
+			name:      "gap before target GTID",
+			indexFile: mustParseTestFile(t, "gap.yaml"),
+			plan: &mariadbv1alpha1.RestorePlan{
+				TargetRecoveryTime: newTime("2026-02-04T13:00:00Z"),
+				TargetRecoveryGtid: "0-10-60",
+				Backup:             "physicalbackup-20260204120000.xb",
+				BackupTime:         newTime("2026-02-04T12:00:00Z"),
+				BackupGtid:         "0-10-1",
+			},
+			wantPlan: &mariadbv1alpha1.RestorePlan{
+				TargetRecoveryTime: newTime("2026-02-04T13:00:00Z"),
+				TargetRecoveryGtid: "0-10-60",
+				Backup:             "physicalbackup-20260204120000.xb",
+				BackupTime:         newTime("2026-02-04T12:00:00Z"),
+				BackupGtid:         "0-10-1",
+				Binlogs: []mariadbv1alpha1.RestorePlanBinlog{
+					{
+						Name:      "server-10/mariadb-repl-bin.000001",
+						FirstTime: newTime("2026-02-04T12:00:00Z"),
+						LastTime:  newTime("2026-02-04T12:05:00Z"),
+						FirstGtid: "0-10-1",
+						LastGtid:  "0-10-18",
+					},
+					{
+						Name:      "server-10/mariadb-repl-bin.000002",
+						FirstTime: newTime("2026-02-04T12:05:00Z"),
+						LastTime:  newTime("2026-02-04T12:10:00Z"),
+						FirstGtid: "0-10-19",
+						LastGtid:  "0-10-35",
+					},
+				},
+				LastRecoverableTime: newTimePtr("2026-02-04T12:10:00Z"),
+				Gaps: []mariadbv1alpha1.RestorePlanGap{
+					{
+						LastGtid:  "0-10-35",
+						NextGtid:  "0-10-53",
+						StartTime: newTime("2026-02-04T12:10:00Z"),
+						EndTime:   newTime("2026-02-04T12:15:00Z"),
+					},
+				},
+				StrictModeError: "timeline did not reach target GTID: 0-10-60, last recoverable GTID: 0-10-35",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.indexFile.AddToRestorePlan(tt.plan, logr.Discard())
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantPlan, tt.plan)
+		})
+	}
+}
@@ -0,0 +1,96 @@
+package binlog
+
+import (
+	"time"
+
+	"github.com/go-logr/logr"
+	mariadbrepl "github.com/mariadb-operator/mariadb-operator/v26/pkg/replication"
+)
+
+// PurgeableBinlogs returns the binlogs that are no longer needed to recover from the given oldest backup GTID.
+// A binlog is purgeable when its GTID range ends before the oldest GTID and it was completed before the safety margin,
+// which is applied relative to the first binlog that is still needed. Binlogs that have only been streamed in chunks are never purgeable.
+func (b *BinlogIndex) PurgeableBinlogs(oldestGtid *mariadbrepl.Gtid, safetyMargin time.Duration, logger logr.Logger) []BinlogMetadata {
+	var cutoff *time.Time
+	needed := make(map[string]map[string]bool)
+
+	for server, binlogs := range b.Binlogs {
+		needed[server] = make(map[string]bool)
+		for _, meta := range binlogs {
+			if !isBinlogNeeded(&meta, oldestGtid, logger) {
+				continue
+			}
+			needed[server][meta.BinlogFilename] = true
+			if cutoff == nil || meta.FirstTime.Time.Before(*cutoff) {
+				firstTime := meta.FirstTime.Time
+				cutoff = &firstTime
+			}
+		}
+	}
+	if cutoff == nil {
+		logger.V(1).Info("No binlogs needed by the oldest backup. Skipping purge", "oldest-gtid", oldestGtid)
+		return nil
+	}
+	purgeBefore := cutoff.Add(-safetyMargin)
+
+	var purgeable []BinlogMetadata
+	for server, binlogs := range b.Binlogs {
+		for _, meta := range binlogs {
+			if needed[server][meta.BinlogFilename] || meta.Chunks > 0 {
+				continue
+			}
+			if meta.LastTime.Time.Before(purgeBefore) {
+				purgeable = append(purgeable, meta)
+			}
+		}
+	}
+	return purgeable
+}
+
+// ExpiredBinlogs returns the binlogs that were completed before the given time.
+// Binlogs that have only been streamed in chunks are never expired.
+func (b *BinlogIndex) ExpiredBinlogs(before time.Time) []BinlogMetadata {
+	var expired []BinlogMetadata
+	for _, binlogs := range b.Binlogs {
+		for _, meta := range binlogs {
+			if meta.Chunks == 0 && meta.LastTime.Time.Before(before) {
+				expired = append(expired, meta)
+			}
+		}
+	}
+	return expired
+}
+
+// Remove removes a binlog from the index.
+func (b *BinlogIndex) Remove(serverId uint32, binlog string) {
+	key := serverKey(serverId)
+	binlogs, ok := b.Binlogs[key]
+	if !ok {
+		return
+	}
+	var kept []BinlogMetadata
+	for _, meta := range binlogs {
+		if meta.BinlogFilename != binlog {
+			kept = append(kept, meta)
+		}
+	}
+	if len(kept) == 0 {
+		delete(b.Binlogs, key)
+		return
+	}
+	b.Binlogs[key] = kept
+}
+
+// isBinlogNeeded determines whether a binlog may contain transactions after the given GTID.
+// Binlogs whose GTIDs cannot be compared, for instance because they belong to another domain, are considered needed.
+func isBinlogNeeded(meta *BinlogMetadata, oldestGtid *mariadbrepl.Gtid, logger logr.Logger) bool {
+	if meta.LastGtid == nil {
+		return true
+	}
+	lessThan, err := meta.LastGtid.LessThan(oldestGtid)
+	if err != nil {
+		logger.V(1).Info("Error comparing GTIDs. Considering binlog as needed", "binlog", meta.ObjectStoragePath(), "err", err)
+		return true
+	}
+	return !lessThan
+}
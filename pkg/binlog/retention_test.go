@@ -0,0 +1,131 @@
+package binlog
+
+import (
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	mariadbrepl "github.com/mariadb-operator/mariadb-operator/v26/pkg/replication"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestPurgeableBinlogs(t *testing.T) {
+	base := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	binlog := func(serverId uint32, filename string, first, last string, firstTime, lastTime time.Duration, chunks int) BinlogMetadata {
+		return BinlogMetadata{
+			ServerId:       serverId,
+			BinlogFilename: filename,
+			FirstTime:      metav1.NewTime(base.Add(firstTime)),
+			LastTime:       metav1.NewTime(base.Add(lastTime)),
+			FirstGtid:      mustParseGtid(t, first),
+			LastGtid:       mustParseGtid(t, last),
+			Chunks:         chunks,
+		}
+	}
+	index := NewBinlogIndex()
+	index.Add(10, binlog(10, "mariadb-repl-bin.000001", "0-10-1", "0-10-10", 0, 59*time.Minute, 0))
+	index.Add(10, binlog(10, "mariadb-repl-bin.000002", "0-10-11", "0-10-20", time.Hour, 119*time.Minute, 0))
+	index.Add(10, binlog(10, "mariadb-repl-bin.000003", "0-10-21", "0-10-30", 2*time.Hour, 239*time.Minute, 0))
+	index.Add(10, binlog(10, "mariadb-repl-bin.000004", "0-10-31", "0-10-40", 4*time.Hour, 299*time.Minute, 0))
+	index.Add(11, binlog(11, "mariadb-repl-bin.000001", "0-11-41", "0-11-50", 5*time.Hour, 6*time.Hour, 3))
+
+	tests := []struct {
+		name         string
+		oldestGtid   *mariadbrepl.Gtid
+		safetyMargin time.Duration
+		wantBinlogs  []string
+	}{
+		{
+			name:         "no margin",
+			oldestGtid:   mustParseGtid(t, "0-10-25"),
+			safetyMargin: 0,
+			wantBinlogs: []string{
+				"server-10/mariadb-repl-bin.000001",
+				"server-10/mariadb-repl-bin.000002",
+			},
+		},
+		{
+			name:         "safety margin",
+			oldestGtid:   mustParseGtid(t, "0-10-25"),
+			safetyMargin: 30 * time.Minute,
+			wantBinlogs: []string{
+				"server-10/mariadb-repl-bin.000001",
+			},
+		},
+		{
+			name:         "oldest GTID in other server",
+			oldestGtid:   mustParseGtid(t, "0-11-45"),
+			safetyMargin: 0,
+			wantBinlogs: []string{
+				"server-10/mariadb-repl-bin.000001",
+				"server-10/mariadb-repl-bin.000002",
+				"server-10/mariadb-repl-bin.000003",
+				"server-10/mariadb-repl-bin.000004",
+			},
+		},
+		{
+			name:         "all needed",
+			oldestGtid:   mustParseGtid(t, "0-10-1"),
+			safetyMargin: 0,
+			wantBinlogs:  nil,
+		},
+		{
+			name:         "different domain",
+			oldestGtid:   mustParseGtid(t, "1-10-100"),
+			safetyMargin: 0,
+			wantBinlogs:  nil,
+		},
+		{
+			name:         "none needed",
+			oldestGtid:   mustParseGtid(t, "0-10-100"),
+			safetyMargin: 0,
+			wantBinlogs:  nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var paths []string
+			for _, meta := range index.PurgeableBinlogs(tt.oldestGtid, tt.safetyMargin, logr.Discard()) {
+				paths = append(paths, meta.ObjectStoragePath())
+			}
+			sort.Strings(paths)
+			assert.Equal(t, tt.wantBinlogs, paths)
+		})
+	}
+}
+
+func TestBinlogIndexRemove(t *testing.T) {
+	index := NewBinlogIndex()
+	index.Add(10, BinlogMetadata{ServerId: 10, BinlogFilename: "mariadb-repl-bin.000001"})
+	index.Add(10, BinlogMetadata{ServerId: 10, BinlogFilename: "mariadb-repl-bin.000002"})
+	index.Add(11, BinlogMetadata{ServerId: 11, BinlogFilename: "mariadb-repl-bin.000001"})
+
+	index.Remove(10, "mariadb-repl-bin.000001")
+	assert.False(t, index.Exists(10, "mariadb-repl-bin.000001"))
+	assert.True(t, index.Exists(10, "mariadb-repl-bin.000002"))
+	assert.True(t, index.Exists(11, "mariadb-repl-bin.000001"))
+
+	index.Remove(11, "mariadb-repl-bin.000001")
+	_, ok := index.Binlogs[serverKey(11)]
+	assert.False(t, ok)
+
+	index.Remove(12, "mariadb-repl-bin.000001")
+	assert.Len(t, index.Binlogs, 1)
+}
+
+func TestExpiredBinlogs(t *testing.T) {
+	base := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	index := NewBinlogIndex()
+	index.Add(10, BinlogMetadata{ServerId: 10, BinlogFilename: "mariadb-repl-bin.000001", LastTime: metav1.NewTime(base)})
+	index.Add(10, BinlogMetadata{ServerId: 10, BinlogFilename: "mariadb-repl-bin.000002", LastTime: metav1.NewTime(base.Add(2 * time.Hour))})
+	index.Add(11, BinlogMetadata{ServerId: 11, BinlogFilename: "mariadb-repl-bin.000001", LastTime: metav1.NewTime(base), Chunks: 2})
+
+	var paths []string
+	for _, meta := range index.ExpiredBinlogs(base.Add(time.Hour)) {
+		paths = append(paths, meta.ObjectStoragePath())
+	}
+	assert.Equal(t, []string{"server-10/mariadb-repl-bin.000001"}, paths)
+	assert.Empty(t, index.ExpiredBinlogs(base))
+}
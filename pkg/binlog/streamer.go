@@ -0,0 +1,268 @@
+package binlog
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/go-mysql-org/go-mysql/mysql"
+	"github.com/go-mysql-org/go-mysql/replication"
+	mariadbv1alpha1 "github.com/mariadb-operator/mariadb-operator/v26/api/v1alpha1"
+	"github.com/mariadb-operator/mariadb-operator/v26/pkg/environment"
+)
+
+var (
+	// streamerServerId is the server_id used by the streamer to connect as a replication client.
+	// It must not collide with the server_id of any MariaDB server in the topology.
+	streamerServerId = uint32(4294967000)
+	// binlogHeaderSize is the size of the magic number at the beginning of every binlog.
+	binlogHeaderSize = uint32(len(replication.BinLogFileHeader))
+)
+
+// ChunkFunc is called after every chunk is uploaded, with the metadata of the binlog up to the last uploaded chunk.
+type ChunkFunc func(ctx context.Context, meta *BinlogMetadata) error
+
+// Streamer connects to the local MariaDB as a replication client and uploads the committed transactions of the active binlog in chunks.
+type Streamer struct {
+	env           *environment.PodEnvironment
+	uploader      *Uploader
+	flushInterval time.Duration
+	logger        logr.Logger
+}
+
+func NewStreamer(env *environment.PodEnvironment, uploader *Uploader, flushInterval time.Duration, logger logr.Logger) *Streamer {
+	return &Streamer{
+		env:           env,
+		uploader:      uploader,
+		flushInterval: flushInterval,
+		logger:        logger,
+	}
+}
+
+// Stream streams the given binlog until it is rotated or the context is cancelled.
+// When the binlog has been partially streamed before, the stream is resumed after the last uploaded chunk.
+func (s *Streamer) Stream(ctx context.Context, binlog string, previous *BinlogMetadata, pitr *mariadbv1alpha1.PointInTimeRecovery,
+	onChunk ChunkFunc) error {
+	buffer := newChunkBuffer(binlog, previous)
+	logger := s.logger.WithValues("binlog", binlog, "position", buffer.position, "chunks", buffer.chunks)
+	logger.Info("Streaming binary log")
+
+	syncer, err := s.newSyncer()
+	if err != nil {
+		return err
+	}
+	defer syncer.Close()
+
+	streamer, err := syncer.StartSync(mysql.Position{Name: binlog, Pos: buffer.position})
+	if err != nil {
+		return fmt.Errorf("error starting binlog sync: %v", err)
+	}
+
+	flush := func() error {
+		data, meta, err := buffer.nextChunk()
+		if err != nil {
+			return fmt.Errorf("error getting binlog chunk: %v", err)
+		}
+		if data == nil {
+			return nil
+		}
+		if err := s.uploader.UploadChunk(ctx, meta.ServerId, binlog, meta.Chunks, data, pitr); err != nil {
+			return err
+		}
+		return onChunk(ctx, meta)
+	}
+
+	nextFlush := time.Now().Add(s.flushInterval)
+	for {
+		eventCtx, cancel := context.WithDeadline(ctx, nextFlush)
+		e, err := streamer.GetEvent(eventCtx)
+		cancel()
+
+		if ctx.Err() != nil {
+			logger.Info("Stopping binary log streaming")
+			return nil
+		}
+		if err != nil && !errors.Is(err, context.DeadlineExceeded) {
+			return fmt.Errorf("error getting binlog event: %v", err)
+		}
+		if e != nil {
+			if err := buffer.addEvent(e); err != nil {
+				return err
+			}
+			if buffer.rotated {
+				if err := flush(); err != nil {
+					return err
+				}
+				logger.Info("Binary log rotated")
+				return nil
+			}
+		}
+		if !time.Now().Before(nextFlush) {
+			if err := flush(); err != nil {
+				return err
+			}
+			nextFlush = time.Now().Add(s.flushInterval)
+		}
+	}
+}
+
+func (s *Streamer) newSyncer() (*replication.BinlogSyncer, error) {
+	port, err := s.env.Port()
+	if err != nil {
+		return nil, fmt.Errorf("error getting port: %v", err)
+	}
+	config := replication.BinlogSyncerConfig{
+		ServerID:       streamerServerId,
+		Flavor:         mysql.MariaDBFlavor,
+		Host:           "localhost",
+		Port:           uint16(port),
+		User:           "root",
+		Password:       s.env.MariadbRootPassword,
+		FillZeroLogPos: true,
+		// errors are returned to the archiver, which resumes the stream from the last uploaded chunk.
+		DisableRetrySync: true,
+		HeartbeatPeriod:  s.flushInterval,
+		Logger:           slog.New(logr.ToSlogHandler(s.logger.WithName("syncer").V(1))),
+	}
+
+	isTLSEnabled, err := s.env.IsTLSEnabled()
+	if err != nil {
+		return nil, fmt.Errorf("error checking whether TLS is enabled in environment: %v", err)
+	}
+	if isTLSEnabled {
+		caCert, err := os.ReadFile(s.env.TLSCACertPath)
+		if err != nil {
+			return nil, fmt.Errorf("error reading CA certificate: %v", err)
+		}
+		caBundle := x509.NewCertPool()
+		if ok := caBundle.AppendCertsFromPEM(caCert); !ok {
+			return nil, errors.New("failed to parse PEM-encoded CA certificates")
+		}
+		config.TLSConfig = &tls.Config{
+			RootCAs:    caBundle,
+			ServerName: "localhost",
+		}
+	}
+	return replication.NewBinlogSyncer(config), nil
+}
+
+// chunkBuffer buffers the events of a streamed binlog, keeping track of transaction boundaries
+// so only committed transactions are included in the chunks.
+type chunkBuffer struct {
+	binlog   string
+	position uint32
+	chunks   int
+	resumed  bool
+	rotated  bool
+
+	builder       *metadataBuilder
+	committed     bytes.Buffer
+	pending       []*replication.BinlogEvent
+	inTransaction bool
+	standalone    bool
+}
+
+func newChunkBuffer(binlog string, previous *BinlogMetadata) *chunkBuffer {
+	if previous != nil && previous.Chunks > 0 && previous.LogPosition > binlogHeaderSize {
+		return &chunkBuffer{
+			binlog:   binlog,
+			position: previous.LogPosition,
+			chunks:   previous.Chunks,
+			resumed:  true,
+			builder:  newMetadataBuilderFrom(*previous),
+		}
+	}
+	buffer := &chunkBuffer{
+		binlog:   binlog,
+		position: binlogHeaderSize,
+		builder:  newMetadataBuilder(binlog),
+	}
+	buffer.committed.Write(replication.BinLogFileHeader)
+	return buffer
+}
+
+func (b *chunkBuffer) addEvent(e *replication.BinlogEvent) error {
+	// Heartbeats and artificial events, such as the fake rotate sent at the beginning of the stream, are not part of the binlog.
+	if e.Header.EventType == replication.HEARTBEAT_EVENT || e.Header.EventType == replication.HEARTBEAT_LOG_EVENT_V2 ||
+		e.Header.Flags&replication.LOG_EVENT_ARTIFICIAL_F != 0 {
+		return nil
+	}
+	// The format description event is sent at the beginning of the stream, it is already part of the previous chunks when resuming.
+	if e.Header.EventType == replication.FORMAT_DESCRIPTION_EVENT && b.resumed {
+		return nil
+	}
+	event := &replication.BinlogEvent{
+		RawData: bytes.Clone(e.RawData),
+		Header:  e.Header,
+		Event:   e.Event,
+	}
+	b.pending = append(b.pending, event)
+
+	switch ev := e.Event.(type) {
+	case *replication.MariadbGTIDEvent:
+		if b.inTransaction {
+			return fmt.Errorf("unexpected GTID event %s in the middle of a transaction", ev.GTID.String())
+		}
+		b.inTransaction = true
+		b.standalone = ev.IsStandalone()
+		return nil
+	case *replication.XIDEvent:
+		b.commit()
+		return nil
+	case *replication.QueryEvent:
+		if !b.inTransaction || b.standalone || isCommitQuery(ev.Query) {
+			b.commit()
+		}
+		return nil
+	}
+	if e.Header.EventType == replication.XA_PREPARE_LOG_EVENT || !b.inTransaction {
+		b.commit()
+	}
+	if e.Header.EventType == replication.ROTATE_EVENT {
+		b.rotated = true
+	}
+	return nil
+}
+
+func (b *chunkBuffer) commit() {
+	for _, e := range b.pending {
+		b.builder.addEvent(e)
+		b.committed.Write(e.RawData)
+	}
+	b.pending = nil
+	b.inTransaction = false
+	b.standalone = false
+}
+
+// nextChunk returns the committed events pending to be uploaded, along with the metadata of the binlog up to them.
+func (b *chunkBuffer) nextChunk() ([]byte, *BinlogMetadata, error) {
+	if b.committed.Len() == 0 || (b.chunks == 0 && b.committed.Len() == int(binlogHeaderSize)) {
+		return nil, nil, nil
+	}
+	meta, err := b.builder.build()
+	if err != nil {
+		return nil, nil, err
+	}
+	data := bytes.Clone(b.committed.Bytes())
+	b.committed.Reset()
+	b.chunks++
+	meta.Chunks = b.chunks
+
+	return data, meta, nil
+}
+
+func isCommitQuery(query []byte) bool {
+	for _, prefix := range []string{"COMMIT", "ROLLBACK", "XA COMMIT", "XA ROLLBACK"} {
+		if bytes.HasPrefix(bytes.ToUpper(bytes.TrimSpace(query)), []byte(prefix)) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,173 @@
+package binlog
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+	"time"
+
+	"github.com/go-mysql-org/go-mysql/mysql"
+	"github.com/go-mysql-org/go-mysql/replication"
+	mariadbv1alpha1 "github.com/mariadb-operator/mariadb-operator/v26/api/v1alpha1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChunkBuffer(t *testing.T) {
+	buffer := newChunkBuffer("mariadb-repl-bin.000001", nil)
+
+	data, meta, err := buffer.nextChunk()
+	assert.NoError(t, err)
+	assert.Nil(t, data, "header only should not produce a chunk")
+	assert.Nil(t, meta)
+
+	events := []*replication.BinlogEvent{
+		testEvent(replication.ROTATE_EVENT, replication.LOG_EVENT_ARTIFICIAL_F, 0, &replication.RotateEvent{}),
+		testEvent(replication.HEARTBEAT_EVENT, 0, 0, nil),
+		testGtidEvent(1, 100, false),
+		testEvent(replication.QUERY_EVENT, 0, 150, &replication.QueryEvent{Query: []byte("BEGIN")}),
+		testEvent(replication.WRITE_ROWS_EVENTv1, 0, 200, nil),
+		testEvent(replication.XID_EVENT, 0, 250, &replication.XIDEvent{}),
+		testGtidEvent(2, 300, true),
+		testEvent(replication.QUERY_EVENT, 0, 350, &replication.QueryEvent{Query: []byte("CREATE TABLE t (id INT)")}),
+		testGtidEvent(3, 400, false),
+		testEvent(replication.QUERY_EVENT, 0, 450, &replication.QueryEvent{Query: []byte("BEGIN")}),
+	}
+	for _, e := range events {
+		assert.NoError(t, buffer.addEvent(e))
+	}
+	assert.True(t, buffer.inTransaction)
+	assert.False(t, buffer.rotated)
+
+	data, meta, err = buffer.nextChunk()
+	assert.NoError(t, err)
+	wantData := append([]byte{}, replication.BinLogFileHeader...)
+	for _, e := range events[2:8] {
+		wantData = append(wantData, e.RawData...)
+	}
+	assert.Equal(t, wantData, data, "only committed transactions should be included")
+	assert.Equal(t, 1, meta.Chunks)
+	assert.Equal(t, uint32(350), meta.LogPosition)
+	assert.Equal(t, "0-10-1", meta.FirstGtid.String())
+	assert.Equal(t, "0-10-2", meta.LastGtid.String())
+
+	events = []*replication.BinlogEvent{
+		testEvent(replication.QUERY_EVENT, 0, 500, &replication.QueryEvent{Query: []byte("COMMIT")}),
+		testEvent(replication.ROTATE_EVENT, 0, 550, &replication.RotateEvent{}),
+	}
+	for _, e := range events {
+		assert.NoError(t, buffer.addEvent(e))
+	}
+	assert.False(t, buffer.inTransaction)
+	assert.True(t, buffer.rotated)
+
+	data, meta, err = buffer.nextChunk()
+	assert.NoError(t, err)
+	wantData = nil
+	for _, e := range append([]*replication.BinlogEvent{
+		testGtidEvent(3, 400, false),
+		testEvent(replication.QUERY_EVENT, 0, 450, &replication.QueryEvent{Query: []byte("BEGIN")}),
+	}, events...) {
+		wantData = append(wantData, e.RawData...)
+	}
+	assert.Equal(t, wantData, data)
+	assert.Equal(t, 2, meta.Chunks)
+	assert.Equal(t, uint32(550), meta.LogPosition)
+	assert.Equal(t, "0-10-1", meta.FirstGtid.String())
+	assert.Equal(t, "0-10-3", meta.LastGtid.String())
+	assert.True(t, meta.RotateEvent)
+}
+
+func TestChunkBufferResume(t *testing.T) {
+	previous := &BinlogMetadata{
+		ServerId:       10,
+		BinlogFilename: "mariadb-repl-bin.000001",
+		LogPosition:    350,
+		Chunks:         3,
+		FirstGtid:      mustParseGtid(t, "0-10-1"),
+		LastGtid:       mustParseGtid(t, "0-10-2"),
+	}
+	buffer := newChunkBuffer(previous.BinlogFilename, previous)
+	assert.Equal(t, uint32(350), buffer.position)
+
+	events := []*replication.BinlogEvent{
+		testEvent(replication.FORMAT_DESCRIPTION_EVENT, 0, 0, nil),
+		testGtidEvent(3, 400, false),
+		testEvent(replication.XID_EVENT, 0, 450, &replication.XIDEvent{}),
+	}
+	for _, e := range events {
+		assert.NoError(t, buffer.addEvent(e))
+	}
+
+	data, meta, err := buffer.nextChunk()
+	assert.NoError(t, err)
+	assert.Equal(t, append(append([]byte{}, events[1].RawData...), events[2].RawData...), data,
+		"binlog header and format description should not be included when resuming")
+	assert.Equal(t, 4, meta.Chunks)
+	assert.Equal(t, uint32(450), meta.LogPosition)
+	assert.Equal(t, "0-10-1", meta.FirstGtid.String())
+	assert.Equal(t, "0-10-3", meta.LastGtid.String())
+}
+
+func TestIsCommitQuery(t *testing.T) {
+	tests := []struct {
+		query string
+		want  bool
+	}{
+		{query: "COMMIT", want: true},
+		{query: "commit", want: true},
+		{query: "ROLLBACK", want: true},
+		{query: "XA COMMIT 'xid'", want: true},
+		{query: "BEGIN", want: false},
+		{query: "INSERT INTO t VALUES (1)", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			assert.Equal(t, tt.want, isCommitQuery([]byte(tt.query)))
+		})
+	}
+}
+
+func TestGetChunkObjectName(t *testing.T) {
+	name, err := getChunkObjectName(10, "mariadb-repl-bin.000001", 1, mariadbv1alpha1.CompressNone)
+	assert.NoError(t, err)
+	assert.Equal(t, "server-10/mariadb-repl-bin.000001.chunk-000001", name)
+
+	name, err = getChunkObjectName(10, "mariadb-repl-bin.000001", 12, mariadbv1alpha1.CompressGzip)
+	assert.NoError(t, err)
+	assert.Equal(t, "server-10/mariadb-repl-bin.000001.chunk-000012.gz", name)
+}
+
+func testEvent(eventType replication.EventType, flags uint16, logPos uint32, event replication.Event) *replication.BinlogEvent {
+	header := &replication.EventHeader{
+		Timestamp: uint32(time.Date(2026, 1, 1, 0, 0, int(logPos), 0, time.UTC).Unix()),
+		EventType: eventType,
+		ServerID:  10,
+		LogPos:    logPos,
+		Flags:     flags,
+	}
+	raw := make([]byte, replication.EventHeaderSize)
+	raw[4] = byte(eventType)
+	binary.LittleEndian.PutUint32(raw[13:], logPos)
+	return &replication.BinlogEvent{
+		RawData: raw,
+		Header:  header,
+		Event:   event,
+	}
+}
+
+func testGtidEvent(seq uint64, logPos uint32, standalone bool) *replication.BinlogEvent {
+	var flags byte
+	if standalone {
+		flags = replication.BINLOG_MARIADB_FL_STANDALONE
+	}
+	e := testEvent(replication.MARIADB_GTID_EVENT, 0, logPos, &replication.MariadbGTIDEvent{
+		GTID:  mysql.MariadbGTID{ServerID: 10, SequenceNumber: seq},
+		Flags: flags,
+	})
+	var body bytes.Buffer
+	_ = binary.Write(&body, binary.LittleEndian, seq)
+	_ = binary.Write(&body, binary.LittleEndian, uint32(0))
+	body.WriteByte(flags)
+	e.RawData = append(e.RawData, body.Bytes()...)
+	return e
+}
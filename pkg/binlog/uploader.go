@@ -1,14 +1,19 @@
 package binlog
 
 import (
+	"bytes"
 	"context"
+	"errors"
 	"fmt"
+	"io"
 	"os"
+	"path"
 	"path/filepath"
 	"time"
 
 	"github.com/go-logr/logr"
 	mariadbv1alpha1 "github.com/mariadb-operator/mariadb-operator/v26/api/v1alpha1"
+	"github.com/mariadb-operator/mariadb-operator/v26/pkg/backup"
 	"github.com/mariadb-operator/mariadb-operator/v26/pkg/compression"
 	"github.com/mariadb-operator/mariadb-operator/v26/pkg/interfaces"
 	"k8s.io/apimachinery/pkg/util/wait"
@@ -24,17 +29,31 @@ type Uploader struct {
 	dataDir       string
 	storageClient interfaces.BlobStorage
 	compressor    compression.Compressor
+	keyring       *compression.Keyring
 	logger        logr.Logger
 }
 
+type UploaderOpt func(*Uploader)
+
+// WithUploaderKeyring sets the keyring used to decrypt the objects archived with a compression other than the current one.
+func WithUploaderKeyring(keyring *compression.Keyring) UploaderOpt {
+	return func(u *Uploader) {
+		u.keyring = keyring
+	}
+}
+
 func NewUploader(dataDir string, storageClient interfaces.BlobStorage, compressor compression.Compressor,
-	logger logr.Logger) *Uploader {
-	return &Uploader{
+	logger logr.Logger, opts ...UploaderOpt) *Uploader {
+	uploader := &Uploader{
 		dataDir:       dataDir,
 		storageClient: storageClient,
 		compressor:    compressor,
 		logger:        logger,
 	}
+	for _, setOpt := range opts {
+		setOpt(uploader)
+	}
+	return uploader
 }
 
 func (u *Uploader) Upload(ctx context.Context, binlog string, mdb *mariadbv1alpha1.MariaDB,
@@ -48,6 +67,12 @@ func (u *Uploader) Upload(ctx context.Context, binlog string, mdb *mariadbv1alph
 	if err != nil {
 		return fmt.Errorf("error getting object name: %v", err)
 	}
+	return u.uploadFile(ctx, binlog, binlogFileName, objectName, meta, mdb, pitr)
+}
+
+// uploadFile compresses and uploads a binlog file along with its manifest, unless the object already exists.
+func (u *Uploader) uploadFile(ctx context.Context, binlog, binlogFileName, objectName string, meta *BinlogMetadata,
+	mdb *mariadbv1alpha1.MariaDB, pitr *mariadbv1alpha1.PointInTimeRecovery) error {
 	binlogLogger := u.logger.WithValues(
 		"binlog", binlog,
 		"object", objectName,
@@ -91,6 +116,10 @@ func (u *Uploader) Upload(ctx context.Context, binlog string, mdb *mariadbv1alph
 	if err != nil {
 		return fmt.Errorf("error stat temp file %s: %v", tmpFile.Name(), err)
 	}
+	manifest, err := newManifest(objectName, tmpFile, binlogFile, meta, mdb, pitr)
+	if err != nil {
+		return fmt.Errorf("error getting binlog manifest: %v", err)
+	}
 
 	uploadIsRetriable := func(err error) bool {
 		if ctx.Err() != nil {
@@ -108,18 +137,196 @@ func (u *Uploader) Upload(ctx context.Context, binlog string, mdb *mariadbv1alph
 		return fmt.Errorf("error uploading binlog %s: %v", binlog, err)
 	}
 
+	manifestBytes, err := manifest.Marshal()
+	if err != nil {
+		return err
+	}
+	manifestName := backup.ManifestFileName(objectName)
+	if err := retry.OnError(uploadBackoff, uploadIsRetriable, func() error {
+		return u.storageClient.PutObjectWithOptions(ctx, manifestName, bytes.NewReader(manifestBytes), int64(len(manifestBytes)))
+	}); err != nil {
+		return fmt.Errorf("error uploading binlog manifest %s: %v", manifestName, err)
+	}
+
 	binlogLogger.Info("Binary log uploaded", "total-time", time.Since(startTime).String())
 	return nil
 }
 
-func getObjectName(binlog string, meta *BinlogMetadata, pitr *mariadbv1alpha1.PointInTimeRecovery) (string, error) {
-	name := binlog
-	if pitr.Spec.Compression != "" && pitr.Spec.Compression != mariadbv1alpha1.CompressNone {
-		ext, err := pitr.Spec.Compression.Extension()
+// newManifest returns the Manifest of a compressed binlog, which is verified before replaying the binlog.
+func newManifest(objectName string, compressedFile io.ReadSeeker, binlogFile *os.File, meta *BinlogMetadata,
+	mdb *mariadbv1alpha1.MariaDB, pitr *mariadbv1alpha1.PointInTimeRecovery) (*backup.Manifest, error) {
+	binlogStat, err := binlogFile.Stat()
+	if err != nil {
+		return nil, fmt.Errorf("error stat binlog file %s: %v", binlogFile.Name(), err)
+	}
+	if _, err := compressedFile.Seek(0, io.SeekStart); err != nil {
+		return nil, fmt.Errorf("error seeking compressed binlog: %v", err)
+	}
+	opts := []backup.ManifestOpt{
+		backup.WithManifestUncompressedSize(binlogStat.Size()),
+		backup.WithManifestCompression(pitr.Spec.Compression),
+		backup.WithManifestServerVersion(meta.ServerVersion),
+		backup.WithManifestMariaDB(mdb.Name),
+	}
+	if meta.LastGtid != nil {
+		opts = append(opts, backup.WithManifestGTID(meta.LastGtid.String()))
+	}
+	return backup.NewManifest(path.Base(objectName), compressedFile, opts...)
+}
+
+// UploadChunk compresses and uploads a chunk of a streamed binary log.
+func (u *Uploader) UploadChunk(ctx context.Context, serverId uint32, binlog string, chunk int, data []byte,
+	pitr *mariadbv1alpha1.PointInTimeRecovery) error {
+	objectName, err := getChunkObjectName(serverId, binlog, chunk, pitr.Spec.Compression)
+	if err != nil {
+		return fmt.Errorf("error getting chunk object name: %v", err)
+	}
+	u.logger.V(1).Info("Uploading binary log chunk", "binlog", binlog, "object", objectName, "size", len(data))
+
+	var compressed bytes.Buffer
+	if err := u.compressor.Compress(ctx, &compressed, bytes.NewReader(data)); err != nil {
+		return fmt.Errorf("error compressing binlog chunk: %v", err)
+	}
+	uploadIsRetriable := func(err error) bool {
+		if ctx.Err() != nil {
+			return false
+		}
+		return err != nil
+	}
+	if err := retry.OnError(uploadBackoff, uploadIsRetriable, func() error {
+		return u.storageClient.PutObjectWithOptions(ctx, objectName, bytes.NewReader(compressed.Bytes()), int64(compressed.Len()))
+	}); err != nil {
+		return fmt.Errorf("error uploading binlog chunk %s: %v", objectName, err)
+	}
+	return nil
+}
+
+// RemoveChunks removes the chunks of a streamed binary log once it has been archived.
+// Chunks are numbered sequentially, the ones left behind by interrupted streams are also removed.
+func (u *Uploader) RemoveChunks(ctx context.Context, meta *BinlogMetadata, pitr *mariadbv1alpha1.PointInTimeRecovery) error {
+	for chunk := 1; ; chunk++ {
+		objectName, err := getChunkObjectName(meta.ServerId, meta.BinlogFilename, chunk, pitr.Spec.Compression)
+		if err != nil {
+			return fmt.Errorf("error getting chunk object name: %v", err)
+		}
+		exists, err := u.storageClient.Exists(ctx, objectName)
 		if err != nil {
-			return "", fmt.Errorf("error getting compression algorithm extension: %v", err)
+			return fmt.Errorf("error determining if binlog chunk exists: %v", err)
 		}
-		name = fmt.Sprintf("%s.%s", name, ext)
+		if !exists {
+			if chunk > meta.Chunks {
+				return nil
+			}
+			continue
+		}
+		locked, err := u.storageClient.IsLocked(ctx, objectName)
+		if err != nil {
+			return fmt.Errorf("error determining if binlog chunk is locked: %v", err)
+		}
+		if locked {
+			u.logger.V(1).Info("Binary log chunk is locked. Skipping removal...", "object", objectName)
+			continue
+		}
+		if err := u.storageClient.RemoveWithOptions(ctx, objectName); err != nil {
+			return fmt.Errorf("error removing binlog chunk %s: %v", objectName, err)
+		}
+	}
+}
+
+// Remove removes an archived binlog along with its manifest. The binlog is looked up with every compression extension,
+// as it may have been archived with a different compression. It returns false when the binlog is locked or it cannot be found,
+// and therefore was not removed.
+func (u *Uploader) Remove(ctx context.Context, meta *BinlogMetadata, pitr *mariadbv1alpha1.PointInTimeRecovery) (bool, error) {
+	objectName, _, err := FindCompressedObject(ctx, meta.ObjectStoragePath(), pitr.Spec.Compression, u.storageClient)
+	if err != nil {
+		if errors.Is(err, ErrBinlogNotFound) {
+			u.logger.Info("Binary log not found in storage. Skipping removal...", "binlog", meta.ObjectStoragePath())
+			return false, nil
+		}
+		return false, err
+	}
+	locked, err := u.storageClient.IsLocked(ctx, objectName)
+	if err != nil {
+		return false, fmt.Errorf("error determining if binlog is locked: %v", err)
+	}
+	if locked {
+		u.logger.V(1).Info("Binary log is locked. Skipping removal...", "object", objectName)
+		return false, nil
+	}
+	for _, name := range []string{backup.ManifestFileName(objectName), objectName} {
+		exists, err := u.storageClient.Exists(ctx, name)
+		if err != nil {
+			return false, fmt.Errorf("error determining if %s exists: %v", name, err)
+		}
+		if !exists {
+			continue
+		}
+		if err := u.storageClient.RemoveWithOptions(ctx, name); err != nil {
+			return false, fmt.Errorf("error removing %s: %v", name, err)
+		}
+	}
+	return true, nil
+}
+
+// ErrBinlogNotFound is returned by FindCompressedObject when the object is not found with any of the compression extensions.
+var ErrBinlogNotFound = errors.New("binlog file not found")
+
+// FindCompressedObject finds an archived object in storage, trying the expected compression algorithm first.
+// Other algorithms are also considered, as the object may have been archived with a different compression.
+// It returns the name of the object and the compression algorithm it was archived with.
+func FindCompressedObject(ctx context.Context, name string, calg mariadbv1alpha1.CompressAlgorithm,
+	storageClient interfaces.BlobStorage) (string, mariadbv1alpha1.CompressAlgorithm, error) {
+	if calg == "" {
+		calg = mariadbv1alpha1.CompressNone
+	}
+	algorithms := []mariadbv1alpha1.CompressAlgorithm{calg}
+	for _, alg := range []mariadbv1alpha1.CompressAlgorithm{
+		mariadbv1alpha1.CompressNone,
+		mariadbv1alpha1.CompressGzip,
+		mariadbv1alpha1.CompressBzip2,
+		mariadbv1alpha1.CompressZstd,
+		mariadbv1alpha1.CompressLz4,
+	} {
+		if alg != calg {
+			algorithms = append(algorithms, alg)
+		}
+	}
+
+	for _, alg := range algorithms {
+		compressedName, err := withCompressionExtension(name, alg)
+		if err != nil {
+			return "", "", err
+		}
+		exists, err := storageClient.Exists(ctx, compressedName)
+		if err != nil {
+			return "", "", fmt.Errorf("error determining if %s exists: %v", compressedName, err)
+		}
+		if exists {
+			return compressedName, alg, nil
+		}
+	}
+	return "", "", fmt.Errorf("%w: %s", ErrBinlogNotFound, name)
+}
+
+func getObjectName(binlog string, meta *BinlogMetadata, pitr *mariadbv1alpha1.PointInTimeRecovery) (string, error) {
+	name, err := withCompressionExtension(binlog, pitr.Spec.Compression)
+	if err != nil {
+		return "", err
 	}
 	return fmt.Sprintf("server-%d/%s", meta.ServerId, name), nil
 }
+
+func getChunkObjectName(serverId uint32, binlog string, chunk int, calg mariadbv1alpha1.CompressAlgorithm) (string, error) {
+	return withCompressionExtension(ChunkObjectStoragePath(serverId, binlog, chunk), calg)
+}
+
+func withCompressionExtension(name string, calg mariadbv1alpha1.CompressAlgorithm) (string, error) {
+	if calg == "" || calg == mariadbv1alpha1.CompressNone {
+		return name, nil
+	}
+	ext, err := calg.Extension()
+	if err != nil {
+		return "", fmt.Errorf("error getting compression algorithm extension: %v", err)
+	}
+	return fmt.Sprintf("%s.%s", name, ext), nil
+}
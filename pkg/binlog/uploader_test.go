@@ -0,0 +1,113 @@
+package binlog
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/go-logr/logr"
+	mariadbv1alpha1 "github.com/mariadb-operator/mariadb-operator/v26/api/v1alpha1"
+	"github.com/mariadb-operator/mariadb-operator/v26/pkg/backup"
+	"github.com/mariadb-operator/mariadb-operator/v26/pkg/compression"
+	"github.com/mariadb-operator/mariadb-operator/v26/pkg/filesystem"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindCompressedObject(t *testing.T) {
+	ctx := context.Background()
+	storageClient, err := filesystem.NewFileSystemClient(t.TempDir(), t.TempDir(), filesystem.WithAllowNestedPrefixes(true))
+	assert.NoError(t, err)
+
+	content := []byte("binlog")
+	for _, name := range []string{"server-10/mariadb-repl-bin.000001.gz", "server-10/mariadb-repl-bin.000002"} {
+		assert.NoError(t, storageClient.PutObjectWithOptions(ctx, name, bytes.NewReader(content), int64(len(content))))
+	}
+
+	tests := []struct {
+		name     string
+		object   string
+		calg     mariadbv1alpha1.CompressAlgorithm
+		wantName string
+		wantCalg mariadbv1alpha1.CompressAlgorithm
+		wantErr  error
+	}{
+		{
+			name:     "expected compression",
+			object:   "server-10/mariadb-repl-bin.000001",
+			calg:     mariadbv1alpha1.CompressGzip,
+			wantName: "server-10/mariadb-repl-bin.000001.gz",
+			wantCalg: mariadbv1alpha1.CompressGzip,
+		},
+		{
+			name:     "different compression",
+			object:   "server-10/mariadb-repl-bin.000001",
+			calg:     mariadbv1alpha1.CompressZstd,
+			wantName: "server-10/mariadb-repl-bin.000001.gz",
+			wantCalg: mariadbv1alpha1.CompressGzip,
+		},
+		{
+			name:     "uncompressed",
+			object:   "server-10/mariadb-repl-bin.000002",
+			calg:     mariadbv1alpha1.CompressAlgorithm(""),
+			wantName: "server-10/mariadb-repl-bin.000002",
+			wantCalg: mariadbv1alpha1.CompressNone,
+		},
+		{
+			name:    "not found",
+			object:  "server-10/mariadb-repl-bin.000003",
+			calg:    mariadbv1alpha1.CompressGzip,
+			wantErr: ErrBinlogNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			name, calg, err := FindCompressedObject(ctx, tt.object, tt.calg, storageClient)
+			if tt.wantErr != nil {
+				assert.True(t, errors.Is(err, tt.wantErr), "unexpected error: %v", err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantName, name)
+			assert.Equal(t, tt.wantCalg, calg)
+		})
+	}
+}
+
+func TestUploaderRemove(t *testing.T) {
+	ctx := context.Background()
+	storageClient, err := filesystem.NewFileSystemClient(t.TempDir(), t.TempDir(), filesystem.WithAllowNestedPrefixes(true))
+	assert.NoError(t, err)
+	compressor, err := compression.NewCompressor(mariadbv1alpha1.CompressZstd)
+	assert.NoError(t, err)
+	uploader := NewUploader(t.TempDir(), storageClient, compressor, logr.Discard())
+	// the compression has been changed after archiving the binlog with gzip.
+	pitr := &mariadbv1alpha1.PointInTimeRecovery{
+		Spec: mariadbv1alpha1.PointInTimeRecoverySpec{
+			Compression: mariadbv1alpha1.CompressZstd,
+		},
+	}
+	meta := &BinlogMetadata{
+		ServerId:       10,
+		BinlogFilename: "mariadb-repl-bin.000001",
+	}
+
+	objectName := "server-10/mariadb-repl-bin.000001.gz"
+	content := []byte("binlog")
+	for _, name := range []string{objectName, backup.ManifestFileName(objectName)} {
+		assert.NoError(t, storageClient.PutObjectWithOptions(ctx, name, bytes.NewReader(content), int64(len(content))))
+	}
+
+	removed, err := uploader.Remove(ctx, meta, pitr)
+	assert.NoError(t, err)
+	assert.True(t, removed)
+	for _, name := range []string{objectName, backup.ManifestFileName(objectName)} {
+		exists, err := storageClient.Exists(ctx, name)
+		assert.NoError(t, err)
+		assert.False(t, exists, "%s should have been removed", name)
+	}
+
+	removed, err = uploader.Remove(ctx, meta, pitr)
+	assert.NoError(t, err)
+	assert.False(t, removed, "missing binlogs should be kept in the index")
+}
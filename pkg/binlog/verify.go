@@ -0,0 +1,249 @@
+package binlog
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/go-logr/logr"
+	mariadbv1alpha1 "github.com/mariadb-operator/mariadb-operator/v26/api/v1alpha1"
+	"github.com/mariadb-operator/mariadb-operator/v26/pkg/backup"
+	mariadbrepl "github.com/mariadb-operator/mariadb-operator/v26/pkg/replication"
+)
+
+// BinlogObject is a binlog archived in the storage.
+type BinlogObject struct {
+	ServerId       uint32
+	BinlogFilename string
+	ObjectName     string
+}
+
+// ObjectStoragePath returns the path of the binlog in the storage, without compression extension.
+func (o *BinlogObject) ObjectStoragePath() string {
+	return fmt.Sprintf("%s/%s", serverKey(o.ServerId), o.BinlogFilename)
+}
+
+// ListBinlogObjects returns the archived binlogs among the given object names, which are relative to the storage prefix,
+// sorted by server and binlog number. Other objects, such as the binlog index, manifests and streamed chunks, are ignored.
+func ListBinlogObjects(objectNames []string) []BinlogObject {
+	var objects []BinlogObject
+	for _, name := range objectNames {
+		if object, ok := parseBinlogObjectName(name); ok {
+			objects = append(objects, *object)
+		}
+	}
+	sort.SliceStable(objects, func(i, j int) bool {
+		if objects[i].ServerId != objects[j].ServerId {
+			return objects[i].ServerId < objects[j].ServerId
+		}
+		return binlogLess(objects[i].BinlogFilename, objects[j].BinlogFilename)
+	})
+	return objects
+}
+
+func parseBinlogObjectName(objectName string) (*BinlogObject, bool) {
+	if backup.IsManifestFile(objectName) || strings.Contains(objectName, ".chunk-") {
+		return nil, false
+	}
+	dir, file := path.Split(objectName)
+	serverIdRaw, ok := strings.CutPrefix(strings.TrimSuffix(dir, "/"), "server-")
+	if !ok {
+		return nil, false
+	}
+	serverId, err := strconv.ParseUint(serverIdRaw, 10, 32)
+	if err != nil {
+		return nil, false
+	}
+	binlog := file
+	for _, calg := range []mariadbv1alpha1.CompressAlgorithm{
+		mariadbv1alpha1.CompressGzip,
+		mariadbv1alpha1.CompressBzip2,
+		mariadbv1alpha1.CompressZstd,
+		mariadbv1alpha1.CompressLz4,
+	} {
+		ext, err := calg.Extension()
+		if err != nil {
+			continue
+		}
+		if trimmed, ok := strings.CutSuffix(file, "."+ext); ok {
+			binlog = trimmed
+			break
+		}
+	}
+	if _, err := ParseBinlogNum(binlog); err != nil {
+		return nil, false
+	}
+	return &BinlogObject{
+		ServerId:       uint32(serverId),
+		BinlogFilename: binlog,
+		ObjectName:     objectName,
+	}, true
+}
+
+// TimelineOverlap is a range of GTIDs present in the binary logs archived by different servers.
+type TimelineOverlap struct {
+	ServerId      uint32 `json:"serverId"`
+	Binlog        string `json:"binlog"`
+	OtherServerId uint32 `json:"otherServerId"`
+	OtherBinlog   string `json:"otherBinlog"`
+	FirstGtid     string `json:"firstGtid"`
+	LastGtid      string `json:"lastGtid"`
+}
+
+// IndexReport is the result of verifying the binlog index against the binlogs archived in the storage.
+type IndexReport struct {
+	// Gaps are the GTID gaps that cannot be bridged with the binary logs of other servers.
+	Gaps []TimelineGap `json:"gaps"`
+	// Overlaps are the GTID ranges archived by more than one server, which are expected after a primary switchover.
+	Overlaps []TimelineOverlap `json:"overlaps"`
+	// MissingEndEvents are the binlogs that are not the last one of their server and have neither a rotate nor a stop event.
+	MissingEndEvents []string `json:"missingEndEvents"`
+	// MissingBinlogs are the binlogs present in the index but not in the storage.
+	MissingBinlogs []string `json:"missingBinlogs"`
+	// UnindexedBinlogs are the binlogs present in the storage but not in the index.
+	UnindexedBinlogs []string `json:"unindexedBinlogs"`
+}
+
+// IsDegraded determines whether the report contains issues that may prevent point-in-time recovery.
+func (r *IndexReport) IsDegraded() bool {
+	return len(r.Gaps) > 0 || len(r.MissingEndEvents) > 0 || len(r.MissingBinlogs) > 0 || len(r.UnindexedBinlogs) > 0
+}
+
+// Summary returns a human readable summary of the issues in the report.
+func (r *IndexReport) Summary() string {
+	if !r.IsDegraded() {
+		return "Binlog index is consistent"
+	}
+	return fmt.Sprintf(
+		"Binlog index is degraded: %d gaps, %d missing end events, %d missing binlogs, %d unindexed binlogs",
+		len(r.Gaps),
+		len(r.MissingEndEvents),
+		len(r.MissingBinlogs),
+		len(r.UnindexedBinlogs),
+	)
+}
+
+// Verify verifies the binlog index against the binlogs archived in the storage, reporting GTID gaps and overlaps between servers,
+// binlogs without end events and inconsistencies between the index and the storage.
+func (b *BinlogIndex) Verify(objects []BinlogObject, logger logr.Logger) *IndexReport {
+	report := &IndexReport{
+		Gaps: b.Gaps(logger),
+	}
+
+	indexed := make(map[string]bool)
+	for _, key := range b.serverKeys() {
+		binlogs := b.sortedBinlogs(key)
+		for i, meta := range binlogs {
+			if meta.Chunks > 0 {
+				continue
+			}
+			indexed[meta.ObjectStoragePath()] = true
+			if i < len(binlogs)-1 && !meta.RotateEvent && !meta.StopEvent {
+				report.MissingEndEvents = append(report.MissingEndEvents, meta.ObjectStoragePath())
+			}
+		}
+	}
+
+	stored := make(map[string]bool)
+	for _, object := range objects {
+		stored[object.ObjectStoragePath()] = true
+		if !indexed[object.ObjectStoragePath()] {
+			report.UnindexedBinlogs = append(report.UnindexedBinlogs, object.ObjectStoragePath())
+		}
+	}
+	for _, key := range b.serverKeys() {
+		for _, meta := range b.sortedBinlogs(key) {
+			if meta.Chunks == 0 && !stored[meta.ObjectStoragePath()] {
+				report.MissingBinlogs = append(report.MissingBinlogs, meta.ObjectStoragePath())
+			}
+		}
+	}
+
+	report.Overlaps = b.overlaps()
+	return report
+}
+
+func (b *BinlogIndex) overlaps() []TimelineOverlap {
+	var overlaps []TimelineOverlap
+	keys := b.serverKeys()
+	for i, key := range keys {
+		for _, otherKey := range keys[i+1:] {
+			for _, binlog := range b.Binlogs[key] {
+				for _, otherBinlog := range b.Binlogs[otherKey] {
+					if overlap := gtidOverlap(&binlog, &otherBinlog); overlap != nil {
+						overlaps = append(overlaps, *overlap)
+					}
+				}
+			}
+		}
+	}
+	return overlaps
+}
+
+// gtidOverlap returns the GTID range present in both binlogs, only considering binlogs within the same GTID domain.
+func gtidOverlap(binlog, otherBinlog *BinlogMetadata) *TimelineOverlap {
+	gtids := []*mariadbrepl.Gtid{binlog.FirstGtid, binlog.LastGtid, otherBinlog.FirstGtid, otherBinlog.LastGtid}
+	for _, gtid := range gtids {
+		if gtid == nil || gtid.DomainID != binlog.FirstGtid.DomainID {
+			return nil
+		}
+	}
+	firstGtid := maxGtid(binlog.FirstGtid, otherBinlog.FirstGtid)
+	lastGtid := minGtid(binlog.LastGtid, otherBinlog.LastGtid)
+	if firstGtid.SequenceID > lastGtid.SequenceID {
+		return nil
+	}
+	return &TimelineOverlap{
+		ServerId:      binlog.ServerId,
+		Binlog:        binlog.BinlogFilename,
+		OtherServerId: otherBinlog.ServerId,
+		OtherBinlog:   otherBinlog.BinlogFilename,
+		FirstGtid:     firstGtid.String(),
+		LastGtid:      lastGtid.String(),
+	}
+}
+
+func maxGtid(a, b *mariadbrepl.Gtid) *mariadbrepl.Gtid {
+	if a.SequenceID >= b.SequenceID {
+		return a
+	}
+	return b
+}
+
+func minGtid(a, b *mariadbrepl.Gtid) *mariadbrepl.Gtid {
+	if a.SequenceID <= b.SequenceID {
+		return a
+	}
+	return b
+}
+
+func (b *BinlogIndex) serverKeys() []string {
+	keys := make([]string, 0, len(b.Binlogs))
+	for key := range b.Binlogs {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func (b *BinlogIndex) sortedBinlogs(key string) []BinlogMetadata {
+	binlogs := append([]BinlogMetadata{}, b.Binlogs[key]...)
+	sort.SliceStable(binlogs, func(i, j int) bool {
+		return binlogLess(binlogs[i].BinlogFilename, binlogs[j].BinlogFilename)
+	})
+	return binlogs
+}
+
+func binlogLess(binlog, other string) bool {
+	num, err := ParseBinlogNum(binlog)
+	if err != nil {
+		return binlog < other
+	}
+	otherNum, err := ParseBinlogNum(other)
+	if err != nil {
+		return binlog < other
+	}
+	return num.LessThan(otherNum)
+}
@@ -0,0 +1,100 @@
+package binlog
+
+import (
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestListBinlogObjects(t *testing.T) {
+	objects := ListBinlogObjects([]string{
+		"index.yaml",
+		"server-11/mariadb-repl-bin.000001.gz",
+		"server-10/mariadb-repl-bin.000010.gz",
+		"server-10/mariadb-repl-bin.000010.gz.manifest.json",
+		"server-10/mariadb-repl-bin.000002.gz",
+		"server-10/mariadb-repl-bin.000011.chunk-000001.gz",
+		"server-10/mariadb-repl-bin.000003",
+		"server-foo/mariadb-repl-bin.000001",
+		"mariadb-repl-bin.000001",
+	})
+	assert.Equal(t, []BinlogObject{
+		{ServerId: 10, BinlogFilename: "mariadb-repl-bin.000002", ObjectName: "server-10/mariadb-repl-bin.000002.gz"},
+		{ServerId: 10, BinlogFilename: "mariadb-repl-bin.000003", ObjectName: "server-10/mariadb-repl-bin.000003"},
+		{ServerId: 10, BinlogFilename: "mariadb-repl-bin.000010", ObjectName: "server-10/mariadb-repl-bin.000010.gz"},
+		{ServerId: 11, BinlogFilename: "mariadb-repl-bin.000001", ObjectName: "server-11/mariadb-repl-bin.000001.gz"},
+	}, objects)
+}
+
+func TestVerifyIndex(t *testing.T) {
+	base := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	binlog := func(serverId uint32, filename, first, last string, hour int, rotate bool) BinlogMetadata {
+		return BinlogMetadata{
+			ServerId:       serverId,
+			BinlogFilename: filename,
+			FirstTime:      metav1.NewTime(base.Add(time.Duration(hour) * time.Hour)),
+			LastTime:       metav1.NewTime(base.Add(time.Duration(hour)*time.Hour + 59*time.Minute)),
+			FirstGtid:      mustParseGtid(t, first),
+			LastGtid:       mustParseGtid(t, last),
+			RotateEvent:    rotate,
+		}
+	}
+	object := func(serverId uint32, filename string) BinlogObject {
+		return BinlogObject{ServerId: serverId, BinlogFilename: filename, ObjectName: serverKey(serverId) + "/" + filename}
+	}
+
+	t.Run("consistent", func(t *testing.T) {
+		index := NewBinlogIndex()
+		index.Add(10, binlog(10, "mariadb-repl-bin.000001", "0-10-1", "0-10-10", 0, true))
+		index.Add(10, binlog(10, "mariadb-repl-bin.000002", "0-10-11", "0-10-20", 1, true))
+		index.Add(10, BinlogMetadata{ServerId: 10, BinlogFilename: "mariadb-repl-bin.000003", Chunks: 2})
+
+		report := index.Verify([]BinlogObject{
+			object(10, "mariadb-repl-bin.000001"),
+			object(10, "mariadb-repl-bin.000002"),
+		}, logr.Discard())
+		assert.False(t, report.IsDegraded())
+		assert.Empty(t, report.Overlaps)
+		assert.Equal(t, "Binlog index is consistent", report.Summary())
+	})
+
+	t.Run("degraded", func(t *testing.T) {
+		index := NewBinlogIndex()
+		index.Add(10, binlog(10, "mariadb-repl-bin.000001", "0-10-1", "0-10-10", 0, false))
+		index.Add(10, binlog(10, "mariadb-repl-bin.000002", "0-10-11", "0-10-20", 1, true))
+		index.Add(10, binlog(10, "mariadb-repl-bin.000003", "0-10-31", "0-10-40", 3, true))
+		index.Add(11, binlog(11, "mariadb-repl-bin.000001", "0-10-15", "0-11-25", 2, false))
+
+		report := index.Verify([]BinlogObject{
+			object(10, "mariadb-repl-bin.000001"),
+			object(10, "mariadb-repl-bin.000002"),
+			object(10, "mariadb-repl-bin.000004"),
+			object(11, "mariadb-repl-bin.000001"),
+		}, logr.Discard())
+
+		assert.True(t, report.IsDegraded())
+		assert.Len(t, report.Gaps, 1)
+		assert.Equal(t, "mariadb-repl-bin.000002", report.Gaps[0].Binlog)
+		assert.Equal(t, "mariadb-repl-bin.000003", report.Gaps[0].NextBinlog)
+		assert.Equal(t, []TimelineOverlap{
+			{
+				ServerId:      10,
+				Binlog:        "mariadb-repl-bin.000002",
+				OtherServerId: 11,
+				OtherBinlog:   "mariadb-repl-bin.000001",
+				FirstGtid:     "0-10-15",
+				LastGtid:      "0-10-20",
+			},
+		}, report.Overlaps)
+		assert.Equal(t, []string{"server-10/mariadb-repl-bin.000001"}, report.MissingEndEvents)
+		assert.Equal(t, []string{"server-10/mariadb-repl-bin.000003"}, report.MissingBinlogs)
+		assert.Equal(t, []string{"server-10/mariadb-repl-bin.000004"}, report.UnindexedBinlogs)
+		assert.Equal(t,
+			"Binlog index is degraded: 1 gaps, 1 missing end events, 1 missing binlogs, 1 unindexed binlogs",
+			report.Summary(),
+		)
+	})
+}
@@ -0,0 +1,125 @@
+package binlog
+
+import (
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/go-logr/logr"
+	mariadbrepl "github.com/mariadb-operator/mariadb-operator/v26/pkg/replication"
+)
+
+// RestorePoint is a backup on top of which binary logs can be replayed.
+type RestorePoint struct {
+	FileName string
+	Time     time.Time
+	Gtid     *mariadbrepl.Gtid
+}
+
+// RecoverableWindow is a time range that can be recovered by restoring a backup and replaying binary logs on top of it.
+type RecoverableWindow struct {
+	Backup    string    `json:"backup"`
+	StartTime time.Time `json:"startTime"`
+	EndTime   time.Time `json:"endTime"`
+}
+
+// TimelineGap is a range of GTIDs missing in the binary logs archived by a server, which cannot be replayed.
+type TimelineGap struct {
+	ServerId   uint32    `json:"serverId"`
+	Binlog     string    `json:"binlog"`
+	NextBinlog string    `json:"nextBinlog"`
+	LastGtid   string    `json:"lastGtid"`
+	NextGtid   string    `json:"nextGtid"`
+	StartTime  time.Time `json:"startTime"`
+	EndTime    time.Time `json:"endTime"`
+}
+
+// RecoverableWindows returns the time ranges that can be recovered from each restore point, up until the given time.
+// Restore points without a GTID or without binary logs to be replayed are skipped.
+func (b *BinlogIndex) RecoverableWindows(restorePoints []RestorePoint, until time.Time, logger logr.Logger) []RecoverableWindow {
+	var windows []RecoverableWindow
+	for _, point := range restorePoints {
+		if point.Gtid == nil {
+			logger.V(1).Info("Restore point does not have a GTID. Skipping...", "backup", point.FileName)
+			continue
+		}
+		binlogs, err := b.BuildTimeline(point.Gtid, until, false, logger.WithName("binlog-timeline").V(1))
+		if err != nil {
+			if !errors.Is(err, ErrNoBinlogs) {
+				logger.Error(err, "Error building binlog timeline. Skipping...", "backup", point.FileName)
+			}
+			continue
+		}
+		endTime := binlogs[len(binlogs)-1].LastTime.UTC()
+		if endTime.Before(point.Time) {
+			continue
+		}
+		windows = append(windows, RecoverableWindow{
+			Backup:    point.FileName,
+			StartTime: point.Time.UTC(),
+			EndTime:   endTime,
+		})
+	}
+	sort.SliceStable(windows, func(i, j int) bool {
+		return windows[i].StartTime.Before(windows[j].StartTime)
+	})
+	return windows
+}
+
+// Gaps returns the GTID gaps in the binary logs archived by each server that cannot be bridged with the binary logs of other servers.
+// Binary logs after a gap cannot be replayed on top of a backup taken before it.
+func (b *BinlogIndex) Gaps(logger logr.Logger) []TimelineGap {
+	serverKeys := make([]string, 0, len(b.Binlogs))
+	for key := range b.Binlogs {
+		serverKeys = append(serverKeys, key)
+	}
+	sort.Strings(serverKeys)
+
+	var gaps []TimelineGap
+	for _, key := range serverKeys {
+		var lastBinlog *BinlogMetadata
+		for _, binlog := range b.Binlogs[key] {
+			// only binlogs with GTID events are considered
+			if binlog.FirstGtid == nil || binlog.LastGtid == nil {
+				continue
+			}
+			if lastBinlog != nil {
+				gap, err := b.gap(lastBinlog, &binlog, key, logger)
+				if err != nil {
+					logger.Error(err, "Error determining GTID gap. Skipping...", "binlog", binlog.BinlogFilename)
+				}
+				if gap != nil {
+					gaps = append(gaps, *gap)
+				}
+			}
+			lastBinlog = &binlog
+		}
+	}
+	return gaps
+}
+
+func (b *BinlogIndex) gap(lastBinlog, nextBinlog *BinlogMetadata, currentServer string, logger logr.Logger) (*TimelineGap, error) {
+	gtidGap, err := hasGtidGap(lastBinlog, nextBinlog)
+	if err != nil {
+		return nil, err
+	}
+	if !gtidGap {
+		return nil, nil
+	}
+	nextGtid, err := b.findNextGtidInOtherServer(lastBinlog, currentServer, nextBinlog.FirstTime.Time, logger.V(1))
+	if err != nil {
+		return nil, err
+	}
+	if nextGtid != nil {
+		return nil, nil
+	}
+	return &TimelineGap{
+		ServerId:   nextBinlog.ServerId,
+		Binlog:     lastBinlog.BinlogFilename,
+		NextBinlog: nextBinlog.BinlogFilename,
+		LastGtid:   lastBinlog.LastGtid.String(),
+		NextGtid:   nextBinlog.FirstGtid.String(),
+		StartTime:  lastBinlog.LastTime.UTC(),
+		EndTime:    nextBinlog.FirstTime.UTC(),
+	}, nil
+}
@@ -0,0 +1,140 @@
+package binlog
+
+import (
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRecoverableWindows(t *testing.T) {
+	tests := []struct {
+		name          string
+		indexFile     *BinlogIndex
+		restorePoints []RestorePoint
+		until         string
+		wantWindows   []RecoverableWindow
+	}{
+		{
+			name:      "no restore points",
+			indexFile: mustParseTestFile(t, "multiple-binlogs.yaml"),
+		},
+		{
+			name:      "restore point without GTID",
+			indexFile: mustParseTestFile(t, "multiple-binlogs.yaml"),
+			restorePoints: []RestorePoint{
+				{
+					FileName: "physicalbackup-20260120110600.xb",
+					Time:     mustParseDate(t, "2026-01-20T11:06:00Z"),
+				},
+			},
+		},
+		{
+			name:      "restore point without binlogs",
+			indexFile: mustParseTestFile(t, "multiple-binlogs.yaml"),
+			restorePoints: []RestorePoint{
+				{
+					FileName: "physicalbackup-20260120110600.xb",
+					Time:     mustParseDate(t, "2026-01-20T11:06:00Z"),
+					Gtid:     mustParseGtid(t, "0-20-1"),
+				},
+			},
+		},
+		{
+			name:      "gap",
+			indexFile: mustParseTestFile(t, "gap.yaml"),
+			restorePoints: []RestorePoint{
+				{
+					FileName: "physicalbackup-20260204121600.xb",
+					Time:     mustParseDate(t, "2026-02-04T12:16:00Z"),
+					Gtid:     mustParseGtid(t, "0-10-55"),
+				},
+				{
+					FileName: "physicalbackup-20260204120000.xb",
+					Time:     mustParseDate(t, "2026-02-04T12:00:00Z"),
+					Gtid:     mustParseGtid(t, "0-10-1"),
+				},
+			},
+			wantWindows: []RecoverableWindow{
+				{
+					Backup:    "physicalbackup-20260204120000.xb",
+					StartTime: mustParseDate(t, "2026-02-04T12:00:00Z"),
+					EndTime:   mustParseDate(t, "2026-02-04T12:10:00Z"),
+				},
+				{
+					Backup:    "physicalbackup-20260204121600.xb",
+					StartTime: mustParseDate(t, "2026-02-04T12:16:00Z"),
+					EndTime:   mustParseDate(t, "2026-02-04T12:20:00Z"),
+				},
+			},
+		},
+		{
+			name:      "failover",
+			indexFile: mustParseTestFile(t, "failover-1205-1208.yaml"),
+			restorePoints: []RestorePoint{
+				{
+					FileName: "physicalbackup-20260204120250.xb",
+					Time:     mustParseDate(t, "2026-02-04T12:02:50Z"),
+					Gtid:     mustParseGtid(t, "0-10-1"),
+				},
+			},
+			until: "2026-02-04T12:06:39Z",
+			wantWindows: []RecoverableWindow{
+				{
+					Backup:    "physicalbackup-20260204120250.xb",
+					StartTime: mustParseDate(t, "2026-02-04T12:02:50Z"),
+					EndTime:   mustParseDate(t, "2026-02-04T12:06:39Z"),
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			until := "2026-02-04T13:00:00Z"
+			if tt.until != "" {
+				until = tt.until
+			}
+			windows := tt.indexFile.RecoverableWindows(tt.restorePoints, mustParseDate(t, until), logr.Discard())
+			assert.Equal(t, tt.wantWindows, windows)
+		})
+	}
+}
+
+func TestGaps(t *testing.T) {
+	tests := []struct {
+		name      string
+		indexFile *BinlogIndex
+		wantGaps  []TimelineGap
+	}{
+		{
+			name:      "no gaps",
+			indexFile: mustParseTestFile(t, "multiple-binlogs.yaml"),
+		},
+		{
+			name:      "failover",
+			indexFile: mustParseTestFile(t, "failover-1205-1208.yaml"),
+		},
+		{
+			name:      "gap",
+			indexFile: mustParseTestFile(t, "gap.yaml"),
+			wantGaps: []TimelineGap{
+				{
+					ServerId:   10,
+					Binlog:     "mariadb-repl-bin.000002",
+					NextBinlog: "mariadb-repl-bin.000004",
+					LastGtid:   "0-10-35",
+					NextGtid:   "0-10-53",
+					StartTime:  mustParseDate(t, "2026-02-04T12:10:00Z"),
+					EndTime:    mustParseDate(t, "2026-02-04T12:15:00Z"),
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.wantGaps, tt.indexFile.Gaps(logr.Discard()))
+		})
+	}
+}
@@ -0,0 +1,83 @@
+package builder
+
+import (
+	"fmt"
+	"time"
+
+	mariadbv1alpha1 "github.com/mariadb-operator/mariadb-operator/v26/api/v1alpha1"
+	metadata "github.com/mariadb-operator/mariadb-operator/v26/pkg/builder/metadata"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+// BuildBackupVerificationMariaDB builds the ephemeral MariaDB where a BackupVerification restores the backup.
+// The MariaDB is bootstrapped from the backup closest to the target recovery time, and it reuses the root credentials
+// of the MariaDB the backup was taken from, as they are restored along with the data.
+func (b *Builder) BuildBackupVerificationMariaDB(key types.NamespacedName, verification *mariadbv1alpha1.BackupVerification,
+	source *mariadbv1alpha1.MariaDB, targetRecoveryTime time.Time) (*mariadbv1alpha1.MariaDB, error) {
+	objMeta :=
+		metadata.NewMetadataBuilder(key).
+			WithMetadata(verification.Spec.InheritMetadata).
+			Build()
+	tpl := verification.Spec.MariaDB
+
+	image := tpl.Image
+	if image == "" {
+		image = source.Spec.Image
+	}
+	storage := mariadbv1alpha1.Storage{
+		Size:             source.Spec.Storage.Size,
+		StorageClassName: source.Spec.Storage.StorageClassName,
+	}
+	if tpl.Storage != nil {
+		storage = *tpl.Storage.DeepCopy()
+	}
+	storage.PVCRetentionPolicy = &mariadbv1alpha1.StatefulSetPersistentVolumeClaimRetentionPolicy{
+		WhenDeleted: mariadbv1alpha1.PersistentVolumeClaimRetentionPolicyDelete,
+		WhenScaled:  mariadbv1alpha1.PersistentVolumeClaimRetentionPolicyDelete,
+	}
+
+	bootstrapFrom := &mariadbv1alpha1.BootstrapFrom{
+		TargetRecoveryTime: &metav1.Time{Time: targetRecoveryTime},
+		RestoreJob:         tpl.RestoreJob,
+	}
+	if verification.Spec.PointInTimeRecoveryRef != nil {
+		bootstrapFrom.PointInTimeRecoveryRef = verification.Spec.PointInTimeRecoveryRef
+	} else {
+		bootstrapFrom.BackupRef = &mariadbv1alpha1.TypedLocalObjectReference{
+			Name: verification.Spec.BackupRef.Name,
+			Kind: verification.BackupKind(),
+		}
+	}
+
+	mariadb := &mariadbv1alpha1.MariaDB{
+		ObjectMeta: objMeta,
+		Spec: mariadbv1alpha1.MariaDBSpec{
+			Image:           image,
+			ImagePullPolicy: source.Spec.ImagePullPolicy,
+			InheritMetadata: verification.Spec.InheritMetadata,
+			MyCnf:           source.Spec.MyCnf,
+			TimeZone:        source.Spec.TimeZone,
+			BootstrapFrom:   bootstrapFrom,
+			Storage:         storage,
+			Replicas:        1,
+		},
+	}
+	if tpl.Resources != nil {
+		mariadb.Spec.Resources = tpl.Resources
+	}
+	if source.IsRootPasswordEmpty() {
+		mariadb.Spec.RootEmptyPassword = ptr.To(true)
+	} else {
+		mariadb.Spec.RootPasswordSecretKeyRef = mariadbv1alpha1.GeneratedSecretKeyRef{
+			SecretKeySelector: source.Spec.RootPasswordSecretKeyRef.SecretKeySelector,
+		}
+	}
+
+	if err := controllerutil.SetControllerReference(verification, mariadb, b.scheme); err != nil {
+		return nil, fmt.Errorf("error setting controller reference to MariaDB: %v", err)
+	}
+	return mariadb, nil
+}
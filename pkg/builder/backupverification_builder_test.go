@@ -0,0 +1,186 @@
+package builder
+
+import (
+	"testing"
+	"time"
+
+	mariadbv1alpha1 "github.com/mariadb-operator/mariadb-operator/v26/api/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+)
+
+func TestBackupVerificationMariaDB(t *testing.T) {
+	builder := newDefaultTestBuilder(t)
+	key := types.NamespacedName{
+		Name: "verification-1735689600",
+	}
+	targetTime := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	source := &mariadbv1alpha1.MariaDB{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "mariadb",
+		},
+		Spec: mariadbv1alpha1.MariaDBSpec{
+			Image: "mariadb:11.4",
+			RootPasswordSecretKeyRef: mariadbv1alpha1.GeneratedSecretKeyRef{
+				SecretKeySelector: mariadbv1alpha1.SecretKeySelector{
+					LocalObjectReference: mariadbv1alpha1.LocalObjectReference{
+						Name: "mariadb-root",
+					},
+					Key: "password",
+				},
+				Generate: true,
+			},
+			Storage: mariadbv1alpha1.Storage{
+				Size: ptr.To(resource.MustParse("1Gi")),
+			},
+			Replicas: 3,
+		},
+	}
+
+	tests := []struct {
+		name              string
+		verification      *mariadbv1alpha1.BackupVerification
+		wantImage         string
+		wantStorageSize   string
+		wantBackupRef     *mariadbv1alpha1.TypedLocalObjectReference
+		wantPitrRef       *mariadbv1alpha1.LocalObjectReference
+		wantInheritLabels map[string]string
+	}{
+		{
+			name: "logical backup",
+			verification: &mariadbv1alpha1.BackupVerification{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "verification",
+				},
+				Spec: mariadbv1alpha1.BackupVerificationSpec{
+					BackupRef: mariadbv1alpha1.TypedLocalObjectReference{
+						Name: "backup",
+					},
+				},
+			},
+			wantImage:       "mariadb:11.4",
+			wantStorageSize: "1Gi",
+			wantBackupRef: &mariadbv1alpha1.TypedLocalObjectReference{
+				Name: "backup",
+				Kind: mariadbv1alpha1.BackupKind,
+			},
+		},
+		{
+			name: "physical backup with template",
+			verification: &mariadbv1alpha1.BackupVerification{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "verification",
+				},
+				Spec: mariadbv1alpha1.BackupVerificationSpec{
+					BackupRef: mariadbv1alpha1.TypedLocalObjectReference{
+						Name: "physicalbackup",
+						Kind: mariadbv1alpha1.PhysicalBackupKind,
+					},
+					MariaDB: mariadbv1alpha1.BackupVerificationMariaDB{
+						Image: "mariadb:11.8",
+						Storage: &mariadbv1alpha1.Storage{
+							Size: ptr.To(resource.MustParse("5Gi")),
+						},
+					},
+					InheritMetadata: &mariadbv1alpha1.Metadata{
+						Labels: map[string]string{
+							"database.myorg.io": "verification",
+						},
+					},
+				},
+			},
+			wantImage:       "mariadb:11.8",
+			wantStorageSize: "5Gi",
+			wantBackupRef: &mariadbv1alpha1.TypedLocalObjectReference{
+				Name: "physicalbackup",
+				Kind: mariadbv1alpha1.PhysicalBackupKind,
+			},
+			wantInheritLabels: map[string]string{
+				"database.myorg.io": "verification",
+			},
+		},
+		{
+			name: "point-in-time recovery",
+			verification: &mariadbv1alpha1.BackupVerification{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "verification",
+				},
+				Spec: mariadbv1alpha1.BackupVerificationSpec{
+					BackupRef: mariadbv1alpha1.TypedLocalObjectReference{
+						Name: "physicalbackup",
+						Kind: mariadbv1alpha1.PhysicalBackupKind,
+					},
+					PointInTimeRecoveryRef: &mariadbv1alpha1.LocalObjectReference{
+						Name: "pitr",
+					},
+				},
+			},
+			wantImage:       "mariadb:11.4",
+			wantStorageSize: "1Gi",
+			wantPitrRef: &mariadbv1alpha1.LocalObjectReference{
+				Name: "pitr",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mariadb, err := builder.BuildBackupVerificationMariaDB(key, tt.verification, source, targetTime)
+			if err != nil {
+				t.Fatalf("unexpected error building MariaDB: %v", err)
+			}
+			if mariadb.Spec.Image != tt.wantImage {
+				t.Errorf("unexpected image, expected: %v got: %v", tt.wantImage, mariadb.Spec.Image)
+			}
+			if mariadb.Spec.Replicas != 1 {
+				t.Errorf("unexpected replicas, expected: 1 got: %v", mariadb.Spec.Replicas)
+			}
+			if size := mariadb.Spec.Storage.Size.String(); size != tt.wantStorageSize {
+				t.Errorf("unexpected storage size, expected: %v got: %v", tt.wantStorageSize, size)
+			}
+			if policy := mariadb.Spec.Storage.PVCRetentionPolicy; policy == nil ||
+				policy.WhenDeleted != mariadbv1alpha1.PersistentVolumeClaimRetentionPolicyDelete {
+				t.Errorf("expected PVCs to be deleted along with the MariaDB, got: %v", policy)
+			}
+
+			rootPasswordRef := mariadb.Spec.RootPasswordSecretKeyRef
+			if rootPasswordRef.Generate {
+				t.Error("expected root password not to be generated")
+			}
+			if rootPasswordRef.SecretKeySelector != source.Spec.RootPasswordSecretKeyRef.SecretKeySelector {
+				t.Errorf("unexpected root password Secret, expected: %v got: %v",
+					source.Spec.RootPasswordSecretKeyRef.SecretKeySelector, rootPasswordRef.SecretKeySelector)
+			}
+
+			bootstrapFrom := ptr.Deref(mariadb.Spec.BootstrapFrom, mariadbv1alpha1.BootstrapFrom{})
+			if bootstrapFrom.TargetRecoveryTime == nil || !bootstrapFrom.TargetRecoveryTime.Time.Equal(targetTime) {
+				t.Errorf("unexpected target recovery time, expected: %v got: %v", targetTime, bootstrapFrom.TargetRecoveryTime)
+			}
+			if tt.wantBackupRef != nil {
+				if bootstrapFrom.BackupRef == nil || *bootstrapFrom.BackupRef != *tt.wantBackupRef {
+					t.Errorf("unexpected backupRef, expected: %v got: %v", tt.wantBackupRef, bootstrapFrom.BackupRef)
+				}
+			} else if bootstrapFrom.BackupRef != nil {
+				t.Errorf("expected backupRef to be nil, got: %v", bootstrapFrom.BackupRef)
+			}
+			if tt.wantPitrRef != nil {
+				if bootstrapFrom.PointInTimeRecoveryRef == nil || *bootstrapFrom.PointInTimeRecoveryRef != *tt.wantPitrRef {
+					t.Errorf("unexpected pointInTimeRecoveryRef, expected: %v got: %v", tt.wantPitrRef, bootstrapFrom.PointInTimeRecoveryRef)
+				}
+			} else if bootstrapFrom.PointInTimeRecoveryRef != nil {
+				t.Errorf("expected pointInTimeRecoveryRef to be nil, got: %v", bootstrapFrom.PointInTimeRecoveryRef)
+			}
+
+			for k, v := range tt.wantInheritLabels {
+				if mariadb.Labels[k] != v {
+					t.Errorf("expected label %s=%s, got: %v", k, v, mariadb.Labels)
+				}
+			}
+			if len(mariadb.OwnerReferences) != 1 || mariadb.OwnerReferences[0].Name != tt.verification.Name {
+				t.Errorf("expected MariaDB to be owned by the BackupVerification, got: %v", mariadb.OwnerReferences)
+			}
+		})
+	}
+}
@@ -52,6 +52,7 @@ func (b *Builder) BuildBackupJob(key types.NamespacedName, backup *mariadbv1alph
 	jobMeta :=
 		metadata.NewMetadataBuilder(key).
 			WithMetadata(backup.Spec.InheritMetadata).
+			WithAnnotations(backupHooksAnnotations(backup.Spec.Hooks, nil)).
 			Build()
 	podMeta :=
 		metadata.NewMetadataBuilder(key).
@@ -66,15 +67,24 @@ func (b *Builder) BuildBackupJob(key types.NamespacedName, backup *mariadbv1alph
 			batchBackupDirFullPath,
 		),
 		command.WithBackupContentType(mariadbv1alpha1.BackupContentTypeLogical),
+		command.WithMariaDBName(mariadb.GetName()),
+		command.WithBackupKey(client.ObjectKeyFromObject(backup)),
+		command.WithBackupMeta(isPointInTimeRecoveryEnabled(mariadb)),
 		command.WithCleanupTargetFile(backupShouldCleanupTargetFile(backup)),
 		command.WithMaxRetention(backup.Spec.MaxRetention.Duration),
+		command.WithRetention(backup.Spec.Retention),
 		command.WithCompression(backup.Spec.Compression),
+		command.WithCompressionLevel(backup.Spec.CompressionLevel),
+		command.WithParallelBackup(backup.Spec.Parallel),
+		command.WithThrottling(backup.Spec.Throttling),
 		command.WithUserEnv(batchUserEnv),
 		command.WithPasswordEnv(batchPasswordEnv),
 		command.WithLogLevel(backup.Spec.LogLevel),
 		command.WithExtraOpts(backup.Spec.Args),
 	}
 	cmdOpts = append(cmdOpts, s3Opts(backup.Spec.Storage.S3)...)
+	cmdOpts = append(cmdOpts, gcsOpts(backup.Spec.Storage.GCS)...)
+	cmdOpts = append(cmdOpts, secondaryStorageOpts(backup.Spec.SecondaryStorages)...)
 
 	cmd, err := command.NewBackupCommand(cmdOpts...)
 	if err != nil {
@@ -95,6 +105,9 @@ func (b *Builder) BuildBackupJob(key types.NamespacedName, backup *mariadbv1alph
 	}
 	// TODO: ABS support in logical backups
 	volumes, volumeMounts := jobBatchStorageVolumes(volume, backup.Spec.Storage.S3, nil, mariadb)
+	secondaryVolumes, secondaryVolumeMounts := secondaryStorageVolumes(backup.Spec.SecondaryStorages)
+	volumes = append(volumes, secondaryVolumes...)
+	volumeMounts = append(volumeMounts, secondaryVolumeMounts...)
 	affinity := ptr.Deref(backup.Spec.Affinity, mariadbv1alpha1.AffinityConfig{}).Affinity
 
 	mariadbContainer, err := b.jobMariadbContainer(
@@ -109,10 +122,14 @@ func (b *Builder) BuildBackupJob(key types.NamespacedName, backup *mariadbv1alph
 	if err != nil {
 		return nil, err
 	}
+	operatorEnv := append(s3Env(backup.Spec.Storage.S3), gcsEnv(backup.Spec.Storage.GCS)...)
+	operatorEnv = append(operatorEnv, encryptionEnv(backup.Spec.Encryption)...)
+	operatorEnv = append(operatorEnv, secondaryStorageEnv(backup.Spec.SecondaryStorages)...)
+
 	operatorContainer, err := b.jobMariadbOperatorContainer(
 		operatorCmd,
 		volumeMounts,
-		s3Env(backup.Spec.Storage.S3),
+		operatorEnv,
 		jobResources(backup.Spec.Resources),
 		mariadb,
 		b.env,
@@ -127,18 +144,35 @@ func (b *Builder) BuildBackupJob(key types.NamespacedName, backup *mariadbv1alph
 		return nil, err
 	}
 
+	initContainers := []corev1.Container{*mariadbContainer}
+	containers := []corev1.Container{*operatorContainer}
+	restartPolicy := backup.Spec.RestartPolicy
+	if backup.IsParallelEnabled() {
+		// mariadb-dump and mariadb-operator run concurrently to split the dump while it is being taken, exchanging it via a named pipe.
+		// The stream can not be resumed by restarting a single container, the Job retries the whole Pod instead.
+		containers = append(initContainers, containers...)
+		initContainers = nil
+		if restartPolicy == "" {
+			restartPolicy = corev1.RestartPolicyNever
+		}
+	}
+	if restartPolicy == "" {
+		restartPolicy = corev1.RestartPolicyOnFailure
+	}
+
 	job := &batchv1.Job{
 		ObjectMeta: jobMeta,
 		Spec: batchv1.JobSpec{
 			BackoffLimit: &backup.Spec.BackoffLimit,
+			Suspend:      backupHooksSuspend(backup.Spec.Hooks),
 			Template: corev1.PodTemplateSpec{
 				ObjectMeta: podMeta,
 				Spec: corev1.PodSpec{
-					RestartPolicy:      backup.Spec.RestartPolicy,
+					RestartPolicy:      restartPolicy,
 					ImagePullSecrets:   batchImagePullSecrets(mariadb, backup.Spec.ImagePullSecrets),
 					Volumes:            volumes,
-					InitContainers:     []corev1.Container{*mariadbContainer},
-					Containers:         []corev1.Container{*operatorContainer},
+					InitContainers:     initContainers,
+					Containers:         containers,
 					Affinity:           ptr.To(affinity.ToKubernetesType()),
 					NodeSelector:       backup.Spec.NodeSelector,
 					Tolerations:        backup.Spec.Tolerations,
@@ -155,8 +189,25 @@ func (b *Builder) BuildBackupJob(key types.NamespacedName, backup *mariadbv1alph
 	return job, nil
 }
 
+type PhysicalBackupOpts struct {
+	IncrementalParent *mariadbv1alpha1.PhysicalBackupChainLink
+}
+
+type PhysicalBackupOpt func(*PhysicalBackupOpts)
+
+// WithIncrementalParent takes an incremental backup relative to the given parent backup.
+func WithIncrementalParent(parent *mariadbv1alpha1.PhysicalBackupChainLink) PhysicalBackupOpt {
+	return func(opts *PhysicalBackupOpts) {
+		opts.IncrementalParent = parent
+	}
+}
+
 func (b *Builder) BuildPhysicalBackupJob(key types.NamespacedName, backup *mariadbv1alpha1.PhysicalBackup,
-	mariadb *mariadbv1alpha1.MariaDB, pod *corev1.Pod, backupFile string) (*batchv1.Job, error) {
+	mariadb *mariadbv1alpha1.MariaDB, pod *corev1.Pod, backupFile string, physicalBackupOpts ...PhysicalBackupOpt) (*batchv1.Job, error) {
+	opts := PhysicalBackupOpts{}
+	for _, setOpt := range physicalBackupOpts {
+		setOpt(&opts)
+	}
 	podIndex, err := statefulset.PodIndex(pod.Name)
 	if err != nil {
 		return nil, fmt.Errorf("error getting index for Pod '%s': %v", pod.Name, err)
@@ -168,6 +219,7 @@ func (b *Builder) BuildPhysicalBackupJob(key types.NamespacedName, backup *maria
 	jobMeta :=
 		metadata.NewMetadataBuilder(key).
 			WithMetadata(backup.Spec.InheritMetadata).
+			WithAnnotations(backupHooksAnnotations(backup.Spec.Hooks, pod)).
 			Build()
 	podMeta :=
 		metadata.NewMetadataBuilder(key).
@@ -182,13 +234,20 @@ func (b *Builder) BuildPhysicalBackupJob(key types.NamespacedName, backup *maria
 			batchBackupDirFullPath,
 		),
 		command.WithBackupContentType(mariadbv1alpha1.BackupContentTypePhysical),
+		command.WithMariaDBName(mariadb.Name),
+		command.WithTargetPod(pod.Name),
 		command.WithPhysicalBackupMeta(
 			mariadb.IsPointInTimeRecoveryEnabled(),
 			client.ObjectKeyFromObject(backup),
 		),
+		command.WithPhysicalBackupChain(backup.IsIncrementalEnabled(), opts.IncrementalParent),
 		command.WithCleanupTargetFile(physicalBackupShouldCleanupTargetFile(backup)),
 		command.WithMaxRetention(backup.Spec.MaxRetention.Duration),
+		command.WithRetention(backup.Spec.Retention),
 		command.WithCompression(backup.Spec.Compression),
+		command.WithCompressionLevel(backup.Spec.CompressionLevel),
+		command.WithStreaming(backup.Spec.Streaming),
+		command.WithThrottling(backup.Spec.Throttling),
 		command.WithUserEnv(batchUserEnv),
 		command.WithPasswordEnv(batchPasswordEnv),
 		command.WithLogLevel(backup.Spec.LogLevel),
@@ -196,6 +255,8 @@ func (b *Builder) BuildPhysicalBackupJob(key types.NamespacedName, backup *maria
 	}
 	cmdOpts = append(cmdOpts, s3Opts(backup.Spec.Storage.S3)...)
 	cmdOpts = append(cmdOpts, absOpts(backup.Spec.Storage.AzureBlob)...)
+	cmdOpts = append(cmdOpts, gcsOpts(backup.Spec.Storage.GCS)...)
+	cmdOpts = append(cmdOpts, secondaryStorageOpts(backup.Spec.SecondaryStorages)...)
 
 	backupFilepath := filepath.Join(batchStorageMountPath, backupFile)
 
@@ -217,6 +278,9 @@ func (b *Builder) BuildPhysicalBackupJob(key types.NamespacedName, backup *maria
 		return nil, fmt.Errorf("error getting volume from Backup: %v", err)
 	}
 	volumes, volumeMounts := jobPhysicalBackupVolumesWithSA(volume, backup.Spec.Storage.S3, backup.Spec.Storage.AzureBlob, mariadb, podIndex)
+	secondaryVolumes, secondaryVolumeMounts := secondaryStorageVolumes(backup.Spec.SecondaryStorages)
+	volumes = append(volumes, secondaryVolumes...)
+	volumeMounts = append(volumeMounts, secondaryVolumeMounts...)
 
 	var initContainers []corev1.Container
 	mariadbBackupContainer, err := b.jobMariadbContainer(
@@ -232,7 +296,8 @@ func (b *Builder) BuildPhysicalBackupJob(key types.NamespacedName, backup *maria
 		return nil, err
 	}
 	initContainers = append(initContainers, *mariadbBackupContainer)
-	if mariadb.IsPointInTimeRecoveryEnabled() {
+	// Streamed backups are not extracted, the GTID is read from the backup information instead.
+	if mariadb.IsPointInTimeRecoveryEnabled() && !backup.Spec.Streaming.IsEnabled() {
 		mariadbBackupMetaContainer, err := b.jobMariadbContainerWithName(
 			"backup-meta",
 			cmd.MariadbBackupMeta(),
@@ -248,10 +313,15 @@ func (b *Builder) BuildPhysicalBackupJob(key types.NamespacedName, backup *maria
 		}
 		initContainers = append(initContainers, *mariadbBackupMetaContainer)
 	}
+	operatorEnv := append(s3Env(backup.Spec.Storage.S3), absEnv(backup.Spec.Storage.AzureBlob)...)
+	operatorEnv = append(operatorEnv, gcsEnv(backup.Spec.Storage.GCS)...)
+	operatorEnv = append(operatorEnv, encryptionEnv(backup.Spec.Encryption)...)
+	operatorEnv = append(operatorEnv, secondaryStorageEnv(backup.Spec.SecondaryStorages)...)
+
 	operatorContainer, err := b.jobMariadbOperatorContainer(
 		operatorCmd,
 		volumeMounts,
-		append(s3Env(backup.Spec.Storage.S3), absEnv(backup.Spec.Storage.AzureBlob)...),
+		operatorEnv,
 		jobResources(backup.Spec.Resources),
 		mariadb,
 		b.env,
@@ -266,6 +336,16 @@ func (b *Builder) BuildPhysicalBackupJob(key types.NamespacedName, backup *maria
 		return nil, err
 	}
 
+	containers := []corev1.Container{*operatorContainer}
+	restartPolicy := backup.Spec.RestartPolicy
+	if backup.Spec.Streaming.IsEnabled() {
+		// mariadb-backup and mariadb-operator run concurrently to exchange the backup stream via a named pipe.
+		// The stream can not be resumed by restarting a single container, the Job retries the whole Pod instead.
+		containers = append(initContainers, containers...)
+		initContainers = nil
+		restartPolicy = corev1.RestartPolicyNever
+	}
+
 	var affinity *corev1.Affinity
 	if ptr.Deref(backup.Spec.PodAffinity, true) {
 		// Use dynamic pod affinity to schedule the Job on the same node as the MariaDB Pod.
@@ -293,14 +373,15 @@ func (b *Builder) BuildPhysicalBackupJob(key types.NamespacedName, backup *maria
 		ObjectMeta: jobMeta,
 		Spec: batchv1.JobSpec{
 			BackoffLimit: &backup.Spec.BackoffLimit,
+			Suspend:      backupHooksSuspend(backup.Spec.Hooks),
 			Template: corev1.PodTemplateSpec{
 				ObjectMeta: podMeta,
 				Spec: corev1.PodSpec{
-					RestartPolicy:      backup.Spec.RestartPolicy,
+					RestartPolicy:      restartPolicy,
 					ImagePullSecrets:   batchImagePullSecrets(mariadb, backup.Spec.ImagePullSecrets),
 					Volumes:            volumes,
 					InitContainers:     initContainers,
-					Containers:         []corev1.Container{*operatorContainer},
+					Containers:         containers,
 					Affinity:           affinity,
 					Tolerations:        backup.Spec.Tolerations,
 					SecurityContext:    securityContext,
@@ -371,12 +452,17 @@ func (b *Builder) BuildRestoreJob(key types.NamespacedName, restore *mariadbv1al
 		),
 		command.WithBackupContentType(mariadbv1alpha1.BackupContentTypeLogical),
 		command.WithTargetTime(restore.Spec.TargetRecoveryTimeOrDefault()),
+		command.WithTargetGtid(restore.Spec.LastRecoveryGtid()),
+		command.WithRestoreKey(client.ObjectKeyFromObject(restore)),
 		command.WithUserEnv(batchUserEnv),
 		command.WithPasswordEnv(batchPasswordEnv),
 		command.WithLogLevel(restore.Spec.LogLevel),
 		command.WithExtraOpts(restore.Spec.Args),
+		command.WithTables(restore.Spec.Tables),
 	}
 	cmdOpts = append(cmdOpts, s3Opts(restore.Spec.S3)...)
+	cmdOpts = append(cmdOpts, gcsOpts(restore.Spec.GCS)...)
+	cmdOpts = append(cmdOpts, secondaryStorageOpts(restore.Spec.SecondaryStorages)...)
 
 	cmd, err := command.NewBackupCommand(cmdOpts...)
 	if err != nil {
@@ -394,12 +480,19 @@ func (b *Builder) BuildRestoreJob(key types.NamespacedName, restore *mariadbv1al
 	volume := ptr.Deref(restore.Spec.Volume, mariadbv1alpha1.StorageVolumeSource{})
 	// TODO: ABS support in logical backups
 	volumes, volumeMounts := jobBatchStorageVolumes(volume, restore.Spec.S3, nil, mariadb)
+	secondaryVolumes, secondaryVolumeMounts := secondaryStorageVolumes(restore.Spec.SecondaryStorages)
+	volumes = append(volumes, secondaryVolumes...)
+	volumeMounts = append(volumeMounts, secondaryVolumeMounts...)
 	affinity := ptr.Deref(restore.Spec.Affinity, mariadbv1alpha1.AffinityConfig{}).Affinity
 
+	operatorEnv := append(s3Env(restore.Spec.S3), gcsEnv(restore.Spec.GCS)...)
+	operatorEnv = append(operatorEnv, encryptionEnv(restore.Spec.Encryption)...)
+	operatorEnv = append(operatorEnv, secondaryStorageEnv(restore.Spec.SecondaryStorages)...)
+
 	operatorContainer, err := b.jobMariadbOperatorContainer(
 		operatorCmd,
 		volumeMounts,
-		s3Env(restore.Spec.S3),
+		operatorEnv,
 		jobResources(restore.Spec.Resources),
 		mariadb,
 		b.env,
@@ -457,15 +550,22 @@ func (b *Builder) BuildRestoreJob(key types.NamespacedName, restore *mariadbv1al
 type RestoreOpts struct {
 	StartGtid          *replication.Gtid
 	TargetRecoveryTime *time.Time
+	TargetRecoveryGtid *replication.Gtid
 	Volume             *mariadbv1alpha1.StorageVolumeSource
 	S3                 *mariadbv1alpha1.S3
 	ABS                *mariadbv1alpha1.AzureBlob
+	GCS                *mariadbv1alpha1.GCS
+	Encryption         *mariadbv1alpha1.Encryption
+	SecondaryStorages  []mariadbv1alpha1.SecondaryStorage
+	Streaming          *mariadbv1alpha1.PhysicalBackupStreaming
+	Throttling         *mariadbv1alpha1.Throttling
 	RestoreJob         *mariadbv1alpha1.Job
 	RestoreCommandOpts []command.MariaDBBackupRestoreOpt
 	MariaDBLabels      *bool
 	Affinity           *bool
 	NodeSelector       map[string]string
 	LogLevel           string
+	Owner              client.Object
 }
 
 type RestoreOpt func(*RestoreOpts) error
@@ -480,15 +580,34 @@ func WithStartGtid(gtid *replication.Gtid) RestoreOpt {
 func WithBootstrapFrom(bootstrapFrom *mariadbv1alpha1.BootstrapFrom) RestoreOpt {
 	return func(opts *RestoreOpts) error {
 		opts.TargetRecoveryTime = ptr.To(bootstrapFrom.TargetRecoveryTimeOrDefault())
+		opts.TargetRecoveryGtid = bootstrapFrom.LastRecoveryGtid()
 		opts.Volume = bootstrapFrom.Volume
 		opts.S3 = bootstrapFrom.S3
 		opts.ABS = bootstrapFrom.AzureBlob
+		opts.GCS = bootstrapFrom.GCS
+		opts.Encryption = bootstrapFrom.Encryption
+		opts.SecondaryStorages = bootstrapFrom.SecondaryStorages
+		opts.Streaming = bootstrapFrom.Streaming
 		opts.RestoreJob = bootstrapFrom.RestoreJob
 		opts.LogLevel = bootstrapFrom.LogLevel
 		return nil
 	}
 }
 
+// WithRestore configures the replay of the binary logs after restoring a logical backup with a Restore, which owns the Job.
+// The binary logs are staged in the staging storage of the Restore, defaulting to an emptyDir volume.
+func WithRestore(restore *mariadbv1alpha1.Restore) RestoreOpt {
+	return func(opts *RestoreOpts) error {
+		stagingStorage := ptr.Deref(restore.Spec.StagingStorage, mariadbv1alpha1.StagingStorage{})
+		opts.TargetRecoveryTime = ptr.To(restore.Spec.TargetRecoveryTimeOrDefault())
+		opts.TargetRecoveryGtid = restore.Spec.LastRecoveryGtid()
+		opts.Volume = ptr.To(stagingStorage.VolumeOrEmptyDir(restore.StagingPVCKey()))
+		opts.LogLevel = restore.Spec.LogLevel
+		opts.Owner = restore
+		return nil
+	}
+}
+
 func WithPhysicalBackup(pb *mariadbv1alpha1.PhysicalBackup, targetRecoveryTime time.Time,
 	restoreJob *mariadbv1alpha1.Job, restoreCommandOpts ...command.MariaDBBackupRestoreOpt) RestoreOpt {
 	return func(opts *RestoreOpts) error {
@@ -500,6 +619,11 @@ func WithPhysicalBackup(pb *mariadbv1alpha1.PhysicalBackup, targetRecoveryTime t
 		opts.Volume = &volume
 		opts.S3 = pb.Spec.Storage.S3
 		opts.ABS = pb.Spec.Storage.AzureBlob
+		opts.GCS = pb.Spec.Storage.GCS
+		opts.Encryption = pb.Spec.Encryption
+		opts.SecondaryStorages = pb.Spec.SecondaryStorages
+		opts.Streaming = pb.Spec.Streaming
+		opts.Throttling = pb.Spec.Throttling
 		opts.RestoreJob = restoreJob
 		opts.RestoreCommandOpts = restoreCommandOpts
 		return nil
@@ -566,11 +690,19 @@ func (b *Builder) BuildPhysicalBackupRestoreJob(key types.NamespacedName, mariad
 		),
 		command.WithBackupContentType(mariadbv1alpha1.BackupContentTypePhysical),
 		command.WithTargetTime(*opts.TargetRecoveryTime),
+		command.WithTargetGtid(opts.TargetRecoveryGtid),
 		command.WithOmitCredentials(true),
+		command.WithThrottling(opts.Throttling),
 		command.WithExtraOpts(restoreJob.Args),
 	}
 	cmdOpts = append(cmdOpts, s3Opts(opts.S3)...)
 	cmdOpts = append(cmdOpts, absOpts(opts.ABS)...)
+	cmdOpts = append(cmdOpts, gcsOpts(opts.GCS)...)
+	cmdOpts = append(cmdOpts, secondaryStorageOpts(opts.SecondaryStorages)...)
+	streaming := opts.Streaming.IsEnabled() && (opts.S3 != nil || opts.ABS != nil || opts.GCS != nil)
+	if streaming {
+		cmdOpts = append(cmdOpts, command.WithStreaming(opts.Streaming))
+	}
 
 	if opts.LogLevel != "" {
 		cmdOpts = append(cmdOpts, command.WithLogLevel(opts.LogLevel))
@@ -594,11 +726,19 @@ func (b *Builder) BuildPhysicalBackupRestoreJob(key types.NamespacedName, mariad
 	}
 
 	volumes, volumeMounts := jobPhysicalBackupVolumes(*opts.Volume, opts.S3, opts.ABS, mariadb, podIndex)
+	secondaryVolumes, secondaryVolumeMounts := secondaryStorageVolumes(opts.SecondaryStorages)
+	volumes = append(volumes, secondaryVolumes...)
+	volumeMounts = append(volumeMounts, secondaryVolumeMounts...)
+
+	operatorEnv := append(s3Env(opts.S3), absEnv(opts.ABS)...)
+	operatorEnv = append(operatorEnv, gcsEnv(opts.GCS)...)
+	operatorEnv = append(operatorEnv, encryptionEnv(opts.Encryption)...)
+	operatorEnv = append(operatorEnv, secondaryStorageEnv(opts.SecondaryStorages)...)
 
 	operatorContainer, err := b.jobMariadbOperatorContainer(
 		operatorCmd,
 		volumeMounts,
-		append(s3Env(opts.S3), absEnv(opts.ABS)...),
+		operatorEnv,
 		jobResources(restoreJob.Resources),
 		mariadb,
 		b.env,
@@ -635,6 +775,17 @@ func (b *Builder) BuildPhysicalBackupRestoreJob(key types.NamespacedName, mariad
 		return nil, err
 	}
 
+	initContainers := []corev1.Container{*operatorContainer}
+	containers := []corev1.Container{*mariadbContainer}
+	restartPolicy := corev1.RestartPolicyOnFailure
+	if streaming {
+		// mariadb-operator and mariadb-backup run concurrently to exchange the backup stream via named pipes.
+		// The stream can not be resumed by restarting a single container, the Job retries the whole Pod instead.
+		containers = append(initContainers, containers...)
+		initContainers = nil
+		restartPolicy = corev1.RestartPolicyNever
+	}
+
 	job := &batchv1.Job{
 		ObjectMeta: jobMeta,
 		Spec: batchv1.JobSpec{
@@ -642,11 +793,11 @@ func (b *Builder) BuildPhysicalBackupRestoreJob(key types.NamespacedName, mariad
 			Template: corev1.PodTemplateSpec{
 				ObjectMeta: podMeta,
 				Spec: corev1.PodSpec{
-					RestartPolicy:      corev1.RestartPolicyOnFailure,
+					RestartPolicy:      restartPolicy,
 					ImagePullSecrets:   kadapter.ToKubernetesSlice(mariadb.Spec.ImagePullSecrets),
 					Volumes:            volumes,
-					InitContainers:     []corev1.Container{*operatorContainer},
-					Containers:         []corev1.Container{*mariadbContainer},
+					InitContainers:     initContainers,
+					Containers:         containers,
 					Affinity:           affinity,
 					NodeSelector:       nodeSelector,
 					Tolerations:        mariadb.Spec.Tolerations,
@@ -702,13 +853,18 @@ func (b *Builder) BuildPITRJob(key types.NamespacedName, pitr *mariadbv1alpha1.P
 		),
 		command.WithStartGtid(opts.StartGtid),
 		command.WithTargetTime(*opts.TargetRecoveryTime),
+		command.WithTargetGtid(opts.TargetRecoveryGtid),
 		command.WithCompression(pitr.Spec.Compression),
+		command.WithThrottling(pitr.Spec.Throttling),
 		command.WithUserEnv(batchUserEnv),
 		command.WithPasswordEnv(batchPasswordEnv),
 		command.WithExtraOpts(restoreJob.Args),
 	}
 	cmdOpts = append(cmdOpts, s3Opts(pitr.Spec.PointInTimeRecoveryStorage.S3)...)
 	cmdOpts = append(cmdOpts, absOpts(pitr.Spec.PointInTimeRecoveryStorage.AzureBlob)...)
+	cmdOpts = append(cmdOpts, gcsOpts(pitr.Spec.PointInTimeRecoveryStorage.GCS)...)
+	cmdOpts = append(cmdOpts, fileSystemOpts(&pitr.Spec.PointInTimeRecoveryStorage)...)
+	cmdOpts = append(cmdOpts, secondaryStorageOpts(pitr.Spec.SecondaryStorages)...)
 
 	if opts.LogLevel != "" {
 		cmdOpts = append(cmdOpts, command.WithLogLevel(opts.LogLevel))
@@ -725,6 +881,12 @@ func (b *Builder) BuildPITRJob(key types.NamespacedName, pitr *mariadbv1alpha1.P
 		pitr.Spec.PointInTimeRecoveryStorage.AzureBlob,
 		mariadb,
 	)
+	pitrStorageVolumes, pitrStorageVolumeMounts := pitrStorageVolumes(pitr)
+	volumes = append(volumes, pitrStorageVolumes...)
+	volumeMounts = append(volumeMounts, pitrStorageVolumeMounts...)
+	secondaryVolumes, secondaryVolumeMounts := secondaryStorageVolumes(pitr.Spec.SecondaryStorages)
+	volumes = append(volumes, secondaryVolumes...)
+	volumeMounts = append(volumeMounts, secondaryVolumeMounts...)
 
 	opteratorPITRCmd, err := cmd.MariadbOperatorPITR(pitr.Spec.StrictMode)
 	if err != nil {
@@ -735,10 +897,18 @@ func (b *Builder) BuildPITRJob(key types.NamespacedName, pitr *mariadbv1alpha1.P
 		return nil, fmt.Errorf("error getting mariadb-binlog command: %v", err)
 	}
 
+	operatorEnv := append(
+		s3Env(pitr.Spec.PointInTimeRecoveryStorage.S3),
+		absEnv(pitr.Spec.PointInTimeRecoveryStorage.AzureBlob)...,
+	)
+	operatorEnv = append(operatorEnv, gcsEnv(pitr.Spec.PointInTimeRecoveryStorage.GCS)...)
+	operatorEnv = append(operatorEnv, encryptionEnv(pitr.Spec.Encryption)...)
+	operatorEnv = append(operatorEnv, secondaryStorageEnv(pitr.Spec.SecondaryStorages)...)
+
 	operatorContainer, err := b.jobMariadbOperatorContainer(
 		opteratorPITRCmd,
 		volumeMounts,
-		append(s3Env(pitr.Spec.PointInTimeRecoveryStorage.S3), absEnv(pitr.Spec.PointInTimeRecoveryStorage.AzureBlob)...),
+		operatorEnv,
 		jobResources(restoreJob.Resources),
 		mariadb,
 		b.env,
@@ -792,7 +962,11 @@ func (b *Builder) BuildPITRJob(key types.NamespacedName, pitr *mariadbv1alpha1.P
 			},
 		},
 	}
-	if err := controllerutil.SetControllerReference(mariadb, job, b.scheme); err != nil {
+	var owner client.Object = mariadb
+	if opts.Owner != nil {
+		owner = opts.Owner
+	}
+	if err := controllerutil.SetControllerReference(owner, job, b.scheme); err != nil {
 		return nil, fmt.Errorf("error setting controller reference to Job: %v", err)
 	}
 	return job, nil
@@ -1098,12 +1272,42 @@ func (b *Builder) BuildSqlCronJob(key types.NamespacedName, sqlJob *mariadbv1alp
 	return cronJob, nil
 }
 
+// backupHooksAnnotations returns the annotations used by the operator to keep track of the hooks to be run for a backup Job.
+func backupHooksAnnotations(hooks *mariadbv1alpha1.BackupHooks, targetPod *corev1.Pod) map[string]string {
+	annotations := make(map[string]string)
+	if hooks.HasPre() {
+		annotations[mdbmetadata.PreHooksAnnotation] = mdbmetadata.HooksPendingValue
+	}
+	if hooks.HasPost() {
+		annotations[mdbmetadata.PostHooksAnnotation] = mdbmetadata.HooksPendingValue
+	}
+	if len(annotations) > 0 && targetPod != nil {
+		annotations[mdbmetadata.HooksTargetPodAnnotation] = targetPod.Name
+	}
+	return annotations
+}
+
+// backupHooksSuspend returns whether a backup Job should be created suspended, which allows the operator to run the pre hooks before resuming it.
+func backupHooksSuspend(hooks *mariadbv1alpha1.BackupHooks) *bool {
+	if !hooks.HasPre() {
+		return nil
+	}
+	return ptr.To(true)
+}
+
+// isPointInTimeRecoveryEnabled determines whether the binary logs of the MariaDB are archived, requiring the backup GTID to be tracked.
+func isPointInTimeRecoveryEnabled(mariadb interfaces.MariaDBObject) bool {
+	mdb, ok := mariadb.(*mariadbv1alpha1.MariaDB)
+	return ok && mdb.IsPointInTimeRecoveryEnabled()
+}
+
 func backupShouldCleanupTargetFile(backup *mariadbv1alpha1.Backup) bool {
-	return backup.Spec.Storage.S3 != nil && backup.Spec.StagingStorage != nil
+	return (backup.Spec.Storage.S3 != nil || backup.Spec.Storage.GCS != nil) && backup.Spec.StagingStorage != nil
 }
 
 func physicalBackupShouldCleanupTargetFile(pyhisicalBackup *mariadbv1alpha1.PhysicalBackup) bool {
-	return pyhisicalBackup.Spec.Storage.S3 != nil && pyhisicalBackup.Spec.StagingStorage != nil
+	return (pyhisicalBackup.Spec.Storage.S3 != nil || pyhisicalBackup.Spec.Storage.GCS != nil) &&
+		pyhisicalBackup.Spec.StagingStorage != nil
 }
 
 func s3Opts(s3 *mariadbv1alpha1.S3) []command.BackupOpt {
@@ -1120,6 +1324,7 @@ func s3Opts(s3 *mariadbv1alpha1.S3) []command.BackupOpt {
 			s3.Prefix,
 		),
 		command.WithS3TLS(tls.Enabled),
+		command.WithObjectLock(s3.ObjectLock),
 	}
 	if tls.Enabled && tls.CASecretKeyRef != nil {
 		caCertPath := filepath.Join(S3PKIMountPath, s3.TLS.CASecretKeyRef.Key)
@@ -1141,6 +1346,7 @@ func absOpts(abs *mariadbv1alpha1.AzureBlob) []command.BackupOpt {
 			abs.Prefix,
 		),
 		command.WithABSTLS(tls.Enabled),
+		command.WithObjectLock(abs.ObjectLock),
 	}
 	if tls.Enabled && tls.CASecretKeyRef != nil {
 		caCertPath := filepath.Join(ABSPKIMountPath, abs.TLS.CASecretKeyRef.Key)
@@ -1149,6 +1355,28 @@ func absOpts(abs *mariadbv1alpha1.AzureBlob) []command.BackupOpt {
 	return cmdOpts
 }
 
+func gcsOpts(gcs *mariadbv1alpha1.GCS) []command.BackupOpt {
+	if gcs == nil {
+		return nil
+	}
+	return []command.BackupOpt{
+		command.WithGCS(
+			gcs.Bucket,
+			gcs.Endpoint,
+			gcs.Prefix,
+		),
+	}
+}
+
+func fileSystemOpts(storage *mariadbv1alpha1.PointInTimeRecoveryStorage) []command.BackupOpt {
+	if !storage.IsFileSystem() {
+		return nil
+	}
+	return []command.BackupOpt{
+		command.WithFileSystem(PITRStorageMountPath),
+	}
+}
+
 func batchImagePullSecrets(mariadb interfaces.Imager,
 	pullSecrets []mariadbv1alpha1.LocalObjectReference) []corev1.LocalObjectReference {
 	var secrets []mariadbv1alpha1.LocalObjectReference
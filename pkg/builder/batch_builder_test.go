@@ -8,9 +8,12 @@ import (
 	"time"
 
 	mariadbv1alpha1 "github.com/mariadb-operator/mariadb-operator/v26/api/v1alpha1"
+	"github.com/mariadb-operator/mariadb-operator/v26/pkg/backup"
 	labels "github.com/mariadb-operator/mariadb-operator/v26/pkg/builder/labels"
 	builderpki "github.com/mariadb-operator/mariadb-operator/v26/pkg/builder/pki"
 	galeraresources "github.com/mariadb-operator/mariadb-operator/v26/pkg/controller/galera/resources"
+	"github.com/mariadb-operator/mariadb-operator/v26/pkg/datastructures"
+	mdbmetadata "github.com/mariadb-operator/mariadb-operator/v26/pkg/metadata"
 	"github.com/mariadb-operator/mariadb-operator/v26/pkg/replication"
 	"github.com/stretchr/testify/assert"
 	v1 "k8s.io/api/batch/v1"
@@ -452,6 +455,171 @@ func TestBackupJobMeta(t *testing.T) {
 	}
 }
 
+func TestBackupJobParallel(t *testing.T) {
+	tests := []struct {
+		name               string
+		parallel           *mariadbv1alpha1.ParallelBackup
+		wantDumpArgs       []string
+		notWantDumpArgs    []string
+		wantOperatorArgs   []string
+		notWantOperatorArg string
+		wantContainers     []string
+		wantRestartPolicy  corev1.RestartPolicy
+	}{
+		{
+			name:               "Parallel disabled",
+			wantDumpArgs:       []string{".sql.gz", "> $(cat '/backup/0-backup-target.txt')"},
+			notWantDumpArgs:    []string{".tar.gz", "/backup/stream-0.fifo"},
+			wantOperatorArgs:   []string{"--backup-name", "test-backup", "--backup-namespace", "test-namespace"},
+			notWantOperatorArg: "--parallel",
+			wantContainers:     []string{"mariadb-operator"},
+			wantRestartPolicy:  corev1.RestartPolicyOnFailure,
+		},
+		{
+			name: "Parallel enabled",
+			parallel: &mariadbv1alpha1.ParallelBackup{
+				Enabled: true,
+			},
+			wantDumpArgs:       []string{".tar.gz", "mkfifo /backup/stream-0.fifo", "> /backup/stream-0.fifo", "--comments"},
+			notWantDumpArgs:    []string{".sql.gz"},
+			wantOperatorArgs:   []string{"--logical-backup-dir-path", "/backup/full", "--parallel"},
+			notWantOperatorArg: "--logical-backup-chunk-size",
+			wantContainers:     []string{"mariadb", "mariadb-operator"},
+			wantRestartPolicy:  corev1.RestartPolicyNever,
+		},
+		{
+			name: "Parallel with chunk size",
+			parallel: &mariadbv1alpha1.ParallelBackup{
+				Enabled:   true,
+				ChunkSize: ptr.To(resource.MustParse("1Mi")),
+			},
+			wantDumpArgs:      []string{".tar.gz", "> /backup/stream-0.fifo"},
+			wantOperatorArgs:  []string{"--logical-backup-chunk-size", "1048576"},
+			wantContainers:    []string{"mariadb", "mariadb-operator"},
+			wantRestartPolicy: corev1.RestartPolicyNever,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			builder := newDefaultTestBuilder(t)
+
+			key := types.NamespacedName{
+				Name:      "test-backup",
+				Namespace: "test-namespace",
+			}
+			backup := &mariadbv1alpha1.Backup{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      key.Name,
+					Namespace: key.Namespace,
+				},
+				Spec: mariadbv1alpha1.BackupSpec{
+					Storage: mariadbv1alpha1.BackupStorage{
+						S3: &mariadbv1alpha1.S3{
+							Bucket:   "test",
+							Endpoint: "test",
+						},
+					},
+					Compression: mariadbv1alpha1.CompressGzip,
+					Parallel:    tt.parallel,
+				},
+			}
+
+			job, err := builder.BuildBackupJob(key, backup, &mariadbv1alpha1.MariaDB{})
+			assert.NoError(t, err)
+			assert.NotNil(t, job)
+
+			podSpec := job.Spec.Template.Spec
+			assert.Equal(t, tt.wantRestartPolicy, podSpec.RestartPolicy)
+			assert.Len(t, podSpec.Containers, len(tt.wantContainers))
+			for i, container := range podSpec.Containers {
+				assert.Equal(t, tt.wantContainers[i], container.Name)
+			}
+
+			dumpContainer := append(podSpec.InitContainers, podSpec.Containers...)[0]
+			dumpScript := strings.Join(dumpContainer.Args, " ")
+			for _, arg := range tt.wantDumpArgs {
+				assert.Contains(t, dumpScript, arg)
+			}
+			for _, arg := range tt.notWantDumpArgs {
+				assert.NotContains(t, dumpScript, arg)
+			}
+			operatorArgs := podSpec.Containers[len(podSpec.Containers)-1].Args
+			for _, arg := range tt.wantOperatorArgs {
+				assert.Contains(t, operatorArgs, arg)
+			}
+			if tt.notWantOperatorArg != "" {
+				assert.NotContains(t, operatorArgs, tt.notWantOperatorArg)
+			}
+		})
+	}
+}
+
+func TestBackupJobRestartPolicy(t *testing.T) {
+	tests := []struct {
+		name              string
+		parallel          *mariadbv1alpha1.ParallelBackup
+		restartPolicy     corev1.RestartPolicy
+		wantRestartPolicy corev1.RestartPolicy
+	}{
+		{
+			name:              "default",
+			wantRestartPolicy: corev1.RestartPolicyOnFailure,
+		},
+		{
+			name:              "custom",
+			restartPolicy:     corev1.RestartPolicyNever,
+			wantRestartPolicy: corev1.RestartPolicyNever,
+		},
+		{
+			name: "parallel default",
+			parallel: &mariadbv1alpha1.ParallelBackup{
+				Enabled: true,
+			},
+			wantRestartPolicy: corev1.RestartPolicyNever,
+		},
+		{
+			name: "parallel custom",
+			parallel: &mariadbv1alpha1.ParallelBackup{
+				Enabled: true,
+			},
+			restartPolicy:     corev1.RestartPolicyNever,
+			wantRestartPolicy: corev1.RestartPolicyNever,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			builder := newDefaultTestBuilder(t)
+
+			key := types.NamespacedName{
+				Name:      "test-backup",
+				Namespace: "test-namespace",
+			}
+			backup := &mariadbv1alpha1.Backup{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      key.Name,
+					Namespace: key.Namespace,
+				},
+				Spec: mariadbv1alpha1.BackupSpec{
+					Storage: mariadbv1alpha1.BackupStorage{
+						S3: &mariadbv1alpha1.S3{
+							Bucket:   "test",
+							Endpoint: "test",
+						},
+					},
+					Parallel:      tt.parallel,
+					RestartPolicy: tt.restartPolicy,
+				},
+			}
+
+			job, err := builder.BuildBackupJob(key, backup, &mariadbv1alpha1.MariaDB{})
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantRestartPolicy, job.Spec.Template.Spec.RestartPolicy)
+		})
+	}
+}
+
 func TestPhysicalBackupJobPodAffinity(t *testing.T) {
 	builder := newDefaultTestBuilder(t)
 	podObjMeta := metav1.ObjectMeta{
@@ -943,87 +1111,536 @@ func TestPhysicalBackupJobImagePullSecrets(t *testing.T) {
 			if err != nil {
 				t.Fatalf("unexpected error building Job: %v", err)
 			}
-			if !reflect.DeepEqual(tt.wantPullSecrets, job.Spec.Template.Spec.ImagePullSecrets) {
-				t.Errorf("unexpected ImagePullSecrets, want: %v  got: %v", tt.wantPullSecrets, job.Spec.Template.Spec.ImagePullSecrets)
+			if !reflect.DeepEqual(tt.wantPullSecrets, job.Spec.Template.Spec.ImagePullSecrets) {
+				t.Errorf("unexpected ImagePullSecrets, want: %v  got: %v", tt.wantPullSecrets, job.Spec.Template.Spec.ImagePullSecrets)
+			}
+		})
+	}
+}
+
+func TestPhysicalBackupJobInitContainers(t *testing.T) {
+	tests := []struct {
+		name               string
+		mariadb            *mariadbv1alpha1.MariaDB
+		wantInitContainers []string
+	}{
+		{
+			name: "Point-in-time recovery disabled",
+			mariadb: &mariadbv1alpha1.MariaDB{
+				Spec: mariadbv1alpha1.MariaDBSpec{
+					Storage: mariadbv1alpha1.Storage{
+						Size: ptr.To(resource.MustParse("1Gi")),
+					},
+				},
+			},
+			wantInitContainers: []string{"mariadb"},
+		},
+		{
+			name: "Replication and point-in-time recovery enabled",
+			mariadb: &mariadbv1alpha1.MariaDB{
+				Spec: mariadbv1alpha1.MariaDBSpec{
+					PointInTimeRecoveryRef: &mariadbv1alpha1.LocalObjectReference{
+						Name: "test",
+					},
+					Replication: &mariadbv1alpha1.Replication{
+						Enabled: true,
+					},
+					Storage: mariadbv1alpha1.Storage{
+						Size: ptr.To(resource.MustParse("1Gi")),
+					},
+				},
+			},
+			wantInitContainers: []string{"mariadb", "backup-meta"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			builder := newDefaultTestBuilder(t)
+
+			key := types.NamespacedName{
+				Name:      "test-backup",
+				Namespace: "test-namespace",
+			}
+			backup := &mariadbv1alpha1.PhysicalBackup{
+				Spec: mariadbv1alpha1.PhysicalBackupSpec{
+					Storage: mariadbv1alpha1.PhysicalBackupStorage{
+						S3: &mariadbv1alpha1.S3{
+							Bucket:   "test",
+							Endpoint: "test",
+						},
+					},
+					Compression: mariadbv1alpha1.CompressBzip2,
+				},
+			}
+			pod := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "mariadb-0",
+				},
+				Spec: corev1.PodSpec{
+					NodeName: "node1",
+				},
+			}
+
+			job, err := builder.BuildPhysicalBackupJob(key, backup, tt.mariadb, pod, "backup.xb.bz2")
+
+			assert.NoError(t, err)
+			assert.NotNil(t, job)
+			assert.NotNil(t, job.Spec.Template.Spec.InitContainers)
+			assert.Len(t, job.Spec.Template.Spec.InitContainers, len(tt.wantInitContainers))
+
+			for i, container := range job.Spec.Template.Spec.InitContainers {
+				assert.Equal(t, tt.wantInitContainers[i], container.Name)
+			}
+		})
+	}
+}
+
+func TestPhysicalBackupJobIncremental(t *testing.T) {
+	tests := []struct {
+		name              string
+		incremental       *mariadbv1alpha1.PhysicalBackupIncremental
+		parent            *mariadbv1alpha1.PhysicalBackupChainLink
+		wantBackupArgs    []string
+		notWantBackupArgs []string
+		wantOperatorArgs  []string
+	}{
+		{
+			name:              "Incremental disabled",
+			wantBackupArgs:    []string{"--extra-lsndir=/backup/full"},
+			notWantBackupArgs: []string{"--incremental-lsn"},
+			wantOperatorArgs:  []string{"--physical-backup-name", "test-backup"},
+		},
+		{
+			name: "Full backup",
+			incremental: &mariadbv1alpha1.PhysicalBackupIncremental{
+				FullCron: "0 0 * * *",
+			},
+			wantBackupArgs:    []string{"--extra-lsndir=/backup/full"},
+			notWantBackupArgs: []string{"--incremental-lsn"},
+			wantOperatorArgs:  []string{"--physical-backup-chain"},
+		},
+		{
+			name: "Incremental backup",
+			incremental: &mariadbv1alpha1.PhysicalBackupIncremental{
+				FullCron: "0 0 * * *",
+			},
+			parent: &mariadbv1alpha1.PhysicalBackupChainLink{
+				FileName: "physicalbackup-20250101000000.xb.bz2",
+				Type:     mariadbv1alpha1.PhysicalBackupTypeFull,
+				ToLSN:    1000,
+			},
+			wantBackupArgs: []string{"--extra-lsndir=/backup/full", "--incremental-lsn=1000"},
+			wantOperatorArgs: []string{
+				"--physical-backup-chain",
+				"--physical-backup-chain-parent",
+				"physicalbackup-20250101000000.xb.bz2",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			builder := newDefaultTestBuilder(t)
+
+			key := types.NamespacedName{
+				Name:      "test-backup",
+				Namespace: "test-namespace",
+			}
+			backup := &mariadbv1alpha1.PhysicalBackup{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-backup",
+					Namespace: "test-namespace",
+				},
+				Spec: mariadbv1alpha1.PhysicalBackupSpec{
+					Storage: mariadbv1alpha1.PhysicalBackupStorage{
+						S3: &mariadbv1alpha1.S3{
+							Bucket:   "test",
+							Endpoint: "test",
+						},
+					},
+					Compression: mariadbv1alpha1.CompressBzip2,
+					Incremental: tt.incremental,
+				},
+			}
+			mariadb := &mariadbv1alpha1.MariaDB{
+				Spec: mariadbv1alpha1.MariaDBSpec{
+					Storage: mariadbv1alpha1.Storage{
+						Size: ptr.To(resource.MustParse("1Gi")),
+					},
+				},
+			}
+			pod := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "mariadb-0",
+				},
+				Spec: corev1.PodSpec{
+					NodeName: "node1",
+				},
+			}
+
+			job, err := builder.BuildPhysicalBackupJob(key, backup, mariadb, pod, "backup.xb.bz2", WithIncrementalParent(tt.parent))
+			assert.NoError(t, err)
+			assert.NotNil(t, job)
+
+			backupScript := strings.Join(job.Spec.Template.Spec.InitContainers[0].Args, " ")
+			for _, arg := range tt.wantBackupArgs {
+				assert.Contains(t, backupScript, arg)
+			}
+			for _, arg := range tt.notWantBackupArgs {
+				assert.NotContains(t, backupScript, arg)
+			}
+			operatorArgs := job.Spec.Template.Spec.Containers[0].Args
+			for _, arg := range tt.wantOperatorArgs {
+				assert.Contains(t, operatorArgs, arg)
+			}
+		})
+	}
+}
+
+func TestPhysicalBackupJobStreaming(t *testing.T) {
+	tests := []struct {
+		name               string
+		streaming          *mariadbv1alpha1.PhysicalBackupStreaming
+		wantInitContainers []string
+		wantContainers     []string
+		wantRestartPolicy  corev1.RestartPolicy
+		wantOperatorArgs   []string
+	}{
+		{
+			name:               "Streaming disabled",
+			wantInitContainers: []string{"mariadb", "backup-meta"},
+			wantContainers:     []string{"mariadb-operator"},
+			wantRestartPolicy:  corev1.RestartPolicyOnFailure,
+		},
+		{
+			name: "Streaming enabled",
+			streaming: &mariadbv1alpha1.PhysicalBackupStreaming{
+				Enabled: true,
+			},
+			wantContainers:    []string{"mariadb", "mariadb-operator"},
+			wantRestartPolicy: corev1.RestartPolicyNever,
+			wantOperatorArgs: []string{
+				"--streaming",
+				"--stream-part-size",
+				"67108864",
+				"--stream-max-part-retries",
+				"10",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			builder := newDefaultTestBuilder(t)
+
+			key := types.NamespacedName{
+				Name:      "test-backup",
+				Namespace: "test-namespace",
+			}
+			backup := &mariadbv1alpha1.PhysicalBackup{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-backup",
+					Namespace: "test-namespace",
+				},
+				Spec: mariadbv1alpha1.PhysicalBackupSpec{
+					Storage: mariadbv1alpha1.PhysicalBackupStorage{
+						S3: &mariadbv1alpha1.S3{
+							Bucket:   "test",
+							Endpoint: "test",
+						},
+					},
+					Compression:   mariadbv1alpha1.CompressBzip2,
+					Streaming:     tt.streaming,
+					RestartPolicy: corev1.RestartPolicyOnFailure,
+				},
+			}
+			mariadb := &mariadbv1alpha1.MariaDB{
+				Spec: mariadbv1alpha1.MariaDBSpec{
+					PointInTimeRecoveryRef: &mariadbv1alpha1.LocalObjectReference{
+						Name: "test",
+					},
+					Replication: &mariadbv1alpha1.Replication{
+						Enabled: true,
+					},
+					Storage: mariadbv1alpha1.Storage{
+						Size: ptr.To(resource.MustParse("1Gi")),
+					},
+				},
+			}
+			pod := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "mariadb-0",
+				},
+				Spec: corev1.PodSpec{
+					NodeName: "node1",
+				},
+			}
+
+			job, err := builder.BuildPhysicalBackupJob(key, backup, mariadb, pod, "backup.xb.bz2")
+			assert.NoError(t, err)
+			assert.NotNil(t, job)
+
+			podSpec := job.Spec.Template.Spec
+			assert.Equal(t, tt.wantRestartPolicy, podSpec.RestartPolicy)
+			assert.Len(t, podSpec.InitContainers, len(tt.wantInitContainers))
+			for i, container := range podSpec.InitContainers {
+				assert.Equal(t, tt.wantInitContainers[i], container.Name)
+			}
+			assert.Len(t, podSpec.Containers, len(tt.wantContainers))
+			for i, container := range podSpec.Containers {
+				assert.Equal(t, tt.wantContainers[i], container.Name)
+			}
+
+			operatorArgs := podSpec.Containers[len(podSpec.Containers)-1].Args
+			for _, arg := range tt.wantOperatorArgs {
+				assert.Contains(t, operatorArgs, arg)
+			}
+			if tt.streaming.IsEnabled() {
+				backupScript := strings.Join(podSpec.Containers[0].Args, " ")
+				assert.Contains(t, backupScript, "mkfifo /backup/stream-0.fifo")
+				assert.Contains(t, backupScript, "> /backup/stream-0.fifo")
+			} else {
+				assert.NotContains(t, operatorArgs, "--streaming")
+			}
+		})
+	}
+}
+
+func TestBackupJobHooks(t *testing.T) {
+	tests := []struct {
+		name            string
+		hooks           *mariadbv1alpha1.BackupHooks
+		wantSuspend     *bool
+		wantAnnotations map[string]string
+	}{
+		{
+			name:            "No hooks",
+			wantAnnotations: map[string]string{},
+		},
+		{
+			name: "Pre hooks",
+			hooks: &mariadbv1alpha1.BackupHooks{
+				Pre: []mariadbv1alpha1.BackupHook{
+					{
+						Name: "marker",
+						SQL:  ptr.To("INSERT INTO audit.backups VALUES (NOW())"),
+					},
+				},
+			},
+			wantSuspend: ptr.To(true),
+			wantAnnotations: map[string]string{
+				mdbmetadata.PreHooksAnnotation:       mdbmetadata.HooksPendingValue,
+				mdbmetadata.HooksTargetPodAnnotation: "mariadb-0",
+			},
+		},
+		{
+			name: "Post hooks",
+			hooks: &mariadbv1alpha1.BackupHooks{
+				Post: []mariadbv1alpha1.BackupHook{
+					{
+						Name: "audit",
+						Exec: &mariadbv1alpha1.BackupHookExec{
+							Command: []string{"touch", "/tmp/backup"},
+						},
+					},
+				},
+			},
+			wantAnnotations: map[string]string{
+				mdbmetadata.PostHooksAnnotation:      mdbmetadata.HooksPendingValue,
+				mdbmetadata.HooksTargetPodAnnotation: "mariadb-0",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			builder := newDefaultTestBuilder(t)
+			key := types.NamespacedName{
+				Name:      "test-backup",
+				Namespace: "test-namespace",
+			}
+			mariadb := &mariadbv1alpha1.MariaDB{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "mariadb",
+					Namespace: "test-namespace",
+				},
+				Spec: mariadbv1alpha1.MariaDBSpec{
+					Storage: mariadbv1alpha1.Storage{
+						Size: ptr.To(resource.MustParse("1Gi")),
+					},
+				},
+			}
+
+			backup := &mariadbv1alpha1.Backup{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-backup",
+					Namespace: "test-namespace",
+				},
+				Spec: mariadbv1alpha1.BackupSpec{
+					Storage: mariadbv1alpha1.BackupStorage{
+						S3: &mariadbv1alpha1.S3{
+							Bucket:   "test",
+							Endpoint: "test",
+						},
+					},
+					Hooks: tt.hooks,
+				},
+			}
+			job, err := builder.BuildBackupJob(key, backup, mariadb)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantSuspend, job.Spec.Suspend)
+			for k, v := range tt.wantAnnotations {
+				if k == mdbmetadata.HooksTargetPodAnnotation {
+					assert.NotContains(t, job.Annotations, k)
+					continue
+				}
+				assert.Equal(t, v, job.Annotations[k])
+			}
+
+			physicalBackup := &mariadbv1alpha1.PhysicalBackup{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-backup",
+					Namespace: "test-namespace",
+				},
+				Spec: mariadbv1alpha1.PhysicalBackupSpec{
+					Storage: mariadbv1alpha1.PhysicalBackupStorage{
+						S3: &mariadbv1alpha1.S3{
+							Bucket:   "test",
+							Endpoint: "test",
+						},
+					},
+					Hooks: tt.hooks,
+				},
+			}
+			pod := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "mariadb-0",
+				},
+				Spec: corev1.PodSpec{
+					NodeName: "node1",
+				},
+			}
+			job, err = builder.BuildPhysicalBackupJob(key, physicalBackup, mariadb, pod, "backup.xb")
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantSuspend, job.Spec.Suspend)
+			for k, v := range tt.wantAnnotations {
+				assert.Equal(t, v, job.Annotations[k])
+			}
+			if tt.hooks == nil {
+				assert.NotContains(t, job.Annotations, mdbmetadata.HooksTargetPodAnnotation)
 			}
 		})
 	}
 }
 
-func TestPhysicalBackupJobInitContainers(t *testing.T) {
+func TestPhysicalBackupRestoreJobStreaming(t *testing.T) {
 	tests := []struct {
 		name               string
-		mariadb            *mariadbv1alpha1.MariaDB
+		bootstrapFrom      *mariadbv1alpha1.BootstrapFrom
 		wantInitContainers []string
+		wantContainers     []string
+		wantRestartPolicy  corev1.RestartPolicy
+		wantStreaming      bool
 	}{
 		{
-			name: "Point-in-time recovery disabled",
-			mariadb: &mariadbv1alpha1.MariaDB{
-				Spec: mariadbv1alpha1.MariaDBSpec{
-					Storage: mariadbv1alpha1.Storage{
-						Size: ptr.To(resource.MustParse("1Gi")),
-					},
+			name: "Streaming disabled",
+			bootstrapFrom: &mariadbv1alpha1.BootstrapFrom{
+				S3: &mariadbv1alpha1.S3{
+					Bucket:   "test",
+					Endpoint: "test",
+				},
+				Volume: &mariadbv1alpha1.StorageVolumeSource{
+					EmptyDir: &mariadbv1alpha1.EmptyDirVolumeSource{},
 				},
 			},
-			wantInitContainers: []string{"mariadb"},
+			wantInitContainers: []string{"mariadb-operator"},
+			wantContainers:     []string{"mariadb"},
+			wantRestartPolicy:  corev1.RestartPolicyOnFailure,
 		},
 		{
-			name: "Replication and point-in-time recovery enabled",
-			mariadb: &mariadbv1alpha1.MariaDB{
-				Spec: mariadbv1alpha1.MariaDBSpec{
-					PointInTimeRecoveryRef: &mariadbv1alpha1.LocalObjectReference{
-						Name: "test",
-					},
-					Replication: &mariadbv1alpha1.Replication{
-						Enabled: true,
-					},
-					Storage: mariadbv1alpha1.Storage{
-						Size: ptr.To(resource.MustParse("1Gi")),
-					},
+			name: "Streaming enabled",
+			bootstrapFrom: &mariadbv1alpha1.BootstrapFrom{
+				S3: &mariadbv1alpha1.S3{
+					Bucket:   "test",
+					Endpoint: "test",
+				},
+				Volume: &mariadbv1alpha1.StorageVolumeSource{
+					EmptyDir: &mariadbv1alpha1.EmptyDirVolumeSource{},
+				},
+				Streaming: &mariadbv1alpha1.PhysicalBackupStreaming{
+					Enabled: true,
 				},
 			},
-			wantInitContainers: []string{"mariadb", "backup-meta"},
+			wantContainers:    []string{"mariadb-operator", "mariadb"},
+			wantRestartPolicy: corev1.RestartPolicyNever,
+			wantStreaming:     true,
+		},
+		{
+			name: "Streaming enabled without object storage",
+			bootstrapFrom: &mariadbv1alpha1.BootstrapFrom{
+				Volume: &mariadbv1alpha1.StorageVolumeSource{
+					EmptyDir: &mariadbv1alpha1.EmptyDirVolumeSource{},
+				},
+				Streaming: &mariadbv1alpha1.PhysicalBackupStreaming{
+					Enabled: true,
+				},
+			},
+			wantInitContainers: []string{"mariadb-operator"},
+			wantContainers:     []string{"mariadb"},
+			wantRestartPolicy:  corev1.RestartPolicyOnFailure,
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			builder := newDefaultTestBuilder(t)
-
 			key := types.NamespacedName{
-				Name:      "test-backup",
-				Namespace: "test-namespace",
-			}
-			backup := &mariadbv1alpha1.PhysicalBackup{
-				Spec: mariadbv1alpha1.PhysicalBackupSpec{
-					Storage: mariadbv1alpha1.PhysicalBackupStorage{
-						S3: &mariadbv1alpha1.S3{
-							Bucket:   "test",
-							Endpoint: "test",
-						},
-					},
-					Compression: mariadbv1alpha1.CompressBzip2,
-				},
+				Name:      "physical-backup-restore-job",
+				Namespace: "test",
 			}
-			pod := &corev1.Pod{
+			mariadb := &mariadbv1alpha1.MariaDB{
 				ObjectMeta: metav1.ObjectMeta{
-					Name: "mariadb-0",
+					Name:      "mariadb-test",
+					Namespace: "test",
 				},
-				Spec: corev1.PodSpec{
-					NodeName: "node1",
+				Spec: mariadbv1alpha1.MariaDBSpec{
+					BootstrapFrom: tt.bootstrapFrom,
 				},
 			}
 
-			job, err := builder.BuildPhysicalBackupJob(key, backup, tt.mariadb, pod, "backup.xb.bz2")
-
+			job, err := builder.BuildPhysicalBackupRestoreJob(key, mariadb, ptr.To(0), WithBootstrapFrom(tt.bootstrapFrom))
 			assert.NoError(t, err)
 			assert.NotNil(t, job)
-			assert.NotNil(t, job.Spec.Template.Spec.InitContainers)
-			assert.Len(t, job.Spec.Template.Spec.InitContainers, len(tt.wantInitContainers))
 
-			for i, container := range job.Spec.Template.Spec.InitContainers {
+			podSpec := job.Spec.Template.Spec
+			assert.Equal(t, tt.wantRestartPolicy, podSpec.RestartPolicy)
+			assert.Len(t, podSpec.InitContainers, len(tt.wantInitContainers))
+			for i, container := range podSpec.InitContainers {
 				assert.Equal(t, tt.wantInitContainers[i], container.Name)
 			}
+			assert.Len(t, podSpec.Containers, len(tt.wantContainers))
+			for i, container := range podSpec.Containers {
+				assert.Equal(t, tt.wantContainers[i], container.Name)
+			}
+
+			var operatorArgs, restoreScript string
+			for _, container := range append(podSpec.InitContainers, podSpec.Containers...) {
+				switch container.Name {
+				case "mariadb-operator":
+					operatorArgs = strings.Join(container.Args, " ")
+				case "mariadb":
+					restoreScript = strings.Join(container.Args, " ")
+				}
+			}
+			if tt.wantStreaming {
+				assert.Contains(t, operatorArgs, "--streaming")
+				assert.Contains(t, restoreScript, "mbstream -x -C /var/lib/mysql")
+				assert.NotContains(t, restoreScript, "--copy-back")
+			} else {
+				assert.NotContains(t, operatorArgs, "--streaming")
+				assert.Contains(t, restoreScript, "--copy-back")
+			}
 		})
 	}
 }
@@ -1389,6 +2006,86 @@ func TestRestoreJobMeta(t *testing.T) {
 	}
 }
 
+func TestRestoreJobParallelism(t *testing.T) {
+	tests := []struct {
+		name            string
+		parallelism     *int32
+		wantParallelism string
+	}{
+		{
+			name:            "default",
+			wantParallelism: "-P 4",
+		},
+		{
+			name:            "custom",
+			parallelism:     ptr.To(int32(8)),
+			wantParallelism: "-P 8",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			builder := newDefaultTestBuilder(t)
+			restore := &mariadbv1alpha1.Restore{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "restore-parallelism",
+					Namespace: "test",
+				},
+				Spec: mariadbv1alpha1.RestoreSpec{
+					RestoreSource: mariadbv1alpha1.RestoreSource{
+						S3: &mariadbv1alpha1.S3{
+							Bucket:   "test",
+							Endpoint: "test",
+						},
+					},
+					Parallelism: tt.parallelism,
+				},
+			}
+
+			job, err := builder.BuildRestoreJob(client.ObjectKeyFromObject(restore), restore, &mariadbv1alpha1.MariaDB{})
+			assert.NoError(t, err)
+			assert.NotNil(t, job)
+
+			restoreScript := strings.Join(job.Spec.Template.Spec.Containers[0].Args, " ")
+			assert.Contains(t, restoreScript, tt.wantParallelism)
+			assert.Contains(t, restoreScript, "/backup/0-backup-target.txt')/schema.sql")
+
+			operatorArgs := job.Spec.Template.Spec.InitContainers[0].Args
+			assert.Contains(t, operatorArgs, "--logical-backup-dir-path")
+		})
+	}
+}
+
+func TestRestoreJobTables(t *testing.T) {
+	builder := newDefaultTestBuilder(t)
+	restore := &mariadbv1alpha1.Restore{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "restore-tables",
+			Namespace: "test",
+		},
+		Spec: mariadbv1alpha1.RestoreSpec{
+			RestoreSource: mariadbv1alpha1.RestoreSource{
+				S3: &mariadbv1alpha1.S3{
+					Bucket:   "test",
+					Endpoint: "test",
+				},
+			},
+			Tables: []string{
+				"db.users",
+				"db.orders_*",
+			},
+		},
+	}
+
+	job, err := builder.BuildRestoreJob(client.ObjectKeyFromObject(restore), restore, &mariadbv1alpha1.MariaDB{})
+	assert.NoError(t, err)
+	assert.NotNil(t, job)
+
+	operatorArgs := job.Spec.Template.Spec.InitContainers[0].Args
+	assert.Contains(t, operatorArgs, "--tables")
+	assert.Contains(t, operatorArgs, "db.users,db.orders_*")
+}
+
 func TestPhysicalBackupRestoreJobSelectorLabels(t *testing.T) {
 	builder := newDefaultTestBuilder(t)
 	key := types.NamespacedName{
@@ -3384,7 +4081,7 @@ func TestBuildPITRJob(t *testing.T) {
 	t.Parallel()
 	pitr := &mariadbv1alpha1.PointInTimeRecovery{
 		Spec: mariadbv1alpha1.PointInTimeRecoverySpec{
-			PhysicalBackupRef: mariadbv1alpha1.LocalObjectReference{
+			PhysicalBackupRef: &mariadbv1alpha1.LocalObjectReference{
 				Name: "test",
 			},
 			PointInTimeRecoveryStorage: mariadbv1alpha1.PointInTimeRecoveryStorage{
@@ -3607,6 +4304,243 @@ func TestBuildPITRJob(t *testing.T) {
 	}
 }
 
+func TestBuildPITRJobTargetGtid(t *testing.T) {
+	pitr := &mariadbv1alpha1.PointInTimeRecovery{
+		Spec: mariadbv1alpha1.PointInTimeRecoverySpec{
+			PhysicalBackupRef: &mariadbv1alpha1.LocalObjectReference{
+				Name: "test",
+			},
+			PointInTimeRecoveryStorage: mariadbv1alpha1.PointInTimeRecoveryStorage{
+				S3: &mariadbv1alpha1.S3{
+					Bucket:   "test-bucket",
+					Endpoint: "s3.amazonaws.com",
+				},
+			},
+		},
+	}
+	mariadb := &mariadbv1alpha1.MariaDB{
+		Spec: mariadbv1alpha1.MariaDBSpec{
+			Port: 3306,
+		},
+	}
+	b := newDefaultTestBuilder(t)
+	key := types.NamespacedName{
+		Name:      "test-pitr-job",
+		Namespace: "test",
+	}
+
+	job, err := b.BuildPITRJob(key, pitr, mariadb,
+		WithStartGtid(mustParseGtid(t, "0-10-1")),
+		WithBootstrapFrom(&mariadbv1alpha1.BootstrapFrom{
+			TargetRecoveryGtid:           mustParseGtid(t, "0-10-42"),
+			StopBeforeTargetRecoveryGtid: true,
+			Volume: &mariadbv1alpha1.StorageVolumeSource{
+				EmptyDir: &mariadbv1alpha1.EmptyDirVolumeSource{},
+			},
+		}),
+	)
+	assert.NoError(t, err)
+	assert.NotNil(t, job)
+
+	operatorContainer := job.Spec.Template.Spec.InitContainers[0]
+	assert.Contains(t, strings.Join(operatorContainer.Args, " "), "--target-gtid 0-10-41")
+
+	mariadbContainer := job.Spec.Template.Spec.Containers[0]
+	assert.Contains(t, strings.Join(mariadbContainer.Args, " "), `--stop-position="0-10-41"`)
+	assert.NotContains(t, strings.Join(mariadbContainer.Args, " "), "--stop-datetime")
+}
+
+func TestBuildPITRJobRestore(t *testing.T) {
+	pitr := &mariadbv1alpha1.PointInTimeRecovery{
+		Spec: mariadbv1alpha1.PointInTimeRecoverySpec{
+			BackupRef: &mariadbv1alpha1.LocalObjectReference{
+				Name: "backup",
+			},
+			PointInTimeRecoveryStorage: mariadbv1alpha1.PointInTimeRecoveryStorage{
+				S3: &mariadbv1alpha1.S3{
+					Bucket:   "test-bucket",
+					Endpoint: "s3.amazonaws.com",
+				},
+			},
+		},
+	}
+	mariadb := &mariadbv1alpha1.MariaDB{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "mariadb",
+			Namespace: "test",
+		},
+		Spec: mariadbv1alpha1.MariaDBSpec{
+			Port: 3306,
+		},
+	}
+	restore := &mariadbv1alpha1.Restore{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "restore",
+			Namespace: "test",
+		},
+		Spec: mariadbv1alpha1.RestoreSpec{
+			RestoreSource: mariadbv1alpha1.RestoreSource{
+				TargetRecoveryTime: &metav1.Time{Time: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)},
+			},
+			PointInTimeRecoveryRef: &mariadbv1alpha1.LocalObjectReference{
+				Name: "pitr",
+			},
+		},
+	}
+	b := newDefaultTestBuilder(t)
+
+	job, err := b.BuildPITRJob(restore.PITRJobKey(), pitr, mariadb,
+		WithStartGtid(mustParseGtid(t, "0-10-1")),
+		WithRestore(restore),
+	)
+	assert.NoError(t, err)
+	assert.NotNil(t, job)
+	assert.Equal(t, "restore-pitr", job.Name)
+
+	if assert.Len(t, job.OwnerReferences, 1) {
+		assert.Equal(t, mariadbv1alpha1.RestoreKind, job.OwnerReferences[0].Kind)
+		assert.Equal(t, "restore", job.OwnerReferences[0].Name)
+	}
+	volume := datastructures.Find(job.Spec.Template.Spec.Volumes, func(v corev1.Volume) bool {
+		return v.Name == batchBinlogsVolume
+	})
+	if assert.NotNil(t, volume) {
+		assert.NotNil(t, volume.EmptyDir, "binlogs should be staged in an emptyDir by default")
+	}
+
+	operatorContainer := job.Spec.Template.Spec.InitContainers[0]
+	assert.Contains(t, strings.Join(operatorContainer.Args, " "), "--start-gtid 0-10-1")
+	assert.Contains(t, strings.Join(operatorContainer.Args, " "), "--target-time 2026-01-01T00:00:00Z")
+}
+
+func TestBuildPITRJobSecondaryStorages(t *testing.T) {
+	pitr := &mariadbv1alpha1.PointInTimeRecovery{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "pitr",
+			Namespace: "test",
+		},
+		Spec: mariadbv1alpha1.PointInTimeRecoverySpec{
+			PhysicalBackupRef: &mariadbv1alpha1.LocalObjectReference{
+				Name: "test",
+			},
+			PointInTimeRecoveryStorage: mariadbv1alpha1.PointInTimeRecoveryStorage{
+				S3: &mariadbv1alpha1.S3{
+					Bucket:   "test-bucket",
+					Endpoint: "s3.amazonaws.com",
+				},
+			},
+			SecondaryStorages: []mariadbv1alpha1.SecondaryStorage{
+				{
+					Name: "pvc",
+					PersistentVolumeClaim: &mariadbv1alpha1.PersistentVolumeClaimVolumeSource{
+						ClaimName: "binlogs",
+					},
+				},
+			},
+		},
+	}
+	mariadb := &mariadbv1alpha1.MariaDB{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "mariadb",
+			Namespace: "test",
+		},
+		Spec: mariadbv1alpha1.MariaDBSpec{
+			Port: 3306,
+		},
+	}
+	b := newDefaultTestBuilder(t)
+
+	job, err := b.BuildPITRJob(mariadb.PITRJobKey(), pitr, mariadb,
+		WithBootstrapFrom(&mariadbv1alpha1.BootstrapFrom{
+			TargetRecoveryTime: &metav1.Time{Time: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)},
+			Volume: &mariadbv1alpha1.StorageVolumeSource{
+				EmptyDir: &mariadbv1alpha1.EmptyDirVolumeSource{},
+			},
+		}),
+		WithStartGtid(mustParseGtid(t, "0-10-1")),
+	)
+	assert.NoError(t, err)
+	assert.NotNil(t, job)
+
+	volume := datastructures.Find(job.Spec.Template.Spec.Volumes, func(v corev1.Volume) bool {
+		return v.Name == "secondary-storage-0"
+	})
+	if assert.NotNil(t, volume) && assert.NotNil(t, volume.PersistentVolumeClaim) {
+		assert.Equal(t, "binlogs", volume.PersistentVolumeClaim.ClaimName)
+	}
+
+	operatorContainer := job.Spec.Template.Spec.InitContainers[0]
+	mount := datastructures.Find(operatorContainer.VolumeMounts, func(m corev1.VolumeMount) bool {
+		return m.Name == "secondary-storage-0"
+	})
+	if assert.NotNil(t, mount) {
+		assert.Equal(t, "/backup-secondary/0", mount.MountPath)
+	}
+	env := datastructures.Find(operatorContainer.Env, func(e corev1.EnvVar) bool {
+		return e.Name == backup.SecondaryStorageEnv(0, backup.SecondaryStoragePath)
+	})
+	if assert.NotNil(t, env) {
+		assert.Equal(t, "/backup-secondary/0", env.Value)
+	}
+}
+
+func TestBuildPITRJobFileSystemStorage(t *testing.T) {
+	pitr := &mariadbv1alpha1.PointInTimeRecovery{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "pitr",
+			Namespace: "test",
+		},
+		Spec: mariadbv1alpha1.PointInTimeRecoverySpec{
+			PhysicalBackupRef: &mariadbv1alpha1.LocalObjectReference{
+				Name: "test",
+			},
+			PointInTimeRecoveryStorage: mariadbv1alpha1.PointInTimeRecoveryStorage{
+				PersistentVolumeClaim: &mariadbv1alpha1.PersistentVolumeClaimSpec{
+					AccessModes: []corev1.PersistentVolumeAccessMode{
+						corev1.ReadWriteMany,
+					},
+				},
+			},
+		},
+	}
+	mariadb := &mariadbv1alpha1.MariaDB{
+		Spec: mariadbv1alpha1.MariaDBSpec{
+			Port: 3306,
+		},
+	}
+	b := newDefaultTestBuilder(t)
+	key := types.NamespacedName{
+		Name:      "test-pitr-job",
+		Namespace: "test",
+	}
+
+	job, err := b.BuildPITRJob(key, pitr, mariadb,
+		WithStartGtid(mustParseGtid(t, "0-10-1")),
+		WithBootstrapFrom(&mariadbv1alpha1.BootstrapFrom{
+			TargetRecoveryTime: &metav1.Time{Time: time.Now()},
+			Volume: &mariadbv1alpha1.StorageVolumeSource{
+				EmptyDir: &mariadbv1alpha1.EmptyDirVolumeSource{},
+			},
+		}),
+	)
+	assert.NoError(t, err)
+	assert.NotNil(t, job)
+
+	volume := datastructures.Find(job.Spec.Template.Spec.Volumes, func(v corev1.Volume) bool {
+		return v.Name == PITRStorageVolume
+	})
+	if assert.NotNil(t, volume) && assert.NotNil(t, volume.PersistentVolumeClaim) {
+		assert.Equal(t, "pitr-binlogs", volume.PersistentVolumeClaim.ClaimName)
+	}
+
+	operatorContainer := job.Spec.Template.Spec.InitContainers[0]
+	assert.Contains(t, strings.Join(operatorContainer.Args, " "), "--fs --fs-path "+PITRStorageMountPath)
+	assert.Contains(t, operatorContainer.VolumeMounts, corev1.VolumeMount{
+		Name:      PITRStorageVolume,
+		MountPath: PITRStorageMountPath,
+	})
+}
+
 func TestJobPhysicalBackupVolumes(t *testing.T) {
 	podIndex := 0
 
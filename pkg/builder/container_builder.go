@@ -39,6 +39,14 @@ var (
 	ABSStorageAccountName = "MARIADB_OPERATOR_ABS_STORAGE_ACCOUNT_NAME"
 	ABSCAPath             = "MARIADB_OPERATOR_ABS_CA_PATH"
 
+	GCSServiceAccountKey = "MARIADB_OPERATOR_GCS_SERVICE_ACCOUNT_KEY"
+
+	PITRStoragePath = "MARIADB_OPERATOR_PITR_STORAGE_PATH"
+
+	EncryptionKey               = "MARIADB_OPERATOR_ENCRYPTION_KEY"
+	EncryptionPreviousKeyPrefix = "MARIADB_OPERATOR_ENCRYPTION_PREVIOUS_KEY_"
+	EncryptionAllowUnencrypted  = "MARIADB_OPERATOR_ENCRYPTION_ALLOW_UNENCRYPTED"
+
 	defaultProbe = corev1.Probe{
 		ProbeHandler: corev1.ProbeHandler{
 			Exec: &corev1.ExecAction{
@@ -213,6 +221,10 @@ func (b *Builder) dataPlaneAgentContainer(mariadb *mariadbv1alpha1.MariaDB, opts
 	if mariadbOpts.pointInTimeRecovery != nil {
 		env = append(env, s3Env(mariadbOpts.pointInTimeRecovery.Spec.PointInTimeRecoveryStorage.S3)...)
 		env = append(env, absEnv(mariadbOpts.pointInTimeRecovery.Spec.PointInTimeRecoveryStorage.AzureBlob)...)
+		env = append(env, gcsEnv(mariadbOpts.pointInTimeRecovery.Spec.PointInTimeRecoveryStorage.GCS)...)
+		env = append(env, pitrStorageEnv(&mariadbOpts.pointInTimeRecovery.Spec.PointInTimeRecoveryStorage)...)
+		env = append(env, encryptionEnv(mariadbOpts.pointInTimeRecovery.Spec.Encryption)...)
+		env = append(env, secondaryStorageEnv(mariadbOpts.pointInTimeRecovery.Spec.SecondaryStorages)...)
 	}
 	volumeMounts, err := mariadbVolumeMounts(mariadb, opts...)
 	if err != nil {
@@ -696,6 +708,61 @@ func absEnv(abs *mariadbv1alpha1.AzureBlob) []corev1.EnvVar {
 	return env
 }
 
+func pitrStorageEnv(storage *mariadbv1alpha1.PointInTimeRecoveryStorage) []corev1.EnvVar {
+	if !storage.IsFileSystem() {
+		return nil
+	}
+	return []corev1.EnvVar{
+		{
+			Name:  PITRStoragePath,
+			Value: PITRStorageMountPath,
+		},
+	}
+}
+
+func gcsEnv(gcs *mariadbv1alpha1.GCS) []corev1.EnvVar {
+	if gcs == nil || gcs.ServiceAccountKeySecretKeyRef == nil {
+		return nil
+	}
+	return []corev1.EnvVar{
+		{
+			Name: GCSServiceAccountKey,
+			ValueFrom: &corev1.EnvVarSource{
+				SecretKeyRef: ptr.To(gcs.ServiceAccountKeySecretKeyRef.ToKubernetesType()),
+			},
+		},
+	}
+}
+
+func encryptionEnv(encryption *mariadbv1alpha1.Encryption) []corev1.EnvVar {
+	if encryption == nil {
+		return nil
+	}
+	env := []corev1.EnvVar{
+		{
+			Name: EncryptionKey,
+			ValueFrom: &corev1.EnvVarSource{
+				SecretKeyRef: ptr.To(encryption.KeySecretKeyRef.ToKubernetesType()),
+			},
+		},
+	}
+	for i, ref := range encryption.PreviousKeySecretKeyRefs {
+		env = append(env, corev1.EnvVar{
+			Name: fmt.Sprintf("%s%d", EncryptionPreviousKeyPrefix, i),
+			ValueFrom: &corev1.EnvVarSource{
+				SecretKeyRef: ptr.To(ref.ToKubernetesType()),
+			},
+		})
+	}
+	if encryption.AllowUnencrypted {
+		env = append(env, corev1.EnvVar{
+			Name:  EncryptionAllowUnencrypted,
+			Value: strconv.FormatBool(encryption.AllowUnencrypted),
+		})
+	}
+	return env
+}
+
 func mariadbStorageVolumeMount(mariadb *mariadbv1alpha1.MariaDB) corev1.VolumeMount {
 	galera := ptr.Deref(mariadb.Spec.Galera, mariadbv1alpha1.Galera{})
 	reuseStorageVolume := ptr.Deref(galera.Config.ReuseStorageVolume, false)
@@ -729,6 +796,12 @@ func mariadbVolumeMounts(mariadb *mariadbv1alpha1.MariaDB, opts ...mariadbPodOpt
 
 		_, absVolumeMounts := absVolumes(mariadbOpts.pointInTimeRecovery.Spec.PointInTimeRecoveryStorage.AzureBlob)
 		volumeMounts = append(volumeMounts, absVolumeMounts...)
+
+		_, pitrStorageVolumeMounts := pitrStorageVolumes(mariadbOpts.pointInTimeRecovery)
+		volumeMounts = append(volumeMounts, pitrStorageVolumeMounts...)
+
+		_, secondaryVolumeMounts := secondaryStorageVolumes(mariadbOpts.pointInTimeRecovery.Spec.SecondaryStorages)
+		volumeMounts = append(volumeMounts, secondaryVolumeMounts...)
 	}
 
 	volumeMounts = append(volumeMounts, mariadbStorageVolumeMount(mariadb))
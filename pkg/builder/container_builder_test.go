@@ -1788,6 +1788,165 @@ func TestS3Env(t *testing.T) {
 	}
 }
 
+func TestEncryptionEnv(t *testing.T) {
+	tests := []struct {
+		name        string
+		encryption  *mariadbv1alpha1.Encryption
+		expectedEnv []string
+	}{
+		{
+			name:        "nil encryption",
+			encryption:  nil,
+			expectedEnv: nil,
+		},
+		{
+			name: "active key",
+			encryption: &mariadbv1alpha1.Encryption{
+				KeySecretKeyRef: mariadbv1alpha1.SecretKeySelector{
+					LocalObjectReference: mariadbv1alpha1.LocalObjectReference{
+						Name: "encryption",
+					},
+					Key: "key",
+				},
+			},
+			expectedEnv: []string{EncryptionKey},
+		},
+		{
+			name: "previous keys",
+			encryption: &mariadbv1alpha1.Encryption{
+				KeySecretKeyRef: mariadbv1alpha1.SecretKeySelector{
+					LocalObjectReference: mariadbv1alpha1.LocalObjectReference{
+						Name: "encryption",
+					},
+					Key: "key",
+				},
+				PreviousKeySecretKeyRefs: []mariadbv1alpha1.SecretKeySelector{
+					{
+						LocalObjectReference: mariadbv1alpha1.LocalObjectReference{
+							Name: "encryption",
+						},
+						Key: "previous-key-0",
+					},
+					{
+						LocalObjectReference: mariadbv1alpha1.LocalObjectReference{
+							Name: "encryption",
+						},
+						Key: "previous-key-1",
+					},
+				},
+			},
+			expectedEnv: []string{EncryptionKey, EncryptionPreviousKeyPrefix + "0", EncryptionPreviousKeyPrefix + "1"},
+		},
+		{
+			name: "allow unencrypted",
+			encryption: &mariadbv1alpha1.Encryption{
+				KeySecretKeyRef: mariadbv1alpha1.SecretKeySelector{
+					LocalObjectReference: mariadbv1alpha1.LocalObjectReference{
+						Name: "encryption",
+					},
+					Key: "key",
+				},
+				AllowUnencrypted: true,
+			},
+			expectedEnv: []string{EncryptionKey, EncryptionAllowUnencrypted},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			env := encryptionEnv(tt.encryption)
+
+			if tt.expectedEnv == nil {
+				if env != nil {
+					t.Errorf("expected nil env, got: %v", env)
+				}
+				return
+			}
+
+			if len(env) != len(tt.expectedEnv) {
+				t.Errorf("expected %d env vars, got: %d", len(tt.expectedEnv), len(env))
+				return
+			}
+
+			for i, expectedName := range tt.expectedEnv {
+				if env[i].Name != expectedName {
+					t.Errorf("expected env var %s at index %d, got: %s", expectedName, i, env[i].Name)
+				}
+				if expectedName == EncryptionAllowUnencrypted {
+					if env[i].Value != "true" {
+						t.Errorf("expected env var %s to be true, got: %s", expectedName, env[i].Value)
+					}
+					continue
+				}
+				if env[i].ValueFrom == nil || env[i].ValueFrom.SecretKeyRef == nil {
+					t.Errorf("expected env var %s to reference a Secret", expectedName)
+				}
+			}
+		})
+	}
+}
+
+func TestGCSEnv(t *testing.T) {
+	tests := []struct {
+		name        string
+		gcs         *mariadbv1alpha1.GCS
+		expectedEnv []string
+	}{
+		{
+			name:        "nil gcs",
+			gcs:         nil,
+			expectedEnv: nil,
+		},
+		{
+			name: "workload identity",
+			gcs: &mariadbv1alpha1.GCS{
+				Bucket: "backups",
+			},
+			expectedEnv: nil,
+		},
+		{
+			name: "service account key",
+			gcs: &mariadbv1alpha1.GCS{
+				Bucket: "backups",
+				ServiceAccountKeySecretKeyRef: &mariadbv1alpha1.SecretKeySelector{
+					LocalObjectReference: mariadbv1alpha1.LocalObjectReference{
+						Name: "gcs",
+					},
+					Key: "service-account.json",
+				},
+			},
+			expectedEnv: []string{GCSServiceAccountKey},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			env := gcsEnv(tt.gcs)
+
+			if tt.expectedEnv == nil {
+				if env != nil {
+					t.Errorf("expected nil env, got: %v", env)
+				}
+				return
+			}
+
+			if len(env) != len(tt.expectedEnv) {
+				t.Errorf("expected %d env vars, got: %d", len(tt.expectedEnv), len(env))
+				return
+			}
+
+			for i, expectedName := range tt.expectedEnv {
+				if env[i].Name != expectedName {
+					t.Errorf("expected env var %s at index %d, got: %s", expectedName, i, env[i].Name)
+				}
+				if env[i].ValueFrom == nil || env[i].ValueFrom.SecretKeyRef == nil {
+					t.Errorf("expected env var %s to reference a Secret", expectedName)
+				}
+			}
+		})
+	}
+}
+
 func TestContainerArgs(t *testing.T) {
 	tests := []struct {
 		name     string
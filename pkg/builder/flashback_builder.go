@@ -0,0 +1,301 @@
+package builder
+
+import (
+	"fmt"
+	"path/filepath"
+
+	mariadbv1alpha1 "github.com/mariadb-operator/mariadb-operator/v26/api/v1alpha1"
+	metadata "github.com/mariadb-operator/mariadb-operator/v26/pkg/builder/metadata"
+	builderpki "github.com/mariadb-operator/mariadb-operator/v26/pkg/builder/pki"
+	"github.com/mariadb-operator/mariadb-operator/v26/pkg/command"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+var batchFlashbackFilePath = filepath.Join(batchBinlogsMountPath, mariadbv1alpha1.FlashbackOutputDefaultKey)
+
+// BuildFlashbackJob builds the Job that generates the reverse SQL of a Flashback. The binlogs of the window are pulled
+// from the PointInTimeRecovery storage, mariadb-binlog generates the reverse SQL and the operator stores it in the output object.
+func (b *Builder) BuildFlashbackJob(key types.NamespacedName, flashback *mariadbv1alpha1.Flashback,
+	pitr *mariadbv1alpha1.PointInTimeRecovery, mariadb *mariadbv1alpha1.MariaDB) (*batchv1.Job, error) {
+	jobMeta :=
+		metadata.NewMetadataBuilder(key).
+			WithMetadata(flashback.Spec.InheritMetadata).
+			Build()
+	podMeta :=
+		metadata.NewMetadataBuilder(key).
+			WithMetadata(flashback.Spec.InheritMetadata).
+			WithMetadata(flashback.Spec.PodMetadata).
+			Build()
+
+	cmdOpts := []command.BackupOpt{
+		command.WithPath(
+			batchBinlogsMountPath,
+			batchBinlogsTargetFilePath,
+			batchBackupDirFullPath,
+		),
+		command.WithStartGtid(flashback.Spec.StartGtid),
+		command.WithTargetGtid(flashback.Spec.EndGtid),
+		command.WithFlashback(batchFlashbackFilePath, flashback.Spec.Database, flashback.Spec.Table),
+		command.WithCompression(pitr.Spec.Compression),
+		command.WithThrottling(pitr.Spec.Throttling),
+		command.WithUserEnv(batchUserEnv),
+		command.WithPasswordEnv(batchPasswordEnv),
+		command.WithLogLevel(flashback.Spec.LogLevel),
+		command.WithExtraOpts(flashback.Spec.Args),
+	}
+	if flashback.Spec.StartTime != nil {
+		cmdOpts = append(cmdOpts, command.WithStartTime(&flashback.Spec.StartTime.Time))
+	}
+	if flashback.Spec.EndTime != nil {
+		cmdOpts = append(cmdOpts, command.WithTargetTime(flashback.Spec.EndTime.Time))
+	}
+	cmdOpts = append(cmdOpts, s3Opts(pitr.Spec.PointInTimeRecoveryStorage.S3)...)
+	cmdOpts = append(cmdOpts, absOpts(pitr.Spec.PointInTimeRecoveryStorage.AzureBlob)...)
+	cmdOpts = append(cmdOpts, gcsOpts(pitr.Spec.PointInTimeRecoveryStorage.GCS)...)
+	cmdOpts = append(cmdOpts, fileSystemOpts(&pitr.Spec.PointInTimeRecoveryStorage)...)
+	cmdOpts = append(cmdOpts, secondaryStorageOpts(pitr.Spec.SecondaryStorages)...)
+
+	cmd, err := command.NewBackupCommand(cmdOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("error building backup command: %v", err)
+	}
+	operatorPITRCmd, err := cmd.MariadbOperatorPITR(false)
+	if err != nil {
+		return nil, fmt.Errorf("error getting operator PITR command: %v", err)
+	}
+	mariadbBinlogCmd, err := cmd.MariadbBinlog(mariadb)
+	if err != nil {
+		return nil, fmt.Errorf("error getting mariadb-binlog command: %v", err)
+	}
+	operatorFlashbackCmd, err := cmd.MariadbOperatorFlashback(client.ObjectKeyFromObject(flashback))
+	if err != nil {
+		return nil, fmt.Errorf("error getting operator flashback command: %v", err)
+	}
+
+	volumes, volumeMounts := jobPITRVolumes(
+		corev1.VolumeSource{
+			EmptyDir: &corev1.EmptyDirVolumeSource{},
+		},
+		pitr.Spec.PointInTimeRecoveryStorage.S3,
+		pitr.Spec.PointInTimeRecoveryStorage.AzureBlob,
+		mariadb,
+	)
+	pitrStorageVolumes, pitrStorageVolumeMounts := pitrStorageVolumes(pitr)
+	volumes = append(volumes, pitrStorageVolumes...)
+	volumeMounts = append(volumeMounts, pitrStorageVolumeMounts...)
+	secondaryVolumes, secondaryVolumeMounts := secondaryStorageVolumes(pitr.Spec.SecondaryStorages)
+	volumes = append(volumes, secondaryVolumes...)
+	volumeMounts = append(volumeMounts, secondaryVolumeMounts...)
+
+	operatorEnv := append(
+		s3Env(pitr.Spec.PointInTimeRecoveryStorage.S3),
+		absEnv(pitr.Spec.PointInTimeRecoveryStorage.AzureBlob)...,
+	)
+	operatorEnv = append(operatorEnv, gcsEnv(pitr.Spec.PointInTimeRecoveryStorage.GCS)...)
+	operatorEnv = append(operatorEnv, encryptionEnv(pitr.Spec.Encryption)...)
+	operatorEnv = append(operatorEnv, secondaryStorageEnv(pitr.Spec.SecondaryStorages)...)
+
+	pitrContainer, err := b.jobContainer(
+		"pitr",
+		operatorPITRCmd,
+		b.env.MariadbOperatorImage,
+		volumeMounts,
+		operatorEnv,
+		jobResources(flashback.Spec.Resources),
+		mariadb,
+		flashback.Spec.SecurityContext,
+	)
+	if err != nil {
+		return nil, err
+	}
+	mariadbContainer, err := b.jobMariadbContainer(
+		mariadbBinlogCmd,
+		b.env,
+		volumeMounts,
+		nil,
+		jobResources(flashback.Spec.Resources),
+		mariadb,
+		flashback.Spec.SecurityContext,
+	)
+	if err != nil {
+		return nil, err
+	}
+	operatorContainer, err := b.jobMariadbOperatorContainer(
+		operatorFlashbackCmd,
+		volumeMounts,
+		nil,
+		jobResources(flashback.Spec.Resources),
+		mariadb,
+		b.env,
+		flashback.Spec.SecurityContext,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	securityContext, err := b.buildPodSecurityContextWithUserGroup(flashback.Spec.PodSecurityContext, mysqlUser, mysqlGroup)
+	if err != nil {
+		return nil, err
+	}
+	affinity := ptr.Deref(flashback.Spec.Affinity, mariadbv1alpha1.AffinityConfig{}).Affinity
+
+	job := &batchv1.Job{
+		ObjectMeta: jobMeta,
+		Spec: batchv1.JobSpec{
+			BackoffLimit: &flashback.Spec.BackoffLimit,
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: podMeta,
+				Spec: corev1.PodSpec{
+					RestartPolicy:      corev1.RestartPolicyOnFailure,
+					ImagePullSecrets:   batchImagePullSecrets(mariadb, flashback.Spec.ImagePullSecrets),
+					Volumes:            volumes,
+					InitContainers:     []corev1.Container{*pitrContainer, *mariadbContainer},
+					Containers:         []corev1.Container{*operatorContainer},
+					Affinity:           ptr.To(affinity.ToKubernetesType()),
+					NodeSelector:       flashback.Spec.NodeSelector,
+					Tolerations:        flashback.Spec.Tolerations,
+					SecurityContext:    securityContext,
+					ServiceAccountName: ptr.Deref(flashback.Spec.ServiceAccountName, "default"),
+					PriorityClassName:  ptr.Deref(flashback.Spec.PriorityClassName, ""),
+				},
+			},
+		},
+	}
+	if err := controllerutil.SetControllerReference(flashback, job, b.scheme); err != nil {
+		return nil, fmt.Errorf("error setting controller reference to Job: %v", err)
+	}
+	return job, nil
+}
+
+// BuildFlashbackApplyJob builds the Job that applies the reverse SQL stored in the output object of a Flashback.
+// The reverse SQL is applied in a single transaction and only attempted once.
+func (b *Builder) BuildFlashbackApplyJob(key types.NamespacedName, flashback *mariadbv1alpha1.Flashback,
+	mariadb *mariadbv1alpha1.MariaDB) (*batchv1.Job, error) {
+	jobMeta :=
+		metadata.NewMetadataBuilder(key).
+			WithMetadata(flashback.Spec.InheritMetadata).
+			Build()
+	podMeta :=
+		metadata.NewMetadataBuilder(key).
+			WithMetadata(flashback.Spec.InheritMetadata).
+			WithMetadata(flashback.Spec.PodMetadata).
+			Build()
+
+	sqlOpts := []command.SqlOpt{
+		command.WithSqlUserEnv(batchUserEnv),
+		command.WithSqlPasswordEnv(batchPasswordEnv),
+		command.WithSqlFile(filepath.Join(batchScriptsMountPath, batchScriptsSqlFile)),
+	}
+	if mariadb.IsTLSEnabled() {
+		sqlOpts = append(sqlOpts, command.WithSSL(
+			builderpki.CACertPath,
+			builderpki.ClientCertPath,
+			builderpki.ClientKeyPath,
+		))
+	}
+	cmd, err := command.NewSqlCommand(sqlOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("error building sql command: %v", err)
+	}
+	execCmd, err := cmd.ExecCommand(mariadb)
+	if err != nil {
+		return nil, fmt.Errorf("error building exec command: %v", err)
+	}
+
+	volumes, volumeMounts := flashbackApplyVolumes(flashback, mariadb)
+	container, err := b.jobMariadbContainer(
+		execCmd,
+		b.env,
+		volumeMounts,
+		jobEnv(mariadb),
+		jobResources(flashback.Spec.Resources),
+		mariadb,
+		flashback.Spec.SecurityContext,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	securityContext, err := b.buildPodSecurityContext(flashback.Spec.PodSecurityContext)
+	if err != nil {
+		return nil, err
+	}
+	affinity := ptr.Deref(flashback.Spec.Affinity, mariadbv1alpha1.AffinityConfig{}).Affinity
+
+	job := &batchv1.Job{
+		ObjectMeta: jobMeta,
+		Spec: batchv1.JobSpec{
+			// The reverse SQL is not idempotent, a failed attempt must not be retried.
+			BackoffLimit: ptr.To(int32(0)),
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: podMeta,
+				Spec: corev1.PodSpec{
+					RestartPolicy:      corev1.RestartPolicyNever,
+					ImagePullSecrets:   batchImagePullSecrets(mariadb, flashback.Spec.ImagePullSecrets),
+					Volumes:            volumes,
+					Containers:         []corev1.Container{*container},
+					Affinity:           ptr.To(affinity.ToKubernetesType()),
+					NodeSelector:       flashback.Spec.NodeSelector,
+					Tolerations:        flashback.Spec.Tolerations,
+					SecurityContext:    securityContext,
+					ServiceAccountName: ptr.Deref(flashback.Spec.ServiceAccountName, "default"),
+					PriorityClassName:  ptr.Deref(flashback.Spec.PriorityClassName, ""),
+				},
+			},
+		},
+	}
+	if err := controllerutil.SetControllerReference(flashback, job, b.scheme); err != nil {
+		return nil, fmt.Errorf("error setting controller reference to Job: %v", err)
+	}
+	return job, nil
+}
+
+func flashbackApplyVolumes(flashback *mariadbv1alpha1.Flashback,
+	mariadb *mariadbv1alpha1.MariaDB) ([]corev1.Volume, []corev1.VolumeMount) {
+	items := []corev1.KeyToPath{
+		{
+			Key:  flashback.OutputDataKey(),
+			Path: batchScriptsSqlFile,
+		},
+	}
+	outputKey := flashback.OutputKey()
+
+	var volumeSource corev1.VolumeSource
+	if flashback.OutputKind() == mariadbv1alpha1.FlashbackOutputSecret {
+		volumeSource.Secret = &corev1.SecretVolumeSource{
+			SecretName: outputKey.Name,
+			Items:      items,
+		}
+	} else {
+		volumeSource.ConfigMap = &corev1.ConfigMapVolumeSource{
+			LocalObjectReference: corev1.LocalObjectReference{
+				Name: outputKey.Name,
+			},
+			Items: items,
+		}
+	}
+
+	volumes := []corev1.Volume{
+		{
+			Name:         batchScriptsVolume,
+			VolumeSource: volumeSource,
+		},
+	}
+	volumeMounts := []corev1.VolumeMount{
+		{
+			Name:      batchScriptsVolume,
+			MountPath: batchScriptsMountPath,
+		},
+	}
+	if mariadb.IsTLSEnabled() {
+		tlsVolumes, tlsVolumeMounts := mariadbTLSVolumes(mariadb)
+		volumes = append(volumes, tlsVolumes...)
+		volumeMounts = append(volumeMounts, tlsVolumeMounts...)
+	}
+	return volumes, volumeMounts
+}
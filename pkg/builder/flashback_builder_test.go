@@ -0,0 +1,193 @@
+package builder
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	mariadbv1alpha1 "github.com/mariadb-operator/mariadb-operator/v26/api/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+)
+
+func TestBuildFlashbackJob(t *testing.T) {
+	b := newDefaultTestBuilder(t)
+	pitr := &mariadbv1alpha1.PointInTimeRecovery{
+		Spec: mariadbv1alpha1.PointInTimeRecoverySpec{
+			PointInTimeRecoveryStorage: mariadbv1alpha1.PointInTimeRecoveryStorage{
+				S3: &mariadbv1alpha1.S3{
+					Bucket:   "test-bucket",
+					Endpoint: "s3.amazonaws.com",
+				},
+			},
+		},
+	}
+	mariadb := &mariadbv1alpha1.MariaDB{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "mariadb",
+			Namespace: "test",
+		},
+		Spec: mariadbv1alpha1.MariaDBSpec{
+			Port: 3306,
+		},
+	}
+	key := types.NamespacedName{
+		Name:      "flashback",
+		Namespace: "test",
+	}
+
+	t.Run("time window", func(t *testing.T) {
+		startTime := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+		flashback := &mariadbv1alpha1.Flashback{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      key.Name,
+				Namespace: key.Namespace,
+			},
+			Spec: mariadbv1alpha1.FlashbackSpec{
+				StartTime:    &metav1.Time{Time: startTime},
+				EndTime:      &metav1.Time{Time: startTime.Add(5 * time.Minute)},
+				Database:     ptr.To("db"),
+				Table:        ptr.To("users"),
+				BackoffLimit: 3,
+			},
+		}
+
+		job, err := b.BuildFlashbackJob(key, flashback, pitr, mariadb)
+		assert.NoError(t, err)
+		assert.Equal(t, int32(3), *job.Spec.BackoffLimit)
+		assert.Len(t, job.OwnerReferences, 1)
+		assert.Equal(t, "Flashback", job.OwnerReferences[0].Kind)
+
+		initContainers := job.Spec.Template.Spec.InitContainers
+		assert.Len(t, initContainers, 2)
+		pitrArgs := strings.Join(initContainers[0].Args, " ")
+		assert.Contains(t, pitrArgs, "--start-time 2026-01-01T12:00:00Z")
+		assert.Contains(t, pitrArgs, "--target-time 2026-01-01T12:05:00Z")
+		assert.Contains(t, pitrArgs, "--s3-bucket test-bucket")
+
+		binlogArgs := strings.Join(initContainers[1].Args, " ")
+		assert.Contains(t, binlogArgs, "--flashback")
+		assert.Contains(t, binlogArgs, `--start-datetime="2026-01-01 12:00:00"`)
+		assert.Contains(t, binlogArgs, `--stop-datetime="2026-01-01 12:05:00"`)
+		assert.Contains(t, binlogArgs, `--database="db" --table="users"`)
+		assert.NotContains(t, binlogArgs, "| mariadb")
+
+		containers := job.Spec.Template.Spec.Containers
+		assert.Len(t, containers, 1)
+		assert.Equal(t, []string{
+			"pitr",
+			"flashback",
+			"--sql-file-path",
+			"/binlogs/flashback.sql",
+			"--flashback-name",
+			"flashback",
+			"--flashback-namespace",
+			"test",
+		}, containers[0].Args)
+		assert.NotNil(t, job.Spec.Template.Spec.Volumes[0].EmptyDir)
+	})
+
+	t.Run("GTID window", func(t *testing.T) {
+		flashback := &mariadbv1alpha1.Flashback{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      key.Name,
+				Namespace: key.Namespace,
+			},
+			Spec: mariadbv1alpha1.FlashbackSpec{
+				StartGtid: mustParseGtid(t, "0-10-5"),
+				EndGtid:   mustParseGtid(t, "0-10-42"),
+			},
+		}
+
+		job, err := b.BuildFlashbackJob(key, flashback, pitr, mariadb)
+		assert.NoError(t, err)
+
+		initContainers := job.Spec.Template.Spec.InitContainers
+		pitrArgs := strings.Join(initContainers[0].Args, " ")
+		assert.Contains(t, pitrArgs, "--start-gtid 0-10-5")
+		assert.Contains(t, pitrArgs, "--target-gtid 0-10-42")
+
+		binlogArgs := strings.Join(initContainers[1].Args, " ")
+		assert.Contains(t, binlogArgs, `--start-position="0-10-4" --stop-position="0-10-42"`)
+	})
+}
+
+func TestBuildFlashbackApplyJob(t *testing.T) {
+	b := newDefaultTestBuilder(t)
+	mariadb := &mariadbv1alpha1.MariaDB{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "mariadb",
+			Namespace: "test",
+		},
+		Spec: mariadbv1alpha1.MariaDBSpec{
+			Port: 3306,
+		},
+	}
+	key := types.NamespacedName{
+		Name:      "flashback-apply",
+		Namespace: "test",
+	}
+
+	tests := []struct {
+		name          string
+		output        mariadbv1alpha1.FlashbackOutput
+		wantConfigMap string
+		wantSecret    string
+		wantKey       string
+	}{
+		{
+			name:          "default output",
+			wantConfigMap: "flashback",
+			wantKey:       "flashback.sql",
+		},
+		{
+			name: "secret output",
+			output: mariadbv1alpha1.FlashbackOutput{
+				Kind: mariadbv1alpha1.FlashbackOutputSecret,
+				Name: "reverse-sql",
+				Key:  "undo.sql",
+			},
+			wantSecret: "reverse-sql",
+			wantKey:    "undo.sql",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			flashback := &mariadbv1alpha1.Flashback{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "flashback",
+					Namespace: "test",
+				},
+				Spec: mariadbv1alpha1.FlashbackSpec{
+					Output: tt.output,
+				},
+			}
+
+			job, err := b.BuildFlashbackApplyJob(key, flashback, mariadb)
+			assert.NoError(t, err)
+			assert.Len(t, job.OwnerReferences, 1)
+
+			volume := job.Spec.Template.Spec.Volumes[0]
+			if tt.wantConfigMap != "" {
+				assert.NotNil(t, volume.ConfigMap)
+				assert.Equal(t, tt.wantConfigMap, volume.ConfigMap.Name)
+				assert.Equal(t, tt.wantKey, volume.ConfigMap.Items[0].Key)
+			}
+			if tt.wantSecret != "" {
+				assert.NotNil(t, volume.Secret)
+				assert.Equal(t, tt.wantSecret, volume.Secret.SecretName)
+				assert.Equal(t, tt.wantKey, volume.Secret.Items[0].Key)
+			}
+
+			assert.Equal(t, int32(0), *job.Spec.BackoffLimit)
+			assert.Equal(t, corev1.RestartPolicyNever, job.Spec.Template.Spec.RestartPolicy)
+
+			container := job.Spec.Template.Spec.Containers[0]
+			assert.Contains(t, strings.Join(container.Args, " "), "< /opt/job.sql")
+		})
+	}
+}
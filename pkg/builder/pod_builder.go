@@ -358,6 +358,12 @@ func mariadbVolumes(mariadb *mariadbv1alpha1.MariaDB, opts ...mariadbPodOpt) ([]
 
 		absVolumes, _ := absVolumes(mariadbOpts.pointInTimeRecovery.Spec.PointInTimeRecoveryStorage.AzureBlob)
 		volumes = append(volumes, absVolumes...)
+
+		pitrStorageVolumes, _ := pitrStorageVolumes(mariadbOpts.pointInTimeRecovery)
+		volumes = append(volumes, pitrStorageVolumes...)
+
+		secondaryVolumes, _ := secondaryStorageVolumes(mariadbOpts.pointInTimeRecovery.Spec.SecondaryStorages)
+		volumes = append(volumes, secondaryVolumes...)
 	}
 	if mariadb.IsReplicationEnabled() {
 		volumes = append(volumes, corev1.Volume{
@@ -679,6 +685,30 @@ func s3Volumes(s3 *mariadbv1alpha1.S3) ([]corev1.Volume, []corev1.VolumeMount) {
 	return nil, nil
 }
 
+// pitrStorageVolumes returns the volume where the binary logs are kept when the PointInTimeRecovery uses filesystem storage.
+func pitrStorageVolumes(pitr *mariadbv1alpha1.PointInTimeRecovery) ([]corev1.Volume, []corev1.VolumeMount) {
+	if !pitr.Spec.PointInTimeRecoveryStorage.IsFileSystem() {
+		return nil, nil
+	}
+	volume, err := pitr.Volume()
+	if err != nil {
+		return nil, nil
+	}
+	volumes := []corev1.Volume{
+		{
+			Name:         PITRStorageVolume,
+			VolumeSource: volume.ToKubernetesType(),
+		},
+	}
+	volumeMounts := []corev1.VolumeMount{
+		{
+			Name:      PITRStorageVolume,
+			MountPath: PITRStorageMountPath,
+		},
+	}
+	return volumes, volumeMounts
+}
+
 func absVolumes(abs *mariadbv1alpha1.AzureBlob) ([]corev1.Volume, []corev1.VolumeMount) {
 	if abs != nil && abs.TLS != nil && abs.TLS.Enabled && abs.TLS.CASecretKeyRef != nil {
 		return []corev1.Volume{
@@ -0,0 +1,130 @@
+package builder
+
+import (
+	"fmt"
+	"path/filepath"
+
+	mariadbv1alpha1 "github.com/mariadb-operator/mariadb-operator/v26/api/v1alpha1"
+	"github.com/mariadb-operator/mariadb-operator/v26/pkg/backup"
+	"github.com/mariadb-operator/mariadb-operator/v26/pkg/command"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/ptr"
+)
+
+const (
+	secondaryStorageVolume       = "secondary-storage-%d"
+	secondaryStorageMountPath    = "/backup-secondary/%d"
+	secondaryStoragePKIVolume    = "pki-secondary-storage-%d"
+	secondaryStoragePKIMountPath = "/etc/secondary-storage/%d/pki"
+)
+
+func secondaryStorageOpts(storages []mariadbv1alpha1.SecondaryStorage) []command.BackupOpt {
+	if len(storages) == 0 {
+		return nil
+	}
+	return []command.BackupOpt{
+		command.WithSecondaryStorages(storages),
+	}
+}
+
+// secondaryStorageEnv provides the settings of the secondary storages to the mariadb-operator container.
+// Each secondary storage is identified by its index, see backup.SecondaryStorageEnv.
+func secondaryStorageEnv(storages []mariadbv1alpha1.SecondaryStorage) []corev1.EnvVar {
+	var env []corev1.EnvVar
+	secretEnv := func(index int, setting string, selector mariadbv1alpha1.SecretKeySelector) corev1.EnvVar {
+		return corev1.EnvVar{
+			Name: backup.SecondaryStorageEnv(index, setting),
+			ValueFrom: &corev1.EnvVarSource{
+				SecretKeyRef: ptr.To(selector.ToKubernetesType()),
+			},
+		}
+	}
+
+	for i, storage := range storages {
+		if s3 := storage.S3; s3 != nil {
+			if s3.AccessKeyIdSecretKeyRef != nil && s3.SecretAccessKeySecretKeyRef != nil {
+				env = append(env,
+					secretEnv(i, backup.SecondaryStorageS3AccessKeyID, *s3.AccessKeyIdSecretKeyRef),
+					secretEnv(i, backup.SecondaryStorageS3SecretAccessKey, *s3.SecretAccessKeySecretKeyRef),
+				)
+			}
+			if s3.SessionTokenSecretKeyRef != nil {
+				env = append(env, secretEnv(i, backup.SecondaryStorageS3SessionToken, *s3.SessionTokenSecretKeyRef))
+			}
+			if s3.SSEC != nil {
+				env = append(env, secretEnv(i, backup.SecondaryStorageS3SSECCustomerKey, s3.SSEC.CustomerKeySecretKeyRef))
+			}
+		}
+		if abs := storage.AzureBlob; abs != nil {
+			if abs.StorageAccountKey != nil {
+				env = append(env, secretEnv(i, backup.SecondaryStorageABSAccountKey, *abs.StorageAccountKey))
+			}
+		}
+		if caSecretKeyRef := secondaryStorageCASecretKeyRef(&storage); caSecretKeyRef != nil {
+			env = append(env, corev1.EnvVar{
+				Name:  backup.SecondaryStorageEnv(i, backup.SecondaryStorageCACertPath),
+				Value: filepath.Join(fmt.Sprintf(secondaryStoragePKIMountPath, i), caSecretKeyRef.Key),
+			})
+		}
+		if storage.PersistentVolumeClaim != nil {
+			env = append(env, corev1.EnvVar{
+				Name:  backup.SecondaryStorageEnv(i, backup.SecondaryStoragePath),
+				Value: fmt.Sprintf(secondaryStorageMountPath, i),
+			})
+		}
+	}
+	return env
+}
+
+// secondaryStorageVolumes mounts the PVCs and the CA bundles of the secondary storages.
+func secondaryStorageVolumes(storages []mariadbv1alpha1.SecondaryStorage) ([]corev1.Volume, []corev1.VolumeMount) {
+	var (
+		volumes      []corev1.Volume
+		volumeMounts []corev1.VolumeMount
+	)
+	for i, storage := range storages {
+		if pvc := storage.PersistentVolumeClaim; pvc != nil {
+			volumeName := fmt.Sprintf(secondaryStorageVolume, i)
+			volumes = append(volumes, corev1.Volume{
+				Name: volumeName,
+				VolumeSource: corev1.VolumeSource{
+					PersistentVolumeClaim: ptr.To(pvc.ToKubernetesType()),
+				},
+			})
+			volumeMounts = append(volumeMounts, corev1.VolumeMount{
+				Name:      volumeName,
+				MountPath: fmt.Sprintf(secondaryStorageMountPath, i),
+			})
+		}
+		if caSecretKeyRef := secondaryStorageCASecretKeyRef(&storage); caSecretKeyRef != nil {
+			volumeName := fmt.Sprintf(secondaryStoragePKIVolume, i)
+			volumes = append(volumes, corev1.Volume{
+				Name: volumeName,
+				VolumeSource: corev1.VolumeSource{
+					Secret: &corev1.SecretVolumeSource{
+						SecretName: caSecretKeyRef.Name,
+					},
+				},
+			})
+			volumeMounts = append(volumeMounts, corev1.VolumeMount{
+				Name:      volumeName,
+				MountPath: fmt.Sprintf(secondaryStoragePKIMountPath, i),
+			})
+		}
+	}
+	return volumes, volumeMounts
+}
+
+func secondaryStorageCASecretKeyRef(storage *mariadbv1alpha1.SecondaryStorage) *mariadbv1alpha1.SecretKeySelector {
+	var tls *mariadbv1alpha1.TLSConfig
+	if storage.S3 != nil {
+		tls = storage.S3.TLS
+	}
+	if storage.AzureBlob != nil {
+		tls = storage.AzureBlob.TLS
+	}
+	if tls == nil || !tls.Enabled {
+		return nil
+	}
+	return tls.CASecretKeyRef
+}
@@ -0,0 +1,169 @@
+package builder
+
+import (
+	"testing"
+
+	mariadbv1alpha1 "github.com/mariadb-operator/mariadb-operator/v26/api/v1alpha1"
+	"github.com/mariadb-operator/mariadb-operator/v26/pkg/backup"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestBackupJobSecondaryStorages(t *testing.T) {
+	builder := newDefaultTestBuilder(t)
+	key := types.NamespacedName{
+		Name:      "backup-secondary-storages",
+		Namespace: "test",
+	}
+	secondaryStorages := []mariadbv1alpha1.SecondaryStorage{
+		{
+			Name: "s3",
+			S3: &mariadbv1alpha1.S3{
+				Bucket:   "backups",
+				Endpoint: "s3.eu-west-1.amazonaws.com",
+				AccessKeyIdSecretKeyRef: &mariadbv1alpha1.SecretKeySelector{
+					LocalObjectReference: mariadbv1alpha1.LocalObjectReference{
+						Name: "s3",
+					},
+					Key: "access-key-id",
+				},
+				SecretAccessKeySecretKeyRef: &mariadbv1alpha1.SecretKeySelector{
+					LocalObjectReference: mariadbv1alpha1.LocalObjectReference{
+						Name: "s3",
+					},
+					Key: "secret-access-key",
+				},
+				TLS: &mariadbv1alpha1.TLSConfig{
+					Enabled: true,
+					CASecretKeyRef: &mariadbv1alpha1.SecretKeySelector{
+						LocalObjectReference: mariadbv1alpha1.LocalObjectReference{
+							Name: "s3-ca",
+						},
+						Key: "ca.crt",
+					},
+				},
+			},
+		},
+		{
+			Name: "pvc",
+			PersistentVolumeClaim: &mariadbv1alpha1.PersistentVolumeClaimVolumeSource{
+				ClaimName: "backups",
+			},
+		},
+	}
+
+	tests := []struct {
+		name              string
+		secondaryStorages []mariadbv1alpha1.SecondaryStorage
+		wantArg           bool
+		wantEnv           []string
+		wantVolumes       []string
+		wantMounts        map[string]string
+	}{
+		{
+			name:              "No secondary storages",
+			secondaryStorages: nil,
+			wantArg:           false,
+		},
+		{
+			name:              "Secondary storages",
+			secondaryStorages: secondaryStorages,
+			wantArg:           true,
+			wantEnv: []string{
+				backup.SecondaryStorageEnv(0, backup.SecondaryStorageS3AccessKeyID),
+				backup.SecondaryStorageEnv(0, backup.SecondaryStorageS3SecretAccessKey),
+				backup.SecondaryStorageEnv(0, backup.SecondaryStorageCACertPath),
+				backup.SecondaryStorageEnv(1, backup.SecondaryStoragePath),
+			},
+			wantVolumes: []string{
+				"pki-secondary-storage-0",
+				"secondary-storage-1",
+			},
+			wantMounts: map[string]string{
+				"pki-secondary-storage-0": "/etc/secondary-storage/0/pki",
+				"secondary-storage-1":     "/backup-secondary/1",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			backup := &mariadbv1alpha1.Backup{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      key.Name,
+					Namespace: key.Namespace,
+				},
+				Spec: mariadbv1alpha1.BackupSpec{
+					Storage: mariadbv1alpha1.BackupStorage{
+						S3: &mariadbv1alpha1.S3{
+							Bucket:   "test",
+							Endpoint: "test",
+						},
+					},
+					SecondaryStorages: tt.secondaryStorages,
+				},
+			}
+
+			job, err := builder.BuildBackupJob(key, backup, &mariadbv1alpha1.MariaDB{})
+			assert.NoError(t, err)
+			assert.NotNil(t, job)
+
+			container := job.Spec.Template.Spec.Containers[0]
+			if tt.wantArg {
+				assert.Contains(t, container.Args, "--secondary-storages")
+			} else {
+				assert.NotContains(t, container.Args, "--secondary-storages")
+			}
+
+			env := make(map[string]corev1.EnvVar)
+			for _, e := range container.Env {
+				env[e.Name] = e
+			}
+			for _, name := range tt.wantEnv {
+				assert.Contains(t, env, name)
+			}
+
+			volumes := make(map[string]corev1.Volume)
+			for _, v := range job.Spec.Template.Spec.Volumes {
+				volumes[v.Name] = v
+			}
+			for _, name := range tt.wantVolumes {
+				assert.Contains(t, volumes, name)
+			}
+
+			mounts := make(map[string]string)
+			for _, m := range container.VolumeMounts {
+				mounts[m.Name] = m.MountPath
+			}
+			for name, mountPath := range tt.wantMounts {
+				assert.Equal(t, mountPath, mounts[name])
+			}
+		})
+	}
+}
+
+func TestSecondaryStorageEnv(t *testing.T) {
+	env := secondaryStorageEnv([]mariadbv1alpha1.SecondaryStorage{
+		{
+			Name: "abs",
+			AzureBlob: &mariadbv1alpha1.AzureBlob{
+				ContainerName: "backups",
+				ServiceURL:    "https://example.blob.core.windows.net",
+				StorageAccountKey: &mariadbv1alpha1.SecretKeySelector{
+					LocalObjectReference: mariadbv1alpha1.LocalObjectReference{
+						Name: "abs",
+					},
+					Key: "account-key",
+				},
+			},
+		},
+	})
+
+	assert.Len(t, env, 1)
+	assert.Equal(t, backup.SecondaryStorageEnv(0, backup.SecondaryStorageABSAccountKey), env[0].Name)
+	assert.NotNil(t, env[0].ValueFrom)
+	assert.Equal(t, "abs", env[0].ValueFrom.SecretKeyRef.Name)
+	assert.Equal(t, "account-key", env[0].ValueFrom.SecretKeyRef.Key)
+}
@@ -55,6 +55,9 @@ const (
 	ABSPKI          = "pki-abs"
 	ABSPKIMountPath = "/etc/abs/pki"
 
+	PITRStorageVolume    = "pitr-storage"
+	PITRStorageMountPath = "/pitr"
+
 	mysqlUser     = int64(999)
 	mysqlGroup    = int64(999)
 	maxscaleUser  = int64(998)
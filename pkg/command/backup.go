@@ -1,9 +1,11 @@
 package command
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"time"
 
@@ -18,20 +20,50 @@ import (
 	"k8s.io/utils/ptr"
 )
 
+// defaultRestoreParallelism is the number of data files loaded concurrently when restoring parallel backups.
+const (
+	defaultRestoreParallelism = 4
+	ignoredTablesEnv          = "IGNORED_TABLES"
+)
+
 type BackupOpts struct {
 	CommandOpts
 	Path                 string
 	TargetFilePath       string
 	BackupFullDirPath    string
 	BackupContentType    mariadbv1alpha1.BackupContentType
+	MariaDBName          string
+	TargetPod            string
+	BackupKey            *types.NamespacedName
+	BackupMeta           bool
 	PhysicalBackupMeta   bool
 	PhysicalBackupKey    *types.NamespacedName
+	RestoreKey           *types.NamespacedName
+	PhysicalBackupChain  bool
+	ChainParent          *mariadbv1alpha1.PhysicalBackupChainLink
+	ParallelBackup       bool
+	ParallelChunkSize    int64
+	Tables               []string
 	OmitCredentials      bool
 	CleanupTargetFile    bool
 	MaxRetentionDuration time.Duration
+	Retention            *mariadbv1alpha1.RetentionPolicy
 	StartGtid            *replication.Gtid
+	StartTime            *time.Time
 	TargetTime           time.Time
+	TargetGtid           *replication.Gtid
 	Compression          mariadbv1alpha1.CompressAlgorithm
+	CompressionLevel     *int32
+	Streaming            bool
+	StreamPartSize       int64
+	StreamMaxPartRetries int32
+	BandwidthLimit       int64
+	IOPSLimit            *int32
+	ObjectLock           *mariadbv1alpha1.ObjectLock
+	Flashback            bool
+	FlashbackFilePath    string
+	FlashbackDatabase    *string
+	FlashbackTable       *string
 	LogLevel             string
 	ExtraOpts            []string
 
@@ -49,6 +81,16 @@ type BackupOpts struct {
 	ABSTLS           bool
 	ABSCACertPath    string
 	ABSPrefix        string
+
+	GCS         bool
+	GCSBucket   string
+	GCSEndpoint string
+	GCSPrefix   string
+
+	FileSystem     bool
+	FileSystemPath string
+
+	SecondaryStorages []mariadbv1alpha1.SecondaryStorage
 }
 
 type BackupOpt func(*BackupOpts)
@@ -67,6 +109,31 @@ func WithBackupContentType(backupContentType mariadbv1alpha1.BackupContentType)
 	}
 }
 
+func WithMariaDBName(name string) BackupOpt {
+	return func(bo *BackupOpts) {
+		bo.MariaDBName = name
+	}
+}
+
+func WithTargetPod(targetPod string) BackupOpt {
+	return func(bo *BackupOpts) {
+		bo.TargetPod = targetPod
+	}
+}
+
+func WithBackupKey(backupKey types.NamespacedName) BackupOpt {
+	return func(bo *BackupOpts) {
+		bo.BackupKey = &backupKey
+	}
+}
+
+// WithBackupMeta enables tracking the GTID of the logical backups in the Backup object, recording it with mariadb-dump.
+func WithBackupMeta(enabled bool) BackupOpt {
+	return func(bo *BackupOpts) {
+		bo.BackupMeta = enabled
+	}
+}
+
 func WithPhysicalBackupMeta(enabled bool, physicalBackupKey types.NamespacedName) BackupOpt {
 	return func(bo *BackupOpts) {
 		bo.PhysicalBackupMeta = enabled
@@ -74,6 +141,38 @@ func WithPhysicalBackupMeta(enabled bool, physicalBackupKey types.NamespacedName
 	}
 }
 
+// WithRestoreKey configures the Restore object where the restoration progress is reported.
+func WithRestoreKey(restoreKey types.NamespacedName) BackupOpt {
+	return func(bo *BackupOpts) {
+		bo.RestoreKey = &restoreKey
+	}
+}
+
+func WithPhysicalBackupChain(enabled bool, parent *mariadbv1alpha1.PhysicalBackupChainLink) BackupOpt {
+	return func(bo *BackupOpts) {
+		bo.PhysicalBackupChain = enabled
+		bo.ChainParent = parent
+	}
+}
+
+func WithParallelBackup(parallel *mariadbv1alpha1.ParallelBackup) BackupOpt {
+	return func(bo *BackupOpts) {
+		if parallel == nil || !parallel.Enabled {
+			return
+		}
+		bo.ParallelBackup = true
+		if parallel.ChunkSize != nil {
+			bo.ParallelChunkSize = parallel.ChunkSize.Value()
+		}
+	}
+}
+
+func WithTables(tables []string) BackupOpt {
+	return func(bo *BackupOpts) {
+		bo.Tables = tables
+	}
+}
+
 func WithOmitCredentials(omit bool) BackupOpt {
 	return func(bo *BackupOpts) {
 		bo.OmitCredentials = omit
@@ -92,24 +191,87 @@ func WithMaxRetention(d time.Duration) BackupOpt {
 	}
 }
 
+func WithRetention(retention *mariadbv1alpha1.RetentionPolicy) BackupOpt {
+	return func(bo *BackupOpts) {
+		bo.Retention = retention
+	}
+}
+
 func WithStartGtid(gtid *replication.Gtid) BackupOpt {
 	return func(bo *BackupOpts) {
 		bo.StartGtid = gtid
 	}
 }
 
+// WithStartTime configures the start of the binlog window, used when the start GTID is unknown.
+func WithStartTime(t *time.Time) BackupOpt {
+	return func(bo *BackupOpts) {
+		bo.StartTime = t
+	}
+}
+
 func WithTargetTime(t time.Time) BackupOpt {
 	return func(bo *BackupOpts) {
 		bo.TargetTime = t
 	}
 }
 
+func WithTargetGtid(gtid *replication.Gtid) BackupOpt {
+	return func(bo *BackupOpts) {
+		bo.TargetGtid = gtid
+	}
+}
+
 func WithCompression(c mariadbv1alpha1.CompressAlgorithm) BackupOpt {
 	return func(bo *BackupOpts) {
 		bo.Compression = c
 	}
 }
 
+func WithCompressionLevel(level *int32) BackupOpt {
+	return func(bo *BackupOpts) {
+		bo.CompressionLevel = level
+	}
+}
+
+func WithStreaming(streaming *mariadbv1alpha1.PhysicalBackupStreaming) BackupOpt {
+	return func(bo *BackupOpts) {
+		if !streaming.IsEnabled() {
+			return
+		}
+		bo.Streaming = true
+		bo.StreamPartSize = streaming.PartSizeOrDefault()
+		bo.StreamMaxPartRetries = streaming.MaxPartRetriesOrDefault()
+	}
+}
+
+func WithThrottling(throttling *mariadbv1alpha1.Throttling) BackupOpt {
+	return func(bo *BackupOpts) {
+		if throttling == nil {
+			return
+		}
+		bo.BandwidthLimit = throttling.BandwidthLimitBytes()
+		bo.IOPSLimit = throttling.IOPSLimit
+	}
+}
+
+func WithObjectLock(objectLock *mariadbv1alpha1.ObjectLock) BackupOpt {
+	return func(bo *BackupOpts) {
+		bo.ObjectLock = objectLock
+	}
+}
+
+// WithFlashback makes mariadb-binlog generate the reverse SQL of the binlog window into a file, instead of replaying it.
+// The row events can optionally be filtered by database and table.
+func WithFlashback(filePath string, database, table *string) BackupOpt {
+	return func(bo *BackupOpts) {
+		bo.Flashback = true
+		bo.FlashbackFilePath = filePath
+		bo.FlashbackDatabase = database
+		bo.FlashbackTable = table
+	}
+}
+
 func WithS3(bucket, endpoint, region, prefix string) BackupOpt {
 	return func(bo *BackupOpts) {
 		bo.S3 = true
@@ -129,6 +291,29 @@ func WithABS(containerName, serviceURL, prefix string) BackupOpt {
 	}
 }
 
+func WithGCS(bucket, endpoint, prefix string) BackupOpt {
+	return func(bo *BackupOpts) {
+		bo.GCS = true
+		bo.GCSBucket = bucket
+		bo.GCSEndpoint = endpoint
+		bo.GCSPrefix = prefix
+	}
+}
+
+// WithFileSystem configures a filesystem storage rooted at the given path, typically a mounted volume.
+func WithFileSystem(path string) BackupOpt {
+	return func(bo *BackupOpts) {
+		bo.FileSystem = true
+		bo.FileSystemPath = path
+	}
+}
+
+func WithSecondaryStorages(storages []mariadbv1alpha1.SecondaryStorage) BackupOpt {
+	return func(bo *BackupOpts) {
+		bo.SecondaryStorages = storages
+	}
+}
+
 func WithABSTLS(tls bool) BackupOpt {
 	return func(bo *BackupOpts) {
 		bo.ABSTLS = tls
@@ -228,6 +413,19 @@ func (b *BackupCommand) MariadbDump(backup *mariadbv1alpha1.Backup,
 
 	args := []string{
 		"set -euo pipefail",
+	}
+	if b.ParallelBackup {
+		// The dump is streamed to mariadb-operator, which splits it by table and bundles it into the target file.
+		args = append(args, []string{
+			"echo 💾 Creating backup directory",
+			fmt.Sprintf(
+				"rm -rf %[1]s && mkdir -p %[1]s",
+				b.BackupFullDirPath,
+			),
+		}...)
+		args = append(args, b.createStreamCmds()...)
+	}
+	args = append(args, []string{
 		"echo 💾 Exporting env",
 		fmt.Sprintf(
 			"export BACKUP_FILE=%s",
@@ -245,13 +443,29 @@ func (b *BackupCommand) MariadbDump(backup *mariadbv1alpha1.Backup,
 			"echo 💾 Taking backup: %s",
 			b.getTargetFilePath(),
 		),
-		fmt.Sprintf(
-			"mariadb-dump %s %s > %s",
-			connFlags,
-			dumpArgs,
-			b.getTargetFilePath(),
-		),
+	}...)
+	if backup.Spec.Tables != nil && needsTableResolution(backup.Spec.Tables) {
+		args = append(args, []string{
+			"echo 💾 Resolving tables",
+			fmt.Sprintf(
+				"%s=$(mariadb %s %s --batch --skip-column-names -e \"%s\")",
+				ignoredTablesEnv,
+				connFlags,
+				strings.Join(b.tlsArgs(mariadb), " "),
+				ignoredTablesQuery(backup.Spec.Tables),
+			),
+		}...)
+	}
+	if b.ParallelBackup {
+		args = append(args, b.streamCmds("mariadb-dump", connFlags, dumpArgs)...)
+		return NewBashCommand(args), nil
 	}
+	args = append(args, fmt.Sprintf(
+		"mariadb-dump %s %s > %s",
+		connFlags,
+		dumpArgs,
+		b.getTargetFilePath(),
+	))
 	return NewBashCommand(args), nil
 }
 
@@ -274,6 +488,11 @@ func (b *BackupCommand) MariadbBackup(mariadb *mariadbv1alpha1.MariaDB, backupFi
 
 	cmds := []string{
 		"set -euo pipefail",
+	}
+	if b.Streaming {
+		cmds = append(cmds, b.createStreamCmds()...)
+	}
+	cmds = append(cmds, []string{
 		fmt.Sprintf(
 			"echo 💾 Writing target file: %s",
 			b.TargetFilePath,
@@ -283,6 +502,23 @@ func (b *BackupCommand) MariadbBackup(mariadb *mariadbv1alpha1.MariaDB, backupFi
 			backupFilePath,
 			b.TargetFilePath,
 		),
+	}...)
+	if b.BackupFullDirPath != "" {
+		// The LSNs and the information of the backup are written to the backup directory to keep track of the incremental backup chain
+		// and to record the server version and GTID in the backup manifest.
+		cmds = append(cmds, []string{
+			"echo 💾 Creating backup directory",
+			fmt.Sprintf(
+				"rm -rf %[1]s && mkdir -p %[1]s",
+				b.BackupFullDirPath,
+			),
+		}...)
+	}
+	if b.Streaming {
+		cmds = append(cmds, b.streamBackupCmds(connFlags, args)...)
+		return NewBashCommand(cmds), nil
+	}
+	cmds = append(cmds, []string{
 		fmt.Sprintf(
 			"echo 💾 Taking backup: %s",
 			b.getTargetFilePath(),
@@ -293,10 +529,58 @@ func (b *BackupCommand) MariadbBackup(mariadb *mariadbv1alpha1.MariaDB, backupFi
 			args,
 			b.getTargetFilePath(),
 		),
-	}
+	}...)
 	return NewBashCommand(cmds), nil
 }
 
+// createStreamCmds creates the named pipe used to stream the backup to mariadb-operator, which runs in a different container.
+// Stale files from previous attempts are removed, as the target file signals mariadb-operator that the stream is ready.
+func (b *BackupCommand) createStreamCmds() []string {
+	fifoPath := backuppkg.StreamFifoPath(b.Path, 0)
+	return []string{
+		fmt.Sprintf(
+			"echo 💾 Creating stream: %s",
+			fifoPath,
+		),
+		fmt.Sprintf(
+			"rm -f %[1]s %[2]s %[3]s && mkfifo %[2]s",
+			b.TargetFilePath,
+			fifoPath,
+			backuppkg.StreamExitCodePath(fifoPath),
+		),
+	}
+}
+
+// streamBackupCmds streams the backup into the named pipe read by mariadb-operator.
+func (b *BackupCommand) streamBackupCmds(connFlags, args string) []string {
+	return b.streamCmds("mariadb-backup", connFlags, args)
+}
+
+// streamCmds streams the output of the given program into the named pipe read by mariadb-operator.
+// The exit code of the program is recorded, so mariadb-operator only completes the backup when the program has succeeded.
+func (b *BackupCommand) streamCmds(program, connFlags, args string) []string {
+	fifoPath := backuppkg.StreamFifoPath(b.Path, 0)
+	return []string{
+		fmt.Sprintf(
+			"echo 💾 Streaming backup: %s",
+			b.getTargetFilePath(),
+		),
+		fmt.Sprintf(`if %[1]s %[2]s %[3]s > %[4]s; then
+	echo 0 > %[5]s;
+else
+	EXIT_CODE=$?;
+	echo ${EXIT_CODE} > %[5]s;
+	exit ${EXIT_CODE};
+fi`,
+			program,
+			connFlags,
+			args,
+			fifoPath,
+			backuppkg.StreamExitCodePath(fifoPath),
+		),
+	}
+}
+
 func (b *BackupCommand) MariadbBackupMeta() *Command {
 	cmds := []string{
 		"set -euo pipefail",
@@ -335,24 +619,52 @@ func (b *BackupCommand) MariadbOperatorBackup() (*Command, error) {
 		"--max-retention",
 		b.MaxRetentionDuration.String(),
 	}
+	args = append(args, b.retentionArgs()...)
 	if b.Compression != "" {
 		args = append(args, []string{
 			"--compression",
 			string(b.Compression),
 		}...)
 	}
+	if b.CompressionLevel != nil {
+		args = append(args, []string{
+			"--compression-level",
+			strconv.Itoa(int(*b.CompressionLevel)),
+		}...)
+	}
 	if b.LogLevel != "" {
 		args = append(args, []string{
 			"--log-level",
 			b.LogLevel,
 		}...)
 	}
+	if b.MariaDBName != "" {
+		args = append(args, []string{
+			"--mariadb-name",
+			b.MariaDBName,
+		}...)
+	}
+	if b.TargetPod != "" {
+		args = append(args, []string{
+			"--target-pod",
+			b.TargetPod,
+		}...)
+	}
 
 	args = append(args, b.s3Args()...)
 	args = append(args, b.absArgs()...)
-	if (b.S3 || b.ABS) && b.CleanupTargetFile {
+	args = append(args, b.gcsArgs()...)
+	args = append(args, b.objectLockArgs()...)
+	args = append(args, b.throttlingArgs()...)
+	secondaryStorageArgs, err := b.secondaryStorageArgs()
+	if err != nil {
+		return nil, err
+	}
+	args = append(args, secondaryStorageArgs...)
+	if (b.S3 || b.ABS || b.GCS) && b.CleanupTargetFile {
 		args = append(args, "--cleanup-target-file")
 	}
+	args = append(args, b.logicalBackupArgs()...)
 	args = append(args, b.physicalBackupArgs()...)
 
 	return NewCommand(nil, args), nil
@@ -374,6 +686,12 @@ func (b *BackupCommand) MariadbOperatorRestore() (*Command, error) {
 		"--backup-content-type",
 		string(b.BackupContentType),
 	}
+	if b.TargetGtid != nil {
+		args = append(args, []string{
+			"--target-gtid",
+			b.TargetGtid.String(),
+		}...)
+	}
 	if b.LogLevel != "" {
 		args = append(args, []string{
 			"--log-level",
@@ -383,7 +701,29 @@ func (b *BackupCommand) MariadbOperatorRestore() (*Command, error) {
 
 	args = append(args, b.s3Args()...)
 	args = append(args, b.absArgs()...)
+	args = append(args, b.gcsArgs()...)
+	args = append(args, b.throttlingArgs()...)
+	secondaryStorageArgs, err := b.secondaryStorageArgs()
+	if err != nil {
+		return nil, err
+	}
+	args = append(args, secondaryStorageArgs...)
+	args = append(args, b.logicalBackupArgs()...)
+	if len(b.Tables) > 0 {
+		args = append(args, []string{
+			"--tables",
+			strings.Join(b.Tables, ","),
+		}...)
+	}
 	args = append(args, b.physicalBackupArgs()...)
+	if b.RestoreKey != nil {
+		args = append(args, []string{
+			"--restore-name",
+			b.RestoreKey.Name,
+			"--restore-namespace",
+			b.RestoreKey.Namespace,
+		}...)
+	}
 
 	return NewCommand(nil, args), nil
 }
@@ -395,19 +735,36 @@ func (b *BackupCommand) MariadbRestore(restore *mariadbv1alpha1.Restore,
 		return nil, fmt.Errorf("error getting connection flags: %v", err)
 	}
 
-	args := strings.Join(b.mariadbRestoreArgs(restore, mariadb), " ")
+	mariadbCmd := fmt.Sprintf("mariadb %s %s", connFlags, strings.Join(b.mariadbRestoreArgs(restore, mariadb), " "))
+	parallelism := ptr.Deref(restore.Spec.Parallelism, defaultRestoreParallelism)
+
+	// Parallel backups are extracted into a directory by mariadb-operator.
+	// The data files are loaded concurrently, after the schema and before the triggers, views, routines and events.
+	restoreCmd := fmt.Sprintf(`if [ -d %[1]s ]; then
+	echo "💾 Restoring schema";
+	%[2]s < %[1]s/%[3]s;
+	echo "💾 Restoring data with parallelism %[4]d";
+	find %[1]s/%[5]s -type f -name '*.sql' -print0 | xargs -0 -r -n 1 -P %[4]d sh -c '%[2]s < "$0"';
+	echo "💾 Restoring post-data";
+	%[2]s < %[1]s/%[6]s;
+else
+	%[2]s < %[1]s;
+fi`,
+		b.getTargetFilePath(),
+		mariadbCmd,
+		backuppkg.ParallelBackupSchemaFileName,
+		parallelism,
+		backuppkg.ParallelBackupDataDir,
+		backuppkg.ParallelBackupPostDataFileName,
+	)
+
 	cmds := []string{
 		"set -euo pipefail",
 		fmt.Sprintf(
 			"echo 💾 Restoring backup: %s",
 			b.getTargetFilePath(),
 		),
-		fmt.Sprintf(
-			"mariadb %s %s < %s",
-			connFlags,
-			args,
-			b.getTargetFilePath(),
-		),
+		restoreCmd,
 	}
 	return NewBashCommand(cmds), nil
 }
@@ -439,6 +796,9 @@ func (b *BackupCommand) MariadbBackupRestore(mariadb *mariadbv1alpha1.MariaDB, d
 	echo "💾 Cleaning up data directory";
 	rm -rf /var/lib/mysql/*;
 fi`
+	if b.Streaming {
+		return NewBashCommand(b.streamRestoreCmds(dataDirPath, opts.cleanupDataDir, cleanupDataDirCmd)), nil
+	}
 	// The ext4 filesystem creates a lost+found directory by default, which causes mariadb-backup to fail with:
 	// "Original data directory /var/lib/mysql is not empty!"
 	// Since we already check the PVC existence earlier, it should be safe to use --force-non-empty-directories.
@@ -465,6 +825,7 @@ fi`
 			b.BackupFullDirPath,
 		),
 	}
+	cmds = append(cmds, b.applyIncrementalBackupsCmd(b.BackupFullDirPath))
 	if opts.cleanupDataDir {
 		cmds = append(cmds, cleanupDataDirCmd)
 	}
@@ -476,9 +837,53 @@ fi`
 	return NewBashCommand(cmds), nil
 }
 
+// streamRestoreCmds extracts the backup streamed by mariadb-operator directly into the data directory and prepares it in place,
+// so no staging area is needed. Each stream is only considered complete when mariadb-operator has verified it.
+func (b *BackupCommand) streamRestoreCmds(dataDirPath string, cleanupDataDir bool, cleanupDataDirCmd string) []string {
+	cmds := []string{
+		"set -euo pipefail",
+		fmt.Sprintf(`echo "💾 Waiting for target file: %[1]s";
+until [ -f %[1]s ]; do
+	sleep 1;
+done`,
+			b.TargetFilePath,
+		),
+	}
+	if cleanupDataDir {
+		cmds = append(cmds, cleanupDataDirCmd)
+	}
+	cmds = append(cmds, []string{
+		"echo 💾 Extracting stream into data directory",
+		fmt.Sprintf(
+			"mbstream -x -C %s < %s",
+			dataDirPath,
+			b.getTargetFilePath(),
+		),
+		verifiedStreamCmd(b.getTargetFilePath()),
+		"echo 💾 Preparing backup",
+		fmt.Sprintf(
+			"mariadb-backup --prepare --target-dir=%s",
+			dataDirPath,
+		),
+		b.applyIncrementalBackupsCmd(dataDirPath),
+	}...)
+	return append(cmds, copyBinlogMetaCmds(dataDirPath, dataDirPath)...)
+}
+
+// verifiedStreamCmd fails when mariadb-operator has not marked the stream as verified, which means that it has been interrupted.
+func verifiedStreamCmd(fifoPath string) string {
+	return fmt.Sprintf(`if [ ! -f "%[1]s" ]; then
+	echo "💾 Stream '%[2]s' has not been verified";
+	exit 1;
+fi`,
+		backuppkg.StreamVerifiedPath(fifoPath),
+		fifoPath,
+	)
+}
+
 func (b *BackupCommand) MariadbOperatorPITR(strictMode bool) (*Command, error) {
-	if b.StartGtid == nil {
-		return nil, errors.New("startGtid must be set")
+	if b.StartGtid == nil && b.StartTime == nil {
+		return nil, errors.New("either startGtid or startTime must be set")
 	}
 	args := []string{
 		"pitr",
@@ -486,16 +891,41 @@ func (b *BackupCommand) MariadbOperatorPITR(strictMode bool) (*Command, error) {
 		b.Path,
 		"--target-file-path",
 		b.TargetFilePath,
-		"--start-gtid",
-		b.StartGtid.String(),
+	}
+	if b.StartGtid != nil {
+		args = append(args, []string{
+			"--start-gtid",
+			b.StartGtid.String(),
+		}...)
+	} else {
+		args = append(args, []string{
+			"--start-time",
+			b.StartTime.Format(time.RFC3339),
+		}...)
+	}
+	args = append(args, []string{
 		"--target-time",
 		b.TargetTime.Format(time.RFC3339),
+	}...)
+	if b.TargetGtid != nil {
+		args = append(args, []string{
+			"--target-gtid",
+			b.TargetGtid.String(),
+		}...)
 	}
 	if strictMode {
 		args = append(args, "--strict-mode")
 	}
 	args = append(args, b.s3Args()...)
 	args = append(args, b.absArgs()...)
+	args = append(args, b.gcsArgs()...)
+	args = append(args, b.fileSystemArgs()...)
+	args = append(args, b.throttlingArgs()...)
+	secondaryStorageArgs, err := b.secondaryStorageArgs()
+	if err != nil {
+		return nil, err
+	}
+	args = append(args, secondaryStorageArgs...)
 
 	if b.Compression != "" {
 		args = append(args, []string{
@@ -513,7 +943,38 @@ func (b *BackupCommand) MariadbOperatorPITR(strictMode bool) (*Command, error) {
 	return NewCommand(nil, args), nil
 }
 
+// MariadbOperatorFlashback stores the reverse SQL generated by mariadb-binlog in the output object of the Flashback.
+func (b *BackupCommand) MariadbOperatorFlashback(flashbackKey types.NamespacedName) (*Command, error) {
+	if b.FlashbackFilePath == "" {
+		return nil, errors.New("flashback file path must be set")
+	}
+	args := []string{
+		"pitr",
+		"flashback",
+		"--sql-file-path",
+		b.FlashbackFilePath,
+		"--flashback-name",
+		flashbackKey.Name,
+		"--flashback-namespace",
+		flashbackKey.Namespace,
+	}
+	if b.LogLevel != "" {
+		args = append(args, []string{
+			"--log-level",
+			b.LogLevel,
+		}...)
+	}
+	return NewCommand(nil, args), nil
+}
+
 func (b *BackupCommand) MariadbBinlog(mariadb *mariadbv1alpha1.MariaDB) (*Command, error) {
+	if b.Flashback {
+		flashbackArgs, err := b.mariadbBinlogFlashbackArgs()
+		if err != nil {
+			return nil, fmt.Errorf("error getting mariadb-binlog flashback args: %v", err)
+		}
+		return NewBashCommand(flashbackArgs), nil
+	}
 	mariadbBinlogArgs, err := b.mariadbBinlogArgs(mariadb)
 	if err != nil {
 		return nil, fmt.Errorf("error getting mariadb-binlog args: %v", err)
@@ -522,19 +983,26 @@ func (b *BackupCommand) MariadbBinlog(mariadb *mariadbv1alpha1.MariaDB) (*Comman
 }
 
 func (b *BackupCommand) newBackupFile() string {
+	// Parallel backups bundle multiple files into a tar archive.
+	fileExt := "sql"
+	if b.ParallelBackup {
+		fileExt = "tar"
+	}
 	var fileName string
 	if b.Compression == mariadbv1alpha1.CompressNone {
 		fileName = fmt.Sprintf(
-			"backup.$(date -u +'%s').sql",
+			"backup.$(date -u +'%s').%s",
 			"%Y-%m-%dT%H:%M:%SZ",
+			fileExt,
 		)
 	} else {
-		// Use standard extension format: .sql.gz or .sql.bz2
+		// Use standard extension format: .sql.gz, .sql.bz2, .sql.zst or .sql.lz4
 		// This allows tools like gunzip to recognize the file format
 		ext, _ := b.Compression.Extension()
 		fileName = fmt.Sprintf(
-			"backup.$(date -u +'%s').sql.%s",
+			"backup.$(date -u +'%s').%s.%s",
 			"%Y-%m-%dT%H:%M:%SZ",
+			fileExt,
 			ext,
 		)
 	}
@@ -582,6 +1050,34 @@ func (b *BackupCommand) mariadbDumpArgs(backup *mariadbv1alpha1.Backup, mariadb
 		args = append(args, "--ignore-table=mysql.global_priv")
 	}
 
+	if tables := backup.Spec.Tables; tables != nil {
+		// Using a separate value, as --ignore-table=<table> flags are deduplicated by flag name.
+		for _, table := range tables.Exclude {
+			if !strings.Contains(table, "*") {
+				args = append(args, fmt.Sprintf("--ignore-table %s", table))
+			}
+		}
+		// Patterns are resolved into --ignore-table flags when taking the backup, see ignoredTablesQuery.
+		if needsTableResolution(tables) {
+			args = append(args, fmt.Sprintf("${%s}", ignoredTablesEnv))
+		}
+	}
+
+	// Parallel backups are split by the comments written by mariadb-dump before each section of the dump.
+	// They are explicitly enabled in case they have been disabled in an option file.
+	if b.ParallelBackup {
+		args = append(args, "--comments")
+	}
+
+	// Binary logs are enabled in Galera and replication, allowing to record the GTID of the snapshot in the backup manifest.
+	// It is required by parallel backups and by point-in-time recovery, which replays the binary logs starting from it.
+	if (b.ParallelBackup || b.BackupMeta) && isBinlogEnabled(mariadb) {
+		args = append(args, []string{
+			"--master-data=2",
+			"--gtid",
+		}...)
+	}
+
 	if mariadb.IsTLSEnabled() {
 		args = append(args, b.tlsArgs(mariadb)...)
 	}
@@ -589,6 +1085,59 @@ func (b *BackupCommand) mariadbDumpArgs(backup *mariadbv1alpha1.Backup, mariadb
 	return ds.UniqueArgs(ds.Merge(args, dumpOpts)...)
 }
 
+// needsTableResolution determines whether the table selection needs to be resolved against the database,
+// which happens when there are include patterns or exclude patterns with wildcards.
+func needsTableResolution(tables *mariadbv1alpha1.TableSelection) bool {
+	return len(tables.Include) > 0 || ds.Any(tables.Exclude, func(table string) bool {
+		return strings.Contains(table, "*")
+	})
+}
+
+// ignoredTablesQuery returns a query that lists --ignore-table flags for the tables that are not selected.
+// Patterns are validated by the webhook to only contain word characters, '-', '*' and a single '.'.
+func ignoredTablesQuery(tables *mariadbv1alpha1.TableSelection) string {
+	var conditions []string
+	if len(tables.Include) > 0 {
+		conditions = append(conditions, fmt.Sprintf("NOT (%s)", tableConditions(tables.Include)))
+	}
+	var excludePatterns []string
+	for _, table := range tables.Exclude {
+		if strings.Contains(table, "*") {
+			excludePatterns = append(excludePatterns, table)
+		}
+	}
+	if len(excludePatterns) > 0 {
+		conditions = append(conditions, fmt.Sprintf("(%s)", tableConditions(excludePatterns)))
+	}
+	return fmt.Sprintf(
+		"SELECT CONCAT('--ignore-table=', TABLE_SCHEMA, '.', TABLE_NAME) FROM information_schema.TABLES "+
+			"WHERE TABLE_SCHEMA NOT IN ('information_schema', 'performance_schema') AND (%s);",
+		strings.Join(conditions, " OR "),
+	)
+}
+
+func tableConditions(patterns []string) string {
+	likePattern := strings.NewReplacer("_", `\_`, "*", "%")
+	conditions := make([]string, len(patterns))
+	for i, pattern := range patterns {
+		database, table, _ := strings.Cut(pattern, ".")
+		conditions[i] = fmt.Sprintf(
+			"(TABLE_SCHEMA LIKE '%s' AND TABLE_NAME LIKE '%s')",
+			likePattern.Replace(database),
+			likePattern.Replace(table),
+		)
+	}
+	return strings.Join(conditions, " OR ")
+}
+
+func isBinlogEnabled(mariadb interfaces.MariaDBObject) bool {
+	mdb, ok := mariadb.(*mariadbv1alpha1.MariaDB)
+	if !ok {
+		return false
+	}
+	return mdb.IsGaleraEnabled() || mdb.IsReplicationEnabled()
+}
+
 func (b *BackupCommand) mariadbBinlogArgs(mariadb *mariadbv1alpha1.MariaDB) ([]string, error) {
 	if b.StartGtid == nil {
 		return nil, errors.New("startGtid must be set")
@@ -604,6 +1153,12 @@ func (b *BackupCommand) mariadbBinlogArgs(mariadb *mariadbv1alpha1.MariaDB) ([]s
 		mariadbCmd += fmt.Sprintf(" %s", strings.Join(mariadbArgs, " "))
 	}
 
+	// The stop position is inclusive: the event with the target GTID is the last one to be replayed.
+	stopArg := fmt.Sprintf("--stop-datetime=\"%s\"", b.TargetTime.UTC().Format(time.DateTime))
+	if b.TargetGtid != nil {
+		stopArg = fmt.Sprintf("--stop-position=\"%s\"", b.TargetGtid.String())
+	}
+
 	return []string{
 		"set -euo pipefail",
 		"echo 💾 Restoring binlogs",
@@ -616,15 +1171,61 @@ func (b *BackupCommand) mariadbBinlogArgs(mariadb *mariadbv1alpha1.MariaDB) ([]s
 		// Here we enforce UTC and use a format compatible with the server.
 		// The server can be in any timezone, mariadb-binlog handles that.
 		fmt.Sprintf(
-			"TZ=UTC mariadb-binlog --start-position=\"%s\" --stop-datetime=\"%s\" %s | %s",
+			"TZ=UTC mariadb-binlog --start-position=\"%s\" %s %s | %s",
 			b.StartGtid.String(),
-			b.TargetTime.UTC().Format(time.DateTime),
+			stopArg,
 			b.getTargetFilePath(),
 			mariadbCmd,
 		),
 	}, nil
 }
 
+func (b *BackupCommand) mariadbBinlogFlashbackArgs() ([]string, error) {
+	if b.StartGtid == nil && b.StartTime == nil {
+		return nil, errors.New("either startGtid or startTime must be set")
+	}
+	if b.FlashbackFilePath == "" {
+		return nil, errors.New("flashback file path must be set")
+	}
+	// Row events are decoded as commented pseudo-SQL to allow reviewing the reverse SQL.
+	args := []string{
+		"--flashback",
+		"--verbose",
+	}
+	if b.StartGtid != nil {
+		// The start position is exclusive: the event right before the start GTID is the last one to be skipped.
+		startGtid := *b.StartGtid
+		if startGtid.SequenceID > 0 {
+			startGtid.SequenceID--
+		}
+		args = append(args, fmt.Sprintf("--start-position=\"%s\"", startGtid.String()))
+	} else {
+		args = append(args, fmt.Sprintf("--start-datetime=\"%s\"", b.StartTime.UTC().Format(time.DateTime)))
+	}
+	if b.TargetGtid != nil {
+		args = append(args, fmt.Sprintf("--stop-position=\"%s\"", b.TargetGtid.String()))
+	} else {
+		args = append(args, fmt.Sprintf("--stop-datetime=\"%s\"", b.TargetTime.UTC().Format(time.DateTime)))
+	}
+	if b.FlashbackDatabase != nil {
+		args = append(args, fmt.Sprintf("--database=\"%s\"", *b.FlashbackDatabase))
+	}
+	if b.FlashbackTable != nil {
+		args = append(args, fmt.Sprintf("--table=\"%s\"", *b.FlashbackTable))
+	}
+
+	return []string{
+		"set -euo pipefail",
+		"echo 💾 Generating reverse SQL",
+		fmt.Sprintf(
+			"TZ=UTC mariadb-binlog %s %s > '%s'",
+			strings.Join(args, " "),
+			b.getTargetFilePath(),
+			b.FlashbackFilePath,
+		),
+	}, nil
+}
+
 func (b *BackupCommand) mariadbBackupArgs(mariadb *mariadbv1alpha1.MariaDB, targetPodIndex int) []string {
 	backupOpts := make([]string, len(b.ExtraOpts))
 	copy(backupOpts, b.ExtraOpts)
@@ -646,6 +1247,15 @@ func (b *BackupCommand) mariadbBackupArgs(mariadb *mariadbv1alpha1.MariaDB, targ
 			"--safe-slave-backup",
 		}...)
 	}
+	if b.BackupFullDirPath != "" {
+		args = append(args, fmt.Sprintf("--extra-lsndir=%s", b.BackupFullDirPath))
+	}
+	if b.PhysicalBackupChain && b.ChainParent != nil {
+		args = append(args, fmt.Sprintf("--incremental-lsn=%d", b.ChainParent.ToLSN))
+	}
+	if b.IOPSLimit != nil {
+		args = append(args, fmt.Sprintf("--throttle=%d", *b.IOPSLimit))
+	}
 
 	return ds.UniqueArgs(ds.Merge(args, backupOpts)...)
 }
@@ -702,6 +1312,90 @@ func (b *BackupCommand) absArgs() []string {
 	return args
 }
 
+func (b *BackupCommand) gcsArgs() []string {
+	if !b.GCS {
+		return nil
+	}
+	args := []string{
+		"--gcs",
+		"--gcs-bucket",
+		b.GCSBucket,
+	}
+	if b.GCSEndpoint != "" {
+		args = append(args,
+			"--gcs-endpoint",
+			b.GCSEndpoint,
+		)
+	}
+	if b.GCSPrefix != "" {
+		args = append(args,
+			"--gcs-prefix",
+			b.GCSPrefix,
+		)
+	}
+	return args
+}
+
+func (b *BackupCommand) fileSystemArgs() []string {
+	if !b.FileSystem {
+		return nil
+	}
+	return []string{
+		"--fs",
+		"--fs-path",
+		b.FileSystemPath,
+	}
+}
+
+func (b *BackupCommand) objectLockArgs() []string {
+	if b.ObjectLock == nil {
+		return nil
+	}
+	args := []string{
+		"--object-lock-mode",
+		string(b.ObjectLock.ModeOrDefault()),
+		"--object-lock-retain-until",
+		b.ObjectLock.RetainUntil.Duration.String(),
+	}
+	if b.ObjectLock.LegalHold {
+		args = append(args, "--object-lock-legal-hold")
+	}
+	return args
+}
+
+func (b *BackupCommand) throttlingArgs() []string {
+	if b.BandwidthLimit <= 0 {
+		return nil
+	}
+	return []string{
+		"--bandwidth-limit",
+		strconv.FormatInt(b.BandwidthLimit, 10),
+	}
+}
+
+func (b *BackupCommand) retentionArgs() []string {
+	if b.Retention == nil {
+		return nil
+	}
+	var args []string
+	for _, rule := range []struct {
+		flag string
+		keep int32
+	}{
+		{flag: "--keep-last", keep: b.Retention.KeepLast},
+		{flag: "--keep-hourly", keep: b.Retention.KeepHourly},
+		{flag: "--keep-daily", keep: b.Retention.KeepDaily},
+		{flag: "--keep-weekly", keep: b.Retention.KeepWeekly},
+		{flag: "--keep-monthly", keep: b.Retention.KeepMonthly},
+		{flag: "--keep-yearly", keep: b.Retention.KeepYearly},
+	} {
+		if rule.keep > 0 {
+			args = append(args, rule.flag, strconv.Itoa(int(rule.keep)))
+		}
+	}
+	return args
+}
+
 func (b *BackupCommand) s3Args() []string {
 	if !b.S3 {
 		return nil
@@ -739,6 +1433,21 @@ func (b *BackupCommand) s3Args() []string {
 	return args
 }
 
+// secondaryStorageArgs passes the secondary storages as JSON. Their credentials are provided via environment variables.
+func (b *BackupCommand) secondaryStorageArgs() ([]string, error) {
+	if len(b.SecondaryStorages) == 0 {
+		return nil, nil
+	}
+	bytes, err := json.Marshal(b.SecondaryStorages)
+	if err != nil {
+		return nil, fmt.Errorf("error marshaling secondary storages: %v", err)
+	}
+	return []string{
+		"--secondary-storages",
+		string(bytes),
+	}, nil
+}
+
 func (b *BackupCommand) tlsArgs(mariadb interfaces.TLSProvider) []string {
 	if !mariadb.IsTLSEnabled() {
 		return nil
@@ -755,6 +1464,40 @@ func (b *BackupCommand) tlsArgs(mariadb interfaces.TLSProvider) []string {
 	}
 }
 
+func (b *BackupCommand) logicalBackupArgs() []string {
+	if b.BackupContentType != mariadbv1alpha1.BackupContentTypeLogical {
+		return nil
+	}
+	var args []string
+	if b.BackupFullDirPath != "" {
+		args = append(args, []string{
+			"--logical-backup-dir-path",
+			b.BackupFullDirPath,
+		}...)
+	}
+	if b.ParallelBackup {
+		args = append(args, "--parallel")
+	}
+	if b.ParallelChunkSize > 0 {
+		args = append(args, []string{
+			"--logical-backup-chunk-size",
+			strconv.FormatInt(b.ParallelChunkSize, 10),
+		}...)
+	}
+	if b.BackupKey != nil {
+		if b.BackupMeta {
+			args = append(args, "--backup-meta")
+		}
+		args = append(args, []string{
+			"--backup-name",
+			b.BackupKey.Name,
+			"--backup-namespace",
+			b.BackupKey.Namespace,
+		}...)
+	}
+	return args
+}
+
 func (b *BackupCommand) physicalBackupArgs() []string {
 	if b.BackupContentType != mariadbv1alpha1.BackupContentTypePhysical {
 		return nil
@@ -766,18 +1509,76 @@ func (b *BackupCommand) physicalBackupArgs() []string {
 			b.BackupFullDirPath,
 		}...)
 	}
-	if b.PhysicalBackupMeta && b.PhysicalBackupKey != nil {
+	if b.Streaming {
 		args = append(args, []string{
-			"--physical-backup-meta",
-			"--physical-backup-name",
-			b.PhysicalBackupKey.Name,
-			"--physical-backup-namespace",
-			b.PhysicalBackupKey.Namespace,
+			"--streaming",
+			"--stream-part-size",
+			strconv.FormatInt(b.StreamPartSize, 10),
+			"--stream-max-part-retries",
+			strconv.Itoa(int(b.StreamMaxPartRetries)),
 		}...)
 	}
+	if b.PhysicalBackupKey == nil {
+		return args
+	}
+	if b.PhysicalBackupMeta {
+		args = append(args, "--physical-backup-meta")
+	}
+	if b.PhysicalBackupChain {
+		args = append(args, "--physical-backup-chain")
+		if b.ChainParent != nil {
+			args = append(args, []string{
+				"--physical-backup-chain-parent",
+				b.ChainParent.FileName,
+			}...)
+		}
+	}
+	args = append(args, []string{
+		"--physical-backup-name",
+		b.PhysicalBackupKey.Name,
+		"--physical-backup-namespace",
+		b.PhysicalBackupKey.Namespace,
+	}...)
 	return args
 }
 
+// applyIncrementalBackupsCmd prepares the incremental backups listed in the incremental target file on top of the full backup.
+// Each incremental backup is extracted into a temporary directory and applied in order.
+func (b *BackupCommand) applyIncrementalBackupsCmd(targetDirPath string) string {
+	incrementalDirPath := b.BackupFullDirPath + "-incremental"
+	copyBinlogInfoCmd := fmt.Sprintf(`for BINLOG_INFO in %[1]s %[2]s; do
+			if [ -f %[3]s/${BINLOG_INFO} ]; then
+				cp %[3]s/${BINLOG_INFO} %[4]s/${BINLOG_INFO};
+			fi
+		done`,
+		replication.BinlogFileName,
+		replication.LegacyBinlogFileName,
+		incrementalDirPath,
+		targetDirPath,
+	)
+	extractCmd := fmt.Sprintf(`mbstream -x -C %s < "${INCREMENTAL_FILE}";`, incrementalDirPath)
+	if b.Streaming {
+		extractCmd += "\n\t\t" + strings.ReplaceAll(verifiedStreamCmd("${INCREMENTAL_FILE}"), "\n", "\n\t\t") + ";"
+	}
+	return fmt.Sprintf(`if [ -s %[1]s ]; then
+	while IFS= read -r INCREMENTAL_FILE; do
+		echo "💾 Applying incremental backup '${INCREMENTAL_FILE}'";
+		rm -rf %[2]s;
+		mkdir -p %[2]s;
+		%[5]s
+		mariadb-backup --prepare --target-dir=%[3]s --incremental-dir=%[2]s;
+		%[4]s
+	done < %[1]s;
+	rm -rf %[2]s;
+fi`,
+		backuppkg.IncrementalTargetFilePath(b.TargetFilePath),
+		incrementalDirPath,
+		targetDirPath,
+		copyBinlogInfoCmd,
+		extractCmd,
+	)
+}
+
 func copyBinlogMetaCmds(sourceDir string, destDir string) []string {
 	// Binlog file with the GTID coordinate is not available on the destination directory.
 	// This ensures that we have access to the coordinate after restoring the backup.
@@ -2,6 +2,7 @@ package command
 
 import (
 	"fmt"
+	"strings"
 	"testing"
 	"time"
 
@@ -10,6 +11,7 @@ import (
 	builderpki "github.com/mariadb-operator/mariadb-operator/v26/pkg/builder/pki"
 	"github.com/mariadb-operator/mariadb-operator/v26/pkg/replication"
 	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/utils/ptr"
@@ -353,6 +355,123 @@ func TestMariadbDumpArgs(t *testing.T) {
 				"--add-drop-table",
 			},
 		},
+		{
+			name: "parallel",
+			backupCmd: &BackupCommand{
+				BackupOpts{
+					ParallelBackup: true,
+				},
+			},
+			backup:  &mariadbv1alpha1.Backup{},
+			mariadb: &mariadbv1alpha1.MariaDB{},
+			wantArgs: []string{
+				"--single-transaction",
+				"--events",
+				"--routines",
+				"--all-databases",
+				"--comments",
+			},
+		},
+		{
+			name: "parallel with binary logs",
+			backupCmd: &BackupCommand{
+				BackupOpts{
+					ParallelBackup: true,
+				},
+			},
+			backup: &mariadbv1alpha1.Backup{},
+			mariadb: &mariadbv1alpha1.MariaDB{
+				Spec: mariadbv1alpha1.MariaDBSpec{
+					Galera: &mariadbv1alpha1.Galera{
+						Enabled: true,
+					},
+				},
+			},
+			wantArgs: []string{
+				"--single-transaction",
+				"--events",
+				"--routines",
+				"--all-databases",
+				"--skip-add-locks",
+				"--comments",
+				"--master-data=2",
+				"--gtid",
+			},
+		},
+		{
+			name: "backup meta with binary logs",
+			backupCmd: &BackupCommand{
+				BackupOpts{
+					BackupMeta: true,
+				},
+			},
+			backup: &mariadbv1alpha1.Backup{},
+			mariadb: &mariadbv1alpha1.MariaDB{
+				Spec: mariadbv1alpha1.MariaDBSpec{
+					Replication: &mariadbv1alpha1.Replication{
+						Enabled: true,
+					},
+				},
+			},
+			wantArgs: []string{
+				"--single-transaction",
+				"--events",
+				"--routines",
+				"--all-databases",
+				"--master-data=2",
+				"--gtid",
+			},
+		},
+		{
+			name:      "exclude tables",
+			backupCmd: &BackupCommand{},
+			backup: &mariadbv1alpha1.Backup{
+				Spec: mariadbv1alpha1.BackupSpec{
+					Tables: &mariadbv1alpha1.TableSelection{
+						Exclude: []string{
+							"db.logs",
+							"db.sessions",
+						},
+					},
+				},
+			},
+			mariadb: &mariadbv1alpha1.MariaDB{},
+			wantArgs: []string{
+				"--single-transaction",
+				"--events",
+				"--routines",
+				"--all-databases",
+				"--ignore-table db.logs",
+				"--ignore-table db.sessions",
+			},
+		},
+		{
+			name:      "include and exclude table patterns",
+			backupCmd: &BackupCommand{},
+			backup: &mariadbv1alpha1.Backup{
+				Spec: mariadbv1alpha1.BackupSpec{
+					Databases: []string{"db"},
+					Tables: &mariadbv1alpha1.TableSelection{
+						Include: []string{
+							"db.*",
+						},
+						Exclude: []string{
+							"db.logs",
+							"db.tmp_*",
+						},
+					},
+				},
+			},
+			mariadb: &mariadbv1alpha1.MariaDB{},
+			wantArgs: []string{
+				"--single-transaction",
+				"--events",
+				"--routines",
+				"--databases db",
+				"--ignore-table db.logs",
+				"${IGNORED_TABLES}",
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -365,6 +484,62 @@ func TestMariadbDumpArgs(t *testing.T) {
 	}
 }
 
+func TestIgnoredTablesQuery(t *testing.T) {
+	tests := []struct {
+		name      string
+		tables    *mariadbv1alpha1.TableSelection
+		wantQuery string
+	}{
+		{
+			name: "include",
+			tables: &mariadbv1alpha1.TableSelection{
+				Include: []string{
+					"db.users",
+					"*.order_*",
+				},
+			},
+			wantQuery: "SELECT CONCAT('--ignore-table=', TABLE_SCHEMA, '.', TABLE_NAME) FROM information_schema.TABLES " +
+				"WHERE TABLE_SCHEMA NOT IN ('information_schema', 'performance_schema') AND " +
+				"(NOT ((TABLE_SCHEMA LIKE 'db' AND TABLE_NAME LIKE 'users') OR (TABLE_SCHEMA LIKE '%' AND TABLE_NAME LIKE 'order\\_%')));",
+		},
+		{
+			name: "exclude",
+			tables: &mariadbv1alpha1.TableSelection{
+				Exclude: []string{
+					"db.logs",
+					"db.tmp*",
+				},
+			},
+			wantQuery: "SELECT CONCAT('--ignore-table=', TABLE_SCHEMA, '.', TABLE_NAME) FROM information_schema.TABLES " +
+				"WHERE TABLE_SCHEMA NOT IN ('information_schema', 'performance_schema') AND " +
+				"(((TABLE_SCHEMA LIKE 'db' AND TABLE_NAME LIKE 'tmp%')));",
+		},
+		{
+			name: "include and exclude",
+			tables: &mariadbv1alpha1.TableSelection{
+				Include: []string{
+					"db.*",
+				},
+				Exclude: []string{
+					"db.tmp*",
+				},
+			},
+			wantQuery: "SELECT CONCAT('--ignore-table=', TABLE_SCHEMA, '.', TABLE_NAME) FROM information_schema.TABLES " +
+				"WHERE TABLE_SCHEMA NOT IN ('information_schema', 'performance_schema') AND " +
+				"(NOT ((TABLE_SCHEMA LIKE 'db' AND TABLE_NAME LIKE '%')) OR ((TABLE_SCHEMA LIKE 'db' AND TABLE_NAME LIKE 'tmp%')));",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query := ignoredTablesQuery(tt.tables)
+			if diff := cmp.Diff(tt.wantQuery, query); diff != "" {
+				t.Errorf("unexpected query (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
 func TestMariadbBackupArgs(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -491,6 +666,78 @@ func TestMariadbBackupArgs(t *testing.T) {
 				"--safe-slave-backup",
 			},
 		},
+		{
+			name: "backup directory",
+			backupCmd: &BackupCommand{
+				BackupOpts: BackupOpts{
+					BackupFullDirPath: "/backup/full",
+				},
+			},
+			mariadb:        &mariadbv1alpha1.MariaDB{},
+			targetPodIndex: 0,
+			wantArgs: []string{
+				"--backup",
+				"--stream=xbstream",
+				"--databases-exclude='lost+found'",
+				"--extra-lsndir=/backup/full",
+			},
+		},
+		{
+			name: "full backup in chain",
+			backupCmd: &BackupCommand{
+				BackupOpts: BackupOpts{
+					BackupFullDirPath:   "/backup/full",
+					PhysicalBackupChain: true,
+				},
+			},
+			mariadb:        &mariadbv1alpha1.MariaDB{},
+			targetPodIndex: 0,
+			wantArgs: []string{
+				"--backup",
+				"--stream=xbstream",
+				"--databases-exclude='lost+found'",
+				"--extra-lsndir=/backup/full",
+			},
+		},
+		{
+			name: "incremental backup in chain",
+			backupCmd: &BackupCommand{
+				BackupOpts: BackupOpts{
+					BackupFullDirPath:   "/backup/full",
+					PhysicalBackupChain: true,
+					ChainParent: &mariadbv1alpha1.PhysicalBackupChainLink{
+						FileName: "physicalbackup-20250101000000.xb",
+						Type:     mariadbv1alpha1.PhysicalBackupTypeFull,
+						ToLSN:    123456,
+					},
+				},
+			},
+			mariadb:        &mariadbv1alpha1.MariaDB{},
+			targetPodIndex: 0,
+			wantArgs: []string{
+				"--backup",
+				"--stream=xbstream",
+				"--databases-exclude='lost+found'",
+				"--extra-lsndir=/backup/full",
+				"--incremental-lsn=123456",
+			},
+		},
+		{
+			name: "with IOPS limit",
+			backupCmd: &BackupCommand{
+				BackupOpts: BackupOpts{
+					IOPSLimit: ptr.To(int32(100)),
+				},
+			},
+			mariadb:        &mariadbv1alpha1.MariaDB{},
+			targetPodIndex: 0,
+			wantArgs: []string{
+				"--backup",
+				"--stream=xbstream",
+				"--databases-exclude='lost+found'",
+				"--throttle=100",
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -537,6 +784,39 @@ func TestMariadbOperatorBackup(t *testing.T) {
 				"info",
 			},
 		},
+		{
+			name: "logical with retention",
+			backupCmd: &BackupCommand{
+				BackupOpts: BackupOpts{
+					Path:                 "/backups",
+					BackupContentType:    mariadbv1alpha1.BackupContentTypeLogical,
+					TargetFilePath:       "/backups/0-backup-target.txt",
+					MaxRetentionDuration: 24 * time.Hour,
+					Retention: &mariadbv1alpha1.RetentionPolicy{
+						KeepDaily:   7,
+						KeepWeekly:  4,
+						KeepMonthly: 12,
+					},
+				},
+			},
+			wantArgs: []string{
+				"backup",
+				"--path",
+				"/backups",
+				"--target-file-path",
+				"/backups/0-backup-target.txt",
+				"--backup-content-type",
+				string(mariadbv1alpha1.BackupContentTypeLogical),
+				"--max-retention",
+				"24h0m0s",
+				"--keep-daily",
+				"7",
+				"--keep-weekly",
+				"4",
+				"--keep-monthly",
+				"12",
+			},
+		},
 		{
 			name: "physical no S3 no cleanupTargetFile",
 			backupCmd: &BackupCommand{
@@ -612,6 +892,47 @@ func TestMariadbOperatorBackup(t *testing.T) {
 				"mariadb",
 			},
 		},
+		{
+			name: "logical GCS with cleanupTargetFile",
+			backupCmd: &BackupCommand{
+				BackupOpts: BackupOpts{
+					Path:                 "/backups",
+					TargetFilePath:       "/backups/0-backup-target.txt",
+					BackupContentType:    mariadbv1alpha1.BackupContentTypeLogical,
+					MaxRetentionDuration: 24 * time.Hour,
+					Compression:          mariadbv1alpha1.CompressGzip,
+					LogLevel:             "info",
+					CleanupTargetFile:    true,
+					GCS:                  true,
+					GCSBucket:            "backups",
+					GCSEndpoint:          "http://fake-gcs-server:4443",
+					GCSPrefix:            "mariadb",
+				},
+			},
+			wantArgs: []string{
+				"backup",
+				"--path",
+				"/backups",
+				"--target-file-path",
+				"/backups/0-backup-target.txt",
+				"--backup-content-type",
+				string(mariadbv1alpha1.BackupContentTypeLogical),
+				"--max-retention",
+				"24h0m0s",
+				"--compression",
+				"gzip",
+				"--log-level",
+				"info",
+				"--gcs",
+				"--gcs-bucket",
+				"backups",
+				"--gcs-endpoint",
+				"http://fake-gcs-server:4443",
+				"--gcs-prefix",
+				"mariadb",
+				"--cleanup-target-file",
+			},
+		},
 		{
 			name: "physical S3",
 			backupCmd: &BackupCommand{
@@ -819,47 +1140,349 @@ func TestMariadbOperatorBackup(t *testing.T) {
 				"test",
 			},
 		},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			command, err := tt.backupCmd.MariadbOperatorBackup()
-			if err != nil {
-				t.Fatalf("unexpected error: %v", err)
-			}
-			if diff := cmp.Diff(command.Args, tt.wantArgs); diff != "" {
-				t.Errorf("unexpected args (-want +got):\n%s", diff)
-			}
-		})
-	}
-}
-
-func TestMariadbRestoreArgs(t *testing.T) {
-	tests := []struct {
-		name      string
-		backupCmd *BackupCommand
-		restore   *mariadbv1alpha1.Restore
-		mariadb   *mariadbv1alpha1.MariaDB
-		wantArgs  []string
-	}{
-		{
-			name:      "empty",
-			backupCmd: &BackupCommand{},
-			restore:   &mariadbv1alpha1.Restore{},
-			mariadb:   &mariadbv1alpha1.MariaDB{},
-			wantArgs:  nil,
-		},
 		{
-			name: "args",
+			name: "logical parallel",
 			backupCmd: &BackupCommand{
 				BackupOpts: BackupOpts{
-					ExtraOpts: []string{
-						"--verbose",
-						"--one-database db1",
-					},
+					Path:                 "/backups",
+					BackupContentType:    mariadbv1alpha1.BackupContentTypeLogical,
+					TargetFilePath:       "/backups/0-backup-target.txt",
+					BackupFullDirPath:    "/backups/full",
+					MaxRetentionDuration: 24 * time.Hour,
+					ParallelBackup:       true,
+					ParallelChunkSize:    1024,
 				},
 			},
-			restore: &mariadbv1alpha1.Restore{},
+			wantArgs: []string{
+				"backup",
+				"--path",
+				"/backups",
+				"--target-file-path",
+				"/backups/0-backup-target.txt",
+				"--backup-content-type",
+				string(mariadbv1alpha1.BackupContentTypeLogical),
+				"--max-retention",
+				"24h0m0s",
+				"--logical-backup-dir-path",
+				"/backups/full",
+				"--parallel",
+				"--logical-backup-chunk-size",
+				"1024",
+			},
+		},
+		{
+			name: "logical with MariaDB and Backup names",
+			backupCmd: &BackupCommand{
+				BackupOpts: BackupOpts{
+					Path:                 "/backups",
+					BackupContentType:    mariadbv1alpha1.BackupContentTypeLogical,
+					TargetFilePath:       "/backups/0-backup-target.txt",
+					MaxRetentionDuration: 24 * time.Hour,
+					MariaDBName:          "mariadb",
+					BackupKey: &types.NamespacedName{
+						Name:      "backup",
+						Namespace: "test",
+					},
+				},
+			},
+			wantArgs: []string{
+				"backup",
+				"--path",
+				"/backups",
+				"--target-file-path",
+				"/backups/0-backup-target.txt",
+				"--backup-content-type",
+				string(mariadbv1alpha1.BackupContentTypeLogical),
+				"--max-retention",
+				"24h0m0s",
+				"--mariadb-name",
+				"mariadb",
+				"--backup-name",
+				"backup",
+				"--backup-namespace",
+				"test",
+			},
+		},
+		{
+			name: "logical with Backup meta",
+			backupCmd: &BackupCommand{
+				BackupOpts: BackupOpts{
+					Path:                 "/backups",
+					BackupContentType:    mariadbv1alpha1.BackupContentTypeLogical,
+					TargetFilePath:       "/backups/0-backup-target.txt",
+					MaxRetentionDuration: 24 * time.Hour,
+					BackupMeta:           true,
+					BackupKey: &types.NamespacedName{
+						Name:      "backup",
+						Namespace: "test",
+					},
+				},
+			},
+			wantArgs: []string{
+				"backup",
+				"--path",
+				"/backups",
+				"--target-file-path",
+				"/backups/0-backup-target.txt",
+				"--backup-content-type",
+				string(mariadbv1alpha1.BackupContentTypeLogical),
+				"--max-retention",
+				"24h0m0s",
+				"--backup-meta",
+				"--backup-name",
+				"backup",
+				"--backup-namespace",
+				"test",
+			},
+		},
+		{
+			name: "physical with MariaDB name and target Pod",
+			backupCmd: &BackupCommand{
+				BackupOpts: BackupOpts{
+					Path:                 "/backups",
+					BackupContentType:    mariadbv1alpha1.BackupContentTypePhysical,
+					TargetFilePath:       "/backups/0-backup-target.txt",
+					MaxRetentionDuration: 24 * time.Hour,
+					MariaDBName:          "mariadb",
+					TargetPod:            "mariadb-1",
+				},
+			},
+			wantArgs: []string{
+				"backup",
+				"--path",
+				"/backups",
+				"--target-file-path",
+				"/backups/0-backup-target.txt",
+				"--backup-content-type",
+				string(mariadbv1alpha1.BackupContentTypePhysical),
+				"--max-retention",
+				"24h0m0s",
+				"--mariadb-name",
+				"mariadb",
+				"--target-pod",
+				"mariadb-1",
+			},
+		},
+		{
+			name: "physical S3 with bandwidth limit",
+			backupCmd: &BackupCommand{
+				BackupOpts: BackupOpts{
+					Path:                 "/backups",
+					BackupContentType:    mariadbv1alpha1.BackupContentTypePhysical,
+					TargetFilePath:       "/backups/0-backup-target.txt",
+					MaxRetentionDuration: 24 * time.Hour,
+					S3:                   true,
+					S3Bucket:             "test",
+					S3Endpoint:           "s3.amazonaws.com",
+					S3Region:             "us-east-1",
+					BandwidthLimit:       52428800,
+					IOPSLimit:            ptr.To(int32(100)),
+				},
+			},
+			wantArgs: []string{
+				"backup",
+				"--path",
+				"/backups",
+				"--target-file-path",
+				"/backups/0-backup-target.txt",
+				"--backup-content-type",
+				string(mariadbv1alpha1.BackupContentTypePhysical),
+				"--max-retention",
+				"24h0m0s",
+				"--s3",
+				"--s3-bucket",
+				"test",
+				"--s3-endpoint",
+				"s3.amazonaws.com",
+				"--s3-region",
+				"us-east-1",
+				"--bandwidth-limit",
+				"52428800",
+			},
+		},
+		{
+			name: "S3 with object lock",
+			backupCmd: &BackupCommand{
+				BackupOpts: BackupOpts{
+					Path:                 "/backups",
+					BackupContentType:    mariadbv1alpha1.BackupContentTypeLogical,
+					TargetFilePath:       "/backups/0-backup-target.txt",
+					MaxRetentionDuration: 24 * time.Hour,
+					S3:                   true,
+					S3Bucket:             "test",
+					S3Endpoint:           "s3.amazonaws.com",
+					ObjectLock: &mariadbv1alpha1.ObjectLock{
+						Mode:        mariadbv1alpha1.ObjectLockModeCompliance,
+						RetainUntil: metav1.Duration{Duration: 720 * time.Hour},
+						LegalHold:   true,
+					},
+				},
+			},
+			wantArgs: []string{
+				"backup",
+				"--path",
+				"/backups",
+				"--target-file-path",
+				"/backups/0-backup-target.txt",
+				"--backup-content-type",
+				string(mariadbv1alpha1.BackupContentTypeLogical),
+				"--max-retention",
+				"24h0m0s",
+				"--s3",
+				"--s3-bucket",
+				"test",
+				"--s3-endpoint",
+				"s3.amazonaws.com",
+				"--object-lock-mode",
+				"Compliance",
+				"--object-lock-retain-until",
+				"720h0m0s",
+				"--object-lock-legal-hold",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			command, err := tt.backupCmd.MariadbOperatorBackup()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(command.Args, tt.wantArgs); diff != "" {
+				t.Errorf("unexpected args (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestMariadbOperatorRestore(t *testing.T) {
+	targetTime := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name      string
+		backupCmd *BackupCommand
+		wantArgs  []string
+	}{
+		{
+			name: "logical",
+			backupCmd: &BackupCommand{
+				BackupOpts: BackupOpts{
+					Path:              "/backups",
+					BackupContentType: mariadbv1alpha1.BackupContentTypeLogical,
+					TargetFilePath:    "/backups/0-backup-target.txt",
+					TargetTime:        targetTime,
+				},
+			},
+			wantArgs: []string{
+				"backup",
+				"restore",
+				"--path",
+				"/backups",
+				"--target-time",
+				"2025-01-01T00:00:00Z",
+				"--target-file-path",
+				"/backups/0-backup-target.txt",
+				"--backup-content-type",
+				string(mariadbv1alpha1.BackupContentTypeLogical),
+			},
+		},
+		{
+			name: "logical with restore key",
+			backupCmd: &BackupCommand{
+				BackupOpts: BackupOpts{
+					Path:              "/backups",
+					BackupContentType: mariadbv1alpha1.BackupContentTypeLogical,
+					TargetFilePath:    "/backups/0-backup-target.txt",
+					TargetTime:        targetTime,
+					RestoreKey: &types.NamespacedName{
+						Name:      "restore",
+						Namespace: "default",
+					},
+				},
+			},
+			wantArgs: []string{
+				"backup",
+				"restore",
+				"--path",
+				"/backups",
+				"--target-time",
+				"2025-01-01T00:00:00Z",
+				"--target-file-path",
+				"/backups/0-backup-target.txt",
+				"--backup-content-type",
+				string(mariadbv1alpha1.BackupContentTypeLogical),
+				"--restore-name",
+				"restore",
+				"--restore-namespace",
+				"default",
+			},
+		},
+		{
+			name: "logical with target GTID",
+			backupCmd: &BackupCommand{
+				BackupOpts: BackupOpts{
+					Path:              "/backups",
+					BackupContentType: mariadbv1alpha1.BackupContentTypeLogical,
+					TargetFilePath:    "/backups/0-backup-target.txt",
+					TargetTime:        targetTime,
+					TargetGtid:        mustParseGtid(t, "0-10-42"),
+				},
+			},
+			wantArgs: []string{
+				"backup",
+				"restore",
+				"--path",
+				"/backups",
+				"--target-time",
+				"2025-01-01T00:00:00Z",
+				"--target-file-path",
+				"/backups/0-backup-target.txt",
+				"--backup-content-type",
+				string(mariadbv1alpha1.BackupContentTypeLogical),
+				"--target-gtid",
+				"0-10-42",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			command, err := tt.backupCmd.MariadbOperatorRestore()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(command.Args, tt.wantArgs); diff != "" {
+				t.Errorf("unexpected args (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestMariadbRestoreArgs(t *testing.T) {
+	tests := []struct {
+		name      string
+		backupCmd *BackupCommand
+		restore   *mariadbv1alpha1.Restore
+		mariadb   *mariadbv1alpha1.MariaDB
+		wantArgs  []string
+	}{
+		{
+			name:      "empty",
+			backupCmd: &BackupCommand{},
+			restore:   &mariadbv1alpha1.Restore{},
+			mariadb:   &mariadbv1alpha1.MariaDB{},
+			wantArgs:  nil,
+		},
+		{
+			name: "args",
+			backupCmd: &BackupCommand{
+				BackupOpts: BackupOpts{
+					ExtraOpts: []string{
+						"--verbose",
+						"--one-database db1",
+					},
+				},
+			},
+			restore: &mariadbv1alpha1.Restore{},
 			mariadb: &mariadbv1alpha1.MariaDB{},
 			wantArgs: []string{
 				"--verbose",
@@ -1028,13 +1651,43 @@ func TestMariadbBackupRestore(t *testing.T) {
 				} else {
 					assert.NotContains(t, script, "rm -rf /var/lib/mysql/*")
 				}
+				assert.Contains(t, script, "done < /backups/target-incremental.sql")
+				assert.Contains(t, script, "mariadb-backup --prepare --target-dir=/backup/full --incremental-dir=/backup/full-incremental")
 			}
 		})
 	}
 }
 
+func TestMariadbBackupRestoreStreaming(t *testing.T) {
+	backupCmd := &BackupCommand{
+		BackupOpts: BackupOpts{
+			Path:              "/backup",
+			TargetFilePath:    "/backup/0-backup-target.txt",
+			BackupFullDirPath: "/backup/full",
+			Streaming:         true,
+		},
+	}
+	cmd, err := backupCmd.MariadbBackupRestore(&mariadbv1alpha1.MariaDB{}, "/var/lib/mysql", WithCleanupDataDir(true))
+	assert.NoError(t, err)
+	if !assert.NotNil(t, cmd) {
+		return
+	}
+	script := cmd.Args[len(cmd.Args)-1]
+
+	assert.Contains(t, script, "until [ -f /backup/0-backup-target.txt ]")
+	assert.Contains(t, script, "rm -rf /var/lib/mysql/*")
+	assert.Contains(t, script, "mbstream -x -C /var/lib/mysql < $(cat '/backup/0-backup-target.txt')")
+	assert.Contains(t, script, `if [ ! -f "$(cat '/backup/0-backup-target.txt').verified" ]`)
+	assert.Contains(t, script, "mariadb-backup --prepare --target-dir=/var/lib/mysql;")
+	assert.Contains(t, script, `if [ ! -f "${INCREMENTAL_FILE}.verified" ]`)
+	assert.Contains(t, script, "mariadb-backup --prepare --target-dir=/var/lib/mysql --incremental-dir=/backup/full-incremental")
+	assert.NotContains(t, script, "--copy-back")
+	assert.Less(t, strings.Index(script, "rm -rf /var/lib/mysql/*"), strings.Index(script, "mbstream -x -C /var/lib/mysql"))
+}
+
 func TestMariadbOperatorPITR(t *testing.T) {
 	startGtid := mustParseGtid(t, "0-10-1")
+	startTime := time.Now().Add(-1 * time.Hour)
 	targetTime := time.Now()
 	tests := []struct {
 		name       string
@@ -1044,11 +1697,89 @@ func TestMariadbOperatorPITR(t *testing.T) {
 		wantArgs   []string
 	}{
 		{
-			name: "basic PITR without S3",
+			name: "basic PITR without S3",
+			opts: []BackupOpt{
+				WithPath("/binlogs", "/binlogs/file", "/backup/full"),
+				WithStartGtid(startGtid),
+				WithTargetTime(targetTime),
+			},
+			wantArgs: []string{
+				"pitr",
+				"--path",
+				"/binlogs",
+				"--target-file-path",
+				"/binlogs/file",
+				"--start-gtid",
+				"0-10-1",
+				"--target-time",
+				targetTime.Format(time.RFC3339),
+			},
+		},
+		{
+			name: "PITR with target GTID",
+			opts: []BackupOpt{
+				WithPath("/binlogs", "/binlogs/file", "/backup/full"),
+				WithStartGtid(startGtid),
+				WithTargetTime(targetTime),
+				WithTargetGtid(mustParseGtid(t, "0-10-42")),
+			},
+			strictMode: true,
+			wantArgs: []string{
+				"pitr",
+				"--path",
+				"/binlogs",
+				"--target-file-path",
+				"/binlogs/file",
+				"--start-gtid",
+				"0-10-1",
+				"--target-time",
+				targetTime.Format(time.RFC3339),
+				"--target-gtid",
+				"0-10-42",
+				"--strict-mode",
+			},
+		},
+		{
+			name: "PITR with S3",
+			opts: []BackupOpt{
+				WithPath("/binlogs", "/binlogs/file", "/backup/full"),
+				WithStartGtid(startGtid),
+				WithTargetTime(targetTime),
+				WithS3("test-bucket", "s3.example.com", "us-west-2", "prefix/"),
+				WithS3TLS(true),
+				WithS3CACertPath("/ca/cert"),
+			},
+			wantArgs: []string{
+				"pitr",
+				"--path",
+				"/binlogs",
+				"--target-file-path",
+				"/binlogs/file",
+				"--start-gtid",
+				"0-10-1",
+				"--target-time",
+				targetTime.Format(time.RFC3339),
+				"--s3",
+				"--s3-bucket",
+				"test-bucket",
+				"--s3-endpoint",
+				"s3.example.com",
+				"--s3-region",
+				"us-west-2",
+				"--s3-tls",
+				"--s3-ca-cert-path",
+				"/ca/cert",
+				"--s3-prefix",
+				"prefix/",
+			},
+		},
+		{
+			name: "PITR with GCS",
 			opts: []BackupOpt{
 				WithPath("/binlogs", "/binlogs/file", "/backup/full"),
 				WithStartGtid(startGtid),
 				WithTargetTime(targetTime),
+				WithGCS("test-bucket", "", "prefix/"),
 			},
 			wantArgs: []string{
 				"pitr",
@@ -1060,17 +1791,20 @@ func TestMariadbOperatorPITR(t *testing.T) {
 				"0-10-1",
 				"--target-time",
 				targetTime.Format(time.RFC3339),
+				"--gcs",
+				"--gcs-bucket",
+				"test-bucket",
+				"--gcs-prefix",
+				"prefix/",
 			},
 		},
 		{
-			name: "PITR with S3",
+			name: "PITR with filesystem",
 			opts: []BackupOpt{
 				WithPath("/binlogs", "/binlogs/file", "/backup/full"),
 				WithStartGtid(startGtid),
 				WithTargetTime(targetTime),
-				WithS3("test-bucket", "s3.example.com", "us-west-2", "prefix/"),
-				WithS3TLS(true),
-				WithS3CACertPath("/ca/cert"),
+				WithFileSystem("/pitr"),
 			},
 			wantArgs: []string{
 				"pitr",
@@ -1082,18 +1816,9 @@ func TestMariadbOperatorPITR(t *testing.T) {
 				"0-10-1",
 				"--target-time",
 				targetTime.Format(time.RFC3339),
-				"--s3",
-				"--s3-bucket",
-				"test-bucket",
-				"--s3-endpoint",
-				"s3.example.com",
-				"--s3-region",
-				"us-west-2",
-				"--s3-tls",
-				"--s3-ca-cert-path",
-				"/ca/cert",
-				"--s3-prefix",
-				"prefix/",
+				"--fs",
+				"--fs-path",
+				"/pitr",
 			},
 		},
 		{
@@ -1204,6 +1929,49 @@ func TestMariadbOperatorPITR(t *testing.T) {
 				"info",
 			},
 		},
+		{
+			name: "PITR with throttling",
+			opts: []BackupOpt{
+				WithPath("/binlogs", "/binlogs/file", "/backup/full"),
+				WithStartGtid(startGtid),
+				WithTargetTime(targetTime),
+				WithThrottling(&mariadbv1alpha1.Throttling{
+					BandwidthLimit: ptr.To(resource.MustParse("10Mi")),
+				}),
+			},
+			wantArgs: []string{
+				"pitr",
+				"--path",
+				"/binlogs",
+				"--target-file-path",
+				"/binlogs/file",
+				"--start-gtid",
+				"0-10-1",
+				"--target-time",
+				targetTime.Format(time.RFC3339),
+				"--bandwidth-limit",
+				"10485760",
+			},
+		},
+		{
+			name: "PITR with start time",
+			opts: []BackupOpt{
+				WithPath("/binlogs", "/binlogs/file", "/backup/full"),
+				WithStartTime(&startTime),
+				WithTargetTime(targetTime),
+			},
+			wantArgs: []string{
+				"pitr",
+				"--path",
+				"/binlogs",
+				"--target-file-path",
+				"/binlogs/file",
+				"--start-time",
+				startTime.Format(time.RFC3339),
+				"--target-time",
+				targetTime.Format(time.RFC3339),
+			},
+		},
 		{
 			name: "PITR without startGtid",
 			opts: []BackupOpt{
@@ -1377,6 +2145,36 @@ func TestMariadbBinlogArgs(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "valid with target GTID",
+			opts: []BackupOpt{
+				WithPath("/binlogs", "/binlogs/file", "/backup/full"),
+				WithStartGtid(startGtid),
+				WithTargetTime(targetTime),
+				WithTargetGtid(mustParseGtid(t, "0-10-42")),
+				WithUserEnv("test"),
+				WithPasswordEnv("test"),
+			},
+			mariadb: &mariadbv1alpha1.MariaDB{
+				ObjectMeta: mdbObjectMeta,
+				Spec: mariadbv1alpha1.MariaDBSpec{
+					Replication: &mariadbv1alpha1.Replication{
+						Enabled: true,
+					},
+					Port: 3306,
+				},
+			},
+			wantArgs: []string{
+				"set -euo pipefail",
+				"echo 💾 Restoring binlogs",
+				fmt.Sprintf(
+					"TZ=UTC mariadb-binlog --start-position=\"%s\" --stop-position=\"0-10-42\" $(cat '/binlogs/file') | mariadb %s",
+					startGtid.String(),
+					mdbFlags,
+				),
+			},
+			wantErr: false,
+		},
 	}
 
 	for _, tt := range tests {
@@ -1401,14 +2199,163 @@ func TestMariadbBinlogArgs(t *testing.T) {
 	}
 }
 
+func TestMariadbBinlogFlashbackArgs(t *testing.T) {
+	startGtid := mustParseGtid(t, "0-10-5")
+	startTime := time.Now().Add(-1 * time.Hour)
+	targetTime := time.Now()
+
+	tests := []struct {
+		name     string
+		opts     []BackupOpt
+		wantArgs []string
+		wantErr  bool
+	}{
+		{
+			name: "error when start is not set",
+			opts: []BackupOpt{
+				WithPath("/binlogs", "/binlogs/file", "/backup/full"),
+				WithTargetTime(targetTime),
+				WithFlashback("/binlogs/flashback.sql", nil, nil),
+			},
+			wantErr: true,
+		},
+		{
+			name: "GTID window",
+			opts: []BackupOpt{
+				WithPath("/binlogs", "/binlogs/file", "/backup/full"),
+				WithStartGtid(startGtid),
+				WithTargetGtid(mustParseGtid(t, "0-10-42")),
+				WithFlashback("/binlogs/flashback.sql", nil, nil),
+			},
+			wantArgs: []string{
+				"set -euo pipefail",
+				"echo 💾 Generating reverse SQL",
+				"TZ=UTC mariadb-binlog --flashback --verbose --start-position=\"0-10-4\" --stop-position=\"0-10-42\" " +
+					"$(cat '/binlogs/file') > '/binlogs/flashback.sql'",
+			},
+		},
+		{
+			name: "time window with filters",
+			opts: []BackupOpt{
+				WithPath("/binlogs", "/binlogs/file", "/backup/full"),
+				WithStartTime(&startTime),
+				WithTargetTime(targetTime),
+				WithFlashback("/binlogs/flashback.sql", ptr.To("db"), ptr.To("users")),
+			},
+			wantArgs: []string{
+				"set -euo pipefail",
+				"echo 💾 Generating reverse SQL",
+				fmt.Sprintf(
+					"TZ=UTC mariadb-binlog --flashback --verbose --start-datetime=\"%s\" --stop-datetime=\"%s\" --database=\"db\" "+
+						"--table=\"users\" $(cat '/binlogs/file') > '/binlogs/flashback.sql'",
+					startTime.UTC().Format(time.DateTime),
+					targetTime.UTC().Format(time.DateTime),
+				),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := []BackupOpt{
+				WithUserEnv("test"),
+				WithPasswordEnv("test"),
+			}
+			opts = append(opts, tt.opts...)
+			b, err := NewBackupCommand(opts...)
+			if err != nil {
+				t.Fatalf("NewBackupCommand() error = %v", err)
+			}
+
+			args, err := b.mariadbBinlogFlashbackArgs()
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+
+			if diff := cmp.Diff(tt.wantArgs, args); diff != "" {
+				t.Errorf("unexpected args (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestMariadbOperatorFlashback(t *testing.T) {
+	key := types.NamespacedName{
+		Name:      "flashback",
+		Namespace: "test",
+	}
+	tests := []struct {
+		name     string
+		opts     []BackupOpt
+		wantArgs []string
+		wantErr  bool
+	}{
+		{
+			name:    "error when flashback is not set",
+			opts:    []BackupOpt{},
+			wantErr: true,
+		},
+		{
+			name: "valid",
+			opts: []BackupOpt{
+				WithFlashback("/binlogs/flashback.sql", nil, nil),
+				WithLogLevel("debug"),
+			},
+			wantArgs: []string{
+				"pitr",
+				"flashback",
+				"--sql-file-path",
+				"/binlogs/flashback.sql",
+				"--flashback-name",
+				"flashback",
+				"--flashback-namespace",
+				"test",
+				"--log-level",
+				"debug",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := []BackupOpt{
+				WithPath("/binlogs", "/binlogs/file", "/backup/full"),
+				WithUserEnv("test"),
+				WithPasswordEnv("test"),
+			}
+			opts = append(opts, tt.opts...)
+			b, err := NewBackupCommand(opts...)
+			if err != nil {
+				t.Fatalf("NewBackupCommand() error = %v", err)
+			}
+
+			cmd, err := b.MariadbOperatorFlashback(key)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+
+			if diff := cmp.Diff(tt.wantArgs, cmd.Args); diff != "" {
+				t.Errorf("unexpected args (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
 func TestPhysicalBackupArgs(t *testing.T) {
 	tests := []struct {
-		name               string
-		backupContentType  mariadbv1alpha1.BackupContentType
-		backupFullDirPath  string
-		physicalBackupMeta bool
-		physicalBackupKey  *types.NamespacedName
-		wantArgs           []string
+		name                string
+		backupContentType   mariadbv1alpha1.BackupContentType
+		backupFullDirPath   string
+		physicalBackupMeta  bool
+		physicalBackupKey   *types.NamespacedName
+		physicalBackupChain bool
+		chainParent         *mariadbv1alpha1.PhysicalBackupChainLink
+		streaming           *mariadbv1alpha1.PhysicalBackupStreaming
+		wantArgs            []string
 	}{
 		{
 			name:               "Non-physical backup content type",
@@ -1448,6 +2395,89 @@ func TestPhysicalBackupArgs(t *testing.T) {
 				"test-namespace",
 			},
 		},
+		{
+			name:               "Physical backup with key",
+			backupContentType:  mariadbv1alpha1.BackupContentTypePhysical,
+			backupFullDirPath:  "/backup/dir",
+			physicalBackupMeta: false,
+			physicalBackupKey: &types.NamespacedName{
+				Name:      "test-backup",
+				Namespace: "test-namespace",
+			},
+			wantArgs: []string{
+				"--physical-backup-dir-path",
+				"/backup/dir",
+				"--physical-backup-name",
+				"test-backup",
+				"--physical-backup-namespace",
+				"test-namespace",
+			},
+		},
+		{
+			name:               "Physical backup with chain",
+			backupContentType:  mariadbv1alpha1.BackupContentTypePhysical,
+			backupFullDirPath:  "/backup/dir",
+			physicalBackupMeta: true,
+			physicalBackupKey: &types.NamespacedName{
+				Name:      "test-backup",
+				Namespace: "test-namespace",
+			},
+			physicalBackupChain: true,
+			chainParent: &mariadbv1alpha1.PhysicalBackupChainLink{
+				FileName: "physicalbackup-20250101000000.xb",
+			},
+			wantArgs: []string{
+				"--physical-backup-dir-path",
+				"/backup/dir",
+				"--physical-backup-meta",
+				"--physical-backup-chain",
+				"--physical-backup-chain-parent",
+				"physicalbackup-20250101000000.xb",
+				"--physical-backup-name",
+				"test-backup",
+				"--physical-backup-namespace",
+				"test-namespace",
+			},
+		},
+		{
+			name:              "Physical backup with streaming",
+			backupContentType: mariadbv1alpha1.BackupContentTypePhysical,
+			backupFullDirPath: "/backup/dir",
+			physicalBackupKey: &types.NamespacedName{
+				Name:      "test-backup",
+				Namespace: "test-namespace",
+			},
+			streaming: &mariadbv1alpha1.PhysicalBackupStreaming{
+				Enabled:        true,
+				PartSize:       ptr.To(resource.MustParse("16Mi")),
+				MaxPartRetries: ptr.To(int32(3)),
+			},
+			wantArgs: []string{
+				"--physical-backup-dir-path",
+				"/backup/dir",
+				"--streaming",
+				"--stream-part-size",
+				"16777216",
+				"--stream-max-part-retries",
+				"3",
+				"--physical-backup-name",
+				"test-backup",
+				"--physical-backup-namespace",
+				"test-namespace",
+			},
+		},
+		{
+			name:              "Physical backup with streaming disabled",
+			backupContentType: mariadbv1alpha1.BackupContentTypePhysical,
+			backupFullDirPath: "/backup/dir",
+			streaming: &mariadbv1alpha1.PhysicalBackupStreaming{
+				Enabled: false,
+			},
+			wantArgs: []string{
+				"--physical-backup-dir-path",
+				"/backup/dir",
+			},
+		},
 		{
 			name:               "Physical backup with directory and meta but no key",
 			backupContentType:  mariadbv1alpha1.BackupContentTypePhysical,
@@ -1465,12 +2495,15 @@ func TestPhysicalBackupArgs(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			b := &BackupCommand{
 				BackupOpts: BackupOpts{
-					BackupContentType:  tt.backupContentType,
-					BackupFullDirPath:  tt.backupFullDirPath,
-					PhysicalBackupMeta: tt.physicalBackupMeta,
-					PhysicalBackupKey:  tt.physicalBackupKey,
+					BackupContentType:   tt.backupContentType,
+					BackupFullDirPath:   tt.backupFullDirPath,
+					PhysicalBackupMeta:  tt.physicalBackupMeta,
+					PhysicalBackupKey:   tt.physicalBackupKey,
+					PhysicalBackupChain: tt.physicalBackupChain,
+					ChainParent:         tt.chainParent,
 				},
 			}
+			WithStreaming(tt.streaming)(&b.BackupOpts)
 
 			if diff := cmp.Diff(b.physicalBackupArgs(), tt.wantArgs); diff != "" {
 				t.Errorf("unexpected args (-want +got):\n%s", diff)
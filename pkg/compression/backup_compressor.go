@@ -1,6 +1,7 @@
 package compression
 
 import (
+	"bufio"
 	"context"
 	"fmt"
 	"os"
@@ -19,7 +20,32 @@ type BackupCompressor interface {
 type GetBackupUncompressedFilenameFn func(compressedFilename string) (string, error)
 
 func NewBackupCompressor(calg mariadbv1alpha1.CompressAlgorithm, basePath string,
-	getUncompressedFilename GetBackupUncompressedFilenameFn, logger logr.Logger) (BackupCompressor, error) {
+	getUncompressedFilename GetBackupUncompressedFilenameFn, logger logr.Logger, compressorOpts ...CompressorOpt) (BackupCompressor, error) {
+	opts := CompressorOpts{}
+	for _, setOpt := range compressorOpts {
+		setOpt(&opts)
+	}
+	if opts.Keyring != nil {
+		compressor, err := NewCompressor(calg, compressorOpts...)
+		if err != nil {
+			return nil, err
+		}
+		return NewEncryptedBackupCompressor(calg, compressor, opts.Keyring, basePath, getUncompressedFilename,
+			logger.WithName("encrypted-compressor")), nil
+	}
+	if opts.ReaderWrapper != nil && calg != mariadbv1alpha1.CompressNone {
+		compressor, err := NewCompressor(calg, compressorOpts...)
+		if err != nil {
+			return nil, err
+		}
+		return &wrappedReaderBackupCompressor{
+			compressor:              compressor,
+			basePath:                basePath,
+			getUncompressedFilename: getUncompressedFilename,
+			logger:                  logger.WithName(fmt.Sprintf("%s-compressor", calg)),
+		}, nil
+	}
+
 	switch calg {
 	case mariadbv1alpha1.CompressNone:
 		return NewNopBackupCompressor(basePath, getUncompressedFilename, logger.WithName("nop-compressor")), nil
@@ -27,6 +53,13 @@ func NewBackupCompressor(calg mariadbv1alpha1.CompressAlgorithm, basePath string
 		return NewGzipBackupCompressor(basePath, getUncompressedFilename, logger.WithName("gzip-compressor")), nil
 	case mariadbv1alpha1.CompressBzip2:
 		return NewBzip2BackupCompressor(basePath, getUncompressedFilename, logger.WithName("bzip2-compressor")), nil
+	case mariadbv1alpha1.CompressZstd:
+		if err := calg.ValidateLevel(opts.Level); err != nil {
+			return nil, err
+		}
+		return NewZstdBackupCompressor(basePath, getUncompressedFilename, logger.WithName("zstd-compressor"), opts.Level), nil
+	case mariadbv1alpha1.CompressLz4:
+		return NewLz4BackupCompressor(basePath, getUncompressedFilename, logger.WithName("lz4-compressor")), nil
 	default:
 		return nil, fmt.Errorf("unsupported compression algorithm: %v", calg)
 	}
@@ -100,6 +133,133 @@ func (c *Bzip2BackupCompressor) Decompress(fileName string) (string, error) {
 	return decompressFile(c.basePath, fileName, c.logger, c.getUncompressedFilename, c.compressor)
 }
 
+type ZstdBackupCompressor struct {
+	compressor              *ZstdCompressor
+	basePath                string
+	getUncompressedFilename GetBackupUncompressedFilenameFn
+	logger                  logr.Logger
+}
+
+func NewZstdBackupCompressor(basePath string, getUncompressedFilename GetBackupUncompressedFilenameFn,
+	logger logr.Logger, level *int32) BackupCompressor {
+	return &ZstdBackupCompressor{
+		compressor:              NewZstdCompressor(level),
+		basePath:                basePath,
+		getUncompressedFilename: getUncompressedFilename,
+		logger:                  logger,
+	}
+}
+
+func (c *ZstdBackupCompressor) Compress(fileName string) error {
+	return compressFile(c.basePath, fileName, c.logger, c.compressor)
+}
+
+func (c *ZstdBackupCompressor) Decompress(fileName string) (string, error) {
+	return decompressFile(c.basePath, fileName, c.logger, c.getUncompressedFilename, c.compressor)
+}
+
+type Lz4BackupCompressor struct {
+	compressor              *Lz4Compressor
+	basePath                string
+	getUncompressedFilename GetBackupUncompressedFilenameFn
+	logger                  logr.Logger
+}
+
+func NewLz4BackupCompressor(basePath string, getUncompressedFilename GetBackupUncompressedFilenameFn,
+	logger logr.Logger) BackupCompressor {
+	return &Lz4BackupCompressor{
+		compressor:              &Lz4Compressor{},
+		basePath:                basePath,
+		getUncompressedFilename: getUncompressedFilename,
+		logger:                  logger,
+	}
+}
+
+func (c *Lz4BackupCompressor) Compress(fileName string) error {
+	return compressFile(c.basePath, fileName, c.logger, c.compressor)
+}
+
+func (c *Lz4BackupCompressor) Decompress(fileName string) (string, error) {
+	return decompressFile(c.basePath, fileName, c.logger, c.getUncompressedFilename, c.compressor)
+}
+
+// wrappedReaderBackupCompressor compresses backup files with a Compressor whose source reader is wrapped.
+type wrappedReaderBackupCompressor struct {
+	compressor              Compressor
+	basePath                string
+	getUncompressedFilename GetBackupUncompressedFilenameFn
+	logger                  logr.Logger
+}
+
+func (c *wrappedReaderBackupCompressor) Compress(fileName string) error {
+	return compressFile(c.basePath, fileName, c.logger, c.compressor)
+}
+
+func (c *wrappedReaderBackupCompressor) Decompress(fileName string) (string, error) {
+	return decompressFile(c.basePath, fileName, c.logger, c.getUncompressedFilename, c.compressor)
+}
+
+// EncryptedBackupCompressor compresses and encrypts backup files in a single pass, and decrypts and decompresses them into a new file.
+// The original file is never modified when decompressing, so backups remain encrypted at rest.
+type EncryptedBackupCompressor struct {
+	calg                    mariadbv1alpha1.CompressAlgorithm
+	compressor              Compressor
+	keyring                 *Keyring
+	basePath                string
+	getUncompressedFilename GetBackupUncompressedFilenameFn
+	logger                  logr.Logger
+}
+
+func NewEncryptedBackupCompressor(calg mariadbv1alpha1.CompressAlgorithm, compressor Compressor, keyring *Keyring, basePath string,
+	getUncompressedFilename GetBackupUncompressedFilenameFn, logger logr.Logger) BackupCompressor {
+	return &EncryptedBackupCompressor{
+		calg:                    calg,
+		compressor:              compressor,
+		keyring:                 keyring,
+		basePath:                basePath,
+		getUncompressedFilename: getUncompressedFilename,
+		logger:                  logger,
+	}
+}
+
+func (c *EncryptedBackupCompressor) Compress(fileName string) error {
+	return compressFile(c.basePath, fileName, c.logger, c.compressor)
+}
+
+func (c *EncryptedBackupCompressor) Decompress(fileName string) (string, error) {
+	if c.calg != mariadbv1alpha1.CompressNone && c.calg != "" {
+		return decompressFile(c.basePath, fileName, c.logger, c.getUncompressedFilename, c.compressor)
+	}
+
+	filePath := getFilePath(c.basePath, fileName)
+	encrypted, err := isEncryptedFile(filePath)
+	if err != nil {
+		return "", fmt.Errorf("error checking if file %s is encrypted: %v", filePath, err)
+	}
+	if !encrypted {
+		if err := c.keyring.checkUnencrypted(); err != nil {
+			return "", fmt.Errorf("error decrypting file %s: %v", filePath, err)
+		}
+		return filePath, nil
+	}
+	return decompressFile(c.basePath, fileName, c.logger, getDecryptedFilename, c.compressor)
+}
+
+// getDecryptedFilename returns the name of the file where an encrypted and uncompressed backup file is decrypted.
+func getDecryptedFilename(fileName string) (string, error) {
+	return fileName + ".decrypted", nil
+}
+
+func isEncryptedFile(filePath string) (bool, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return false, err
+	}
+	defer file.Close()
+
+	return isEncrypted(bufio.NewReader(file))
+}
+
 func compressFile(path, fileName string, logger logr.Logger, compressor Compressor) error {
 	filePath := getFilePath(path, fileName)
 	compressedFilePath := filePath + ".tmp"
@@ -1,12 +1,15 @@
 package compression
 
 import (
+	"io"
 	"os"
 	"path/filepath"
 	"testing"
 
 	"github.com/go-logr/logr"
+	mariadbv1alpha1 "github.com/mariadb-operator/mariadb-operator/v26/api/v1alpha1"
 	"github.com/mariadb-operator/mariadb-operator/v26/pkg/backup"
+	"k8s.io/utils/ptr"
 )
 
 func TestBackupCompressors(t *testing.T) {
@@ -34,6 +37,27 @@ func TestBackupCompressors(t *testing.T) {
 			newCompressorFn: NewBzip2BackupCompressor,
 			fileName:        "backup.2023-12-18T16:14:00Z.sql.bz2",
 		},
+		{
+			name: "zstd",
+			newCompressorFn: func(basePath string, getUncompressedFilename GetBackupUncompressedFilenameFn,
+				logger logr.Logger) BackupCompressor {
+				return NewZstdBackupCompressor(basePath, getUncompressedFilename, logger, nil)
+			},
+			fileName: "backup.2023-12-18T16:14:00Z.sql.zst",
+		},
+		{
+			name: "zstd with level",
+			newCompressorFn: func(basePath string, getUncompressedFilename GetBackupUncompressedFilenameFn,
+				logger logr.Logger) BackupCompressor {
+				return NewZstdBackupCompressor(basePath, getUncompressedFilename, logger, ptr.To(int32(19)))
+			},
+			fileName: "backup.2023-12-18T16:14:00Z.sql.zst",
+		},
+		{
+			name:            "lz4",
+			newCompressorFn: NewLz4BackupCompressor,
+			fileName:        "backup.2023-12-18T16:14:00Z.sql.lz4",
+		},
 	}
 
 	for _, tt := range tests {
@@ -69,3 +93,62 @@ func TestBackupCompressors(t *testing.T) {
 		})
 	}
 }
+
+type countingReader struct {
+	reader io.Reader
+	count  *int
+}
+
+func (r *countingReader) Read(p []byte) (int, error) {
+	n, err := r.reader.Read(p)
+	*r.count += n
+	return n, err
+}
+
+func TestBackupCompressorWithReaderWrapper(t *testing.T) {
+	content := "Lorem ipsum dolor sit amet, consectetur adipiscing elit."
+	processor := backup.NewLogicalBackupProcessor()
+
+	dir, err := os.MkdirTemp("", "backup_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	var count int
+	compressor, err := NewBackupCompressor(
+		mariadbv1alpha1.CompressGzip,
+		dir,
+		processor.GetUncompressedBackupFile,
+		logr.Discard(),
+		WithReaderWrapper(func(r io.Reader) io.Reader {
+			return &countingReader{reader: r, count: &count}
+		}),
+	)
+	if err != nil {
+		t.Fatalf("Failed to create compressor: %v", err)
+	}
+
+	filePath := filepath.Join(dir, "backup.2023-12-18T16:14:00Z.sql.gz")
+	if err := os.WriteFile(filePath, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write test file: %v", err)
+	}
+	if err := compressor.Compress(filePath); err != nil {
+		t.Fatalf("Failed to compress test file: %v", err)
+	}
+	if count != len(content) {
+		t.Errorf("Unexpected bytes read when compressing, expected: %d got: %d", len(content), count)
+	}
+
+	decompressedFileName, err := compressor.Decompress(filePath)
+	if err != nil {
+		t.Fatalf("Failed to decompress test file: %v", err)
+	}
+	decompressedContent, err := os.ReadFile(decompressedFileName)
+	if err != nil {
+		t.Fatalf("Failed to read decompressed file: %v", err)
+	}
+	if string(decompressedContent) != content {
+		t.Errorf("Decompressed content does not match original content:\nGot: %s\nWant: %s", decompressedContent, content)
+	}
+}
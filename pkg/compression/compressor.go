@@ -7,8 +7,10 @@ import (
 	"io"
 
 	"github.com/dsnet/compress/bzip2"
+	"github.com/klauspost/compress/zstd"
 	mariadbv1alpha1 "github.com/mariadb-operator/mariadb-operator/v26/api/v1alpha1"
 	"github.com/mariadb-operator/mariadb-operator/v26/pkg/reader"
+	"github.com/pierrec/lz4/v4"
 )
 
 type Compressor interface {
@@ -16,17 +18,89 @@ type Compressor interface {
 	Decompress(ctx context.Context, dst io.Writer, src io.Reader) error
 }
 
-func NewCompressor(calg mariadbv1alpha1.CompressAlgorithm) (Compressor, error) {
+// CompressorOpts defines options for configuring compressors.
+type CompressorOpts struct {
+	Level         *int32
+	Keyring       *Keyring
+	ReaderWrapper ReaderWrapper
+}
+
+// ReaderWrapper wraps the source reader of compressors, for instance, to keep track of the bytes read.
+type ReaderWrapper func(io.Reader) io.Reader
+
+// CompressorOpt is an option to modify compressor behavior.
+type CompressorOpt func(*CompressorOpts)
+
+// WithCompressionLevel configures the compression level. Only supported by zstd.
+func WithCompressionLevel(level *int32) CompressorOpt {
+	return func(co *CompressorOpts) {
+		co.Level = level
+	}
+}
+
+// WithReaderWrapper wraps the source reader of both compressions and decompressions.
+func WithReaderWrapper(wrapper ReaderWrapper) CompressorOpt {
+	return func(co *CompressorOpts) {
+		co.ReaderWrapper = wrapper
+	}
+}
+
+// WithKeyring configures client-side encryption. Data is encrypted after being compressed and decrypted before being decompressed.
+func WithKeyring(keyring *Keyring) CompressorOpt {
+	return func(co *CompressorOpts) {
+		co.Keyring = keyring
+	}
+}
+
+func NewCompressor(calg mariadbv1alpha1.CompressAlgorithm, compressorOpts ...CompressorOpt) (Compressor, error) {
+	opts := CompressorOpts{}
+	for _, setOpt := range compressorOpts {
+		setOpt(&opts)
+	}
+	if err := calg.ValidateLevel(opts.Level); err != nil {
+		return nil, err
+	}
+
+	var compressor Compressor
 	switch calg {
 	case mariadbv1alpha1.CompressNone:
-		return &NopCompressor{}, nil
+		compressor = &NopCompressor{}
 	case mariadbv1alpha1.CompressGzip:
-		return &GzipCompressor{}, nil
+		compressor = &GzipCompressor{}
 	case mariadbv1alpha1.CompressBzip2:
-		return &Bzip2Compressor{}, nil
+		compressor = &Bzip2Compressor{}
+	case mariadbv1alpha1.CompressZstd:
+		compressor = NewZstdCompressor(opts.Level)
+	case mariadbv1alpha1.CompressLz4:
+		compressor = &Lz4Compressor{}
 	default:
 		return nil, fmt.Errorf("unsupported compression algorithm: %v", calg)
 	}
+
+	if opts.Keyring != nil {
+		compressor = NewEncryptedCompressor(compressor, opts.Keyring)
+	}
+	if opts.ReaderWrapper != nil {
+		compressor = &wrappedReaderCompressor{
+			compressor: compressor,
+			wrapper:    opts.ReaderWrapper,
+		}
+	}
+	return compressor, nil
+}
+
+// wrappedReaderCompressor wraps the source reader before delegating to the underlying Compressor.
+type wrappedReaderCompressor struct {
+	compressor Compressor
+	wrapper    ReaderWrapper
+}
+
+func (c *wrappedReaderCompressor) Compress(ctx context.Context, dst io.Writer, src io.Reader) error {
+	return c.compressor.Compress(ctx, dst, c.wrapper(src))
+}
+
+func (c *wrappedReaderCompressor) Decompress(ctx context.Context, dst io.Writer, src io.Reader) error {
+	return c.compressor.Decompress(ctx, dst, c.wrapper(src))
 }
 
 type NopCompressor struct{}
@@ -83,3 +157,56 @@ func (c *Bzip2Compressor) Decompress(ctx context.Context, dst io.Writer, src io.
 	_, err = io.Copy(dst, reader.NewContextReader(ctx, bzip2Reader))
 	return err
 }
+
+type ZstdCompressor struct {
+	level zstd.EncoderLevel
+}
+
+// NewZstdCompressor creates a new ZstdCompressor. The level follows the zstd convention, ranging from 1 to 22.
+func NewZstdCompressor(level *int32) *ZstdCompressor {
+	encoderLevel := zstd.SpeedDefault
+	if level != nil {
+		encoderLevel = zstd.EncoderLevelFromZstd(int(*level))
+	}
+	return &ZstdCompressor{
+		level: encoderLevel,
+	}
+}
+
+func (c *ZstdCompressor) Compress(ctx context.Context, dst io.Writer, src io.Reader) error {
+	writer, err := zstd.NewWriter(dst, zstd.WithEncoderLevel(c.level))
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(writer, reader.NewContextReader(ctx, src)); err != nil {
+		writer.Close()
+		return err
+	}
+	return writer.Close()
+}
+
+func (c *ZstdCompressor) Decompress(ctx context.Context, dst io.Writer, src io.Reader) error {
+	zstdReader, err := zstd.NewReader(src)
+	if err != nil {
+		return err
+	}
+	defer zstdReader.Close()
+	_, err = io.Copy(dst, reader.NewContextReader(ctx, zstdReader))
+	return err
+}
+
+type Lz4Compressor struct{}
+
+func (c *Lz4Compressor) Compress(ctx context.Context, dst io.Writer, src io.Reader) error {
+	writer := lz4.NewWriter(dst)
+	if _, err := io.Copy(writer, reader.NewContextReader(ctx, src)); err != nil {
+		writer.Close()
+		return err
+	}
+	return writer.Close()
+}
+
+func (c *Lz4Compressor) Decompress(ctx context.Context, dst io.Writer, src io.Reader) error {
+	_, err := io.Copy(dst, reader.NewContextReader(ctx, lz4.NewReader(src)))
+	return err
+}
@@ -0,0 +1,423 @@
+package compression
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"strconv"
+)
+
+// EncryptionKeyIDMetadataKey is the object metadata key used to record the ID of the key that encrypted an object.
+const EncryptionKeyIDMetadataKey = "encryption_key_id"
+
+const (
+	encryptionKeySize        = 32
+	encryptionKeyIDSize      = 8
+	encryptionChunkSize      = 64 * 1024
+	encryptionNoncePrefixLen = 7
+)
+
+// encryptionMagic identifies encrypted streams. It is followed by the key ID length, the key ID and the nonce prefix.
+var encryptionMagic = []byte("MDBOENC1")
+
+// EncryptionKey is a 256-bit key used for encrypting and decrypting data.
+type EncryptionKey struct {
+	id  string
+	key []byte
+}
+
+// NewEncryptionKey creates a new EncryptionKey from a base64 encoded 32-byte key.
+// The key ID is derived from the key itself, so it is stable across restarts and does not reveal the key.
+func NewEncryptionKey(encodedKey string) (*EncryptionKey, error) {
+	key, err := base64.StdEncoding.DecodeString(encodedKey)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding encryption key from base64: %v", err)
+	}
+	if len(key) != encryptionKeySize {
+		return nil, fmt.Errorf("invalid encryption key size: %d bytes, it must be %d bytes", len(key), encryptionKeySize)
+	}
+	sum := sha256.Sum256(key)
+	return &EncryptionKey{
+		id:  hex.EncodeToString(sum[:encryptionKeyIDSize]),
+		key: key,
+	}, nil
+}
+
+// ID returns the key identifier.
+func (k *EncryptionKey) ID() string {
+	return k.id
+}
+
+// Keyring holds the active key, used for encrypting, and all the known keys, used for decrypting.
+type Keyring struct {
+	active           *EncryptionKey
+	keys             map[string]*EncryptionKey
+	allowUnencrypted bool
+}
+
+// NewKeyring creates a new Keyring. The active key may be nil when the Keyring is only used for decrypting.
+func NewKeyring(active *EncryptionKey, previous ...*EncryptionKey) *Keyring {
+	keys := make(map[string]*EncryptionKey)
+	for _, k := range append([]*EncryptionKey{active}, previous...) {
+		if k != nil {
+			keys[k.id] = k
+		}
+	}
+	return &Keyring{
+		active: active,
+		keys:   keys,
+	}
+}
+
+// NewKeyringFromEncodedKeys creates a new Keyring from base64 encoded keys. An empty active key is allowed.
+func NewKeyringFromEncodedKeys(activeKey string, previousKeys ...string) (*Keyring, error) {
+	var active *EncryptionKey
+	if activeKey != "" {
+		key, err := NewEncryptionKey(activeKey)
+		if err != nil {
+			return nil, fmt.Errorf("error getting active encryption key: %v", err)
+		}
+		active = key
+	}
+	previous := make([]*EncryptionKey, len(previousKeys))
+	for i, k := range previousKeys {
+		key, err := NewEncryptionKey(k)
+		if err != nil {
+			return nil, fmt.Errorf("error getting previous encryption key %d: %v", i, err)
+		}
+		previous[i] = key
+	}
+	return NewKeyring(active, previous...), nil
+}
+
+// NewKeyringFromEnv creates a new Keyring from base64 encoded keys available in environment variables.
+// The previous keys are read from the environment variables with the given prefix and a numeric suffix, starting from 0.
+// Unencrypted data is only allowed to be decrypted when the allowUnencryptedEnv environment variable is set to true.
+func NewKeyringFromEnv(activeKeyEnv, previousKeyEnvPrefix, allowUnencryptedEnv string) (*Keyring, error) {
+	var previousKeys []string
+	for i := 0; ; i++ {
+		key, ok := os.LookupEnv(fmt.Sprintf("%s%d", previousKeyEnvPrefix, i))
+		if !ok {
+			break
+		}
+		previousKeys = append(previousKeys, key)
+	}
+	keyring, err := NewKeyringFromEncodedKeys(os.Getenv(activeKeyEnv), previousKeys...)
+	if err != nil {
+		return nil, err
+	}
+	if allowUnencrypted, ok := os.LookupEnv(allowUnencryptedEnv); ok {
+		allow, err := strconv.ParseBool(allowUnencrypted)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing %s: %v", allowUnencryptedEnv, err)
+		}
+		keyring.SetAllowUnencrypted(allow)
+	}
+	return keyring, nil
+}
+
+// SetAllowUnencrypted determines whether unencrypted data, such as data written before enabling encryption, can be read.
+func (k *Keyring) SetAllowUnencrypted(allow bool) {
+	k.allowUnencrypted = allow
+}
+
+// CanEncrypt determines whether the Keyring has an active key.
+func (k *Keyring) CanEncrypt() bool {
+	return k.active != nil
+}
+
+// ActiveKeyID returns the ID of the active key.
+func (k *Keyring) ActiveKeyID() string {
+	if k.active == nil {
+		return ""
+	}
+	return k.active.id
+}
+
+// NewEncryptWriter returns a writer that encrypts data with the active key before writing it into dst.
+// The writer must be closed to flush the final chunk.
+func (k *Keyring) NewEncryptWriter(dst io.Writer) (io.WriteCloser, error) {
+	if k.active == nil {
+		return nil, errors.New("no active encryption key available")
+	}
+	aead, err := newAEAD(k.active)
+	if err != nil {
+		return nil, err
+	}
+	noncePrefix := make([]byte, encryptionNoncePrefixLen)
+	if _, err := rand.Read(noncePrefix); err != nil {
+		return nil, fmt.Errorf("error generating nonce: %v", err)
+	}
+	header := encryptionHeader(k.active.id, noncePrefix)
+	if _, err := dst.Write(header); err != nil {
+		return nil, fmt.Errorf("error writing encryption header: %v", err)
+	}
+	return &encryptWriter{
+		dst:         dst,
+		aead:        aead,
+		header:      header,
+		noncePrefix: noncePrefix,
+		buf:         make([]byte, 0, encryptionChunkSize),
+	}, nil
+}
+
+// NewDecryptReader returns a reader that decrypts the data read from src using the key recorded in the stream.
+// Data that has not been encrypted is only returned as is when the Keyring has no keys or when it explicitly allows unencrypted data.
+func (k *Keyring) NewDecryptReader(src io.Reader) (io.Reader, error) {
+	br := bufio.NewReader(src)
+	encrypted, err := isEncrypted(br)
+	if err != nil {
+		return nil, err
+	}
+	if !encrypted {
+		if err := k.checkUnencrypted(); err != nil {
+			return nil, err
+		}
+		return br, nil
+	}
+	keyID, noncePrefix, header, err := readEncryptionHeader(br)
+	if err != nil {
+		return nil, err
+	}
+	key, ok := k.keys[keyID]
+	if !ok {
+		return nil, fmt.Errorf("encryption key '%s' not found. Make sure that the key is provided as active or previous key", keyID)
+	}
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+	return &decryptReader{
+		src:         br,
+		aead:        aead,
+		header:      header,
+		noncePrefix: noncePrefix,
+		chunk:       make([]byte, encryptionChunkSize+aead.Overhead()),
+	}, nil
+}
+
+// checkUnencrypted returns an error when unencrypted data is not allowed to be read.
+// Keyrings without keys always allow it, as encryption is not configured.
+func (k *Keyring) checkUnencrypted() error {
+	if len(k.keys) == 0 || k.allowUnencrypted {
+		return nil
+	}
+	return errors.New("encryption header not found, the data is not encrypted or it has been tampered with. " +
+		"Set 'allowUnencrypted' to read data written before enabling encryption")
+}
+
+// EncryptedCompressor wraps a Compressor to encrypt the data after compressing it and to decrypt it before decompressing it.
+type EncryptedCompressor struct {
+	compressor Compressor
+	keyring    *Keyring
+}
+
+func NewEncryptedCompressor(compressor Compressor, keyring *Keyring) Compressor {
+	return &EncryptedCompressor{
+		compressor: compressor,
+		keyring:    keyring,
+	}
+}
+
+func (c *EncryptedCompressor) Compress(ctx context.Context, dst io.Writer, src io.Reader) error {
+	writer, err := c.keyring.NewEncryptWriter(dst)
+	if err != nil {
+		return err
+	}
+	if err := c.compressor.Compress(ctx, writer, src); err != nil {
+		return err
+	}
+	return writer.Close()
+}
+
+func (c *EncryptedCompressor) Decompress(ctx context.Context, dst io.Writer, src io.Reader) error {
+	reader, err := c.keyring.NewDecryptReader(src)
+	if err != nil {
+		return err
+	}
+	return c.compressor.Decompress(ctx, dst, reader)
+}
+
+type encryptWriter struct {
+	dst         io.Writer
+	aead        cipher.AEAD
+	header      []byte
+	noncePrefix []byte
+	counter     uint32
+	buf         []byte
+	closed      bool
+}
+
+func (w *encryptWriter) Write(p []byte) (int, error) {
+	if w.closed {
+		return 0, errors.New("write to closed encrypt writer")
+	}
+	written := 0
+	for len(p) > 0 {
+		// A full chunk is only flushed when there is more data, the last chunk is flushed when closing.
+		if len(w.buf) == encryptionChunkSize {
+			if err := w.flush(false); err != nil {
+				return written, err
+			}
+		}
+		n := copy(w.buf[len(w.buf):encryptionChunkSize], p)
+		w.buf = w.buf[:len(w.buf)+n]
+		p = p[n:]
+		written += n
+	}
+	return written, nil
+}
+
+func (w *encryptWriter) Close() error {
+	if w.closed {
+		return nil
+	}
+	w.closed = true
+	return w.flush(true)
+}
+
+func (w *encryptWriter) flush(last bool) error {
+	nonce, err := chunkNonce(w.noncePrefix, w.counter, last)
+	if err != nil {
+		return err
+	}
+	sealed := w.aead.Seal(nil, nonce, w.buf, w.header)
+	if _, err := w.dst.Write(sealed); err != nil {
+		return fmt.Errorf("error writing encrypted chunk: %v", err)
+	}
+	w.counter++
+	w.buf = w.buf[:0]
+	return nil
+}
+
+type decryptReader struct {
+	src         *bufio.Reader
+	aead        cipher.AEAD
+	header      []byte
+	noncePrefix []byte
+	counter     uint32
+	chunk       []byte
+	plain       []byte
+	done        bool
+}
+
+func (r *decryptReader) Read(p []byte) (int, error) {
+	for len(r.plain) == 0 {
+		if r.done {
+			return 0, io.EOF
+		}
+		if err := r.next(); err != nil {
+			return 0, err
+		}
+	}
+	n := copy(p, r.plain)
+	r.plain = r.plain[n:]
+	return n, nil
+}
+
+func (r *decryptReader) next() error {
+	n, err := io.ReadFull(r.src, r.chunk)
+	last := false
+	switch {
+	case err == io.EOF:
+		return errors.New("encrypted stream is truncated")
+	case err == io.ErrUnexpectedEOF:
+		last = true
+	case err != nil:
+		return fmt.Errorf("error reading encrypted chunk: %v", err)
+	default:
+		if _, err := r.src.Peek(1); err == io.EOF {
+			last = true
+		} else if err != nil {
+			return fmt.Errorf("error reading encrypted chunk: %v", err)
+		}
+	}
+
+	nonce, err := chunkNonce(r.noncePrefix, r.counter, last)
+	if err != nil {
+		return err
+	}
+	plain, err := r.aead.Open(r.chunk[:0], nonce, r.chunk[:n], r.header)
+	if err != nil {
+		return fmt.Errorf("error decrypting chunk %d, the data may be corrupted or truncated: %v", r.counter, err)
+	}
+	r.counter++
+	r.plain = plain
+	r.done = last
+	return nil
+}
+
+func newAEAD(key *EncryptionKey) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key.key)
+	if err != nil {
+		return nil, fmt.Errorf("error creating cipher: %v", err)
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("error creating GCM cipher: %v", err)
+	}
+	return aead, nil
+}
+
+func chunkNonce(prefix []byte, counter uint32, last bool) ([]byte, error) {
+	if counter == math.MaxUint32 {
+		return nil, errors.New("maximum number of encrypted chunks exceeded")
+	}
+	nonce := make([]byte, 0, encryptionNoncePrefixLen+5)
+	nonce = append(nonce, prefix...)
+	nonce = binary.BigEndian.AppendUint32(nonce, counter)
+	if last {
+		return append(nonce, 1), nil
+	}
+	return append(nonce, 0), nil
+}
+
+func encryptionHeader(keyID string, noncePrefix []byte) []byte {
+	header := make([]byte, 0, len(encryptionMagic)+1+len(keyID)+len(noncePrefix))
+	header = append(header, encryptionMagic...)
+	header = append(header, byte(len(keyID)))
+	header = append(header, keyID...)
+	return append(header, noncePrefix...)
+}
+
+func isEncrypted(br *bufio.Reader) (bool, error) {
+	magic, err := br.Peek(len(encryptionMagic))
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return false, nil
+		}
+		return false, fmt.Errorf("error reading encryption header: %v", err)
+	}
+	return bytes.Equal(magic, encryptionMagic), nil
+}
+
+func readEncryptionHeader(br *bufio.Reader) (keyID string, noncePrefix []byte, header []byte, err error) {
+	var buf bytes.Buffer
+	tee := io.TeeReader(br, &buf)
+
+	magicAndLen := make([]byte, len(encryptionMagic)+1)
+	if _, err := io.ReadFull(tee, magicAndLen); err != nil {
+		return "", nil, nil, fmt.Errorf("error reading encryption header: %v", err)
+	}
+	keyIDBytes := make([]byte, int(magicAndLen[len(encryptionMagic)]))
+	if _, err := io.ReadFull(tee, keyIDBytes); err != nil {
+		return "", nil, nil, fmt.Errorf("error reading encryption key ID: %v", err)
+	}
+	noncePrefix = make([]byte, encryptionNoncePrefixLen)
+	if _, err := io.ReadFull(tee, noncePrefix); err != nil {
+		return "", nil, nil, fmt.Errorf("error reading encryption nonce: %v", err)
+	}
+	return string(keyIDBytes), noncePrefix, buf.Bytes(), nil
+}
@@ -0,0 +1,416 @@
+package compression
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/go-logr/logr"
+	mariadbv1alpha1 "github.com/mariadb-operator/mariadb-operator/v26/api/v1alpha1"
+	"github.com/mariadb-operator/mariadb-operator/v26/pkg/backup"
+)
+
+func TestEncryptionRoundTrip(t *testing.T) {
+	keyring := newTestKeyring(t, newTestEncodedKey(t))
+
+	tests := []struct {
+		name string
+		size int
+	}{
+		{
+			name: "empty",
+			size: 0,
+		},
+		{
+			name: "smaller than chunk",
+			size: 1024,
+		},
+		{
+			name: "exact chunk",
+			size: encryptionChunkSize,
+		},
+		{
+			name: "multiple chunks",
+			size: 3*encryptionChunkSize + 42,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plain := make([]byte, tt.size)
+			if _, err := rand.Read(plain); err != nil {
+				t.Fatalf("Failed to generate data: %v", err)
+			}
+
+			var encrypted bytes.Buffer
+			writer, err := keyring.NewEncryptWriter(&encrypted)
+			if err != nil {
+				t.Fatalf("Failed to create encrypt writer: %v", err)
+			}
+			if _, err := writer.Write(plain); err != nil {
+				t.Fatalf("Failed to write data: %v", err)
+			}
+			if err := writer.Close(); err != nil {
+				t.Fatalf("Failed to close encrypt writer: %v", err)
+			}
+			if tt.size > 0 && bytes.Contains(encrypted.Bytes(), plain) {
+				t.Fatal("Expected encrypted data not to contain the plain data")
+			}
+
+			reader, err := keyring.NewDecryptReader(&encrypted)
+			if err != nil {
+				t.Fatalf("Failed to create decrypt reader: %v", err)
+			}
+			decrypted, err := io.ReadAll(reader)
+			if err != nil {
+				t.Fatalf("Failed to decrypt data: %v", err)
+			}
+			if !bytes.Equal(plain, decrypted) {
+				t.Fatal("Expected decrypted data to match the plain data")
+			}
+		})
+	}
+}
+
+func TestEncryptionKeyRotation(t *testing.T) {
+	oldKey := newTestEncodedKey(t)
+	newKey := newTestEncodedKey(t)
+	content := []byte("Lorem ipsum dolor sit amet, consectetur adipiscing elit.")
+
+	encrypted := encryptTestData(t, newTestKeyring(t, oldKey), content)
+
+	reader, err := newTestKeyring(t, newKey).NewDecryptReader(bytes.NewReader(encrypted))
+	if err == nil {
+		t.Fatalf("Expected error decrypting with unknown key, got reader: %v", reader)
+	}
+
+	reader, err = newTestKeyring(t, newKey, oldKey).NewDecryptReader(bytes.NewReader(encrypted))
+	if err != nil {
+		t.Fatalf("Failed to create decrypt reader with previous key: %v", err)
+	}
+	decrypted, err := io.ReadAll(reader)
+	if err != nil {
+		t.Fatalf("Failed to decrypt data with previous key: %v", err)
+	}
+	if !bytes.Equal(content, decrypted) {
+		t.Fatal("Expected decrypted data to match the plain data")
+	}
+}
+
+func TestEncryptionTampering(t *testing.T) {
+	keyring := newTestKeyring(t, newTestEncodedKey(t))
+	content := bytes.Repeat([]byte("mariadb"), encryptionChunkSize)
+	encrypted := encryptTestData(t, keyring, content)
+	headerSize := len(encryptionHeader(keyring.ActiveKeyID(), make([]byte, encryptionNoncePrefixLen)))
+
+	tests := []struct {
+		name   string
+		mutate func([]byte) []byte
+	}{
+		{
+			name: "modified chunk",
+			mutate: func(b []byte) []byte {
+				b[headerSize+10] ^= 0xff
+				return b
+			},
+		},
+		{
+			name: "truncated last chunk",
+			mutate: func(b []byte) []byte {
+				return b[:len(b)-1]
+			},
+		},
+		{
+			name: "truncated at chunk boundary",
+			mutate: func(b []byte) []byte {
+				return b[:headerSize+encryptionChunkSize+16]
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := tt.mutate(bytes.Clone(encrypted))
+			reader, err := keyring.NewDecryptReader(bytes.NewReader(data))
+			if err != nil {
+				t.Fatalf("Failed to create decrypt reader: %v", err)
+			}
+			if _, err := io.ReadAll(reader); err == nil {
+				t.Fatal("Expected error decrypting tampered data")
+			}
+		})
+	}
+}
+
+func TestDecryptUnencryptedData(t *testing.T) {
+	content := []byte("Lorem ipsum dolor sit amet, consectetur adipiscing elit.")
+
+	reader, err := NewKeyring(nil).NewDecryptReader(bytes.NewReader(content))
+	if err != nil {
+		t.Fatalf("Failed to create decrypt reader: %v", err)
+	}
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		t.Fatalf("Failed to read data: %v", err)
+	}
+	if !bytes.Equal(content, data) {
+		t.Fatal("Expected unencrypted data to be returned as is")
+	}
+}
+
+func TestDecryptUnencryptedDataWithKeys(t *testing.T) {
+	content := []byte("Lorem ipsum dolor sit amet, consectetur adipiscing elit.")
+	keyring := newTestKeyring(t, newTestEncodedKey(t))
+
+	reader, err := keyring.NewDecryptReader(bytes.NewReader(content))
+	if err == nil {
+		t.Fatalf("Expected error decrypting unencrypted data, got reader: %v", reader)
+	}
+
+	keyring.SetAllowUnencrypted(true)
+	reader, err = keyring.NewDecryptReader(bytes.NewReader(content))
+	if err != nil {
+		t.Fatalf("Failed to create decrypt reader allowing unencrypted data: %v", err)
+	}
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		t.Fatalf("Failed to read data: %v", err)
+	}
+	if !bytes.Equal(content, data) {
+		t.Fatal("Expected unencrypted data to be returned as is")
+	}
+}
+
+func TestNewKeyringFromEnv(t *testing.T) {
+	t.Setenv("TEST_ENCRYPTION_KEY", newTestEncodedKey(t))
+	t.Setenv("TEST_ENCRYPTION_PREVIOUS_KEY_0", newTestEncodedKey(t))
+
+	keyring, err := NewKeyringFromEnv("TEST_ENCRYPTION_KEY", "TEST_ENCRYPTION_PREVIOUS_KEY_", "TEST_ENCRYPTION_ALLOW_UNENCRYPTED")
+	if err != nil {
+		t.Fatalf("Failed to create keyring: %v", err)
+	}
+	if len(keyring.keys) != 2 {
+		t.Fatalf("Expected 2 keys, got: %d", len(keyring.keys))
+	}
+	if keyring.allowUnencrypted {
+		t.Fatal("Expected unencrypted data not to be allowed by default")
+	}
+
+	t.Setenv("TEST_ENCRYPTION_ALLOW_UNENCRYPTED", "true")
+	keyring, err = NewKeyringFromEnv("TEST_ENCRYPTION_KEY", "TEST_ENCRYPTION_PREVIOUS_KEY_", "TEST_ENCRYPTION_ALLOW_UNENCRYPTED")
+	if err != nil {
+		t.Fatalf("Failed to create keyring: %v", err)
+	}
+	if !keyring.allowUnencrypted {
+		t.Fatal("Expected unencrypted data to be allowed")
+	}
+
+	t.Setenv("TEST_ENCRYPTION_ALLOW_UNENCRYPTED", "invalid")
+	if _, err := NewKeyringFromEnv("TEST_ENCRYPTION_KEY", "TEST_ENCRYPTION_PREVIOUS_KEY_", "TEST_ENCRYPTION_ALLOW_UNENCRYPTED"); err == nil {
+		t.Fatal("Expected error parsing invalid allow unencrypted value")
+	}
+}
+
+func TestEncryptedCompressor(t *testing.T) {
+	keyring := newTestKeyring(t, newTestEncodedKey(t))
+	content := strings.Repeat("Lorem ipsum dolor sit amet, consectetur adipiscing elit.", 1000)
+
+	compressor, err := NewCompressor(mariadbv1alpha1.CompressGzip, WithKeyring(keyring))
+	if err != nil {
+		t.Fatalf("Failed to create compressor: %v", err)
+	}
+
+	var encrypted bytes.Buffer
+	if err := compressor.Compress(context.Background(), &encrypted, strings.NewReader(content)); err != nil {
+		t.Fatalf("Failed to compress and encrypt: %v", err)
+	}
+	var decrypted bytes.Buffer
+	if err := compressor.Decompress(context.Background(), &decrypted, &encrypted); err != nil {
+		t.Fatalf("Failed to decrypt and decompress: %v", err)
+	}
+	if decrypted.String() != content {
+		t.Fatal("Expected decrypted content to match the original content")
+	}
+}
+
+func TestEncryptedBackupCompressor(t *testing.T) {
+	content := "Lorem ipsum dolor sit amet, consectetur adipiscing elit."
+	processor := backup.NewLogicalBackupProcessor()
+	logger := logr.Discard()
+	keyring := newTestKeyring(t, newTestEncodedKey(t))
+
+	tests := []struct {
+		name     string
+		calg     mariadbv1alpha1.CompressAlgorithm
+		fileName string
+	}{
+		{
+			name:     "none",
+			calg:     mariadbv1alpha1.CompressNone,
+			fileName: "backup.2023-12-18T16:14:00Z.sql",
+		},
+		{
+			name:     "gzip",
+			calg:     mariadbv1alpha1.CompressGzip,
+			fileName: "backup.2023-12-18T16:14:00Z.sql.gz",
+		},
+		{
+			name:     "zstd",
+			calg:     mariadbv1alpha1.CompressZstd,
+			fileName: "backup.2023-12-18T16:14:00Z.sql.zst",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			compressor, err := NewBackupCompressor(tt.calg, dir, processor.GetUncompressedBackupFile, logger, WithKeyring(keyring))
+			if err != nil {
+				t.Fatalf("Failed to create backup compressor: %v", err)
+			}
+
+			filePath := filepath.Join(dir, tt.fileName)
+			if err := os.WriteFile(filePath, []byte(content), 0644); err != nil {
+				t.Fatalf("Failed to write test file: %v", err)
+			}
+			if err := compressor.Compress(filePath); err != nil {
+				t.Fatalf("Failed to compress and encrypt test file: %v", err)
+			}
+
+			encrypted, err := isEncryptedFile(filePath)
+			if err != nil {
+				t.Fatalf("Failed to check if file is encrypted: %v", err)
+			}
+			if !encrypted {
+				t.Fatal("Expected file to be encrypted")
+			}
+
+			decompressedFileName, err := compressor.Decompress(filePath)
+			if err != nil {
+				t.Fatalf("Failed to decrypt and decompress test file: %v", err)
+			}
+			encrypted, err = isEncryptedFile(filePath)
+			if err != nil {
+				t.Fatalf("Failed to check if file is encrypted: %v", err)
+			}
+			if !encrypted {
+				t.Fatal("Expected original file to remain encrypted")
+			}
+			bytes, err := os.ReadFile(decompressedFileName)
+			if err != nil {
+				t.Fatalf("Failed to read decompressed file: %v", err)
+			}
+			if string(bytes) != content {
+				t.Fatalf("Decompressed content does not match original content. Got: %s, want: %s", string(bytes), content)
+			}
+		})
+	}
+}
+
+func TestEncryptedBackupCompressorUnencryptedFile(t *testing.T) {
+	content := "Lorem ipsum dolor sit amet, consectetur adipiscing elit."
+	processor := backup.NewLogicalBackupProcessor()
+	keyring := newTestKeyring(t, newTestEncodedKey(t))
+	dir := t.TempDir()
+	filePath := filepath.Join(dir, "backup.2023-12-18T16:14:00Z.sql")
+	if err := os.WriteFile(filePath, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write test file: %v", err)
+	}
+
+	compressor, err := NewBackupCompressor(mariadbv1alpha1.CompressNone, dir, processor.GetUncompressedBackupFile, logr.Discard(),
+		WithKeyring(keyring))
+	if err != nil {
+		t.Fatalf("Failed to create backup compressor: %v", err)
+	}
+	if _, err := compressor.Decompress(filePath); err == nil {
+		t.Fatal("Expected error decompressing unencrypted file")
+	}
+
+	keyring.SetAllowUnencrypted(true)
+	decompressedFileName, err := compressor.Decompress(filePath)
+	if err != nil {
+		t.Fatalf("Failed to decompress unencrypted file: %v", err)
+	}
+	if decompressedFileName != filePath {
+		t.Fatalf("Expected unencrypted file to be used as is. Got: %s, want: %s", decompressedFileName, filePath)
+	}
+}
+
+func TestNewEncryptionKey(t *testing.T) {
+	tests := []struct {
+		name    string
+		key     string
+		wantErr bool
+	}{
+		{
+			name:    "valid",
+			key:     base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{1}, 32)),
+			wantErr: false,
+		},
+		{
+			name:    "invalid base64",
+			key:     "not-base64!",
+			wantErr: true,
+		},
+		{
+			name:    "invalid size",
+			key:     base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{1}, 16)),
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			key, err := NewEncryptionKey(tt.key)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("Expected error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if key.ID() == "" {
+				t.Fatal("Expected key ID to be set")
+			}
+		})
+	}
+}
+
+func newTestEncodedKey(t *testing.T) string {
+	key := make([]byte, encryptionKeySize)
+	if _, err := rand.Read(key); err != nil {
+		t.Fatalf("Failed to generate key: %v", err)
+	}
+	return base64.StdEncoding.EncodeToString(key)
+}
+
+func newTestKeyring(t *testing.T, activeKey string, previousKeys ...string) *Keyring {
+	keyring, err := NewKeyringFromEncodedKeys(activeKey, previousKeys...)
+	if err != nil {
+		t.Fatalf("Failed to create keyring: %v", err)
+	}
+	return keyring
+}
+
+func encryptTestData(t *testing.T, keyring *Keyring, data []byte) []byte {
+	var encrypted bytes.Buffer
+	writer, err := keyring.NewEncryptWriter(&encrypted)
+	if err != nil {
+		t.Fatalf("Failed to create encrypt writer: %v", err)
+	}
+	if _, err := writer.Write(data); err != nil {
+		t.Fatalf("Failed to write data: %v", err)
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatalf("Failed to close encrypt writer: %v", err)
+	}
+	return encrypted.Bytes()
+}
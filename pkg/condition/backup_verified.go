@@ -0,0 +1,43 @@
+package conditions
+
+import (
+	"fmt"
+
+	mariadbv1alpha1 "github.com/mariadb-operator/mariadb-operator/v26/api/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func SetBackupVerifying(c Conditioner, fileName string) {
+	msg := "Verifying backup"
+	if fileName != "" {
+		msg = fmt.Sprintf("Verifying %s", fileName)
+	}
+	c.SetCondition(metav1.Condition{
+		Type:    mariadbv1alpha1.ConditionTypeBackupVerified,
+		Status:  metav1.ConditionFalse,
+		Reason:  mariadbv1alpha1.ConditionReasonBackupVerifying,
+		Message: msg,
+	})
+}
+
+func SetBackupVerified(c Conditioner, fileName string) {
+	msg := "Backup verified"
+	if fileName != "" {
+		msg = fmt.Sprintf("Verified %s", fileName)
+	}
+	c.SetCondition(metav1.Condition{
+		Type:    mariadbv1alpha1.ConditionTypeBackupVerified,
+		Status:  metav1.ConditionTrue,
+		Reason:  mariadbv1alpha1.ConditionReasonBackupVerified,
+		Message: msg,
+	})
+}
+
+func SetBackupVerificationFailed(c Conditioner, message string) {
+	c.SetCondition(metav1.Condition{
+		Type:    mariadbv1alpha1.ConditionTypeBackupVerified,
+		Status:  metav1.ConditionFalse,
+		Reason:  mariadbv1alpha1.ConditionReasonBackupVerificationFailed,
+		Message: message,
+	})
+}
@@ -0,0 +1,24 @@
+package conditions
+
+import (
+	mariadbv1alpha1 "github.com/mariadb-operator/mariadb-operator/v26/api/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func SetBinlogIndexConsistent(c Conditioner, msg string) {
+	c.SetCondition(metav1.Condition{
+		Type:    mariadbv1alpha1.ConditionTypeDegraded,
+		Status:  metav1.ConditionFalse,
+		Reason:  mariadbv1alpha1.ConditionReasonBinlogIndexConsistent,
+		Message: msg,
+	})
+}
+
+func SetBinlogIndexInconsistent(c Conditioner, msg string) {
+	c.SetCondition(metav1.Condition{
+		Type:    mariadbv1alpha1.ConditionTypeDegraded,
+		Status:  metav1.ConditionTrue,
+		Reason:  mariadbv1alpha1.ConditionReasonBinlogIndexInconsistent,
+		Message: msg,
+	})
+}
@@ -91,6 +91,15 @@ func SetCompleteWithJob(c Conditioner, job *batchv1.Job) {
 	})
 }
 
+func SetCompleteDryRun(c Conditioner) {
+	c.SetCondition(metav1.Condition{
+		Type:    mariadbv1alpha1.ConditionTypeComplete,
+		Status:  metav1.ConditionTrue,
+		Reason:  mariadbv1alpha1.ConditionReasonRestoreDryRun,
+		Message: "Dry run completed",
+	})
+}
+
 func SetCompleteFailedWithMessage(c Conditioner, message string) {
 	c.SetCondition(metav1.Condition{
 		Type:    mariadbv1alpha1.ConditionTypeComplete,
@@ -0,0 +1,42 @@
+package conditions
+
+import (
+	mariadbv1alpha1 "github.com/mariadb-operator/mariadb-operator/v26/api/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func SetFlashbackGenerating(c Conditioner) {
+	c.SetCondition(metav1.Condition{
+		Type:    mariadbv1alpha1.ConditionTypeFlashbackGenerated,
+		Status:  metav1.ConditionFalse,
+		Reason:  mariadbv1alpha1.ConditionReasonFlashbackGenerating,
+		Message: "Generating reverse SQL",
+	})
+}
+
+func SetFlashbackGenerated(c Conditioner) {
+	c.SetCondition(metav1.Condition{
+		Type:    mariadbv1alpha1.ConditionTypeFlashbackGenerated,
+		Status:  metav1.ConditionTrue,
+		Reason:  mariadbv1alpha1.ConditionReasonFlashbackGenerated,
+		Message: "Reverse SQL generated",
+	})
+}
+
+func SetFlashbackGenerationError(c Conditioner, message string) {
+	c.SetCondition(metav1.Condition{
+		Type:    mariadbv1alpha1.ConditionTypeFlashbackGenerated,
+		Status:  metav1.ConditionFalse,
+		Reason:  mariadbv1alpha1.ConditionReasonFlashbackGenerationError,
+		Message: message,
+	})
+}
+
+func SetFlashbackPendingApproval(c Conditioner) {
+	c.SetCondition(metav1.Condition{
+		Type:    mariadbv1alpha1.ConditionTypeComplete,
+		Status:  metav1.ConditionFalse,
+		Reason:  mariadbv1alpha1.ConditionReasonFlashbackPendingApproval,
+		Message: "Pending approval",
+	})
+}
@@ -0,0 +1,26 @@
+package conditions
+
+import (
+	"fmt"
+
+	mariadbv1alpha1 "github.com/mariadb-operator/mariadb-operator/v26/api/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func SetIntegrityVerified(c Conditioner, fileName string) {
+	c.SetCondition(metav1.Condition{
+		Type:    mariadbv1alpha1.ConditionTypeIntegrityVerified,
+		Status:  metav1.ConditionTrue,
+		Reason:  mariadbv1alpha1.ConditionReasonIntegrityVerified,
+		Message: fmt.Sprintf("Verified integrity of %s", fileName),
+	})
+}
+
+func SetIntegrityVerificationFailed(c Conditioner, fileName string, err error) {
+	c.SetCondition(metav1.Condition{
+		Type:    mariadbv1alpha1.ConditionTypeIntegrityVerified,
+		Status:  metav1.ConditionFalse,
+		Reason:  mariadbv1alpha1.ConditionReasonIntegrityVerificationFailed,
+		Message: fmt.Sprintf("Error verifying integrity of %s: %v", fileName, err),
+	})
+}
@@ -303,6 +303,15 @@ func SetReadySuspended(c Conditioner) {
 	})
 }
 
+func SetReadyRestoreDryRun(c Conditioner) {
+	c.SetCondition(metav1.Condition{
+		Type:    mariadbv1alpha1.ConditionTypeReady,
+		Status:  metav1.ConditionFalse,
+		Reason:  mariadbv1alpha1.ConditionReasonRestoreDryRun,
+		Message: "Restore dry run completed",
+	})
+}
+
 // SetReadyWithMaintenance will set the correct ready state based on the Maintenance prop, while checking if the object is cordoned
 func SetReadyWithMaintenance(c Conditioner, obj interfaces.Cordonable) {
 	if obj.IsCordonEnabled() {
@@ -0,0 +1,318 @@
+package hook
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/go-logr/logr"
+	mariadbv1alpha1 "github.com/mariadb-operator/mariadb-operator/v26/api/v1alpha1"
+	"github.com/mariadb-operator/mariadb-operator/v26/pkg/builder"
+	"github.com/mariadb-operator/mariadb-operator/v26/pkg/interfaces"
+	jobpkg "github.com/mariadb-operator/mariadb-operator/v26/pkg/job"
+	"github.com/mariadb-operator/mariadb-operator/v26/pkg/metadata"
+	"github.com/mariadb-operator/mariadb-operator/v26/pkg/refresolver"
+	"github.com/mariadb-operator/mariadb-operator/v26/pkg/sql"
+	"github.com/mariadb-operator/mariadb-operator/v26/pkg/statefulset"
+	batchv1 "k8s.io/api/batch/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// ConditionPatcher records a hook condition in the status of the backup resource.
+type ConditionPatcher func(condition metav1.Condition) error
+
+type Option func(*HookReconciler)
+
+func WithRefResolver(rr *refresolver.RefResolver) Option {
+	return func(r *HookReconciler) {
+		r.refResolver = rr
+	}
+}
+
+func WithExecutor(executor Executor) Option {
+	return func(r *HookReconciler) {
+		r.executor = executor
+	}
+}
+
+// HookReconciler runs the pre and post hooks of backup Jobs.
+// Jobs with pre hooks are created suspended, they are resumed once the pre hooks have been run.
+// The progress is tracked via annotations in the Jobs, so each hook is run once per Job.
+type HookReconciler struct {
+	client.Client
+	refResolver *refresolver.RefResolver
+	executor    Executor
+}
+
+func NewHookReconciler(client client.Client, executor Executor, opts ...Option) *HookReconciler {
+	r := &HookReconciler{
+		Client:   client,
+		executor: executor,
+	}
+	for _, setOpt := range opts {
+		setOpt(r)
+	}
+	if r.refResolver == nil {
+		r.refResolver = refresolver.New(client)
+	}
+	return r
+}
+
+// ReconcileJobs runs the pending hooks of the given backup Jobs and records the results using the ConditionPatcher.
+// When scheduled is true, Jobs whose pre hooks fail are deleted, so they do not block the next scheduled backups.
+// Otherwise, they are kept suspended.
+func (r *HookReconciler) ReconcileJobs(ctx context.Context, jobs []batchv1.Job, hooks *mariadbv1alpha1.BackupHooks,
+	mariadb interfaces.MariaDBObject, scheduled bool, patcher ConditionPatcher) error {
+	logger := log.FromContext(ctx).WithName("hook")
+	hooks = ptr.To(ptr.Deref(hooks, mariadbv1alpha1.BackupHooks{}))
+
+	for _, job := range jobs {
+		jobLogger := logger.WithValues("job", job.Name)
+
+		if job.Annotations[metadata.PreHooksAnnotation] == metadata.HooksPendingValue && ptr.Deref(job.Spec.Suspend, false) {
+			if err := r.reconcilePreHooks(ctx, &job, hooks.Pre, mariadb, scheduled, patcher, jobLogger); err != nil {
+				return fmt.Errorf("error reconciling pre hooks for Job '%s': %v", job.Name, err)
+			}
+			continue
+		}
+		if job.Annotations[metadata.PostHooksAnnotation] == metadata.HooksPendingValue && jobpkg.IsJobComplete(&job) {
+			if err := r.reconcilePostHooks(ctx, &job, hooks.Post, mariadb, patcher, jobLogger); err != nil {
+				return fmt.Errorf("error reconciling post hooks for Job '%s': %v", job.Name, err)
+			}
+		}
+	}
+	return nil
+}
+
+func (r *HookReconciler) reconcilePreHooks(ctx context.Context, job *batchv1.Job, hooks []mariadbv1alpha1.BackupHook,
+	mariadb interfaces.MariaDBObject, scheduled bool, patcher ConditionPatcher, logger logr.Logger) error {
+	logger.Info("Running pre hooks", "hooks", len(hooks))
+	result := runHooks(ctx, hooks, func(ctx context.Context, hook *mariadbv1alpha1.BackupHook) error {
+		return r.runHook(ctx, job, hook, mariadb, logger)
+	}, logger)
+
+	if len(hooks) > 0 {
+		if err := patcher(result.condition(mariadbv1alpha1.ConditionTypePreHooksExecuted)); err != nil {
+			return fmt.Errorf("error patching condition: %v", err)
+		}
+	}
+
+	if result.failed {
+		if scheduled {
+			logger.Info("Pre hooks failed. Deleting Job to allow new schedule...")
+			if err := r.Delete(ctx, job, &client.DeleteOptions{
+				PropagationPolicy: ptr.To(metav1.DeletePropagationBackground),
+			}); err != nil && !apierrors.IsNotFound(err) {
+				return fmt.Errorf("error deleting Job: %v", err)
+			}
+			return nil
+		}
+		logger.Info("Pre hooks failed. Keeping Job suspended")
+		return r.patchJob(ctx, job, func(j *batchv1.Job) {
+			j.Annotations[metadata.PreHooksAnnotation] = metadata.HooksFailedValue
+		})
+	}
+
+	logger.Info("Pre hooks succeeded. Resuming Job")
+	return r.patchJob(ctx, job, func(j *batchv1.Job) {
+		j.Annotations[metadata.PreHooksAnnotation] = metadata.HooksSucceededValue
+		j.Spec.Suspend = ptr.To(false)
+	})
+}
+
+func (r *HookReconciler) reconcilePostHooks(ctx context.Context, job *batchv1.Job, hooks []mariadbv1alpha1.BackupHook,
+	mariadb interfaces.MariaDBObject, patcher ConditionPatcher, logger logr.Logger) error {
+	logger.Info("Running post hooks", "hooks", len(hooks))
+	result := runHooks(ctx, hooks, func(ctx context.Context, hook *mariadbv1alpha1.BackupHook) error {
+		return r.runHook(ctx, job, hook, mariadb, logger)
+	}, logger)
+
+	if len(hooks) > 0 {
+		if err := patcher(result.condition(mariadbv1alpha1.ConditionTypePostHooksExecuted)); err != nil {
+			return fmt.Errorf("error patching condition: %v", err)
+		}
+	}
+
+	value := metadata.HooksSucceededValue
+	if result.failed {
+		value = metadata.HooksFailedValue
+	}
+	return r.patchJob(ctx, job, func(j *batchv1.Job) {
+		j.Annotations[metadata.PostHooksAnnotation] = value
+	})
+}
+
+func (r *HookReconciler) runHook(ctx context.Context, job *batchv1.Job, hook *mariadbv1alpha1.BackupHook,
+	mariadb interfaces.MariaDBObject, logger logr.Logger) error {
+	if hook.SQL != nil {
+		return r.runSQL(ctx, job, hook, mariadb)
+	}
+	if hook.Exec != nil {
+		return r.runExec(ctx, job, hook, mariadb, logger)
+	}
+	return errors.New("either 'sql' or 'exec' must be set")
+}
+
+func (r *HookReconciler) runSQL(ctx context.Context, job *batchv1.Job, hook *mariadbv1alpha1.BackupHook,
+	mariadb interfaces.MariaDBObject) error {
+	opts := []sql.Opt{
+		sql.WithTimeout(hook.TimeoutOrDefault()),
+	}
+	var sqlClient *sql.Client
+	var err error
+
+	mdb, ok := mariadb.(*mariadbv1alpha1.MariaDB)
+	if ok {
+		var podIndex *int
+		podIndex, err = sqlPodIndex(job, hook, mdb)
+		if err != nil {
+			return err
+		}
+		if podIndex != nil {
+			sqlClient, err = sql.NewInternalClientWithPodIndex(ctx, mdb, r.refResolver, *podIndex, opts...)
+		} else {
+			sqlClient, err = sql.NewClientWithMariaDB(ctx, mdb, r.refResolver, opts...)
+		}
+	} else {
+		sqlClient, err = sql.NewClientWithMariaDB(ctx, mariadb, r.refResolver, opts...)
+	}
+	if err != nil {
+		return fmt.Errorf("error getting SQL client: %v", err)
+	}
+	defer sqlClient.Close()
+
+	if err := sqlClient.Exec(ctx, *hook.SQL); err != nil {
+		return fmt.Errorf("error executing SQL: %v", err)
+	}
+	return nil
+}
+
+func (r *HookReconciler) runExec(ctx context.Context, job *batchv1.Job, hook *mariadbv1alpha1.BackupHook,
+	mariadb interfaces.MariaDBObject, logger logr.Logger) error {
+	mdb, ok := mariadb.(*mariadbv1alpha1.MariaDB)
+	if !ok {
+		return errors.New("exec hooks are only supported by MariaDB resources")
+	}
+	podName, err := targetPodName(job, mdb)
+	if err != nil {
+		return err
+	}
+	podKey := types.NamespacedName{
+		Name:      podName,
+		Namespace: mdb.Namespace,
+	}
+	container := ptr.Deref(hook.Exec.Container, builder.MariadbContainerName)
+
+	stdout, stderr, err := r.executor.Exec(ctx, podKey, container, hook.Exec.Command)
+	if err != nil {
+		if stderr != "" {
+			return fmt.Errorf("error executing command in Pod '%s': %v: %s", podName, err, strings.TrimSpace(stderr))
+		}
+		return fmt.Errorf("error executing command in Pod '%s': %v", podName, err)
+	}
+	logger.V(1).Info("Command executed", "hook", hook.Name, "pod", podName, "stdout", stdout, "stderr", stderr)
+	return nil
+}
+
+func (r *HookReconciler) patchJob(ctx context.Context, job *batchv1.Job, patchFn func(*batchv1.Job)) error {
+	patch := client.MergeFrom(job.DeepCopy())
+	if job.Annotations == nil {
+		job.Annotations = make(map[string]string)
+	}
+	patchFn(job)
+	if err := r.Patch(ctx, job, patch); err != nil {
+		return fmt.Errorf("error patching Job: %v", err)
+	}
+	return nil
+}
+
+// sqlPodIndex returns the index of the Pod where the SQL of a hook is executed.
+// It returns nil when the primary Pod is not known, meaning that the SQL is executed via the primary Service.
+func sqlPodIndex(job *batchv1.Job, hook *mariadbv1alpha1.BackupHook, mariadb *mariadbv1alpha1.MariaDB) (*int, error) {
+	if hook.PodRoleOrDefault() == mariadbv1alpha1.BackupHookPodRoleTarget {
+		if podName, ok := job.Annotations[metadata.HooksTargetPodAnnotation]; ok {
+			podIndex, err := statefulset.PodIndex(podName)
+			if err != nil {
+				return nil, fmt.Errorf("error getting index for Pod '%s': %v", podName, err)
+			}
+			return podIndex, nil
+		}
+	}
+	return mariadb.Status.CurrentPrimaryPodIndex, nil
+}
+
+// targetPodName returns the name of the Pod where the backup is taken.
+// Logical backups are taken from the primary, whereas physical backups record the target Pod in the Job annotations.
+func targetPodName(job *batchv1.Job, mariadb *mariadbv1alpha1.MariaDB) (string, error) {
+	if podName, ok := job.Annotations[metadata.HooksTargetPodAnnotation]; ok {
+		return podName, nil
+	}
+	if mariadb.Status.CurrentPrimaryPodIndex == nil {
+		return "", errors.New("primary Pod not available")
+	}
+	return statefulset.PodName(mariadb.ObjectMeta, *mariadb.Status.CurrentPrimaryPodIndex), nil
+}
+
+type hookFn func(ctx context.Context, hook *mariadbv1alpha1.BackupHook) error
+
+type hooksResult struct {
+	succeeded []string
+	errors    []string
+	failed    bool
+}
+
+// runHooks runs the hooks sequentially, each of them bounded by its timeout.
+// It stops at the first hook that fails with the 'Fail' policy.
+func runHooks(ctx context.Context, hooks []mariadbv1alpha1.BackupHook, fn hookFn, logger logr.Logger) hooksResult {
+	var result hooksResult
+	for _, hook := range hooks {
+		hookCtx, cancel := context.WithTimeout(ctx, hook.TimeoutOrDefault())
+		err := fn(hookCtx, &hook)
+		cancel()
+
+		if err == nil {
+			logger.V(1).Info("Hook succeeded", "hook", hook.Name)
+			result.succeeded = append(result.succeeded, hook.Name)
+			continue
+		}
+		logger.Error(err, "Hook failed", "hook", hook.Name, "on-error", hook.OnErrorOrDefault())
+		result.errors = append(result.errors, fmt.Sprintf("hook '%s' failed: %v", hook.Name, err))
+
+		if hook.OnErrorOrDefault() == mariadbv1alpha1.BackupHookOnErrorFail {
+			result.failed = true
+			return result
+		}
+	}
+	return result
+}
+
+func (r hooksResult) condition(conditionType string) metav1.Condition {
+	if r.failed {
+		return metav1.Condition{
+			Type:    conditionType,
+			Status:  metav1.ConditionFalse,
+			Reason:  mariadbv1alpha1.ConditionReasonHooksFailed,
+			Message: strings.Join(r.errors, "; "),
+		}
+	}
+	if len(r.errors) > 0 {
+		return metav1.Condition{
+			Type:    conditionType,
+			Status:  metav1.ConditionTrue,
+			Reason:  mariadbv1alpha1.ConditionReasonHooksSucceededWithErrors,
+			Message: strings.Join(r.errors, "; "),
+		}
+	}
+	return metav1.Condition{
+		Type:    conditionType,
+		Status:  metav1.ConditionTrue,
+		Reason:  mariadbv1alpha1.ConditionReasonHooksSucceeded,
+		Message: fmt.Sprintf("Executed hooks: %s", strings.Join(r.succeeded, ", ")),
+	}
+}
@@ -0,0 +1,341 @@
+package hook
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/go-logr/logr"
+	mariadbv1alpha1 "github.com/mariadb-operator/mariadb-operator/v26/api/v1alpha1"
+	"github.com/mariadb-operator/mariadb-operator/v26/pkg/metadata"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestRunHooks(t *testing.T) {
+	tests := []struct {
+		name          string
+		hooks         []mariadbv1alpha1.BackupHook
+		failing       map[string]bool
+		wantRun       []string
+		wantFailed    bool
+		wantReason    string
+		wantCondition metav1.ConditionStatus
+	}{
+		{
+			name: "all succeeded",
+			hooks: []mariadbv1alpha1.BackupHook{
+				{Name: "a"},
+				{Name: "b"},
+			},
+			wantRun:       []string{"a", "b"},
+			wantReason:    mariadbv1alpha1.ConditionReasonHooksSucceeded,
+			wantCondition: metav1.ConditionTrue,
+		},
+		{
+			name: "fail policy",
+			hooks: []mariadbv1alpha1.BackupHook{
+				{Name: "a"},
+				{Name: "b"},
+				{Name: "c"},
+			},
+			failing:       map[string]bool{"b": true},
+			wantRun:       []string{"a", "b"},
+			wantFailed:    true,
+			wantReason:    mariadbv1alpha1.ConditionReasonHooksFailed,
+			wantCondition: metav1.ConditionFalse,
+		},
+		{
+			name: "continue policy",
+			hooks: []mariadbv1alpha1.BackupHook{
+				{Name: "a", OnError: ptr.To(mariadbv1alpha1.BackupHookOnErrorContinue)},
+				{Name: "b"},
+			},
+			failing:       map[string]bool{"a": true},
+			wantRun:       []string{"a", "b"},
+			wantReason:    mariadbv1alpha1.ConditionReasonHooksSucceededWithErrors,
+			wantCondition: metav1.ConditionTrue,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var run []string
+			result := runHooks(context.Background(), tt.hooks, func(ctx context.Context, hook *mariadbv1alpha1.BackupHook) error {
+				if _, ok := ctx.Deadline(); !ok {
+					t.Error("expected hook context to have a deadline")
+				}
+				run = append(run, hook.Name)
+				if tt.failing[hook.Name] {
+					return errors.New("test error")
+				}
+				return nil
+			}, logr.Discard())
+
+			if len(run) != len(tt.wantRun) {
+				t.Fatalf("unexpected hooks run, expected: %v got: %v", tt.wantRun, run)
+			}
+			for i := range run {
+				if run[i] != tt.wantRun[i] {
+					t.Errorf("unexpected hooks run, expected: %v got: %v", tt.wantRun, run)
+				}
+			}
+			if result.failed != tt.wantFailed {
+				t.Errorf("unexpected failed result, expected: %v got: %v", tt.wantFailed, result.failed)
+			}
+			condition := result.condition(mariadbv1alpha1.ConditionTypePreHooksExecuted)
+			if condition.Reason != tt.wantReason {
+				t.Errorf("unexpected condition reason, expected: %s got: %s", tt.wantReason, condition.Reason)
+			}
+			if condition.Status != tt.wantCondition {
+				t.Errorf("unexpected condition status, expected: %s got: %s", tt.wantCondition, condition.Status)
+			}
+		})
+	}
+}
+
+func TestSQLPodIndex(t *testing.T) {
+	mariadb := &mariadbv1alpha1.MariaDB{
+		Status: mariadbv1alpha1.MariaDBStatus{
+			CurrentPrimaryPodIndex: ptr.To(0),
+		},
+	}
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Annotations: map[string]string{
+				metadata.HooksTargetPodAnnotation: "mariadb-2",
+			},
+		},
+	}
+	tests := []struct {
+		name    string
+		job     *batchv1.Job
+		podRole *mariadbv1alpha1.BackupHookPodRole
+		want    int
+	}{
+		{
+			name: "default role",
+			job:  job,
+			want: 0,
+		},
+		{
+			name:    "target role",
+			job:     job,
+			podRole: ptr.To(mariadbv1alpha1.BackupHookPodRoleTarget),
+			want:    2,
+		},
+		{
+			name:    "target role without target Pod",
+			job:     &batchv1.Job{},
+			podRole: ptr.To(mariadbv1alpha1.BackupHookPodRoleTarget),
+			want:    0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hook := &mariadbv1alpha1.BackupHook{
+				Name:    "test",
+				SQL:     ptr.To("SELECT 1"),
+				PodRole: tt.podRole,
+			}
+			podIndex, err := sqlPodIndex(tt.job, hook, mariadb)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if podIndex == nil || *podIndex != tt.want {
+				t.Errorf("unexpected Pod index, expected: %d got: %v", tt.want, podIndex)
+			}
+		})
+	}
+}
+
+type fakeExecutor struct {
+	commands []string
+	err      error
+}
+
+func (e *fakeExecutor) Exec(ctx context.Context, podKey types.NamespacedName, container string,
+	command []string) (string, string, error) {
+	e.commands = append(e.commands, podKey.Name+"/"+container)
+	return "", "", e.err
+}
+
+func TestReconcileJobs(t *testing.T) {
+	mariadb := &mariadbv1alpha1.MariaDB{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "mariadb",
+			Namespace: "test",
+		},
+		Status: mariadbv1alpha1.MariaDBStatus{
+			CurrentPrimaryPodIndex: ptr.To(0),
+		},
+	}
+	hooks := &mariadbv1alpha1.BackupHooks{
+		Pre: []mariadbv1alpha1.BackupHook{
+			{
+				Name: "pre",
+				Exec: &mariadbv1alpha1.BackupHookExec{
+					Command: []string{"true"},
+				},
+			},
+		},
+		Post: []mariadbv1alpha1.BackupHook{
+			{
+				Name: "post",
+				Exec: &mariadbv1alpha1.BackupHookExec{
+					Command:   []string{"true"},
+					Container: ptr.To("agent"),
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		name           string
+		job            *batchv1.Job
+		execErr        error
+		scheduled      bool
+		wantCommands   []string
+		wantConditions []string
+		wantDeleted    bool
+		wantSuspend    bool
+		wantPre        string
+		wantPost       string
+	}{
+		{
+			name:           "pre hooks succeeded",
+			job:            newTestJob(true, false),
+			wantCommands:   []string{"mariadb-1/mariadb"},
+			wantConditions: []string{mariadbv1alpha1.ConditionTypePreHooksExecuted},
+			wantPre:        metadata.HooksSucceededValue,
+			wantPost:       metadata.HooksPendingValue,
+		},
+		{
+			name:           "pre hooks failed",
+			job:            newTestJob(true, false),
+			execErr:        errors.New("test error"),
+			wantCommands:   []string{"mariadb-1/mariadb"},
+			wantConditions: []string{mariadbv1alpha1.ConditionTypePreHooksExecuted},
+			wantSuspend:    true,
+			wantPre:        metadata.HooksFailedValue,
+			wantPost:       metadata.HooksPendingValue,
+		},
+		{
+			name:           "pre hooks failed in scheduled Job",
+			job:            newTestJob(true, false),
+			execErr:        errors.New("test error"),
+			scheduled:      true,
+			wantCommands:   []string{"mariadb-1/mariadb"},
+			wantConditions: []string{mariadbv1alpha1.ConditionTypePreHooksExecuted},
+			wantDeleted:    true,
+		},
+		{
+			name:     "Job running",
+			job:      newTestJob(false, false),
+			wantPre:  metadata.HooksSucceededValue,
+			wantPost: metadata.HooksPendingValue,
+		},
+		{
+			name:           "post hooks succeeded",
+			job:            newTestJob(false, true),
+			wantCommands:   []string{"mariadb-1/agent"},
+			wantConditions: []string{mariadbv1alpha1.ConditionTypePostHooksExecuted},
+			wantPre:        metadata.HooksSucceededValue,
+			wantPost:       metadata.HooksSucceededValue,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scheme := runtime.NewScheme()
+			if err := batchv1.AddToScheme(scheme); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if err := corev1.AddToScheme(scheme); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(tt.job).Build()
+			executor := &fakeExecutor{err: tt.execErr}
+			r := NewHookReconciler(fakeClient, executor)
+
+			var conditions []string
+			patcher := func(condition metav1.Condition) error {
+				conditions = append(conditions, condition.Type)
+				return nil
+			}
+			if err := r.ReconcileJobs(context.Background(), []batchv1.Job{*tt.job}, hooks, mariadb, tt.scheduled, patcher); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if len(executor.commands) != len(tt.wantCommands) {
+				t.Fatalf("unexpected commands, expected: %v got: %v", tt.wantCommands, executor.commands)
+			}
+			for i := range executor.commands {
+				if executor.commands[i] != tt.wantCommands[i] {
+					t.Errorf("unexpected commands, expected: %v got: %v", tt.wantCommands, executor.commands)
+				}
+			}
+			if len(conditions) != len(tt.wantConditions) {
+				t.Fatalf("unexpected conditions, expected: %v got: %v", tt.wantConditions, conditions)
+			}
+
+			var job batchv1.Job
+			err := fakeClient.Get(context.Background(), client.ObjectKeyFromObject(tt.job), &job)
+			if tt.wantDeleted {
+				if !apierrors.IsNotFound(err) {
+					t.Fatalf("expected Job to be deleted, got error: %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if job.Annotations[metadata.PreHooksAnnotation] != tt.wantPre {
+				t.Errorf("unexpected pre hooks annotation, expected: %s got: %s", tt.wantPre, job.Annotations[metadata.PreHooksAnnotation])
+			}
+			if job.Annotations[metadata.PostHooksAnnotation] != tt.wantPost {
+				t.Errorf("unexpected post hooks annotation, expected: %s got: %s", tt.wantPost, job.Annotations[metadata.PostHooksAnnotation])
+			}
+			if tt.job.Spec.Suspend != nil && *tt.job.Spec.Suspend && ptr.Deref(job.Spec.Suspend, false) != tt.wantSuspend {
+				t.Errorf("unexpected suspend, expected: %v got: %v", tt.wantSuspend, ptr.Deref(job.Spec.Suspend, false))
+			}
+		})
+	}
+}
+
+func newTestJob(suspend, complete bool) *batchv1.Job {
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "backup",
+			Namespace: "test",
+			Annotations: map[string]string{
+				metadata.PreHooksAnnotation:       metadata.HooksPendingValue,
+				metadata.PostHooksAnnotation:      metadata.HooksPendingValue,
+				metadata.HooksTargetPodAnnotation: "mariadb-1",
+			},
+		},
+		Spec: batchv1.JobSpec{
+			Suspend: ptr.To(suspend),
+		},
+	}
+	if !suspend {
+		job.Annotations[metadata.PreHooksAnnotation] = metadata.HooksSucceededValue
+	}
+	if complete {
+		job.Status.Conditions = []batchv1.JobCondition{
+			{
+				Type:   batchv1.JobComplete,
+				Status: corev1.ConditionTrue,
+			},
+		}
+	}
+	return job
+}
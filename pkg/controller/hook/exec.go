@@ -0,0 +1,61 @@
+package hook
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/remotecommand"
+)
+
+// Executor executes commands in a container of a Pod.
+type Executor interface {
+	Exec(ctx context.Context, podKey types.NamespacedName, container string, command []string) (stdout, stderr string, err error)
+}
+
+// PodExecutor executes commands in Pods using the exec subresource of the Kubernetes API.
+type PodExecutor struct {
+	restConfig    *rest.Config
+	kubeClientset *kubernetes.Clientset
+}
+
+func NewPodExecutor(restConfig *rest.Config, kubeClientset *kubernetes.Clientset) *PodExecutor {
+	return &PodExecutor{
+		restConfig:    restConfig,
+		kubeClientset: kubeClientset,
+	}
+}
+
+func (e *PodExecutor) Exec(ctx context.Context, podKey types.NamespacedName, container string,
+	command []string) (string, string, error) {
+	req := e.kubeClientset.CoreV1().RESTClient().
+		Post().
+		Resource("pods").
+		Name(podKey.Name).
+		Namespace(podKey.Namespace).
+		SubResource("exec").
+		VersionedParams(&corev1.PodExecOptions{
+			Container: container,
+			Command:   command,
+			Stdout:    true,
+			Stderr:    true,
+		}, scheme.ParameterCodec)
+
+	executor, err := remotecommand.NewSPDYExecutor(e.restConfig, http.MethodPost, req.URL())
+	if err != nil {
+		return "", "", fmt.Errorf("error creating executor: %v", err)
+	}
+
+	var stdout, stderr bytes.Buffer
+	err = executor.StreamWithContext(ctx, remotecommand.StreamOptions{
+		Stdout: &stdout,
+		Stderr: &stderr,
+	})
+	return stdout.String(), stderr.String(), err
+}
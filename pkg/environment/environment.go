@@ -89,6 +89,12 @@ type PodEnvironment struct {
 
 	MariadbOperatorABSCAPath string `env:"MARIADB_OPERATOR_ABS_CA_PATH"`
 	ABSStorageAccountKey     string `env:"MARIADB_OPERATOR_ABS_STORAGE_ACCOUNT_KEY"`
+
+	MariadbOperatorGCSServiceAccountKey string `env:"MARIADB_OPERATOR_GCS_SERVICE_ACCOUNT_KEY"`
+
+	MariadbOperatorPITRStoragePath string `env:"MARIADB_OPERATOR_PITR_STORAGE_PATH"`
+
+	MariadbOperatorEncryptionKey string `env:"MARIADB_OPERATOR_ENCRYPTION_KEY"`
 }
 
 func (e *PodEnvironment) Port() (int32, error) {
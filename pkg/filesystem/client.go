@@ -0,0 +1,267 @@
+package filesystem
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/mariadb-operator/mariadb-operator/v26/pkg/interfaces"
+	"github.com/mariadb-operator/mariadb-operator/v26/pkg/progress"
+	"github.com/mariadb-operator/mariadb-operator/v26/pkg/ratelimit"
+)
+
+// tmpFilePrefix is the prefix of the temporary files used to write objects atomically. They are not listed as objects.
+const tmpFilePrefix = ".tmp-"
+
+type FileSystemOpts struct {
+	Prefix              string // A prefix relative to the root path to be applied to object names. Perform All operations under here
+	AllowNestedPrefixes bool
+
+	RateLimiter     *ratelimit.Limiter // Limits the throughput of uploads and downloads
+	ProgressTracker *progress.Tracker  // Keeps track of the bytes uploaded and downloaded
+}
+
+type FileSystemOpt func(o *FileSystemOpts)
+
+func WithPrefix(prefix string) FileSystemOpt {
+	return func(o *FileSystemOpts) {
+		o.Prefix = prefix
+	}
+}
+
+func WithAllowNestedPrefixes(allowNestedPrefixes bool) FileSystemOpt {
+	return func(o *FileSystemOpts) {
+		o.AllowNestedPrefixes = allowNestedPrefixes
+	}
+}
+
+func WithRateLimiter(limiter *ratelimit.Limiter) FileSystemOpt {
+	return func(o *FileSystemOpts) {
+		o.RateLimiter = limiter
+	}
+}
+
+func WithProgressTracker(tracker *progress.Tracker) FileSystemOpt {
+	return func(o *FileSystemOpts) {
+		o.ProgressTracker = tracker
+	}
+}
+
+// FileSystemClient implements the blob storage interface on top of a directory, typically a mounted PVC or volume.
+// Object names are slash-separated paths relative to the root path.
+type FileSystemClient struct {
+	Opts *FileSystemOpts
+	// RootPath is the directory where the objects are stored.
+	RootPath string
+
+	// Local FS Opts
+	BasePath string
+}
+
+// NewFileSystemClient creates a client that stores objects under rootPath.
+// basePath is used for local FS operations.
+func NewFileSystemClient(basePath, rootPath string, fsOpts ...FileSystemOpt) (*FileSystemClient, error) {
+	if rootPath == "" {
+		return nil, errors.New("root path must be set")
+	}
+	opts := &FileSystemOpts{}
+	for _, setOpt := range fsOpts {
+		setOpt(opts)
+	}
+	return &FileSystemClient{
+		Opts:     opts,
+		RootPath: rootPath,
+		BasePath: basePath,
+	}, nil
+}
+
+// Blob Storage Interop
+
+// PutObjectWithOptions writes the given reader to a temporary file, which is renamed to the object path once completed.
+// This way, readers never observe partially written objects. `size` is ignored and is passed to satisfy the interface
+func (c *FileSystemClient) PutObjectWithOptions(ctx context.Context, fileName string, reader io.Reader, size int64) error {
+	objectPath := c.objectPath(c.PrefixedFileName(fileName))
+	if err := os.MkdirAll(filepath.Dir(objectPath), os.ModePerm); err != nil {
+		return err
+	}
+
+	tmpFile, err := os.CreateTemp(filepath.Dir(objectPath), tmpFilePrefix+filepath.Base(objectPath)+"-*")
+	if err != nil {
+		return err
+	}
+	tmpPath := tmpFile.Name()
+	defer os.Remove(tmpPath)
+
+	_, err = io.Copy(tmpFile, c.Opts.ProgressTracker.Reader(c.Opts.RateLimiter.Reader(ctx, reader)))
+	if err == nil {
+		err = tmpFile.Sync()
+	}
+	if closeErr := tmpFile.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("error writing object: %w", err)
+	}
+	return os.Rename(tmpPath, objectPath)
+}
+
+// PutObjectStreamWithOptions writes the given reader. Objects are streamed to the filesystem, so parts are not used.
+func (c *FileSystemClient) PutObjectStreamWithOptions(ctx context.Context, fileName string, reader io.Reader,
+	opts interfaces.StreamOpts) error {
+	return c.PutObjectWithOptions(ctx, fileName, reader, 0)
+}
+
+func (c *FileSystemClient) FPutObjectWithOptions(ctx context.Context, fileName string) error {
+	file, err := os.Open(c.getFilePath(fileName))
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	return c.PutObjectWithOptions(ctx, fileName, file, 0)
+}
+
+func (c *FileSystemClient) GetObjectWithOptions(ctx context.Context, fileName string) (io.ReadCloser, error) {
+	file, err := os.Open(c.objectPath(c.PrefixedFileName(fileName)))
+	if err != nil {
+		return nil, err
+	}
+	return c.Opts.ProgressTracker.ReadCloser(c.Opts.RateLimiter.ReadCloser(ctx, file)), nil
+}
+
+func (c *FileSystemClient) FGetObjectWithOptions(ctx context.Context, fileName string) error {
+	rc, err := c.GetObjectWithOptions(ctx, fileName)
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+
+	filePath := c.getFilePath(fileName)
+	if err := os.MkdirAll(filepath.Dir(filePath), os.ModePerm); err != nil {
+		return err
+	}
+
+	file, err := os.Create(filePath)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	_, err = io.Copy(file, rc)
+	return err
+}
+
+func (c *FileSystemClient) RemoveWithOptions(ctx context.Context, fileName string) error {
+	return os.Remove(c.objectPath(c.PrefixedFileName(fileName)))
+}
+
+func (c *FileSystemClient) Exists(ctx context.Context, fileName string) (bool, error) {
+	info, err := os.Stat(c.objectPath(c.PrefixedFileName(fileName)))
+	if err != nil {
+		if c.IsNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return info.Mode().IsRegular(), nil
+}
+
+func (c *FileSystemClient) Size(ctx context.Context, fileName string) (int64, error) {
+	info, err := os.Stat(c.objectPath(c.PrefixedFileName(fileName)))
+	if err != nil {
+		return 0, err
+	}
+	return info.Size(), nil
+}
+
+// IsLocked always returns false, as filesystems do not support object locking.
+func (c *FileSystemClient) IsLocked(ctx context.Context, fileName string) (bool, error) {
+	return false, nil
+}
+
+func (c *FileSystemClient) PrefixedFileName(fileName string) string {
+	if c.Opts.AllowNestedPrefixes {
+		return c.GetPrefix() + fileName
+	}
+	return c.GetPrefix() + filepath.Base(fileName)
+}
+
+func (c *FileSystemClient) UnprefixedFilename(fileName string) string {
+	return strings.TrimPrefix(filepath.Base(fileName), c.GetPrefix())
+}
+
+func (c *FileSystemClient) GetPrefix() string {
+	if c.Opts.Prefix == "" || c.Opts.Prefix == "/" {
+		return "" // objects are stored in the root path
+	}
+	if !strings.HasSuffix(c.Opts.Prefix, "/") {
+		return c.Opts.Prefix + "/" // ending slash is required for avoiding matching like "foo/" and "foobar/" with prefix "foo"
+	}
+	return c.Opts.Prefix
+}
+
+// ListObjectsWithOptions returns the names of the objects under the prefix, sorted lexicographically as object storages do.
+func (c *FileSystemClient) ListObjectsWithOptions(ctx context.Context) ([]string, error) {
+	prefixPath := c.objectPath(c.GetPrefix())
+	if _, err := os.Stat(prefixPath); err != nil {
+		if c.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("error listing objects: %v", err)
+	}
+
+	var objects []string
+	err := filepath.WalkDir(prefixPath, func(filePath string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		// ext4 volumes are created with a lost+found directory that is not readable by non-root users.
+		if d.IsDir() && d.Name() == "lost+found" {
+			return fs.SkipDir
+		}
+		if !d.Type().IsRegular() || strings.HasPrefix(d.Name(), tmpFilePrefix) {
+			return nil
+		}
+		relPath, err := filepath.Rel(c.RootPath, filePath)
+		if err != nil {
+			return err
+		}
+		objects = append(objects, filepath.ToSlash(relPath))
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error listing objects: %v", err)
+	}
+	sort.Strings(objects)
+	return objects, nil
+}
+
+// IsAuthenticated checks that the root path is an accessible directory, as there are no credentials involved.
+func (c *FileSystemClient) IsAuthenticated(ctx context.Context) bool {
+	info, err := os.Stat(c.RootPath)
+	return err == nil && info.IsDir()
+}
+
+func (c *FileSystemClient) IsNotFound(err error) bool {
+	return errors.Is(err, fs.ErrNotExist)
+}
+
+func (c *FileSystemClient) objectPath(objectName string) string {
+	return filepath.Join(c.RootPath, filepath.FromSlash(objectName))
+}
+
+func (c *FileSystemClient) getFilePath(fileName string) string {
+	if filepath.IsAbs(fileName) {
+		return fileName
+	}
+	return filepath.Join(c.BasePath, fileName)
+}
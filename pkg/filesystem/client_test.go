@@ -0,0 +1,210 @@
+package filesystem
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestPrefixedFile(t *testing.T) {
+	tests := []struct {
+		name         string
+		opts         FileSystemOpts
+		fileName     string
+		wantFileName string
+	}{
+		{
+			name:         "no prefix",
+			opts:         FileSystemOpts{},
+			fileName:     "backup.2023-12-18T16:14:00Z.sql",
+			wantFileName: "backup.2023-12-18T16:14:00Z.sql",
+		},
+		{
+			name:         "no prefix with file path",
+			opts:         FileSystemOpts{},
+			fileName:     "backup/backup.2023-12-18T16:14:00Z.sql",
+			wantFileName: "backup.2023-12-18T16:14:00Z.sql",
+		},
+		{
+			name: "prefix",
+			opts: FileSystemOpts{
+				Prefix: "mariadb",
+			},
+			fileName:     "backup.2023-12-18T16:14:00Z.sql",
+			wantFileName: "mariadb/backup.2023-12-18T16:14:00Z.sql",
+		},
+		{
+			name: "nested prefixes allowed",
+			opts: FileSystemOpts{
+				Prefix:              "binlogs",
+				AllowNestedPrefixes: true,
+			},
+			fileName:     "server-0/mariadb-bin.000001",
+			wantFileName: "binlogs/server-0/mariadb-bin.000001",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &FileSystemClient{Opts: &tt.opts}
+			if fileName := client.PrefixedFileName(tt.fileName); fileName != tt.wantFileName {
+				t.Errorf("unexpected file name, got: %s, want: %s", fileName, tt.wantFileName)
+			}
+		})
+	}
+}
+
+func TestClientObjects(t *testing.T) {
+	rootPath := t.TempDir()
+	client, err := NewFileSystemClient(t.TempDir(), rootPath, WithPrefix("binlogs"), WithAllowNestedPrefixes(true))
+	if err != nil {
+		t.Fatalf("unexpected error creating client: %v", err)
+	}
+	ctx := context.Background()
+
+	if !client.IsAuthenticated(ctx) {
+		t.Fatal("expected client to be authenticated")
+	}
+	objects, err := client.ListObjectsWithOptions(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error listing objects: %v", err)
+	}
+	if len(objects) != 0 {
+		t.Fatalf("expected no objects, got: %v", objects)
+	}
+
+	exists, err := client.Exists(ctx, "server-10/mariadb-repl-bin.000001")
+	if err != nil {
+		t.Fatalf("unexpected error checking object: %v", err)
+	}
+	if exists {
+		t.Fatal("expected object not to exist")
+	}
+	if _, err := client.GetObjectWithOptions(ctx, "server-10/mariadb-repl-bin.000001"); !client.IsNotFound(err) {
+		t.Fatalf("expected not found error, got: %v", err)
+	}
+
+	contents := map[string][]byte{
+		"index.yaml":                           []byte("apiVersion: v1"),
+		"server-10/mariadb-repl-bin.000001":    []byte("Lorem ipsum dolor sit amet"),
+		"server-10/mariadb-repl-bin.000002.gz": []byte("consectetur adipiscing elit"),
+	}
+	for fileName, content := range contents {
+		if err := client.PutObjectWithOptions(ctx, fileName, bytes.NewReader(content), int64(len(content))); err != nil {
+			t.Fatalf("unexpected error putting object %s: %v", fileName, err)
+		}
+		exists, err := client.Exists(ctx, fileName)
+		if err != nil {
+			t.Fatalf("unexpected error checking object: %v", err)
+		}
+		if !exists {
+			t.Fatalf("expected object %s to exist", fileName)
+		}
+		size, err := client.Size(ctx, fileName)
+		if err != nil {
+			t.Fatalf("unexpected error getting object size: %v", err)
+		}
+		if size != int64(len(content)) {
+			t.Fatalf("unexpected size for object %s, got: %d, want: %d", fileName, size, len(content))
+		}
+
+		reader, err := client.GetObjectWithOptions(ctx, fileName)
+		if err != nil {
+			t.Fatalf("unexpected error getting object: %v", err)
+		}
+		got, err := io.ReadAll(reader)
+		reader.Close()
+		if err != nil {
+			t.Fatalf("unexpected error reading object: %v", err)
+		}
+		if !bytes.Equal(got, content) {
+			t.Fatalf("unexpected content for object %s, got: %s, want: %s", fileName, got, content)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(rootPath, "binlogs", "server-10", "mariadb-repl-bin.000001")); err != nil {
+		t.Fatalf("expected object to be stored under the root path: %v", err)
+	}
+
+	// Objects outside of the prefix and temporary files are not listed.
+	if err := os.WriteFile(filepath.Join(rootPath, "other.txt"), []byte("other"), 0644); err != nil {
+		t.Fatalf("unexpected error writing file: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(rootPath, "binlogs", tmpFilePrefix+"index.yaml-123"), []byte("tmp"), 0644); err != nil {
+		t.Fatalf("unexpected error writing file: %v", err)
+	}
+	objects, err = client.ListObjectsWithOptions(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error listing objects: %v", err)
+	}
+	wantObjects := []string{
+		"binlogs/index.yaml",
+		"binlogs/server-10/mariadb-repl-bin.000001",
+		"binlogs/server-10/mariadb-repl-bin.000002.gz",
+	}
+	if !reflect.DeepEqual(objects, wantObjects) {
+		t.Fatalf("unexpected objects, got: %v, want: %v", objects, wantObjects)
+	}
+
+	if err := client.RemoveWithOptions(ctx, "server-10/mariadb-repl-bin.000001"); err != nil {
+		t.Fatalf("unexpected error removing object: %v", err)
+	}
+	if err := client.RemoveWithOptions(ctx, "server-10/mariadb-repl-bin.000001"); !client.IsNotFound(err) {
+		t.Fatalf("expected not found error, got: %v", err)
+	}
+	objects, err = client.ListObjectsWithOptions(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error listing objects: %v", err)
+	}
+	if len(objects) != len(wantObjects)-1 {
+		t.Fatalf("unexpected number of objects after removal, got: %d, want: %d", len(objects), len(wantObjects)-1)
+	}
+}
+
+func TestClientFiles(t *testing.T) {
+	basePath := t.TempDir()
+	client, err := NewFileSystemClient(basePath, t.TempDir())
+	if err != nil {
+		t.Fatalf("unexpected error creating client: %v", err)
+	}
+	ctx := context.Background()
+	fileName := "backup.2023-12-18T16:14:00Z.sql"
+	content := []byte("Lorem ipsum dolor sit amet, consectetur adipiscing elit.")
+
+	if err := os.WriteFile(filepath.Join(basePath, fileName), content, 0644); err != nil {
+		t.Fatalf("unexpected error writing file: %v", err)
+	}
+	if err := client.FPutObjectWithOptions(ctx, fileName); err != nil {
+		t.Fatalf("unexpected error putting file: %v", err)
+	}
+	if err := os.Remove(filepath.Join(basePath, fileName)); err != nil {
+		t.Fatalf("unexpected error removing file: %v", err)
+	}
+	if err := client.FGetObjectWithOptions(ctx, fileName); err != nil {
+		t.Fatalf("unexpected error getting file: %v", err)
+	}
+	got, err := os.ReadFile(filepath.Join(basePath, fileName))
+	if err != nil {
+		t.Fatalf("unexpected error reading file: %v", err)
+	}
+	if !bytes.Equal(got, content) {
+		t.Fatalf("unexpected file content, got: %s, want: %s", got, content)
+	}
+}
+
+func TestClientAuthentication(t *testing.T) {
+	ctx := context.Background()
+	client, err := NewFileSystemClient("", filepath.Join(t.TempDir(), "missing"))
+	if err != nil {
+		t.Fatalf("unexpected error creating client: %v", err)
+	}
+	if client.IsAuthenticated(ctx) {
+		t.Fatal("expected client not to be authenticated when the root path does not exist")
+	}
+	if _, err := NewFileSystemClient("", ""); err == nil {
+		t.Fatal("expected error creating client without root path")
+	}
+}
@@ -0,0 +1,682 @@
+package gcs
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mariadb-operator/mariadb-operator/v26/pkg/interfaces"
+	"github.com/mariadb-operator/mariadb-operator/v26/pkg/multipart"
+	"github.com/mariadb-operator/mariadb-operator/v26/pkg/progress"
+	"github.com/mariadb-operator/mariadb-operator/v26/pkg/ratelimit"
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/jwt"
+)
+
+const (
+	// DefaultEndpoint is the Google Cloud Storage JSON API endpoint.
+	DefaultEndpoint = "https://storage.googleapis.com"
+	// EmulatorHostEnv is the environment variable used by the Google Cloud SDKs to point to a GCS emulator.
+	// When set, it takes precedence over the configured endpoint and authentication is disabled.
+	EmulatorHostEnv = "STORAGE_EMULATOR_HOST"
+
+	storageScope       = "https://www.googleapis.com/auth/devstorage.read_write"
+	defaultTokenURL    = "https://oauth2.googleapis.com/token"
+	metadataHostEnv    = "GCE_METADATA_HOST"
+	defaultMetadataURL = "http://metadata.google.internal"
+	metadataTokenPath  = "/computeMetadata/v1/instance/service-accounts/default/token"
+
+	// uploadChunkSize must be a multiple of uploadChunkMultiple, as required by resumable uploads.
+	uploadChunkSize     = 8 * 1024 * 1024
+	uploadChunkMultiple = 256 * 1024
+)
+
+type GCSOpts struct {
+	Endpoint string
+
+	// Authentication Opts
+	ServiceAccountKey     []byte // Service account JSON key. If not provided, workload identity is used.
+	WithoutAuthentication bool
+
+	Prefix              string // A prefix relative to the bucket root to be applied to object names. Perform All operations under here
+	AllowNestedPrefixes bool
+	Metadata            map[string]string // Metadata to be added to the uploaded objects
+
+	RateLimiter     *ratelimit.Limiter // Limits the throughput of uploads and downloads
+	ProgressTracker *progress.Tracker  // Keeps track of the bytes uploaded and downloaded
+}
+
+type GCSOpt func(o *GCSOpts)
+
+func WithEndpoint(endpoint string) GCSOpt {
+	return func(o *GCSOpts) {
+		o.Endpoint = endpoint
+	}
+}
+
+func WithServiceAccountKey(key []byte) GCSOpt {
+	return func(o *GCSOpts) {
+		o.ServiceAccountKey = key
+	}
+}
+
+func WithoutAuthentication() GCSOpt {
+	return func(o *GCSOpts) {
+		o.WithoutAuthentication = true
+	}
+}
+
+func WithPrefix(prefix string) GCSOpt {
+	return func(o *GCSOpts) {
+		o.Prefix = prefix
+	}
+}
+
+func WithAllowNestedPrefixes(allowNestedPrefixes bool) GCSOpt {
+	return func(o *GCSOpts) {
+		o.AllowNestedPrefixes = allowNestedPrefixes
+	}
+}
+
+func WithMetadata(metadata map[string]string) GCSOpt {
+	return func(o *GCSOpts) {
+		o.Metadata = metadata
+	}
+}
+
+func WithRateLimiter(limiter *ratelimit.Limiter) GCSOpt {
+	return func(o *GCSOpts) {
+		o.RateLimiter = limiter
+	}
+}
+
+func WithProgressTracker(tracker *progress.Tracker) GCSOpt {
+	return func(o *GCSOpts) {
+		o.ProgressTracker = tracker
+	}
+}
+
+// Error is an error returned by the GCS JSON API.
+type Error struct {
+	StatusCode int
+	Message    string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("GCS error (status %d): %s", e.StatusCode, e.Message)
+}
+
+type GCSClient struct {
+	Opts   *GCSOpts
+	Bucket string
+
+	// Local FS Opts
+	BasePath string
+
+	httpClient *http.Client
+}
+
+// NewGCSClient creates a Google Cloud Storage client for a single bucket.
+// basePath is used for local FS operations.
+// Authentication is performed using the service account key, if provided, or workload identity otherwise.
+func NewGCSClient(basePath, bucket string, gcsOpts ...GCSOpt) (*GCSClient, error) {
+	opts := &GCSOpts{}
+	for _, setOpt := range gcsOpts {
+		setOpt(opts)
+	}
+	if opts.Endpoint == "" {
+		opts.Endpoint = DefaultEndpoint
+	}
+	if emulatorHost := os.Getenv(EmulatorHostEnv); emulatorHost != "" {
+		opts.Endpoint = emulatorHost
+		opts.WithoutAuthentication = true
+	}
+	if !strings.Contains(opts.Endpoint, "://") {
+		opts.Endpoint = "https://" + opts.Endpoint
+	}
+	opts.Endpoint = strings.TrimSuffix(opts.Endpoint, "/")
+
+	httpClient, err := getHTTPClient(opts)
+	if err != nil {
+		return nil, err
+	}
+	return &GCSClient{
+		Opts:       opts,
+		Bucket:     bucket,
+		BasePath:   basePath,
+		httpClient: httpClient,
+	}, nil
+}
+
+// Blob Storage Interop
+
+// PutObjectWithOptions uploads the given reader to GCS using a resumable upload.
+// `size` is ignored and is passed to satisfy the interface
+func (c *GCSClient) PutObjectWithOptions(ctx context.Context, fileName string, reader io.Reader, size int64) error {
+	return c.PutObjectStreamWithOptions(ctx, fileName, reader, interfaces.StreamOpts{
+		PartSize: uploadChunkSize,
+	})
+}
+
+// PutObjectStreamWithOptions uploads the given reader to GCS using a resumable upload, where each part is uploaded as a chunk.
+// When a chunk fails, the offset persisted by GCS is queried and the upload is resumed from there.
+func (c *GCSClient) PutObjectStreamWithOptions(ctx context.Context, fileName string, reader io.Reader,
+	opts interfaces.StreamOpts) error {
+	sessionURL, err := c.createUploadSession(ctx, c.PrefixedFileName(fileName))
+	if err != nil {
+		return fmt.Errorf("error creating upload session: %w", err)
+	}
+	opts.PartSize = multipart.RoundPartSize(opts.PartSize, uploadChunkMultiple)
+
+	var resume bool
+	_, err = multipart.Upload(ctx, reader, opts, func(ctx context.Context, part multipart.Part) error {
+		offset := part.Offset
+		if resume {
+			persistedOffset, completed, err := c.queryUploadOffset(ctx, sessionURL)
+			if err != nil {
+				return err
+			}
+			if completed && part.Last {
+				return nil
+			}
+			if persistedOffset < part.Offset || persistedOffset > part.Offset+int64(len(part.Data)) {
+				return fmt.Errorf("unexpected persisted offset %d for chunk at offset %d", persistedOffset, part.Offset)
+			}
+			offset = persistedOffset
+			resume = false
+			if offset == part.Offset+int64(len(part.Data)) && !part.Last {
+				return nil
+			}
+		}
+		if err := c.uploadChunk(ctx, sessionURL, part.Data[offset-part.Offset:], offset, part.Last); err != nil {
+			resume = true
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("error uploading object: %w", err)
+	}
+	return nil
+}
+
+func (c *GCSClient) FPutObjectWithOptions(ctx context.Context, fileName string) error {
+	file, err := os.Open(c.getFilePath(fileName))
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	return c.PutObjectWithOptions(ctx, fileName, file, 0)
+}
+
+func (c *GCSClient) GetObjectWithOptions(ctx context.Context, fileName string) (io.ReadCloser, error) {
+	query := url.Values{}
+	query.Set("alt", "media")
+
+	resp, err := c.do(ctx, http.MethodGet, c.objectURL(c.PrefixedFileName(fileName), query), nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Body, nil
+}
+
+func (c *GCSClient) FGetObjectWithOptions(ctx context.Context, fileName string) error {
+	rc, err := c.GetObjectWithOptions(ctx, fileName)
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+
+	filePath := c.getFilePath(fileName)
+	if err := os.MkdirAll(filepath.Dir(filePath), os.ModePerm); err != nil {
+		return err
+	}
+
+	file, err := os.Create(filePath)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	_, err = io.Copy(file, rc)
+	return err
+}
+
+func (c *GCSClient) RemoveWithOptions(ctx context.Context, fileName string) error {
+	resp, err := c.do(ctx, http.MethodDelete, c.objectURL(c.PrefixedFileName(fileName), nil), nil, nil)
+	if err != nil {
+		return err
+	}
+	return resp.Body.Close()
+}
+
+func (c *GCSClient) Exists(ctx context.Context, fileName string) (bool, error) {
+	resp, err := c.do(ctx, http.MethodGet, c.objectURL(c.PrefixedFileName(fileName), nil), nil, nil)
+	if err != nil {
+		if c.IsNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, resp.Body.Close()
+}
+
+func (c *GCSClient) Size(ctx context.Context, fileName string) (int64, error) {
+	query := url.Values{}
+	query.Set("fields", "size")
+	resp, err := c.do(ctx, http.MethodGet, c.objectURL(c.PrefixedFileName(fileName), query), nil, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	var object struct {
+		Size int64 `json:"size,string"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&object); err != nil {
+		return 0, fmt.Errorf("error decoding object metadata: %v", err)
+	}
+	return object.Size, nil
+}
+
+// IsLocked determines whether the object is protected by a hold or by an unexpired retention.
+func (c *GCSClient) IsLocked(ctx context.Context, fileName string) (bool, error) {
+	query := url.Values{}
+	query.Set("fields", "temporaryHold,eventBasedHold,retentionExpirationTime,retention")
+	resp, err := c.do(ctx, http.MethodGet, c.objectURL(c.PrefixedFileName(fileName), query), nil, nil)
+	if err != nil {
+		if c.IsNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	defer resp.Body.Close()
+
+	var object struct {
+		TemporaryHold           bool       `json:"temporaryHold"`
+		EventBasedHold          bool       `json:"eventBasedHold"`
+		RetentionExpirationTime *time.Time `json:"retentionExpirationTime"`
+		Retention               *struct {
+			RetainUntilTime *time.Time `json:"retainUntilTime"`
+		} `json:"retention"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&object); err != nil {
+		return false, fmt.Errorf("error decoding object metadata: %v", err)
+	}
+	if object.TemporaryHold || object.EventBasedHold {
+		return true, nil
+	}
+	now := time.Now()
+	if object.RetentionExpirationTime != nil && object.RetentionExpirationTime.After(now) {
+		return true, nil
+	}
+	return object.Retention != nil && object.Retention.RetainUntilTime != nil && object.Retention.RetainUntilTime.After(now), nil
+}
+
+func (c *GCSClient) PrefixedFileName(fileName string) string {
+	if c.Opts.AllowNestedPrefixes {
+		return c.GetPrefix() + fileName
+	}
+	return c.GetPrefix() + filepath.Base(fileName)
+}
+
+// UnprefixedFilename trims the prefix from the full object name. Objects are listed recursively,
+// so the ones under nested prefixes keep their relative path and are not mistaken for the ones at the prefix root.
+func (c *GCSClient) UnprefixedFilename(fileName string) string {
+	return strings.TrimPrefix(fileName, c.GetPrefix())
+}
+
+func (c *GCSClient) GetPrefix() string {
+	if c.Opts.Prefix == "" || c.Opts.Prefix == "/" {
+		return "" // object store doesn't use slash for root path
+	}
+	if !strings.HasSuffix(c.Opts.Prefix, "/") {
+		return c.Opts.Prefix + "/" // ending slash is required for avoiding matching like "foo/" and "foobar/" with prefix "foo"
+	}
+	return c.Opts.Prefix
+}
+
+func (c *GCSClient) ListObjectsWithOptions(ctx context.Context) ([]string, error) {
+	var items []string
+	pageToken := ""
+
+	for {
+		query := url.Values{}
+		query.Set("prefix", c.GetPrefix())
+		query.Set("fields", "items(name),nextPageToken")
+		if pageToken != "" {
+			query.Set("pageToken", pageToken)
+		}
+
+		resp, err := c.do(ctx, http.MethodGet, c.bucketURL("/o", query), nil, nil)
+		if err != nil {
+			return nil, err
+		}
+		var list struct {
+			Items []struct {
+				Name string `json:"name"`
+			} `json:"items"`
+			NextPageToken string `json:"nextPageToken"`
+		}
+		err = json.NewDecoder(resp.Body).Decode(&list)
+		resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("error decoding object list: %v", err)
+		}
+
+		for _, item := range list.Items {
+			items = append(items, item.Name)
+		}
+		if list.NextPageToken == "" {
+			return items, nil
+		}
+		pageToken = list.NextPageToken
+	}
+}
+
+// IsAuthenticated will get the bucket metadata to validate authentication
+func (c *GCSClient) IsAuthenticated(ctx context.Context) bool {
+	resp, err := c.do(ctx, http.MethodGet, c.bucketURL("", nil), nil, nil)
+	if err != nil {
+		code := getStatusCodeFromErr(err)
+		return code != http.StatusUnauthorized && code != http.StatusForbidden
+	}
+	resp.Body.Close()
+	return true
+}
+
+func (c *GCSClient) IsNotFound(err error) bool {
+	return getStatusCodeFromErr(err) == http.StatusNotFound
+}
+
+func (c *GCSClient) createUploadSession(ctx context.Context, objectName string) (string, error) {
+	body, err := json.Marshal(struct {
+		Name     string            `json:"name"`
+		Metadata map[string]string `json:"metadata,omitempty"`
+	}{
+		Name:     objectName,
+		Metadata: c.Opts.Metadata,
+	})
+	if err != nil {
+		return "", fmt.Errorf("error marshaling object metadata: %v", err)
+	}
+
+	query := url.Values{}
+	query.Set("uploadType", "resumable")
+	query.Set("name", objectName)
+	uploadURL := fmt.Sprintf("%s/upload/storage/v1/b/%s/o?%s", c.Opts.Endpoint, url.PathEscape(c.Bucket), query.Encode())
+
+	resp, err := c.do(ctx, http.MethodPost, uploadURL, bytes.NewReader(body), http.Header{
+		"Content-Type": []string{"application/json; charset=UTF-8"},
+	})
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	sessionURL := resp.Header.Get("Location")
+	if sessionURL == "" {
+		return "", errors.New("upload session URL not returned")
+	}
+	return sessionURL, nil
+}
+
+func (c *GCSClient) uploadChunk(ctx context.Context, sessionURL string, chunk []byte, offset int64, isLast bool) error {
+	var contentRange string
+	switch {
+	case isLast && len(chunk) == 0:
+		contentRange = fmt.Sprintf("bytes */%d", offset)
+	case isLast:
+		contentRange = fmt.Sprintf("bytes %d-%d/%d", offset, offset+int64(len(chunk))-1, offset+int64(len(chunk)))
+	default:
+		contentRange = fmt.Sprintf("bytes %d-%d/*", offset, offset+int64(len(chunk))-1)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, sessionURL, bytes.NewReader(chunk))
+	if err != nil {
+		return err
+	}
+	req.ContentLength = int64(len(chunk))
+	req.Header.Set("Content-Range", contentRange)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	// 308 Resume Incomplete is returned until the last chunk is uploaded.
+	if resp.StatusCode == http.StatusPermanentRedirect && !isLast {
+		return nil
+	}
+	if isLast && (resp.StatusCode == http.StatusOK || resp.StatusCode == http.StatusCreated) {
+		return nil
+	}
+	return newErrorFromResponse(resp)
+}
+
+// queryUploadOffset returns the number of bytes persisted by an upload session, and whether the upload has been completed.
+func (c *GCSClient) queryUploadOffset(ctx context.Context, sessionURL string) (int64, bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, sessionURL, nil)
+	if err != nil {
+		return 0, false, err
+	}
+	req.ContentLength = 0
+	req.Header.Set("Content-Range", "bytes */*")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return 0, false, err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK, http.StatusCreated:
+		return 0, true, nil
+	case http.StatusPermanentRedirect:
+		// Range: bytes=0-<last persisted byte>. It is not returned when no bytes have been persisted.
+		byteRange := resp.Header.Get("Range")
+		if byteRange == "" {
+			return 0, false, nil
+		}
+		_, end, ok := strings.Cut(strings.TrimPrefix(byteRange, "bytes="), "-")
+		if !ok {
+			return 0, false, fmt.Errorf("invalid Range header: %s", byteRange)
+		}
+		lastByte, err := strconv.ParseInt(end, 10, 64)
+		if err != nil {
+			return 0, false, fmt.Errorf("invalid Range header %s: %v", byteRange, err)
+		}
+		return lastByte + 1, false, nil
+	default:
+		return 0, false, newErrorFromResponse(resp)
+	}
+}
+
+func (c *GCSClient) do(ctx context.Context, method, reqURL string, body io.Reader, header http.Header) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, reqURL, body)
+	if err != nil {
+		return nil, err
+	}
+	for k, v := range header {
+		req.Header[k] = v
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		defer resp.Body.Close()
+		return nil, newErrorFromResponse(resp)
+	}
+	return resp, nil
+}
+
+func (c *GCSClient) bucketURL(path string, query url.Values) string {
+	u := fmt.Sprintf("%s/storage/v1/b/%s%s", c.Opts.Endpoint, url.PathEscape(c.Bucket), path)
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+	return u
+}
+
+func (c *GCSClient) objectURL(objectName string, query url.Values) string {
+	return c.bucketURL("/o/"+url.PathEscape(objectName), query)
+}
+
+func (c *GCSClient) getFilePath(fileName string) string {
+	if filepath.IsAbs(fileName) {
+		return fileName
+	}
+	return filepath.Join(c.BasePath, fileName)
+}
+
+// ===============
+func newErrorFromResponse(resp *http.Response) error {
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 64*1024))
+	var errResp struct {
+		Error struct {
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+	message := strings.TrimSpace(string(body))
+	if err := json.Unmarshal(body, &errResp); err == nil && errResp.Error.Message != "" {
+		message = errResp.Error.Message
+	}
+	if message == "" {
+		message = http.StatusText(resp.StatusCode)
+	}
+	return &Error{
+		StatusCode: resp.StatusCode,
+		Message:    message,
+	}
+}
+
+func getStatusCodeFromErr(err error) int {
+	if err == nil {
+		return 0
+	}
+
+	var gcsErr *Error
+	if errors.As(err, &gcsErr) {
+		return gcsErr.StatusCode
+	}
+
+	return 0
+}
+
+func getHTTPClient(opts *GCSOpts) (*http.Client, error) {
+	if opts.WithoutAuthentication {
+		return &http.Client{
+			Transport: opts.ProgressTracker.Transport(opts.RateLimiter.Transport(http.DefaultTransport)),
+		}, nil
+	}
+
+	var tokenSource oauth2.TokenSource
+	if len(opts.ServiceAccountKey) > 0 {
+		ts, err := serviceAccountTokenSource(opts.ServiceAccountKey)
+		if err != nil {
+			return nil, err
+		}
+		tokenSource = ts
+	} else {
+		tokenSource = oauth2.ReuseTokenSource(nil, &metadataTokenSource{
+			client: &http.Client{
+				Timeout: 10 * time.Second,
+			},
+		})
+	}
+
+	return &http.Client{
+		Transport: &oauth2.Transport{
+			Source: tokenSource,
+			Base:   opts.ProgressTracker.Transport(opts.RateLimiter.Transport(http.DefaultTransport)),
+		},
+	}, nil
+}
+
+// serviceAccountTokenSource returns a TokenSource that exchanges a JWT signed with the service account key for access tokens.
+func serviceAccountTokenSource(key []byte) (oauth2.TokenSource, error) {
+	var serviceAccountKey struct {
+		Type         string `json:"type"`
+		ClientEmail  string `json:"client_email"`
+		PrivateKey   string `json:"private_key"`
+		PrivateKeyID string `json:"private_key_id"`
+		TokenURI     string `json:"token_uri"`
+	}
+	if err := json.Unmarshal(key, &serviceAccountKey); err != nil {
+		return nil, fmt.Errorf("error parsing service account key: %v", err)
+	}
+	if serviceAccountKey.Type != "service_account" {
+		return nil, fmt.Errorf("unsupported credentials type \"%s\", only \"service_account\" is supported", serviceAccountKey.Type)
+	}
+	if serviceAccountKey.ClientEmail == "" || serviceAccountKey.PrivateKey == "" {
+		return nil, errors.New("service account key must contain 'client_email' and 'private_key'")
+	}
+	tokenURL := serviceAccountKey.TokenURI
+	if tokenURL == "" {
+		tokenURL = defaultTokenURL
+	}
+
+	config := &jwt.Config{
+		Email:        serviceAccountKey.ClientEmail,
+		PrivateKey:   []byte(serviceAccountKey.PrivateKey),
+		PrivateKeyID: serviceAccountKey.PrivateKeyID,
+		Scopes:       []string{storageScope},
+		TokenURL:     tokenURL,
+	}
+	return config.TokenSource(context.Background()), nil
+}
+
+// metadataTokenSource obtains access tokens from the GKE metadata server, which is how workload identity is exposed to Pods.
+type metadataTokenSource struct {
+	client *http.Client
+}
+
+func (m *metadataTokenSource) Token() (*oauth2.Token, error) {
+	metadataURL := defaultMetadataURL
+	if host := os.Getenv(metadataHostEnv); host != "" {
+		metadataURL = "http://" + host
+	}
+
+	req, err := http.NewRequest(http.MethodGet, metadataURL+metadataTokenPath, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Metadata-Flavor", "Google")
+
+	resp, err := m.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error getting token from metadata server: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("error getting token from metadata server: %v", newErrorFromResponse(resp))
+	}
+
+	var tokenResp struct {
+		AccessToken string `json:"access_token"`
+		ExpiresIn   int64  `json:"expires_in"`
+		TokenType   string `json:"token_type"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&tokenResp); err != nil {
+		return nil, fmt.Errorf("error decoding metadata server token: %v", err)
+	}
+	return &oauth2.Token{
+		AccessToken: tokenResp.AccessToken,
+		TokenType:   tokenResp.TokenType,
+		Expiry:      time.Now().Add(time.Duration(tokenResp.ExpiresIn) * time.Second),
+	}, nil
+}
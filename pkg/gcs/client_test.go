@@ -0,0 +1,648 @@
+package gcs
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/mariadb-operator/mariadb-operator/v26/pkg/interfaces"
+)
+
+func TestPrefixedFile(t *testing.T) {
+	tests := []struct {
+		name         string
+		opts         GCSOpts
+		fileName     string
+		wantFileName string
+	}{
+		{
+			name:         "no prefix",
+			opts:         GCSOpts{},
+			fileName:     "backup.2023-12-18T16:14:00Z.sql",
+			wantFileName: "backup.2023-12-18T16:14:00Z.sql",
+		},
+		{
+			name:         "no prefix with file path",
+			opts:         GCSOpts{},
+			fileName:     "backup/backup.2023-12-18T16:14:00Z.sql",
+			wantFileName: "backup.2023-12-18T16:14:00Z.sql",
+		},
+		{
+			name: "prefix",
+			opts: GCSOpts{
+				Prefix: "mariadb",
+			},
+			fileName:     "backup.2023-12-18T16:14:00Z.sql",
+			wantFileName: "mariadb/backup.2023-12-18T16:14:00Z.sql",
+		},
+		{
+			name: "prefix with trailing slash and file path",
+			opts: GCSOpts{
+				Prefix: "mariadb/",
+			},
+			fileName:     "backup/backup.2023-12-18T16:14:00Z.sql",
+			wantFileName: "mariadb/backup.2023-12-18T16:14:00Z.sql",
+		},
+		{
+			name: "nested prefixes allowed",
+			opts: GCSOpts{
+				Prefix:              "binlogs",
+				AllowNestedPrefixes: true,
+			},
+			fileName:     "server-0/mariadb-bin.000001",
+			wantFileName: "binlogs/server-0/mariadb-bin.000001",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &GCSClient{Opts: &tt.opts}
+			if fileName := client.PrefixedFileName(tt.fileName); fileName != tt.wantFileName {
+				t.Errorf("unexpected file name, got: %s, want: %s", fileName, tt.wantFileName)
+			}
+		})
+	}
+}
+
+func TestUnprefixedFile(t *testing.T) {
+	tests := []struct {
+		name         string
+		opts         GCSOpts
+		fileName     string
+		wantFileName string
+	}{
+		{
+			name:         "no prefix",
+			opts:         GCSOpts{},
+			fileName:     "backup.2023-12-18T16:14:00Z.sql",
+			wantFileName: "backup.2023-12-18T16:14:00Z.sql",
+		},
+		{
+			name: "prefix",
+			opts: GCSOpts{
+				Prefix: "mariadb",
+			},
+			fileName:     "mariadb/backup.2023-12-18T16:14:00Z.sql",
+			wantFileName: "backup.2023-12-18T16:14:00Z.sql",
+		},
+		{
+			name: "nested prefix",
+			opts: GCSOpts{
+				Prefix: "backups/production/mariadb",
+			},
+			fileName:     "backups/production/mariadb/backup.2023-12-18T16:14:00Z.sql",
+			wantFileName: "backup.2023-12-18T16:14:00Z.sql",
+		},
+		{
+			name: "object under nested prefix",
+			opts: GCSOpts{
+				Prefix: "mariadb",
+			},
+			fileName:     "mariadb/binlogs/server-0/mariadb-bin.000001",
+			wantFileName: "binlogs/server-0/mariadb-bin.000001",
+		},
+		{
+			name: "already unprefixed",
+			opts: GCSOpts{
+				Prefix: "mariadb",
+			},
+			fileName:     "backup.2023-12-18T16:14:00Z.sql",
+			wantFileName: "backup.2023-12-18T16:14:00Z.sql",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &GCSClient{Opts: &tt.opts}
+			if fileName := client.UnprefixedFilename(tt.fileName); fileName != tt.wantFileName {
+				t.Errorf("unexpected file name, got: %s, want: %s", fileName, tt.wantFileName)
+			}
+		})
+	}
+}
+
+func TestClientObjects(t *testing.T) {
+	server := newFakeServer(t, "backups")
+	client, err := NewGCSClient(
+		t.TempDir(),
+		"backups",
+		WithEndpoint(server.URL()),
+		WithoutAuthentication(),
+		WithPrefix("mariadb"),
+		WithMetadata(map[string]string{"encryption_key_id": "test"}),
+	)
+	if err != nil {
+		t.Fatalf("unexpected error creating client: %v", err)
+	}
+	ctx := context.Background()
+
+	if !client.IsAuthenticated(ctx) {
+		t.Fatal("expected client to be authenticated")
+	}
+
+	exists, err := client.Exists(ctx, "backup.2023-12-18T16:14:00Z.sql")
+	if err != nil {
+		t.Fatalf("unexpected error checking object: %v", err)
+	}
+	if exists {
+		t.Fatal("expected object not to exist")
+	}
+	if _, err := client.GetObjectWithOptions(ctx, "backup.2023-12-18T16:14:00Z.sql"); !client.IsNotFound(err) {
+		t.Fatalf("expected not found error, got: %v", err)
+	}
+
+	sizes := []int{0, 1024, uploadChunkSize, uploadChunkSize + 42}
+	for _, size := range sizes {
+		fileName := fmt.Sprintf("backup.2023-12-18T16:14:%02dZ.sql", size%60)
+		content := make([]byte, size)
+		if _, err := rand.Read(content); err != nil {
+			t.Fatalf("unexpected error generating content: %v", err)
+		}
+
+		if err := client.PutObjectWithOptions(ctx, fileName, bytes.NewReader(content), int64(size)); err != nil {
+			t.Fatalf("unexpected error putting object of size %d: %v", size, err)
+		}
+		exists, err := client.Exists(ctx, fileName)
+		if err != nil {
+			t.Fatalf("unexpected error checking object: %v", err)
+		}
+		if !exists {
+			t.Fatalf("expected object of size %d to exist", size)
+		}
+		gotSize, err := client.Size(ctx, fileName)
+		if err != nil {
+			t.Fatalf("unexpected error getting object size: %v", err)
+		}
+		if gotSize != int64(size) {
+			t.Fatalf("unexpected object size, got: %d, want: %d", gotSize, size)
+		}
+		locked, err := client.IsLocked(ctx, fileName)
+		if err != nil {
+			t.Fatalf("unexpected error checking object lock: %v", err)
+		}
+		if locked {
+			t.Fatalf("expected object of size %d not to be locked", size)
+		}
+
+		reader, err := client.GetObjectWithOptions(ctx, fileName)
+		if err != nil {
+			t.Fatalf("unexpected error getting object: %v", err)
+		}
+		got, err := io.ReadAll(reader)
+		reader.Close()
+		if err != nil {
+			t.Fatalf("unexpected error reading object: %v", err)
+		}
+		if !bytes.Equal(got, content) {
+			t.Fatalf("unexpected content for object of size %d, got %d bytes", size, len(got))
+		}
+		if metadata := server.Metadata("mariadb/" + fileName); metadata["encryption_key_id"] != "test" {
+			t.Fatalf("expected object metadata to be set, got: %v", metadata)
+		}
+	}
+
+	objects, err := client.ListObjectsWithOptions(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error listing objects: %v", err)
+	}
+	if len(objects) != len(sizes) {
+		t.Fatalf("unexpected number of objects, got: %d, want: %d", len(objects), len(sizes))
+	}
+	for _, object := range objects {
+		if !strings.HasPrefix(object, "mariadb/") {
+			t.Errorf("expected object %s to be prefixed", object)
+		}
+	}
+
+	if err := client.RemoveWithOptions(ctx, "backup.2023-12-18T16:14:00Z.sql"); err != nil {
+		t.Fatalf("unexpected error removing object: %v", err)
+	}
+	objects, err = client.ListObjectsWithOptions(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error listing objects: %v", err)
+	}
+	if len(objects) != len(sizes)-1 {
+		t.Fatalf("unexpected number of objects after removal, got: %d, want: %d", len(objects), len(sizes)-1)
+	}
+}
+
+func TestClientStream(t *testing.T) {
+	server := newFakeServer(t, "backups")
+	server.failChunks = 2
+	client, err := NewGCSClient(t.TempDir(), "backups", WithEndpoint(server.URL()), WithoutAuthentication())
+	if err != nil {
+		t.Fatalf("unexpected error creating client: %v", err)
+	}
+	ctx := context.Background()
+	fileName := "backup.2023-12-18T16:14:00Z.xb"
+	content := make([]byte, 3*uploadChunkMultiple+42)
+	if _, err := rand.Read(content); err != nil {
+		t.Fatalf("unexpected error generating content: %v", err)
+	}
+
+	// The part size is rounded up to a multiple of 256KiB.
+	if err := client.PutObjectStreamWithOptions(ctx, fileName, bytes.NewReader(content), interfaces.StreamOpts{
+		PartSize:       uploadChunkMultiple + 1,
+		MaxPartRetries: 2,
+	}); err != nil {
+		t.Fatalf("unexpected error streaming object: %v", err)
+	}
+
+	reader, err := client.GetObjectWithOptions(ctx, fileName)
+	if err != nil {
+		t.Fatalf("unexpected error getting object: %v", err)
+	}
+	defer reader.Close()
+	got, err := io.ReadAll(reader)
+	if err != nil {
+		t.Fatalf("unexpected error reading object: %v", err)
+	}
+	if !bytes.Equal(got, content) {
+		t.Fatalf("unexpected content, got %d bytes, want %d bytes", len(got), len(content))
+	}
+}
+
+func TestClientFiles(t *testing.T) {
+	server := newFakeServer(t, "backups")
+	basePath := t.TempDir()
+	client, err := NewGCSClient(basePath, "backups", WithEndpoint(server.URL()), WithoutAuthentication())
+	if err != nil {
+		t.Fatalf("unexpected error creating client: %v", err)
+	}
+	ctx := context.Background()
+	fileName := "backup.2023-12-18T16:14:00Z.sql"
+	content := []byte("Lorem ipsum dolor sit amet, consectetur adipiscing elit.")
+
+	if err := os.WriteFile(filepath.Join(basePath, fileName), content, 0644); err != nil {
+		t.Fatalf("unexpected error writing file: %v", err)
+	}
+	if err := client.FPutObjectWithOptions(ctx, fileName); err != nil {
+		t.Fatalf("unexpected error putting file: %v", err)
+	}
+	if err := os.Remove(filepath.Join(basePath, fileName)); err != nil {
+		t.Fatalf("unexpected error removing file: %v", err)
+	}
+	if err := client.FGetObjectWithOptions(ctx, fileName); err != nil {
+		t.Fatalf("unexpected error getting file: %v", err)
+	}
+	got, err := os.ReadFile(filepath.Join(basePath, fileName))
+	if err != nil {
+		t.Fatalf("unexpected error reading file: %v", err)
+	}
+	if !bytes.Equal(got, content) {
+		t.Fatalf("unexpected file content, got: %s, want: %s", got, content)
+	}
+}
+
+func TestClientAuthentication(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("workload identity", func(t *testing.T) {
+		server := newFakeServer(t, "backups")
+		server.token = "workload-identity-token"
+		t.Setenv(metadataHostEnv, strings.TrimPrefix(server.URL(), "http://"))
+
+		client, err := NewGCSClient("", "backups", WithEndpoint(server.URL()))
+		if err != nil {
+			t.Fatalf("unexpected error creating client: %v", err)
+		}
+		if !client.IsAuthenticated(ctx) {
+			t.Fatal("expected client to be authenticated")
+		}
+	})
+
+	t.Run("service account key", func(t *testing.T) {
+		server := newFakeServer(t, "backups")
+		server.token = "service-account-token"
+
+		client, err := NewGCSClient(
+			"",
+			"backups",
+			WithEndpoint(server.URL()),
+			WithServiceAccountKey(newTestServiceAccountKey(t, server.URL()+"/token")),
+		)
+		if err != nil {
+			t.Fatalf("unexpected error creating client: %v", err)
+		}
+		if !client.IsAuthenticated(ctx) {
+			t.Fatal("expected client to be authenticated")
+		}
+	})
+
+	t.Run("unauthenticated", func(t *testing.T) {
+		server := newFakeServer(t, "backups")
+		server.token = "token"
+
+		client, err := NewGCSClient("", "backups", WithEndpoint(server.URL()), WithoutAuthentication())
+		if err != nil {
+			t.Fatalf("unexpected error creating client: %v", err)
+		}
+		if client.IsAuthenticated(ctx) {
+			t.Fatal("expected client not to be authenticated")
+		}
+	})
+
+	t.Run("invalid service account key", func(t *testing.T) {
+		if _, err := NewGCSClient("", "backups", WithServiceAccountKey([]byte(`{"type":"authorized_user"}`))); err == nil {
+			t.Fatal("expected error creating client")
+		}
+	})
+
+	t.Run("emulator", func(t *testing.T) {
+		server := newFakeServer(t, "backups")
+		t.Setenv(EmulatorHostEnv, server.URL())
+
+		client, err := NewGCSClient("", "backups")
+		if err != nil {
+			t.Fatalf("unexpected error creating client: %v", err)
+		}
+		if client.Opts.Endpoint != server.URL() {
+			t.Fatalf("unexpected endpoint, got: %s, want: %s", client.Opts.Endpoint, server.URL())
+		}
+		if !client.IsAuthenticated(ctx) {
+			t.Fatal("expected client to be authenticated")
+		}
+	})
+}
+
+// fakeServer implements the subset of the GCS JSON API used by the client.
+type fakeServer struct {
+	t      *testing.T
+	server *httptest.Server
+	bucket string
+	// token is the bearer token required by the server. Authentication is disabled when empty.
+	token string
+	// failChunks is the number of chunks that fail after persisting their first 256KiB, to exercise resumable uploads.
+	failChunks int
+
+	mu       sync.Mutex
+	objects  map[string][]byte
+	metadata map[string]map[string]string
+	uploads  map[string]*fakeUpload
+}
+
+type fakeUpload struct {
+	name     string
+	metadata map[string]string
+	data     []byte
+}
+
+func newFakeServer(t *testing.T, bucket string) *fakeServer {
+	f := &fakeServer{
+		t:        t,
+		bucket:   bucket,
+		objects:  make(map[string][]byte),
+		metadata: make(map[string]map[string]string),
+		uploads:  make(map[string]*fakeUpload),
+	}
+	f.server = httptest.NewServer(http.HandlerFunc(f.handle))
+	t.Cleanup(f.server.Close)
+	return f
+}
+
+func (f *fakeServer) URL() string {
+	return f.server.URL
+}
+
+func (f *fakeServer) Metadata(name string) map[string]string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.metadata[name]
+}
+
+func (f *fakeServer) handle(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	switch {
+	case r.URL.Path == metadataTokenPath:
+		if r.Header.Get("Metadata-Flavor") != "Google" {
+			f.writeError(w, http.StatusForbidden, "missing Metadata-Flavor header")
+			return
+		}
+		f.writeJSON(w, map[string]any{"access_token": f.token, "expires_in": 3600, "token_type": "Bearer"})
+		return
+	case r.URL.Path == "/token":
+		if err := r.ParseForm(); err != nil || r.Form.Get("assertion") == "" {
+			f.writeError(w, http.StatusBadRequest, "missing assertion")
+			return
+		}
+		f.writeJSON(w, map[string]any{"access_token": f.token, "expires_in": 3600, "token_type": "Bearer"})
+		return
+	}
+
+	if f.token != "" && r.Header.Get("Authorization") != "Bearer "+f.token {
+		f.writeError(w, http.StatusUnauthorized, "invalid credentials")
+		return
+	}
+
+	uploadPath := fmt.Sprintf("/upload/storage/v1/b/%s/o", f.bucket)
+	bucketPath := fmt.Sprintf("/storage/v1/b/%s", f.bucket)
+	objectsPath := bucketPath + "/o"
+
+	switch {
+	case r.URL.Path == uploadPath && r.Method == http.MethodPost:
+		f.createUpload(w, r)
+	case strings.HasPrefix(r.URL.Path, "/upload/session/") && r.Method == http.MethodPut:
+		f.uploadChunk(w, r, strings.TrimPrefix(r.URL.Path, "/upload/session/"))
+	case r.URL.Path == bucketPath && r.Method == http.MethodGet:
+		f.writeJSON(w, map[string]any{"name": f.bucket})
+	case r.URL.Path == objectsPath && r.Method == http.MethodGet:
+		f.listObjects(w, r)
+	case strings.HasPrefix(r.URL.Path, objectsPath+"/"):
+		f.handleObject(w, r, strings.TrimPrefix(r.URL.Path, objectsPath+"/"))
+	default:
+		f.writeError(w, http.StatusNotFound, "not found")
+	}
+}
+
+func (f *fakeServer) createUpload(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Query().Get("uploadType") != "resumable" {
+		f.writeError(w, http.StatusBadRequest, "unsupported upload type")
+		return
+	}
+	var body struct {
+		Name     string            `json:"name"`
+		Metadata map[string]string `json:"metadata"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		f.writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	id := strconv.Itoa(len(f.uploads))
+	f.uploads[id] = &fakeUpload{
+		name:     body.Name,
+		metadata: body.Metadata,
+	}
+	w.Header().Set("Location", f.server.URL+"/upload/session/"+id)
+	w.WriteHeader(http.StatusOK)
+}
+
+func (f *fakeServer) uploadChunk(w http.ResponseWriter, r *http.Request, id string) {
+	upload, ok := f.uploads[id]
+	if !ok {
+		f.writeError(w, http.StatusNotFound, "upload session not found")
+		return
+	}
+	data, err := io.ReadAll(r.Body)
+	if err != nil {
+		f.writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Content-Range: bytes <start>-<end>/<total|*> or bytes */<total>
+	contentRange := strings.TrimPrefix(r.Header.Get("Content-Range"), "bytes ")
+	byteRange, total, ok := strings.Cut(contentRange, "/")
+	if !ok {
+		f.writeError(w, http.StatusBadRequest, "invalid Content-Range")
+		return
+	}
+	if byteRange != "*" {
+		start, _, _ := strings.Cut(byteRange, "-")
+		if start != strconv.Itoa(len(upload.data)) {
+			f.writeError(w, http.StatusBadRequest, "unexpected chunk offset")
+			return
+		}
+		if total == "*" && len(data)%(256*1024) != 0 {
+			f.writeError(w, http.StatusBadRequest, "chunk size must be a multiple of 256KiB")
+			return
+		}
+		if f.failChunks > 0 {
+			f.failChunks--
+			upload.data = append(upload.data, data[:min(len(data), 256*1024)]...)
+			f.writeError(w, http.StatusServiceUnavailable, "backend error")
+			return
+		}
+	}
+	upload.data = append(upload.data, data...)
+
+	if total == "*" {
+		if len(upload.data) > 0 {
+			w.Header().Set("Range", fmt.Sprintf("bytes=0-%d", len(upload.data)-1))
+		}
+		w.WriteHeader(http.StatusPermanentRedirect)
+		return
+	}
+	if total != strconv.Itoa(len(upload.data)) {
+		f.writeError(w, http.StatusBadRequest, "unexpected object size")
+		return
+	}
+	f.objects[upload.name] = upload.data
+	f.metadata[upload.name] = upload.metadata
+	delete(f.uploads, id)
+	f.writeJSON(w, map[string]any{"name": upload.name})
+}
+
+func (f *fakeServer) listObjects(w http.ResponseWriter, r *http.Request) {
+	prefix := r.URL.Query().Get("prefix")
+	var names []string
+	for name := range f.objects {
+		if strings.HasPrefix(name, prefix) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	// Return one object per page to exercise pagination.
+	start := 0
+	if pageToken := r.URL.Query().Get("pageToken"); pageToken != "" {
+		start, _ = strconv.Atoi(pageToken)
+	}
+	resp := map[string]any{}
+	if start < len(names) {
+		resp["items"] = []map[string]string{{"name": names[start]}}
+	}
+	if start+1 < len(names) {
+		resp["nextPageToken"] = strconv.Itoa(start + 1)
+	}
+	f.writeJSON(w, resp)
+}
+
+func (f *fakeServer) handleObject(w http.ResponseWriter, r *http.Request, escapedName string) {
+	name, err := url.PathUnescape(escapedName)
+	if err != nil {
+		f.writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	data, ok := f.objects[name]
+	if !ok {
+		f.writeError(w, http.StatusNotFound, "No such object")
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		if r.URL.Query().Get("alt") == "media" {
+			w.WriteHeader(http.StatusOK)
+			if _, err := w.Write(data); err != nil {
+				f.t.Errorf("error writing object: %v", err)
+			}
+			return
+		}
+		f.writeJSON(w, map[string]any{"name": name, "size": strconv.Itoa(len(data))})
+	case http.MethodDelete:
+		delete(f.objects, name)
+		delete(f.metadata, name)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		f.writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+	}
+}
+
+func (f *fakeServer) writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		f.t.Errorf("error encoding response: %v", err)
+	}
+}
+
+func (f *fakeServer) writeError(w http.ResponseWriter, code int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	if err := json.NewEncoder(w).Encode(map[string]any{
+		"error": map[string]any{
+			"code":    code,
+			"message": message,
+		},
+	}); err != nil {
+		f.t.Errorf("error encoding error response: %v", err)
+	}
+}
+
+func newTestServiceAccountKey(t *testing.T, tokenURI string) []byte {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("unexpected error generating key: %v", err)
+	}
+	keyBytes, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatalf("unexpected error marshaling key: %v", err)
+	}
+	serviceAccountKey, err := json.Marshal(map[string]string{
+		"type":           "service_account",
+		"client_email":   "mariadb-operator@test.iam.gserviceaccount.com",
+		"private_key_id": "test",
+		"private_key":    string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyBytes})),
+		"token_uri":      tokenURI,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error marshaling service account key: %v", err)
+	}
+	return serviceAccountKey
+}
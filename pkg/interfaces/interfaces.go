@@ -54,13 +54,26 @@ type MariaDBObject interface {
 	IsReady() bool
 }
 
+// StreamOpts configures the upload of objects of unknown size, which are split in parts.
+type StreamOpts struct {
+	// PartSize is the size in bytes of each part. Parts are buffered in memory before being uploaded.
+	PartSize int64
+	// MaxPartRetries is the number of times that the upload of a part is retried before aborting the whole upload.
+	MaxPartRetries int
+}
+
 type BlobStorage interface {
 	PutObjectWithOptions(ctx context.Context, fileName string, reader io.Reader, size int64) error
+	PutObjectStreamWithOptions(ctx context.Context, fileName string, reader io.Reader, opts StreamOpts) error
 	FPutObjectWithOptions(ctx context.Context, fileName string) error
 	GetObjectWithOptions(ctx context.Context, fileName string) (io.ReadCloser, error)
 	FGetObjectWithOptions(ctx context.Context, fileName string) error
 	RemoveWithOptions(ctx context.Context, fileName string) error
 	Exists(ctx context.Context, fileName string) (bool, error)
+	// Size returns the size in bytes of a stored object.
+	Size(ctx context.Context, fileName string) (int64, error)
+	// IsLocked determines whether the object is protected against deletion, for example by a retention or a legal hold.
+	IsLocked(ctx context.Context, fileName string) (bool, error)
 	PrefixedFileName(fileName string) string
 	UnprefixedFilename(fileName string) string
 	GetPrefix() string
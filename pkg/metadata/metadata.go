@@ -12,6 +12,8 @@ var (
 	ReplicationAnnotation = "k8s.mariadb.com/replication"
 	GtidAnnotation        = "k8s.mariadb.com/gtid"
 	LastGtidAnnotation    = "k8s.mariadb.com/last-gtid"
+	OldestGtidAnnotation  = "k8s.mariadb.com/oldest-gtid"
+	OldestTimeAnnotation  = "k8s.mariadb.com/oldest-time"
 	GaleraAnnotation      = "k8s.mariadb.com/galera"
 	MariadbAnnotation     = "k8s.mariadb.com/mariadb"
 
@@ -27,5 +29,12 @@ var (
 
 	WebhookConfigAnnotation = "k8s.mariadb.com/webhook"
 
+	PreHooksAnnotation       = "k8s.mariadb.com/pre-hooks"
+	PostHooksAnnotation      = "k8s.mariadb.com/post-hooks"
+	HooksTargetPodAnnotation = "k8s.mariadb.com/hooks-target-pod"
+	HooksPendingValue        = "pending"
+	HooksSucceededValue      = "succeeded"
+	HooksFailedValue         = "failed"
+
 	MetaCtrlFieldPath = ".metadata.controller"
 )
@@ -0,0 +1,197 @@
+// Package metrics defines the custom Prometheus metrics exposed by the operator.
+// Metrics are registered in the controller-runtime registry, so they are served by the manager metrics endpoint.
+package metrics
+
+import (
+	"time"
+
+	mariadbv1alpha1 "github.com/mariadb-operator/mariadb-operator/v26/api/v1alpha1"
+	"github.com/prometheus/client_golang/prometheus"
+	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const (
+	namespace = "mariadb_operator"
+
+	backupVerificationSubsystem = "backup_verification"
+
+	// BackupVerificationResultSuccess is the result label value of successful verifications.
+	BackupVerificationResultSuccess = "success"
+	// BackupVerificationResultFailure is the result label value of failed verifications.
+	BackupVerificationResultFailure = "failure"
+
+	backupProgressSubsystem = "backup_progress"
+)
+
+var (
+	backupVerificationLabels = []string{"namespace", "name"}
+
+	// BackupVerificationsTotal counts the completed backup verifications by result.
+	BackupVerificationsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: backupVerificationSubsystem,
+			Name:      "total",
+			Help:      "Number of completed backup verifications by result.",
+		},
+		append(backupVerificationLabels, "result"),
+	)
+	// BackupVerificationLastSucceeded indicates whether the last backup verification succeeded.
+	BackupVerificationLastSucceeded = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: backupVerificationSubsystem,
+			Name:      "last_succeeded",
+			Help:      "Whether the last backup verification succeeded (1) or failed (0).",
+		},
+		backupVerificationLabels,
+	)
+	// BackupVerificationLastDurationSeconds is the duration of the last backup verification.
+	BackupVerificationLastDurationSeconds = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: backupVerificationSubsystem,
+			Name:      "last_duration_seconds",
+			Help:      "Duration of the last backup verification in seconds.",
+		},
+		backupVerificationLabels,
+	)
+	// BackupVerificationLastCompletionTimestamp is the completion time of the last backup verification.
+	BackupVerificationLastCompletionTimestamp = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: backupVerificationSubsystem,
+			Name:      "last_completion_timestamp_seconds",
+			Help:      "Unix time of the completion of the last backup verification.",
+		},
+		backupVerificationLabels,
+	)
+	// BackupVerificationLastSuccessTimestamp is the completion time of the last successful backup verification.
+	BackupVerificationLastSuccessTimestamp = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: backupVerificationSubsystem,
+			Name:      "last_success_timestamp_seconds",
+			Help:      "Unix time of the completion of the last successful backup verification.",
+		},
+		backupVerificationLabels,
+	)
+
+	backupProgressLabels = []string{"kind", "namespace", "name"}
+
+	// BackupProgressPhase indicates the current phase of a running backup or restoration.
+	BackupProgressPhase = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: backupProgressSubsystem,
+			Name:      "phase",
+			Help:      "Current phase of a running backup or restoration, labeled by phase.",
+		},
+		append(backupProgressLabels, "phase"),
+	)
+	// BackupProgressBytesProcessed is the number of bytes processed during the current phase.
+	BackupProgressBytesProcessed = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: backupProgressSubsystem,
+			Name:      "bytes_processed",
+			Help:      "Number of bytes processed during the current phase of a running backup or restoration.",
+		},
+		backupProgressLabels,
+	)
+	// BackupProgressTotalBytes is the number of bytes to be processed during the current phase, when known.
+	BackupProgressTotalBytes = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: backupProgressSubsystem,
+			Name:      "total_bytes",
+			Help:      "Number of bytes to be processed during the current phase of a running backup or restoration, when known.",
+		},
+		backupProgressLabels,
+	)
+	// BackupProgressBytesPerSecond is the throughput of the current phase.
+	BackupProgressBytesPerSecond = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: backupProgressSubsystem,
+			Name:      "bytes_per_second",
+			Help:      "Throughput in bytes per second of the current phase of a running backup or restoration.",
+		},
+		backupProgressLabels,
+	)
+	// BackupProgressLastUpdateTimestamp is the last time that the progress was updated.
+	BackupProgressLastUpdateTimestamp = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: backupProgressSubsystem,
+			Name:      "last_update_timestamp_seconds",
+			Help:      "Unix time of the last progress update of a running backup or restoration.",
+		},
+		backupProgressLabels,
+	)
+)
+
+func init() {
+	ctrlmetrics.Registry.MustRegister(
+		BackupVerificationsTotal,
+		BackupVerificationLastSucceeded,
+		BackupVerificationLastDurationSeconds,
+		BackupVerificationLastCompletionTimestamp,
+		BackupVerificationLastSuccessTimestamp,
+		BackupProgressPhase,
+		BackupProgressBytesProcessed,
+		BackupProgressTotalBytes,
+		BackupProgressBytesPerSecond,
+		BackupProgressLastUpdateTimestamp,
+	)
+}
+
+// RecordBackupVerification records the result of a completed backup verification.
+func RecordBackupVerification(ns, name string, succeeded bool, duration time.Duration, completionTime time.Time) {
+	result := BackupVerificationResultFailure
+	lastSucceeded := 0.0
+	if succeeded {
+		result = BackupVerificationResultSuccess
+		lastSucceeded = 1
+		BackupVerificationLastSuccessTimestamp.WithLabelValues(ns, name).Set(float64(completionTime.Unix()))
+	}
+	BackupVerificationsTotal.WithLabelValues(ns, name, result).Inc()
+	BackupVerificationLastSucceeded.WithLabelValues(ns, name).Set(lastSucceeded)
+	BackupVerificationLastDurationSeconds.WithLabelValues(ns, name).Set(duration.Seconds())
+	BackupVerificationLastCompletionTimestamp.WithLabelValues(ns, name).Set(float64(completionTime.Unix()))
+}
+
+// DeleteBackupVerification removes the metrics of a BackupVerification that no longer exists.
+func DeleteBackupVerification(ns, name string) {
+	labels := prometheus.Labels{"namespace": ns, "name": name}
+	BackupVerificationsTotal.DeletePartialMatch(labels)
+	BackupVerificationLastSucceeded.Delete(labels)
+	BackupVerificationLastDurationSeconds.Delete(labels)
+	BackupVerificationLastCompletionTimestamp.Delete(labels)
+	BackupVerificationLastSuccessTimestamp.Delete(labels)
+}
+
+// RecordBackupProgress records the progress of a running backup or restoration.
+func RecordBackupProgress(kind, ns, name string, progress *mariadbv1alpha1.BackupProgress) {
+	labels := prometheus.Labels{"kind": kind, "namespace": ns, "name": name}
+	BackupProgressPhase.DeletePartialMatch(labels)
+	BackupProgressPhase.WithLabelValues(kind, ns, name, string(progress.Phase)).Set(1)
+	BackupProgressBytesProcessed.WithLabelValues(kind, ns, name).Set(float64(progress.BytesProcessed))
+	if progress.TotalBytes != nil {
+		BackupProgressTotalBytes.WithLabelValues(kind, ns, name).Set(float64(*progress.TotalBytes))
+	} else {
+		BackupProgressTotalBytes.Delete(labels)
+	}
+	BackupProgressBytesPerSecond.WithLabelValues(kind, ns, name).Set(float64(progress.BytesPerSecond))
+	BackupProgressLastUpdateTimestamp.WithLabelValues(kind, ns, name).Set(float64(progress.LastUpdateTime.Unix()))
+}
+
+// DeleteBackupProgress removes the progress metrics of a backup or restoration that is no longer running.
+func DeleteBackupProgress(kind, ns, name string) {
+	labels := prometheus.Labels{"kind": kind, "namespace": ns, "name": name}
+	BackupProgressPhase.DeletePartialMatch(labels)
+	BackupProgressBytesProcessed.Delete(labels)
+	BackupProgressTotalBytes.Delete(labels)
+	BackupProgressBytesPerSecond.Delete(labels)
+	BackupProgressLastUpdateTimestamp.Delete(labels)
+}
@@ -0,0 +1,89 @@
+package metrics
+
+import (
+	"testing"
+	"time"
+
+	mariadbv1alpha1 "github.com/mariadb-operator/mariadb-operator/v26/api/v1alpha1"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+func TestRecordBackupVerification(t *testing.T) {
+	ns, name := "default", "verification"
+	completionTime := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	RecordBackupVerification(ns, name, true, 90*time.Second, completionTime)
+	RecordBackupVerification(ns, name, false, 30*time.Second, completionTime.Add(time.Hour))
+
+	if v := testutil.ToFloat64(BackupVerificationsTotal.WithLabelValues(ns, name, BackupVerificationResultSuccess)); v != 1 {
+		t.Errorf("unexpected successful verifications, expected: 1 got: %v", v)
+	}
+	if v := testutil.ToFloat64(BackupVerificationsTotal.WithLabelValues(ns, name, BackupVerificationResultFailure)); v != 1 {
+		t.Errorf("unexpected failed verifications, expected: 1 got: %v", v)
+	}
+	if v := testutil.ToFloat64(BackupVerificationLastSucceeded.WithLabelValues(ns, name)); v != 0 {
+		t.Errorf("unexpected last succeeded, expected: 0 got: %v", v)
+	}
+	if v := testutil.ToFloat64(BackupVerificationLastDurationSeconds.WithLabelValues(ns, name)); v != 30 {
+		t.Errorf("unexpected last duration, expected: 30 got: %v", v)
+	}
+	if v := testutil.ToFloat64(BackupVerificationLastSuccessTimestamp.WithLabelValues(ns, name)); v != float64(completionTime.Unix()) {
+		t.Errorf("unexpected last success timestamp, expected: %v got: %v", completionTime.Unix(), v)
+	}
+
+	DeleteBackupVerification(ns, name)
+	if n := testutil.CollectAndCount(BackupVerificationsTotal); n != 0 {
+		t.Errorf("unexpected verification series after deletion, expected: 0 got: %v", n)
+	}
+	if n := testutil.CollectAndCount(BackupVerificationLastSucceeded); n != 0 {
+		t.Errorf("unexpected last succeeded series after deletion, expected: 0 got: %v", n)
+	}
+}
+
+func TestRecordBackupProgress(t *testing.T) {
+	kind, ns, name := mariadbv1alpha1.PhysicalBackupKind, "default", "backup"
+	updateTime := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	RecordBackupProgress(kind, ns, name, &mariadbv1alpha1.BackupProgress{
+		Phase:          mariadbv1alpha1.BackupProgressPhaseCompress,
+		BytesProcessed: 512,
+		TotalBytes:     ptr.To(int64(1024)),
+		BytesPerSecond: 128,
+		LastUpdateTime: metav1.NewTime(updateTime),
+	})
+	RecordBackupProgress(kind, ns, name, &mariadbv1alpha1.BackupProgress{
+		Phase:          mariadbv1alpha1.BackupProgressPhaseUpload,
+		BytesProcessed: 256,
+		BytesPerSecond: 64,
+		LastUpdateTime: metav1.NewTime(updateTime.Add(time.Minute)),
+	})
+
+	if n := testutil.CollectAndCount(BackupProgressPhase); n != 1 {
+		t.Errorf("unexpected phase series, expected: 1 got: %v", n)
+	}
+	if v := testutil.ToFloat64(BackupProgressPhase.WithLabelValues(kind, ns, name, string(mariadbv1alpha1.BackupProgressPhaseUpload))); v != 1 {
+		t.Errorf("unexpected phase, expected: 1 got: %v", v)
+	}
+	if v := testutil.ToFloat64(BackupProgressBytesProcessed.WithLabelValues(kind, ns, name)); v != 256 {
+		t.Errorf("unexpected bytes processed, expected: 256 got: %v", v)
+	}
+	if n := testutil.CollectAndCount(BackupProgressTotalBytes); n != 0 {
+		t.Errorf("unexpected total bytes series, expected: 0 got: %v", n)
+	}
+	if v := testutil.ToFloat64(BackupProgressBytesPerSecond.WithLabelValues(kind, ns, name)); v != 64 {
+		t.Errorf("unexpected bytes per second, expected: 64 got: %v", v)
+	}
+	if v := testutil.ToFloat64(BackupProgressLastUpdateTimestamp.WithLabelValues(kind, ns, name)); v != float64(updateTime.Add(time.Minute).Unix()) {
+		t.Errorf("unexpected last update timestamp, expected: %v got: %v", updateTime.Add(time.Minute).Unix(), v)
+	}
+
+	DeleteBackupProgress(kind, ns, name)
+	if n := testutil.CollectAndCount(BackupProgressPhase); n != 0 {
+		t.Errorf("unexpected phase series after deletion, expected: 0 got: %v", n)
+	}
+	if n := testutil.CollectAndCount(BackupProgressBytesProcessed); n != 0 {
+		t.Errorf("unexpected bytes processed series after deletion, expected: 0 got: %v", n)
+	}
+}
@@ -1,7 +1,9 @@
 package minio
 
 import (
+	"bytes"
 	"context"
+	"crypto/md5"
 	"crypto/x509"
 	"encoding/base64"
 	"errors"
@@ -11,8 +13,13 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/mariadb-operator/mariadb-operator/v26/api/v1alpha1"
+	"github.com/mariadb-operator/mariadb-operator/v26/pkg/interfaces"
+	"github.com/mariadb-operator/mariadb-operator/v26/pkg/multipart"
+	"github.com/mariadb-operator/mariadb-operator/v26/pkg/progress"
+	"github.com/mariadb-operator/mariadb-operator/v26/pkg/ratelimit"
 	"github.com/mariadb-operator/mariadb-operator/v26/pkg/refresolver"
 	"github.com/minio/minio-go/v7"
 	"github.com/minio/minio-go/v7/pkg/credentials"
@@ -29,6 +36,10 @@ type MinioOpts struct {
 	Prefix              string
 	AllowNestedPrefixes bool
 	SSECCustomerKey     string
+	UserMetadata        map[string]string
+	RateLimiter         *ratelimit.Limiter
+	ProgressTracker     *progress.Tracker
+	ObjectLock          *v1alpha1.ObjectLock
 }
 
 func (o *MinioOpts) getCredentials() *credentials.Credentials {
@@ -94,6 +105,30 @@ func WithSSECCustomerKey(ssecCustomerKey string) MinioOpt {
 	}
 }
 
+func WithUserMetadata(userMetadata map[string]string) MinioOpt {
+	return func(m *MinioOpts) {
+		m.UserMetadata = userMetadata
+	}
+}
+
+func WithRateLimiter(limiter *ratelimit.Limiter) MinioOpt {
+	return func(m *MinioOpts) {
+		m.RateLimiter = limiter
+	}
+}
+
+func WithProgressTracker(tracker *progress.Tracker) MinioOpt {
+	return func(m *MinioOpts) {
+		m.ProgressTracker = tracker
+	}
+}
+
+func WithObjectLock(objectLock *v1alpha1.ObjectLock) MinioOpt {
+	return func(m *MinioOpts) {
+		m.ObjectLock = objectLock
+	}
+}
+
 type Client struct {
 	*minio.Client
 	MinioOpts
@@ -112,6 +147,7 @@ func NewMinioClientFromS3Config(
 	minioOpts := []MinioOpt{
 		WithRegion(s3.Region),
 		WithPrefix(s3.Prefix),
+		WithObjectLock(s3.ObjectLock),
 	}
 
 	if s3.AccessKeyIdSecretKeyRef != nil && s3.SecretAccessKeySecretKeyRef != nil {
@@ -198,6 +234,8 @@ func (c *Client) ListObjectsWithOptions(ctx context.Context) ([]string, error) {
 	var fileNames []string
 	for o := range c.ListObjects(ctx, c.bucket, minio.ListObjectsOptions{
 		Prefix: c.GetPrefix(),
+		// objects under nested prefixes, such as the binlogs of each server, are only listed recursively.
+		Recursive: c.AllowNestedPrefixes,
 	}) {
 		if o.Err != nil {
 			return nil, o.Err
@@ -218,6 +256,56 @@ func (c *Client) PutObjectWithOptions(ctx context.Context, fileName string, read
 	return err
 }
 
+// PutObjectStreamWithOptions uploads the given reader using a multipart upload, retrying each part independently.
+// The multipart upload is aborted if any of the parts cannot be uploaded, so no partial object is left behind.
+func (c *Client) PutObjectStreamWithOptions(ctx context.Context, fileName string, reader io.Reader,
+	opts interfaces.StreamOpts) error {
+	putOpts, err := c.putObjectOptions()
+	if err != nil {
+		return err
+	}
+	prefixedFilePath := c.PrefixedFileName(fileName)
+	core := minio.Core{Client: c.Client}
+
+	uploadID, err := core.NewMultipartUpload(ctx, c.bucket, prefixedFilePath, *putOpts)
+	if err != nil {
+		return fmt.Errorf("error creating multipart upload: %v", err)
+	}
+	var parts []minio.CompletePart
+	_, err = multipart.Upload(ctx, reader, opts, func(ctx context.Context, part multipart.Part) error {
+		partOpts := minio.PutObjectPartOptions{
+			SSE: putOpts.ServerSideEncryption,
+		}
+		// S3 requires the parts of locked objects to be uploaded along with their checksum.
+		if putOpts.SendContentMd5 {
+			sum := md5.Sum(part.Data)
+			partOpts.Md5Base64 = base64.StdEncoding.EncodeToString(sum[:])
+		}
+		// S3 requires at least one part, even if the object is empty.
+		objectPart, err := core.PutObjectPart(ctx, c.bucket, prefixedFilePath, uploadID, part.Number,
+			bytes.NewReader(part.Data), int64(len(part.Data)), partOpts)
+		if err != nil {
+			return err
+		}
+		parts = append(parts, minio.CompletePart{
+			PartNumber: objectPart.PartNumber,
+			ETag:       objectPart.ETag,
+		})
+		return nil
+	})
+	if err == nil {
+		_, err = core.CompleteMultipartUpload(ctx, c.bucket, prefixedFilePath, uploadID, parts, *putOpts)
+	}
+	if err != nil {
+		// Aborting must succeed even if the upload was cancelled.
+		if abortErr := core.AbortMultipartUpload(context.Background(), c.bucket, prefixedFilePath, uploadID); abortErr != nil {
+			return errors.Join(err, fmt.Errorf("error aborting multipart upload: %v", abortErr))
+		}
+		return err
+	}
+	return nil
+}
+
 func (c *Client) FPutObjectWithOptions(ctx context.Context, fileName string) error {
 	putOpts, err := c.putObjectOptions()
 	if err != nil {
@@ -256,6 +344,22 @@ func (c *Client) RemoveWithOptions(ctx context.Context, fileName string) error {
 	return c.RemoveObject(ctx, c.bucket, prefixedFilePath, minio.RemoveObjectOptions{})
 }
 
+// IsLocked determines whether the object is protected by an unexpired Object Lock retention or by a legal hold.
+func (c *Client) IsLocked(ctx context.Context, fileName string) (bool, error) {
+	statOpts, err := c.getObjectOptions()
+	if err != nil {
+		return false, err
+	}
+	info, err := c.StatObject(ctx, c.bucket, c.PrefixedFileName(fileName), *statOpts)
+	if err != nil {
+		if c.IsNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return isLocked(info.Metadata, time.Now()), nil
+}
+
 func (c *Client) IsNotFound(err error) bool {
 	resp := minio.ToErrorResponse(err)
 	if resp.StatusCode == http.StatusNotFound {
@@ -286,6 +390,19 @@ func (c *Client) Exists(ctx context.Context, fileName string) (bool, error) {
 	return true, nil
 }
 
+func (c *Client) Size(ctx context.Context, fileName string) (int64, error) {
+	statOpts, err := c.getObjectOptions()
+	if err != nil {
+		return 0, err
+	}
+
+	info, err := c.StatObject(ctx, c.bucket, c.PrefixedFileName(fileName), *statOpts)
+	if err != nil {
+		return 0, err
+	}
+	return info.Size, nil
+}
+
 func (c *Client) PrefixedFileName(fileName string) string {
 	if c.AllowNestedPrefixes {
 		return c.GetPrefix() + fileName
@@ -317,7 +434,18 @@ func (c *Client) GetCredentials() *credentials.Credentials {
 }
 
 func (c *Client) putObjectOptions() (*minio.PutObjectOptions, error) {
-	putOpts := minio.PutObjectOptions{}
+	putOpts := minio.PutObjectOptions{
+		UserMetadata: c.UserMetadata,
+	}
+	if c.ObjectLock != nil {
+		putOpts.Mode = objectLockMode(c.ObjectLock.ModeOrDefault())
+		putOpts.RetainUntilDate = time.Now().Add(c.ObjectLock.RetainUntil.Duration)
+		if c.ObjectLock.LegalHold {
+			putOpts.LegalHold = minio.LegalHoldEnabled
+		}
+		// S3 requires the Content-MD5 header when uploading locked objects.
+		putOpts.SendContentMd5 = true
+	}
 	if sse, err := c.getSSEC(); err != nil {
 		return nil, fmt.Errorf("error creating SSE-C encryption: %v", err)
 	} else if sse != nil {
@@ -366,6 +494,30 @@ func (c *Client) getSSEC() (encrypt.ServerSide, error) {
 	return sse, nil
 }
 
+func objectLockMode(mode v1alpha1.ObjectLockMode) minio.RetentionMode {
+	if mode == v1alpha1.ObjectLockModeCompliance {
+		return minio.Compliance
+	}
+	return minio.Governance
+}
+
+// isLocked determines whether the Object Lock headers of an object prevent it from being deleted at the given time.
+// Objects with a retention date that cannot be parsed are considered locked, as deleting them is not safe.
+func isLocked(header http.Header, now time.Time) bool {
+	if minio.LegalHoldStatus(header.Get("X-Amz-Object-Lock-Legal-Hold")) == minio.LegalHoldEnabled {
+		return true
+	}
+	retainUntil := header.Get("X-Amz-Object-Lock-Retain-Until-Date")
+	if retainUntil == "" {
+		return false
+	}
+	retainUntilDate, err := time.Parse(time.RFC3339, retainUntil)
+	if err != nil {
+		return true
+	}
+	return retainUntilDate.After(now)
+}
+
 func getMinioOptions(opts MinioOpts) (*minio.Options, error) {
 	transport, err := getTransport(&opts)
 	if err != nil {
@@ -375,7 +527,7 @@ func getMinioOptions(opts MinioOpts) (*minio.Options, error) {
 		Creds:     opts.getCredentials(),
 		Region:    opts.Region,
 		Secure:    opts.TLS,
-		Transport: transport,
+		Transport: opts.ProgressTracker.Transport(opts.RateLimiter.Transport(transport)),
 	}, nil
 }
 
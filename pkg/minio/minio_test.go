@@ -2,7 +2,13 @@ package minio
 
 import (
 	"encoding/base64"
+	"net/http"
 	"testing"
+	"time"
+
+	"github.com/mariadb-operator/mariadb-operator/v26/api/v1alpha1"
+	"github.com/minio/minio-go/v7"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 func TestPrefixedFile(t *testing.T) {
@@ -349,3 +355,103 @@ func TestS3GetSSEC(t *testing.T) {
 		})
 	}
 }
+
+func TestPutObjectOptionsObjectLock(t *testing.T) {
+	client := &Client{
+		MinioOpts: MinioOpts{
+			ObjectLock: &v1alpha1.ObjectLock{
+				Mode:        v1alpha1.ObjectLockModeCompliance,
+				RetainUntil: metav1.Duration{Duration: 24 * time.Hour},
+				LegalHold:   true,
+			},
+		},
+	}
+	before := time.Now()
+	putOpts, err := client.putObjectOptions()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if putOpts.Mode != minio.Compliance {
+		t.Errorf("unexpected mode, expected: %v got: %v", minio.Compliance, putOpts.Mode)
+	}
+	if putOpts.RetainUntilDate.Before(before.Add(24 * time.Hour)) {
+		t.Errorf("unexpected retain until date: %v", putOpts.RetainUntilDate)
+	}
+	if putOpts.LegalHold != minio.LegalHoldEnabled {
+		t.Errorf("unexpected legal hold, expected: %v got: %v", minio.LegalHoldEnabled, putOpts.LegalHold)
+	}
+	if !putOpts.SendContentMd5 {
+		t.Error("expected Content-MD5 to be sent")
+	}
+
+	putOpts, err = (&Client{}).putObjectOptions()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if putOpts.Mode != "" || !putOpts.RetainUntilDate.IsZero() || putOpts.LegalHold != "" {
+		t.Errorf("expected object not to be locked, got: %v", putOpts)
+	}
+}
+
+func TestIsLocked(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name       string
+		header     http.Header
+		wantLocked bool
+	}{
+		{
+			name:       "no lock",
+			header:     http.Header{},
+			wantLocked: false,
+		},
+		{
+			name: "unexpired retention",
+			header: http.Header{
+				"X-Amz-Object-Lock-Mode":              []string{"GOVERNANCE"},
+				"X-Amz-Object-Lock-Retain-Until-Date": []string{now.Add(time.Hour).Format(time.RFC3339)},
+			},
+			wantLocked: true,
+		},
+		{
+			name: "expired retention",
+			header: http.Header{
+				"X-Amz-Object-Lock-Mode":              []string{"COMPLIANCE"},
+				"X-Amz-Object-Lock-Retain-Until-Date": []string{now.Add(-time.Hour).Format(time.RFC3339)},
+			},
+			wantLocked: false,
+		},
+		{
+			name: "invalid retention",
+			header: http.Header{
+				"X-Amz-Object-Lock-Retain-Until-Date": []string{"foo"},
+			},
+			wantLocked: true,
+		},
+		{
+			name: "legal hold",
+			header: http.Header{
+				"X-Amz-Object-Lock-Legal-Hold": []string{"ON"},
+			},
+			wantLocked: true,
+		},
+		{
+			name: "legal hold off and expired retention",
+			header: http.Header{
+				"X-Amz-Object-Lock-Legal-Hold":        []string{"OFF"},
+				"X-Amz-Object-Lock-Retain-Until-Date": []string{now.Add(-time.Hour).Format(time.RFC3339)},
+			},
+			wantLocked: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if locked := isLocked(tt.header, now); locked != tt.wantLocked {
+				t.Errorf("unexpected locked, expected: %v got: %v", tt.wantLocked, locked)
+			}
+		})
+	}
+}
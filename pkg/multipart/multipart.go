@@ -0,0 +1,122 @@
+package multipart
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/mariadb-operator/mariadb-operator/v26/pkg/interfaces"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/util/retry"
+)
+
+const (
+	// DefaultPartSize is the part size used when none is provided.
+	DefaultPartSize = 64 * 1024 * 1024
+	// DefaultMaxPartRetries is the number of retries used when none is provided.
+	DefaultMaxPartRetries = 10
+)
+
+// Part is a chunk of an object being uploaded.
+type Part struct {
+	// Number is the 1-based position of the part within the object.
+	Number int
+	// Offset is the position in bytes of the part within the object.
+	Offset int64
+	// Data is the content of the part. It is only valid until the upload function returns.
+	Data []byte
+	// Last indicates that this is the last part of the object. It is only empty when the object is empty.
+	Last bool
+}
+
+// UploadPartFn uploads a single part. It may be called multiple times for the same part when retrying.
+type UploadPartFn func(ctx context.Context, part Part) error
+
+// Upload splits the object read from r in parts and uploads them sequentially.
+// Each part is kept in memory until it has been uploaded, which allows retrying it independently,
+// so a transient error does not restart the whole upload. It returns the size of the object.
+func Upload(ctx context.Context, r io.Reader, opts interfaces.StreamOpts, uploadPart UploadPartFn) (int64, error) {
+	partSize := opts.PartSize
+	if partSize <= 0 {
+		partSize = DefaultPartSize
+	}
+	backoff := newBackoff(opts.MaxPartRetries)
+	isRetriable := func(err error) bool {
+		if ctx.Err() != nil {
+			return false
+		}
+		return err != nil
+	}
+
+	buf := make([]byte, partSize)
+	// The next part is read in advance to determine whether the current one is the last one.
+	next := make([]byte, partSize)
+	n, err := readPart(r, buf)
+	if err != nil {
+		return 0, err
+	}
+	var (
+		offset int64
+		number = 1
+	)
+	for {
+		nextN := 0
+		if n == len(buf) {
+			if nextN, err = readPart(r, next); err != nil {
+				return 0, err
+			}
+		}
+		part := Part{
+			Number: number,
+			Offset: offset,
+			Data:   buf[:n],
+			Last:   nextN == 0,
+		}
+		if err := retry.OnError(backoff, isRetriable, func() error {
+			return uploadPart(ctx, part)
+		}); err != nil {
+			return 0, fmt.Errorf("error uploading part %d: %v", number, err)
+		}
+		offset += int64(n)
+
+		if part.Last {
+			return offset, nil
+		}
+		buf, next = next, buf
+		n = nextN
+		number++
+	}
+}
+
+// RoundPartSize rounds up the part size to a multiple of the given size.
+func RoundPartSize(partSize, multiple int64) int64 {
+	if partSize <= 0 {
+		partSize = DefaultPartSize
+	}
+	if rem := partSize % multiple; rem != 0 {
+		partSize += multiple - rem
+	}
+	return partSize
+}
+
+func readPart(r io.Reader, buf []byte) (int, error) {
+	n, err := io.ReadFull(r, buf)
+	if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, io.ErrUnexpectedEOF) {
+		return 0, fmt.Errorf("error reading object data: %v", err)
+	}
+	return n, nil
+}
+
+func newBackoff(maxRetries int) wait.Backoff {
+	if maxRetries <= 0 {
+		maxRetries = DefaultMaxPartRetries
+	}
+	return wait.Backoff{
+		Steps:    maxRetries + 1,
+		Duration: 1 * time.Second,
+		Factor:   1.5,
+		Jitter:   0.1,
+	}
+}
@@ -0,0 +1,132 @@
+package multipart
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/mariadb-operator/mariadb-operator/v26/pkg/interfaces"
+)
+
+func TestUpload(t *testing.T) {
+	tests := []struct {
+		name      string
+		size      int
+		partSize  int64
+		wantParts int
+	}{
+		{
+			name:      "empty",
+			size:      0,
+			partSize:  4,
+			wantParts: 1,
+		},
+		{
+			name:      "smaller than part",
+			size:      3,
+			partSize:  4,
+			wantParts: 1,
+		},
+		{
+			name:      "multiple of part size",
+			size:      8,
+			partSize:  4,
+			wantParts: 2,
+		},
+		{
+			name:      "not multiple of part size",
+			size:      9,
+			partSize:  4,
+			wantParts: 3,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			content := bytes.Repeat([]byte("x"), tt.size)
+			var (
+				got   []byte
+				parts []Part
+			)
+			size, err := Upload(context.Background(), bytes.NewReader(content), interfaces.StreamOpts{PartSize: tt.partSize},
+				func(ctx context.Context, part Part) error {
+					if part.Offset != int64(len(got)) {
+						t.Errorf("unexpected offset, expected: %d got: %d", len(got), part.Offset)
+					}
+					got = append(got, part.Data...)
+					parts = append(parts, part)
+					return nil
+				})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if size != int64(tt.size) {
+				t.Errorf("unexpected size, expected: %d got: %d", tt.size, size)
+			}
+			if !bytes.Equal(got, content) {
+				t.Errorf("unexpected content, expected: %s got: %s", content, got)
+			}
+			if len(parts) != tt.wantParts {
+				t.Fatalf("unexpected number of parts, expected: %d got: %d", tt.wantParts, len(parts))
+			}
+			for i, part := range parts {
+				if part.Number != i+1 {
+					t.Errorf("unexpected part number, expected: %d got: %d", i+1, part.Number)
+				}
+				if part.Last != (i == len(parts)-1) {
+					t.Errorf("unexpected last flag in part %d: %v", part.Number, part.Last)
+				}
+			}
+		})
+	}
+}
+
+func TestUploadRetry(t *testing.T) {
+	content := []byte("0123456789")
+	attempts := make(map[int]int)
+	var got []byte
+
+	_, err := Upload(context.Background(), bytes.NewReader(content), interfaces.StreamOpts{PartSize: 4, MaxPartRetries: 1},
+		func(ctx context.Context, part Part) error {
+			attempts[part.Number]++
+			if part.Number == 2 && attempts[part.Number] == 1 {
+				return errors.New("transient error")
+			}
+			got = append(got, part.Data...)
+			return nil
+		})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !bytes.Equal(got, content) {
+		t.Errorf("unexpected content, expected: %s got: %s", content, got)
+	}
+	if attempts[1] != 1 || attempts[2] != 2 || attempts[3] != 1 {
+		t.Errorf("unexpected attempts: %v", attempts)
+	}
+
+	_, err = Upload(context.Background(), bytes.NewReader(content), interfaces.StreamOpts{PartSize: 4, MaxPartRetries: 1},
+		func(ctx context.Context, part Part) error {
+			return errors.New("permanent error")
+		})
+	if err == nil {
+		t.Fatal("expected error, got nil")
+	}
+}
+
+func TestRoundPartSize(t *testing.T) {
+	tests := []struct {
+		partSize int64
+		multiple int64
+		want     int64
+	}{
+		{partSize: 0, multiple: 256, want: DefaultPartSize},
+		{partSize: 256, multiple: 256, want: 256},
+		{partSize: 257, multiple: 256, want: 512},
+	}
+	for _, tt := range tests {
+		if got := RoundPartSize(tt.partSize, tt.multiple); got != tt.want {
+			t.Errorf("unexpected part size for %d, expected: %d got: %d", tt.partSize, tt.want, got)
+		}
+	}
+}
@@ -0,0 +1,285 @@
+package progress
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	mariadbv1alpha1 "github.com/mariadb-operator/mariadb-operator/v26/api/v1alpha1"
+	jobpkg "github.com/mariadb-operator/mariadb-operator/v26/pkg/job"
+	batchv1 "k8s.io/api/batch/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+// PublishFn publishes the progress, for instance, in the status of the backup object.
+type PublishFn func(ctx context.Context, progress *mariadbv1alpha1.BackupProgress) error
+
+// Tracker tracks the progress of a backup or a restoration by counting the bytes transferred by the readers and
+// HTTP transports that it wraps. Bytes are only accounted while a phase is in progress.
+// A nil Tracker does not track anything.
+type Tracker struct {
+	publish PublishFn
+	logger  logr.Logger
+	now     func() time.Time
+
+	mu        sync.Mutex
+	phase     mariadbv1alpha1.BackupProgressPhase
+	running   bool
+	startTime time.Time
+	processed int64
+	total     *int64
+
+	lastProcessed  int64
+	lastUpdateTime time.Time
+}
+
+// TrackerOpt is an option to modify the Tracker behavior.
+type TrackerOpt func(*Tracker)
+
+// WithClock configures the function used to get the current time.
+func WithClock(now func() time.Time) TrackerOpt {
+	return func(t *Tracker) {
+		t.now = now
+	}
+}
+
+// NewTracker returns a Tracker that publishes the progress using the given function.
+func NewTracker(publish PublishFn, logger logr.Logger, opts ...TrackerOpt) *Tracker {
+	tracker := &Tracker{
+		publish: publish,
+		logger:  logger,
+		now:     time.Now,
+	}
+	for _, setOpt := range opts {
+		setOpt(tracker)
+	}
+	return tracker
+}
+
+// StartPhase starts a new phase and publishes it. totalBytes is the number of bytes to be processed, or 0 if unknown.
+func (t *Tracker) StartPhase(ctx context.Context, phase mariadbv1alpha1.BackupProgressPhase, totalBytes int64) {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	now := t.now()
+	t.phase = phase
+	t.running = true
+	t.startTime = now
+	t.processed = 0
+	t.total = nil
+	if totalBytes > 0 {
+		t.total = ptr.To(totalBytes)
+	}
+	t.lastProcessed = 0
+	t.lastUpdateTime = now
+	t.mu.Unlock()
+
+	t.logger.Info("starting phase", "phase", phase, "total-bytes", totalBytes)
+	t.Publish(ctx)
+}
+
+// EndPhase publishes the final progress of the current phase and stops accounting bytes.
+func (t *Tracker) EndPhase(ctx context.Context) {
+	if t == nil {
+		return
+	}
+	t.Publish(ctx)
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.running = false
+}
+
+// Add accounts the given number of bytes in the current phase.
+func (t *Tracker) Add(n int64) {
+	if t == nil || n <= 0 {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.running {
+		t.processed += n
+	}
+}
+
+// Progress returns the progress of the current phase, or nil if no phase has been started.
+// The throughput is computed since the previous call.
+func (t *Tracker) Progress() *mariadbv1alpha1.BackupProgress {
+	if t == nil {
+		return nil
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.phase == "" {
+		return nil
+	}
+	now := t.now()
+
+	var bytesPerSecond int64
+	if elapsed := now.Sub(t.lastUpdateTime); elapsed > 0 {
+		bytesPerSecond = int64(float64(t.processed-t.lastProcessed) / elapsed.Seconds())
+	}
+	t.lastProcessed = t.processed
+	t.lastUpdateTime = now
+
+	progress := &mariadbv1alpha1.BackupProgress{
+		Phase:          t.phase,
+		BytesProcessed: t.processed,
+		TotalBytes:     t.total,
+		BytesPerSecond: bytesPerSecond,
+		StartTime:      metav1.NewTime(t.startTime),
+		LastUpdateTime: metav1.NewTime(now),
+	}
+	if t.total != nil && bytesPerSecond > 0 && *t.total > t.processed {
+		remaining := time.Duration(float64(*t.total-t.processed) / float64(bytesPerSecond) * float64(time.Second))
+		progress.EstimatedCompletionTime = ptr.To(metav1.NewTime(now.Add(remaining)))
+	}
+	return progress
+}
+
+// Publish publishes the progress of the current phase. Errors are logged, as progress reporting is best effort.
+func (t *Tracker) Publish(ctx context.Context) {
+	if t == nil {
+		return
+	}
+	progress := t.Progress()
+	if progress == nil {
+		return
+	}
+	if err := t.publish(ctx, progress); err != nil {
+		t.logger.Error(err, "error publishing progress", "phase", progress.Phase)
+		return
+	}
+	t.logger.V(1).Info("published progress", "phase", progress.Phase, "bytes", progress.BytesProcessed,
+		"bytes-per-second", progress.BytesPerSecond)
+}
+
+// Run periodically publishes the progress of the running phases until the context is done.
+func (t *Tracker) Run(ctx context.Context, interval time.Duration) {
+	if t == nil || interval <= 0 {
+		return
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if t.isRunning() {
+				t.Publish(ctx)
+			}
+		}
+	}
+}
+
+func (t *Tracker) isRunning() bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.running
+}
+
+// Reader wraps the given reader, accounting the bytes read.
+func (t *Tracker) Reader(r io.Reader) io.Reader {
+	if t == nil {
+		return r
+	}
+	return &reader{
+		reader:  r,
+		tracker: t,
+	}
+}
+
+// ReadCloser wraps the given ReadCloser, accounting the bytes read.
+func (t *Tracker) ReadCloser(rc io.ReadCloser) io.ReadCloser {
+	if t == nil {
+		return rc
+	}
+	return &readCloser{
+		Reader: t.Reader(rc),
+		Closer: rc,
+	}
+}
+
+// Transport wraps the given RoundTripper, accounting the bytes of both the request and the response bodies.
+func (t *Tracker) Transport(rt http.RoundTripper) http.RoundTripper {
+	if t == nil {
+		return rt
+	}
+	return &transport{
+		base:    rt,
+		tracker: t,
+	}
+}
+
+type reader struct {
+	reader  io.Reader
+	tracker *Tracker
+}
+
+func (r *reader) Read(p []byte) (int, error) {
+	n, err := r.reader.Read(p)
+	r.tracker.Add(int64(n))
+	return n, err
+}
+
+type readCloser struct {
+	io.Reader
+	io.Closer
+}
+
+type transport struct {
+	base    http.RoundTripper
+	tracker *Tracker
+}
+
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Body != nil && req.Body != http.NoBody {
+		// RoundTrippers must not modify the original request.
+		req = req.Clone(req.Context())
+		req.Body = t.tracker.ReadCloser(req.Body)
+	}
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.Body != nil && resp.Body != http.NoBody {
+		resp.Body = t.tracker.ReadCloser(resp.Body)
+	}
+	return resp, nil
+}
+
+// RunningJob returns the first running Job, or nil if none of them are running.
+func RunningJob(jobs []batchv1.Job) *batchv1.Job {
+	for i := range jobs {
+		job := &jobs[i]
+		if job.Status.Active > 0 && job.Status.StartTime != nil && jobpkg.IsJobRunning(job) {
+			return job
+		}
+	}
+	return nil
+}
+
+// ForJob returns the progress to be reported for a running Job, and whether it differs from the given progress.
+// The given progress is kept unless it was last updated before the Job started, in which case it belongs to a previous Job
+// and the initial phase is returned instead. The subsequent phases are reported by the Job itself.
+func ForJob(job *batchv1.Job, progress *mariadbv1alpha1.BackupProgress, initialPhase mariadbv1alpha1.BackupProgressPhase,
+	now time.Time) (*mariadbv1alpha1.BackupProgress, bool) {
+	if job.Status.StartTime == nil {
+		return progress, false
+	}
+	if progress != nil && !progress.LastUpdateTime.Before(job.Status.StartTime) {
+		return progress, false
+	}
+	return &mariadbv1alpha1.BackupProgress{
+		Phase:          initialPhase,
+		StartTime:      *job.Status.StartTime,
+		LastUpdateTime: metav1.NewTime(now),
+	}, true
+}
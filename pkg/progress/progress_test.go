@@ -0,0 +1,303 @@
+package progress
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	mariadbv1alpha1 "github.com/mariadb-operator/mariadb-operator/v26/api/v1alpha1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	return c.now
+}
+
+func (c *fakeClock) Advance(d time.Duration) {
+	c.now = c.now.Add(d)
+}
+
+func TestNilTracker(t *testing.T) {
+	var tracker *Tracker
+	ctx := context.Background()
+
+	tracker.StartPhase(ctx, mariadbv1alpha1.BackupProgressPhaseUpload, 10)
+	tracker.Add(10)
+	tracker.EndPhase(ctx)
+	tracker.Publish(ctx)
+	tracker.Run(ctx, time.Second)
+
+	if progress := tracker.Progress(); progress != nil {
+		t.Errorf("expected nil progress, got: %v", progress)
+	}
+	r := strings.NewReader("test")
+	if got := tracker.Reader(r); got != r {
+		t.Error("expected Reader to be returned as is")
+	}
+	if got := tracker.Transport(http.DefaultTransport); got != http.DefaultTransport {
+		t.Error("expected Transport to be returned as is")
+	}
+}
+
+func TestTracker(t *testing.T) {
+	ctx := context.Background()
+	clock := &fakeClock{now: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)}
+	var published []*mariadbv1alpha1.BackupProgress
+	tracker := NewTracker(
+		func(ctx context.Context, progress *mariadbv1alpha1.BackupProgress) error {
+			published = append(published, progress)
+			return nil
+		},
+		logr.Discard(),
+		WithClock(clock.Now),
+	)
+
+	if progress := tracker.Progress(); progress != nil {
+		t.Fatalf("expected nil progress before starting a phase, got: %v", progress)
+	}
+
+	tracker.Add(100)
+	tracker.StartPhase(ctx, mariadbv1alpha1.BackupProgressPhaseUpload, 1000)
+	if len(published) != 1 {
+		t.Fatalf("expected phase start to be published, got %d publications", len(published))
+	}
+	if published[0].Phase != mariadbv1alpha1.BackupProgressPhaseUpload {
+		t.Errorf("unexpected phase, expected: %s got: %s", mariadbv1alpha1.BackupProgressPhaseUpload, published[0].Phase)
+	}
+	if published[0].BytesProcessed != 0 {
+		t.Errorf("expected bytes added before the phase to be ignored, got: %d", published[0].BytesProcessed)
+	}
+
+	clock.Advance(10 * time.Second)
+	if _, err := io.Copy(io.Discard, tracker.Reader(bytes.NewReader(make([]byte, 200)))); err != nil {
+		t.Fatalf("unexpected error reading: %v", err)
+	}
+
+	progress := tracker.Progress()
+	if progress.BytesProcessed != 200 {
+		t.Errorf("unexpected bytes processed, expected: %d got: %d", 200, progress.BytesProcessed)
+	}
+	if progress.TotalBytes == nil || *progress.TotalBytes != 1000 {
+		t.Errorf("unexpected total bytes, expected: %d got: %v", 1000, progress.TotalBytes)
+	}
+	if progress.BytesPerSecond != 20 {
+		t.Errorf("unexpected bytes per second, expected: %d got: %d", 20, progress.BytesPerSecond)
+	}
+	if progress.EstimatedCompletionTime == nil {
+		t.Fatal("expected estimated completion time to be set")
+	}
+	if expected := clock.now.Add(40 * time.Second); !progress.EstimatedCompletionTime.Time.Equal(expected) {
+		t.Errorf("unexpected estimated completion time, expected: %v got: %v", expected, progress.EstimatedCompletionTime.Time)
+	}
+
+	tracker.EndPhase(ctx)
+	tracker.Add(100)
+	clock.Advance(time.Second)
+	if progress := tracker.Progress(); progress.BytesProcessed != 200 {
+		t.Errorf("expected bytes added after the phase to be ignored, got: %d", progress.BytesProcessed)
+	}
+
+	tracker.StartPhase(ctx, mariadbv1alpha1.BackupProgressPhaseDownload, 0)
+	progress = tracker.Progress()
+	if progress.Phase != mariadbv1alpha1.BackupProgressPhaseDownload {
+		t.Errorf("unexpected phase, expected: %s got: %s", mariadbv1alpha1.BackupProgressPhaseDownload, progress.Phase)
+	}
+	if progress.BytesProcessed != 0 || progress.TotalBytes != nil || progress.EstimatedCompletionTime != nil {
+		t.Errorf("expected progress to be reset, got: %v", progress)
+	}
+}
+
+func TestTransport(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, err := io.Copy(io.Discard, r.Body); err != nil {
+			t.Errorf("unexpected error reading request: %v", err)
+		}
+		if _, err := w.Write(make([]byte, 50)); err != nil {
+			t.Errorf("unexpected error writing response: %v", err)
+		}
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	tracker := NewTracker(
+		func(ctx context.Context, progress *mariadbv1alpha1.BackupProgress) error {
+			return nil
+		},
+		logr.Discard(),
+	)
+	tracker.StartPhase(ctx, mariadbv1alpha1.BackupProgressPhaseUpload, 0)
+
+	httpClient := &http.Client{
+		Transport: tracker.Transport(http.DefaultTransport),
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, server.URL, bytes.NewReader(make([]byte, 100)))
+	if err != nil {
+		t.Fatalf("unexpected error creating request: %v", err)
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		t.Fatalf("unexpected error doing request: %v", err)
+	}
+	if _, err := io.Copy(io.Discard, resp.Body); err != nil {
+		t.Fatalf("unexpected error reading response: %v", err)
+	}
+	if err := resp.Body.Close(); err != nil {
+		t.Fatalf("unexpected error closing response: %v", err)
+	}
+
+	if progress := tracker.Progress(); progress.BytesProcessed != 150 {
+		t.Errorf("unexpected bytes processed, expected: %d got: %d", 150, progress.BytesProcessed)
+	}
+}
+
+func TestRunningJob(t *testing.T) {
+	startTime := metav1.NewTime(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+	completed := batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{Name: "completed"},
+		Status: batchv1.JobStatus{
+			StartTime: &startTime,
+			Conditions: []batchv1.JobCondition{
+				{
+					Type:   batchv1.JobComplete,
+					Status: corev1.ConditionTrue,
+				},
+			},
+		},
+	}
+	pending := batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{Name: "pending"},
+	}
+	running := batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{Name: "running"},
+		Status: batchv1.JobStatus{
+			Active:    1,
+			StartTime: &startTime,
+		},
+	}
+
+	tests := []struct {
+		name     string
+		jobs     []batchv1.Job
+		wantName string
+	}{
+		{
+			name: "no Jobs",
+		},
+		{
+			name: "no running Jobs",
+			jobs: []batchv1.Job{completed, pending},
+		},
+		{
+			name:     "running Job",
+			jobs:     []batchv1.Job{completed, pending, running},
+			wantName: "running",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			job := RunningJob(tt.jobs)
+			if tt.wantName == "" {
+				if job != nil {
+					t.Errorf("expected no running Job, got: %s", job.Name)
+				}
+				return
+			}
+			if job == nil || job.Name != tt.wantName {
+				t.Errorf("unexpected running Job, expected: %s got: %v", tt.wantName, job)
+			}
+		})
+	}
+}
+
+func TestForJob(t *testing.T) {
+	startTime := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	now := startTime.Add(time.Minute)
+	job := &batchv1.Job{
+		Status: batchv1.JobStatus{
+			Active:    1,
+			StartTime: &metav1.Time{Time: startTime},
+		},
+	}
+	current := &mariadbv1alpha1.BackupProgress{
+		Phase:          mariadbv1alpha1.BackupProgressPhaseUpload,
+		BytesProcessed: 100,
+		StartTime:      metav1.NewTime(startTime.Add(10 * time.Second)),
+		LastUpdateTime: metav1.NewTime(startTime.Add(20 * time.Second)),
+	}
+	previous := &mariadbv1alpha1.BackupProgress{
+		Phase:          mariadbv1alpha1.BackupProgressPhaseUpload,
+		BytesProcessed: 100,
+		StartTime:      metav1.NewTime(startTime.Add(-time.Hour)),
+		LastUpdateTime: metav1.NewTime(startTime.Add(-time.Minute)),
+	}
+
+	tests := []struct {
+		name        string
+		job         *batchv1.Job
+		progress    *mariadbv1alpha1.BackupProgress
+		wantPhase   mariadbv1alpha1.BackupProgressPhase
+		wantChanged bool
+	}{
+		{
+			name:        "no progress",
+			job:         job,
+			wantPhase:   mariadbv1alpha1.BackupProgressPhaseDump,
+			wantChanged: true,
+		},
+		{
+			name:        "progress from previous Job",
+			job:         job,
+			progress:    previous,
+			wantPhase:   mariadbv1alpha1.BackupProgressPhaseDump,
+			wantChanged: true,
+		},
+		{
+			name:      "progress from current Job",
+			job:       job,
+			progress:  current,
+			wantPhase: mariadbv1alpha1.BackupProgressPhaseUpload,
+		},
+		{
+			name:     "Job not started",
+			job:      &batchv1.Job{},
+			progress: previous,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			progress, changed := ForJob(tt.job, tt.progress, mariadbv1alpha1.BackupProgressPhaseDump, now)
+			if changed != tt.wantChanged {
+				t.Errorf("unexpected changed, expected: %v got: %v", tt.wantChanged, changed)
+			}
+			if !changed {
+				if progress != tt.progress {
+					t.Errorf("expected progress to be kept, got: %v", progress)
+				}
+				return
+			}
+			if progress.Phase != tt.wantPhase {
+				t.Errorf("unexpected phase, expected: %s got: %s", tt.wantPhase, progress.Phase)
+			}
+			if !progress.StartTime.Time.Equal(startTime) {
+				t.Errorf("unexpected start time, expected: %v got: %v", startTime, progress.StartTime.Time)
+			}
+			if !progress.LastUpdateTime.Time.Equal(now) {
+				t.Errorf("unexpected last update time, expected: %v got: %v", now, progress.LastUpdateTime.Time)
+			}
+		})
+	}
+}
@@ -0,0 +1,122 @@
+package ratelimit
+
+import (
+	"context"
+	"io"
+	"math"
+	"net/http"
+
+	"golang.org/x/time/rate"
+)
+
+// maxChunkSize is the maximum number of bytes accounted at once, which keeps the throughput smooth.
+const maxChunkSize = 32 * 1024
+
+// Limiter limits the number of bytes per second transferred by the readers and HTTP transports that it wraps.
+// The limit is shared among all of them, so wrapping multiple clients with the same Limiter limits their aggregated throughput.
+// A nil Limiter does not limit anything.
+type Limiter struct {
+	limiter   *rate.Limiter
+	chunkSize int
+}
+
+// NewLimiter returns a Limiter that allows the given number of bytes per second.
+// It returns nil when bytesPerSecond is not positive, meaning that the throughput is not limited.
+func NewLimiter(bytesPerSecond int64) *Limiter {
+	if bytesPerSecond <= 0 {
+		return nil
+	}
+	burst := int(min(bytesPerSecond, math.MaxInt32))
+	return &Limiter{
+		limiter:   rate.NewLimiter(rate.Limit(bytesPerSecond), burst),
+		chunkSize: min(burst, maxChunkSize),
+	}
+}
+
+// BytesPerSecond returns the configured limit, or 0 when the throughput is not limited.
+func (l *Limiter) BytesPerSecond() int64 {
+	if l == nil {
+		return 0
+	}
+	return int64(l.limiter.Limit())
+}
+
+// Reader wraps the given reader, blocking the reads that exceed the limit until the context is done.
+func (l *Limiter) Reader(ctx context.Context, r io.Reader) io.Reader {
+	if l == nil {
+		return r
+	}
+	return &reader{
+		ctx:     ctx,
+		reader:  r,
+		limiter: l,
+	}
+}
+
+// ReadCloser wraps the given ReadCloser, blocking the reads that exceed the limit until the context is done.
+func (l *Limiter) ReadCloser(ctx context.Context, rc io.ReadCloser) io.ReadCloser {
+	if l == nil {
+		return rc
+	}
+	return &readCloser{
+		Reader: l.Reader(ctx, rc),
+		Closer: rc,
+	}
+}
+
+// Transport wraps the given RoundTripper, limiting both the request and the response bodies.
+func (l *Limiter) Transport(rt http.RoundTripper) http.RoundTripper {
+	if l == nil {
+		return rt
+	}
+	return &transport{
+		base:    rt,
+		limiter: l,
+	}
+}
+
+type reader struct {
+	ctx     context.Context
+	reader  io.Reader
+	limiter *Limiter
+}
+
+func (r *reader) Read(p []byte) (int, error) {
+	if len(p) > r.limiter.chunkSize {
+		p = p[:r.limiter.chunkSize]
+	}
+	n, err := r.reader.Read(p)
+	if n > 0 {
+		if waitErr := r.limiter.limiter.WaitN(r.ctx, n); waitErr != nil {
+			return n, waitErr
+		}
+	}
+	return n, err
+}
+
+type readCloser struct {
+	io.Reader
+	io.Closer
+}
+
+type transport struct {
+	base    http.RoundTripper
+	limiter *Limiter
+}
+
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	if req.Body != nil && req.Body != http.NoBody {
+		// RoundTrippers must not modify the original request.
+		req = req.Clone(ctx)
+		req.Body = t.limiter.ReadCloser(ctx, req.Body)
+	}
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.Body != nil && resp.Body != http.NoBody {
+		resp.Body = t.limiter.ReadCloser(ctx, resp.Body)
+	}
+	return resp, nil
+}
@@ -0,0 +1,90 @@
+package ratelimit
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestNilLimiter(t *testing.T) {
+	limiter := NewLimiter(0)
+	if limiter != nil {
+		t.Fatalf("expected nil limiter, got: %v", limiter)
+	}
+	if limiter.BytesPerSecond() != 0 {
+		t.Errorf("unexpected bytes per second, expected: 0 got: %d", limiter.BytesPerSecond())
+	}
+	r := bytes.NewReader([]byte("test"))
+	if got := limiter.Reader(context.Background(), r); got != r {
+		t.Error("expected reader not to be wrapped")
+	}
+	if got := limiter.Transport(http.DefaultTransport); got != http.DefaultTransport {
+		t.Error("expected transport not to be wrapped")
+	}
+}
+
+func TestReader(t *testing.T) {
+	bytesPerSecond := int64(1024 * 1024)
+	limiter := NewLimiter(bytesPerSecond)
+	content := bytes.Repeat([]byte("x"), int(bytesPerSecond+bytesPerSecond/2))
+
+	start := time.Now()
+	got, err := io.ReadAll(limiter.Reader(context.Background(), bytes.NewReader(content)))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	elapsed := time.Since(start)
+
+	if !bytes.Equal(got, content) {
+		t.Error("unexpected content")
+	}
+	// The first second worth of bytes is allowed immediately, the rest is limited.
+	if elapsed < 400*time.Millisecond {
+		t.Errorf("expected reads to be limited, took: %v", elapsed)
+	}
+}
+
+func TestReaderContextCancelled(t *testing.T) {
+	limiter := NewLimiter(1024)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := io.ReadAll(limiter.Reader(ctx, bytes.NewReader(bytes.Repeat([]byte("x"), 4096))))
+	if err == nil {
+		t.Fatal("expected error, got nil")
+	}
+}
+
+func TestTransport(t *testing.T) {
+	content := bytes.Repeat([]byte("x"), 4096)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		_, _ = w.Write(body)
+	}))
+	defer server.Close()
+
+	client := &http.Client{
+		Transport: NewLimiter(1024 * 1024).Transport(http.DefaultTransport),
+	}
+	resp, err := client.Post(server.URL, "application/octet-stream", bytes.NewReader(content))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer resp.Body.Close()
+
+	got, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !bytes.Equal(got, content) {
+		t.Error("unexpected content")
+	}
+}
@@ -814,6 +814,18 @@ func (c *Client) GtidDomainId(ctx context.Context) (*uint32, error) {
 	return ptr.To(uint32(gtidDomainId)), nil
 }
 
+func (c *Client) ServerId(ctx context.Context) (*uint32, error) {
+	rawServerId, err := c.SystemVariable(ctx, "server_id")
+	if err != nil {
+		return nil, err
+	}
+	serverId, err := strconv.ParseUint(rawServerId, 10, 32)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing server_id: %v", err)
+	}
+	return ptr.To(uint32(serverId)), nil
+}
+
 func (c *Client) GtidStrictMode(ctx context.Context) (bool, error) {
 	rawGtidStrictMode, err := c.SystemVariable(ctx, "gtid_strict_mode")
 	if err != nil {